@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+// ActorHeader carries the identity the caller claims to be. Nothing verifies
+// it, so actors taken from it are recorded with UnverifiedActorPrefix.
+const ActorHeader = "X-Actor"
+
+// UnverifiedActorPrefix marks actors that were not authenticated, so audit
+// readers can tell them apart from verified identities
+const UnverifiedActorPrefix = "unverified:"
+
+type actorContextKey struct{}
+
+// ActorMiddleware stores the claimed caller identity in the request context
+// so handlers can attribute changes to it. The header can be set by any
+// client, so the actor is marked as unverified.
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithActor(r.Context(), UnverifiedActorPrefix+actor)))
+	})
+}
+
+// WithActor returns a copy of ctx carrying actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the caller identity, or an empty string when unknown
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
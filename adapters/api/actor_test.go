@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActorMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{
+			name:     "header actor is marked unverified",
+			header:   "admin@example.com",
+			expected: "unverified:admin@example.com",
+		},
+		{
+			name:     "missing header leaves the actor empty",
+			header:   "",
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var actor string
+			handler := ActorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor = ActorFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.header != "" {
+				req.Header.Set(ActorHeader, test.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if actor != test.expected {
+				t.Errorf("expected actor %q, got %q", test.expected, actor)
+			}
+		})
+	}
+}
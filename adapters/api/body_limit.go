@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrorCodeRequestTooLarge is returned when a request body exceeds the
+// server's body size limit
+const ErrorCodeRequestTooLarge ErrorCode = "request.body_too_large"
+
+// BodyLimitMiddleware answers 413 when a request body is larger than maxBytes.
+// Bodies declaring a larger Content-Length are rejected before the handler
+// runs. Other bodies are cut off at the limit, and the error the handler then
+// answers with is replaced by the 413. A non-positive maxBytes disables the
+// middleware.
+func BodyLimitMiddleware(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if maxBytes <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				writeRequestTooLarge(w, maxBytes)
+				return
+			}
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body := &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, maxBytes)}
+			r.Body = body
+			next.ServeHTTP(&bodyLimitResponseWriter{ResponseWriter: w, body: body, maxBytes: maxBytes}, r)
+		})
+	}
+}
+
+func writeRequestTooLarge(w http.ResponseWriter, maxBytes int64) {
+	NewResponseWriter(w).WriteCodedErrorMessage(
+		ErrorCodeRequestTooLarge,
+		fmt.Sprintf("request body exceeds %d bytes", maxBytes),
+		http.StatusRequestEntityTooLarge,
+	)
+}
+
+// limitedBody records whether reading the body ran into the limit
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// bodyLimitResponseWriter swaps the handler's error response for a 413 once
+// the body ran into the limit
+type bodyLimitResponseWriter struct {
+	http.ResponseWriter
+	body        *limitedBody
+	maxBytes    int64
+	wroteHeader bool
+	replaced    bool
+}
+
+func (w *bodyLimitResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if w.body.exceeded && statusCode >= http.StatusBadRequest {
+		w.replaced = true
+		writeRequestTooLarge(w.ResponseWriter, w.maxBytes)
+		return
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *bodyLimitResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.replaced {
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
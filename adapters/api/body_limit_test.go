@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	decoding := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := NewResponseWriter(w)
+		var body map[string]string
+		if err := DecodeJSON(r, &body); err != nil {
+			rw.WriteBadRequest(err.Error())
+			return
+		}
+		rw.WriteJSON(body, http.StatusCreated)
+	})
+
+	oversized := `{"name": "` + strings.Repeat("a", 100) + `"}`
+	tests := []struct {
+		name     string
+		body     string
+		chunked  bool
+		maxBytes int64
+		expected int
+	}{
+		{
+			name:     "body within the limit",
+			body:     `{"name": "small"}`,
+			maxBytes: 64,
+			expected: http.StatusCreated,
+		},
+		{
+			name:     "oversized body",
+			body:     oversized,
+			maxBytes: 64,
+			expected: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "oversized body without a content length",
+			body:     oversized,
+			chunked:  true,
+			maxBytes: 64,
+			expected: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "zero limit disables the middleware",
+			body:     oversized,
+			maxBytes: 0,
+			expected: http.StatusCreated,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := BodyLimitMiddleware(test.maxBytes)(decoding)
+
+			var body io.Reader = strings.NewReader(test.body)
+			if test.chunked {
+				// Hide the length so the limit is only hit while reading
+				body = io.MultiReader(body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			if test.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != test.expected {
+				t.Fatalf("expected status %d, got %d. Body: %s", test.expected, rec.Code, rec.Body.String())
+			}
+			if test.expected != http.StatusRequestEntityTooLarge {
+				return
+			}
+
+			var response codedErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if response.Error.Code != ErrorCodeRequestTooLarge {
+				t.Errorf("expected code %q, got %q", ErrorCodeRequestTooLarge, response.Error.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package booking_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/adapters/db/session_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/adapters/events/booking_events"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestCancelTherapistFutureBookings(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "booking_cancel_future_test_*.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	dbFilename := tmpfile.Name()
+
+	database := db.NewDatabase(db.DatabaseConfig{
+		DBFilename: dbFilename,
+		SchemaFile: "../../../schema.sql",
+	})
+	defer func() {
+		database.Close()
+		os.Remove(dbFilename)
+	}()
+
+	// Seed a therapist with two future bookings and one past booking
+	now := time.Now().UTC()
+	therapistID := domain.NewTherapistID()
+	clientID := domain.NewClientID()
+	timeSlotID := domain.NewTimeSlotID()
+	_, err = database.Exec(`
+		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, therapistID, "Dr. Leaving", "leaving@example.com", "+1555000500", "+1555000500", true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert therapist: %v", err)
+	}
+	_, err = database.Exec(`
+		INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, clientID, "Affected Client", "+201001234567", 0, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert client: %v", err)
+	}
+	_, err = database.Exec(`
+		INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, timeSlotID, therapistID, "Monday", "10:00", 120, 0, 0, true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert time slot: %v", err)
+	}
+
+	bookingRepo := booking_db.NewBookingRepository(database)
+	createBooking := func(state booking.BookingState, startTime time.Time) domain.BookingID {
+		id := domain.NewBookingID()
+		err := bookingRepo.Create(&booking.Booking{
+			ID:          id,
+			TimeSlotID:  timeSlotID,
+			TherapistID: therapistID,
+			ClientID:    clientID,
+			State:       state,
+			StartTime:   domain.UTCTimestamp(startTime),
+			Duration:    60,
+			CreatedAt:   domain.NewUTCTimestamp(),
+			UpdatedAt:   domain.NewUTCTimestamp(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+		return id
+	}
+	futurePending := createBooking(booking.BookingStatePending, now.AddDate(0, 0, 3))
+	futureConfirmed := createBooking(booking.BookingStateConfirmed, now.AddDate(0, 0, 10))
+	pastConfirmed := createBooking(booking.BookingStateConfirmed, now.AddDate(0, 0, -3))
+
+	// The confirmed booking already has a planned session
+	sessionID := domain.NewSessionID()
+	_, err = database.Exec(`
+		INSERT INTO sessions (id, regular_booking_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, paid_amount, language, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, sessionID, futureConfirmed, therapistID, clientID, now.AddDate(0, 0, 10), 60, 0, 100, "english", "planned", now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert session: %v", err)
+	}
+
+	bookingEvents := booking_events.NewBroker()
+	events, unsubscribe := bookingEvents.Subscribe(therapistID)
+	defer unsubscribe()
+
+	cancelUsecase := cancel_future_bookings.NewUsecase(
+		bookingRepo,
+		adhoc_booking_db.NewAdhocBookingRepository(database),
+		session_db.NewSessionRepository(database),
+		therapist_db.NewTherapistRepository(database),
+		client_db.NewClientRepository(database),
+		db.NewSQLTransactionRepo(database),
+		bookingEvents,
+	)
+	handler := NewBookingHandler(
+		create_booking.Usecase{},
+		create_adhoc_booking.Usecase{},
+		confirm_regular_booking.Usecase{},
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		*cancelUsecase,
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body := []byte(`{"reason": "Therapist is no longer available"}`)
+	req := httptest.NewRequest("POST", "/api/v1/admin/therapists/"+string(therapistID)+"/cancel-future-bookings", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var output cancel_future_bookings.Output
+	if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if output.Count != 2 || len(output.CancelledBookings) != 2 {
+		t.Fatalf("Expected 2 cancelled bookings, got %+v", output)
+	}
+	for _, cancelled := range output.CancelledBookings {
+		if cancelled.RegularBookingID != futurePending && cancelled.RegularBookingID != futureConfirmed {
+			t.Errorf("Unexpected cancelled booking %s", cancelled.RegularBookingID)
+		}
+		if !strings.HasPrefix(cancelled.WhatsAppLink, "https://wa.me/201001234567?text=") {
+			t.Errorf("Expected a WhatsApp link to the client, got %q", cancelled.WhatsAppLink)
+		}
+	}
+
+	expectedStates := map[domain.BookingID]booking.BookingState{
+		futurePending:   booking.BookingStateCancelled,
+		futureConfirmed: booking.BookingStateCancelled,
+		pastConfirmed:   booking.BookingStateConfirmed,
+	}
+	for id, expected := range expectedStates {
+		stored, err := bookingRepo.GetByID(id)
+		if err != nil {
+			t.Fatalf("Failed to get booking %s: %v", id, err)
+		}
+		if stored.State != expected {
+			t.Errorf("Expected booking %s to be %s, got %s", id, expected, stored.State)
+		}
+	}
+
+	for range 2 {
+		select {
+		case event := <-events:
+			if event.Type != booking.EventTypeCancelled {
+				t.Errorf("Expected a cancellation event, got %s", event.Type)
+			}
+		default:
+			t.Fatal("Expected a cancellation event for every cancelled booking")
+		}
+	}
+
+	var sessionState string
+	if err := database.QueryRow(`SELECT state FROM sessions WHERE id = ?`, sessionID).Scan(&sessionState); err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	if sessionState != "cancelled" {
+		t.Errorf("Expected the linked session to be cancelled, got %s", sessionState)
+	}
+
+	var auditRows int
+	err = database.QueryRow(`
+		SELECT COUNT(*) FROM booking_audit
+		WHERE to_state = 'cancelled' AND booking_id IN (?, ?)
+	`, futurePending, futureConfirmed).Scan(&auditRows)
+	if err != nil {
+		t.Fatalf("Failed to count audit rows: %v", err)
+	}
+	if auditRows != 2 {
+		t.Errorf("Expected 2 cancellation audit rows, got %d", auditRows)
+	}
+
+	t.Run("unknown therapist", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/admin/therapists/"+string(domain.NewTherapistID())+"/cancel-future-bookings", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+		}
+	})
+}
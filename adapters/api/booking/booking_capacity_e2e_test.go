@@ -0,0 +1,207 @@
+package booking_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/adapters/db/notification_db"
+	"github.com/mishkahtherapy/brain/adapters/db/session_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+	"github.com/mishkahtherapy/brain/core/usecases/notification/notify_therapist_new_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestGroupSlotAcceptsBookingsUpToCapacity(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "booking_capacity_test_*.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	dbFilename := tmpfile.Name()
+
+	database := db.NewDatabase(db.DatabaseConfig{
+		DBFilename: dbFilename,
+		SchemaFile: "../../../schema.sql",
+	})
+	defer func() {
+		database.Close()
+		os.Remove(dbFilename)
+	}()
+
+	// Seed a therapist with a one-hour Monday group slot for two clients
+	now := time.Now().UTC()
+	therapistID := domain.NewTherapistID()
+	timeSlotID := domain.NewTimeSlotID()
+	_, err = database.Exec(`
+		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, therapistID, "Dr. Group", "group@example.com", "+1555000800", "+1555000800", true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert therapist: %v", err)
+	}
+	_, err = database.Exec(`
+		INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, capacity, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, timeSlotID, therapistID, "Monday", "09:00", 60, 0, 0, true, 2, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert time slot: %v", err)
+	}
+	clientIDs := make([]domain.ClientID, 3)
+	for i := range clientIDs {
+		clientIDs[i] = domain.NewClientID()
+		_, err = database.Exec(`
+			INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, clientIDs[i], fmt.Sprintf("Group Client %d", i+1), fmt.Sprintf("+20100123480%d", i), 0, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert client: %v", err)
+		}
+	}
+
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+	bookingRepo := booking_db.NewBookingRepository(database)
+	adhocBookingRepo := adhoc_booking_db.NewAdhocBookingRepository(database)
+	clientRepo := client_db.NewClientRepository(database)
+	timeSlotRepo := timeslot_db.NewTimeSlotRepository(database)
+	notificationPort := &noopNotificationPort{}
+	notificationRepo := notification_db.NewNotificationRepository(database)
+	getScheduleUsecase := get_schedule.NewUsecase(
+		therapistRepo,
+		timeSlotRepo,
+		bookingRepo,
+		adhocBookingRepo,
+		nil,
+		15,
+		nil,
+		0,
+	)
+	checkAvailabilityUsecase := check_availability.NewUsecase(bookingRepo, adhocBookingRepo, timeSlotRepo, *getScheduleUsecase)
+	confirmUsecase := confirm_regular_booking.NewUsecase(
+		bookingRepo,
+		adhocBookingRepo,
+		session_db.NewSessionRepository(database),
+		therapistRepo,
+		notificationPort,
+		notificationRepo,
+		"",
+		db.NewSQLUnitOfWork(database),
+		notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, notificationRepo, ""),
+		[]domain.Currency{domain.DefaultCurrency},
+		confirm_booking.PaidAmountLimits{},
+		nil,
+		timeSlotRepo,
+	)
+
+	handler := NewBookingHandler(
+		*create_booking.NewUsecase(bookingRepo, therapistRepo, clientRepo, timeSlotRepo, *checkAvailabilityUsecase, nil),
+		create_adhoc_booking.Usecase{},
+		*confirmUsecase,
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	// A Monday at least a week ahead
+	monday := now.AddDate(0, 0, 7)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	book := func(clientID domain.ClientID) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]any{
+			"therapistId":          therapistID,
+			"clientId":             clientID,
+			"timeSlotId":           timeSlotID,
+			"startTime":            time.Date(monday.Year(), monday.Month(), monday.Day(), 9, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			"duration":             60,
+			"clientTimezoneOffset": 0,
+		})
+		req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+	bookAndConfirm := func(clientID domain.ClientID) {
+		rec := book(clientID)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+		var created ports.BookingResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+			t.Fatalf("Failed to parse booking: %v", err)
+		}
+		if location := rec.Header().Get("Location"); location != "/api/v1/bookings/"+string(created.RegularBookingID) {
+			t.Errorf("Expected Location of the created booking, got %q", location)
+		}
+
+		body := []byte(`{"paidAmount": 5000, "language": "english"}`)
+		req := httptest.NewRequest("PUT", "/api/v1/bookings/"+string(created.RegularBookingID)+"/confirm", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		confirmRec := httptest.NewRecorder()
+		mux.ServeHTTP(confirmRec, req)
+		if confirmRec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, confirmRec.Code, confirmRec.Body.String())
+		}
+	}
+
+	bookAndConfirm(clientIDs[0])
+	bookAndConfirm(clientIDs[1])
+
+	rec := book(clientIDs[2])
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("Expected a full group slot to conflict, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,126 @@
+package booking_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api"
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+)
+
+func TestBookingsOfAnotherClinicAreForbidden(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	// Seed a held booking of clinic A. The guard rejects requests of
+	// clinic B before any usecase runs, so none are set up.
+	const clinicA, clinicB domain.ClinicID = "clinic_a", "clinic_b"
+	now := time.Now().UTC()
+	therapistID := testutils.CreateTestTherapist(t, database)
+	clientID := testutils.CreateTestClient(t, database)
+	timeSlotID := testutils.CreateTestTimeSlot(t, database, therapistID)
+	if _, err := database.Exec(`UPDATE therapists SET clinic_id = ? WHERE id = ?`, clinicA, therapistID); err != nil {
+		t.Fatalf("Failed to move therapist: %v", err)
+	}
+	if _, err := database.Exec(`UPDATE clients SET clinic_id = ? WHERE id = ?`, clinicA, clientID); err != nil {
+		t.Fatalf("Failed to move client: %v", err)
+	}
+	bookingID := domain.NewBookingID()
+	_, err := database.Exec(`
+		INSERT INTO bookings (id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, bookingID, timeSlotID, therapistID, clientID, now.Add(48*time.Hour), 60, 0, "held", now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert booking: %v", err)
+	}
+	holdToken := domain.NewHoldToken()
+	_, err = database.Exec(`
+		INSERT INTO booking_holds (token, booking_id, expires_at, created_at)
+		VALUES (?, ?, ?, ?)
+	`, holdToken, bookingID, now.Add(10*time.Minute), now)
+	if err != nil {
+		t.Fatalf("Failed to insert hold: %v", err)
+	}
+
+	handler := NewBookingHandler(
+		create_booking.Usecase{},
+		create_adhoc_booking.Usecase{},
+		confirm_regular_booking.Usecase{},
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	server := api.ClinicMiddleware()(mux)
+
+	bookingBody, _ := json.Marshal(map[string]interface{}{
+		"therapistId":          therapistID,
+		"clientId":             clientID,
+		"timeSlotId":           timeSlotID,
+		"startTime":            now.Add(72 * time.Hour).Format(time.RFC3339),
+		"clientTimezoneOffset": 0,
+	})
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   []byte
+	}{
+		{"Cancel a booking", http.MethodPut, "/api/v1/bookings/" + string(bookingID) + "/cancel", nil},
+		{"Read a booking's history", http.MethodGet, "/api/v1/bookings/" + string(bookingID) + "/history", nil},
+		{"Confirm a hold", http.MethodPost, "/api/v1/bookings/hold/" + string(holdToken) + "/confirm", nil},
+		{"Book the therapist and client", http.MethodPost, "/api/v1/bookings", bookingBody},
+		{"Hold the therapist and client", http.MethodPost, "/api/v1/bookings/hold", bookingBody},
+		{"Read the therapist's calendar", http.MethodGet, "/api/v1/therapists/" + string(therapistID) + "/bookings/calendar", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBuffer(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(api.ClinicHeader, string(clinicB))
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			testutils.AssertErrorCode(t, rec, http.StatusForbidden, "clinic.mismatch")
+		})
+	}
+}
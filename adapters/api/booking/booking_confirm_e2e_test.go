@@ -0,0 +1,280 @@
+package booking_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/notification_db"
+	"github.com/mishkahtherapy/brain/adapters/db/session_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+	"github.com/mishkahtherapy/brain/core/usecases/notification/notify_therapist_new_booking"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+type noopNotificationPort struct{}
+
+func (p *noopNotificationPort) SendNotification(deviceID domain.DeviceID, notification ports.Notification) (*ports.NotificationID, error) {
+	return nil, nil
+}
+
+// newConfirmTestEnv seeds a database with a pending 60 minute booking in a
+// 120 minute slot and serves the confirm endpoints against it. wrapSessionRepo, when set, wraps the session
+// repository so tests can inject failures.
+func newConfirmTestEnv(
+	t *testing.T,
+	wrapSessionRepo func(ports.SessionRepository) ports.SessionRepository,
+) (ports.SQLDatabase, domain.BookingID, *http.ServeMux) {
+	tmpfile, err := os.CreateTemp("", "booking_confirm_test_*.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	dbFilename := tmpfile.Name()
+
+	database := db.NewDatabase(db.DatabaseConfig{
+		DBFilename: dbFilename,
+		SchemaFile: "../../../schema.sql",
+	})
+	t.Cleanup(func() {
+		database.Close()
+		os.Remove(dbFilename)
+	})
+
+	// Seed a therapist, client, time slot and a pending booking
+	now := time.Now().UTC()
+	therapistID := domain.NewTherapistID()
+	clientID := domain.NewClientID()
+	timeSlotID := domain.NewTimeSlotID()
+	bookingID := domain.NewBookingID()
+	startTime := now.AddDate(0, 0, 3).Truncate(time.Hour)
+	seed := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			[]any{therapistID, "Dr. Retry", "retry@example.com", "+1555000200", "+1555000200", true, now, now}},
+		{`INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			[]any{clientID, "Retry Client", "+1555000201", 0, now, now}},
+		{`INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			[]any{timeSlotID, therapistID, startTime.Weekday().String(), startTime.Format("15:04"), 120, 0, 0, true, now, now}},
+		{`INSERT INTO bookings (id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			[]any{bookingID, timeSlotID, therapistID, clientID, startTime, 60, 0, "pending", now, now}},
+	}
+	for _, s := range seed {
+		if _, err := database.Exec(s.query, s.args...); err != nil {
+			t.Fatalf("Failed to seed test data: %v", err)
+		}
+	}
+
+	var sessionRepo ports.SessionRepository = session_db.NewSessionRepository(database)
+	if wrapSessionRepo != nil {
+		sessionRepo = wrapSessionRepo(sessionRepo)
+	}
+
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+	notificationPort := &noopNotificationPort{}
+	notificationRepo := notification_db.NewNotificationRepository(database)
+	notifyTherapist := notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, notificationRepo, "")
+	confirmUsecase := confirm_regular_booking.NewUsecase(
+		booking_db.NewBookingRepository(database),
+		adhoc_booking_db.NewAdhocBookingRepository(database),
+		sessionRepo,
+		therapistRepo,
+		notificationPort,
+		notificationRepo,
+		"",
+		db.NewSQLUnitOfWork(database),
+		notifyTherapist,
+		[]domain.Currency{domain.DefaultCurrency},
+		confirm_booking.PaidAmountLimits{},
+		nil,
+		nil,
+	)
+	handler := NewBookingHandler(
+		create_booking.Usecase{},
+		create_adhoc_booking.Usecase{},
+		*confirmUsecase,
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		*get_confirmation_preview.NewUsecase(
+			booking_db.NewBookingRepository(database),
+			therapistRepo,
+			timeslot_db.NewTimeSlotRepository(database),
+			get_confirmation_preview.Price{Amount: 5000, Currency: domain.DefaultCurrency},
+		),
+		*resend_confirmation_notification.NewUsecase(booking_db.NewBookingRepository(database), sessionRepo, notifyTherapist),
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	return database, bookingID, mux
+}
+
+func TestConfirmBookingTwiceCreatesOneSession(t *testing.T) {
+	database, bookingID, mux := newConfirmTestEnv(t, nil)
+
+	var sessionID domain.SessionID
+	for attempt := 1; attempt <= 2; attempt++ {
+		body := []byte(`{"paidAmount": 5000, "language": "english"}`)
+		req := httptest.NewRequest("PUT", "/api/v1/bookings/"+string(bookingID)+"/confirm", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Attempt %d: expected status %d, got %d. Body: %s", attempt, http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response ports.BookingResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Attempt %d: failed to parse response: %v", attempt, err)
+		}
+		if response.RegularBookingID != bookingID {
+			t.Errorf("Attempt %d: expected booking %s, got %s", attempt, bookingID, response.RegularBookingID)
+		}
+		if response.SessionID == "" {
+			t.Fatalf("Attempt %d: expected the created session ID in the response", attempt)
+		}
+		if sessionID != "" && response.SessionID != sessionID {
+			t.Errorf("Attempt %d: expected session %s again, got %s", attempt, sessionID, response.SessionID)
+		}
+		sessionID = response.SessionID
+		if response.MeetingURL == nil || *response.MeetingURL != "" {
+			t.Errorf("Attempt %d: expected an empty meeting URL in the response, got %v", attempt, response.MeetingURL)
+		}
+	}
+
+	var sessionCount int
+	if err := database.QueryRow(`SELECT COUNT(*) FROM sessions WHERE regular_booking_id = ?`, bookingID).Scan(&sessionCount); err != nil {
+		t.Fatalf("Failed to count sessions: %v", err)
+	}
+	if sessionCount != 1 {
+		t.Errorf("Expected exactly one session, got %d", sessionCount)
+	}
+}
+
+// failingSessionRepo fails every session insert
+type failingSessionRepo struct {
+	ports.SessionRepository
+}
+
+func (r *failingSessionRepo) CreateSession(tx ports.SQLTx, session *domain.Session) error {
+	return errors.New("session insert failed")
+}
+
+func TestConfirmBookingRollsBackWhenSessionCreationFails(t *testing.T) {
+	database, bookingID, mux := newConfirmTestEnv(t, func(repo ports.SessionRepository) ports.SessionRepository {
+		return &failingSessionRepo{SessionRepository: repo}
+	})
+
+	body := []byte(`{"paidAmount": 5000, "language": "english"}`)
+	req := httptest.NewRequest("PUT", "/api/v1/bookings/"+string(bookingID)+"/confirm", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusInternalServerError, rec.Code, rec.Body.String())
+	}
+
+	// The state change ran first in the same transaction and must be undone
+	var state string
+	if err := database.QueryRow(`SELECT state FROM bookings WHERE id = ?`, bookingID).Scan(&state); err != nil {
+		t.Fatalf("Failed to read booking state: %v", err)
+	}
+	if state != "pending" {
+		t.Errorf("Expected the booking to stay pending, got %s", state)
+	}
+
+	var sessionCount int
+	if err := database.QueryRow(`SELECT COUNT(*) FROM sessions WHERE regular_booking_id = ?`, bookingID).Scan(&sessionCount); err != nil {
+		t.Fatalf("Failed to count sessions: %v", err)
+	}
+	if sessionCount != 0 {
+		t.Errorf("Expected no sessions, got %d", sessionCount)
+	}
+}
+
+func TestResendNotificationRequiresConfirmedBooking(t *testing.T) {
+	_, bookingID, mux := newConfirmTestEnv(t, nil)
+
+	resend := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/bookings/"+string(bookingID)+"/resend-notification", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := resend(); rec.Code != http.StatusConflict {
+		t.Fatalf("Expected a pending booking to conflict, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	body := []byte(`{"paidAmount": 5000, "language": "english"}`)
+	req := httptest.NewRequest("PUT", "/api/v1/bookings/"+string(bookingID)+"/confirm", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// The seeded therapist never registered a device
+	rec = resend()
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "therapist.no_device") {
+		t.Errorf("Expected the therapist.no_device code, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,81 @@
+package booking_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+)
+
+func TestConfirmPreview(t *testing.T) {
+	database, bookingID, mux := newConfirmTestEnv(t, nil)
+
+	preview := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/bookings/"+string(bookingID)+"/confirm-preview", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Pending booking is previewed with the timeslot's duration", func(t *testing.T) {
+		rec := preview()
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var output get_confirmation_preview.Output
+		if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if output.BookingID != bookingID {
+			t.Errorf("Expected booking %s, got %s", bookingID, output.BookingID)
+		}
+		if output.Duration != 120 {
+			t.Errorf("Expected the 120 minute timeslot duration, got %d", output.Duration)
+		}
+		if output.Therapist.Name != "Dr. Retry" {
+			t.Errorf("Expected therapist Dr. Retry, got %q", output.Therapist.Name)
+		}
+		if output.Price == nil || output.Price.Amount != 5000 || output.Price.Currency != domain.DefaultCurrency {
+			t.Errorf("Expected a price of 5000 %s, got %+v", domain.DefaultCurrency, output.Price)
+		}
+
+		// Previewing leaves the booking pending
+		var state string
+		if err := database.QueryRow(`SELECT state FROM bookings WHERE id = ?`, bookingID).Scan(&state); err != nil {
+			t.Fatalf("Failed to read booking state: %v", err)
+		}
+		if state != "pending" {
+			t.Errorf("Expected the booking to stay pending, got %s", state)
+		}
+	})
+
+	t.Run("Confirmed booking conflicts", func(t *testing.T) {
+		body := []byte(`{"paidAmount": 5000, "language": "english"}`)
+		req := httptest.NewRequest("PUT", "/api/v1/bookings/"+string(bookingID)+"/confirm", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected confirmation to succeed, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		rec = preview()
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Unknown booking", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/bookings/booking_missing/confirm-preview", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+		}
+	})
+}
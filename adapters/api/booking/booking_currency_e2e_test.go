@@ -0,0 +1,82 @@
+package booking_handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+)
+
+func TestConfirmBookingRejectsUnsupportedCurrency(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	// The currency is checked before any repository is touched
+	confirmUsecase := confirm_regular_booking.NewUsecase(
+		nil, nil, nil, nil, nil, nil, "", nil, nil,
+		[]domain.Currency{"USD", "EGP"},
+		confirm_booking.PaidAmountLimits{},
+		nil,
+		nil,
+	)
+	handler := NewBookingHandler(
+		create_booking.Usecase{},
+		create_adhoc_booking.Usecase{},
+		*confirmUsecase,
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	body := []byte(`{"paidAmount": 5000, "currency": "XYZ", "language": "english"}`)
+	req := httptest.NewRequest("PUT", "/api/v1/bookings/"+string(domain.NewBookingID())+"/confirm", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+	if !bytes.Contains(rec.Body.Bytes(), []byte("booking.invalid_currency")) {
+		t.Errorf("Expected invalid currency error code, got %s", rec.Body.String())
+	}
+}
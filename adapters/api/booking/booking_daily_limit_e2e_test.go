@@ -0,0 +1,168 @@
+package booking_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestMaxDailySessionsRejectsBookingsOverTheCap(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "booking_daily_limit_test_*.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	dbFilename := tmpfile.Name()
+
+	database := db.NewDatabase(db.DatabaseConfig{
+		DBFilename: dbFilename,
+		SchemaFile: "../../../schema.sql",
+	})
+	defer func() {
+		database.Close()
+		os.Remove(dbFilename)
+	}()
+
+	// Seed a therapist capped at two sessions a day, with four hour Monday
+	// and Tuesday slots
+	now := time.Now().UTC()
+	therapistID := domain.NewTherapistID()
+	_, err = database.Exec(`
+		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, max_daily_sessions, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, therapistID, "Dr. Capped", "capped@example.com", "+1555000900", "+1555000900", true, 2, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert therapist: %v", err)
+	}
+	timeSlotIDs := map[time.Weekday]domain.TimeSlotID{}
+	for _, day := range []time.Weekday{time.Monday, time.Tuesday} {
+		timeSlotIDs[day] = domain.NewTimeSlotID()
+		_, err = database.Exec(`
+			INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, timeSlotIDs[day], therapistID, day.String(), "09:00", 240, 0, 0, true, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert time slot: %v", err)
+		}
+	}
+	clientIDs := make([]domain.ClientID, 4)
+	for i := range clientIDs {
+		clientIDs[i] = domain.NewClientID()
+		_, err = database.Exec(`
+			INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, clientIDs[i], fmt.Sprintf("Capped Client %d", i+1), fmt.Sprintf("+20100123490%d", i), 0, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert client: %v", err)
+		}
+	}
+
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+	bookingRepo := booking_db.NewBookingRepository(database)
+	adhocBookingRepo := adhoc_booking_db.NewAdhocBookingRepository(database)
+	timeSlotRepo := timeslot_db.NewTimeSlotRepository(database)
+	getScheduleUsecase := get_schedule.NewUsecase(therapistRepo, timeSlotRepo, bookingRepo, adhocBookingRepo, nil, 15, nil, 0)
+	checkAvailabilityUsecase := check_availability.NewUsecase(bookingRepo, adhocBookingRepo, timeSlotRepo, *getScheduleUsecase)
+
+	handler := NewBookingHandler(
+		*create_booking.NewUsecase(bookingRepo, therapistRepo, client_db.NewClientRepository(database), timeSlotRepo, *checkAvailabilityUsecase, nil),
+		create_adhoc_booking.Usecase{},
+		confirm_regular_booking.Usecase{},
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	// A Monday at least a week ahead
+	monday := now.AddDate(0, 0, 7)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	book := func(clientID domain.ClientID, day time.Time, hour int) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]any{
+			"therapistId":          therapistID,
+			"clientId":             clientID,
+			"timeSlotId":           timeSlotIDs[day.Weekday()],
+			"startTime":            time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			"duration":             60,
+			"clientTimezoneOffset": 0,
+		})
+		req := httptest.NewRequest("POST", "/api/v1/bookings", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i, hour := range []int{9, 10} {
+		if rec := book(clientIDs[i], monday, hour); rec.Code != http.StatusCreated {
+			t.Fatalf("Expected booking %d to be created, got %d. Body: %s", i+1, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := book(clientIDs[2], monday, 11)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("Expected a third booking on the same day to conflict, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	if !bytes.Contains(rec.Body.Bytes(), []byte("booking.daily_session_limit_reached")) {
+		t.Errorf("Expected the daily session limit code, got %s", rec.Body.String())
+	}
+
+	// The cap is per day
+	if rec := book(clientIDs[3], monday.AddDate(0, 0, 1), 9); rec.Code != http.StatusCreated {
+		t.Fatalf("Expected a booking on the next day to be created, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -1,7 +1,9 @@
 package booking_handler
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,6 +42,14 @@ func (r *TestSessionRepository) GetSessionByID(id domain.SessionID) (*domain.Ses
 	return nil, nil
 }
 
+func (r *TestSessionRepository) GetSessionByRegularBookingID(bookingID domain.BookingID) (*domain.Session, error) {
+	return nil, nil
+}
+
+func (r *TestSessionRepository) GetSessionByAdhocBookingID(bookingID domain.AdhocBookingID) (*domain.Session, error) {
+	return nil, nil
+}
+
 func (r *TestSessionRepository) UpdateSessionState(id domain.SessionID, state domain.SessionState) error {
 	return nil
 }
@@ -52,7 +62,7 @@ func (r *TestSessionRepository) UpdateMeetingURL(id domain.SessionID, meetingURL
 	return nil
 }
 
-func (r *TestSessionRepository) ListSessionsByTherapist(therapistID domain.TherapistID) ([]*domain.Session, error) {
+func (r *TestSessionRepository) ListSessionsByTherapist(therapistID domain.TherapistID, states []domain.SessionState) ([]*domain.Session, error) {
 	return nil, nil
 }
 
@@ -60,7 +70,7 @@ func (r *TestSessionRepository) ListSessionsByClient(clientID domain.ClientID) (
 	return nil, nil
 }
 
-func (r *TestSessionRepository) ListSessionsAdmin(startDate, endDate time.Time) ([]*domain.Session, error) {
+func (r *TestSessionRepository) ListSessionsAdmin(clinicID domain.ClinicID, startDate, endDate time.Time) ([]*domain.Session, error) {
 	return nil, nil
 }
 
@@ -69,8 +79,18 @@ type TestClientRepository struct {
 }
 
 func (r *TestClientRepository) BulkGetByID(ids []domain.ClientID) ([]*client.Client, error) {
-	query := `SELECT id, name, whatsapp_number, timezone_offset, created_at, updated_at FROM clients WHERE id IN (?)`
-	rows, err := r.db.Query(query, ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	query := fmt.Sprintf(`SELECT id, name, whatsapp_number, timezone_offset, created_at, updated_at FROM clients WHERE id IN (%s)`, placeholders)
+	rows, err := r.db.Query(query, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +119,10 @@ func (r *TestClientRepository) UpdateTimezoneOffset(id domain.ClientID, offsetMi
 	return nil
 }
 
+func (r *TestClientRepository) GetSummary(id domain.ClientID, now time.Time) (*client.Summary, error) {
+	return nil, nil
+}
+
 type TestTimeSlotRepository struct {
 	db ports.SQLDatabase
 }
@@ -116,12 +140,27 @@ func (r *TestTimeSlotRepository) GetByID(id domain.TimeSlotID) (*timeslot.TimeSl
 }
 
 func (r *TestTimeSlotRepository) Create(timeslot *timeslot.TimeSlot) error { return nil }
+func (r *TestTimeSlotRepository) CreateTx(sqlExec ports.SQLExec, timeslot *timeslot.TimeSlot) error {
+	return nil
+}
 func (r *TestTimeSlotRepository) Update(timeslot *timeslot.TimeSlot) error { return nil }
 func (r *TestTimeSlotRepository) Delete(id domain.TimeSlotID) error        { return nil }
+func (r *TestTimeSlotRepository) BulkDeleteTx(sqlExec ports.SQLExec, ids []domain.TimeSlotID) error {
+	return nil
+}
+func (r *TestTimeSlotRepository) ListActiveBookingIDsTx(sqlExec ports.SQLExec, id domain.TimeSlotID, after time.Time) ([]domain.BookingID, error) {
+	return nil, nil
+}
 func (r *TestTimeSlotRepository) ListByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error) {
 	return nil, nil
 }
-func (r *TestTimeSlotRepository) BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) error {
+func (r *TestTimeSlotRepository) ListActiveByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error) {
+	return nil, nil
+}
+func (r *TestTimeSlotRepository) BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) (int, error) {
+	return 0, nil
+}
+func (r *TestTimeSlotRepository) SetActive(id domain.TimeSlotID, isActive bool) error {
 	return nil
 }
 func (r *TestTimeSlotRepository) BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.TimeSlot, error) {
@@ -176,6 +215,7 @@ func TestBookingE2E(t *testing.T) {
 		*listByTherapistUsecase,
 		*listByClientUsecase,
 		*searchBookingsUsecase,
+		testutils.NewClinicGuard(database),
 	)
 
 	// Setup router
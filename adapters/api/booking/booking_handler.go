@@ -2,7 +2,10 @@ package booking_handler
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,12 +14,27 @@ import (
 	"github.com/mishkahtherapy/brain/core/domain/booking"
 	"github.com/mishkahtherapy/brain/core/ports"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+	"github.com/mishkahtherapy/brain/core/usecases/clinic/check_clinic"
 	"github.com/mishkahtherapy/brain/core/usecases/common"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
 )
 
 type BookingHandler struct {
@@ -26,6 +44,20 @@ type BookingHandler struct {
 	confirmAdhocBookingUsecase   confirm_adhoc_booking.Usecase
 	cancelBookingUsecase         cancel_booking.Usecase
 	searchBookingsUsecase        search_bookings.Usecase
+	getBookingStatsUsecase       get_booking_stats.Usecase
+	reassignBookingUsecase       reassign_booking.Usecase
+	getBookingHistoryUsecase     get_booking_history.Usecase
+	updateBookingDurationUsecase update_booking_duration.Usecase
+	getLeadTimeStatsUsecase      get_lead_time_stats.Usecase
+	cancelFutureBookingsUsecase  cancel_future_bookings.Usecase
+	getBookingCalendarUsecase    get_booking_calendar.Usecase
+	reactivateBookingUsecase     reactivate_booking.Usecase
+	streamBookingEventsUsecase   stream_booking_events.Usecase
+	holdBookingUsecase           hold_booking.Usecase
+	confirmBookingHoldUsecase    confirm_booking_hold.Usecase
+	confirmPreviewUsecase        get_confirmation_preview.Usecase
+	resendNotificationUsecase    resend_confirmation_notification.Usecase
+	clinicGuard                  *api.ClinicGuard
 }
 
 func NewBookingHandler(
@@ -35,6 +67,20 @@ func NewBookingHandler(
 	confirmAdhocBookingUsecase confirm_adhoc_booking.Usecase,
 	cancelUsecase cancel_booking.Usecase,
 	searchUsecase search_bookings.Usecase,
+	getStatsUsecase get_booking_stats.Usecase,
+	reassignUsecase reassign_booking.Usecase,
+	getHistoryUsecase get_booking_history.Usecase,
+	updateDurationUsecase update_booking_duration.Usecase,
+	getLeadTimeStatsUsecase get_lead_time_stats.Usecase,
+	cancelFutureBookingsUsecase cancel_future_bookings.Usecase,
+	getBookingCalendarUsecase get_booking_calendar.Usecase,
+	reactivateBookingUsecase reactivate_booking.Usecase,
+	streamBookingEventsUsecase stream_booking_events.Usecase,
+	holdBookingUsecase hold_booking.Usecase,
+	confirmBookingHoldUsecase confirm_booking_hold.Usecase,
+	confirmPreviewUsecase get_confirmation_preview.Usecase,
+	resendNotificationUsecase resend_confirmation_notification.Usecase,
+	clinicGuard *api.ClinicGuard,
 ) *BookingHandler {
 	return &BookingHandler{
 		createBookingUsecase:         createUsecase,
@@ -43,27 +89,68 @@ func NewBookingHandler(
 		confirmAdhocBookingUsecase:   confirmAdhocBookingUsecase,
 		cancelBookingUsecase:         cancelUsecase,
 		searchBookingsUsecase:        searchUsecase,
+		getBookingStatsUsecase:       getStatsUsecase,
+		reassignBookingUsecase:       reassignUsecase,
+		getBookingHistoryUsecase:     getHistoryUsecase,
+		updateBookingDurationUsecase: updateDurationUsecase,
+		getLeadTimeStatsUsecase:      getLeadTimeStatsUsecase,
+		cancelFutureBookingsUsecase:  cancelFutureBookingsUsecase,
+		getBookingCalendarUsecase:    getBookingCalendarUsecase,
+		reactivateBookingUsecase:     reactivateBookingUsecase,
+		streamBookingEventsUsecase:   streamBookingEventsUsecase,
+		holdBookingUsecase:           holdBookingUsecase,
+		confirmBookingHoldUsecase:    confirmBookingHoldUsecase,
+		confirmPreviewUsecase:        confirmPreviewUsecase,
+		resendNotificationUsecase:    resendNotificationUsecase,
+		clinicGuard:                  clinicGuard,
 	}
 }
 
-func (h *BookingHandler) RegisterRoutes(mux *http.ServeMux) {
+func (h *BookingHandler) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc("POST /api/v1/bookings", h.handleCreateBooking)
 	mux.HandleFunc("GET /api/v1/bookings/search", h.handleSearchBookings)
-	mux.HandleFunc("PUT /api/v1/bookings/{id}/confirm", h.handleConfirmBooking)
-	mux.HandleFunc("PUT /api/v1/bookings/{id}/cancel", h.handleCancelBooking)
+	mux.HandleFunc("PUT /api/v1/bookings/{id}/confirm", h.clinicGuard.Booking("id", h.handleConfirmBooking))
+	mux.HandleFunc("GET /api/v1/bookings/{id}/confirm-preview", h.clinicGuard.Booking("id", h.handleGetConfirmPreview))
+	mux.HandleFunc("POST /api/v1/bookings/{id}/resend-notification", h.clinicGuard.Booking("id", h.handleResendNotification))
+	mux.HandleFunc("PUT /api/v1/bookings/{id}/cancel", h.clinicGuard.Booking("id", h.handleCancelBooking))
+	mux.HandleFunc("PUT /api/v1/bookings/{id}/reactivate", h.clinicGuard.Booking("id", h.handleReactivateBooking))
+	mux.HandleFunc("PUT /api/v1/bookings/{id}/reassign", h.clinicGuard.Booking("id", h.handleReassignBooking))
+	mux.HandleFunc("PUT /api/v1/bookings/{id}/duration", h.clinicGuard.Booking("id", h.handleUpdateBookingDuration))
+	mux.HandleFunc("GET /api/v1/bookings/{id}/history", h.clinicGuard.Booking("id", h.handleGetBookingHistory))
 	mux.HandleFunc("POST /api/v1/bookings/adhoc", h.handleCreateAdhocBooking)
+	mux.HandleFunc("POST /api/v1/bookings/hold", h.handleHoldBooking)
+	mux.HandleFunc("POST /api/v1/bookings/hold/{token}/confirm", h.clinicGuard.Hold("token", h.handleConfirmBookingHold))
+	// The clinic-wide admin calendar lists bookings across all therapists
+	mux.HandleFunc("GET /api/v1/admin/bookings", h.handleSearchBookings)
+	mux.HandleFunc("GET /api/v1/admin/bookings/stats", h.handleGetBookingStats)
+	mux.HandleFunc("GET /api/v1/admin/therapists/{id}/lead-time-stats", h.clinicGuard.Therapist("id", h.handleGetLeadTimeStats))
+	mux.HandleFunc("POST /api/v1/admin/therapists/{id}/cancel-future-bookings", h.clinicGuard.Therapist("id", h.handleCancelFutureBookings))
+	mux.HandleFunc("GET /api/v1/therapists/{id}/bookings/calendar", h.clinicGuard.Therapist("id", h.handleGetBookingCalendar))
+	mux.HandleFunc(streamBookingEventsRoute, h.clinicGuard.Therapist("id", h.handleStreamBookingEvents))
+}
+
+// LongLivedRoutes lists the routes that stay open by design, which the
+// server exempts from its request timeout
+func (h *BookingHandler) LongLivedRoutes() []string {
+	return []string{streamBookingEventsRoute}
 }
 
 func (h *BookingHandler) handleCreateBooking(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
 	var input create_booking.Input
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		rw.WriteBadRequest(err.Error())
+	if err := api.DecodeJSON(r, &input); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return
+	}
+	if !h.clinicGuard.Check(rw, r, check_clinic.Input{
+		TherapistIDs: []domain.TherapistID{input.TherapistID},
+		ClientIDs:    []domain.ClientID{input.ClientID},
+	}) {
 		return
 	}
 
-	booking, err := h.createBookingUsecase.Execute(input)
+	createdBooking, err := h.createBookingUsecase.Execute(input)
 	if err != nil {
 		// Handle specific business logic errors
 		switch err {
@@ -75,18 +162,88 @@ func (h *BookingHandler) handleCreateBooking(w http.ResponseWriter, r *http.Requ
 			common.ErrTherapistNotFound,
 			common.ErrClientNotFound,
 			common.ErrTimeSlotNotFound,
+			booking.ErrTimeSlotNotOwned,
 			domain.ErrInvalidTimezone:
-			rw.WriteBadRequest(err.Error())
-		case common.ErrTimeSlotAlreadyBooked:
-			rw.WriteError(err, http.StatusConflict)
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrTimeSlotAlreadyBooked,
+			booking.ErrDailySessionLimitReached:
+			rw.WriteCodedError(err, http.StatusConflict)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	if err := rw.WriteJSON(booking, http.StatusCreated); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+	if err := rw.WriteCreatedJSON(createdBooking, "/api/v1/bookings/"+string(createdBooking.RegularBookingID)); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleHoldBooking(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	var input hold_booking.Input
+	if err := api.DecodeJSON(r, &input); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return
+	}
+	if !h.clinicGuard.Check(rw, r, check_clinic.Input{
+		TherapistIDs: []domain.TherapistID{input.TherapistID},
+		ClientIDs:    []domain.ClientID{input.ClientID},
+	}) {
+		return
+	}
+
+	hold, err := h.holdBookingUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case common.ErrTherapistIDIsRequired,
+			common.ErrClientIDIsRequired,
+			common.ErrTimeSlotIDIsRequired,
+			common.ErrStartTimeIsRequired,
+			common.ErrDurationIsRequired,
+			common.ErrClientNotFound,
+			common.ErrTimeSlotNotFound:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrTimeSlotAlreadyBooked,
+			booking.ErrDailySessionLimitReached:
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(hold, http.StatusCreated); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleConfirmBookingHold(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	input := confirm_booking_hold.Input{
+		Token: domain.HoldToken(r.PathValue("token")),
+	}
+
+	confirmedBooking, err := h.confirmBookingHoldUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case booking.ErrHoldNotFound,
+			common.ErrBookingNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case booking.ErrHoldExpired:
+			rw.WriteCodedError(err, http.StatusGone)
+		case common.ErrInvalidStateTransition:
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(confirmedBooking, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
@@ -94,28 +251,42 @@ func (h *BookingHandler) handleCreateAdhocBooking(w http.ResponseWriter, r *http
 	rw := api.NewResponseWriter(w)
 
 	var input create_adhoc_booking.Input
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		rw.WriteBadRequest(err.Error())
+	if err := api.DecodeJSON(r, &input); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return
+	}
+	if !h.clinicGuard.Check(rw, r, check_clinic.Input{
+		TherapistIDs: []domain.TherapistID{input.TherapistID},
+		ClientIDs:    []domain.ClientID{input.ClientID},
+	}) {
 		return
 	}
 
 	adhocBooking, err := h.createAdhocBookingUsecase.Execute(input)
 	if err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 		return
 	}
 
 	if err := rw.WriteJSON(adhocBooking, http.StatusCreated); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
+// searchBookingsResponse is one page of search results. Pass nextCursor as
+// the cursor param to get the following page; it is omitted on the last page.
+type searchBookingsResponse struct {
+	Bookings   []*search_bookings.Output `json:"bookings"`
+	NextCursor string                    `json:"nextCursor,omitempty"`
+}
+
 func (h *BookingHandler) handleSearchBookings(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
-	// Parse optional start & end query params (YYYY-MM-DD expected)
-	startParam := r.URL.Query().Get("start")
-	endParam := r.URL.Query().Get("end")
+	// Parse optional from & to query params (YYYY-MM-DD expected). start and
+	// end are still accepted for older clients.
+	startName, startParam := firstQueryParam(r, "from", "start")
+	endName, endParam := firstQueryParam(r, "to", "end")
 	stateParam := r.URL.Query().Get("state")
 
 	var startTime, endTime time.Time
@@ -125,7 +296,7 @@ func (h *BookingHandler) handleSearchBookings(w http.ResponseWriter, r *http.Req
 	if startParam != "" {
 		startTime, err = time.Parse(time.DateOnly, startParam)
 		if err != nil {
-			rw.WriteBadRequest("invalid start parameter. Expected YYYY-MM-DD format")
+			rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "invalid "+startName+" parameter. Expected YYYY-MM-DD format", http.StatusBadRequest)
 			return
 		}
 		startTime = startTime.UTC()
@@ -135,7 +306,7 @@ func (h *BookingHandler) handleSearchBookings(w http.ResponseWriter, r *http.Req
 	if endParam != "" {
 		endTime, err = time.Parse(time.DateOnly, endParam)
 		if err != nil {
-			rw.WriteBadRequest("invalid end parameter. Expected YYYY-MM-DD format")
+			rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "invalid "+endName+" parameter. Expected YYYY-MM-DD format", http.StatusBadRequest)
 			return
 		}
 		endTime = endTime.AddDate(0, 0, 1).Add(-time.Nanosecond).UTC() // End of day
@@ -143,7 +314,7 @@ func (h *BookingHandler) handleSearchBookings(w http.ResponseWriter, r *http.Req
 
 	// Validate date range only if both dates are provided
 	if !startTime.IsZero() && !endTime.IsZero() && endTime.Before(startTime) {
-		rw.WriteBadRequest("end must be after start")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, endName+" must be after "+startName, http.StatusBadRequest)
 		return
 	}
 
@@ -156,37 +327,269 @@ func (h *BookingHandler) handleSearchBookings(w http.ResponseWriter, r *http.Req
 			bookingStates = append(bookingStates, bookingState)
 			if bookingState != booking.BookingStatePending &&
 				bookingState != booking.BookingStateConfirmed &&
-				bookingState != booking.BookingStateCancelled {
-				rw.WriteBadRequest("Invalid state parameter. Must be one of: pending, confirmed, cancelled")
+				bookingState != booking.BookingStateCancelled &&
+				bookingState != booking.BookingStateHeld {
+				rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid state parameter. Must be one of: pending, confirmed, cancelled, held", http.StatusBadRequest)
 				return
 			}
 		}
 		states = bookingStates
 	}
 
+	page, err := api.ParsePagination(r)
+	if err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	input := search_bookings.Input{
-		Start:  startTime,
-		End:    endTime,
-		States: states,
+		Start:    startTime,
+		End:      endTime,
+		States:   states,
+		Page:     page,
+		ClinicID: api.ClinicFromContext(r.Context()),
 	}
 
-	bookings, err := h.searchBookingsUsecase.Execute(input)
+	result, err := h.searchBookingsUsecase.Execute(input)
 
 	// TODO: combine with adhoc bookings.
 	if err != nil {
 		switch err {
 		case common.ErrInvalidDateRange:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case common.ErrFailedToListBookings:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := searchBookingsResponse{
+		Bookings:   result.Bookings,
+		NextCursor: api.EncodeCursor(result.Next),
+	}
+	if err := rw.WriteJSON(response, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+// firstQueryParam returns the first of the given query parameters that is set,
+// along with its name for error messages.
+func firstQueryParam(r *http.Request, names ...string) (string, string) {
+	for _, name := range names {
+		if value := r.URL.Query().Get(name); value != "" {
+			return name, value
+		}
+	}
+	return names[0], ""
+}
+
+// handleGetBookingStats handles GET /api/v1/admin/bookings/stats
+func (h *BookingHandler) handleGetBookingStats(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Parse optional from & to query params (YYYY-MM-DD expected)
+	fromParam := r.URL.Query().Get("from")
+	toParam := r.URL.Query().Get("to")
+
+	var from, to time.Time
+	var err error
+
+	if fromParam != "" {
+		from, err = time.Parse(time.DateOnly, fromParam)
+		if err != nil {
+			rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "invalid from parameter. Expected YYYY-MM-DD format", http.StatusBadRequest)
+			return
+		}
+		from = from.UTC()
+	}
+
+	if toParam != "" {
+		to, err = time.Parse(time.DateOnly, toParam)
+		if err != nil {
+			rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "invalid to parameter. Expected YYYY-MM-DD format", http.StatusBadRequest)
+			return
+		}
+		to = to.AddDate(0, 0, 1).Add(-time.Nanosecond).UTC() // End of day
+	}
+
+	stats, err := h.getBookingStatsUsecase.Execute(get_booking_stats.Input{
+		From:     from,
+		To:       to,
+		ClinicID: api.ClinicFromContext(r.Context()),
+	})
+	if err != nil {
+		switch err {
+		case common.ErrInvalidDateRange:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(stats, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleGetLeadTimeStats(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse optional from & to query params (YYYY-MM-DD expected)
+	fromParam := r.URL.Query().Get("from")
+	toParam := r.URL.Query().Get("to")
+
+	var from, to time.Time
+	var err error
+
+	if fromParam != "" {
+		from, err = time.Parse(time.DateOnly, fromParam)
+		if err != nil {
+			rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "invalid from parameter. Expected YYYY-MM-DD format", http.StatusBadRequest)
+			return
+		}
+		from = from.UTC()
+	}
+
+	if toParam != "" {
+		to, err = time.Parse(time.DateOnly, toParam)
+		if err != nil {
+			rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "invalid to parameter. Expected YYYY-MM-DD format", http.StatusBadRequest)
+			return
+		}
+		to = to.AddDate(0, 0, 1).Add(-time.Nanosecond).UTC() // End of day
+	}
+
+	stats, err := h.getLeadTimeStatsUsecase.Execute(get_lead_time_stats.Input{
+		TherapistID: therapistID,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		switch err {
+		case common.ErrTherapistIDIsRequired,
+			common.ErrInvalidDateRange:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	if err := rw.WriteJSON(bookings, http.StatusOK); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+	if err := rw.WriteJSON(stats, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleGetBookingCalendar(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	// from & to are local dates (YYYY-MM-DD) in the given timezone
+	from, err := time.Parse(time.DateOnly, r.URL.Query().Get("from"))
+	if err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "invalid from parameter. Expected YYYY-MM-DD format", http.StatusBadRequest)
+		return
+	}
+	to, err := time.Parse(time.DateOnly, r.URL.Query().Get("to"))
+	if err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "invalid to parameter. Expected YYYY-MM-DD format", http.StatusBadRequest)
+		return
+	}
+
+	timezoneOffsetParam := r.URL.Query().Get("timezoneOffset")
+	if timezoneOffsetParam == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing timezoneOffset query parameter", http.StatusBadRequest)
+		return
+	}
+
+	var timezoneOffset domain.TimezoneOffset
+	if _, err := fmt.Sscanf(timezoneOffsetParam, "%d", &timezoneOffset); err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid timezoneOffset format", http.StatusBadRequest)
+		return
+	}
+
+	if err := timeslot_usecase.ValidateTimezoneOffset(timezoneOffset); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return
+	}
+
+	calendar, err := h.getBookingCalendarUsecase.Execute(get_booking_calendar.Input{
+		TherapistID:    therapistID,
+		From:           from,
+		To:             to,
+		TimezoneOffset: timezoneOffset,
+	})
+	if err != nil {
+		switch err {
+		case common.ErrTherapistIDIsRequired,
+			common.ErrInvalidDateRange:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(calendar, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleCancelFutureBookings(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	// The body is optional
+	var requestBody struct {
+		Reason string `json:"reason"`
+	}
+	if err := api.DecodeJSON(r, &requestBody); err != nil && err != io.EOF {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.cancelFutureBookingsUsecase.Execute(cancel_future_bookings.Input{
+		TherapistID: therapistID,
+		Reason:      requestBody.Reason,
+		Actor:       api.ActorFromContext(r.Context()),
+	})
+	if err != nil {
+		switch err {
+		case common.ErrTherapistIDIsRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(output, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
@@ -196,41 +599,45 @@ func (h *BookingHandler) handleConfirmBooking(w http.ResponseWriter, r *http.Req
 	// Read id from path
 	id := r.PathValue("id")
 	if id == "" {
-		rw.WriteBadRequest("Missing booking ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing booking ID", http.StatusBadRequest)
 		return
 	}
 
-	// Parse request body to get paid amount and language
+	// Parse request body to get paid amount, currency and language
 	var requestBody struct {
-		PaidAmountUSD int                    `json:"paidAmount"` // WhatsApp currency (smallest unit integer)
-		Language      domain.SessionLanguage `json:"language"`
-		Notes         string                 `json:"notes"`
+		PaidAmount int                    `json:"paidAmount"` // Smallest unit integer of the currency
+		Currency   domain.Currency        `json:"currency"`   // ISO 4217 code, defaults to USD
+		Language   domain.SessionLanguage `json:"language"`
+		Notes      string                 `json:"notes"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		rw.WriteBadRequest(err.Error())
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
 		return
 	}
 
 	bookingType, err := booking.GetType(id)
 	if err != nil {
-		rw.WriteBadRequest(err.Error())
+		rw.WriteCodedError(err, http.StatusBadRequest)
 		return
 	}
 
 	var confirmedBooking *ports.BookingResponse
 	if bookingType == booking.BookingTypeRegular {
 		input := confirm_regular_booking.Input{
-			BookingID:     domain.BookingID(id),
-			PaidAmountUSD: requestBody.PaidAmountUSD,
-			Language:      requestBody.Language,
+			BookingID:  domain.BookingID(id),
+			PaidAmount: requestBody.PaidAmount,
+			Currency:   requestBody.Currency,
+			Language:   requestBody.Language,
+			Actor:      api.ActorFromContext(r.Context()),
 		}
 		confirmedBooking, err = h.confirmRegularBookingUsecase.Execute(input)
 	} else {
 		input := confirm_adhoc_booking.Input{
-			BookingID:     domain.AdhocBookingID(id),
-			PaidAmountUSD: requestBody.PaidAmountUSD,
-			Language:      requestBody.Language,
+			BookingID:  domain.AdhocBookingID(id),
+			PaidAmount: requestBody.PaidAmount,
+			Currency:   requestBody.Currency,
+			Language:   requestBody.Language,
 		}
 		confirmedBooking, err = h.confirmAdhocBookingUsecase.Execute(input)
 	}
@@ -240,23 +647,25 @@ func (h *BookingHandler) handleConfirmBooking(w http.ResponseWriter, r *http.Req
 		switch err {
 		case common.ErrBookingIDIsRequired,
 			common.ErrPaidAmountIsRequired,
+			common.ErrPaidAmountOutOfRange,
 			common.ErrLanguageIsRequired,
+			common.ErrInvalidCurrency,
 			common.ErrTimeSlotAlreadyBooked:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case common.ErrBookingNotFound:
-			rw.WriteNotFound(err.Error())
+			rw.WriteCodedError(err, http.StatusNotFound)
 		case common.ErrInvalidBookingState:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case booking.ErrFailedToCreateSession:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
 	if err := rw.WriteJSON(confirmedBooking, http.StatusOK); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
@@ -266,31 +675,356 @@ func (h *BookingHandler) handleCancelBooking(w http.ResponseWriter, r *http.Requ
 	// Read id from path
 	id := domain.BookingID(r.PathValue("id"))
 	if id == "" {
-		rw.WriteBadRequest("Missing booking ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing booking ID", http.StatusBadRequest)
 		return
 	}
 
+	// Admins may cancel within the cancellation cutoff with ?override=true
+	override := false
+	if overrideParam := r.URL.Query().Get("override"); overrideParam != "" {
+		var err error
+		override, err = strconv.ParseBool(overrideParam)
+		if err != nil {
+			rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid override parameter. Expected true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
 	input := cancel_booking.Input{
 		BookingID: id,
+		Actor:     api.ActorFromContext(r.Context()),
+		Override:  override,
 	}
 
-	booking, err := h.cancelBookingUsecase.Execute(input)
+	cancelledBooking, err := h.cancelBookingUsecase.Execute(input)
 	if err != nil {
 		// Handle specific business logic errors
 		switch err {
 		case common.ErrBookingIDIsRequired:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case common.ErrBookingNotFound:
-			rw.WriteNotFound(err.Error())
+			rw.WriteCodedError(err, http.StatusNotFound)
 		case common.ErrInvalidStateTransition:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case booking.ErrWithinCancellationCutoff:
+			rw.WriteCodedError(err, http.StatusConflict)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	if err := rw.WriteJSON(booking, http.StatusOK); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+	if err := rw.WriteJSON(cancelledBooking, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleReactivateBooking(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read id from path
+	id := domain.BookingID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing booking ID", http.StatusBadRequest)
+		return
+	}
+
+	input := reactivate_booking.Input{
+		BookingID: id,
+		Actor:     api.ActorFromContext(r.Context()),
+	}
+
+	reactivatedBooking, err := h.reactivateBookingUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case common.ErrBookingIDIsRequired,
+			common.ErrInvalidStateTransition,
+			common.ErrInvalidBookingTime,
+			booking.ErrReactivationWindowExpired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrBookingNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case common.ErrTimeSlotAlreadyBooked,
+			booking.ErrDailySessionLimitReached:
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(reactivatedBooking, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleReassignBooking(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read id from path
+	id := domain.BookingID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing booking ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody struct {
+		NewTherapistID domain.TherapistID `json:"newTherapistId"`
+		NewTimeSlotID  domain.TimeSlotID  `json:"newTimeSlotId"`
+	}
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return
+	}
+
+	if !h.clinicGuard.Check(rw, r, check_clinic.Input{TherapistIDs: []domain.TherapistID{requestBody.NewTherapistID}}) {
+		return
+	}
+
+	input := reassign_booking.Input{
+		BookingID:      id,
+		NewTherapistID: requestBody.NewTherapistID,
+		NewTimeSlotID:  requestBody.NewTimeSlotID,
+	}
+
+	reassignedBooking, err := h.reassignBookingUsecase.Execute(input)
+	if err != nil {
+		// Handle specific business logic errors
+		switch err {
+		case common.ErrBookingIDIsRequired,
+			common.ErrTherapistIDIsRequired,
+			common.ErrTimeSlotIDIsRequired,
+			common.ErrTimeSlotNotFound,
+			common.ErrInvalidStateTransition,
+			booking.ErrSpecializationMismatch:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrBookingNotFound,
+			common.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case common.ErrTimeSlotAlreadyBooked,
+			booking.ErrDailySessionLimitReached:
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(reassignedBooking, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleUpdateBookingDuration(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read id from path
+	id := domain.BookingID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing booking ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody struct {
+		DurationMinutes domain.DurationMinutes `json:"durationMinutes"`
+	}
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return
+	}
+
+	updatedBooking, err := h.updateBookingDurationUsecase.Execute(update_booking_duration.Input{
+		BookingID:       id,
+		DurationMinutes: requestBody.DurationMinutes,
+	})
+	if err != nil {
+		// Handle specific business logic errors
+		switch err {
+		case common.ErrBookingIDIsRequired,
+			common.ErrDurationIsRequired,
+			common.ErrInvalidStateTransition,
+			booking.ErrOutsideTimeSlot:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrBookingNotFound,
+			common.ErrTimeSlotNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case common.ErrTimeSlotAlreadyBooked:
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(updatedBooking, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *BookingHandler) handleGetBookingHistory(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read id from path
+	id := domain.BookingID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing booking ID", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.getBookingHistoryUsecase.Execute(id)
+	if err != nil {
+		switch err {
+		case common.ErrBookingIDIsRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrBookingNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(history, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+// handleGetConfirmPreview summarizes a pending booking's confirmation, e.g.
+// before the client pays, without changing the booking.
+func (h *BookingHandler) handleGetConfirmPreview(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read id from path
+	id := domain.BookingID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing booking ID", http.StatusBadRequest)
+		return
+	}
+
+	preview, err := h.confirmPreviewUsecase.Execute(id)
+	if err != nil {
+		switch err {
+		case common.ErrBookingIDIsRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrBookingNotFound,
+			common.ErrTherapistNotFound,
+			common.ErrTimeSlotNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case common.ErrInvalidBookingState:
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(preview, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+// handleResendNotification sends the therapist the confirmation notification
+// of a confirmed booking again
+func (h *BookingHandler) handleResendNotification(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read id from path
+	id := domain.BookingID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing booking ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.resendNotificationUsecase.Execute(id); err != nil {
+		switch err {
+		case common.ErrBookingIDIsRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrBookingNotFound,
+			common.ErrSessionNotFound,
+			common.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case common.ErrInvalidBookingState,
+			booking.ErrTherapistHasNoDevice:
+			rw.WriteCodedError(err, http.StatusConflict)
+		case ports.ErrNotificationFailed:
+			rw.WriteCodedError(err, http.StatusBadGateway)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+const streamBookingEventsRoute = "GET /api/v1/therapists/{id}/bookings/stream"
+
+// streamKeepAliveInterval is how often an idle event stream sends a comment,
+// so proxies don't close the connection.
+const streamKeepAliveInterval = 15 * time.Second
+
+// handleStreamBookingEvents streams the therapist's booking events as
+// Server-Sent Events until the client disconnects.
+func (h *BookingHandler) handleStreamBookingEvents(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.streamBookingEventsUsecase.Execute(stream_booking_events.Input{
+		TherapistID: therapistID,
+	})
+	if err != nil {
+		switch err {
+		case common.ErrTherapistIDIsRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case common.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+	defer output.Unsubscribe()
+
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		// The response can't be streamed; nothing else to send
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-output.Events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
 	}
 }
@@ -0,0 +1,249 @@
+package booking_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_hold_db"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/release_expired_holds"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestBookingHolds(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "booking_hold_test_*.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	dbFilename := tmpfile.Name()
+
+	database := db.NewDatabase(db.DatabaseConfig{
+		DBFilename: dbFilename,
+		SchemaFile: "../../../schema.sql",
+	})
+	defer func() {
+		database.Close()
+		os.Remove(dbFilename)
+	}()
+
+	// Seed a therapist with one-hour Monday and Tuesday slots and a client
+	now := time.Now().UTC()
+	therapistID := domain.NewTherapistID()
+	clientID := domain.NewClientID()
+	_, err = database.Exec(`
+		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, therapistID, "Dr. Hold", "hold@example.com", "+1555000700", "+1555000700", true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert therapist: %v", err)
+	}
+	_, err = database.Exec(`
+		INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, clientID, "Hold Client", "+201001234569", 0, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert client: %v", err)
+	}
+	insertSlot := func(dayOfWeek string) domain.TimeSlotID {
+		timeSlotID := domain.NewTimeSlotID()
+		_, err := database.Exec(`
+			INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, timeSlotID, therapistID, dayOfWeek, "09:00", 60, 0, 0, true, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert time slot: %v", err)
+		}
+		return timeSlotID
+	}
+	mondaySlotID := insertSlot("Monday")
+	tuesdaySlotID := insertSlot("Tuesday")
+
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+	bookingRepo := booking_db.NewBookingRepository(database)
+	adhocBookingRepo := adhoc_booking_db.NewAdhocBookingRepository(database)
+	holdRepo := booking_hold_db.NewBookingHoldRepository(database)
+	clientRepo := client_db.NewClientRepository(database)
+	timeSlotRepo := timeslot_db.NewTimeSlotRepository(database)
+	transactions := db.NewSQLTransactionRepo(database)
+	getScheduleUsecase := get_schedule.NewUsecase(
+		therapistRepo,
+		timeSlotRepo,
+		bookingRepo,
+		adhocBookingRepo,
+		nil,
+		15,
+		nil,
+		0,
+	)
+	checkAvailabilityUsecase := check_availability.NewUsecase(bookingRepo, adhocBookingRepo, timeSlotRepo, *getScheduleUsecase)
+	releaseExpiredHolds := release_expired_holds.NewUsecase(bookingRepo, holdRepo, transactions)
+
+	handler := NewBookingHandler(
+		*create_booking.NewUsecase(bookingRepo, therapistRepo, clientRepo, timeSlotRepo, *checkAvailabilityUsecase, nil),
+		create_adhoc_booking.Usecase{},
+		confirm_regular_booking.Usecase{},
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		*hold_booking.NewUsecase(bookingRepo, holdRepo, clientRepo, *checkAvailabilityUsecase, 10*time.Minute, db.NewSQLUnitOfWork(database), therapistRepo),
+		*confirm_booking_hold.NewUsecase(bookingRepo, holdRepo, transactions, nil),
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	// A Monday at least a week ahead, and the day after
+	monday := now.AddDate(0, 0, 7)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	tuesday := monday.AddDate(0, 0, 1)
+	post := func(path string, timeSlotID domain.TimeSlotID, day time.Time) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]any{
+			"therapistId":          therapistID,
+			"clientId":             clientID,
+			"timeSlotId":           timeSlotID,
+			"startTime":            time.Date(day.Year(), day.Month(), day.Day(), 9, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			"duration":             60,
+			"clientTimezoneOffset": 0,
+		})
+		req := httptest.NewRequest("POST", path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+	hold := func(t *testing.T, timeSlotID domain.TimeSlotID, day time.Time) hold_booking.Output {
+		rec := post("/api/v1/bookings/hold", timeSlotID, day)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+		var output hold_booking.Output
+		if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+			t.Fatalf("Failed to parse hold: %v", err)
+		}
+		if output.Token == "" || output.Booking.State != booking.BookingStateHeld {
+			t.Fatalf("Expected a held booking with a token, got %+v", output)
+		}
+		return output
+	}
+
+	t.Run("A hold blocks a competing booking, also once it is confirmed", func(t *testing.T) {
+		held := hold(t, mondaySlotID, monday)
+
+		rec := post("/api/v1/bookings", mondaySlotID, monday)
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("Expected the booking to conflict with the hold, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		rec = post("/api/v1/bookings/hold", mondaySlotID, monday)
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("Expected a second hold to conflict, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		req := httptest.NewRequest("POST", "/api/v1/bookings/hold/"+string(held.Token)+"/confirm", nil)
+		confirmRec := httptest.NewRecorder()
+		mux.ServeHTTP(confirmRec, req)
+		if confirmRec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, confirmRec.Code, confirmRec.Body.String())
+		}
+		confirmed, err := bookingRepo.GetByID(held.Booking.RegularBookingID)
+		if err != nil {
+			t.Fatalf("Failed to get booking: %v", err)
+		}
+		if confirmed.State != booking.BookingStateHeld {
+			t.Errorf("Expected the confirmed hold to stay held, got %s", confirmed.State)
+		}
+
+		// Once confirmed, the hold keeps blocking the slot without expiring
+		rec = post("/api/v1/bookings", mondaySlotID, monday)
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("Expected the booking to still conflict with the confirmed hold, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		output, err := releaseExpiredHolds.Execute(release_expired_holds.Input{Now: time.Now().Add(11 * time.Minute)})
+		if err != nil {
+			t.Fatalf("Failed to release expired holds: %v", err)
+		}
+		if output.Released != 0 {
+			t.Errorf("Expected a confirmed hold not to be released, got %d", output.Released)
+		}
+	})
+
+	t.Run("An expired hold is released", func(t *testing.T) {
+		held := hold(t, tuesdaySlotID, tuesday)
+
+		output, err := releaseExpiredHolds.Execute(release_expired_holds.Input{Now: time.Now().Add(11 * time.Minute)})
+		if err != nil {
+			t.Fatalf("Failed to release expired holds: %v", err)
+		}
+		if output.Released != 1 {
+			t.Errorf("Expected 1 released hold, got %d", output.Released)
+		}
+
+		released, err := bookingRepo.GetByID(held.Booking.RegularBookingID)
+		if err != nil {
+			t.Fatalf("Failed to get booking: %v", err)
+		}
+		if released.State != booking.BookingStateCancelled {
+			t.Errorf("Expected the released booking to be cancelled, got %s", released.State)
+		}
+
+		rec := post("/api/v1/bookings", tuesdaySlotID, tuesday)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected the released slot to be bookable, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		req := httptest.NewRequest("POST", "/api/v1/bookings/hold/"+string(held.Token)+"/confirm", nil)
+		confirmRec := httptest.NewRecorder()
+		mux.ServeHTTP(confirmRec, req)
+		if confirmRec.Code != http.StatusNotFound {
+			t.Errorf("Expected a released hold to be gone, got %d. Body: %s", confirmRec.Code, confirmRec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,287 @@
+package booking_handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestSearchBookingsByWindowAndState(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "booking_search_test_*.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	dbFilename := tmpfile.Name()
+
+	database := db.NewDatabase(db.DatabaseConfig{
+		DBFilename: dbFilename,
+		SchemaFile: "../../../schema.sql",
+	})
+	defer func() {
+		database.Close()
+		os.Remove(dbFilename)
+	}()
+
+	bookingRepo := booking_db.NewBookingRepository(database)
+	searchUsecase := search_bookings.NewUsecase(
+		bookingRepo,
+		adhoc_booking_db.NewAdhocBookingRepository(database),
+		therapist_db.NewTherapistRepository(database),
+		client_db.NewClientRepository(database),
+		"",
+	)
+	handler := NewBookingHandler(
+		create_booking.Usecase{},
+		create_adhoc_booking.Usecase{},
+		confirm_regular_booking.Usecase{},
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		*searchUsecase,
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	// Seed a therapist, client and time slot
+	now := time.Now().UTC()
+	therapistID := domain.NewTherapistID()
+	_, err = database.Exec(`
+		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, therapistID, "Dr. Search", "search@example.com", "+1234567890", "+1234567890", true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert therapist: %v", err)
+	}
+
+	clientID := domain.NewClientID()
+	_, err = database.Exec(`
+		INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, clientID, "Search Client", "+1234567891", 0, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert client: %v", err)
+	}
+
+	timeSlotID := domain.NewTimeSlotID()
+	_, err = database.Exec(`
+		INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, timeSlotID, therapistID, "Monday", "10:00", 60, 0, 0, true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert time slot: %v", err)
+	}
+
+	createdAt := domain.NewUTCTimestamp()
+	newBooking := func(state booking.BookingState, startTime time.Time) domain.BookingID {
+		id := domain.NewBookingID()
+		err := bookingRepo.Create(&booking.Booking{
+			ID:          id,
+			TimeSlotID:  timeSlotID,
+			TherapistID: therapistID,
+			ClientID:    clientID,
+			State:       state,
+			StartTime:   domain.UTCTimestamp(startTime),
+			Duration:    60,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+		return id
+	}
+
+	// Mondays in June 2025; created out of order to check sorting
+	laterConfirmed := newBooking(booking.BookingStateConfirmed, time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC))
+	earlierConfirmed := newBooking(booking.BookingStateConfirmed, time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC))
+	newBooking(booking.BookingStatePending, time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC))
+	newBooking(booking.BookingStateConfirmed, time.Date(2025, 7, 7, 10, 0, 0, 0, time.UTC))
+
+	search := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/bookings/search?"+query, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("returns only matching bookings ordered by start time", func(t *testing.T) {
+		rec := search("from=2025-06-01&to=2025-06-30&state=confirmed")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var response searchBookingsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		results := response.Bookings
+		if len(results) != 2 {
+			t.Fatalf("Expected 2 bookings, got %d: %+v", len(results), results)
+		}
+		if results[0].RegularBookingID != earlierConfirmed || results[1].RegularBookingID != laterConfirmed {
+			t.Errorf("Expected [%s %s], got [%s %s]", earlierConfirmed, laterConfirmed, results[0].RegularBookingID, results[1].RegularBookingID)
+		}
+		for _, result := range results {
+			if result.State != booking.BookingStateConfirmed {
+				t.Errorf("Expected state %s, got %s", booking.BookingStateConfirmed, result.State)
+			}
+		}
+	})
+
+	t.Run("pages through results with the next cursor", func(t *testing.T) {
+		seen := []domain.BookingID{}
+		query := "limit=1"
+		for range 10 {
+			rec := search(query)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			var response searchBookingsResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if len(response.Bookings) != 1 {
+				t.Fatalf("Expected 1 booking per page, got %d", len(response.Bookings))
+			}
+			seen = append(seen, response.Bookings[0].RegularBookingID)
+
+			if response.NextCursor == "" {
+				break
+			}
+			query = "limit=1&cursor=" + response.NextCursor
+		}
+
+		if len(seen) != 4 {
+			t.Fatalf("Expected 4 bookings across pages, got %d: %v", len(seen), seen)
+		}
+		if seen[2] != laterConfirmed {
+			t.Errorf("Expected %s on the third page, got %s", laterConfirmed, seen[2])
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		rec := search("limit=-1")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("invalid date format", func(t *testing.T) {
+		rec := search("from=06/01/2025")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("inverted range", func(t *testing.T) {
+		rec := search("from=2025-06-30&to=2025-06-01")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("admin listing spans all therapists", func(t *testing.T) {
+		otherTherapistID := domain.NewTherapistID()
+		_, err := database.Exec(`
+			INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, otherTherapistID, "Dr. Other", "other-search@example.com", "+1234567892", "+1234567892", true, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert therapist: %v", err)
+		}
+		otherTimeSlotID := domain.NewTimeSlotID()
+		_, err = database.Exec(`
+			INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, otherTimeSlotID, otherTherapistID, "Monday", "10:00", 60, 0, 0, true, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert time slot: %v", err)
+		}
+		otherBookingID := domain.NewBookingID()
+		err = bookingRepo.Create(&booking.Booking{
+			ID:          otherBookingID,
+			TimeSlotID:  otherTimeSlotID,
+			TherapistID: otherTherapistID,
+			ClientID:    clientID,
+			State:       booking.BookingStateConfirmed,
+			StartTime:   domain.UTCTimestamp(time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)),
+			Duration:    60,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+
+		req := httptest.NewRequest("GET", "/api/v1/admin/bookings?from=2025-06-02&to=2025-06-02&state=confirmed", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var response searchBookingsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		names := map[domain.BookingID]string{}
+		for _, result := range response.Bookings {
+			names[result.RegularBookingID] = result.TherapistName
+			if result.ClientName != "Search Client" {
+				t.Errorf("Expected client name %q, got %q", "Search Client", result.ClientName)
+			}
+		}
+		if len(response.Bookings) != 2 || names[earlierConfirmed] != "Dr. Search" || names[otherBookingID] != "Dr. Other" {
+			t.Errorf("Expected bookings of both therapists, got %+v", response.Bookings)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package booking_handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
+	"github.com/mishkahtherapy/brain/adapters/events/booking_events"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestStreamBookingEvents(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "booking_stream_test_*.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	dbFilename := tmpfile.Name()
+
+	database := db.NewDatabase(db.DatabaseConfig{
+		DBFilename: dbFilename,
+		SchemaFile: "../../../schema.sql",
+	})
+	defer func() {
+		database.Close()
+		os.Remove(dbFilename)
+	}()
+
+	// Seed a therapist with a Monday morning slot and a client
+	now := time.Now().UTC()
+	therapistID := domain.NewTherapistID()
+	clientID := domain.NewClientID()
+	timeSlotID := domain.NewTimeSlotID()
+	_, err = database.Exec(`
+		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, therapistID, "Dr. Live", "live@example.com", "+1555000600", "+1555000600", true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert therapist: %v", err)
+	}
+	_, err = database.Exec(`
+		INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, clientID, "Live Client", "+201001234568", 0, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert client: %v", err)
+	}
+	_, err = database.Exec(`
+		INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, timeSlotID, therapistID, "Monday", "09:00", 180, 0, 0, true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert time slot: %v", err)
+	}
+
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+	bookingRepo := booking_db.NewBookingRepository(database)
+	adhocBookingRepo := adhoc_booking_db.NewAdhocBookingRepository(database)
+	timeSlotRepo := timeslot_db.NewTimeSlotRepository(database)
+	getScheduleUsecase := get_schedule.NewUsecase(
+		therapistRepo,
+		timeSlotRepo,
+		bookingRepo,
+		adhocBookingRepo,
+		nil,
+		15,
+		nil,
+		0,
+	)
+	checkAvailabilityUsecase := check_availability.NewUsecase(bookingRepo, adhocBookingRepo, timeSlotRepo, *getScheduleUsecase)
+
+	bookingEvents := booking_events.NewBroker()
+	handler := NewBookingHandler(
+		*create_booking.NewUsecase(
+			bookingRepo,
+			therapistRepo,
+			client_db.NewClientRepository(database),
+			timeSlotRepo,
+			*checkAvailabilityUsecase,
+			bookingEvents,
+		),
+		create_adhoc_booking.Usecase{},
+		confirm_regular_booking.Usecase{},
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		*stream_booking_events.NewUsecase(therapistRepo, bookingEvents),
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	server := httptest.NewServer(mux)
+	// Close waits for the stream handler, so it also checks the handler
+	// returns once the client disconnects
+	defer server.Close()
+
+	t.Run("Unknown therapist", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/api/v1/therapists/unknown_therapist/bookings/stream")
+		if err != nil {
+			t.Fatalf("Failed to open stream: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, resp.StatusCode)
+		}
+	})
+
+	t.Run("Creating a booking emits an event", func(t *testing.T) {
+		ctx, disconnect := context.WithCancel(context.Background())
+		defer disconnect()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/v1/therapists/%s/bookings/stream", server.URL, therapistID), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to open stream: %v", err)
+		}
+		defer stream.Body.Close()
+		if stream.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, stream.StatusCode)
+		}
+		if contentType := stream.Header.Get("Content-Type"); contentType != "text/event-stream" {
+			t.Errorf("Expected content type text/event-stream, got %s", contentType)
+		}
+
+		// Read events in the background; the subscription exists once the
+		// headers are received
+		received := make(chan booking.Event, 1)
+		go func() {
+			scanner := bufio.NewScanner(stream.Body)
+			for scanner.Scan() {
+				data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+				if !ok {
+					continue
+				}
+				var event booking.Event
+				if err := json.Unmarshal([]byte(data), &event); err == nil {
+					received <- event
+					return
+				}
+			}
+		}()
+
+		// A Monday at least a week ahead, inside the slot
+		day := now.AddDate(0, 0, 7)
+		for day.Weekday() != time.Monday {
+			day = day.AddDate(0, 0, 1)
+		}
+		startTime := time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.UTC)
+		body, _ := json.Marshal(map[string]any{
+			"therapistId":          therapistID,
+			"clientId":             clientID,
+			"timeSlotId":           timeSlotID,
+			"startTime":            startTime.Format(time.RFC3339),
+			"duration":             60,
+			"clientTimezoneOffset": 0,
+		})
+		created, err := http.Post(server.URL+"/api/v1/bookings", "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+		var createdBooking struct {
+			RegularBookingID domain.BookingID `json:"regularBookingId"`
+		}
+		json.NewDecoder(created.Body).Decode(&createdBooking)
+		created.Body.Close()
+		if created.StatusCode != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d", http.StatusCreated, created.StatusCode)
+		}
+
+		select {
+		case event := <-received:
+			if event.Type != booking.EventTypeCreated {
+				t.Errorf("Expected event %s, got %s", booking.EventTypeCreated, event.Type)
+			}
+			if event.BookingID != createdBooking.RegularBookingID {
+				t.Errorf("Expected booking %s, got %s", createdBooking.RegularBookingID, event.BookingID)
+			}
+			if event.TherapistID != therapistID || event.State != booking.BookingStatePending {
+				t.Errorf("Expected a pending booking for %s, got %+v", therapistID, event)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the booking event")
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package booking_handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+)
+
+func TestCreateBookingRejectsTimeslotOfAnotherTherapist(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	repos := testutils.SetupRepositories(database)
+	bookedTherapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Booked")
+	otherTherapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Other")
+	otherTimeSlotID := testutils.CreateTestTimeSlot(t, database, otherTherapistID)
+	clientID := testutils.CreateTestClient(t, database)
+
+	handler := NewBookingHandler(
+		*create_booking.NewUsecase(repos.BookingRepo, repos.TherapistRepo, client_db.NewClientRepository(database), repos.TimeSlotRepo, check_availability.Usecase{}, nil),
+		create_adhoc_booking.Usecase{},
+		confirm_regular_booking.Usecase{},
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	rec := testutils.NewHTTPTestUtils(mux).MakeRequest(http.MethodPost, "/api/v1/bookings", map[string]any{
+		"therapistId":          bookedTherapistID,
+		"clientId":             clientID,
+		"timeSlotId":           otherTimeSlotID,
+		"startTime":            time.Now().UTC().AddDate(0, 0, 7).Format(time.RFC3339),
+		"duration":             60,
+		"clientTimezoneOffset": 0,
+	})
+	testutils.AssertErrorCode(t, rec, http.StatusBadRequest, "booking.timeslot_not_owned")
+
+	var count int
+	if err := database.QueryRow(`SELECT COUNT(*) FROM bookings`).Scan(&count); err != nil {
+		t.Fatalf("Failed to count bookings: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected no booking to be stored, got %d", count)
+	}
+}
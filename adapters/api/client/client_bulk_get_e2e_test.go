@@ -0,0 +1,117 @@
+package client_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/create_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_all_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_email"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_whatsapp"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_summary"
+	"github.com/mishkahtherapy/brain/core/usecases/client/merge_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_timezone"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestBulkGetClients(t *testing.T) {
+	database, cleanup := setupClientTestDB(t)
+	defer cleanup()
+
+	clientRepo := client_db.NewClientRepository(database)
+	clientHandler := NewClientHandler(
+		*create_client.NewUsecase(clientRepo),
+		*get_all_clients.NewUsecase(clientRepo),
+		*get_client.NewUsecase(clientRepo),
+		*get_client_by_whatsapp.NewUsecase(clientRepo),
+		*update_timezone.NewUsecase(clientRepo),
+		get_client_summary.Usecase{},
+		update_client.Usecase{},
+		get_client_by_email.Usecase{},
+		merge_clients.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	clientHandler.RegisterRoutes(mux)
+
+	createClient := func(name, number string) domain.ClientID {
+		body, _ := json.Marshal(map[string]interface{}{
+			"name":           name,
+			"whatsAppNumber": number,
+			"timezoneOffset": 0,
+		})
+		req := httptest.NewRequest("POST", "/api/v1/clients", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+
+		var created client.Client
+		if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+			t.Fatalf("Failed to parse created client: %v", err)
+		}
+		return created.ID
+	}
+
+	firstID := createClient("First Client", "+201001111111")
+	secondID := createClient("Second Client", "+201002222222")
+	createClient("Unrequested Client", "+201003333333")
+
+	bulkGet := func(ids ...domain.ClientID) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"ids": ids})
+		req := httptest.NewRequest("POST", "/api/v1/clients/bulk-get", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("returns the existing clients", func(t *testing.T) {
+		rec := bulkGet(firstID, domain.NewClientID(), secondID)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var clients []client.Client
+		if err := json.Unmarshal(rec.Body.Bytes(), &clients); err != nil {
+			t.Fatalf("Failed to parse clients: %v", err)
+		}
+		if len(clients) != 2 {
+			t.Fatalf("Expected 2 clients, got %d", len(clients))
+		}
+		found := map[domain.ClientID]bool{}
+		for _, c := range clients {
+			found[c.ID] = true
+		}
+		if !found[firstID] || !found[secondID] {
+			t.Errorf("Expected clients %s and %s, got %+v", firstID, secondID, clients)
+		}
+	})
+
+	t.Run("no client matches", func(t *testing.T) {
+		rec := bulkGet(domain.NewClientID())
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("missing ids", func(t *testing.T) {
+		rec := bulkGet()
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+}
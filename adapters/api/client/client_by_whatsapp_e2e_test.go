@@ -0,0 +1,129 @@
+package client_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api"
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/create_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_all_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_email"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_whatsapp"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_summary"
+	"github.com/mishkahtherapy/brain/core/usecases/client/merge_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_timezone"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestGetClientByWhatsApp(t *testing.T) {
+	database, cleanup := setupClientTestDB(t)
+	defer cleanup()
+
+	clientRepo := client_db.NewClientRepository(database)
+	clientHandler := NewClientHandler(
+		*create_client.NewUsecase(clientRepo),
+		*get_all_clients.NewUsecase(clientRepo),
+		*get_client.NewUsecase(clientRepo),
+		*get_client_by_whatsapp.NewUsecase(clientRepo),
+		*update_timezone.NewUsecase(clientRepo),
+		get_client_summary.Usecase{},
+		update_client.Usecase{},
+		get_client_by_email.Usecase{},
+		merge_clients.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	clientHandler.RegisterRoutes(mux)
+
+	// Create the client to look up
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":           "Returning Client",
+		"whatsAppNumber": "+201001234567",
+		"timezoneOffset": 120,
+	})
+	createReq := httptest.NewRequest("POST", "/api/v1/clients", bytes.NewBuffer(body))
+	createReq.Header.Set("Content-Type", "application/json")
+	createRec := httptest.NewRecorder()
+	mux.ServeHTTP(createRec, createReq)
+	if createRec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, createRec.Code, createRec.Body.String())
+	}
+
+	var created client.Client
+	if err := json.Unmarshal(createRec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse created client: %v", err)
+	}
+
+	getByNumber := func(number string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/clients/by-whatsapp?number="+url.QueryEscape(number), nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("exact number", func(t *testing.T) {
+		rec := getByNumber("+201001234567")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var found client.Client
+		if err := json.Unmarshal(rec.Body.Bytes(), &found); err != nil {
+			t.Fatalf("Failed to parse client: %v", err)
+		}
+		if found.ID != created.ID {
+			t.Errorf("Expected client %s, got %s", created.ID, found.ID)
+		}
+	})
+
+	t.Run("number with spaces is normalized", func(t *testing.T) {
+		rec := getByNumber("+20 100 123 4567")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var found client.Client
+		if err := json.Unmarshal(rec.Body.Bytes(), &found); err != nil {
+			t.Fatalf("Failed to parse client: %v", err)
+		}
+		if found.ID != created.ID {
+			t.Errorf("Expected client %s, got %s", created.ID, found.ID)
+		}
+	})
+
+	t.Run("unknown number", func(t *testing.T) {
+		rec := getByNumber("+19999999999")
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("invalid number", func(t *testing.T) {
+		rec := getByNumber("not-a-number")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("client of another clinic", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/clients/by-whatsapp?number="+url.QueryEscape("+201001234567"), nil)
+		req.Header.Set(api.ClinicHeader, "clinic_b")
+		rec := httptest.NewRecorder()
+		api.ClinicMiddleware()(mux).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+		}
+	})
+}
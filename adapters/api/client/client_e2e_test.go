@@ -8,6 +8,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
 	"github.com/mishkahtherapy/brain/adapters/db"
 	"github.com/mishkahtherapy/brain/adapters/db/client_db"
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -16,6 +17,12 @@ import (
 	"github.com/mishkahtherapy/brain/core/usecases/client/create_client"
 	"github.com/mishkahtherapy/brain/core/usecases/client/get_all_clients"
 	"github.com/mishkahtherapy/brain/core/usecases/client/get_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_email"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_whatsapp"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_summary"
+	"github.com/mishkahtherapy/brain/core/usecases/client/merge_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_timezone"
 
 	_ "github.com/glebarez/go-sqlite"
 )
@@ -32,9 +39,10 @@ func TestClientE2E(t *testing.T) {
 	createUsecase := create_client.NewUsecase(clientRepo)
 	getAllUsecase := get_all_clients.NewUsecase(clientRepo)
 	getUsecase := get_client.NewUsecase(clientRepo)
+	getByWhatsAppUsecase := get_client_by_whatsapp.NewUsecase(clientRepo)
 
 	// Setup handler
-	clientHandler := NewClientHandler(*createUsecase, *getAllUsecase, *getUsecase)
+	clientHandler := NewClientHandler(*createUsecase, *getAllUsecase, *getUsecase, *getByWhatsAppUsecase, *update_timezone.NewUsecase(clientRepo), get_client_summary.Usecase{}, update_client.Usecase{}, get_client_by_email.Usecase{}, merge_clients.Usecase{}, testutils.NewClinicGuard(database))
 
 	// Setup router
 	mux := http.NewServeMux()
@@ -65,6 +73,9 @@ func TestClientE2E(t *testing.T) {
 		if err := json.Unmarshal(createRec.Body.Bytes(), &createdClient); err != nil {
 			t.Fatalf("Failed to parse created client: %v", err)
 		}
+		if location := createRec.Header().Get("Location"); location != "/api/v1/clients/"+string(createdClient.ID) {
+			t.Errorf("Expected Location of the created client, got %q", location)
+		}
 
 		// Verify created client data
 		if createdClient.Name != "John Doe" {
@@ -0,0 +1,197 @@
+package client_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/create_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_all_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_email"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_whatsapp"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_summary"
+	"github.com/mishkahtherapy/brain/core/usecases/client/merge_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_timezone"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestClientEmail(t *testing.T) {
+	database, cleanup := setupClientTestDB(t)
+	defer cleanup()
+
+	clientRepo := client_db.NewClientRepository(database)
+	clientHandler := NewClientHandler(
+		*create_client.NewUsecase(clientRepo),
+		*get_all_clients.NewUsecase(clientRepo),
+		*get_client.NewUsecase(clientRepo),
+		*get_client_by_whatsapp.NewUsecase(clientRepo),
+		*update_timezone.NewUsecase(clientRepo),
+		get_client_summary.Usecase{},
+		*update_client.NewUsecase(clientRepo),
+		*get_client_by_email.NewUsecase(clientRepo),
+		merge_clients.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	clientHandler.RegisterRoutes(mux)
+
+	send := func(method, path string, body map[string]interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&payload).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	createRec := send("POST", "/api/v1/clients", map[string]interface{}{
+		"name":           "Email Only",
+		"email":          "Email.Only@Example.com",
+		"timezoneOffset": 120,
+	})
+	if createRec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, createRec.Code, createRec.Body.String())
+	}
+	var created client.Client
+	if err := json.Unmarshal(createRec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse created client: %v", err)
+	}
+	if created.Email != "email.only@example.com" || created.WhatsAppNumber != "" {
+		t.Errorf("Expected lowercased email and no WhatsApp number, got %q and %q", created.Email, created.WhatsAppNumber)
+	}
+
+	t.Run("fetch by email", func(t *testing.T) {
+		rec := send("GET", "/api/v1/clients/by-email?email="+url.QueryEscape("EMAIL.ONLY@example.com"), nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var found client.Client
+		if err := json.Unmarshal(rec.Body.Bytes(), &found); err != nil {
+			t.Fatalf("Failed to parse client: %v", err)
+		}
+		if found.ID != created.ID {
+			t.Errorf("Expected client %s, got %s", created.ID, found.ID)
+		}
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		rec := send("GET", "/api/v1/clients/by-email?email=nobody%40example.com", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("duplicate email conflicts", func(t *testing.T) {
+		rec := send("POST", "/api/v1/clients", map[string]interface{}{
+			"email":          "email.only@example.com",
+			"timezoneOffset": 0,
+		})
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("whatsapp or email is required", func(t *testing.T) {
+		rec := send("POST", "/api/v1/clients", map[string]interface{}{
+			"name":           "No Contact",
+			"timezoneOffset": 0,
+		})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		rec := send("POST", "/api/v1/clients", map[string]interface{}{
+			"email":          "not-an-email",
+			"timezoneOffset": 0,
+		})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("update adds a WhatsApp number and changes the email", func(t *testing.T) {
+		rec := send("PUT", "/api/v1/clients/"+string(created.ID), map[string]interface{}{
+			"name":           "Email Only",
+			"whatsAppNumber": "+20 100 123 4567",
+			"email":          "new@example.com",
+		})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		lookup := send("GET", "/api/v1/clients/by-email?email=new%40example.com", nil)
+		var found client.Client
+		if err := json.Unmarshal(lookup.Body.Bytes(), &found); err != nil {
+			t.Fatalf("Failed to parse client: %v", err)
+		}
+		if found.ID != created.ID || found.WhatsAppNumber != "+201001234567" {
+			t.Errorf("Expected client %s with +201001234567, got %s with %q", created.ID, found.ID, found.WhatsAppNumber)
+		}
+	})
+}
+
+func TestCreateClientReportsAllValidationErrors(t *testing.T) {
+	database, cleanup := setupClientTestDB(t)
+	defer cleanup()
+
+	clientRepo := client_db.NewClientRepository(database)
+	clientHandler := NewClientHandler(
+		*create_client.NewUsecase(clientRepo),
+		get_all_clients.Usecase{},
+		get_client.Usecase{},
+		get_client_by_whatsapp.Usecase{},
+		update_timezone.Usecase{},
+		get_client_summary.Usecase{},
+		update_client.Usecase{},
+		get_client_by_email.Usecase{},
+		merge_clients.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	clientHandler.RegisterRoutes(mux)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"whatsAppNumber": "not-a-number",
+		"email":          "not-an-email",
+		"timezoneOffset": 5000,
+	})
+	req := httptest.NewRequest("POST", "/api/v1/clients?validate=all", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	}
+
+	var response domain.ValidationErrors
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse validation errors: %v", err)
+	}
+	reported := make(map[string]bool)
+	for _, fieldErr := range response.Errors {
+		reported[fieldErr.Field] = true
+	}
+	for _, field := range []string{"whatsAppNumber", "email", "timezoneOffset"} {
+		if !reported[field] {
+			t.Errorf("Expected an error for %s, got %+v", field, response.Errors)
+		}
+	}
+}
@@ -2,14 +2,22 @@ package client_handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/mishkahtherapy/brain/adapters/api"
 	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
 	"github.com/mishkahtherapy/brain/core/usecases/client/create_client"
 	"github.com/mishkahtherapy/brain/core/usecases/client/get_all_clients"
 	"github.com/mishkahtherapy/brain/core/usecases/client/get_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_email"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_whatsapp"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_summary"
+	"github.com/mishkahtherapy/brain/core/usecases/client/merge_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_timezone"
 	"github.com/mishkahtherapy/brain/core/usecases/common"
 )
 
@@ -17,17 +25,40 @@ type ClientHandler struct {
 	createClientUsecase  create_client.Usecase
 	getClientUsecase     get_client.Usecase
 	getAllClientsUsecase get_all_clients.Usecase
+
+	getClientByWhatsAppUsecase get_client_by_whatsapp.Usecase
+	updateTimezoneUsecase      update_timezone.Usecase
+	getClientSummaryUsecase    get_client_summary.Usecase
+	updateClientUsecase        update_client.Usecase
+	getClientByEmailUsecase    get_client_by_email.Usecase
+	mergeClientsUsecase        merge_clients.Usecase
+	clinicGuard                *api.ClinicGuard
 }
 
 func NewClientHandler(
 	createUsecase create_client.Usecase,
 	getAllUsecase get_all_clients.Usecase,
 	getUsecase get_client.Usecase,
+	getByWhatsAppUsecase get_client_by_whatsapp.Usecase,
+	updateTimezoneUsecase update_timezone.Usecase,
+	getSummaryUsecase get_client_summary.Usecase,
+	updateUsecase update_client.Usecase,
+	getByEmailUsecase get_client_by_email.Usecase,
+	mergeUsecase merge_clients.Usecase,
+	clinicGuard *api.ClinicGuard,
 ) *ClientHandler {
 	return &ClientHandler{
 		createClientUsecase:  createUsecase,
 		getClientUsecase:     getUsecase,
 		getAllClientsUsecase: getAllUsecase,
+
+		getClientByWhatsAppUsecase: getByWhatsAppUsecase,
+		updateTimezoneUsecase:      updateTimezoneUsecase,
+		getClientSummaryUsecase:    getSummaryUsecase,
+		updateClientUsecase:        updateUsecase,
+		getClientByEmailUsecase:    getByEmailUsecase,
+		mergeClientsUsecase:        mergeUsecase,
+		clinicGuard:                clinicGuard,
 	}
 }
 
@@ -42,10 +73,17 @@ func (h *ClientHandler) SetUsecases(
 	h.getAllClientsUsecase = getAllUsecase
 }
 
-func (h *ClientHandler) RegisterRoutes(mux *http.ServeMux) {
+func (h *ClientHandler) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc("POST /api/v1/clients", h.handleCreateClient)
 	mux.HandleFunc("GET /api/v1/clients/search", h.handleSearchClients)
-	mux.HandleFunc("GET /api/v1/clients/{id}", h.handleGetClient)
+	mux.HandleFunc("POST /api/v1/clients/bulk-get", h.handleBulkGetClients)
+	mux.HandleFunc("GET /api/v1/clients/by-whatsapp", h.handleGetClientByWhatsApp)
+	mux.HandleFunc("GET /api/v1/clients/by-email", h.handleGetClientByEmail)
+	mux.HandleFunc("GET /api/v1/clients/{id}", h.clinicGuard.Client("id", h.handleGetClient))
+	mux.HandleFunc("PUT /api/v1/clients/{id}", h.clinicGuard.Client("id", h.handleUpdateClient))
+	mux.HandleFunc("PUT /api/v1/clients/{id}/timezone", h.clinicGuard.Client("id", h.handleUpdateClientTimezone))
+	mux.HandleFunc("GET /api/v1/clients/{id}/summary", h.clinicGuard.Client("id", h.handleGetClientSummary))
+	mux.HandleFunc("POST /api/v1/admin/clients/merge", h.handleMergeClients)
 }
 
 func (h *ClientHandler) handleCreateClient(w http.ResponseWriter, r *http.Request) {
@@ -56,16 +94,32 @@ func (h *ClientHandler) handleCreateClient(w http.ResponseWriter, r *http.Reques
 		rw.WriteBadRequest(err.Error())
 		return
 	}
+	input.ClinicID = api.ClinicFromContext(r.Context())
+
+	// With ?validate=all, report every invalid field together
+	if api.WantsAllValidationErrors(r) {
+		if err := h.createClientUsecase.Validate(input); err != nil {
+			var validationErrs *domain.ValidationErrors
+			if errors.As(err, &validationErrs) {
+				rw.WriteValidationErrors(validationErrs)
+			} else {
+				rw.WriteError(err, http.StatusInternalServerError)
+			}
+			return
+		}
+	}
 
-	client, err := h.createClientUsecase.Execute(input)
+	created, err := h.createClientUsecase.Execute(input)
 	if err != nil {
 		// Handle specific business logic errors
 		switch err {
-		case create_client.ErrWhatsAppNumberIsRequired,
-			create_client.ErrInvalidWhatsAppNumber,
+		case client.ErrClientContactIsRequired,
+			client.ErrClientInvalidWhatsAppNumber,
+			client.ErrClientInvalidEmail,
 			create_client.ErrInvalidTimezoneOffset:
 			rw.WriteBadRequest(err.Error())
-		case create_client.ErrClientAlreadyExists:
+		case client.ErrClientAlreadyExists,
+			client.ErrClientEmailAlreadyExists:
 			rw.WriteError(err, http.StatusConflict)
 		default:
 			rw.WriteError(err, http.StatusInternalServerError)
@@ -73,7 +127,7 @@ func (h *ClientHandler) handleCreateClient(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := rw.WriteJSON(client, http.StatusCreated); err != nil {
+	if err := rw.WriteCreatedJSON(created, "/api/v1/clients/"+string(created.ID)); err != nil {
 		rw.WriteError(err, http.StatusInternalServerError)
 	}
 }
@@ -109,6 +163,7 @@ func (h *ClientHandler) handleSearchClients(w http.ResponseWriter, r *http.Reque
 	}
 
 	clients, err := h.getAllClientsUsecase.Execute(get_all_clients.Input{
+		ClinicID: api.ClinicFromContext(r.Context()),
 		WhatsApp: domain.WhatsAppNumber(whatsApp),
 		Ids:      ids,
 	})
@@ -122,6 +177,111 @@ func (h *ClientHandler) handleSearchClients(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+func (h *ClientHandler) handleBulkGetClients(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	var requestBody struct {
+		IDs []domain.ClientID `json:"ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+
+	ids := make([]domain.ClientID, 0, len(requestBody.IDs))
+	for _, id := range requestBody.IDs {
+		if trimmedId := strings.TrimSpace(string(id)); trimmedId != "" {
+			ids = append(ids, domain.ClientID(trimmedId))
+		}
+	}
+	if len(ids) == 0 {
+		rw.WriteBadRequest("Missing client IDs")
+		return
+	}
+
+	clients, err := h.getClientUsecase.Execute(api.ClinicFromContext(r.Context()), ids)
+	if err != nil {
+		if err == common.ErrClientNotFound {
+			rw.WriteNotFound(err.Error())
+			return
+		}
+		if err == common.ErrClinicMismatch {
+			rw.WriteError(err, http.StatusForbidden)
+			return
+		}
+		rw.WriteError(err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := rw.WriteJSON(clients, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *ClientHandler) handleGetClientByWhatsApp(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	number := r.URL.Query().Get("number")
+	if number == "" {
+		rw.WriteBadRequest("Missing WhatsApp number")
+		return
+	}
+
+	client, err := h.getClientByWhatsAppUsecase.Execute(get_client_by_whatsapp.Input{
+		ClinicID:       api.ClinicFromContext(r.Context()),
+		WhatsAppNumber: domain.WhatsAppNumber(number),
+	})
+	if err != nil {
+		switch err {
+		case get_client_by_whatsapp.ErrInvalidWhatsAppNumber:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrClientNotFound:
+			rw.WriteNotFound(err.Error())
+		case common.ErrClinicMismatch:
+			rw.WriteError(err, http.StatusForbidden)
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(client, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *ClientHandler) handleGetClientByEmail(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	email := r.URL.Query().Get("email")
+	if email == "" {
+		rw.WriteBadRequest("Missing email")
+		return
+	}
+
+	found, err := h.getClientByEmailUsecase.Execute(get_client_by_email.Input{
+		ClinicID: api.ClinicFromContext(r.Context()),
+		Email:    domain.Email(email),
+	})
+	if err != nil {
+		switch err {
+		case client.ErrClientInvalidEmail:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrClientNotFound:
+			rw.WriteNotFound(err.Error())
+		case common.ErrClinicMismatch:
+			rw.WriteError(err, http.StatusForbidden)
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(found, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
 func (h *ClientHandler) handleGetClient(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
@@ -136,13 +296,97 @@ func (h *ClientHandler) handleGetClient(w http.ResponseWriter, r *http.Request)
 		ids[i] = domain.ClientID(id)
 	}
 
-	client, err := h.getClientUsecase.Execute(ids)
+	client, err := h.getClientUsecase.Execute(api.ClinicFromContext(r.Context()), ids)
 	if err != nil {
 		if err == common.ErrClientNotFound {
 			rw.WriteNotFound(err.Error())
 			return
 		}
+		if err == common.ErrClinicMismatch {
+			rw.WriteError(err, http.StatusForbidden)
+			return
+		}
+		rw.WriteError(err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := rw.WriteJSON(client, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *ClientHandler) handleUpdateClient(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read client id from path
+	clientID := domain.ClientID(r.PathValue("id"))
+	if clientID == "" {
+		rw.WriteBadRequest("Missing client ID")
+		return
+	}
+
+	var input update_client.Input
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+	input.ClientID = clientID
+
+	updated, err := h.updateClientUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case common.ErrClientIDIsRequired,
+			client.ErrClientContactIsRequired,
+			client.ErrClientInvalidWhatsAppNumber,
+			client.ErrClientInvalidEmail:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrClientNotFound:
+			rw.WriteNotFound(err.Error())
+		case client.ErrClientAlreadyExists,
+			client.ErrClientEmailAlreadyExists:
+			rw.WriteError(err, http.StatusConflict)
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(updated, http.StatusOK); err != nil {
 		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *ClientHandler) handleUpdateClientTimezone(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read client id from path
+	clientID := domain.ClientID(r.PathValue("id"))
+	if clientID == "" {
+		rw.WriteBadRequest("Missing client ID")
+		return
+	}
+
+	var requestBody struct {
+		TimezoneOffset domain.TimezoneOffset `json:"timezoneOffset"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+
+	client, err := h.updateTimezoneUsecase.Execute(update_timezone.Input{
+		ClientID:       clientID,
+		TimezoneOffset: requestBody.TimezoneOffset,
+	})
+	if err != nil {
+		switch err {
+		case update_timezone.ErrInvalidTimezoneOffset:
+			rw.WriteBadRequest(err.Error())
+		case update_timezone.ErrClientNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -150,3 +394,65 @@ func (h *ClientHandler) handleGetClient(w http.ResponseWriter, r *http.Request)
 		rw.WriteError(err, http.StatusInternalServerError)
 	}
 }
+
+func (h *ClientHandler) handleGetClientSummary(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read client id from path
+	clientID := domain.ClientID(r.PathValue("id"))
+	if clientID == "" {
+		rw.WriteBadRequest("Missing client ID")
+		return
+	}
+
+	summary, err := h.getClientSummaryUsecase.Execute(get_client_summary.Input{ClientID: clientID})
+	if err != nil {
+		switch err {
+		case common.ErrClientIDIsRequired:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrClientNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(summary, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+// handleMergeClients handles POST /api/v1/admin/clients/merge
+func (h *ClientHandler) handleMergeClients(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	var input merge_clients.Input
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+	input.ClinicID = api.ClinicFromContext(r.Context())
+
+	output, err := h.mergeClientsUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case common.ErrClientIDIsRequired,
+			merge_clients.ErrCannotMergeIntoSelf:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrClientNotFound:
+			rw.WriteNotFound(err.Error())
+		case common.ErrClinicMismatch:
+			rw.WriteError(err, http.StatusForbidden)
+		case client.ErrClientBookingsOverlap:
+			rw.WriteError(err, http.StatusConflict)
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(output, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
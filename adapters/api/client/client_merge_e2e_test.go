@@ -0,0 +1,186 @@
+package client_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/client/create_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_all_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_email"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_whatsapp"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_summary"
+	"github.com/mishkahtherapy/brain/core/usecases/client/merge_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_timezone"
+)
+
+func TestMergeClients(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	clientRepo := client_db.NewClientRepository(database)
+	clientHandler := NewClientHandler(
+		create_client.Usecase{},
+		get_all_clients.Usecase{},
+		*get_client.NewUsecase(clientRepo),
+		get_client_by_whatsapp.Usecase{},
+		update_timezone.Usecase{},
+		get_client_summary.Usecase{},
+		update_client.Usecase{},
+		get_client_by_email.Usecase{},
+		*merge_clients.NewUsecase(clientRepo, db.NewSQLUnitOfWork(database)),
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	clientHandler.RegisterRoutes(mux)
+
+	now := time.Now().UTC()
+	sourceID, targetID := domain.NewClientID(), domain.NewClientID()
+	for i, clientID := range []domain.ClientID{sourceID, targetID} {
+		_, err := database.Exec(`
+			INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, clientID, "Duplicate Client", []string{"+201001234560", "+201001234561"}[i], 0, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert client: %v", err)
+		}
+	}
+
+	// The source has a regular booking with its session and an adhoc booking
+	therapistID := testutils.CreateTestTherapist(t, database)
+	timeSlotID := testutils.CreateTestTimeSlot(t, database, therapistID)
+	bookingID := domain.NewBookingID()
+	_, err := database.Exec(`
+		INSERT INTO bookings (id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, bookingID, timeSlotID, therapistID, sourceID, now.AddDate(0, 0, 7), 60, 0, "confirmed", now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert booking: %v", err)
+	}
+	adhocBookingID := domain.NewAdhocBookingID()
+	_, err = database.Exec(`
+		INSERT INTO adhoc_bookings (id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, adhocBookingID, therapistID, sourceID, now.AddDate(0, 0, 8), 60, 0, "pending", now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert adhoc booking: %v", err)
+	}
+	sessionID := domain.NewSessionID()
+	_, err = database.Exec(`
+		INSERT INTO sessions (id, regular_booking_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, paid_amount, language, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, sessionID, bookingID, therapistID, sourceID, now.AddDate(0, 0, 7), 60, 0, 5000, "english", now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert session: %v", err)
+	}
+
+	merge := func(sourceID, targetID domain.ClientID) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]any{"sourceId": sourceID, "targetId": targetID})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/clients/merge", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Cannot merge a client into itself", func(t *testing.T) {
+		testutils.AssertStatus(t, merge(sourceID, sourceID), http.StatusBadRequest)
+	})
+
+	t.Run("Unknown client", func(t *testing.T) {
+		testutils.AssertStatus(t, merge("client_missing", targetID), http.StatusNotFound)
+	})
+
+	t.Run("Moves bookings and sessions to the target", func(t *testing.T) {
+		var output merge_clients.Output
+		testutils.AssertJSONResponse(t, merge(sourceID, targetID), http.StatusOK, &output)
+		if output.MovedBookings != 2 || output.MovedSessions != 1 {
+			t.Errorf("Expected 2 bookings and 1 session moved, got %+v", output)
+		}
+
+		for _, check := range []struct {
+			table string
+			id    string
+		}{
+			{"bookings", string(bookingID)},
+			{"adhoc_bookings", string(adhocBookingID)},
+			{"sessions", string(sessionID)},
+		} {
+			var clientID domain.ClientID
+			err := database.QueryRow("SELECT client_id FROM "+check.table+" WHERE id = ?", check.id).Scan(&clientID)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", check.table, err)
+			}
+			if clientID != targetID {
+				t.Errorf("Expected %s %s to belong to %s, got %s", check.table, check.id, targetID, clientID)
+			}
+		}
+
+		// The source is soft-deleted and no longer found
+		var deletedAt *time.Time
+		if err := database.QueryRow("SELECT deleted_at FROM clients WHERE id = ?", sourceID).Scan(&deletedAt); err != nil {
+			t.Fatalf("Failed to read the source client: %v", err)
+		}
+		if deletedAt == nil {
+			t.Error("Expected the source client to be soft-deleted")
+		}
+		testutils.AssertStatus(t, merge(sourceID, targetID), http.StatusNotFound)
+
+		// Its number is freed for a new client
+		_, err := database.Exec(`
+			INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, domain.NewClientID(), "New Client", "+201001234560", 0, now, now)
+		if err != nil {
+			t.Errorf("Expected the source's number to be free, got %v", err)
+		}
+	})
+
+	t.Run("Clients booked with a therapist at the same time conflict", func(t *testing.T) {
+		otherSourceID, otherTargetID := domain.NewClientID(), domain.NewClientID()
+		for i, clientID := range []domain.ClientID{otherSourceID, otherTargetID} {
+			_, err := database.Exec(`
+				INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+			`, clientID, "Double Booked Client", []string{"+201001234570", "+201001234571"}[i], 0, now, now)
+			if err != nil {
+				t.Fatalf("Failed to insert client: %v", err)
+			}
+			_, err = database.Exec(`
+				INSERT INTO bookings (id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, domain.NewBookingID(), timeSlotID, therapistID, clientID, now.AddDate(0, 0, 14), 60, 0, "confirmed", now, now)
+			if err != nil {
+				t.Fatalf("Failed to insert booking: %v", err)
+			}
+		}
+
+		testutils.AssertStatus(t, merge(otherSourceID, otherTargetID), http.StatusConflict)
+
+		// Nothing moved and the source is kept
+		var count int
+		if err := database.QueryRow("SELECT COUNT(*) FROM bookings WHERE client_id = ?", otherSourceID).Scan(&count); err != nil {
+			t.Fatalf("Failed to count bookings: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("Expected the source to keep its booking, got %d", count)
+		}
+		var deletedAt *time.Time
+		if err := database.QueryRow("SELECT deleted_at FROM clients WHERE id = ?", otherSourceID).Scan(&deletedAt); err != nil {
+			t.Fatalf("Failed to read the source client: %v", err)
+		}
+		if deletedAt != nil {
+			t.Error("Expected the source client to be kept")
+		}
+	})
+}
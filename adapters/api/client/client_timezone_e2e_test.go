@@ -0,0 +1,110 @@
+package client_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/create_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_all_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_email"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_whatsapp"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_summary"
+	"github.com/mishkahtherapy/brain/core/usecases/client/merge_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_timezone"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestUpdateClientTimezone(t *testing.T) {
+	database, cleanup := setupClientTestDB(t)
+	defer cleanup()
+
+	clientRepo := client_db.NewClientRepository(database)
+	clientHandler := NewClientHandler(
+		*create_client.NewUsecase(clientRepo),
+		*get_all_clients.NewUsecase(clientRepo),
+		*get_client.NewUsecase(clientRepo),
+		*get_client_by_whatsapp.NewUsecase(clientRepo),
+		*update_timezone.NewUsecase(clientRepo),
+		get_client_summary.Usecase{},
+		update_client.Usecase{},
+		get_client_by_email.Usecase{},
+		merge_clients.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	clientHandler.RegisterRoutes(mux)
+
+	const whatsAppNumber = "+201004444444"
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":           "Traveling Client",
+		"whatsAppNumber": whatsAppNumber,
+		"timezoneOffset": 120,
+	})
+	req := httptest.NewRequest("POST", "/api/v1/clients", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var created client.Client
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse created client: %v", err)
+	}
+
+	updateTimezone := func(id domain.ClientID, offset int) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"timezoneOffset": offset})
+		req := httptest.NewRequest("PUT", "/api/v1/clients/"+string(id)+"/timezone", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("updates and persists the offset", func(t *testing.T) {
+		rec := updateTimezone(created.ID, -300)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		req := httptest.NewRequest("GET", "/api/v1/clients/by-whatsapp?number="+url.QueryEscape(whatsAppNumber), nil)
+		getRec := httptest.NewRecorder()
+		mux.ServeHTTP(getRec, req)
+		if getRec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, getRec.Code, getRec.Body.String())
+		}
+		var fetched client.Client
+		if err := json.Unmarshal(getRec.Body.Bytes(), &fetched); err != nil {
+			t.Fatalf("Failed to parse client: %v", err)
+		}
+		if fetched.TimezoneOffset != -300 {
+			t.Errorf("Expected timezone offset -300, got %d", fetched.TimezoneOffset)
+		}
+	})
+
+	t.Run("invalid offset", func(t *testing.T) {
+		rec := updateTimezone(created.ID, 900)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("missing client", func(t *testing.T) {
+		rec := updateTimezone(domain.NewClientID(), 60)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/usecases/clinic/check_clinic"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// ClinicHeader names the clinic the request is scoped to
+const ClinicHeader = "X-Clinic-ID"
+
+type clinicContextKey struct{}
+
+// ClinicMiddleware stores the requested clinic in the request context so
+// handlers can scope their queries to it. Requests without the header are
+// rejected, except those matching one of publicPatterns, ServeMux patterns
+// for routes reached without the API client, such as health checks.
+func ClinicMiddleware(publicPatterns ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		public := http.NewServeMux()
+		for _, pattern := range publicPatterns {
+			public.Handle(pattern, next)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clinicID := domain.ClinicID(r.Header.Get(ClinicHeader))
+			if clinicID == "" {
+				if _, pattern := public.Handler(r); pattern != "" {
+					next.ServeHTTP(w, r)
+					return
+				}
+				NewResponseWriter(w).WriteBadRequest("Missing " + ClinicHeader + " header")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithClinic(r.Context(), clinicID)))
+		})
+	}
+}
+
+// WithClinic returns a copy of ctx scoped to clinicID
+func WithClinic(ctx context.Context, clinicID domain.ClinicID) context.Context {
+	return context.WithValue(ctx, clinicContextKey{}, clinicID)
+}
+
+// ClinicFromContext returns the clinic the request is scoped to, or the
+// default clinic when none was given, as on public routes
+func ClinicFromContext(ctx context.Context) domain.ClinicID {
+	clinicID, _ := ctx.Value(clinicContextKey{}).(domain.ClinicID)
+	return clinicID.OrDefault()
+}
+
+// ClinicGuard rejects requests naming records of another clinic with a 403.
+// Routes naming a record in their path wrap their handler at registration;
+// handlers check the records named in bodies and queries themselves.
+type ClinicGuard struct {
+	checkClinicUsecase check_clinic.Usecase
+}
+
+func NewClinicGuard(checkClinicUsecase check_clinic.Usecase) *ClinicGuard {
+	return &ClinicGuard{checkClinicUsecase: checkClinicUsecase}
+}
+
+// Check checks the records of input against the request's clinic. When one
+// belongs to another clinic, or the check fails, it writes the error response
+// and returns false.
+func (g *ClinicGuard) Check(rw *ResponseWriter, r *http.Request, input check_clinic.Input) bool {
+	input.ClinicID = ClinicFromContext(r.Context())
+	if err := g.checkClinicUsecase.Execute(input); err != nil {
+		if err == common.ErrClinicMismatch {
+			rw.WriteCodedError(err, http.StatusForbidden)
+		} else {
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return false
+	}
+	return true
+}
+
+// Therapist guards a route naming a therapist by the path parameter param
+func (g *ClinicGuard) Therapist(param string, next http.HandlerFunc) http.HandlerFunc {
+	return g.guard(next, func(r *http.Request) check_clinic.Input {
+		return check_clinic.Input{TherapistIDs: []domain.TherapistID{domain.TherapistID(r.PathValue(param))}}
+	})
+}
+
+// Client guards a route naming a client by the path parameter param
+func (g *ClinicGuard) Client(param string, next http.HandlerFunc) http.HandlerFunc {
+	return g.guard(next, func(r *http.Request) check_clinic.Input {
+		return check_clinic.Input{ClientIDs: []domain.ClientID{domain.ClientID(r.PathValue(param))}}
+	})
+}
+
+// Specialization guards a route naming a specialization by the path
+// parameter param
+func (g *ClinicGuard) Specialization(param string, next http.HandlerFunc) http.HandlerFunc {
+	return g.guard(next, func(r *http.Request) check_clinic.Input {
+		return check_clinic.Input{SpecializationIDs: []domain.SpecializationID{domain.SpecializationID(r.PathValue(param))}}
+	})
+}
+
+// Booking guards a route naming a regular or adhoc booking by the path
+// parameter param. Ids of neither type are left for the handler to reject.
+func (g *ClinicGuard) Booking(param string, next http.HandlerFunc) http.HandlerFunc {
+	return g.guard(next, func(r *http.Request) check_clinic.Input {
+		id := r.PathValue(param)
+		bookingType, err := booking.GetType(id)
+		if err != nil {
+			return check_clinic.Input{}
+		}
+		if bookingType == booking.BookingTypeRegular {
+			return check_clinic.Input{BookingIDs: []domain.BookingID{domain.BookingID(id)}}
+		}
+		return check_clinic.Input{AdhocBookingIDs: []domain.AdhocBookingID{domain.AdhocBookingID(id)}}
+	})
+}
+
+// Hold guards a route naming a booking hold by the path parameter param
+func (g *ClinicGuard) Hold(param string, next http.HandlerFunc) http.HandlerFunc {
+	return g.guard(next, func(r *http.Request) check_clinic.Input {
+		return check_clinic.Input{HoldTokens: []domain.HoldToken{domain.HoldToken(r.PathValue(param))}}
+	})
+}
+
+// Session guards a route naming a session by the path parameter param
+func (g *ClinicGuard) Session(param string, next http.HandlerFunc) http.HandlerFunc {
+	return g.guard(next, func(r *http.Request) check_clinic.Input {
+		return check_clinic.Input{SessionIDs: []domain.SessionID{domain.SessionID(r.PathValue(param))}}
+	})
+}
+
+func (g *ClinicGuard) guard(next http.HandlerFunc, records func(r *http.Request) check_clinic.Input) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !g.Check(NewResponseWriter(w), r, records(r)) {
+			return
+		}
+		next(w, r)
+	}
+}
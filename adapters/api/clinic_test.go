@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+func TestClinicMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		header         string
+		expectedStatus int
+		expected       domain.ClinicID
+	}{
+		{
+			name:           "header sets the clinic",
+			path:           "/api/v1/therapists",
+			header:         "clinic_a",
+			expectedStatus: http.StatusOK,
+			expected:       "clinic_a",
+		},
+		{
+			name:           "missing header falls back to the default clinic on public routes",
+			path:           "/health",
+			expectedStatus: http.StatusOK,
+			expected:       domain.DefaultClinicID,
+		},
+		{
+			name:           "missing header is rejected",
+			path:           "/api/v1/therapists",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var clinicID domain.ClinicID
+			handler := ClinicMiddleware("GET /health")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				clinicID = ClinicFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			if test.header != "" {
+				req.Header.Set(ClinicHeader, test.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d", test.expectedStatus, rec.Code)
+			}
+			if clinicID != test.expected {
+				t.Errorf("expected clinic %q, got %q", test.expected, clinicID)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// ErrorCode is a stable, machine-readable identifier for an API error.
+// Clients should branch on codes instead of parsing error messages.
+type ErrorCode string
+
+// Generic codes used when an error has no dedicated code
+const (
+	ErrorCodeInvalidRequest   ErrorCode = "request.invalid"
+	ErrorCodeNotFound         ErrorCode = "resource.not_found"
+	ErrorCodeConflict         ErrorCode = "resource.conflict"
+	ErrorCodeMethodNotAllowed ErrorCode = "request.method_not_allowed"
+	ErrorCodeInternal         ErrorCode = "internal"
+)
+
+var domainErrorCodes = map[error]ErrorCode{
+	// Timeslot errors
+	timeslot.ErrTimeslotIDIsRequired:          "timeslot.id_required",
+	timeslot.ErrDayOfWeekIsRequired:           "timeslot.day_of_week_required",
+	timeslot.ErrStartTimeIsRequired:           "timeslot.start_time_required",
+	timeslot.ErrEndTimeIsRequired:             "timeslot.end_time_required",
+	timeslot.ErrDurationIsRequired:            "timeslot.duration_required",
+	timeslot.ErrTherapistIDRequired:           "therapist.id_required",
+	timeslot.ErrTimezoneOffsetRequired:        "timeslot.timezone_offset_required",
+	timeslot.ErrTherapistNotFound:             "therapist.not_found",
+	timeslot.ErrTimeslotNotFound:              "timeslot.not_found",
+	timeslot.ErrTimeslotNotOwned:              "timeslot.not_owned",
+	timeslot.ErrInvalidDayOfWeek:              "timeslot.invalid_day_of_week",
+	timeslot.ErrInvalidTimeFormat:             "timeslot.invalid_time_format",
+	timeslot.ErrInvalidTimeRange:              "timeslot.invalid_time_range",
+	timeslot.ErrInvalidDuration:               "timeslot.invalid_duration",
+	timeslot.ErrPreSessionBufferNegative:      "timeslot.pre_session_buffer_negative",
+	timeslot.ErrPostSessionBufferTooLow:       "timeslot.post_session_buffer_too_low",
+	timeslot.ErrSessionDoesNotFitSlot:         "timeslot.session_does_not_fit",
+	timeslot.ErrOverlappingTimeslot:           "timeslot.overlapping",
+	timeslot.ErrOverlappingBooking:            "booking.overlapping",
+	timeslot.ErrBookingShouldBeMadeInTimeslot: "booking.should_be_made_in_timeslot",
+	timeslot.ErrInsufficientGapBetweenSlots:   "timeslot.insufficient_gap",
+	timeslot.ErrOutsideClinicHours:            "timeslot.outside_clinic_hours",
+	timeslot.ErrInvalidCapacity:               "timeslot.invalid_capacity",
+	timeslot.ErrInvalidTimezoneOffset:         "timeslot.invalid_timezone_offset",
+	timeslot.ErrInvalidTimezoneName:           "timeslot.invalid_timezone",
+	timeslot.ErrTimeslotHasActiveBookings:     "timeslot.has_active_bookings",
+	timeslot.ErrTemplateHasMixedTimezones:     "timeslot.template_mixed_timezones",
+	timeslot.ErrTemplateIsEmpty:               "timeslot.template_empty",
+	timeslot.ErrRecurringBlockIDIsRequired:    "recurring_block.id_required",
+	timeslot.ErrRecurringBlockNotFound:        "recurring_block.not_found",
+	timeslot.ErrRecurringBlockNotOwned:        "recurring_block.not_owned",
+
+	// Booking errors
+	booking.ErrBookingAlreadyConfirmed:   "booking.already_confirmed",
+	booking.ErrFailedToCreateSession:     "session.create_failed",
+	booking.ErrSpecializationMismatch:    "booking.specialization_mismatch",
+	booking.ErrFailedToReassign:          "booking.reassign_failed",
+	booking.ErrOutsideTimeSlot:           "booking.outside_timeslot",
+	booking.ErrTimeSlotNotOwned:          "booking.timeslot_not_owned",
+	booking.ErrDailySessionLimitReached:  "booking.daily_session_limit_reached",
+	booking.ErrFailedToUpdateDuration:    "booking.update_duration_failed",
+	booking.ErrReactivationWindowExpired: "booking.reactivation_window_expired",
+	booking.ErrWithinCancellationCutoff:  "booking.within_cancellation_cutoff",
+	booking.ErrFailedToReactivate:        "booking.reactivate_failed",
+	booking.ErrHoldNotFound:              "booking.hold_not_found",
+	booking.ErrHoldExpired:               "booking.hold_expired",
+	booking.ErrFailedToHold:              "booking.hold_failed",
+	booking.ErrTherapistHasNoDevice:      "therapist.no_device",
+
+	// Notification errors
+	ports.ErrNotificationFailed: "notification.failed",
+
+	// Timezone errors
+	domain.ErrTimezoneIsRequired: "timezone.required",
+	domain.ErrInvalidTimezone:    "timezone.invalid",
+
+	// Shared usecase errors
+	common.ErrBookingNotFound:        "booking.not_found",
+	common.ErrTherapistNotFound:      "therapist.not_found",
+	common.ErrClientNotFound:         "client.not_found",
+	common.ErrTimeSlotNotFound:       "timeslot.not_found",
+	common.ErrSessionNotFound:        "session.not_found",
+	common.ErrFailedToCreateBooking:  "booking.create_failed",
+	common.ErrFailedToCancelBooking:  "booking.cancel_failed",
+	common.ErrFailedToConfirmBooking: "booking.confirm_failed",
+	common.ErrFailedToListBookings:   "booking.list_failed",
+	common.ErrInvalidStateTransition: "booking.invalid_state_transition",
+	common.ErrInvalidBookingState:    "booking.invalid_state",
+	common.ErrTimeSlotAlreadyBooked:  "booking.timeslot_already_booked",
+	common.ErrInvalidBookingTime:     "booking.invalid_time",
+	common.ErrInvalidDateRange:       "request.invalid_date_range",
+	common.ErrBookingIDIsRequired:    "booking.id_required",
+	common.ErrTherapistIDIsRequired:  "therapist.id_required",
+	common.ErrClientIDIsRequired:     "client.id_required",
+	common.ErrTimeSlotIDIsRequired:   "timeslot.id_required",
+	common.ErrStartTimeIsRequired:    "booking.start_time_required",
+	common.ErrDurationIsRequired:     "booking.duration_required",
+	common.ErrPaidAmountIsRequired:   "booking.paid_amount_required",
+	common.ErrPaidAmountOutOfRange:   "booking.paid_amount_out_of_range",
+	common.ErrLanguageIsRequired:     "booking.language_required",
+	common.ErrInvalidCurrency:        "booking.invalid_currency",
+	common.ErrClinicMismatch:         "clinic.mismatch",
+}
+
+// CodeForError returns the code registered for err. Errors without a
+// dedicated code fall back to a generic code derived from the status.
+func CodeForError(err error, statusCode int) ErrorCode {
+	if code, ok := domainErrorCodes[err]; ok {
+		return code
+	}
+	return codeForStatus(statusCode)
+}
+
+func codeForStatus(statusCode int) ErrorCode {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case statusCode == http.StatusConflict:
+		return ErrorCodeConflict
+	case statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+		return ErrorCodeInvalidRequest
+	default:
+		return ErrorCodeInternal
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+func TestWriteCodedError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		status       int
+		expectedCode ErrorCode
+	}{
+		{
+			name:         "overlapping timeslot",
+			err:          timeslot.ErrOverlappingTimeslot,
+			status:       http.StatusConflict,
+			expectedCode: "timeslot.overlapping",
+		},
+		{
+			name:         "timeslot not found",
+			err:          timeslot.ErrTimeslotNotFound,
+			status:       http.StatusNotFound,
+			expectedCode: "timeslot.not_found",
+		},
+		{
+			name:         "booking not found",
+			err:          common.ErrBookingNotFound,
+			status:       http.StatusNotFound,
+			expectedCode: "booking.not_found",
+		},
+		{
+			name:         "unregistered error falls back to status code",
+			err:          errors.New("something unexpected"),
+			status:       http.StatusInternalServerError,
+			expectedCode: ErrorCodeInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewResponseWriter(rec).WriteCodedError(tc.err, tc.status)
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, rec.Code)
+			}
+
+			var response struct {
+				Error struct {
+					Code    ErrorCode `json:"code"`
+					Message string    `json:"message"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to parse response: %v. Body: %s", err, rec.Body.String())
+			}
+
+			if response.Error.Code != tc.expectedCode {
+				t.Errorf("expected code %s, got %s", tc.expectedCode, response.Error.Code)
+			}
+			if response.Error.Message != tc.err.Error() {
+				t.Errorf("expected message %q, got %q", tc.err.Error(), response.Error.Message)
+			}
+		})
+	}
+}
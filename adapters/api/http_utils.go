@@ -3,6 +3,8 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/mishkahtherapy/brain/core/domain"
 )
 
 // ResponseWriter wraps common HTTP response writing operations
@@ -14,6 +16,16 @@ type errorResponse struct {
 	Error string `json:"error"`
 }
 
+type codedErrorResponse struct {
+	Error codedError `json:"error"`
+}
+
+type codedError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	Details any       `json:"details,omitempty"`
+}
+
 // NewResponseWriter creates a new ResponseWriter
 func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
 	return &ResponseWriter{w: w}
@@ -40,6 +52,46 @@ func (rw *ResponseWriter) WriteErrorMessage(message string, statusCode int) {
 	json.NewEncoder(rw.w).Encode(errorResponse{Error: message})
 }
 
+// WriteCodedError writes an error envelope {error: {code, message}} for err,
+// using the code registered for the error value
+func (rw *ResponseWriter) WriteCodedError(err error, statusCode int) {
+	rw.WriteCodedErrorMessage(CodeForError(err, statusCode), err.Error(), statusCode)
+}
+
+// WriteCodedErrorMessage writes an error envelope with an explicit code and message
+func (rw *ResponseWriter) WriteCodedErrorMessage(code ErrorCode, message string, statusCode int) {
+	rw.w.Header().Set("Content-Type", "application/json")
+	rw.w.WriteHeader(statusCode)
+	json.NewEncoder(rw.w).Encode(codedErrorResponse{Error: codedError{Code: code, Message: message}})
+}
+
+// WriteCodedErrorWithDetails writes an error envelope for err carrying extra
+// details, e.g. the conflicting resources of a 409
+func (rw *ResponseWriter) WriteCodedErrorWithDetails(err error, details any, statusCode int) {
+	rw.w.Header().Set("Content-Type", "application/json")
+	rw.w.WriteHeader(statusCode)
+	json.NewEncoder(rw.w).Encode(codedErrorResponse{Error: codedError{
+		Code:    CodeForError(err, statusCode),
+		Message: err.Error(),
+		Details: details,
+	}})
+}
+
+// WriteValidationErrors writes a 422 Unprocessable Entity response listing
+// every invalid field as {errors: [{field, message}]}
+func (rw *ResponseWriter) WriteValidationErrors(errs *domain.ValidationErrors) {
+	rw.w.Header().Set("Content-Type", "application/json")
+	rw.w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(rw.w).Encode(errs)
+}
+
+// WriteCreatedJSON writes a 201 Created JSON response with a Location header
+// pointing at the created resource, e.g. /api/v1/clients/{id}
+func (rw *ResponseWriter) WriteCreatedJSON(data any, location string) error {
+	rw.w.Header().Set("Location", location)
+	return rw.WriteJSON(data, http.StatusCreated)
+}
+
 // WriteCreated writes a 201 Created response
 func (rw *ResponseWriter) WriteCreated() {
 	rw.w.WriteHeader(http.StatusCreated)
@@ -67,3 +119,18 @@ func (rw *ResponseWriter) WriteNotFound(message string) {
 	rw.w.WriteHeader(http.StatusNotFound)
 	json.NewEncoder(rw.w).Encode(errorResponse{Error: message})
 }
+
+// DecodeJSON decodes the request body into v. Fields v does not declare are
+// rejected, so a misspelled field fails instead of being silently dropped.
+func DecodeJSON(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// WantsAllValidationErrors reports whether the request asked, with
+// ?validate=all, for every invalid field to be reported at once rather than
+// only the first.
+func WantsAllValidationErrors(r *http.Request) bool {
+	return r.URL.Query().Get("validate") == "all"
+}
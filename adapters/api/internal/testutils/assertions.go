@@ -82,3 +82,23 @@ func AssertFieldExists(t *testing.T, data map[string]interface{}, fieldName stri
 		t.Errorf("Expected field %s to exist in response", fieldName)
 	}
 }
+
+// AssertErrorCode verifies response contains a structured error with the expected code
+func AssertErrorCode(t *testing.T, rec *httptest.ResponseRecorder, expectedStatus int, expectedCode string) {
+	t.Helper()
+	AssertStatus(t, rec, expectedStatus)
+
+	var errorResponse struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+		t.Fatalf("Failed to parse error response JSON: %v. Body: %s", err, rec.Body.String())
+	}
+
+	if errorResponse.Error.Code != expectedCode {
+		t.Errorf("Expected error code %s, got %s. Body: %s", expectedCode, errorResponse.Error.Code, rec.Body.String())
+	}
+}
@@ -45,9 +45,9 @@ func CreateTestClientWithName(t *testing.T, database ports.SQLDatabase, name str
 	clientID := domain.NewClientID()
 
 	_, err := database.Exec(`
-		INSERT INTO clients (id, name, whatsapp_number, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?)
-	`, clientID, name, "+1234567891", now, now)
+		INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, clientID, name, "+1234567891", 0, now, now)
 
 	if err != nil {
 		t.Fatalf("Failed to insert test client: %v", err)
@@ -1,10 +1,14 @@
 package testutils
 
 import (
+	"github.com/mishkahtherapy/brain/adapters/api"
+	"github.com/mishkahtherapy/brain/adapters/db"
 	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/clinic_db"
 	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
 	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
 	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/clinic/check_clinic"
 )
 
 // RepositorySet contains commonly used repositories
@@ -14,6 +18,7 @@ type RepositorySet struct {
 	BookingRepo   ports.BookingRepository
 	ClientRepo    ports.ClientRepository
 	SessionRepo   ports.SessionRepository
+	Transactions  ports.TransactionPort
 }
 
 // SetupRepositories creates standard repositories plus test repositories for missing ones
@@ -24,5 +29,11 @@ func SetupRepositories(database ports.SQLDatabase) *RepositorySet {
 		BookingRepo:   booking_db.NewBookingRepository(database),
 		ClientRepo:    NewTestClientRepository(database),
 		SessionRepo:   NewTestSessionRepository(database),
+		Transactions:  db.NewSQLTransactionRepo(database),
 	}
 }
+
+// NewClinicGuard creates the guard handlers check requested records with
+func NewClinicGuard(database ports.SQLDatabase) *api.ClinicGuard {
+	return api.NewClinicGuard(*check_clinic.NewUsecase(clinic_db.NewClinicRepository(database)))
+}
@@ -1,6 +1,8 @@
 package testutils
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -18,7 +20,7 @@ func NewTestSessionRepository(db ports.SQLDatabase) ports.SessionRepository {
 	return &TestSessionRepository{db: db}
 }
 
-func (r *TestSessionRepository) CreateSession(session *domain.Session) error {
+func (r *TestSessionRepository) CreateSession(tx ports.SQLTx, session *domain.Session) error {
 	return nil // Just return success for test
 }
 
@@ -26,6 +28,14 @@ func (r *TestSessionRepository) GetSessionByID(id domain.SessionID) (*domain.Ses
 	return nil, nil
 }
 
+func (r *TestSessionRepository) GetSessionByRegularBookingID(bookingID domain.BookingID) (*domain.Session, error) {
+	return nil, nil
+}
+
+func (r *TestSessionRepository) GetSessionByAdhocBookingID(bookingID domain.AdhocBookingID) (*domain.Session, error) {
+	return nil, nil
+}
+
 func (r *TestSessionRepository) UpdateSessionState(id domain.SessionID, state domain.SessionState) error {
 	return nil
 }
@@ -38,7 +48,7 @@ func (r *TestSessionRepository) UpdateMeetingURL(id domain.SessionID, meetingURL
 	return nil
 }
 
-func (r *TestSessionRepository) ListSessionsByTherapist(therapistID domain.TherapistID) ([]*domain.Session, error) {
+func (r *TestSessionRepository) ListSessionsByTherapist(therapistID domain.TherapistID, states []domain.SessionState) ([]*domain.Session, error) {
 	return nil, nil
 }
 
@@ -46,10 +56,26 @@ func (r *TestSessionRepository) ListSessionsByClient(clientID domain.ClientID) (
 	return nil, nil
 }
 
-func (r *TestSessionRepository) ListSessionsAdmin(startDate, endDate time.Time) ([]*domain.Session, error) {
+func (r *TestSessionRepository) ListSessionsAdmin(clinicID domain.ClinicID, startDate, endDate, updatedFrom, updatedTo time.Time) ([]*domain.Session, error) {
 	return nil, nil
 }
 
+func (r *TestSessionRepository) ListMissingMeetingURL(clinicID domain.ClinicID, startDate, endDate time.Time) ([]*domain.Session, error) {
+	return nil, nil
+}
+
+func (r *TestSessionRepository) CancelPlannedByBookingIDs(tx ports.SQLTx, bookingIDs []domain.BookingID, adhocBookingIDs []domain.AdhocBookingID, updatedAt time.Time) error {
+	return nil
+}
+
+func (r *TestSessionRepository) ListPlannedStartedBefore(before time.Time) ([]*domain.Session, error) {
+	return nil, nil
+}
+
+func (r *TestSessionRepository) MarkNoShow(ids []domain.SessionID, updatedAt time.Time) (int, error) {
+	return 0, nil
+}
+
 // TestClientRepository is a minimal test implementation that can read clients
 type TestClientRepository struct {
 	db ports.SQLDatabase
@@ -59,9 +85,19 @@ func NewTestClientRepository(db ports.SQLDatabase) ports.ClientRepository {
 	return &TestClientRepository{db: db}
 }
 
-func (r *TestClientRepository) BulkGetByID(ids []domain.ClientID) ([]*client.Client, error) {
-	query := `SELECT id, name, whatsapp_number, created_at, updated_at FROM clients WHERE id IN (?)`
-	rows, err := r.db.Query(query, ids)
+func (r *TestClientRepository) FindByIDs(ids []domain.ClientID) ([]*client.Client, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+
+	query := fmt.Sprintf(`SELECT id, name, whatsapp_number, created_at, updated_at FROM clients WHERE id IN (%s)`, placeholders)
+	rows, err := r.db.Query(query, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,10 +121,28 @@ func (r *TestClientRepository) Delete(id domain.ClientID) error    { return nil
 func (r *TestClientRepository) UpdateTimezoneOffset(id domain.ClientID, offsetMinutes domain.TimezoneOffset) error {
 	return nil
 }
+
+func (r *TestClientRepository) GetSummary(id domain.ClientID, now time.Time) (*client.Summary, error) {
+	return nil, nil
+}
+func (r *TestClientRepository) MoveBookingsTx(sqlExec ports.SQLExec, from, to domain.ClientID) (int, error) {
+	return 0, nil
+}
+func (r *TestClientRepository) MoveSessionsTx(sqlExec ports.SQLExec, from, to domain.ClientID) (int, error) {
+	return 0, nil
+}
+func (r *TestClientRepository) SoftDeleteTx(sqlExec ports.SQLExec, id domain.ClientID, deletedAt time.Time) error {
+	return nil
+}
 func (r *TestClientRepository) GetByWhatsAppNumber(whatsappNumber domain.WhatsAppNumber) (*client.Client, error) {
 	return nil, nil
 }
-func (r *TestClientRepository) List() ([]*client.Client, error) { return nil, nil }
+func (r *TestClientRepository) GetByEmail(email domain.Email) (*client.Client, error) {
+	return nil, nil
+}
+func (r *TestClientRepository) List(clinicID domain.ClinicID) ([]*client.Client, error) {
+	return nil, nil
+}
 
 // TestTimeSlotRepository is a minimal test implementation that can read timeslots
 type TestTimeSlotRepository struct {
@@ -112,15 +166,30 @@ func (r *TestTimeSlotRepository) GetByID(id domain.TimeSlotID) (*timeslot.TimeSl
 }
 
 func (r *TestTimeSlotRepository) Create(timeslot *timeslot.TimeSlot) error { return nil }
+func (r *TestTimeSlotRepository) CreateTx(sqlExec ports.SQLExec, timeslot *timeslot.TimeSlot) error {
+	return nil
+}
 func (r *TestTimeSlotRepository) Update(timeslot *timeslot.TimeSlot) error { return nil }
 func (r *TestTimeSlotRepository) Delete(id domain.TimeSlotID) error        { return nil }
+func (r *TestTimeSlotRepository) BulkDeleteTx(sqlExec ports.SQLExec, ids []domain.TimeSlotID) error {
+	return nil
+}
+func (r *TestTimeSlotRepository) ListActiveBookingIDsTx(sqlExec ports.SQLExec, id domain.TimeSlotID, after time.Time) ([]domain.BookingID, error) {
+	return nil, nil
+}
 func (r *TestTimeSlotRepository) ListByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error) {
 	return nil, nil
 }
+func (r *TestTimeSlotRepository) ListActiveByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error) {
+	return nil, nil
+}
 func (r *TestTimeSlotRepository) BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.TimeSlot, error) {
 	return nil, nil
 }
 
-func (r *TestTimeSlotRepository) BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) error {
+func (r *TestTimeSlotRepository) BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) (int, error) {
+	return 0, nil
+}
+func (r *TestTimeSlotRepository) SetActive(id domain.TimeSlotID, isActive bool) error {
 	return nil
 }
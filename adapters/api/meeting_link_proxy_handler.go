@@ -26,10 +26,18 @@ func (h *MeetingLinkProxyHandler) SetUsecases(getMeetingLinkUsecase get_meeting_
 }
 
 // RegisterRoutes registers all the routes handled by the MeetingLinkProxyHandler
-func (h *MeetingLinkProxyHandler) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /api/v1/sessions/{id}/meeting", h.handleGetMeetingLink)
+func (h *MeetingLinkProxyHandler) RegisterRoutes(mux Router) {
+	mux.HandleFunc(meetingLinkRoute, h.handleGetMeetingLink)
 }
 
+// PublicRoutes lists the routes opened from links sent to clients, which
+// come without a clinic header
+func (h *MeetingLinkProxyHandler) PublicRoutes() []string {
+	return []string{meetingLinkRoute}
+}
+
+const meetingLinkRoute = "GET /api/v1/sessions/{id}/meeting"
+
 // handleGetMeetingLink redirects to the meeting URL for a session
 // This handler supports safe redirection to video conferencing links
 func (h *MeetingLinkProxyHandler) handleGetMeetingLink(w http.ResponseWriter, r *http.Request) {
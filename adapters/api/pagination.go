@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+var (
+	ErrInvalidPageLimit = errors.New("invalid limit parameter. Expected a positive integer")
+	ErrInvalidCursor    = errors.New("invalid cursor parameter")
+)
+
+// ParsePagination reads the limit and cursor query params of a list
+// endpoint. A missing limit falls back to DefaultPageLimit and larger
+// limits are capped at MaxPageLimit. The cursor is the nextCursor returned
+// with the previous page.
+func ParsePagination(r *http.Request) (ports.Page, error) {
+	page := ports.Page{Limit: DefaultPageLimit}
+
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			return ports.Page{}, ErrInvalidPageLimit
+		}
+		page.Limit = min(limit, MaxPageLimit)
+	}
+
+	if cursorParam := r.URL.Query().Get("cursor"); cursorParam != "" {
+		cursor, err := decodeCursor(cursorParam)
+		if err != nil {
+			return ports.Page{}, ErrInvalidCursor
+		}
+		page.After = cursor
+	}
+
+	return page, nil
+}
+
+// EncodeCursor returns the opaque nextCursor for a page ending at cursor,
+// or an empty string on the last page
+func EncodeCursor(cursor *ports.PageCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw := cursor.StartTime.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(encoded string) (*ports.PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	startParam, id, found := strings.Cut(string(raw), "|")
+	if !found || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	startTime, err := time.Parse(time.RFC3339Nano, startParam)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ports.PageCursor{StartTime: startTime.UTC(), ID: id}, nil
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+func TestParsePagination(t *testing.T) {
+	cursor := &ports.PageCursor{
+		StartTime: time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC),
+		ID:        "booking_1",
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedLimit int
+		expectedAfter *ports.PageCursor
+		expectedErr   error
+	}{
+		{
+			name:          "defaults the limit",
+			query:         "",
+			expectedLimit: DefaultPageLimit,
+		},
+		{
+			name:          "caps the limit",
+			query:         "limit=1000",
+			expectedLimit: MaxPageLimit,
+		},
+		{
+			name:          "keeps a valid limit",
+			query:         "limit=10",
+			expectedLimit: 10,
+		},
+		{
+			name:        "rejects a zero limit",
+			query:       "limit=0",
+			expectedErr: ErrInvalidPageLimit,
+		},
+		{
+			name:        "rejects a non-numeric limit",
+			query:       "limit=ten",
+			expectedErr: ErrInvalidPageLimit,
+		},
+		{
+			name:          "decodes a cursor",
+			query:         "cursor=" + EncodeCursor(cursor),
+			expectedLimit: DefaultPageLimit,
+			expectedAfter: cursor,
+		},
+		{
+			name:        "rejects a malformed cursor",
+			query:       "cursor=not-a-cursor",
+			expectedErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?"+test.query, nil)
+
+			page, err := ParsePagination(req)
+			if err != test.expectedErr {
+				t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if page.Limit != test.expectedLimit {
+				t.Errorf("expected limit %d, got %d", test.expectedLimit, page.Limit)
+			}
+			if test.expectedAfter == nil {
+				if page.After != nil {
+					t.Errorf("expected no cursor, got %+v", page.After)
+				}
+				return
+			}
+			if page.After == nil || !page.After.StartTime.Equal(test.expectedAfter.StartTime) || page.After.ID != test.expectedAfter.ID {
+				t.Errorf("expected cursor %+v, got %+v", test.expectedAfter, page.After)
+			}
+		})
+	}
+}
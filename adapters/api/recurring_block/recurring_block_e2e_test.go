@@ -0,0 +1,95 @@
+package recurring_block_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/recurring_block_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_recurring_block"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_recurring_block"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_recurring_blocks"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_recurring_block"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestRecurringBlockOwnership(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	ownerID := testutils.CreateTestTherapistWithName(t, database, "Dr. Owner")
+	otherID := testutils.CreateTestTherapistWithName(t, database, "Dr. Other")
+
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+	blockRepo := recurring_block_db.NewRecurringBlockRepository(database)
+	handler := NewRecurringBlockHandler(
+		*create_recurring_block.NewUsecase(therapistRepo, blockRepo),
+		*list_recurring_blocks.NewUsecase(therapistRepo, blockRepo),
+		*update_recurring_block.NewUsecase(blockRepo),
+		*delete_recurring_block.NewUsecase(blockRepo),
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	window := map[string]interface{}{"dayOfWeek": "Monday", "start": "12:00", "duration": 60}
+	var block timeslot.RecurringBlock
+	testutils.AssertJSONResponse(t, request(http.MethodPost, fmt.Sprintf("/api/v1/therapists/%s/blocks", ownerID), window), http.StatusCreated, &block)
+
+	t.Run("Another therapist cannot update the block", func(t *testing.T) {
+		moved := map[string]interface{}{"dayOfWeek": "Tuesday", "start": "15:00", "duration": 30}
+		rec := request(http.MethodPut, fmt.Sprintf("/api/v1/therapists/%s/blocks/%s", otherID, block.ID), moved)
+		testutils.AssertErrorCode(t, rec, http.StatusNotFound, "recurring_block.not_owned")
+
+		stored, err := blockRepo.GetByID(block.ID)
+		if err != nil || stored == nil {
+			t.Fatalf("Expected block %s to still exist, got %v", block.ID, err)
+		}
+		if stored.DayOfWeek != timeslot.DayOfWeekMonday || stored.Start != "12:00" || stored.Duration != 60 {
+			t.Errorf("Expected block to be unchanged, got %+v", stored)
+		}
+	})
+
+	t.Run("Another therapist cannot delete the block", func(t *testing.T) {
+		rec := request(http.MethodDelete, fmt.Sprintf("/api/v1/therapists/%s/blocks/%s", otherID, block.ID), nil)
+		testutils.AssertErrorCode(t, rec, http.StatusNotFound, "recurring_block.not_owned")
+
+		stored, err := blockRepo.GetByID(block.ID)
+		if err != nil || stored == nil {
+			t.Errorf("Expected block %s to still exist, got %v", block.ID, err)
+		}
+	})
+
+	t.Run("The owner can update and delete the block", func(t *testing.T) {
+		moved := map[string]interface{}{"dayOfWeek": "Tuesday", "start": "15:00", "duration": 30}
+		rec := request(http.MethodPut, fmt.Sprintf("/api/v1/therapists/%s/blocks/%s", ownerID, block.ID), moved)
+		testutils.AssertStatus(t, rec, http.StatusOK)
+
+		rec = request(http.MethodDelete, fmt.Sprintf("/api/v1/therapists/%s/blocks/%s", ownerID, block.ID), nil)
+		testutils.AssertStatus(t, rec, http.StatusNoContent)
+
+		stored, err := blockRepo.GetByID(block.ID)
+		if err != nil || stored != nil {
+			t.Errorf("Expected block %s to be deleted, got %+v, %v", block.ID, stored, err)
+		}
+	})
+}
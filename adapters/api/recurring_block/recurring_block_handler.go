@@ -0,0 +1,183 @@
+package recurring_block_handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mishkahtherapy/brain/adapters/api"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_recurring_block"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_recurring_block"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_recurring_blocks"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_recurring_block"
+)
+
+type RecurringBlockHandler struct {
+	createUsecase create_recurring_block.Usecase
+	listUsecase   list_recurring_blocks.Usecase
+	updateUsecase update_recurring_block.Usecase
+	deleteUsecase delete_recurring_block.Usecase
+	clinicGuard   *api.ClinicGuard
+}
+
+func NewRecurringBlockHandler(
+	createUsecase create_recurring_block.Usecase,
+	listUsecase list_recurring_blocks.Usecase,
+	updateUsecase update_recurring_block.Usecase,
+	deleteUsecase delete_recurring_block.Usecase,
+	clinicGuard *api.ClinicGuard,
+) *RecurringBlockHandler {
+	return &RecurringBlockHandler{
+		createUsecase: createUsecase,
+		listUsecase:   listUsecase,
+		updateUsecase: updateUsecase,
+		deleteUsecase: deleteUsecase,
+		clinicGuard:   clinicGuard,
+	}
+}
+
+func (h *RecurringBlockHandler) RegisterRoutes(mux api.Router) {
+	mux.HandleFunc("POST /api/v1/therapists/{therapistId}/blocks", h.clinicGuard.Therapist("therapistId", h.handleCreateBlock))
+	mux.HandleFunc("GET /api/v1/therapists/{therapistId}/blocks", h.clinicGuard.Therapist("therapistId", h.handleListBlocks))
+	mux.HandleFunc("PUT /api/v1/therapists/{therapistId}/blocks/{blockId}", h.clinicGuard.Therapist("therapistId", h.handleUpdateBlock))
+	mux.HandleFunc("DELETE /api/v1/therapists/{therapistId}/blocks/{blockId}", h.clinicGuard.Therapist("therapistId", h.handleDeleteBlock))
+}
+
+// blockRequestBody is the window of a block. Day and start are in UTC unless
+// a timezone is given.
+type blockRequestBody struct {
+	DayOfWeek string                 `json:"dayOfWeek"`
+	Start     domain.Time24h         `json:"start"`
+	Duration  domain.DurationMinutes `json:"duration"`
+	Timezone  string                 `json:"timezone"`
+}
+
+func (h *RecurringBlockHandler) handleCreateBlock(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody blockRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	block, err := h.createUsecase.Execute(create_recurring_block.Input{
+		TherapistID:     therapistID,
+		DayOfWeek:       requestBody.DayOfWeek,
+		StartTime:       requestBody.Start,
+		DurationMinutes: requestBody.Duration,
+		Timezone:        requestBody.Timezone,
+	})
+	if err != nil {
+		writeBlockError(rw, err)
+		return
+	}
+
+	if err := rw.WriteJSON(block, http.StatusCreated); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *RecurringBlockHandler) handleListBlocks(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	blocks, err := h.listUsecase.Execute(therapistID)
+	if err != nil {
+		writeBlockError(rw, err)
+		return
+	}
+
+	if err := rw.WriteJSON(blocks, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *RecurringBlockHandler) handleUpdateBlock(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	blockID := domain.RecurringBlockID(r.PathValue("blockId"))
+	if therapistID == "" || blockID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist or block ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody blockRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	block, err := h.updateUsecase.Execute(update_recurring_block.Input{
+		TherapistID:     therapistID,
+		BlockID:         blockID,
+		DayOfWeek:       requestBody.DayOfWeek,
+		StartTime:       requestBody.Start,
+		DurationMinutes: requestBody.Duration,
+		Timezone:        requestBody.Timezone,
+	})
+	if err != nil {
+		writeBlockError(rw, err)
+		return
+	}
+
+	if err := rw.WriteJSON(block, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *RecurringBlockHandler) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	blockID := domain.RecurringBlockID(r.PathValue("blockId"))
+	if therapistID == "" || blockID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist or block ID", http.StatusBadRequest)
+		return
+	}
+
+	err := h.deleteUsecase.Execute(delete_recurring_block.Input{
+		TherapistID: therapistID,
+		BlockID:     blockID,
+	})
+	if err != nil {
+		writeBlockError(rw, err)
+		return
+	}
+
+	rw.WriteNoContent()
+}
+
+func writeBlockError(rw *api.ResponseWriter, err error) {
+	switch err {
+	case timeslot.ErrTherapistIDRequired,
+		timeslot.ErrRecurringBlockIDIsRequired,
+		timeslot.ErrDayOfWeekIsRequired,
+		timeslot.ErrStartTimeIsRequired,
+		timeslot.ErrDurationIsRequired,
+		timeslot.ErrInvalidDayOfWeek,
+		timeslot.ErrInvalidTimeFormat,
+		timeslot.ErrInvalidDuration,
+		timeslot.ErrInvalidTimezoneName:
+		rw.WriteCodedError(err, http.StatusBadRequest)
+	case timeslot.ErrTherapistNotFound,
+		timeslot.ErrRecurringBlockNotFound,
+		timeslot.ErrRecurringBlockNotOwned:
+		rw.WriteCodedError(err, http.StatusNotFound)
+	default:
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
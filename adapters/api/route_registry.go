@@ -0,0 +1,125 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Router is what handlers register their routes on. *http.ServeMux
+// satisfies it, so handlers can still be mounted straight on a mux in tests.
+type Router interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+// Route is a registered method and path pattern. Method is empty for
+// patterns that match any method.
+type Route struct {
+	Method  string `json:"method"`
+	Pattern string `json:"pattern"`
+}
+
+// RouteRegistry registers routes on a mux and remembers them, so the API can
+// describe itself for client generation. It also serves the mux, answering
+// known paths requested with an unsupported method with a 405.
+type RouteRegistry struct {
+	mux    *http.ServeMux
+	mu     sync.Mutex
+	routes []Route
+}
+
+func NewRouteRegistry(mux *http.ServeMux) *RouteRegistry {
+	return &RouteRegistry{mux: mux}
+}
+
+// HandleFunc registers the handler on the mux and records its route
+func (r *RouteRegistry) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.mux.HandleFunc(pattern, handler)
+
+	route := Route{Pattern: pattern}
+	if method, path, ok := strings.Cut(pattern, " "); ok {
+		route = Route{Method: method, Pattern: strings.TrimSpace(path)}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, route)
+}
+
+// ServeHTTP serves the request from the mux. When no route matches but the
+// path is registered under other methods, it answers 405 with an Allow header
+// and the usual error envelope instead of the mux's plain text response.
+func (r *RouteRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if _, pattern := r.mux.Handler(req); pattern == "" {
+		if allowed := r.allowedMethods(req); len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			NewResponseWriter(w).WriteCodedErrorMessage(
+				ErrorCodeMethodNotAllowed,
+				fmt.Sprintf("Method %s is not allowed for %s", req.Method, req.URL.Path),
+				http.StatusMethodNotAllowed,
+			)
+			return
+		}
+	}
+	r.mux.ServeHTTP(w, req)
+}
+
+// allowedMethods returns the registered methods the mux would serve the
+// request's path with, sorted. GET routes also serve HEAD.
+func (r *RouteRegistry) allowedMethods(req *http.Request) []string {
+	r.mu.Lock()
+	methods := make(map[string]bool)
+	for _, route := range r.routes {
+		if route.Method != "" {
+			methods[route.Method] = true
+		}
+	}
+	r.mu.Unlock()
+	if methods[http.MethodGet] {
+		methods[http.MethodHead] = true
+	}
+
+	allowed := []string{}
+	for method := range methods {
+		if method == req.Method {
+			continue
+		}
+		probe := req.Clone(req.Context())
+		probe.Method = method
+		if _, pattern := r.mux.Handler(probe); pattern != "" {
+			allowed = append(allowed, method)
+		}
+	}
+	sort.Strings(allowed)
+	return allowed
+}
+
+// Routes returns the registered routes ordered by pattern, then method
+func (r *RouteRegistry) Routes() []Route {
+	r.mu.Lock()
+	routes := make([]Route, len(r.routes))
+	copy(routes, r.routes)
+	r.mu.Unlock()
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Pattern != routes[j].Pattern {
+			return routes[i].Pattern < routes[j].Pattern
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// RegisterRoutes registers the route manifest endpoint
+func (r *RouteRegistry) RegisterRoutes(router Router) {
+	router.HandleFunc("GET /api/v1/routes", r.handleListRoutes)
+}
+
+func (r *RouteRegistry) handleListRoutes(w http.ResponseWriter, req *http.Request) {
+	rw := NewResponseWriter(w)
+	if err := rw.WriteJSON(r.Routes(), http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,145 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api"
+	booking_handler "github.com/mishkahtherapy/brain/adapters/api/booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
+)
+
+func TestRouteManifest(t *testing.T) {
+	mux := http.NewServeMux()
+	routes := api.NewRouteRegistry(mux)
+
+	bookingHandler := booking_handler.NewBookingHandler(
+		create_booking.Usecase{},
+		create_adhoc_booking.Usecase{},
+		confirm_regular_booking.Usecase{},
+		confirm_adhoc_booking.Usecase{},
+		cancel_booking.Usecase{},
+		search_bookings.Usecase{},
+		get_booking_stats.Usecase{},
+		reassign_booking.Usecase{},
+		get_booking_history.Usecase{},
+		update_booking_duration.Usecase{},
+		get_lead_time_stats.Usecase{},
+		cancel_future_bookings.Usecase{},
+		get_booking_calendar.Usecase{},
+		reactivate_booking.Usecase{},
+		stream_booking_events.Usecase{},
+		hold_booking.Usecase{},
+		confirm_booking_hold.Usecase{},
+		get_confirmation_preview.Usecase{},
+		resend_confirmation_notification.Usecase{},
+		nil,
+	)
+	bookingHandler.RegisterRoutes(routes)
+	api.NewServerTimeHandler().RegisterRoutes(routes)
+	routes.RegisterRoutes(routes)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/routes", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var manifest []api.Route
+	if err := json.Unmarshal(rec.Body.Bytes(), &manifest); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	registered := make(map[api.Route]bool)
+	for _, route := range manifest {
+		registered[route] = true
+	}
+	for _, expected := range []api.Route{
+		{Method: http.MethodPost, Pattern: "/api/v1/bookings"},
+		{Method: http.MethodPut, Pattern: "/api/v1/bookings/{id}/confirm"},
+		{Method: http.MethodGet, Pattern: "/api/v1/time"},
+		{Method: http.MethodGet, Pattern: "/api/v1/routes"},
+	} {
+		if !registered[expected] {
+			t.Errorf("Expected %s %s in the manifest", expected.Method, expected.Pattern)
+		}
+	}
+
+	// Registered routes are still served by the mux
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/time", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected the time route to be served, got %d", rec.Code)
+	}
+}
+
+func TestRouteRegistryMethodNotAllowed(t *testing.T) {
+	routes := api.NewRouteRegistry(http.NewServeMux())
+	api.NewServerTimeHandler().RegisterRoutes(routes)
+	routes.HandleFunc("POST /api/v1/bookings", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	routes.HandleFunc("PUT /api/v1/bookings", func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantAllow  string
+	}{
+		{name: "Unsupported method on a known path", method: http.MethodDelete, path: "/api/v1/bookings", wantStatus: http.StatusMethodNotAllowed, wantAllow: "POST, PUT"},
+		{name: "GET routes also allow HEAD", method: http.MethodPost, path: "/api/v1/time", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET, HEAD"},
+		{name: "Supported method", method: http.MethodPost, path: "/api/v1/bookings", wantStatus: http.StatusCreated},
+		{name: "Unknown path", method: http.MethodDelete, path: "/api/v1/unknown", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if got := rec.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("Expected Allow %q, got %q", tt.wantAllow, got)
+			}
+			if tt.wantStatus != http.StatusMethodNotAllowed {
+				return
+			}
+
+			var response struct {
+				Error struct {
+					Code api.ErrorCode `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Error.Code != api.ErrorCodeMethodNotAllowed {
+				t.Errorf("Expected code %s, got %s", api.ErrorCodeMethodNotAllowed, response.Error.Code)
+			}
+		})
+	}
+}
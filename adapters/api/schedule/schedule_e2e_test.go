@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
 	"github.com/mishkahtherapy/brain/adapters/db"
 	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
 	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
@@ -21,7 +22,12 @@ import (
 	"github.com/mishkahtherapy/brain/core/domain/timeslot"
 
 	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_availability_days"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_next_availability"
 	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_therapist_availability_report"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_upcoming_schedule"
 
 	_ "github.com/glebarez/go-sqlite"
 )
@@ -39,8 +45,9 @@ func TestScheduleE2E(t *testing.T) {
 	database, cleanup := setupScheduleTestDB(t)
 	defer cleanup()
 
-	// Insert comprehensive test data
-	testData := insertScheduleTestData(t, database)
+	// Insert comprehensive test data for the week starting next Monday
+	monday := nextScheduleTestMonday()
+	testData := insertScheduleTestData(t, database, monday)
 	t.Logf("Created test data with %d therapists, %d time slots, %d bookings",
 		len(testData.Therapists), len(testData.TimeSlots), len(testData.Bookings))
 
@@ -50,10 +57,18 @@ func TestScheduleE2E(t *testing.T) {
 	bookingRepo := booking_db.NewBookingRepository(database)
 
 	// Setup usecase
-	getScheduleUsecase := get_schedule.NewUsecase(therapistRepo, timeSlotRepo, bookingRepo)
+	getScheduleUsecase := get_schedule.NewUsecase(therapistRepo, timeSlotRepo, bookingRepo, nil, nil, 15, nil, 0)
 
 	// Setup handler
-	scheduleHandler := NewScheduleHandler(*getScheduleUsecase)
+	scheduleHandler := NewScheduleHandler(
+		*getScheduleUsecase,
+		check_availability.Usecase{},
+		get_next_availability.Usecase{},
+		get_availability_days.Usecase{},
+		get_therapist_availability_report.Usecase{},
+		get_upcoming_schedule.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
 
 	// Setup router
 	mux := http.NewServeMux()
@@ -62,8 +77,7 @@ func TestScheduleE2E(t *testing.T) {
 	t.Run("Complex Three-Therapist Overlap Scenario", func(t *testing.T) {
 		// Test overlapping availability from 9:15-10:45 on Monday
 		// Expected: 3 therapists available from 9:15-10:00, then 2 therapists from 10:00-10:45
-		// Use a future Monday
-		req := httptest.NewRequest("GET", "/api/v1/schedule?specialization=anxiety&startDate=2025-07-07&endDate=2025-07-07", nil)
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/schedule?specializations=anxiety&startDate=%s&endDate=%s", monday.Format(time.DateOnly), monday.Format(time.DateOnly)), nil)
 		rec := httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -119,8 +133,7 @@ func TestScheduleE2E(t *testing.T) {
 	t.Run("Transition Point Testing", func(t *testing.T) {
 		// Test Wednesday where therapists join and leave at different times
 		// Expected: Complex transitions with varying therapist counts
-		// Use a future Wednesday
-		req := httptest.NewRequest("GET", "/api/v1/schedule?specialization=depression&startDate=2025-07-09&endDate=2025-07-09", nil)
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/schedule?specializations=depression&startDate=%s&endDate=%s", monday.AddDate(0, 0, 2).Format(time.DateOnly), monday.AddDate(0, 0, 2).Format(time.DateOnly)), nil)
 		rec := httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -152,8 +165,7 @@ func TestScheduleE2E(t *testing.T) {
 
 	t.Run("Mid-Hour Overlap Complex Pattern", func(t *testing.T) {
 		// Test Tuesday with non-standard times creating complex overlaps
-		// Use a future Tuesday
-		req := httptest.NewRequest("GET", "/api/v1/schedule?specialization=anxiety&startDate=2025-07-08&endDate=2025-07-08", nil)
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/schedule?specializations=anxiety&startDate=%s&endDate=%s", monday.AddDate(0, 0, 1).Format(time.DateOnly), monday.AddDate(0, 0, 1).Format(time.DateOnly)), nil)
 		rec := httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -188,8 +200,7 @@ func TestScheduleE2E(t *testing.T) {
 
 	t.Run("Full Day Multiple Therapists", func(t *testing.T) {
 		// Test Thursday with comprehensive availability patterns
-		// Use a future Thursday
-		req := httptest.NewRequest("GET", "/api/v1/schedule?specialization=anxiety&startDate=2025-07-10&endDate=2025-07-10", nil)
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/schedule?specializations=anxiety&startDate=%s&endDate=%s", monday.AddDate(0, 0, 3).Format(time.DateOnly), monday.AddDate(0, 0, 3).Format(time.DateOnly)), nil)
 		rec := httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -218,8 +229,7 @@ func TestScheduleE2E(t *testing.T) {
 
 	t.Run("English Language Requirement", func(t *testing.T) {
 		// Test with english=true filter
-		// Use a future Monday
-		req := httptest.NewRequest("GET", "/api/v1/schedule?specialization=anxiety&english=true&startDate=2025-07-07&endDate=2025-07-07", nil)
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/schedule?specializations=anxiety&requiresEnglish=true&startDate=%s&endDate=%s", monday.Format(time.DateOnly), monday.Format(time.DateOnly)), nil)
 		rec := httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -245,8 +255,7 @@ func TestScheduleE2E(t *testing.T) {
 
 	t.Run("Booking Interference Testing", func(t *testing.T) {
 		// Test Friday where bookings create "holes" in availability
-		// Use a future Friday
-		req := httptest.NewRequest("GET", "/api/v1/schedule?specialization=anxiety&startDate=2025-07-11&endDate=2025-07-11", nil)
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/schedule?specializations=anxiety&startDate=%s&endDate=%s", monday.AddDate(0, 0, 4).Format(time.DateOnly), monday.AddDate(0, 0, 4).Format(time.DateOnly)), nil)
 		rec := httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -275,8 +284,7 @@ func TestScheduleE2E(t *testing.T) {
 
 	t.Run("No Matching Therapists Edge Case", func(t *testing.T) {
 		// Test with a specialization that doesn't exist
-		// Use a future Monday
-		req := httptest.NewRequest("GET", "/api/v1/schedule?specialization=nonexistent&startDate=2025-07-07&endDate=2025-07-07", nil)
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/schedule?specializations=nonexistent&startDate=%s&endDate=%s", monday.Format(time.DateOnly), monday.Format(time.DateOnly)), nil)
 		rec := httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -308,7 +316,7 @@ func TestScheduleE2E(t *testing.T) {
 		}
 
 		// Test invalid date format
-		req = httptest.NewRequest("GET", "/api/v1/schedule?specialization=anxiety&startDate=invalid", nil)
+		req = httptest.NewRequest("GET", "/api/v1/schedule?specializations=anxiety&startDate=invalid", nil)
 		rec = httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -318,7 +326,7 @@ func TestScheduleE2E(t *testing.T) {
 		}
 
 		// Test invalid date range
-		req = httptest.NewRequest("GET", "/api/v1/schedule?specialization=anxiety&startDate=2024-01-10&endDate=2024-01-08", nil)
+		req = httptest.NewRequest("GET", "/api/v1/schedule?specializations=anxiety&startDate=2024-01-10&endDate=2024-01-08", nil)
 		rec = httptest.NewRecorder()
 
 		mux.ServeHTTP(rec, req)
@@ -329,7 +337,7 @@ func TestScheduleE2E(t *testing.T) {
 	})
 }
 
-func insertScheduleTestData(t *testing.T, database ports.SQLDatabase) *ScheduleTestData {
+func insertScheduleTestData(t *testing.T, database ports.SQLDatabase, monday time.Time) *ScheduleTestData {
 	now := domain.NewUTCTimestamp()
 
 	// Create specializations
@@ -416,6 +424,16 @@ func insertScheduleTestData(t *testing.T, database ports.SQLDatabase) *ScheduleT
 			t.Fatalf("Failed to insert therapist %s: %v", therapist.Name, err)
 		}
 
+		if therapist.SpeaksEnglish {
+			_, err = database.Exec(`
+				INSERT INTO therapist_languages (therapist_id, language_code, created_at)
+				VALUES (?, ?, ?)
+			`, therapist.ID, domain.LanguageCodeEnglish, now)
+			if err != nil {
+				t.Fatalf("Failed to insert therapist language: %v", err)
+			}
+		}
+
 		// Insert therapist specializations
 		for i, spec := range therapist.Specializations {
 			_, err = database.Exec(`
@@ -632,7 +650,7 @@ func insertScheduleTestData(t *testing.T, database ports.SQLDatabase) *ScheduleT
 
 	// Create strategic bookings to create "holes" in availability
 	// Friday bookings to test interference
-	fridayDate := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+	fridayDate := monday.AddDate(0, 0, 4)
 	bookings := []booking.Booking{
 		// Alice has a booking at 11:00 on Friday
 		{
@@ -661,10 +679,10 @@ func insertScheduleTestData(t *testing.T, database ports.SQLDatabase) *ScheduleT
 	// Insert bookings
 	for _, booking := range bookings {
 		_, err = database.Exec(`
-			INSERT INTO bookings (id, timeslot_id, therapist_id, client_id, start_time, timezone_offset, state, created_at, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			INSERT INTO bookings (id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`, booking.ID, booking.TimeSlotID, booking.TherapistID, booking.ClientID,
-			booking.StartTime, booking.ClientTimezoneOffset, booking.State, booking.CreatedAt, booking.UpdatedAt)
+			booking.StartTime, booking.Duration, booking.ClientTimezoneOffset, booking.State, booking.CreatedAt, booking.UpdatedAt)
 		if err != nil {
 			t.Fatalf("Failed to insert booking: %v", err)
 		}
@@ -679,6 +697,17 @@ func insertScheduleTestData(t *testing.T, database ports.SQLDatabase) *ScheduleT
 	}
 }
 
+// nextScheduleTestMonday returns the Monday at least a week ahead, far enough
+// out for advance notice not to hide any availability.
+func nextScheduleTestMonday() time.Time {
+	monday := time.Now().UTC().AddDate(0, 0, 7)
+	monday = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, time.UTC)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	return monday
+}
+
 func setupScheduleTestDB(_ *testing.T) (ports.SQLDatabase, func()) {
 	// Use in-memory database for testing
 	dbFilename := ":memory:"
@@ -1,42 +1,94 @@
 package schedule_handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mishkahtherapy/brain/adapters/api"
 	"github.com/mishkahtherapy/brain/core/domain"
+	scheduleDomain "github.com/mishkahtherapy/brain/core/domain/schedule"
+	"github.com/mishkahtherapy/brain/core/usecases/clinic/check_clinic"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_availability_days"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_next_availability"
 	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_therapist_availability_report"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_upcoming_schedule"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
 )
 
 type ScheduleHandler struct {
-	getScheduleUsecase get_schedule.Usecase
+	getScheduleUsecase         get_schedule.Usecase
+	checkAvailabilityUsecase   check_availability.Usecase
+	getNextAvailabilityUsecase get_next_availability.Usecase
+	getAvailabilityDaysUsecase get_availability_days.Usecase
+	getAvailabilityReport      get_therapist_availability_report.Usecase
+	getUpcomingScheduleUsecase get_upcoming_schedule.Usecase
+	clinicGuard                *api.ClinicGuard
 }
 
-func NewScheduleHandler(getScheduleUsecase get_schedule.Usecase) *ScheduleHandler {
+func NewScheduleHandler(
+	getScheduleUsecase get_schedule.Usecase,
+	checkAvailabilityUsecase check_availability.Usecase,
+	getNextAvailabilityUsecase get_next_availability.Usecase,
+	getAvailabilityDaysUsecase get_availability_days.Usecase,
+	getAvailabilityReport get_therapist_availability_report.Usecase,
+	getUpcomingScheduleUsecase get_upcoming_schedule.Usecase,
+	clinicGuard *api.ClinicGuard,
+) *ScheduleHandler {
 	return &ScheduleHandler{
-		getScheduleUsecase: getScheduleUsecase,
+		getScheduleUsecase:         getScheduleUsecase,
+		checkAvailabilityUsecase:   checkAvailabilityUsecase,
+		getNextAvailabilityUsecase: getNextAvailabilityUsecase,
+		getAvailabilityDaysUsecase: getAvailabilityDaysUsecase,
+		getAvailabilityReport:      getAvailabilityReport,
+		getUpcomingScheduleUsecase: getUpcomingScheduleUsecase,
+		clinicGuard:                clinicGuard,
 	}
 }
 
-func (h *ScheduleHandler) RegisterRoutes(mux *http.ServeMux) {
+func (h *ScheduleHandler) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc("GET /api/v1/schedule", h.handleGetSchedule)
+	mux.HandleFunc("GET /api/v1/schedule/next", h.handleGetNextAvailability)
+	mux.HandleFunc("GET /api/v1/schedule/days", h.handleGetAvailabilityDays)
+	mux.HandleFunc("POST /api/v1/schedule/batch", h.handleGetScheduleBatch)
+	mux.HandleFunc("GET /api/v1/therapists/{id}/availability-check", h.clinicGuard.Therapist("id", h.handleCheckAvailability))
+	mux.HandleFunc("GET /api/v1/therapists/{id}/schedule/upcoming", h.clinicGuard.Therapist("id", h.handleGetUpcomingSchedule))
+	mux.HandleFunc("GET /api/v1/admin/therapists/availability", h.handleGetAvailabilityReport)
+}
+
+// explainedScheduleResponse is the schedule returned when explain=true
+type explainedScheduleResponse struct {
+	Availabilities any                       `json:"availabilities"`
+	Explanation    *get_schedule.Explanation `json:"explanation"`
+}
+
+// emptyScheduleResponse is the 404 returned for an empty schedule when
+// strict=true
+type emptyScheduleResponse struct {
+	Availabilities []scheduleDomain.AvailableTimeRange `json:"availabilities"`
+	Reason         get_schedule.EmptyReason            `json:"reason"`
 }
 
 func (h *ScheduleHandler) handleGetSchedule(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
-	// Parse specializationsParam parameter (required)
+	// Parse specializationsParam parameter (required), either comma separated
+	// or as repeated tag parameters, e.g. tag=anxiety&tag=depression
 	specializationsParam := r.URL.Query().Get("specializations")
+	tagParams := r.URL.Query()["tag"]
 	therapistIdsParam := r.URL.Query().Get("therapistIds")
 
-	if specializationsParam == "" && therapistIdsParam == "" {
+	if specializationsParam == "" && len(tagParams) == 0 && therapistIdsParam == "" {
 		rw.WriteBadRequest("specialization or therapistIds is required")
 		return
 	}
 
-	if specializationsParam != "" && therapistIdsParam != "" {
+	if (specializationsParam != "" || len(tagParams) > 0) && therapistIdsParam != "" {
 		rw.WriteBadRequest("specialization and therapistIds cannot be used together")
 		return
 	}
@@ -78,12 +130,59 @@ func (h *ScheduleHandler) handleGetSchedule(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Parse slotLengthMinutes parameter (optional)
+	var slotLength int
+	slotLengthParam := r.URL.Query().Get("slotLengthMinutes")
+	if slotLengthParam != "" {
+		var err error
+		slotLength, err = strconv.Atoi(slotLengthParam)
+		if err != nil || slotLength <= 0 {
+			rw.WriteBadRequest("invalid slotLengthMinutes: must be a positive integer")
+			return
+		}
+	}
+
+	// Parse fields parameter (optional), e.g. fields=specializations=ids
+	specializationIDsOnly := false
+	fieldsParam := r.URL.Query().Get("fields")
+	if fieldsParam != "" {
+		for _, field := range strings.Split(fieldsParam, ",") {
+			switch strings.TrimSpace(field) {
+			case "specializations=ids":
+				specializationIDsOnly = true
+			case "specializations=full":
+				specializationIDsOnly = false
+			default:
+				rw.WriteBadRequest("invalid fields: supported values are specializations=ids and specializations=full")
+				return
+			}
+		}
+	}
+
+	// Parse explain parameter (optional), adding why the schedule came back
+	// the way it did
+	explain := r.URL.Query().Get("explain") == "true"
+
+	// Parse mergeAdjacent parameter (optional), joining ranges of the same
+	// therapists split only by a short gap
+	mergeAdjacent := r.URL.Query().Get("mergeAdjacent") == "true"
+
+	// Parse weekdaysOnly parameter (optional), skipping Saturdays and Sundays
+	weekdaysOnly := r.URL.Query().Get("weekdaysOnly") == "true"
+
+	// Parse strict parameter (optional), answering an empty schedule with a
+	// 404 telling whether there was no availability or it was all booked
+	strict := r.URL.Query().Get("strict") == "true"
+
 	therapistIds := []domain.TherapistID{}
 	if therapistIdsParam != "" {
 		therapistIdStrings := strings.Split(strings.TrimSpace(therapistIdsParam), ",")
 		for _, id := range therapistIdStrings {
 			therapistIds = append(therapistIds, domain.TherapistID(id))
 		}
+		if !h.clinicGuard.Check(rw, r, check_clinic.Input{TherapistIDs: therapistIds}) {
+			return
+		}
 	}
 
 	specializations := []string{}
@@ -91,16 +190,18 @@ func (h *ScheduleHandler) handleGetSchedule(w http.ResponseWriter, r *http.Reque
 		specializationStrings := strings.Split(strings.TrimSpace(specializationsParam), ",")
 		specializations = append(specializations, specializationStrings...)
 	}
+	specializations = append(specializations, tagParams...)
 
 	// Create input for usecase
 	input := get_schedule.Input{
-		MustSpeakEnglish: english,
-		StartDate:        startDate,
-		EndDate:          endDate,
-	}
-
-	if len(specializations) > 0 {
-		input.SpecializationTag = specializations[0]
+		ClinicID:           api.ClinicFromContext(r.Context()),
+		SpecializationTags: specializations,
+		MustSpeakEnglish:   english,
+		StartDate:          startDate,
+		EndDate:            endDate,
+		SlotLengthMinutes:  domain.DurationMinutes(slotLength),
+		MergeAdjacent:      mergeAdjacent,
+		WeekdaysOnly:       weekdaysOnly,
 	}
 
 	if len(therapistIds) > 0 {
@@ -108,7 +209,14 @@ func (h *ScheduleHandler) handleGetSchedule(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Execute usecase
-	schedule, err := h.getScheduleUsecase.Execute(input)
+	var schedule []scheduleDomain.AvailableTimeRange
+	var explanation *get_schedule.Explanation
+	var err error
+	if explain || strict {
+		schedule, explanation, err = h.getScheduleUsecase.Explain(input)
+	} else {
+		schedule, err = h.getScheduleUsecase.Execute(input)
+	}
 	if err != nil {
 		// Handle specific business logic errors
 		switch err {
@@ -118,14 +226,399 @@ func (h *ScheduleHandler) handleGetSchedule(w http.ResponseWriter, r *http.Reque
 			rw.WriteBadRequest(err.Error())
 		case get_schedule.ErrInvalidDateRange:
 			rw.WriteBadRequest(err.Error())
+		case get_schedule.ErrInvalidSlotLength:
+			rw.WriteBadRequest(err.Error())
 		default:
 			rw.WriteError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
+	if strict && len(schedule) == 0 {
+		response := emptyScheduleResponse{
+			Availabilities: []scheduleDomain.AvailableTimeRange{},
+			Reason:         explanation.EmptyReason(),
+		}
+		if err := rw.WriteJSON(response, http.StatusNotFound); err != nil {
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
 	// Return response
-	if err := rw.WriteJSON(schedule, http.StatusOK); err != nil {
+	var availabilities any = schedule
+	if specializationIDsOnly {
+		availabilities = scheduleDomain.WithSpecializationIDs(schedule)
+	}
+
+	if explain {
+		response := explainedScheduleResponse{
+			Availabilities: availabilities,
+			Explanation:    explanation,
+		}
+		if err := rw.WriteJSON(response, http.StatusOK); err != nil {
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(availabilities, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+// scheduleBatchQuery is one query of a batch schedule request. Its result is
+// keyed by Key, or by Tag when no key is given.
+type scheduleBatchQuery struct {
+	Key     string `json:"key"`
+	Tag     string `json:"tag"`
+	English bool   `json:"english"`
+	From    string `json:"from"` // YYYY-MM-DD, optional
+	To      string `json:"to"`   // YYYY-MM-DD, optional
+}
+
+type scheduleBatchRequest struct {
+	Queries []scheduleBatchQuery `json:"queries"`
+}
+
+// handleGetScheduleBatch handles POST /api/v1/schedule/batch
+func (h *ScheduleHandler) handleGetScheduleBatch(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	var request scheduleBatchRequest
+	if err := api.DecodeJSON(r, &request); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+
+	inputs := make(map[string]get_schedule.Input, len(request.Queries))
+	for _, query := range request.Queries {
+		if query.Tag == "" {
+			rw.WriteBadRequest("tag is required for every query")
+			return
+		}
+		key := query.Key
+		if key == "" {
+			key = query.Tag
+		}
+		if _, ok := inputs[key]; ok {
+			rw.WriteBadRequest("duplicate query key: " + key)
+			return
+		}
+
+		input := get_schedule.Input{
+			ClinicID:           api.ClinicFromContext(r.Context()),
+			SpecializationTags: []string{query.Tag},
+			MustSpeakEnglish:   query.English,
+		}
+		if query.From != "" {
+			from, err := time.Parse(time.DateOnly, query.From)
+			if err != nil {
+				rw.WriteBadRequest("invalid from format: use YYYY-MM-DD")
+				return
+			}
+			input.StartDate = from
+		}
+		if query.To != "" {
+			to, err := time.Parse(time.DateOnly, query.To)
+			if err != nil {
+				rw.WriteBadRequest("invalid to format: use YYYY-MM-DD")
+				return
+			}
+			input.EndDate = to
+		}
+		inputs[key] = input
+	}
+
+	results, err := h.getScheduleUsecase.ExecuteBatch(inputs)
+	if err != nil {
+		switch {
+		case errors.Is(err, get_schedule.ErrBatchQueriesRequired),
+			errors.Is(err, get_schedule.ErrTooManyBatchQueries),
+			errors.Is(err, get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired),
+			errors.Is(err, get_schedule.ErrInvalidDateRange):
+			rw.WriteBadRequest(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(results, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *ScheduleHandler) handleGetNextAvailability(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	tag := r.URL.Query().Get("tag")
+	if tag == "" {
+		rw.WriteBadRequest("tag is required")
+		return
+	}
+
+	english := r.URL.Query().Get("english") == "true"
+
+	next, err := h.getNextAvailabilityUsecase.Execute(get_next_availability.Input{
+		ClinicID:          api.ClinicFromContext(r.Context()),
+		SpecializationTag: tag,
+		MustSpeakEnglish:  english,
+	})
+	if err != nil {
+		switch err {
+		case get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired:
+			rw.WriteBadRequest(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if next == nil {
+		rw.WriteNotFound("no availability found")
+		return
+	}
+
+	if err := rw.WriteJSON(next, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *ScheduleHandler) handleGetAvailabilityDays(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	tag := r.URL.Query().Get("tag")
+	if tag == "" {
+		rw.WriteBadRequest("tag is required")
+		return
+	}
+
+	english := r.URL.Query().Get("english") == "true"
+
+	// Parse from & to parameters (optional, YYYY-MM-DD)
+	var from time.Time
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		var err error
+		from, err = time.Parse(time.DateOnly, fromParam)
+		if err != nil {
+			rw.WriteBadRequest("invalid from format: use YYYY-MM-DD")
+			return
+		}
+	}
+
+	var to time.Time
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		var err error
+		to, err = time.Parse(time.DateOnly, toParam)
+		if err != nil {
+			rw.WriteBadRequest("invalid to format: use YYYY-MM-DD")
+			return
+		}
+	}
+
+	days, err := h.getAvailabilityDaysUsecase.Execute(get_availability_days.Input{
+		ClinicID:          api.ClinicFromContext(r.Context()),
+		SpecializationTag: tag,
+		MustSpeakEnglish:  english,
+		From:              from,
+		To:                to,
+	})
+	if err != nil {
+		switch err {
+		case get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired,
+			get_schedule.ErrInvalidDateRange:
+			rw.WriteBadRequest(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(days, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+// handleGetAvailabilityReport handles GET /api/v1/admin/therapists/availability
+func (h *ScheduleHandler) handleGetAvailabilityReport(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	tag := r.URL.Query().Get("tag")
+	if tag == "" {
+		rw.WriteBadRequest("tag is required")
+		return
+	}
+
+	// Parse language parameter (optional, ISO 639-1 code, e.g. en)
+	language := domain.LanguageCode(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("language"))))
+
+	// Parse from & to parameters (optional, YYYY-MM-DD)
+	var from time.Time
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		var err error
+		from, err = time.Parse(time.DateOnly, fromParam)
+		if err != nil {
+			rw.WriteBadRequest("invalid from format: use YYYY-MM-DD")
+			return
+		}
+	}
+
+	var to time.Time
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		var err error
+		to, err = time.Parse(time.DateOnly, toParam)
+		if err != nil {
+			rw.WriteBadRequest("invalid to format: use YYYY-MM-DD")
+			return
+		}
+	}
+
+	report, err := h.getAvailabilityReport.Execute(get_therapist_availability_report.Input{
+		ClinicID:          api.ClinicFromContext(r.Context()),
+		SpecializationTag: tag,
+		Language:          language,
+		From:              from,
+		To:                to,
+	})
+	if err != nil {
+		switch err {
+		case get_therapist_availability_report.ErrSpecializationTagIsRequired,
+			get_therapist_availability_report.ErrInvalidLanguage,
+			get_schedule.ErrInvalidDateRange:
+			rw.WriteBadRequest(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(report, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *ScheduleHandler) handleCheckAvailability(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist ID from path
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteBadRequest("Missing therapist ID")
+		return
+	}
+
+	timeSlotID := domain.TimeSlotID(r.URL.Query().Get("timeSlotId"))
+
+	// Parse startTime parameter (RFC3339, e.g. 2025-07-07T09:00:00Z)
+	var startTime time.Time
+	startTimeParam := r.URL.Query().Get("startTime")
+	if startTimeParam != "" {
+		var err error
+		startTime, err = time.Parse(time.RFC3339, startTimeParam)
+		if err != nil {
+			rw.WriteBadRequest("invalid startTime format: use RFC3339")
+			return
+		}
+	}
+
+	// Parse durationMinutes parameter
+	var duration int
+	durationParam := r.URL.Query().Get("durationMinutes")
+	if durationParam != "" {
+		var err error
+		duration, err = strconv.Atoi(durationParam)
+		if err != nil {
+			rw.WriteBadRequest("invalid durationMinutes: must be an integer")
+			return
+		}
+	}
+
+	input := check_availability.Input{
+		TherapistID: therapistID,
+		TimeSlotID:  timeSlotID,
+		StartTime:   domain.UTCTimestamp(startTime.UTC()),
+		Duration:    domain.DurationMinutes(duration),
+	}
+
+	result, err := h.checkAvailabilityUsecase.Execute(input)
+	if err != nil {
+		// Handle specific business logic errors
+		switch err {
+		case common.ErrTherapistIDIsRequired,
+			common.ErrTimeSlotIDIsRequired,
+			common.ErrStartTimeIsRequired,
+			common.ErrDurationIsRequired:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrTimeSlotNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(result, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+// handleGetUpcomingSchedule handles GET /api/v1/therapists/{id}/schedule/upcoming
+func (h *ScheduleHandler) handleGetUpcomingSchedule(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist ID from path
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteBadRequest("Missing therapist ID")
+		return
+	}
+
+	// Parse days parameter (optional)
+	var days int
+	if daysParam := r.URL.Query().Get("days"); daysParam != "" {
+		var err error
+		days, err = strconv.Atoi(daysParam)
+		if err != nil || days <= 0 {
+			rw.WriteBadRequest("invalid days: must be a positive integer")
+			return
+		}
+	}
+
+	// Parse timezoneOffset parameter (optional, minutes from UTC)
+	var timezoneOffset domain.TimezoneOffset
+	if offsetParam := r.URL.Query().Get("timezoneOffset"); offsetParam != "" {
+		offset, err := strconv.Atoi(offsetParam)
+		if err != nil {
+			rw.WriteBadRequest("invalid timezoneOffset: must be minutes from UTC as an integer")
+			return
+		}
+		timezoneOffset = domain.TimezoneOffset(offset)
+		if err := timeslot_usecase.ValidateTimezoneOffset(timezoneOffset); err != nil {
+			rw.WriteBadRequest(err.Error())
+			return
+		}
+	}
+
+	ranges, err := h.getUpcomingScheduleUsecase.Execute(get_upcoming_schedule.Input{
+		TherapistID:    therapistID,
+		Days:           days,
+		TimezoneOffset: timezoneOffset,
+	})
+	if err != nil {
+		switch err {
+		case common.ErrTherapistIDIsRequired,
+			get_upcoming_schedule.ErrInvalidDays:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrTherapistNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(ranges, http.StatusOK); err != nil {
 		rw.WriteError(err, http.StatusInternalServerError)
 	}
 }
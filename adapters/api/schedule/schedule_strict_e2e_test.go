@@ -0,0 +1,116 @@
+package schedule_handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_availability_days"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_next_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_therapist_availability_report"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_upcoming_schedule"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestStrictScheduleExplainsEmptyDays(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	// The therapist only works Monday mornings, and the first Monday is booked
+	therapistID := testutils.CreateTestTherapist(t, database)
+	timeSlotID := testutils.CreateTestTimeSlotCustom(t, database, therapistID, "Monday", "09:00", 60, true)
+	clientID := testutils.CreateTestClient(t, database)
+
+	bookedMonday := time.Now().UTC().AddDate(0, 0, 7)
+	bookedMonday = time.Date(bookedMonday.Year(), bookedMonday.Month(), bookedMonday.Day(), 0, 0, 0, 0, time.UTC)
+	for bookedMonday.Weekday() != time.Monday {
+		bookedMonday = bookedMonday.AddDate(0, 0, 1)
+	}
+	now := time.Now().UTC()
+	_, err := database.Exec(`
+		INSERT INTO bookings (id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, domain.NewBookingID(), timeSlotID, therapistID, clientID, bookedMonday.Add(9*time.Hour), 60, 0, "confirmed", now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert booking: %v", err)
+	}
+
+	repos := testutils.SetupRepositories(database)
+	scheduleHandler := NewScheduleHandler(
+		*get_schedule.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo, nil, nil, 15, nil, 0),
+		check_availability.Usecase{},
+		get_next_availability.Usecase{},
+		get_availability_days.Usecase{},
+		get_therapist_availability_report.Usecase{},
+		get_upcoming_schedule.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	scheduleHandler.RegisterRoutes(mux)
+
+	tests := []struct {
+		name           string
+		day            time.Time
+		strict         bool
+		expectedStatus int
+		expectedReason get_schedule.EmptyReason
+	}{
+		{
+			name:           "Day without a weekly slot",
+			day:            bookedMonday.AddDate(0, 0, 1),
+			strict:         true,
+			expectedStatus: http.StatusNotFound,
+			expectedReason: get_schedule.EmptyReasonNoConfiguredAvailability,
+		},
+		{
+			name:           "Day with its slot fully booked",
+			day:            bookedMonday,
+			strict:         true,
+			expectedStatus: http.StatusNotFound,
+			expectedReason: get_schedule.EmptyReasonFullyBooked,
+		},
+		{
+			name:           "Day with availability",
+			day:            bookedMonday.AddDate(0, 0, 7),
+			strict:         true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Empty day without strict",
+			day:            bookedMonday,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := tt.day.Format(time.DateOnly)
+			path := fmt.Sprintf("/api/v1/schedule?therapistIds=%s&startDate=%s&endDate=%s&strict=%t", therapistID, date, date, tt.strict)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			testutils.AssertStatus(t, rec, tt.expectedStatus)
+
+			if tt.expectedStatus != http.StatusNotFound {
+				return
+			}
+			var response emptyScheduleResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Reason != tt.expectedReason {
+				t.Errorf("Expected reason %q, got %q", tt.expectedReason, response.Reason)
+			}
+			if response.Availabilities == nil || len(response.Availabilities) != 0 {
+				t.Errorf("Expected empty availabilities, got %+v", response.Availabilities)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
+)
+
+// ServerTimeResponse is the server's notion of now along with the timezone
+// offsets it accepts, so clients can align clocks and validate offsets locally.
+type ServerTimeResponse struct {
+	Now               string                `json:"now"`
+	MinTimezoneOffset domain.TimezoneOffset `json:"minTimezoneOffset"`
+	MaxTimezoneOffset domain.TimezoneOffset `json:"maxTimezoneOffset"`
+}
+
+// ServerTimeHandler serves the current server time
+type ServerTimeHandler struct {
+	now func() time.Time
+}
+
+// NewServerTimeHandler creates a new instance of the ServerTimeHandler
+func NewServerTimeHandler() *ServerTimeHandler {
+	return &ServerTimeHandler{now: time.Now}
+}
+
+// RegisterRoutes registers all the routes handled by the ServerTimeHandler
+func (h *ServerTimeHandler) RegisterRoutes(mux Router) {
+	mux.HandleFunc("GET /api/v1/time", h.handleGetServerTime)
+}
+
+func (h *ServerTimeHandler) handleGetServerTime(w http.ResponseWriter, r *http.Request) {
+	rw := NewResponseWriter(w)
+
+	response := ServerTimeResponse{
+		Now:               h.now().UTC().Format(time.RFC3339),
+		MinTimezoneOffset: timeslot_usecase.MinTimezoneOffset,
+		MaxTimezoneOffset: timeslot_usecase.MaxTimezoneOffset,
+	}
+	if err := rw.WriteJSON(response, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
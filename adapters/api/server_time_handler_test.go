@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
+)
+
+func TestGetServerTime(t *testing.T) {
+	cairo := time.FixedZone("Cairo", 3*60*60)
+	handler := NewServerTimeHandler()
+	handler.now = func() time.Time { return time.Date(2025, 7, 1, 12, 30, 0, 0, cairo) }
+
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/time", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var response ServerTimeResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Now != "2025-07-01T09:30:00Z" {
+		t.Errorf("Expected the time in UTC, got %s", response.Now)
+	}
+	now, err := time.Parse(time.RFC3339, response.Now)
+	if err != nil {
+		t.Fatalf("Expected an RFC3339 time, got %s", response.Now)
+	}
+	if _, offset := now.Zone(); offset != 0 {
+		t.Errorf("Expected a UTC time, got offset %d", offset)
+	}
+
+	// The bounds are the last offsets the validator accepts
+	if err := timeslot_usecase.ValidateTimezoneOffset(response.MinTimezoneOffset); err != nil {
+		t.Errorf("Expected min offset %d to be valid", response.MinTimezoneOffset)
+	}
+	if err := timeslot_usecase.ValidateTimezoneOffset(response.MinTimezoneOffset - 1); err == nil {
+		t.Errorf("Expected offset below %d to be invalid", response.MinTimezoneOffset)
+	}
+	if err := timeslot_usecase.ValidateTimezoneOffset(response.MaxTimezoneOffset); err != nil {
+		t.Errorf("Expected max offset %d to be valid", response.MaxTimezoneOffset)
+	}
+	if err := timeslot_usecase.ValidateTimezoneOffset(response.MaxTimezoneOffset + 1); err == nil {
+		t.Errorf("Expected offset above %d to be invalid", response.MaxTimezoneOffset)
+	}
+}
@@ -7,10 +7,14 @@ import (
 
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/notification/notify_therapist_session_reminders"
 	"github.com/mishkahtherapy/brain/core/usecases/session/get_session"
+	"github.com/mishkahtherapy/brain/core/usecases/session/get_session_context"
+	"github.com/mishkahtherapy/brain/core/usecases/session/get_session_therapist"
 	"github.com/mishkahtherapy/brain/core/usecases/session/list_sessions_admin"
 	"github.com/mishkahtherapy/brain/core/usecases/session/list_sessions_by_client"
 	"github.com/mishkahtherapy/brain/core/usecases/session/list_sessions_by_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/session/list_sessions_missing_meeting_url"
 	"github.com/mishkahtherapy/brain/core/usecases/session/update_meeting_url"
 	"github.com/mishkahtherapy/brain/core/usecases/session/update_session_notes"
 	"github.com/mishkahtherapy/brain/core/usecases/session/update_session_state"
@@ -27,6 +31,11 @@ type SessionHandler struct {
 	listSessionsByTherapistUsecase list_sessions_by_therapist.Usecase
 	listSessionsByClientUsecase    list_sessions_by_client.Usecase
 	listSessionsAdminUsecase       list_sessions_admin.Usecase
+	listMissingMeetingURLUsecase   list_sessions_missing_meeting_url.Usecase
+	getSessionTherapistUsecase     get_session_therapist.Usecase
+	sendRemindersUsecase           notify_therapist_session_reminders.Usecase
+	getSessionContextUsecase       get_session_context.Usecase
+	clinicGuard                    *ClinicGuard
 }
 
 // NewSessionHandler creates a new instance of the SessionHandler
@@ -39,6 +48,11 @@ func NewSessionHandler(
 	listByTherapistUsecase list_sessions_by_therapist.Usecase,
 	listByClientUsecase list_sessions_by_client.Usecase,
 	listAdminUsecase list_sessions_admin.Usecase,
+	listMissingMeetingURLUsecase list_sessions_missing_meeting_url.Usecase,
+	getSessionTherapistUsecase get_session_therapist.Usecase,
+	sendRemindersUsecase notify_therapist_session_reminders.Usecase,
+	getSessionContextUsecase get_session_context.Usecase,
+	clinicGuard *ClinicGuard,
 ) *SessionHandler {
 	return &SessionHandler{
 		// createSessionUsecase:           createUsecase,
@@ -49,6 +63,11 @@ func NewSessionHandler(
 		listSessionsByTherapistUsecase: listByTherapistUsecase,
 		listSessionsByClientUsecase:    listByClientUsecase,
 		listSessionsAdminUsecase:       listAdminUsecase,
+		listMissingMeetingURLUsecase:   listMissingMeetingURLUsecase,
+		getSessionTherapistUsecase:     getSessionTherapistUsecase,
+		sendRemindersUsecase:           sendRemindersUsecase,
+		getSessionContextUsecase:       getSessionContextUsecase,
+		clinicGuard:                    clinicGuard,
 	}
 }
 
@@ -62,6 +81,9 @@ func (h *SessionHandler) SetUsecases(
 	listByTherapistUsecase list_sessions_by_therapist.Usecase,
 	listByClientUsecase list_sessions_by_client.Usecase,
 	listAdminUsecase list_sessions_admin.Usecase,
+	listMissingMeetingURLUsecase list_sessions_missing_meeting_url.Usecase,
+	getSessionTherapistUsecase get_session_therapist.Usecase,
+	sendRemindersUsecase notify_therapist_session_reminders.Usecase,
 ) {
 	// h.createSessionUsecase = createUsecase
 	h.getSessionUsecase = getUsecase
@@ -71,17 +93,24 @@ func (h *SessionHandler) SetUsecases(
 	h.listSessionsByTherapistUsecase = listByTherapistUsecase
 	h.listSessionsByClientUsecase = listByClientUsecase
 	h.listSessionsAdminUsecase = listAdminUsecase
+	h.listMissingMeetingURLUsecase = listMissingMeetingURLUsecase
+	h.getSessionTherapistUsecase = getSessionTherapistUsecase
+	h.sendRemindersUsecase = sendRemindersUsecase
 }
 
 // RegisterRoutes registers all the routes handled by the SessionHandler
-func (h *SessionHandler) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /api/v1/sessions/{id}", h.handleGetSession)
-	mux.HandleFunc("PUT /api/v1/sessions/{id}/state", h.handleUpdateSessionState)
-	mux.HandleFunc("PUT /api/v1/sessions/{id}/notes", h.handleUpdateSessionNotes)
-	mux.HandleFunc("PUT /api/v1/sessions/{id}/meeting-url", h.handleUpdateMeetingURL)
-	mux.HandleFunc("GET /api/v1/therapists/{id}/sessions", h.handleListSessionsByTherapist)
-	mux.HandleFunc("GET /api/v1/clients/{id}/sessions", h.handleListSessionsByClient)
+func (h *SessionHandler) RegisterRoutes(mux Router) {
+	mux.HandleFunc("GET /api/v1/sessions/{id}", h.clinicGuard.Session("id", h.handleGetSession))
+	mux.HandleFunc("GET /api/v1/sessions/{id}/therapist", h.clinicGuard.Session("id", h.handleGetSessionTherapist))
+	mux.HandleFunc("GET /api/v1/sessions/{id}/context", h.clinicGuard.Session("id", h.handleGetSessionContext))
+	mux.HandleFunc("PUT /api/v1/sessions/{id}/state", h.clinicGuard.Session("id", h.handleUpdateSessionState))
+	mux.HandleFunc("PUT /api/v1/sessions/{id}/notes", h.clinicGuard.Session("id", h.handleUpdateSessionNotes))
+	mux.HandleFunc("PUT /api/v1/sessions/{id}/meeting-url", h.clinicGuard.Session("id", h.handleUpdateMeetingURL))
+	mux.HandleFunc("GET /api/v1/therapists/{id}/sessions", h.clinicGuard.Therapist("id", h.handleListSessionsByTherapist))
+	mux.HandleFunc("GET /api/v1/clients/{id}/sessions", h.clinicGuard.Client("id", h.handleListSessionsByClient))
 	mux.HandleFunc("GET /api/v1/admin/sessions", h.handleListSessionsAdmin)
+	mux.HandleFunc("GET /api/v1/admin/sessions/missing-meeting-url", h.handleListSessionsMissingMeetingURL)
+	mux.HandleFunc("POST /api/v1/admin/sessions/send-reminders", h.handleSendSessionReminders)
 }
 
 // handleGetSession handles GET /api/v1/sessions/{id}
@@ -110,6 +139,67 @@ func (h *SessionHandler) handleGetSession(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// handleGetSessionTherapist handles GET /api/v1/sessions/{id}/therapist
+func (h *SessionHandler) handleGetSessionTherapist(w http.ResponseWriter, r *http.Request) {
+	rw := NewResponseWriter(w)
+
+	// Read session id from path
+	id := domain.SessionID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteBadRequest("Missing session ID")
+		return
+	}
+
+	therapist, err := h.getSessionTherapistUsecase.Execute(id)
+	if err != nil {
+		switch err {
+		case common.ErrSessionIDIsRequired:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrSessionNotFound,
+			common.ErrTherapistNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(therapist, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+// handleGetSessionContext handles GET /api/v1/sessions/{id}/context
+func (h *SessionHandler) handleGetSessionContext(w http.ResponseWriter, r *http.Request) {
+	rw := NewResponseWriter(w)
+
+	// Read session id from path
+	id := domain.SessionID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteBadRequest("Missing session ID")
+		return
+	}
+
+	sessionContext, err := h.getSessionContextUsecase.Execute(id)
+	if err != nil {
+		switch err {
+		case common.ErrSessionIDIsRequired:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrSessionNotFound,
+			common.ErrBookingNotFound,
+			common.ErrClientNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(sessionContext, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
 // handleUpdateSessionState handles PUT /api/v1/sessions/{id}/state
 func (h *SessionHandler) handleUpdateSessionState(w http.ResponseWriter, r *http.Request) {
 	rw := NewResponseWriter(w)
@@ -187,7 +277,8 @@ func (h *SessionHandler) handleUpdateSessionNotes(w http.ResponseWriter, r *http
 	if err != nil {
 		switch err {
 		case common.ErrSessionIDIsRequired,
-			common.ErrNotesIsRequired:
+			common.ErrNotesIsRequired,
+			domain.ErrNotesTooLong:
 			rw.WriteBadRequest(err.Error())
 		case common.ErrSessionNotFound:
 			rw.WriteNotFound(err.Error())
@@ -236,7 +327,9 @@ func (h *SessionHandler) handleUpdateMeetingURL(w http.ResponseWriter, r *http.R
 			rw.WriteBadRequest(err.Error())
 		case common.ErrSessionNotFound:
 			rw.WriteNotFound(err.Error())
-		case common.ErrInvalidMeetingURL:
+		case common.ErrInvalidMeetingURL,
+			common.ErrMeetingURLNotHTTPS,
+			common.ErrMeetingHostNotAllowed:
 			rw.WriteBadRequest(err.Error())
 		default:
 			rw.WriteError(err, http.StatusInternalServerError)
@@ -249,7 +342,8 @@ func (h *SessionHandler) handleUpdateMeetingURL(w http.ResponseWriter, r *http.R
 	}
 }
 
-// handleListSessionsByTherapist handles GET /api/v1/therapists/{id}/sessions
+// handleListSessionsByTherapist handles GET /api/v1/therapists/{id}/sessions.
+// A repeatable ?state= narrows the sessions down to those states.
 func (h *SessionHandler) handleListSessionsByTherapist(w http.ResponseWriter, r *http.Request) {
 	rw := NewResponseWriter(w)
 
@@ -260,14 +354,21 @@ func (h *SessionHandler) handleListSessionsByTherapist(w http.ResponseWriter, r
 		return
 	}
 
+	// Optional, repeatable state filter
+	states := []domain.SessionState{}
+	for _, state := range r.URL.Query()["state"] {
+		states = append(states, domain.SessionState(state))
+	}
+
 	input := list_sessions_by_therapist.Input{
 		TherapistID: therapistID,
+		States:      states,
 	}
 
 	sessions, err := h.listSessionsByTherapistUsecase.Execute(input)
 	if err != nil {
 		switch err {
-		case common.ErrTherapistIDIsRequired:
+		case common.ErrTherapistIDIsRequired, common.ErrInvalidSessionState:
 			rw.WriteBadRequest(err.Error())
 		default:
 			rw.WriteError(err, http.StatusInternalServerError)
@@ -322,6 +423,7 @@ func (h *SessionHandler) handleListSessionsAdmin(w http.ResponseWriter, r *http.
 
 	// Parse query parameters for date range
 	var input list_sessions_admin.Input
+	input.ClinicID = ClinicFromContext(r.Context())
 
 	if startDateParam := r.URL.Query().Get("startDate"); startDateParam != "" {
 		if startDate, err := time.Parse(time.DateOnly, startDateParam); err != nil {
@@ -341,6 +443,26 @@ func (h *SessionHandler) handleListSessionsAdmin(w http.ResponseWriter, r *http.
 		}
 	}
 
+	// updatedFrom/updatedTo are full timestamps so admins can reconcile
+	// changes within a tight window
+	if updatedFromParam := r.URL.Query().Get("updatedFrom"); updatedFromParam != "" {
+		if updatedFrom, err := time.Parse(time.RFC3339, updatedFromParam); err != nil {
+			rw.WriteBadRequest("Invalid updatedFrom format. Use RFC3339")
+			return
+		} else {
+			input.UpdatedFrom = updatedFrom.UTC()
+		}
+	}
+
+	if updatedToParam := r.URL.Query().Get("updatedTo"); updatedToParam != "" {
+		if updatedTo, err := time.Parse(time.RFC3339, updatedToParam); err != nil {
+			rw.WriteBadRequest("Invalid updatedTo format. Use RFC3339")
+			return
+		} else {
+			input.UpdatedTo = updatedTo.UTC()
+		}
+	}
+
 	sessions, err := h.listSessionsAdminUsecase.Execute(input)
 	if err != nil {
 		switch err {
@@ -356,3 +478,86 @@ func (h *SessionHandler) handleListSessionsAdmin(w http.ResponseWriter, r *http.
 		rw.WriteError(err, http.StatusInternalServerError)
 	}
 }
+
+// handleListSessionsMissingMeetingURL handles GET /api/v1/admin/sessions/missing-meeting-url
+func (h *SessionHandler) handleListSessionsMissingMeetingURL(w http.ResponseWriter, r *http.Request) {
+	rw := NewResponseWriter(w)
+
+	// from/to are full timestamps on the sessions' start time
+	var input list_sessions_missing_meeting_url.Input
+	input.ClinicID = ClinicFromContext(r.Context())
+
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		if from, err := time.Parse(time.RFC3339, fromParam); err != nil {
+			rw.WriteBadRequest("Invalid from format. Use RFC3339")
+			return
+		} else {
+			input.From = from.UTC()
+		}
+	}
+
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		if to, err := time.Parse(time.RFC3339, toParam); err != nil {
+			rw.WriteBadRequest("Invalid to format. Use RFC3339")
+			return
+		} else {
+			input.To = to.UTC()
+		}
+	}
+
+	sessions, err := h.listMissingMeetingURLUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case common.ErrInvalidDateRange:
+			rw.WriteBadRequest(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(sessions, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *SessionHandler) handleSendSessionReminders(w http.ResponseWriter, r *http.Request) {
+	rw := NewResponseWriter(w)
+
+	// from/to are full timestamps on the sessions' start time
+	var input notify_therapist_session_reminders.Input
+	input.ClinicID = ClinicFromContext(r.Context())
+
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		if from, err := time.Parse(time.RFC3339, fromParam); err != nil {
+			rw.WriteBadRequest("Invalid from format. Use RFC3339")
+			return
+		} else {
+			input.From = from.UTC()
+		}
+	}
+
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		if to, err := time.Parse(time.RFC3339, toParam); err != nil {
+			rw.WriteBadRequest("Invalid to format. Use RFC3339")
+			return
+		} else {
+			input.To = to.UTC()
+		}
+	}
+
+	output, err := h.sendRemindersUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case common.ErrInvalidDateRange:
+			rw.WriteBadRequest(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(output, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,104 @@
+package specialization_handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/delete_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_all_specializations"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_counts"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_therapists"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/new_specialization"
+)
+
+func TestDeleteSpecializationWithReassign(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	specializationRepo := specialization_db.NewSpecializationRepository(database)
+	handler := NewSpecializationHandler(
+		new_specialization.Usecase{},
+		get_all_specializations.Usecase{},
+		get_specialization.Usecase{},
+		get_specialization_counts.Usecase{},
+		get_specialization_therapists.Usecase{},
+		*delete_specialization.NewUsecase(specializationRepo, db.NewSQLUnitOfWork(database)),
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	therapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Reassign")
+	sourceID := testutils.CreateTestSpecializationWithName(t, database, "family counselling")
+	targetID := testutils.CreateTestSpecializationWithName(t, database, "family therapy")
+	testutils.LinkTherapistSpecialization(t, database, therapistID, sourceID)
+
+	del := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, path, nil))
+		return rec
+	}
+
+	t.Run("In use without reassignTo", func(t *testing.T) {
+		rec := del("/api/v1/specializations/" + string(sourceID))
+		testutils.AssertStatus(t, rec, http.StatusConflict)
+	})
+
+	t.Run("Unknown reassign target", func(t *testing.T) {
+		rec := del("/api/v1/specializations/" + string(sourceID) + "?reassignTo=specialization_missing")
+		testutils.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("Reassigns linked therapists and deletes", func(t *testing.T) {
+		rec := del("/api/v1/specializations/" + string(sourceID) + "?reassignTo=" + string(targetID))
+		testutils.AssertStatus(t, rec, http.StatusOK)
+
+		var output delete_specialization.Output
+		if err := json.Unmarshal(rec.Body.Bytes(), &output); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if output.DeletedID != sourceID {
+			t.Errorf("Expected deleted id %s, got %s", sourceID, output.DeletedID)
+		}
+		if output.MovedTherapists != 1 {
+			t.Errorf("Expected 1 moved therapist, got %d", output.MovedTherapists)
+		}
+
+		var linked []domain.SpecializationID
+		rows, err := database.Query(`SELECT specialization_id FROM therapist_specializations WHERE therapist_id = ?`, therapistID)
+		if err != nil {
+			t.Fatalf("Failed to query therapist specializations: %v", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id domain.SpecializationID
+			if err := rows.Scan(&id); err != nil {
+				t.Fatalf("Failed to scan specialization id: %v", err)
+			}
+			linked = append(linked, id)
+		}
+		if len(linked) != 1 || linked[0] != targetID {
+			t.Errorf("Expected the therapist to carry only %s, got %v", targetID, linked)
+		}
+
+		source, err := specializationRepo.GetByID(sourceID)
+		if err != nil {
+			t.Fatalf("Failed to get specialization: %v", err)
+		}
+		if source != nil {
+			t.Errorf("Expected specialization %s to be deleted", sourceID)
+		}
+	})
+
+	t.Run("Already deleted", func(t *testing.T) {
+		rec := del("/api/v1/specializations/" + string(sourceID))
+		testutils.AssertStatus(t, rec, http.StatusNotFound)
+	})
+}
@@ -8,13 +8,17 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
 	"github.com/mishkahtherapy/brain/adapters/db"
 	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/specialization"
 	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/delete_specialization"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_all_specializations"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_counts"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_therapists"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/new_specialization"
 
 	_ "github.com/glebarez/go-sqlite"
@@ -32,9 +36,11 @@ func TestSpecializationE2E(t *testing.T) {
 	createUsecase := new_specialization.NewUsecase(specializationRepo)
 	getAllUsecase := get_all_specializations.NewUsecase(specializationRepo)
 	getUsecase := get_specialization.NewUsecase(specializationRepo)
+	countsUsecase := get_specialization_counts.NewUsecase(specializationRepo)
+	therapistsUsecase := get_specialization_therapists.NewUsecase(specializationRepo)
 
 	// Setup handler with usecases
-	handler := NewSpecializationHandler(*createUsecase, *getAllUsecase, *getUsecase)
+	handler := NewSpecializationHandler(*createUsecase, *getAllUsecase, *getUsecase, *countsUsecase, *therapistsUsecase, delete_specialization.Usecase{}, testutils.NewClinicGuard(db))
 
 	// Setup router
 	mux := http.NewServeMux()
@@ -68,6 +74,9 @@ func TestSpecializationE2E(t *testing.T) {
 		if err := json.Unmarshal(body, &createdSpec); err != nil {
 			t.Fatalf("Failed to parse created specialization: %v", err)
 		}
+		if location := createRec.Header().Get("Location"); location != "/api/v1/specializations/"+string(createdSpec.ID) {
+			t.Errorf("Expected Location of the created specialization, got %q", location)
+		}
 
 		// Verify created specialization data
 		if createdSpec.Name != testName {
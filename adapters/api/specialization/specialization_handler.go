@@ -2,12 +2,17 @@ package specialization_handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/mishkahtherapy/brain/adapters/api"
 	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/delete_specialization"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_all_specializations"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_counts"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_therapists"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/new_specialization"
 )
 
@@ -15,17 +20,29 @@ type SpecializationHandler struct {
 	createSpecializationUsecase  new_specialization.Usecase
 	getAllSpecializationsUsecase get_all_specializations.Usecase
 	getSpecializationUsecase     get_specialization.Usecase
+	getCountsUsecase             get_specialization_counts.Usecase
+	getTherapistsUsecase         get_specialization_therapists.Usecase
+	deleteUsecase                delete_specialization.Usecase
+	clinicGuard                  *api.ClinicGuard
 }
 
 func NewSpecializationHandler(
 	createUsecase new_specialization.Usecase,
 	getAllSpecializationsUsecase get_all_specializations.Usecase,
 	getSpecializationUsecase get_specialization.Usecase,
+	getCountsUsecase get_specialization_counts.Usecase,
+	getTherapistsUsecase get_specialization_therapists.Usecase,
+	deleteUsecase delete_specialization.Usecase,
+	clinicGuard *api.ClinicGuard,
 ) *SpecializationHandler {
 	return &SpecializationHandler{
 		createSpecializationUsecase:  createUsecase,
 		getAllSpecializationsUsecase: getAllSpecializationsUsecase,
 		getSpecializationUsecase:     getSpecializationUsecase,
+		getCountsUsecase:             getCountsUsecase,
+		getTherapistsUsecase:         getTherapistsUsecase,
+		deleteUsecase:                deleteUsecase,
+		clinicGuard:                  clinicGuard,
 	}
 }
 
@@ -34,16 +51,23 @@ func (h *SpecializationHandler) SetUsecases(
 	createUsecase new_specialization.Usecase,
 	getAllUsecase get_all_specializations.Usecase,
 	getUsecase get_specialization.Usecase,
+	getCountsUsecase get_specialization_counts.Usecase,
+	getTherapistsUsecase get_specialization_therapists.Usecase,
 ) {
 	h.createSpecializationUsecase = createUsecase
 	h.getAllSpecializationsUsecase = getAllUsecase
 	h.getSpecializationUsecase = getUsecase
+	h.getCountsUsecase = getCountsUsecase
+	h.getTherapistsUsecase = getTherapistsUsecase
 }
 
-func (h *SpecializationHandler) RegisterRoutes(mux *http.ServeMux) {
+func (h *SpecializationHandler) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc("POST /api/v1/specializations", h.handleCreateSpecialization)
 	mux.HandleFunc("GET /api/v1/specializations", h.handleGetAllSpecializations)
-	mux.HandleFunc("GET /api/v1/specializations/{id}", h.handleGetSpecialization)
+	mux.HandleFunc("GET /api/v1/specializations/counts", h.handleGetSpecializationCounts)
+	mux.HandleFunc("GET /api/v1/specializations/{id}", h.clinicGuard.Specialization("id", h.handleGetSpecialization))
+	mux.HandleFunc("GET /api/v1/specializations/{id}/therapists", h.clinicGuard.Specialization("id", h.handleGetSpecializationTherapists))
+	mux.HandleFunc("DELETE /api/v1/specializations/{id}", h.clinicGuard.Specialization("id", h.handleDeleteSpecialization))
 }
 
 func (h *SpecializationHandler) handleCreateSpecialization(w http.ResponseWriter, r *http.Request) {
@@ -54,6 +78,7 @@ func (h *SpecializationHandler) handleCreateSpecialization(w http.ResponseWriter
 		rw.WriteBadRequest(err.Error())
 		return
 	}
+	input.ClinicID = api.ClinicFromContext(r.Context())
 
 	specialization, err := h.createSpecializationUsecase.Execute(input)
 	if err != nil {
@@ -61,7 +86,7 @@ func (h *SpecializationHandler) handleCreateSpecialization(w http.ResponseWriter
 		return
 	}
 
-	if err := rw.WriteJSON(specialization, http.StatusCreated); err != nil {
+	if err := rw.WriteCreatedJSON(specialization, "/api/v1/specializations/"+string(specialization.ID)); err != nil {
 		rw.WriteError(err, http.StatusInternalServerError)
 	}
 }
@@ -69,7 +94,7 @@ func (h *SpecializationHandler) handleCreateSpecialization(w http.ResponseWriter
 func (h *SpecializationHandler) handleGetAllSpecializations(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
-	specializations, err := h.getAllSpecializationsUsecase.Execute()
+	specializations, err := h.getAllSpecializationsUsecase.Execute(api.ClinicFromContext(r.Context()))
 	if err != nil {
 		rw.WriteError(err, http.StatusInternalServerError)
 		return
@@ -80,6 +105,20 @@ func (h *SpecializationHandler) handleGetAllSpecializations(w http.ResponseWrite
 	}
 }
 
+func (h *SpecializationHandler) handleGetSpecializationCounts(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	counts, err := h.getCountsUsecase.Execute(api.ClinicFromContext(r.Context()))
+	if err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := rw.WriteJSON(counts, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
 func (h *SpecializationHandler) handleGetSpecialization(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
@@ -90,8 +129,12 @@ func (h *SpecializationHandler) handleGetSpecialization(w http.ResponseWriter, r
 		return
 	}
 
-	specialization, err := h.getSpecializationUsecase.Execute(id)
+	specialization, err := h.getSpecializationUsecase.Execute(api.ClinicFromContext(r.Context()), id)
 	if err != nil {
+		if errors.Is(err, common.ErrClinicMismatch) {
+			rw.WriteError(err, http.StatusForbidden)
+			return
+		}
 		rw.WriteError(err, http.StatusInternalServerError)
 		return
 	}
@@ -104,3 +147,63 @@ func (h *SpecializationHandler) handleGetSpecialization(w http.ResponseWriter, r
 		rw.WriteError(err, http.StatusInternalServerError)
 	}
 }
+
+func (h *SpecializationHandler) handleGetSpecializationTherapists(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	id := domain.SpecializationID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteBadRequest("Missing specialization ID")
+		return
+	}
+
+	therapists, err := h.getTherapistsUsecase.Execute(id)
+	if err != nil {
+		if errors.Is(err, common.ErrSpecializationNotFound) {
+			rw.WriteNotFound("Specialization not found")
+			return
+		}
+		rw.WriteError(err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := rw.WriteJSON(therapists, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *SpecializationHandler) handleDeleteSpecialization(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	id := domain.SpecializationID(r.PathValue("id"))
+	if id == "" {
+		rw.WriteBadRequest("Missing specialization ID")
+		return
+	}
+
+	output, err := h.deleteUsecase.Execute(delete_specialization.Input{
+		ClinicID:   api.ClinicFromContext(r.Context()),
+		ID:         id,
+		ReassignTo: domain.SpecializationID(r.URL.Query().Get("reassignTo")),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrSpecializationNotFound):
+			rw.WriteNotFound("Specialization not found")
+		case errors.Is(err, common.ErrClinicMismatch):
+			rw.WriteError(err, http.StatusForbidden)
+		case errors.Is(err, delete_specialization.ErrSpecializationInUse):
+			rw.WriteError(err, http.StatusConflict)
+		case errors.Is(err, delete_specialization.ErrReassignTargetNotFound),
+			errors.Is(err, delete_specialization.ErrCannotReassignToSelf):
+			rw.WriteBadRequest(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(output, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
@@ -18,7 +18,7 @@ func NewTestHandler(notificationPort ports.NotificationPort, notificationRepo po
 	return &TestHandler{notificationPort: notificationPort, notificationRepo: notificationRepo}
 }
 
-func (h *TestHandler) RegisterRoutes(mux *http.ServeMux) {
+func (h *TestHandler) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc("POST /api/v1/test/notification", h.handleTestNotification)
 }
 
@@ -0,0 +1,121 @@
+package therapist_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api"
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
+)
+
+func TestTherapistsAreScopedToTheirClinic(t *testing.T) {
+	database, cleanup := setupTherapistTestDB(t)
+	defer cleanup()
+
+	specializationRepo := specialization_db.NewSpecializationRepository(database)
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+	therapistHandler := NewTherapistHandler(
+		*new_therapist.NewUsecase(therapistRepo, specializationRepo),
+		*get_all_therapists.NewUsecase(therapistRepo),
+		*get_therapist.NewUsecase(therapistRepo),
+		update_therapist_info.Usecase{},
+		update_therapist_specializations.Usecase{},
+		update_therapist_device.Usecase{},
+		update_timezone_offset.Usecase{},
+		update_notification_preferences.Usecase{},
+		list_therapists_by_device.Usecase{},
+		update_therapist_languages.Usecase{},
+		update_max_daily_sessions.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	therapistHandler.RegisterRoutes(mux)
+	handler := api.ClinicMiddleware()(mux)
+
+	serve := func(method, path string, clinicID domain.ClinicID, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if clinicID != "" {
+			req.Header.Set(api.ClinicHeader, string(clinicID))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	listTherapists := func(t *testing.T, clinicID domain.ClinicID) []*therapist.Therapist {
+		rec := serve(http.MethodGet, "/api/v1/therapists", clinicID, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var therapists []*therapist.Therapist
+		if err := json.Unmarshal(rec.Body.Bytes(), &therapists); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return therapists
+	}
+
+	const clinicA, clinicB domain.ClinicID = "clinic_a", "clinic_b"
+
+	body, _ := json.Marshal(new_therapist.Input{
+		Name:           "Dr. Clinic A",
+		Email:          "clinic.a@example.com",
+		PhoneNumber:    "+1555000701",
+		WhatsAppNumber: "+1555000701",
+	})
+	rec := serve(http.MethodPost, "/api/v1/therapists", clinicA, body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var created therapist.Therapist
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if created.ClinicID != clinicA {
+		t.Fatalf("Expected the therapist to belong to %s, got %s", clinicA, created.ClinicID)
+	}
+
+	t.Run("Listed for its own clinic", func(t *testing.T) {
+		therapists := listTherapists(t, clinicA)
+		if len(therapists) != 1 || therapists[0].ID != created.ID {
+			t.Fatalf("Expected only %s, got %v", created.ID, therapists)
+		}
+	})
+
+	t.Run("Invisible to another clinic's list", func(t *testing.T) {
+		if therapists := listTherapists(t, clinicB); len(therapists) != 0 {
+			t.Fatalf("Expected no therapists for %s, got %d", clinicB, len(therapists))
+		}
+	})
+
+	t.Run("Another clinic is forbidden from reading it", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/api/v1/therapists/"+string(created.ID), clinicB, nil)
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Missing clinic header", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/api/v1/therapists", "", nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+}
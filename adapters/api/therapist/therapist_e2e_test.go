@@ -8,6 +8,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
 	specialization_handler "github.com/mishkahtherapy/brain/adapters/api/specialization"
 	"github.com/mishkahtherapy/brain/adapters/db"
 	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
@@ -16,14 +17,21 @@ import (
 	"github.com/mishkahtherapy/brain/core/domain/specialization"
 	"github.com/mishkahtherapy/brain/core/domain/therapist"
 	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/delete_specialization"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_all_specializations"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_counts"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_therapists"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/new_specialization"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
 
@@ -42,6 +50,7 @@ func TestTherapistE2E(t *testing.T) {
 	newSpecializationUsecase := new_specialization.NewUsecase(specializationRepo)
 	getAllSpecializationsUsecase := get_all_specializations.NewUsecase(specializationRepo)
 	getSpecializationUsecase := get_specialization.NewUsecase(specializationRepo)
+	getSpecializationCountsUsecase := get_specialization_counts.NewUsecase(specializationRepo)
 
 	// Setup therapist usecases
 	newTherapistUsecase := new_therapist.NewUsecase(therapistRepo, specializationRepo)
@@ -49,12 +58,11 @@ func TestTherapistE2E(t *testing.T) {
 	getTherapistUsecase := get_therapist.NewUsecase(therapistRepo)
 	updateTherapistInfoUsecase := update_therapist_info.NewUsecase(therapistRepo)
 	updateTherapistSpecializationsUsecase := update_therapist_specializations.NewUsecase(therapistRepo, specializationRepo)
-	// TODO: add mock firebase_notifier
-	updateTherapistDeviceUsecase := update_therapist_device.NewUsecase(therapistRepo, notificationRepo)
+	updateTherapistDeviceUsecase := update_therapist_device.NewUsecase(therapistRepo, &TestNotificationPort{})
 	updateTherapistTimezoneOffsetUsecase := update_timezone_offset.NewUsecase(therapistRepo)
 	// Setup handlers
-	specializationHandler := specialization_handler.NewSpecializationHandler(*newSpecializationUsecase, *getAllSpecializationsUsecase, *getSpecializationUsecase)
-	therapistHandler := NewTherapistHandler(*newTherapistUsecase, *getAllTherapistsUsecase, *getTherapistUsecase, *updateTherapistInfoUsecase, *updateTherapistSpecializationsUsecase, *updateTherapistDeviceUsecase, *updateTherapistTimezoneOffsetUsecase)
+	specializationHandler := specialization_handler.NewSpecializationHandler(*newSpecializationUsecase, *getAllSpecializationsUsecase, *getSpecializationUsecase, *getSpecializationCountsUsecase, get_specialization_therapists.Usecase{}, delete_specialization.Usecase{}, testutils.NewClinicGuard(db))
+	therapistHandler := NewTherapistHandler(*newTherapistUsecase, *getAllTherapistsUsecase, *getTherapistUsecase, *updateTherapistInfoUsecase, *updateTherapistSpecializationsUsecase, *updateTherapistDeviceUsecase, *updateTherapistTimezoneOffsetUsecase, *update_notification_preferences.NewUsecase(therapistRepo), *list_therapists_by_device.NewUsecase(therapistRepo), *update_therapist_languages.NewUsecase(therapistRepo), *update_max_daily_sessions.NewUsecase(therapistRepo), testutils.NewClinicGuard(db))
 
 	// Setup router
 	mux := http.NewServeMux()
@@ -92,6 +100,9 @@ func TestTherapistE2E(t *testing.T) {
 		if err := json.Unmarshal(createRec.Body.Bytes(), &createdTherapist); err != nil {
 			t.Fatalf("Failed to parse created therapist: %v", err)
 		}
+		if location := createRec.Header().Get("Location"); location != "/api/v1/therapists/"+string(createdTherapist.ID) {
+			t.Errorf("Expected Location of the created therapist, got %q", location)
+		}
 
 		// Verify created therapist data
 		if createdTherapist.Name != "Dr. Sarah Johnson" {
@@ -522,6 +533,13 @@ func TestTherapistE2E(t *testing.T) {
 	})
 }
 
+// TestNotificationPort is a no-op notifier so device updates don't reach firebase
+type TestNotificationPort struct{}
+
+func (p *TestNotificationPort) SendNotification(deviceID domain.DeviceID, notification ports.Notification) (*ports.NotificationID, error) {
+	return nil, nil
+}
+
 // Helper function to create test specializations
 func createTestSpecialization(t *testing.T, mux *http.ServeMux, name string) *specialization.Specialization {
 	createPayload := map[string]string{
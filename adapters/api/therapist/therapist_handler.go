@@ -1,8 +1,9 @@
 package therapist_handler
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/mishkahtherapy/brain/adapters/api"
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -10,9 +11,13 @@ import (
 	"github.com/mishkahtherapy/brain/core/usecases/common"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
 )
@@ -25,6 +30,11 @@ type TherapistHandler struct {
 	updateTherapistSpecializationsUsecase update_therapist_specializations.Usecase
 	updateTherapistDeviceUsecase          update_therapist_device.Usecase
 	updateTherapistTimezoneOffsetUsecase  update_timezone_offset.Usecase
+	updateNotificationPreferencesUsecase  update_notification_preferences.Usecase
+	listTherapistsByDeviceUsecase         list_therapists_by_device.Usecase
+	updateTherapistLanguagesUsecase       update_therapist_languages.Usecase
+	updateMaxDailySessionsUsecase         update_max_daily_sessions.Usecase
+	clinicGuard                           *api.ClinicGuard
 }
 
 func NewTherapistHandler(
@@ -35,6 +45,11 @@ func NewTherapistHandler(
 	updateSpecializationsUsecase update_therapist_specializations.Usecase,
 	updateTherapistDeviceUsecase update_therapist_device.Usecase,
 	updateTherapistTimezoneOffsetUsecase update_timezone_offset.Usecase,
+	updateNotificationPreferencesUsecase update_notification_preferences.Usecase,
+	listTherapistsByDeviceUsecase list_therapists_by_device.Usecase,
+	updateTherapistLanguagesUsecase update_therapist_languages.Usecase,
+	updateMaxDailySessionsUsecase update_max_daily_sessions.Usecase,
+	clinicGuard *api.ClinicGuard,
 ) *TherapistHandler {
 	return &TherapistHandler{
 		newTherapistUsecase:                   newUsecase,
@@ -44,6 +59,11 @@ func NewTherapistHandler(
 		updateTherapistSpecializationsUsecase: updateSpecializationsUsecase,
 		updateTherapistDeviceUsecase:          updateTherapistDeviceUsecase,
 		updateTherapistTimezoneOffsetUsecase:  updateTherapistTimezoneOffsetUsecase,
+		updateNotificationPreferencesUsecase:  updateNotificationPreferencesUsecase,
+		listTherapistsByDeviceUsecase:         listTherapistsByDeviceUsecase,
+		updateTherapistLanguagesUsecase:       updateTherapistLanguagesUsecase,
+		updateMaxDailySessionsUsecase:         updateMaxDailySessionsUsecase,
+		clinicGuard:                           clinicGuard,
 	}
 }
 
@@ -63,24 +83,43 @@ func (h *TherapistHandler) SetUsecases(
 	h.updateTherapistDeviceUsecase = updateTherapistDeviceUsecase
 }
 
-func (h *TherapistHandler) RegisterRoutes(mux *http.ServeMux) {
+func (h *TherapistHandler) RegisterRoutes(mux api.Router) {
 	mux.HandleFunc("POST /api/v1/therapists", h.handleNewTherapist)
 	mux.HandleFunc("GET /api/v1/therapists", h.handleGetAllTherapists)
-	mux.HandleFunc("GET /api/v1/therapists/{id}", h.handleGetTherapist)
-	mux.HandleFunc("PUT /api/v1/therapists/{id}", h.handleUpdateTherapistInfo)
-	mux.HandleFunc("PUT /api/v1/therapists/{id}/specializations", h.handleUpdateTherapistSpecializations)
-	mux.HandleFunc("PUT /api/v1/therapists/{id}/device", h.handleUpdateTherapistDevice)
-	mux.HandleFunc("PUT /api/v1/therapists/{id}/timezone-offset", h.handleUpdateTherapistTimezoneOffset)
+	mux.HandleFunc("GET /api/v1/therapists/{id}", h.clinicGuard.Therapist("id", h.handleGetTherapist))
+	mux.HandleFunc("PUT /api/v1/therapists/{id}", h.clinicGuard.Therapist("id", h.handleUpdateTherapistInfo))
+	mux.HandleFunc("PATCH /api/v1/therapists/{id}", h.clinicGuard.Therapist("id", h.handlePatchTherapistInfo))
+	mux.HandleFunc("PUT /api/v1/therapists/{id}/specializations", h.clinicGuard.Therapist("id", h.handleUpdateTherapistSpecializations))
+	mux.HandleFunc("PUT /api/v1/therapists/{id}/languages", h.clinicGuard.Therapist("id", h.handleUpdateTherapistLanguages))
+	mux.HandleFunc("PUT /api/v1/therapists/{id}/device", h.clinicGuard.Therapist("id", h.handleUpdateTherapistDevice))
+	mux.HandleFunc("PUT /api/v1/therapists/{id}/timezone-offset", h.clinicGuard.Therapist("id", h.handleUpdateTherapistTimezoneOffset))
+	mux.HandleFunc("PUT /api/v1/therapists/{id}/notification-preferences", h.clinicGuard.Therapist("id", h.handleUpdateNotificationPreferences))
+	mux.HandleFunc("PUT /api/v1/therapists/{id}/max-daily-sessions", h.clinicGuard.Therapist("id", h.handleUpdateMaxDailySessions))
+	mux.HandleFunc("GET /api/v1/admin/therapists/by-device", h.handleListTherapistsByDevice)
 }
 
 func (h *TherapistHandler) handleNewTherapist(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
 	var input new_therapist.Input
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+	if err := api.DecodeJSON(r, &input); err != nil {
 		rw.WriteBadRequest(err.Error())
 		return
 	}
+	input.ClinicID = api.ClinicFromContext(r.Context())
+
+	// With ?validate=all, report every invalid field together
+	if api.WantsAllValidationErrors(r) {
+		if err := h.newTherapistUsecase.Validate(input); err != nil {
+			var validationErrs *domain.ValidationErrors
+			if errors.As(err, &validationErrs) {
+				rw.WriteValidationErrors(validationErrs)
+			} else {
+				rw.WriteError(err, http.StatusInternalServerError)
+			}
+			return
+		}
+	}
 
 	newTherapist, err := h.newTherapistUsecase.Execute(input)
 	if err != nil {
@@ -91,7 +130,10 @@ func (h *TherapistHandler) handleNewTherapist(w http.ResponseWriter, r *http.Req
 			therapist.ErrTherapistPhoneRequired,
 			therapist.ErrTherapistWhatsAppRequired,
 			therapist.ErrTherapistInvalidPhone,
-			therapist.ErrTherapistInvalidWhatsApp:
+			therapist.ErrTherapistInvalidWhatsApp,
+			therapist.ErrTherapistInvalidPhotoURL,
+			therapist.ErrTherapistInvalidLanguage,
+			therapist.ErrTherapistInvalidDefaultLanguage:
 			rw.WriteBadRequest(err.Error())
 		case therapist.ErrTherapistAlreadyExists,
 			therapist.ErrTherapistEmailExists,
@@ -105,7 +147,7 @@ func (h *TherapistHandler) handleNewTherapist(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if err := rw.WriteJSON(newTherapist, http.StatusCreated); err != nil {
+	if err := rw.WriteCreatedJSON(newTherapist, "/api/v1/therapists/"+string(newTherapist.ID)); err != nil {
 		rw.WriteError(err, http.StatusInternalServerError)
 	}
 }
@@ -113,7 +155,21 @@ func (h *TherapistHandler) handleNewTherapist(w http.ResponseWriter, r *http.Req
 func (h *TherapistHandler) handleGetAllTherapists(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
-	therapists, err := h.getAllTherapistsUsecase.Execute()
+	// Parse optional filters, e.g. ?specialization=anxiety&speaksEnglish=true
+	input := get_all_therapists.Input{
+		ClinicID:       api.ClinicFromContext(r.Context()),
+		Specialization: r.URL.Query().Get("specialization"),
+	}
+	if speaksEnglishParam := r.URL.Query().Get("speaksEnglish"); speaksEnglishParam != "" {
+		speaksEnglish, err := strconv.ParseBool(speaksEnglishParam)
+		if err != nil {
+			rw.WriteBadRequest("invalid speaksEnglish parameter. Expected true or false")
+			return
+		}
+		input.MustSpeakEnglish = speaksEnglish
+	}
+
+	therapists, err := h.getAllTherapistsUsecase.Execute(input)
 	if err != nil {
 		rw.WriteError(err, http.StatusInternalServerError)
 		return
@@ -134,12 +190,16 @@ func (h *TherapistHandler) handleGetTherapist(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	therapist, err := h.getTherapistUsecase.Execute(id)
+	therapist, err := h.getTherapistUsecase.Execute(api.ClinicFromContext(r.Context()), id)
 	if err != nil {
 		if err == common.ErrTherapistNotFound {
 			rw.WriteNotFound(err.Error())
 			return
 		}
+		if err == common.ErrClinicMismatch {
+			rw.WriteError(err, http.StatusForbidden)
+			return
+		}
 		rw.WriteError(err, http.StatusInternalServerError)
 		return
 	}
@@ -161,28 +221,82 @@ func (h *TherapistHandler) handleUpdateTherapistInfo(w http.ResponseWriter, r *h
 
 	// Parse request body to get update data
 	var requestBody struct {
-		Name           string                `json:"name"`
-		Email          domain.Email          `json:"email"`
-		PhoneNumber    domain.PhoneNumber    `json:"phoneNumber"`
-		WhatsAppNumber domain.WhatsAppNumber `json:"whatsAppNumber"`
-		SpeaksEnglish  bool                  `json:"speaksEnglish"`
+		Name            string                 `json:"name"`
+		Email           domain.Email           `json:"email"`
+		PhoneNumber     domain.PhoneNumber     `json:"phoneNumber"`
+		WhatsAppNumber  domain.WhatsAppNumber  `json:"whatsAppNumber"`
+		SpeaksEnglish   bool                   `json:"speaksEnglish"`
+		Bio             string                 `json:"bio"`
+		PhotoURL        string                 `json:"photoUrl"`
+		DefaultLanguage domain.SessionLanguage `json:"defaultLanguage"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
 		rw.WriteBadRequest(err.Error())
 		return
 	}
 
 	input := update_therapist_info.Input{
-		TherapistID:    therapistID,
-		Name:           requestBody.Name,
-		Email:          requestBody.Email,
-		PhoneNumber:    requestBody.PhoneNumber,
-		WhatsAppNumber: requestBody.WhatsAppNumber,
-		SpeaksEnglish:  requestBody.SpeaksEnglish,
+		TherapistID:     therapistID,
+		Name:            requestBody.Name,
+		Email:           requestBody.Email,
+		PhoneNumber:     requestBody.PhoneNumber,
+		WhatsAppNumber:  requestBody.WhatsAppNumber,
+		SpeaksEnglish:   requestBody.SpeaksEnglish,
+		Bio:             requestBody.Bio,
+		PhotoURL:        requestBody.PhotoURL,
+		DefaultLanguage: requestBody.DefaultLanguage,
 	}
 
 	updatedTherapist, err := h.updateTherapistInfoUsecase.Execute(input)
+	writeUpdatedTherapist(rw, updatedTherapist, err)
+}
+
+func (h *TherapistHandler) handlePatchTherapistInfo(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist id from path
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteBadRequest("Missing therapist ID")
+		return
+	}
+
+	// Parse request body, omitted fields are left unchanged
+	var requestBody struct {
+		Name            *string                 `json:"name"`
+		Email           *domain.Email           `json:"email"`
+		PhoneNumber     *domain.PhoneNumber     `json:"phoneNumber"`
+		WhatsAppNumber  *domain.WhatsAppNumber  `json:"whatsAppNumber"`
+		SpeaksEnglish   *bool                   `json:"speaksEnglish"`
+		Bio             *string                 `json:"bio"`
+		PhotoURL        *string                 `json:"photoUrl"`
+		DefaultLanguage *domain.SessionLanguage `json:"defaultLanguage"`
+	}
+
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+
+	input := update_therapist_info.PatchInput{
+		TherapistID:     therapistID,
+		Name:            requestBody.Name,
+		Email:           requestBody.Email,
+		PhoneNumber:     requestBody.PhoneNumber,
+		WhatsAppNumber:  requestBody.WhatsAppNumber,
+		SpeaksEnglish:   requestBody.SpeaksEnglish,
+		Bio:             requestBody.Bio,
+		PhotoURL:        requestBody.PhotoURL,
+		DefaultLanguage: requestBody.DefaultLanguage,
+	}
+
+	updatedTherapist, err := h.updateTherapistInfoUsecase.Patch(input)
+	writeUpdatedTherapist(rw, updatedTherapist, err)
+}
+
+// writeUpdatedTherapist writes the result of a therapist info update
+func writeUpdatedTherapist(rw *api.ResponseWriter, updatedTherapist *therapist.Therapist, err error) {
 	if err != nil {
 		// Handle specific business logic errors
 		switch err {
@@ -195,7 +309,9 @@ func (h *TherapistHandler) handleUpdateTherapistInfo(w http.ResponseWriter, r *h
 			therapist.ErrTherapistPhoneRequired,
 			therapist.ErrTherapistWhatsAppRequired,
 			therapist.ErrTherapistInvalidPhone,
-			therapist.ErrTherapistInvalidWhatsApp:
+			therapist.ErrTherapistInvalidWhatsApp,
+			therapist.ErrTherapistInvalidPhotoURL,
+			therapist.ErrTherapistInvalidDefaultLanguage:
 			rw.WriteBadRequest(err.Error())
 		case therapist.ErrTherapistEmailExists,
 			therapist.ErrTherapistWhatsAppExists:
@@ -226,7 +342,7 @@ func (h *TherapistHandler) handleUpdateTherapistSpecializations(w http.ResponseW
 		SpecializationIDs []domain.SpecializationID `json:"specializationIds"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
 		rw.WriteBadRequest(err.Error())
 		return
 	}
@@ -255,6 +371,46 @@ func (h *TherapistHandler) handleUpdateTherapistSpecializations(w http.ResponseW
 	}
 }
 
+func (h *TherapistHandler) handleUpdateTherapistLanguages(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist id from path
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteBadRequest("Missing therapist ID")
+		return
+	}
+
+	var requestBody struct {
+		Languages []domain.LanguageCode `json:"languages"`
+	}
+
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+
+	updatedTherapist, err := h.updateTherapistLanguagesUsecase.Execute(update_therapist_languages.Input{
+		TherapistID: therapistID,
+		Languages:   requestBody.Languages,
+	})
+	if err != nil {
+		switch err {
+		case therapist.ErrTherapistInvalidLanguage:
+			rw.WriteBadRequest(err.Error())
+		case update_therapist_languages.ErrTherapistNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(updatedTherapist, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
 func (h *TherapistHandler) handleUpdateTherapistDevice(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
@@ -270,7 +426,7 @@ func (h *TherapistHandler) handleUpdateTherapistDevice(w http.ResponseWriter, r
 		DeviceID domain.DeviceID `json:"deviceId"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
 		rw.WriteBadRequest(err.Error())
 		return
 	}
@@ -289,6 +445,26 @@ func (h *TherapistHandler) handleUpdateTherapistDevice(w http.ResponseWriter, r
 	rw.WriteOK()
 }
 
+func (h *TherapistHandler) handleListTherapistsByDevice(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	deviceID := domain.DeviceID(r.URL.Query().Get("deviceId"))
+	if deviceID == "" {
+		rw.WriteBadRequest("Missing deviceId parameter")
+		return
+	}
+
+	therapists, err := h.listTherapistsByDeviceUsecase.Execute(api.ClinicFromContext(r.Context()), deviceID)
+	if err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := rw.WriteJSON(therapists, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
 func (h *TherapistHandler) handleUpdateTherapistTimezoneOffset(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
@@ -304,7 +480,7 @@ func (h *TherapistHandler) handleUpdateTherapistTimezoneOffset(w http.ResponseWr
 		TimezoneOffset domain.TimezoneOffset `json:"timezoneOffset"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
 		rw.WriteBadRequest(err.Error())
 		return
 	}
@@ -330,3 +506,75 @@ func (h *TherapistHandler) handleUpdateTherapistTimezoneOffset(w http.ResponseWr
 		rw.WriteError(err, http.StatusInternalServerError)
 	}
 }
+
+func (h *TherapistHandler) handleUpdateMaxDailySessions(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist id from path
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteBadRequest("Missing therapist ID")
+		return
+	}
+
+	var input update_max_daily_sessions.Input
+	if err := api.DecodeJSON(r, &input); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+	input.TherapistID = therapistID
+
+	updated, err := h.updateMaxDailySessionsUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case common.ErrTherapistIDIsRequired,
+			update_max_daily_sessions.ErrInvalidMaxDailySessions:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrTherapistNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(updated, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *TherapistHandler) handleUpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist id from path
+	therapistID := domain.TherapistID(r.PathValue("id"))
+	if therapistID == "" {
+		rw.WriteBadRequest("Missing therapist ID")
+		return
+	}
+
+	// Omitted preferences keep their current value
+	var input update_notification_preferences.Input
+	if err := api.DecodeJSON(r, &input); err != nil {
+		rw.WriteBadRequest(err.Error())
+		return
+	}
+	input.TherapistID = therapistID
+
+	updated, err := h.updateNotificationPreferencesUsecase.Execute(input)
+	if err != nil {
+		switch err {
+		case common.ErrTherapistIDIsRequired:
+			rw.WriteBadRequest(err.Error())
+		case common.ErrTherapistNotFound:
+			rw.WriteNotFound(err.Error())
+		default:
+			rw.WriteError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(updated, http.StatusOK); err != nil {
+		rw.WriteError(err, http.StatusInternalServerError)
+	}
+}
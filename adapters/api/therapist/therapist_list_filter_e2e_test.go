@@ -0,0 +1,154 @@
+package therapist_handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/new_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestListTherapistsWithFilters(t *testing.T) {
+	// Setup test database
+	database, cleanup := setupTherapistTestDB(t)
+	defer cleanup()
+
+	// Setup repositories
+	specializationRepo := specialization_db.NewSpecializationRepository(database)
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+
+	// Setup usecases
+	newSpecializationUsecase := new_specialization.NewUsecase(specializationRepo)
+	newTherapistUsecase := new_therapist.NewUsecase(therapistRepo, specializationRepo)
+	therapistHandler := NewTherapistHandler(
+		*newTherapistUsecase,
+		*get_all_therapists.NewUsecase(therapistRepo),
+		*get_therapist.NewUsecase(therapistRepo),
+		*update_therapist_info.NewUsecase(therapistRepo),
+		*update_therapist_specializations.NewUsecase(therapistRepo, specializationRepo),
+		*update_therapist_device.NewUsecase(therapistRepo, &TestNotificationPort{}),
+		*update_timezone_offset.NewUsecase(therapistRepo),
+		*update_notification_preferences.NewUsecase(therapistRepo),
+		*list_therapists_by_device.NewUsecase(therapistRepo),
+		*update_therapist_languages.NewUsecase(therapistRepo),
+		update_max_daily_sessions.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	// Setup router
+	mux := http.NewServeMux()
+	therapistHandler.RegisterRoutes(mux)
+
+	// Seed specializations and therapists
+	anxiety, err := newSpecializationUsecase.Execute(new_specialization.Input{Name: "anxiety"})
+	if err != nil {
+		t.Fatalf("Failed to create specialization: %v", err)
+	}
+	depression, err := newSpecializationUsecase.Execute(new_specialization.Input{Name: "depression"})
+	if err != nil {
+		t.Fatalf("Failed to create specialization: %v", err)
+	}
+
+	createTherapist := func(name, email, phone string, speaksEnglish bool, specializationIDs ...domain.SpecializationID) *therapist.Therapist {
+		created, err := newTherapistUsecase.Execute(new_therapist.Input{
+			Name:              name,
+			Email:             domain.Email(email),
+			PhoneNumber:       domain.PhoneNumber(phone),
+			WhatsAppNumber:    domain.WhatsAppNumber(phone),
+			SpeaksEnglish:     speaksEnglish,
+			SpecializationIDs: specializationIDs,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create therapist %s: %v", name, err)
+		}
+		return created
+	}
+
+	englishAnxiety := createTherapist("English Anxiety", "english.anxiety@example.com", "+1555000001", true, anxiety.ID)
+	arabicAnxiety := createTherapist("Arabic Anxiety", "arabic.anxiety@example.com", "+1555000002", false, anxiety.ID)
+	englishDepression := createTherapist("English Depression", "english.depression@example.com", "+1555000003", true, depression.ID)
+
+	listTherapists := func(t *testing.T, query string) map[domain.TherapistID]bool {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/therapists"+query, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var therapists []*therapist.Therapist
+		if err := json.Unmarshal(rec.Body.Bytes(), &therapists); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		ids := make(map[domain.TherapistID]bool)
+		for _, th := range therapists {
+			ids[th.ID] = true
+		}
+		return ids
+	}
+
+	t.Run("No filters lists every therapist", func(t *testing.T) {
+		ids := listTherapists(t, "")
+		if len(ids) != 3 {
+			t.Errorf("Expected 3 therapists, got %d", len(ids))
+		}
+	})
+
+	t.Run("Filter by specialization", func(t *testing.T) {
+		ids := listTherapists(t, "?specialization=anxiety")
+		if len(ids) != 2 || !ids[englishAnxiety.ID] || !ids[arabicAnxiety.ID] {
+			t.Errorf("Expected only the anxiety therapists, got %v", ids)
+		}
+		if ids[englishDepression.ID] {
+			t.Error("Expected depression therapist to be excluded")
+		}
+	})
+
+	t.Run("Filter by specialization and English", func(t *testing.T) {
+		ids := listTherapists(t, "?specialization=anxiety&speaksEnglish=true")
+		if len(ids) != 1 || !ids[englishAnxiety.ID] {
+			t.Errorf("Expected only the English-speaking anxiety therapist, got %v", ids)
+		}
+		if ids[arabicAnxiety.ID] {
+			t.Error("Expected non-English therapist to be excluded")
+		}
+	})
+
+	t.Run("Filter by English only", func(t *testing.T) {
+		ids := listTherapists(t, "?speaksEnglish=true")
+		if len(ids) != 2 || !ids[englishAnxiety.ID] || !ids[englishDepression.ID] {
+			t.Errorf("Expected only the English-speaking therapists, got %v", ids)
+		}
+	})
+
+	t.Run("Invalid speaksEnglish value", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/therapists?speaksEnglish=maybe", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package therapist_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/new_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestPatchTherapistInfo(t *testing.T) {
+	// Setup test database
+	database, cleanup := setupTherapistTestDB(t)
+	defer cleanup()
+
+	// Setup repositories
+	specializationRepo := specialization_db.NewSpecializationRepository(database)
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+
+	therapistHandler := NewTherapistHandler(
+		*new_therapist.NewUsecase(therapistRepo, specializationRepo),
+		*get_all_therapists.NewUsecase(therapistRepo),
+		*get_therapist.NewUsecase(therapistRepo),
+		*update_therapist_info.NewUsecase(therapistRepo),
+		*update_therapist_specializations.NewUsecase(therapistRepo, specializationRepo),
+		*update_therapist_device.NewUsecase(therapistRepo, &TestNotificationPort{}),
+		*update_timezone_offset.NewUsecase(therapistRepo),
+		*update_notification_preferences.NewUsecase(therapistRepo),
+		*list_therapists_by_device.NewUsecase(therapistRepo),
+		*update_therapist_languages.NewUsecase(therapistRepo),
+		update_max_daily_sessions.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	// Setup router
+	mux := http.NewServeMux()
+	therapistHandler.RegisterRoutes(mux)
+
+	anxiety, err := new_specialization.NewUsecase(specializationRepo).Execute(new_specialization.Input{Name: "anxiety"})
+	if err != nil {
+		t.Fatalf("Failed to create specialization: %v", err)
+	}
+
+	body, _ := json.Marshal(new_therapist.Input{
+		Name:              "Dr. Before",
+		Email:             "patch@example.com",
+		PhoneNumber:       "+1555000400",
+		WhatsAppNumber:    "+1555000401",
+		SpeaksEnglish:     true,
+		SpecializationIDs: []domain.SpecializationID{anxiety.ID},
+		Bio:               "Original bio",
+	})
+	req := httptest.NewRequest("POST", "/api/v1/therapists", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var created therapist.Therapist
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PATCH", "/api/v1/therapists/"+string(created.ID), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("patching the name keeps the other fields", func(t *testing.T) {
+		rec := patch(`{"name": "Dr. After"}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var patched therapist.Therapist
+		if err := json.Unmarshal(rec.Body.Bytes(), &patched); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if patched.Name != "Dr. After" {
+			t.Errorf("Expected name %q, got %q", "Dr. After", patched.Name)
+		}
+		if patched.Email != created.Email || patched.PhoneNumber != created.PhoneNumber || patched.WhatsAppNumber != created.WhatsAppNumber {
+			t.Errorf("Expected contact details to be unchanged, got %s %s %s", patched.Email, patched.PhoneNumber, patched.WhatsAppNumber)
+		}
+		if !patched.SpeaksEnglish || patched.Bio != "Original bio" {
+			t.Errorf("Expected speaksEnglish and bio to be unchanged, got %v %q", patched.SpeaksEnglish, patched.Bio)
+		}
+	})
+
+	t.Run("invalid phone number", func(t *testing.T) {
+		rec := patch(`{"phoneNumber": "not-a-number"}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("unknown therapist", func(t *testing.T) {
+		req := httptest.NewRequest("PATCH", "/api/v1/therapists/"+string(domain.NewTherapistID()), bytes.NewBufferString(`{"name": "Dr. Nobody"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package therapist_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
+)
+
+func TestTherapistPhoneNumbersAreNormalized(t *testing.T) {
+	db, cleanup := setupTherapistTestDB(t)
+	defer cleanup()
+
+	therapistRepo := therapist_db.NewTherapistRepository(db)
+	specializationRepo := specialization_db.NewSpecializationRepository(db)
+	therapistHandler := NewTherapistHandler(
+		*new_therapist.NewUsecase(therapistRepo, specializationRepo),
+		get_all_therapists.Usecase{},
+		get_therapist.Usecase{},
+		*update_therapist_info.NewUsecase(therapistRepo),
+		update_therapist_specializations.Usecase{},
+		update_therapist_device.Usecase{},
+		update_timezone_offset.Usecase{},
+		update_notification_preferences.Usecase{},
+		list_therapists_by_device.Usecase{},
+		update_therapist_languages.Usecase{},
+		update_max_daily_sessions.Usecase{},
+		testutils.NewClinicGuard(db),
+	)
+	mux := http.NewServeMux()
+	therapistHandler.RegisterRoutes(mux)
+
+	createTherapist := func(email, phoneNumber, whatsAppNumber string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"name":              "Dr. Format",
+			"email":             email,
+			"phoneNumber":       phoneNumber,
+			"whatsAppNumber":    whatsAppNumber,
+			"specializationIds": []string{},
+		})
+		req := httptest.NewRequest("POST", "/api/v1/therapists", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Numbers are stored in E.164", func(t *testing.T) {
+		rec := createTherapist("format@example.com", "+1 (555) 000-0601", "00 1 555 000 0602")
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+
+		var created therapist.Therapist
+		if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+			t.Fatalf("Failed to parse created therapist: %v", err)
+		}
+		if created.PhoneNumber != "+15550000601" {
+			t.Errorf("Expected phone number +15550000601, got %s", created.PhoneNumber)
+		}
+		if created.WhatsAppNumber != "+15550000602" {
+			t.Errorf("Expected WhatsApp number +15550000602, got %s", created.WhatsAppNumber)
+		}
+	})
+
+	t.Run("Duplicate WhatsApp number in another format conflicts", func(t *testing.T) {
+		rec := createTherapist("other-format@example.com", "+15550000603", "+1-555-000-0602")
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusConflict, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Unparseable numbers are rejected", func(t *testing.T) {
+		rec := createTherapist("letters@example.com", "+1 555 CALL NOW", "+15550000604")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+}
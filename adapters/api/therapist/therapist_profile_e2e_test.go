@@ -0,0 +1,188 @@
+package therapist_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/recurring_block_db"
+	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/new_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func TestTherapistBioAndPhoto(t *testing.T) {
+	// Setup test database
+	database, cleanup := setupTherapistTestDB(t)
+	defer cleanup()
+
+	// Setup repositories
+	specializationRepo := specialization_db.NewSpecializationRepository(database)
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+
+	therapistHandler := NewTherapistHandler(
+		*new_therapist.NewUsecase(therapistRepo, specializationRepo),
+		*get_all_therapists.NewUsecase(therapistRepo),
+		*get_therapist.NewUsecase(therapistRepo),
+		*update_therapist_info.NewUsecase(therapistRepo),
+		*update_therapist_specializations.NewUsecase(therapistRepo, specializationRepo),
+		*update_therapist_device.NewUsecase(therapistRepo, &TestNotificationPort{}),
+		*update_timezone_offset.NewUsecase(therapistRepo),
+		*update_notification_preferences.NewUsecase(therapistRepo),
+		*list_therapists_by_device.NewUsecase(therapistRepo),
+		*update_therapist_languages.NewUsecase(therapistRepo),
+		update_max_daily_sessions.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	// Setup router
+	mux := http.NewServeMux()
+	therapistHandler.RegisterRoutes(mux)
+
+	anxiety, err := new_specialization.NewUsecase(specializationRepo).Execute(new_specialization.Input{Name: "anxiety"})
+	if err != nil {
+		t.Fatalf("Failed to create specialization: %v", err)
+	}
+
+	const bio = "Licensed therapist focusing on anxiety."
+	const photoURL = "https://cdn.example.com/therapists/profile.jpg"
+
+	createTherapist := func(photoURL string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(new_therapist.Input{
+			Name:              "Dr. Profile",
+			Email:             "profile@example.com",
+			PhoneNumber:       "+1555000100",
+			WhatsAppNumber:    "+1555000100",
+			SpeaksEnglish:     true,
+			SpecializationIDs: []domain.SpecializationID{anxiety.ID},
+			Bio:               bio,
+			PhotoURL:          photoURL,
+		})
+		req := httptest.NewRequest("POST", "/api/v1/therapists", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("rejects a non-https photo URL", func(t *testing.T) {
+		rec := createTherapist("http://cdn.example.com/therapists/profile.jpg")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+
+	rec := createTherapist(photoURL)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var created therapist.Therapist
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	t.Run("round-trips through get by id", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/therapists/"+string(created.ID), nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var fetched therapist.Therapist
+		if err := json.Unmarshal(rec.Body.Bytes(), &fetched); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if fetched.Bio != bio || fetched.PhotoURL != photoURL {
+			t.Errorf("Expected bio %q and photo %q, got %q and %q", bio, photoURL, fetched.Bio, fetched.PhotoURL)
+		}
+	})
+
+	t.Run("appears in the therapist list", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/therapists", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var therapists []therapist.Therapist
+		if err := json.Unmarshal(rec.Body.Bytes(), &therapists); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(therapists) != 1 || therapists[0].Bio != bio || therapists[0].PhotoURL != photoURL {
+			t.Errorf("Expected one therapist with bio and photo, got %+v", therapists)
+		}
+	})
+
+	t.Run("appears in the schedule", func(t *testing.T) {
+		// Open a two hour slot on a day a week from now
+		day := time.Now().UTC().AddDate(0, 0, 7)
+		now := time.Now().UTC()
+		_, err := database.Exec(`
+			INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, domain.NewTimeSlotID(), created.ID, day.Weekday().String(), "10:00", 120, 0, 0, true, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert time slot: %v", err)
+		}
+
+		getSchedule := get_schedule.NewUsecase(
+			therapistRepo,
+			timeslot_db.NewTimeSlotRepository(database),
+			booking_db.NewBookingRepository(database),
+			adhoc_booking_db.NewAdhocBookingRepository(database),
+			recurring_block_db.NewRecurringBlockRepository(database),
+			15,
+			nil,
+			0,
+		)
+		startDate := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		ranges, err := getSchedule.Execute(get_schedule.Input{
+			SpecializationTags: []string{"anxiety"},
+			StartDate:          startDate,
+			EndDate:            startDate.AddDate(0, 0, 1),
+		})
+		if err != nil {
+			t.Fatalf("Failed to get schedule: %v", err)
+		}
+		if len(ranges) == 0 || len(ranges[0].Therapists) == 0 {
+			t.Fatalf("Expected the therapist to be available, got %+v", ranges)
+		}
+
+		encoded, err := json.Marshal(ranges[0].Therapists[0])
+		if err != nil {
+			t.Fatalf("Failed to encode therapist info: %v", err)
+		}
+		var info map[string]any
+		if err := json.Unmarshal(encoded, &info); err != nil {
+			t.Fatalf("Failed to decode therapist info: %v", err)
+		}
+		if info["bio"] != bio || info["photoUrl"] != photoURL {
+			t.Errorf("Expected bio and photoUrl in schedule, got %s", encoded)
+		}
+	})
+}
@@ -44,7 +44,7 @@ func TestTherapistSpeaksEnglish(t *testing.T) {
 		}
 
 		// Retrieve therapist and verify speaksEnglish field
-		retrieved, err := getTherapistUsecase.Execute(therapist.ID)
+		retrieved, err := getTherapistUsecase.Execute(domain.DefaultClinicID, therapist.ID)
 		if err != nil {
 			t.Fatalf("Failed to retrieve therapist: %v", err)
 		}
@@ -72,7 +72,7 @@ func TestTherapistSpeaksEnglish(t *testing.T) {
 		}
 
 		// Retrieve therapist and verify speaksEnglish field
-		retrieved, err := getTherapistUsecase.Execute(therapist.ID)
+		retrieved, err := getTherapistUsecase.Execute(domain.DefaultClinicID, therapist.ID)
 		if err != nil {
 			t.Fatalf("Failed to retrieve therapist: %v", err)
 		}
@@ -109,7 +109,7 @@ func TestTherapistSpeaksEnglish(t *testing.T) {
 		}
 
 		// Retrieve therapist and verify speaksEnglish defaults to false
-		retrieved, err := getTherapistUsecase.Execute(therapistID)
+		retrieved, err := getTherapistUsecase.Execute(domain.DefaultClinicID, therapistID)
 		if err != nil {
 			t.Fatalf("Failed to retrieve therapist: %v", err)
 		}
@@ -153,7 +153,7 @@ func TestTherapistSpeaksEnglish(t *testing.T) {
 		}
 
 		// Retrieve therapist and verify speaksEnglish is still true
-		retrieved, err := getTherapistUsecase.Execute(therapist.ID)
+		retrieved, err := getTherapistUsecase.Execute(domain.DefaultClinicID, therapist.ID)
 		if err != nil {
 			t.Fatalf("Failed to retrieve therapist: %v", err)
 		}
@@ -0,0 +1,53 @@
+package therapist_handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
+)
+
+func TestNewTherapistRejectsUnknownFields(t *testing.T) {
+	// The body is decoded before any usecase runs
+	therapistHandler := NewTherapistHandler(
+		new_therapist.Usecase{},
+		get_all_therapists.Usecase{},
+		get_therapist.Usecase{},
+		update_therapist_info.Usecase{},
+		update_therapist_specializations.Usecase{},
+		update_therapist_device.Usecase{},
+		update_timezone_offset.Usecase{},
+		update_notification_preferences.Usecase{},
+		list_therapists_by_device.Usecase{},
+		update_therapist_languages.Usecase{},
+		update_max_daily_sessions.Usecase{},
+		nil,
+	)
+	mux := http.NewServeMux()
+	therapistHandler.RegisterRoutes(mux)
+
+	body := []byte(`{"name": "Dr. Typo", "emial": "typo@example.com", "phoneNumber": "+1555000500"}`)
+	req := httptest.NewRequest("POST", "/api/v1/therapists", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+	if !bytes.Contains(rec.Body.Bytes(), []byte("emial")) {
+		t.Errorf("Expected the error to name the unknown field, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,115 @@
+package therapist_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
+)
+
+func TestNewTherapistReportsAllValidationErrors(t *testing.T) {
+	db, cleanup := setupTherapistTestDB(t)
+	defer cleanup()
+
+	therapistRepo := therapist_db.NewTherapistRepository(db)
+	specializationRepo := specialization_db.NewSpecializationRepository(db)
+	therapistHandler := NewTherapistHandler(
+		*new_therapist.NewUsecase(therapistRepo, specializationRepo),
+		get_all_therapists.Usecase{},
+		get_therapist.Usecase{},
+		update_therapist_info.Usecase{},
+		update_therapist_specializations.Usecase{},
+		update_therapist_device.Usecase{},
+		update_timezone_offset.Usecase{},
+		update_notification_preferences.Usecase{},
+		list_therapists_by_device.Usecase{},
+		update_therapist_languages.Usecase{},
+		update_max_daily_sessions.Usecase{},
+		testutils.NewClinicGuard(db),
+	)
+	mux := http.NewServeMux()
+	therapistHandler.RegisterRoutes(mux)
+
+	post := func(path string, body map[string]interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	invalidBody := map[string]interface{}{
+		"name":              "",
+		"email":             "dr.invalid@example.com",
+		"phoneNumber":       "+1 CALL NOW",
+		"whatsAppNumber":    "",
+		"photoUrl":          "http://example.com/photo.jpg",
+		"languages":         []string{"xx-invalid"},
+		"specializationIds": []string{},
+	}
+
+	t.Run("every invalid field is reported with 422", func(t *testing.T) {
+		rec := post("/api/v1/therapists?validate=all", invalidBody)
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+		}
+
+		var response domain.ValidationErrors
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse validation errors: %v", err)
+		}
+		reported := make(map[string]bool)
+		for _, fieldErr := range response.Errors {
+			if fieldErr.Message == "" {
+				t.Errorf("Expected a message for field %s", fieldErr.Field)
+			}
+			reported[fieldErr.Field] = true
+		}
+		for _, field := range []string{"name", "phoneNumber", "whatsAppNumber", "photoUrl", "languages"} {
+			if !reported[field] {
+				t.Errorf("Expected an error for %s, got %+v", field, response.Errors)
+			}
+		}
+		if reported["email"] {
+			t.Errorf("Expected the valid email not to be reported, got %+v", response.Errors)
+		}
+	})
+
+	t.Run("without the mode only the first error is returned", func(t *testing.T) {
+		rec := post("/api/v1/therapists", invalidBody)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("a valid body is still created", func(t *testing.T) {
+		rec := post("/api/v1/therapists?validate=all", map[string]interface{}{
+			"name":              "Dr. Valid",
+			"email":             "dr.valid@example.com",
+			"phoneNumber":       "+15550000701",
+			"whatsAppNumber":    "+15550000702",
+			"specializationIds": []string{},
+		})
+		if rec.Code != http.StatusCreated {
+			t.Errorf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+	})
+}
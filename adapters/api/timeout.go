@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ErrorCodeRequestTimeout is returned when a request exceeds the server's
+// request timeout
+const ErrorCodeRequestTimeout ErrorCode = "request.timeout"
+
+// TimeoutMiddleware answers 503 when a handler runs longer than timeout. The
+// handler keeps running in the background until it returns; only its response
+// is discarded. Requests matching one of exemptPatterns, ServeMux patterns
+// such as event streams, are not limited. A non-positive timeout disables the
+// middleware.
+func TimeoutMiddleware(timeout time.Duration, exemptPatterns ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+
+		// Match exemptions by route, a client-sent header could opt any request out
+		exempt := http.NewServeMux()
+		for _, pattern := range exemptPatterns {
+			exempt.Handle(pattern, next)
+		}
+
+		body, _ := json.Marshal(codedErrorResponse{Error: codedError{
+			Code:    ErrorCodeRequestTimeout,
+			Message: "request timed out",
+		}})
+		limited := http.TimeoutHandler(next, timeout, string(body))
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, pattern := exempt.Handler(r); pattern != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			// The timeout body is JSON; handlers that finish in time set their own
+			w.Header().Set("Content-Type", "application/json")
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(100 * time.Millisecond):
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+	fast := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Error("expected the request context to carry a deadline")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		handler  http.Handler
+		timeout  time.Duration
+		path     string
+		accept   string
+		expected int
+	}{
+		{
+			name:     "slow handler times out",
+			handler:  slow,
+			timeout:  10 * time.Millisecond,
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "fast handler completes",
+			handler:  fast,
+			timeout:  time.Second,
+			expected: http.StatusOK,
+		},
+		{
+			name:     "exempt routes are not limited",
+			handler:  slow,
+			timeout:  10 * time.Millisecond,
+			path:     "/api/v1/therapists/therapist_1/bookings/stream",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "an event-stream Accept header does not exempt other routes",
+			handler:  slow,
+			timeout:  10 * time.Millisecond,
+			accept:   "text/event-stream",
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "zero timeout disables the middleware",
+			handler:  slow,
+			timeout:  0,
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := TimeoutMiddleware(test.timeout, "GET /api/v1/therapists/{id}/bookings/stream")(test.handler)
+
+			path := test.path
+			if path == "" {
+				path = "/"
+			}
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if test.accept != "" {
+				req.Header.Set("Accept", test.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != test.expected {
+				t.Fatalf("expected status %d, got %d", test.expected, rec.Code)
+			}
+			if test.expected != http.StatusServiceUnavailable {
+				return
+			}
+
+			var body codedErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode timeout body: %v", err)
+			}
+			if body.Error.Code != ErrorCodeRequestTimeout {
+				t.Errorf("expected code %q, got %q", ErrorCodeRequestTimeout, body.Error.Code)
+			}
+		})
+	}
+}
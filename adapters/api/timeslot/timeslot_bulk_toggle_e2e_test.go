@@ -6,15 +6,25 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
 	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/bulk_toggle_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
 )
 
@@ -30,12 +40,15 @@ func TestBulkToggleTimeslots(t *testing.T) {
 	repos := testutils.SetupRepositories(database)
 
 	// Setup usecases
-	createUsecase := create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
-	getUsecase := get_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
-	updateUsecase := update_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
-	deleteUsecase := delete_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	createUsecase := create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, 12*60, 15, timeslot.ClinicHours{})
+	getUsecase := get_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	updateUsecase := update_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, timeslot.ClinicHours{})
+	deleteUsecase := delete_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
 	listUsecase := list_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
-	bulkToggleUsecase := bulk_toggle_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	bulkToggleUsecase := bulk_toggle_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+	setActiveUsecase := set_therapist_timeslot_active.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	listBookingsUsecase := list_timeslot_bookings.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	deleteForDayUsecase := delete_therapist_timeslots_for_day.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
 
 	// Setup handler
 	timeslotHandler := NewTimeslotHandler(
@@ -45,6 +58,13 @@ func TestBulkToggleTimeslots(t *testing.T) {
 		*updateUsecase,
 		*deleteUsecase,
 		*listUsecase,
+		*setActiveUsecase,
+		*listBookingsUsecase,
+		*deleteForDayUsecase,
+		export_therapist_timeslots.Usecase{},
+		import_therapist_timeslots.Usecase{},
+		list_raw_therapist_timeslots.Usecase{},
+		testutils.NewClinicGuard(database),
 	)
 
 	// Setup router
@@ -89,6 +109,7 @@ func TestBulkToggleTimeslots(t *testing.T) {
 
 		expectedMessage := "Bulk toggle completed successfully"
 		testutils.AssertStringField(t, response, "message", expectedMessage)
+		testutils.AssertFloatField(t, response, "affected", 3)
 
 		// Verify all timeslots are now inactive
 		for _, timeslotID := range timeslotIDs {
@@ -150,6 +171,7 @@ func TestBulkToggleTimeslots(t *testing.T) {
 
 		expectedMessage := "Bulk toggle completed successfully"
 		testutils.AssertStringField(t, response, "message", expectedMessage)
+		testutils.AssertFloatField(t, response, "affected", 3)
 
 		// Verify all timeslots are now active
 		for _, timeslotID := range timeslotIDs {
@@ -160,6 +182,94 @@ func TestBulkToggleTimeslots(t *testing.T) {
 		}
 	})
 
+	t.Run("Dry run reports the timeslots a real run changes", func(t *testing.T) {
+		dryRunTherapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Dry Run")
+		testClientID := testutils.CreateTestClient(t, database)
+
+		bookedSlotID := testutils.CreateTestTimeSlotCustom(t, database, dryRunTherapistID, "Monday", "09:00", 60, true)
+		freeSlotID := testutils.CreateTestTimeSlotCustom(t, database, dryRunTherapistID, "Tuesday", "09:00", 60, true)
+		inactiveSlotID := testutils.CreateTestTimeSlotCustom(t, database, dryRunTherapistID, "Wednesday", "09:00", 60, false)
+
+		now := domain.NewUTCTimestamp()
+		bookingID := domain.NewBookingID()
+		err := repos.BookingRepo.Create(&booking.Booking{
+			ID:          bookingID,
+			TimeSlotID:  bookedSlotID,
+			TherapistID: dryRunTherapistID,
+			ClientID:    testClientID,
+			State:       booking.BookingStateConfirmed,
+			StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 7)),
+			Duration:    60,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+
+		toggle := func(query string) bulk_toggle_therapist_timeslots.Output {
+			req := httptest.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("/api/v1/therapists/%s/timeslots/bulk-toggle%s", dryRunTherapistID, query),
+				bytes.NewBufferString(`{"isActive": false}`),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			var output bulk_toggle_therapist_timeslots.Output
+			testutils.AssertJSONResponse(t, rr, http.StatusOK, &output)
+			return output
+		}
+
+		preview := toggle("?dryRun=true")
+		if !preview.DryRun {
+			t.Errorf("Expected the response to be marked as a dry run")
+		}
+		if preview.Affected != 2 || !slices.Equal(preview.AffectedTimeslotIDs, []domain.TimeSlotID{bookedSlotID, freeSlotID}) {
+			t.Fatalf("Expected %s and %s to be affected, got %v", bookedSlotID, freeSlotID, preview.AffectedTimeslotIDs)
+		}
+		if len(preview.BlockingBookings) != 1 || preview.BlockingBookings[0].TimeslotID != bookedSlotID ||
+			!slices.Equal(preview.BlockingBookings[0].BookingIDs, []domain.BookingID{bookingID}) {
+			t.Errorf("Expected booking %s on %s to be reported, got %+v", bookingID, bookedSlotID, preview.BlockingBookings)
+		}
+
+		// Nothing changed
+		for _, id := range []domain.TimeSlotID{bookedSlotID, freeSlotID} {
+			timeslot := getTimeslotByID(t, mux, dryRunTherapistID, string(id))
+			if isActive, ok := timeslot["isActive"].(bool); !ok || !isActive {
+				t.Errorf("Expected timeslot %s to stay active after a dry run", id)
+			}
+		}
+
+		result := toggle("")
+		if result.DryRun {
+			t.Errorf("Expected a real run not to be marked as a dry run")
+		}
+		if result.Affected != preview.Affected || !slices.Equal(result.AffectedTimeslotIDs, preview.AffectedTimeslotIDs) {
+			t.Errorf("Expected the real run to affect %v, got %v", preview.AffectedTimeslotIDs, result.AffectedTimeslotIDs)
+		}
+		for _, id := range []domain.TimeSlotID{bookedSlotID, freeSlotID, inactiveSlotID} {
+			timeslot := getTimeslotByID(t, mux, dryRunTherapistID, string(id))
+			if isActive, ok := timeslot["isActive"].(bool); !ok || isActive {
+				t.Errorf("Expected timeslot %s to be inactive after the real run", id)
+			}
+		}
+	})
+
+	t.Run("Bulk toggle with invalid dryRun", func(t *testing.T) {
+		req := httptest.NewRequest(
+			http.MethodPut,
+			fmt.Sprintf("/api/v1/therapists/%s/timeslots/bulk-toggle?dryRun=maybe", testTherapistID),
+			bytes.NewBufferString(`{"isActive": false}`),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		testutils.AssertError(t, rr, http.StatusBadRequest)
+	})
+
 	t.Run("Bulk toggle with no timeslots", func(t *testing.T) {
 		// Create a new therapist with no timeslots using utilities with unique name
 		newTherapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Empty Schedule")
@@ -186,6 +296,7 @@ func TestBulkToggleTimeslots(t *testing.T) {
 
 		expectedMessage := "Bulk toggle completed successfully"
 		testutils.AssertStringField(t, response, "message", expectedMessage)
+		testutils.AssertFloatField(t, response, "affected", 0)
 	})
 
 	t.Run("Bulk toggle with non-existent therapist", func(t *testing.T) {
@@ -255,11 +366,10 @@ func createMultipleTimeslots(t *testing.T, mux *http.ServeMux, therapistID domai
 
 	for i := 0; i < count; i++ {
 		timeslotData := map[string]interface{}{
-			"therapistId":           string(therapistID),
 			"dayOfWeek":             days[i%len(days)],
-			"startTime":             fmt.Sprintf("%02d:00", 9+i*2), // Use different times: 09:00, 11:00, 13:00
-			"durationMinutes":       60,
-			"timezoneOffset":        0, // UTC
+			"start":                 fmt.Sprintf("%02d:00", 9+i*2), // Use different times: 09:00, 11:00, 13:00
+			"duration":              60,
+			"isActive":              true,
 			"advanceNotice":         15,
 			"afterSessionBreakTime": 30, // Fix: Must be at least 30 minutes
 		}
@@ -0,0 +1,205 @@
+package timeslot_handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/bulk_toggle_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
+)
+
+func TestDeleteTimeslotsForDay(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	testTherapistID := testutils.CreateTestTherapist(t, database)
+	testClientID := testutils.CreateTestClient(t, database)
+	repos := testutils.SetupRepositories(database)
+
+	createUsecase := create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, 12*60, 15, timeslot.ClinicHours{})
+	getUsecase := get_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	updateUsecase := update_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, timeslot.ClinicHours{})
+	deleteUsecase := delete_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+	listUsecase := list_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	bulkToggleUsecase := bulk_toggle_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+	setActiveUsecase := set_therapist_timeslot_active.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	listBookingsUsecase := list_timeslot_bookings.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	deleteForDayUsecase := delete_therapist_timeslots_for_day.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+
+	timeslotHandler := NewTimeslotHandler(
+		bulkToggleUsecase,
+		*createUsecase,
+		*getUsecase,
+		*updateUsecase,
+		*deleteUsecase,
+		*listUsecase,
+		*setActiveUsecase,
+		*listBookingsUsecase,
+		*deleteForDayUsecase,
+		export_therapist_timeslots.Usecase{},
+		import_therapist_timeslots.Usecase{},
+		list_raw_therapist_timeslots.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	timeslotHandler.RegisterRoutes(mux)
+
+	deleteForDay := func(day string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(
+			http.MethodDelete,
+			fmt.Sprintf("/api/v1/therapists/%s/timeslots?day=%s", testTherapistID, day),
+			nil,
+		)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	dryRunDeleteForDay := func(day string) delete_therapist_timeslots_for_day.Output {
+		req := httptest.NewRequest(
+			http.MethodDelete,
+			fmt.Sprintf("/api/v1/therapists/%s/timeslots?day=%s&dryRun=true", testTherapistID, day),
+			nil,
+		)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		var output delete_therapist_timeslots_for_day.Output
+		testutils.AssertJSONResponse(t, rr, http.StatusOK, &output)
+		if !output.DryRun {
+			t.Errorf("Expected the response to be marked as a dry run")
+		}
+		return output
+	}
+
+	bookedSlotID := testutils.CreateTestTimeSlotCustom(t, database, testTherapistID, "Monday", "09:00", 60, true)
+	freeSlotID := testutils.CreateTestTimeSlotCustom(t, database, testTherapistID, "Monday", "14:00", 60, true)
+	tuesdaySlotID := testutils.CreateTestTimeSlotCustom(t, database, testTherapistID, "Tuesday", "09:00", 60, true)
+
+	now := domain.NewUTCTimestamp()
+	bookingID := domain.NewBookingID()
+	err := repos.BookingRepo.Create(&booking.Booking{
+		ID:          bookingID,
+		TimeSlotID:  bookedSlotID,
+		TherapistID: testTherapistID,
+		ClientID:    testClientID,
+		State:       booking.BookingStateConfirmed,
+		StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 7)),
+		Duration:    60,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create booking: %v", err)
+	}
+
+	// A cancelled booking does not block the deletion
+	err = repos.BookingRepo.Create(&booking.Booking{
+		ID:          domain.NewBookingID(),
+		TimeSlotID:  freeSlotID,
+		TherapistID: testTherapistID,
+		ClientID:    testClientID,
+		State:       booking.BookingStateCancelled,
+		StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 7)),
+		Duration:    60,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create booking: %v", err)
+	}
+
+	t.Run("Dry run reports the conflicts without deleting", func(t *testing.T) {
+		output := dryRunDeleteForDay("Monday")
+		if len(output.DeletedTimeslotIDs) != 0 {
+			t.Errorf("Expected nothing to be deletable, got %v", output.DeletedTimeslotIDs)
+		}
+		if len(output.Conflicts) != 1 || output.Conflicts[0].TimeslotID != bookedSlotID {
+			t.Fatalf("Expected a single conflict on %s, got %+v", bookedSlotID, output.Conflicts)
+		}
+		if len(output.Conflicts[0].BookingIDs) != 1 || output.Conflicts[0].BookingIDs[0] != bookingID {
+			t.Errorf("Expected conflict to list booking %s, got %v", bookingID, output.Conflicts[0].BookingIDs)
+		}
+	})
+
+	t.Run("Booked slot blocks the whole day", func(t *testing.T) {
+		rr := deleteForDay("Monday")
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusConflict, rr.Code, rr.Body.String())
+		}
+
+		var response struct {
+			Error struct {
+				Code    string                      `json:"code"`
+				Details []timeslot_usecase.Conflict `json:"details"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Error.Code != "timeslot.has_active_bookings" {
+			t.Errorf("Expected code timeslot.has_active_bookings, got %s", response.Error.Code)
+		}
+		conflicts := response.Error.Details
+		if len(conflicts) != 1 || conflicts[0].TimeslotID != bookedSlotID {
+			t.Fatalf("Expected a single conflict on %s, got %+v", bookedSlotID, conflicts)
+		}
+		if len(conflicts[0].BookingIDs) != 1 || conflicts[0].BookingIDs[0] != bookingID {
+			t.Errorf("Expected conflict to list booking %s, got %v", bookingID, conflicts[0].BookingIDs)
+		}
+
+		// Nothing was deleted
+		for _, id := range []domain.TimeSlotID{bookedSlotID, freeSlotID, tuesdaySlotID} {
+			if _, err := repos.TimeSlotRepo.GetByID(id); err != nil {
+				t.Errorf("Expected timeslot %s to still exist, got %v", id, err)
+			}
+		}
+	})
+
+	t.Run("Day without bookings is cleared", func(t *testing.T) {
+		preview := dryRunDeleteForDay("Tuesday")
+		if len(preview.DeletedTimeslotIDs) != 1 || preview.DeletedTimeslotIDs[0] != tuesdaySlotID {
+			t.Fatalf("Expected dry run to report %s, got %v", tuesdaySlotID, preview.DeletedTimeslotIDs)
+		}
+		if _, err := repos.TimeSlotRepo.GetByID(tuesdaySlotID); err != nil {
+			t.Fatalf("Expected timeslot %s to survive the dry run, got %v", tuesdaySlotID, err)
+		}
+
+		rr := deleteForDay("Tuesday")
+
+		var response map[string]interface{}
+		testutils.AssertJSONResponse(t, rr, http.StatusOK, &response)
+
+		deleted, ok := response["deletedTimeslotIds"].([]interface{})
+		if !ok || len(deleted) != 1 || deleted[0] != string(tuesdaySlotID) {
+			t.Errorf("Expected %s to be deleted, got %v", tuesdaySlotID, response["deletedTimeslotIds"])
+		}
+		if _, err := repos.TimeSlotRepo.GetByID(tuesdaySlotID); err == nil {
+			t.Errorf("Expected timeslot %s to be deleted", tuesdaySlotID)
+		}
+	})
+
+	t.Run("Invalid day", func(t *testing.T) {
+		testutils.AssertError(t, deleteForDay("Someday"), http.StatusBadRequest)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
@@ -12,8 +13,14 @@ import (
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/bulk_toggle_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
 
 	_ "github.com/glebarez/go-sqlite"
@@ -31,12 +38,15 @@ func TestTimeslotE2E(t *testing.T) {
 	repos := testutils.SetupRepositories(database)
 
 	// Setup usecases (test-specific logic remains explicit)
-	createUsecase := create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
-	getUsecase := get_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
-	updateUsecase := update_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
-	deleteUsecase := delete_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	createUsecase := create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, 12*60, 15, timeslot.ClinicHours{})
+	getUsecase := get_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	updateUsecase := update_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, timeslot.ClinicHours{})
+	deleteUsecase := delete_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
 	listUsecase := list_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
-	bulkToggleUsecase := bulk_toggle_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	bulkToggleUsecase := bulk_toggle_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+	setActiveUsecase := set_therapist_timeslot_active.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	listBookingsUsecase := list_timeslot_bookings.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	deleteForDayUsecase := delete_therapist_timeslots_for_day.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
 
 	// Setup handler
 	timeslotHandler := NewTimeslotHandler(
@@ -46,6 +56,13 @@ func TestTimeslotE2E(t *testing.T) {
 		*updateUsecase,
 		*deleteUsecase,
 		*listUsecase,
+		*setActiveUsecase,
+		*listBookingsUsecase,
+		*deleteForDayUsecase,
+		export_therapist_timeslots.Usecase{},
+		import_therapist_timeslots.Usecase{},
+		list_raw_therapist_timeslots.Usecase{},
+		testutils.NewClinicGuard(database),
 	)
 
 	// Setup router
@@ -67,7 +84,7 @@ func TestTimeslotE2E(t *testing.T) {
 		}
 		timeslotBody, _ := json.Marshal(timeslotData)
 
-		createReq := httptest.NewRequest("POST", "/api/v1/therapists/"+string(testTherapistID)+"/timeslots?timezoneOffset="+string(rune(testTimezoneOffset)), bytes.NewBuffer(timeslotBody))
+		createReq := httptest.NewRequest("POST", "/api/v1/therapists/"+string(testTherapistID)+"/timeslots?timezoneOffset="+strconv.Itoa(testTimezoneOffset), bytes.NewBuffer(timeslotBody))
 		createReq.Header.Set("Content-Type", "application/json")
 		createRec := httptest.NewRecorder()
 
@@ -309,9 +326,7 @@ func TestTimeslotE2E(t *testing.T) {
 
 		mux.ServeHTTP(overlappingRec, overlappingReq)
 
-		if overlappingRec.Code != http.StatusConflict {
-			t.Errorf("Expected status %d for overlapping timeslot, got %d", http.StatusConflict, overlappingRec.Code)
-		}
+		testutils.AssertErrorCode(t, overlappingRec, http.StatusConflict, "timeslot.overlapping")
 	})
 
 	t.Run("Duration validation", func(t *testing.T) {
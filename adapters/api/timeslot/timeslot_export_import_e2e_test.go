@@ -0,0 +1,140 @@
+package timeslot_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/bulk_toggle_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
+)
+
+func TestExportImportTimeslots(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	sourceTherapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Source")
+	targetTherapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Target")
+	repos := testutils.SetupRepositories(database)
+
+	timeslotHandler := NewTimeslotHandler(
+		bulk_toggle_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions),
+		*create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, 12*60, 15, timeslot.ClinicHours{}),
+		*get_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo),
+		*update_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, timeslot.ClinicHours{}),
+		*delete_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions),
+		*list_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo),
+		*set_therapist_timeslot_active.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo),
+		*list_timeslot_bookings.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo),
+		*delete_therapist_timeslots_for_day.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions),
+		*export_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo),
+		*import_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions, 15, timeslot.ClinicHours{}),
+		list_raw_therapist_timeslots.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	timeslotHandler.RegisterRoutes(mux)
+
+	create := func(t *testing.T, dayOfWeek, start string, isActive bool) {
+		requestBodyJSON, _ := json.Marshal(map[string]interface{}{
+			"dayOfWeek":             dayOfWeek,
+			"start":                 start,
+			"duration":              120,
+			"isActive":              isActive,
+			"advanceNotice":         60,
+			"afterSessionBreakTime": 15,
+			"timezone":              "Africa/Cairo",
+		})
+		req := httptest.NewRequest(
+			http.MethodPost,
+			fmt.Sprintf("/api/v1/therapists/%s/timeslots", sourceTherapistID),
+			bytes.NewBuffer(requestBodyJSON),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		testutils.AssertStatus(t, rr, http.StatusCreated)
+	}
+
+	export := func(t *testing.T, therapistID domain.TherapistID) timeslot.Template {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/therapists/%s/timeslots/export", therapistID), nil)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		var template timeslot.Template
+		testutils.AssertJSONResponse(t, rr, http.StatusOK, &template)
+		return template
+	}
+
+	importTemplate := func(t *testing.T, therapistID domain.TherapistID, template timeslot.Template) *httptest.ResponseRecorder {
+		requestBodyJSON, _ := json.Marshal(template)
+		req := httptest.NewRequest(
+			http.MethodPost,
+			fmt.Sprintf("/api/v1/therapists/%s/timeslots/import", therapistID),
+			bytes.NewBuffer(requestBodyJSON),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	create(t, "Monday", "09:00", true)
+	create(t, "Wednesday", "14:30", false)
+
+	t.Run("round trip into a fresh therapist", func(t *testing.T) {
+		exported := export(t, sourceTherapistID)
+		if exported.Timezone != "Africa/Cairo" || len(exported.Timeslots) != 2 {
+			t.Fatalf("Expected 2 slots in Africa/Cairo, got %+v", exported)
+		}
+
+		rr := importTemplate(t, targetTherapistID, exported)
+		testutils.AssertStatus(t, rr, http.StatusCreated)
+
+		imported := export(t, targetTherapistID)
+		if !reflect.DeepEqual(exported, imported) {
+			t.Errorf("Expected imported template %+v to equal exported %+v", imported, exported)
+		}
+	})
+
+	t.Run("importing again overlaps the existing slots", func(t *testing.T) {
+		rr := importTemplate(t, targetTherapistID, export(t, sourceTherapistID))
+		testutils.AssertErrorCode(t, rr, http.StatusConflict, "timeslot.overlapping")
+	})
+
+	t.Run("overlapping template creates nothing", func(t *testing.T) {
+		therapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Empty")
+		template := timeslot.Template{
+			Timezone: "Africa/Cairo",
+			Timeslots: []timeslot.TemplateSlot{
+				{DayOfWeek: timeslot.DayOfWeekFriday, StartTime: "09:00", DurationMinutes: 120, AfterSessionBreakTime: 15},
+				{DayOfWeek: timeslot.DayOfWeekFriday, StartTime: "10:00", DurationMinutes: 120, AfterSessionBreakTime: 15},
+			},
+		}
+
+		rr := importTemplate(t, therapistID, template)
+		testutils.AssertErrorCode(t, rr, http.StatusConflict, "timeslot.overlapping")
+
+		if slots := export(t, therapistID).Timeslots; len(slots) != 0 {
+			t.Errorf("Expected no slots to be created, got %d", len(slots))
+		}
+	})
+}
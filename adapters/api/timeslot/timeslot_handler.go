@@ -1,19 +1,26 @@
 package timeslot_handler
 
 import (
-	"encoding/json"
-	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/mishkahtherapy/brain/adapters/api"
 	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
 	"github.com/mishkahtherapy/brain/core/domain/timeslot"
 	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/bulk_toggle_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
 )
 
@@ -38,6 +45,13 @@ type TimeslotHandler struct {
 	updateTimeslotUsecase update_therapist_timeslot.Usecase
 	deleteTimeslotUsecase delete_therapist_timeslot.Usecase
 	listTimeslotsUsecase  list_therapist_timeslots.Usecase
+	setActiveUsecase      set_therapist_timeslot_active.Usecase
+	listBookingsUsecase   list_timeslot_bookings.Usecase
+	deleteForDayUsecase   delete_therapist_timeslots_for_day.Usecase
+	exportUsecase         export_therapist_timeslots.Usecase
+	importUsecase         import_therapist_timeslots.Usecase
+	listRawUsecase        list_raw_therapist_timeslots.Usecase
+	clinicGuard           *api.ClinicGuard
 }
 
 func NewTimeslotHandler(
@@ -47,6 +61,13 @@ func NewTimeslotHandler(
 	updateUsecase update_therapist_timeslot.Usecase,
 	deleteUsecase delete_therapist_timeslot.Usecase,
 	listUsecase list_therapist_timeslots.Usecase,
+	setActiveUsecase set_therapist_timeslot_active.Usecase,
+	listBookingsUsecase list_timeslot_bookings.Usecase,
+	deleteForDayUsecase delete_therapist_timeslots_for_day.Usecase,
+	exportUsecase export_therapist_timeslots.Usecase,
+	importUsecase import_therapist_timeslots.Usecase,
+	listRawUsecase list_raw_therapist_timeslots.Usecase,
+	clinicGuard *api.ClinicGuard,
 ) *TimeslotHandler {
 	return &TimeslotHandler{
 		bulkToggleUsecase:     bulkToggleUsecase,
@@ -55,16 +76,34 @@ func NewTimeslotHandler(
 		updateTimeslotUsecase: updateUsecase,
 		deleteTimeslotUsecase: deleteUsecase,
 		listTimeslotsUsecase:  listUsecase,
+		setActiveUsecase:      setActiveUsecase,
+		listBookingsUsecase:   listBookingsUsecase,
+		deleteForDayUsecase:   deleteForDayUsecase,
+		exportUsecase:         exportUsecase,
+		importUsecase:         importUsecase,
+		listRawUsecase:        listRawUsecase,
+		clinicGuard:           clinicGuard,
 	}
 }
 
-func (h *TimeslotHandler) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("PUT /api/v1/therapists/{therapistId}/timeslots/bulk-toggle", h.handleBulkToggleTimeslots)
-	mux.HandleFunc("POST /api/v1/therapists/{therapistId}/timeslots", h.handleCreateTimeslot)
-	mux.HandleFunc("GET /api/v1/therapists/{therapistId}/timeslots", h.handleListTimeslots)
-	mux.HandleFunc("GET /api/v1/therapists/{therapistId}/timeslots/{timeslotId}", h.handleGetTimeslot)
-	mux.HandleFunc("PUT /api/v1/therapists/{therapistId}/timeslots/{timeslotId}", h.handleUpdateTimeslot)
-	mux.HandleFunc("DELETE /api/v1/therapists/{therapistId}/timeslots/{timeslotId}", h.handleDeleteTimeslot)
+func (h *TimeslotHandler) RegisterRoutes(mux api.Router) {
+	mux.HandleFunc("PUT /api/v1/therapists/{therapistId}/timeslots/bulk-toggle", h.clinicGuard.Therapist("therapistId", h.handleBulkToggleTimeslots))
+	mux.HandleFunc("POST /api/v1/therapists/{therapistId}/timeslots", h.clinicGuard.Therapist("therapistId", h.handleCreateTimeslot))
+	mux.HandleFunc("GET /api/v1/therapists/{therapistId}/timeslots", h.clinicGuard.Therapist("therapistId", h.handleListTimeslots))
+	mux.HandleFunc("DELETE /api/v1/therapists/{therapistId}/timeslots", h.clinicGuard.Therapist("therapistId", h.handleDeleteTimeslotsForDay))
+	mux.HandleFunc("GET /api/v1/therapists/{therapistId}/timeslots/export", h.clinicGuard.Therapist("therapistId", h.handleExportTimeslots))
+	mux.HandleFunc("POST /api/v1/therapists/{therapistId}/timeslots/import", h.clinicGuard.Therapist("therapistId", h.handleImportTimeslots))
+	mux.HandleFunc("GET /api/v1/therapists/{therapistId}/timeslots/{timeslotId}", h.clinicGuard.Therapist("therapistId", h.handleGetTimeslot))
+	mux.HandleFunc("PUT /api/v1/therapists/{therapistId}/timeslots/{timeslotId}", h.clinicGuard.Therapist("therapistId", h.handleUpdateTimeslot))
+	mux.HandleFunc("DELETE /api/v1/therapists/{therapistId}/timeslots/{timeslotId}", h.clinicGuard.Therapist("therapistId", h.handleDeleteTimeslot))
+	mux.HandleFunc("PATCH /api/v1/therapists/{therapistId}/timeslots/{timeslotId}/active", h.clinicGuard.Therapist("therapistId", h.handleSetTimeslotActive))
+	mux.HandleFunc("GET /api/v1/therapists/{therapistId}/timeslots/{timeslotId}/bookings", h.clinicGuard.Therapist("therapistId", h.handleListTimeslotBookings))
+}
+
+// RegisterDebugRoutes registers routes exposing stored data as is. Only
+// register them in development.
+func (h *TimeslotHandler) RegisterDebugRoutes(mux api.Router) {
+	mux.HandleFunc("GET /api/v1/admin/therapists/{therapistId}/timeslots/raw", h.clinicGuard.Therapist("therapistId", h.handleListRawTimeslots))
 }
 
 func (h *TimeslotHandler) handleBulkToggleTimeslots(w http.ResponseWriter, r *http.Request) {
@@ -73,7 +112,12 @@ func (h *TimeslotHandler) handleBulkToggleTimeslots(w http.ResponseWriter, r *ht
 	// Read therapist ID from path
 	therapistID := domain.TherapistID(r.PathValue("therapistId"))
 	if therapistID == "" {
-		rw.WriteBadRequest("Missing therapist ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	dryRun, ok := parseDryRun(rw, r)
+	if !ok {
 		return
 	}
 
@@ -82,8 +126,8 @@ func (h *TimeslotHandler) handleBulkToggleTimeslots(w http.ResponseWriter, r *ht
 		IsActive bool `json:"isActive"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		rw.WriteBadRequest("Invalid request body: " + err.Error())
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -91,29 +135,38 @@ func (h *TimeslotHandler) handleBulkToggleTimeslots(w http.ResponseWriter, r *ht
 	input := bulk_toggle_therapist_timeslots.Input{
 		TherapistID: therapistID,
 		IsActive:    requestBody.IsActive,
+		DryRun:      dryRun,
 	}
 
-	err := h.bulkToggleUsecase.Execute(input)
+	output, err := h.bulkToggleUsecase.Execute(input)
 	if err != nil {
 		// Handle specific business logic errors
 		switch err {
 		case timeslot.ErrTherapistIDRequired:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case timeslot.ErrTherapistNotFound:
-			rw.WriteNotFound(err.Error())
+			rw.WriteCodedError(err, http.StatusNotFound)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	// Return simple success response
-	response := map[string]string{
-		"message": "Bulk toggle completed successfully",
+	// Return success response with the timeslots changed
+	message := "Bulk toggle completed successfully"
+	if dryRun {
+		message = "Bulk toggle dry run completed, nothing was changed"
+	}
+	response := map[string]any{
+		"message":             message,
+		"affected":            output.Affected,
+		"affectedTimeslotIds": output.AffectedTimeslotIDs,
+		"blockingBookings":    output.BlockingBookings,
+		"dryRun":              dryRun,
 	}
 
 	if err := rw.WriteJSON(response, http.StatusOK); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
@@ -123,7 +176,7 @@ func (h *TimeslotHandler) handleCreateTimeslot(w http.ResponseWriter, r *http.Re
 	// Read therapist ID from path
 	therapistID := domain.TherapistID(r.PathValue("therapistId"))
 	if therapistID == "" {
-		rw.WriteBadRequest("Missing therapist ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
 		return
 	}
 
@@ -136,10 +189,12 @@ func (h *TimeslotHandler) handleCreateTimeslot(w http.ResponseWriter, r *http.Re
 		// TimezoneOffset    domain.TimezoneOffset  `json:"timezoneOffset"`    // Minutes from UTC
 		AdvanceNotice         domain.AdvanceNoticeMinutes         `json:"advanceNotice"`         // minutes
 		AfterSessionBreakTime domain.AfterSessionBreakTimeMinutes `json:"afterSessionBreakTime"` // minutes
+		Timezone              string                              `json:"timezone"`              // Optional IANA name
+		Capacity              int                                 `json:"capacity"`              // Optional, defaults to 1
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		rw.WriteBadRequest(err.Error())
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
 		return
 	}
 
@@ -152,6 +207,8 @@ func (h *TimeslotHandler) handleCreateTimeslot(w http.ResponseWriter, r *http.Re
 		AdvanceNotice:         requestBody.AdvanceNotice,
 		AfterSessionBreakTime: requestBody.AfterSessionBreakTime,
 		IsActive:              requestBody.IsActive,
+		Timezone:              requestBody.Timezone,
+		Capacity:              requestBody.Capacity,
 	}
 
 	newTimeslot, err := h.createTimeslotUsecase.Execute(input)
@@ -166,22 +223,26 @@ func (h *TimeslotHandler) handleCreateTimeslot(w http.ResponseWriter, r *http.Re
 			timeslot.ErrInvalidTimeFormat,
 			timeslot.ErrInvalidDuration,
 			timeslot.ErrInvalidTimezoneOffset,
+			timeslot.ErrInvalidTimezoneName,
 			timeslot.ErrInvalidDayOfWeek,
 			timeslot.ErrPreSessionBufferNegative,
-			timeslot.ErrPostSessionBufferTooLow:
-			rw.WriteBadRequest(err.Error())
+			timeslot.ErrPostSessionBufferTooLow,
+			timeslot.ErrSessionDoesNotFitSlot,
+			timeslot.ErrOutsideClinicHours,
+			timeslot.ErrInvalidCapacity:
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case timeslot.ErrTherapistNotFound:
-			rw.WriteNotFound(err.Error())
+			rw.WriteCodedError(err, http.StatusNotFound)
 		case timeslot.ErrOverlappingTimeslot:
-			rw.WriteError(err, http.StatusConflict)
+			rw.WriteCodedError(err, http.StatusConflict)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	if err := rw.WriteJSON(newTimeslot, http.StatusCreated); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+	if err := rw.WriteCreatedJSON(newTimeslot, "/api/v1/therapists/"+string(therapistID)+"/timeslots/"+string(newTimeslot.ID)); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
@@ -191,7 +252,7 @@ func (h *TimeslotHandler) handleListTimeslots(w http.ResponseWriter, r *http.Req
 	// Read therapist ID from path
 	therapistID := domain.TherapistID(r.PathValue("therapistId"))
 	if therapistID == "" {
-		rw.WriteBadRequest("Missing therapist ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
 		return
 	}
 
@@ -199,6 +260,14 @@ func (h *TimeslotHandler) handleListTimeslots(w http.ResponseWriter, r *http.Req
 	input := list_therapist_timeslots.Input{
 		TherapistID: therapistID,
 	}
+	if activeParam := r.URL.Query().Get("active"); activeParam != "" {
+		activeOnly, err := strconv.ParseBool(activeParam)
+		if err != nil {
+			rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid active parameter. Expected true or false", http.StatusBadRequest)
+			return
+		}
+		input.ActiveOnly = activeOnly
+	}
 
 	timeslots, err := h.listTimeslotsUsecase.Execute(input)
 	if err != nil {
@@ -206,53 +275,155 @@ func (h *TimeslotHandler) handleListTimeslots(w http.ResponseWriter, r *http.Req
 		switch err {
 		case timeslot.ErrTherapistIDRequired,
 			timeslot.ErrInvalidDayOfWeek:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case timeslot.ErrTherapistNotFound:
-			rw.WriteNotFound(err.Error())
+			rw.WriteCodedError(err, http.StatusNotFound)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
 	if err := rw.WriteJSON(timeslots, http.StatusOK); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
-func (h *TimeslotHandler) handleGetTimeslot(w http.ResponseWriter, r *http.Request) {
+func (h *TimeslotHandler) handleExportTimeslots(w http.ResponseWriter, r *http.Request) {
 	rw := api.NewResponseWriter(w)
 
 	// Read therapist ID from path
 	therapistID := domain.TherapistID(r.PathValue("therapistId"))
 	if therapistID == "" {
-		rw.WriteBadRequest("Missing therapist ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
 		return
 	}
 
-	// Read timeslot ID from path
-	timeslotID := domain.TimeSlotID(r.PathValue("timeslotId"))
-	if timeslotID == "" {
-		rw.WriteBadRequest("Missing timeslot ID")
+	template, err := h.exportUsecase.Execute(export_therapist_timeslots.Input{
+		TherapistID: therapistID,
+	})
+	if err != nil {
+		switch err {
+		case timeslot.ErrTherapistIDRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case timeslot.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case timeslot.ErrTemplateHasMixedTimezones:
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// Parse timezone offset from query parameter (required for response conversion)
-	timezoneOffsetParam := r.URL.Query().Get("timezoneOffset")
-	if timezoneOffsetParam == "" {
-		rw.WriteBadRequest("Missing timezoneOffset query parameter")
+	if err := rw.WriteJSON(template, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+// handleListRawTimeslots handles GET /api/v1/admin/therapists/{therapistId}/timeslots/raw
+func (h *TimeslotHandler) handleListRawTimeslots(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist ID from path
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
 		return
 	}
 
-	var timezoneOffset domain.TimezoneOffset
-	if _, err := fmt.Sscanf(timezoneOffsetParam, "%d", &timezoneOffset); err != nil {
-		rw.WriteBadRequest("Invalid timezoneOffset format")
+	timeslots, err := h.listRawUsecase.Execute(list_raw_therapist_timeslots.Input{
+		TherapistID: therapistID,
+	})
+	if err != nil {
+		switch err {
+		case timeslot.ErrTherapistIDRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case timeslot.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// Validate timezone offset
-	if err := timeslot_usecase.ValidateTimezoneOffset(timezoneOffset); err != nil {
-		rw.WriteBadRequest(err.Error())
+	if err := rw.WriteJSON(timeslots, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *TimeslotHandler) handleImportTimeslots(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist ID from path
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	// The body is a template as returned by the export endpoint
+	var template timeslot.Template
+	if err := api.DecodeJSON(r, &template); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return
+	}
+
+	timeslots, err := h.importUsecase.Execute(import_therapist_timeslots.Input{
+		TherapistID: therapistID,
+		Template:    template,
+	})
+	if err != nil {
+		switch err {
+		case timeslot.ErrTherapistIDRequired,
+			timeslot.ErrTemplateIsEmpty,
+			timeslot.ErrDayOfWeekIsRequired,
+			timeslot.ErrStartTimeIsRequired,
+			timeslot.ErrDurationIsRequired,
+			timeslot.ErrInvalidDayOfWeek,
+			timeslot.ErrInvalidTimeFormat,
+			timeslot.ErrInvalidDuration,
+			timeslot.ErrInvalidTimezoneName,
+			timeslot.ErrPreSessionBufferNegative,
+			timeslot.ErrPostSessionBufferTooLow,
+			timeslot.ErrSessionDoesNotFitSlot,
+			timeslot.ErrOutsideClinicHours:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case timeslot.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case timeslot.ErrOverlappingTimeslot,
+			timeslot.ErrInsufficientGapBetweenSlots:
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(timeslots, http.StatusCreated); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *TimeslotHandler) handleGetTimeslot(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist ID from path
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	// Read timeslot ID from path
+	timeslotID := domain.TimeSlotID(r.PathValue("timeslotId"))
+	if timeslotID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing timeslot ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse timezone offset from query parameter (required for response conversion)
+	if _, ok := parseTimezoneOffset(rw, r); !ok {
 		return
 	}
 
@@ -268,19 +439,19 @@ func (h *TimeslotHandler) handleGetTimeslot(w http.ResponseWriter, r *http.Reque
 		switch err {
 		case timeslot.ErrTherapistIDRequired,
 			timeslot.ErrTimeslotIDIsRequired:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case timeslot.ErrTherapistNotFound,
 			timeslot.ErrTimeslotNotFound,
 			timeslot.ErrTimeslotNotOwned:
-			rw.WriteNotFound(err.Error())
+			rw.WriteCodedError(err, http.StatusNotFound)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
 	if err := rw.WriteJSON(dbTimeslot, http.StatusOK); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
@@ -290,14 +461,14 @@ func (h *TimeslotHandler) handleUpdateTimeslot(w http.ResponseWriter, r *http.Re
 	// Read therapist ID from path
 	therapistID := domain.TherapistID(r.PathValue("therapistId"))
 	if therapistID == "" {
-		rw.WriteBadRequest("Missing therapist ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
 		return
 	}
 
 	// Read timeslot ID from path
 	timeslotID := domain.TimeSlotID(r.PathValue("timeslotId"))
 	if timeslotID == "" {
-		rw.WriteBadRequest("Missing timeslot ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing timeslot ID", http.StatusBadRequest)
 		return
 	}
 
@@ -309,10 +480,12 @@ func (h *TimeslotHandler) handleUpdateTimeslot(w http.ResponseWriter, r *http.Re
 		AdvanceNotice         domain.AdvanceNoticeMinutes         `json:"advanceNotice"`
 		AfterSessionBreakTime domain.AfterSessionBreakTimeMinutes `json:"afterSessionBreakTime"`
 		IsActive              bool                                `json:"isActive"`
+		Timezone              string                              `json:"timezone"` // Optional IANA name
+		Capacity              int                                 `json:"capacity"` // Optional, kept when omitted
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		rw.WriteBadRequest(err.Error())
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
 		return
 	}
 
@@ -326,6 +499,8 @@ func (h *TimeslotHandler) handleUpdateTimeslot(w http.ResponseWriter, r *http.Re
 		AdvanceNotice:         requestBody.AdvanceNotice,
 		AfterSessionBreakTime: requestBody.AfterSessionBreakTime,
 		IsActive:              requestBody.IsActive,
+		Timezone:              requestBody.Timezone,
+		Capacity:              requestBody.Capacity,
 	}
 
 	updatedTimeslot, err := h.updateTimeslotUsecase.Execute(input)
@@ -340,23 +515,87 @@ func (h *TimeslotHandler) handleUpdateTimeslot(w http.ResponseWriter, r *http.Re
 			timeslot.ErrInvalidDayOfWeek,
 			timeslot.ErrInvalidTimeFormat,
 			timeslot.ErrInvalidDuration,
+			timeslot.ErrInvalidTimezoneName,
 			timeslot.ErrPreSessionBufferNegative,
-			timeslot.ErrPostSessionBufferTooLow:
-			rw.WriteBadRequest(err.Error())
+			timeslot.ErrPostSessionBufferTooLow,
+			timeslot.ErrOutsideClinicHours,
+			timeslot.ErrInvalidCapacity:
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case timeslot.ErrTherapistNotFound,
 			timeslot.ErrTimeslotNotFound,
 			timeslot.ErrTimeslotNotOwned:
-			rw.WriteNotFound(err.Error())
+			rw.WriteCodedError(err, http.StatusNotFound)
 		case timeslot.ErrOverlappingTimeslot:
-			rw.WriteError(err, http.StatusConflict)
+			rw.WriteCodedError(err, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(updatedTimeslot, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *TimeslotHandler) handleSetTimeslotActive(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist ID from path
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	// Read timeslot ID from path
+	timeslotID := domain.TimeSlotID(r.PathValue("timeslotId"))
+	if timeslotID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing timeslot ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse request body
+	var requestBody struct {
+		IsActive *bool `json:"isActive"`
+	}
+
+	if err := api.DecodeJSON(r, &requestBody); err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if requestBody.IsActive == nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing isActive", http.StatusBadRequest)
+		return
+	}
+
+	// Create input for usecase
+	input := set_therapist_timeslot_active.Input{
+		TherapistID: therapistID,
+		TimeslotID:  timeslotID,
+		IsActive:    *requestBody.IsActive,
+	}
+
+	updatedTimeslot, err := h.setActiveUsecase.Execute(input)
+	if err != nil {
+		// Handle specific business logic errors
+		switch err {
+		case timeslot.ErrTherapistIDRequired,
+			timeslot.ErrTimeslotIDIsRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case timeslot.ErrTherapistNotFound,
+			timeslot.ErrTimeslotNotFound,
+			timeslot.ErrTimeslotNotOwned:
+			rw.WriteCodedError(err, http.StatusNotFound)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
 
 	if err := rw.WriteJSON(updatedTimeslot, http.StatusOK); err != nil {
-		rw.WriteError(err, http.StatusInternalServerError)
+		rw.WriteCodedError(err, http.StatusInternalServerError)
 	}
 }
 
@@ -366,14 +605,14 @@ func (h *TimeslotHandler) handleDeleteTimeslot(w http.ResponseWriter, r *http.Re
 	// Read therapist ID from path
 	therapistID := domain.TherapistID(r.PathValue("therapistId"))
 	if therapistID == "" {
-		rw.WriteBadRequest("Missing therapist ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
 		return
 	}
 
 	// Read timeslot ID from path
 	timeslotID := domain.TimeSlotID(r.PathValue("timeslotId"))
 	if timeslotID == "" {
-		rw.WriteBadRequest("Missing timeslot ID")
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing timeslot ID", http.StatusBadRequest)
 		return
 	}
 
@@ -389,15 +628,15 @@ func (h *TimeslotHandler) handleDeleteTimeslot(w http.ResponseWriter, r *http.Re
 		switch err {
 		case timeslot.ErrTherapistIDRequired,
 			timeslot.ErrTimeslotIDIsRequired:
-			rw.WriteBadRequest(err.Error())
+			rw.WriteCodedError(err, http.StatusBadRequest)
 		case timeslot.ErrTherapistNotFound,
 			timeslot.ErrTimeslotNotFound,
 			timeslot.ErrTimeslotNotOwned:
-			rw.WriteNotFound(err.Error())
+			rw.WriteCodedError(err, http.StatusNotFound)
 		case timeslot.ErrTimeslotHasActiveBookings:
-			rw.WriteError(err, http.StatusConflict)
+			rw.WriteCodedError(err, http.StatusConflict)
 		default:
-			rw.WriteError(err, http.StatusInternalServerError)
+			rw.WriteCodedError(err, http.StatusInternalServerError)
 		}
 		return
 	}
@@ -405,3 +644,145 @@ func (h *TimeslotHandler) handleDeleteTimeslot(w http.ResponseWriter, r *http.Re
 	// Return 204 No Content for successful deletion
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *TimeslotHandler) handleDeleteTimeslotsForDay(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist ID from path
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	dryRun, ok := parseDryRun(rw, r)
+	if !ok {
+		return
+	}
+
+	output, err := h.deleteForDayUsecase.Execute(delete_therapist_timeslots_for_day.Input{
+		TherapistID: therapistID,
+		DayOfWeek:   timeslot.DayOfWeek(r.URL.Query().Get("day")),
+		DryRun:      dryRun,
+	})
+	if err != nil {
+		switch err {
+		case timeslot.ErrTherapistIDRequired,
+			timeslot.ErrDayOfWeekIsRequired,
+			timeslot.ErrInvalidDayOfWeek:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case timeslot.ErrTherapistNotFound:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		case timeslot.ErrTimeslotHasActiveBookings:
+			rw.WriteCodedErrorWithDetails(err, output.Conflicts, http.StatusConflict)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(output, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *TimeslotHandler) handleListTimeslotBookings(w http.ResponseWriter, r *http.Request) {
+	rw := api.NewResponseWriter(w)
+
+	// Read therapist ID from path
+	therapistID := domain.TherapistID(r.PathValue("therapistId"))
+	if therapistID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing therapist ID", http.StatusBadRequest)
+		return
+	}
+
+	// Read timeslot ID from path
+	timeslotID := domain.TimeSlotID(r.PathValue("timeslotId"))
+	if timeslotID == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing timeslot ID", http.StatusBadRequest)
+		return
+	}
+
+	// Optional state filter, e.g. ?state=pending,confirmed
+	var states []booking.BookingState
+	if stateParam := r.URL.Query().Get("state"); stateParam != "" {
+		for _, state := range strings.Split(stateParam, ",") {
+			bookingState := booking.BookingState(strings.TrimSpace(state))
+			if bookingState != booking.BookingStatePending &&
+				bookingState != booking.BookingStateConfirmed &&
+				bookingState != booking.BookingStateCancelled {
+				rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid state parameter. Must be one of: pending, confirmed, cancelled", http.StatusBadRequest)
+				return
+			}
+			states = append(states, bookingState)
+		}
+	}
+
+	input := list_timeslot_bookings.Input{
+		TherapistID: therapistID,
+		TimeslotID:  timeslotID,
+		States:      states,
+	}
+
+	bookings, err := h.listBookingsUsecase.Execute(input)
+	if err != nil {
+		// Handle specific business logic errors
+		switch err {
+		case timeslot.ErrTherapistIDRequired,
+			timeslot.ErrTimeslotIDIsRequired:
+			rw.WriteCodedError(err, http.StatusBadRequest)
+		case timeslot.ErrTherapistNotFound,
+			timeslot.ErrTimeslotNotFound,
+			timeslot.ErrTimeslotNotOwned:
+			rw.WriteCodedError(err, http.StatusNotFound)
+		default:
+			rw.WriteCodedError(err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := rw.WriteJSON(bookings, http.StatusOK); err != nil {
+		rw.WriteCodedError(err, http.StatusInternalServerError)
+	}
+}
+
+// parseDryRun reads the optional ?dryRun= query parameter. It writes a bad
+// request response and returns false when the value is not a boolean.
+// parseTimezoneOffset reads the required timezoneOffset query parameter. Only a
+// plain base-10 integer within the supported offset range is accepted; anything
+// else, including whitespace, control characters or values overflowing an int,
+// is answered with a 400.
+func parseTimezoneOffset(rw *api.ResponseWriter, r *http.Request) (domain.TimezoneOffset, bool) {
+	timezoneOffsetParam := r.URL.Query().Get("timezoneOffset")
+	if timezoneOffsetParam == "" {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Missing timezoneOffset query parameter", http.StatusBadRequest)
+		return 0, false
+	}
+
+	offset, err := strconv.Atoi(timezoneOffsetParam)
+	if err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid timezoneOffset format. Expected minutes from UTC as an integer", http.StatusBadRequest)
+		return 0, false
+	}
+
+	timezoneOffset := domain.TimezoneOffset(offset)
+	if err := timeslot_usecase.ValidateTimezoneOffset(timezoneOffset); err != nil {
+		rw.WriteCodedError(err, http.StatusBadRequest)
+		return 0, false
+	}
+	return timezoneOffset, true
+}
+
+func parseDryRun(rw *api.ResponseWriter, r *http.Request) (bool, bool) {
+	dryRunParam := r.URL.Query().Get("dryRun")
+	if dryRunParam == "" {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(dryRunParam)
+	if err != nil {
+		rw.WriteCodedErrorMessage(api.ErrorCodeInvalidRequest, "Invalid dryRun parameter. Expected true or false", http.StatusBadRequest)
+		return false, false
+	}
+	return dryRun, true
+}
@@ -0,0 +1,121 @@
+package timeslot_handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
+)
+
+func TestListRawTimeslots(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	therapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Raw")
+	repos := testutils.SetupRepositories(database)
+
+	timeslotHandler := NewTimeslotHandler(
+		nil,
+		*create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, 12*60, 15, timeslot.ClinicHours{}),
+		get_therapist_timeslot.Usecase{},
+		update_therapist_timeslot.Usecase{},
+		delete_therapist_timeslot.Usecase{},
+		list_therapist_timeslots.Usecase{},
+		set_therapist_timeslot_active.Usecase{},
+		list_timeslot_bookings.Usecase{},
+		delete_therapist_timeslots_for_day.Usecase{},
+		export_therapist_timeslots.Usecase{},
+		import_therapist_timeslots.Usecase{},
+		*list_raw_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo),
+		testutils.NewClinicGuard(database),
+	)
+
+	// One slot stored in UTC and one stored local to Cairo
+	for _, tz := range []string{"", "Africa/Cairo"} {
+		slotID := domain.NewTimeSlotID()
+		_, err := database.Exec(`
+			INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, timezone, capacity)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, slotID, therapistID, "Sunday", "23:30", 90, 60, 15, true, tz, 3)
+		if err != nil {
+			t.Fatalf("Failed to insert time slot: %v", err)
+		}
+	}
+
+	path := fmt.Sprintf("/api/v1/admin/therapists/%s/timeslots/raw", therapistID)
+
+	t.Run("Not served by the regular routes", func(t *testing.T) {
+		mux := http.NewServeMux()
+		timeslotHandler.RegisterRoutes(mux)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		testutils.AssertStatus(t, rr, http.StatusNotFound)
+	})
+
+	t.Run("Returns the stored rows unconverted", func(t *testing.T) {
+		mux := http.NewServeMux()
+		timeslotHandler.RegisterDebugRoutes(mux)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		testutils.AssertStatus(t, rr, http.StatusOK)
+
+		var raw []list_raw_therapist_timeslots.RawTimeSlot
+		if err := json.Unmarshal(rr.Body.Bytes(), &raw); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(raw) != 2 {
+			t.Fatalf("Expected 2 timeslots, got %d", len(raw))
+		}
+
+		for _, got := range raw {
+			var stored list_raw_therapist_timeslots.RawTimeSlot
+			err := database.QueryRow(`
+				SELECT id, is_active, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, timezone, capacity
+				FROM time_slots WHERE id = ?
+			`, got.ID).Scan(
+				&stored.ID,
+				&stored.IsActive,
+				&stored.DayOfWeek,
+				&stored.Start,
+				&stored.Duration,
+				&stored.AdvanceNotice,
+				&stored.AfterSessionBreakTime,
+				&stored.Timezone,
+				&stored.Capacity,
+			)
+			if err != nil {
+				t.Fatalf("Failed to read stored timeslot: %v", err)
+			}
+			if got != stored {
+				t.Errorf("Expected the stored row %+v, got %+v", stored, got)
+			}
+		}
+	})
+
+	t.Run("Unknown therapist", func(t *testing.T) {
+		mux := http.NewServeMux()
+		timeslotHandler.RegisterDebugRoutes(mux)
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/therapists/therapist_missing/timeslots/raw", nil))
+		testutils.AssertStatus(t, rr, http.StatusNotFound)
+	})
+}
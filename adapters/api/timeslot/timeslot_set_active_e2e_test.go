@@ -0,0 +1,139 @@
+package timeslot_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/bulk_toggle_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
+)
+
+func TestSetTimeslotActive(t *testing.T) {
+	// Setup test database using utilities
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	// Insert test therapist using utilities
+	testTherapistID := testutils.CreateTestTherapist(t, database)
+
+	// Setup repositories using utilities
+	repos := testutils.SetupRepositories(database)
+
+	// Setup usecases
+	createUsecase := create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, 12*60, 15, timeslot.ClinicHours{})
+	getUsecase := get_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	updateUsecase := update_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, timeslot.ClinicHours{})
+	deleteUsecase := delete_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+	listUsecase := list_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	bulkToggleUsecase := bulk_toggle_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+	setActiveUsecase := set_therapist_timeslot_active.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	listBookingsUsecase := list_timeslot_bookings.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	deleteForDayUsecase := delete_therapist_timeslots_for_day.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+
+	// Setup handler
+	timeslotHandler := NewTimeslotHandler(
+		bulkToggleUsecase,
+		*createUsecase,
+		*getUsecase,
+		*updateUsecase,
+		*deleteUsecase,
+		*listUsecase,
+		*setActiveUsecase,
+		*listBookingsUsecase,
+		*deleteForDayUsecase,
+		export_therapist_timeslots.Usecase{},
+		import_therapist_timeslots.Usecase{},
+		list_raw_therapist_timeslots.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	// Setup router
+	mux := http.NewServeMux()
+	timeslotHandler.RegisterRoutes(mux)
+
+	setActive := func(t *testing.T, timeslotID string, isActive bool) *httptest.ResponseRecorder {
+		requestBodyJSON, _ := json.Marshal(map[string]bool{"isActive": isActive})
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			fmt.Sprintf("/api/v1/therapists/%s/timeslots/%s/active", testTherapistID, timeslotID),
+			bytes.NewBuffer(requestBodyJSON),
+		)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Flip active state twice leaves other fields untouched", func(t *testing.T) {
+		timeslotID := string(testutils.CreateTestTimeSlotCustom(t, database, testTherapistID, "Wednesday", "14:30", 90, true))
+		original := getTimeslotByID(t, mux, testTherapistID, timeslotID)
+
+		// Deactivate
+		var response map[string]interface{}
+		testutils.AssertJSONResponse(t, setActive(t, timeslotID, false), http.StatusOK, &response)
+		testutils.AssertBoolField(t, response, "isActive", false)
+
+		deactivated := getTimeslotByID(t, mux, testTherapistID, timeslotID)
+		testutils.AssertBoolField(t, deactivated, "isActive", false)
+
+		// Reactivate
+		testutils.AssertJSONResponse(t, setActive(t, timeslotID, true), http.StatusOK, &response)
+		testutils.AssertBoolField(t, response, "isActive", true)
+
+		reactivated := getTimeslotByID(t, mux, testTherapistID, timeslotID)
+		testutils.AssertBoolField(t, reactivated, "isActive", true)
+
+		// Everything except the flag and updatedAt must be unchanged
+		for _, field := range []string{"dayOfWeek", "start", "duration", "advanceNotice", "afterSessionBreakTime", "createdAt"} {
+			if fmt.Sprint(original[field]) != fmt.Sprint(deactivated[field]) ||
+				fmt.Sprint(original[field]) != fmt.Sprint(reactivated[field]) {
+				t.Errorf("Expected field %s to be untouched: original=%v deactivated=%v reactivated=%v",
+					field, original[field], deactivated[field], reactivated[field])
+			}
+		}
+	})
+
+	t.Run("Missing isActive in body", func(t *testing.T) {
+		timeslotID := string(testutils.CreateTestTimeSlot(t, database, testTherapistID))
+
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			fmt.Sprintf("/api/v1/therapists/%s/timeslots/%s/active", testTherapistID, timeslotID),
+			bytes.NewBufferString(`{}`),
+		)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		testutils.AssertError(t, rr, http.StatusBadRequest)
+	})
+
+	t.Run("Timeslot owned by another therapist", func(t *testing.T) {
+		otherTherapistID := testutils.CreateTestTherapistWithName(t, database, "Dr. Other Therapist")
+		timeslotID := string(testutils.CreateTestTimeSlot(t, database, otherTherapistID))
+
+		testutils.AssertErrorCode(t, setActive(t, timeslotID, false), http.StatusNotFound, "timeslot.not_owned")
+	})
+
+	t.Run("Non-existent timeslot", func(t *testing.T) {
+		testutils.AssertErrorCode(t, setActive(t, "non-existent-timeslot", false), http.StatusNotFound, "timeslot.not_found")
+	})
+}
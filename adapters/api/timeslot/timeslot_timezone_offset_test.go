@@ -0,0 +1,52 @@
+package timeslot_handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api"
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+func TestParseTimezoneOffset(t *testing.T) {
+	tests := []struct {
+		name       string
+		param      string
+		wantOffset domain.TimezoneOffset
+		wantOK     bool
+	}{
+		{name: "Positive offset", param: "180", wantOffset: 180, wantOK: true},
+		{name: "Negative offset", param: "-300", wantOffset: -300, wantOK: true},
+		{name: "Empty", param: "", wantOK: false},
+		{name: "Not a number", param: "abc", wantOK: false},
+		{name: "Trailing garbage", param: "180abc", wantOK: false},
+		{name: "Huge number", param: "99999999999999999999999999", wantOK: false},
+		{name: "Out of range", param: "100000", wantOK: false},
+		{name: "Rune from an int", param: string(rune(180)), wantOK: false},
+		{name: "Control character", param: "\x00", wantOK: false},
+		{name: "Surrounding whitespace", param: " 180\n", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?timezoneOffset="+url.QueryEscape(tt.param), nil)
+			rec := httptest.NewRecorder()
+
+			offset, ok := parseTimezoneOffset(api.NewResponseWriter(rec), req)
+			if ok != tt.wantOK {
+				t.Fatalf("Expected ok=%v, got %v", tt.wantOK, ok)
+			}
+			if ok {
+				if offset != tt.wantOffset {
+					t.Errorf("Expected offset %d, got %d", tt.wantOffset, offset)
+				}
+				return
+			}
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+		})
+	}
+}
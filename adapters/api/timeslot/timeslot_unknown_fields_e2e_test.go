@@ -0,0 +1,58 @@
+package timeslot_handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
+)
+
+func TestCreateTimeslotRejectsUnknownFields(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	// The body is decoded before any usecase runs
+	timeslotHandler := NewTimeslotHandler(
+		nil,
+		create_therapist_timeslot.Usecase{},
+		get_therapist_timeslot.Usecase{},
+		update_therapist_timeslot.Usecase{},
+		delete_therapist_timeslot.Usecase{},
+		list_therapist_timeslots.Usecase{},
+		set_therapist_timeslot_active.Usecase{},
+		list_timeslot_bookings.Usecase{},
+		delete_therapist_timeslots_for_day.Usecase{},
+		export_therapist_timeslots.Usecase{},
+		import_therapist_timeslots.Usecase{},
+		list_raw_therapist_timeslots.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+	mux := http.NewServeMux()
+	timeslotHandler.RegisterRoutes(mux)
+
+	body := []byte(`{"dayOfWeek": "Monday", "startTimee": "09:00", "duration": 60, "afterSessionBreakTime": 15}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/therapists/therapist_1/timeslots", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+	if !bytes.Contains(rec.Body.Bytes(), []byte("startTimee")) {
+		t.Errorf("Expected the error to name the unknown field, got %s", rec.Body.String())
+	}
+}
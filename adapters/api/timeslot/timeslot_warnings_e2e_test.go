@@ -0,0 +1,110 @@
+package timeslot_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/api/internal/testutils"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/bulk_toggle_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
+)
+
+func TestCreateTimeslotDurationWarning(t *testing.T) {
+	database, cleanup := testutils.SetupTestDB(t)
+	defer cleanup()
+
+	testTherapistID := testutils.CreateTestTherapist(t, database)
+	repos := testutils.SetupRepositories(database)
+
+	// Warn above 12 hours
+	createUsecase := create_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, 12*60, 15, timeslot.ClinicHours{})
+	getUsecase := get_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	updateUsecase := update_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, timeslot.ClinicHours{})
+	deleteUsecase := delete_therapist_timeslot.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+	listUsecase := list_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	bulkToggleUsecase := bulk_toggle_therapist_timeslots.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+	setActiveUsecase := set_therapist_timeslot_active.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo)
+	listBookingsUsecase := list_timeslot_bookings.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.BookingRepo)
+	deleteForDayUsecase := delete_therapist_timeslots_for_day.NewUsecase(repos.TherapistRepo, repos.TimeSlotRepo, repos.Transactions)
+
+	timeslotHandler := NewTimeslotHandler(
+		bulkToggleUsecase,
+		*createUsecase,
+		*getUsecase,
+		*updateUsecase,
+		*deleteUsecase,
+		*listUsecase,
+		*setActiveUsecase,
+		*listBookingsUsecase,
+		*deleteForDayUsecase,
+		export_therapist_timeslots.Usecase{},
+		import_therapist_timeslots.Usecase{},
+		list_raw_therapist_timeslots.Usecase{},
+		testutils.NewClinicGuard(database),
+	)
+
+	mux := http.NewServeMux()
+	timeslotHandler.RegisterRoutes(mux)
+
+	create := func(t *testing.T, dayOfWeek string, duration int) map[string]interface{} {
+		requestBodyJSON, _ := json.Marshal(map[string]interface{}{
+			"dayOfWeek":             dayOfWeek,
+			"start":                 "02:00",
+			"duration":              duration,
+			"isActive":              true,
+			"afterSessionBreakTime": 15,
+		})
+		req := httptest.NewRequest(
+			http.MethodPost,
+			fmt.Sprintf("/api/v1/therapists/%s/timeslots", testTherapistID),
+			bytes.NewBuffer(requestBodyJSON),
+		)
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		var response map[string]interface{}
+		testutils.AssertJSONResponse(t, rr, http.StatusCreated, &response)
+		expectedLocation := fmt.Sprintf("/api/v1/therapists/%s/timeslots/%v", testTherapistID, response["id"])
+		if location := rr.Header().Get("Location"); location != expectedLocation {
+			t.Errorf("Expected Location %q, got %q", expectedLocation, location)
+		}
+		return response
+	}
+
+	t.Run("20 hour slot is created with a warning", func(t *testing.T) {
+		response := create(t, "Monday", 20*60)
+
+		testutils.AssertFieldExists(t, response, "id")
+		testutils.AssertFloatField(t, response, "duration", 20*60)
+
+		warnings, ok := response["warnings"].([]interface{})
+		if !ok || len(warnings) != 1 {
+			t.Fatalf("Expected one warning, got %v", response["warnings"])
+		}
+	})
+
+	t.Run("regular slot has no warnings", func(t *testing.T) {
+		response := create(t, "Tuesday", 60)
+
+		if _, ok := response["warnings"]; ok {
+			t.Errorf("Expected no warnings, got %v", response["warnings"])
+		}
+	})
+}
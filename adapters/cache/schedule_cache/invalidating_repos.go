@@ -0,0 +1,273 @@
+package schedule_cache
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+// BookingRepository invalidates the cached schedule whenever a booking is
+// written. Reads go straight to the wrapped repository.
+type BookingRepository struct {
+	ports.BookingRepository
+	cache ports.ScheduleCache
+}
+
+func NewBookingRepository(repo ports.BookingRepository, cache ports.ScheduleCache) ports.BookingRepository {
+	return &BookingRepository{BookingRepository: repo, cache: cache}
+}
+
+func (r *BookingRepository) Create(b *booking.Booking) error {
+	if err := r.BookingRepository.Create(b); err != nil {
+		return err
+	}
+	invalidateAround(r.cache, b.StartTime.Time())
+	return nil
+}
+
+func (r *BookingRepository) CreateTx(sqlExec ports.SQLExec, b *booking.Booking) error {
+	if err := r.BookingRepository.CreateTx(sqlExec, b); err != nil {
+		return err
+	}
+	invalidateAround(r.cache, b.StartTime.Time())
+	return nil
+}
+
+func (r *BookingRepository) UpdateState(bookingID domain.BookingID, state booking.BookingState, updatedAt time.Time, actor string) error {
+	defer r.invalidateBooking(bookingID)
+	return r.BookingRepository.UpdateState(bookingID, state, updatedAt, actor)
+}
+
+func (r *BookingRepository) Delete(id domain.BookingID) error {
+	// Look the booking up first, it's gone afterwards
+	r.invalidateBooking(id)
+	return r.BookingRepository.Delete(id)
+}
+
+// The transactional writes below drop the whole cache instead of looking the
+// booking up, which would need a second connection while the transaction
+// holds the database.
+
+func (r *BookingRepository) UpdateStateTx(sqlExec ports.SQLExec, bookingID domain.BookingID, state booking.BookingState, updatedAt time.Time, actor string) error {
+	defer r.cache.InvalidateAll()
+	return r.BookingRepository.UpdateStateTx(sqlExec, bookingID, state, updatedAt, actor)
+}
+
+func (r *BookingRepository) BulkCancel(tx ports.SQLTx, bookingIDs []domain.BookingID, updatedAt time.Time, actor string) error {
+	defer r.cache.InvalidateAll()
+	return r.BookingRepository.BulkCancel(tx, bookingIDs, updatedAt, actor)
+}
+
+func (r *BookingRepository) ReassignTx(sqlExec ports.SQLExec, bookingID domain.BookingID, therapistID domain.TherapistID, timeSlotID domain.TimeSlotID, updatedAt time.Time) error {
+	defer r.cache.InvalidateAll()
+	return r.BookingRepository.ReassignTx(sqlExec, bookingID, therapistID, timeSlotID, updatedAt)
+}
+
+func (r *BookingRepository) UpdateDurationTx(sqlExec ports.SQLExec, bookingID domain.BookingID, duration domain.DurationMinutes, updatedAt time.Time) error {
+	defer r.cache.InvalidateAll()
+	return r.BookingRepository.UpdateDurationTx(sqlExec, bookingID, duration, updatedAt)
+}
+
+// invalidateBooking drops the days around the booking, or the whole cache
+// when the booking can't be looked up.
+func (r *BookingRepository) invalidateBooking(id domain.BookingID) {
+	existing, err := r.BookingRepository.GetByID(id)
+	if err != nil || existing == nil {
+		r.cache.InvalidateAll()
+		return
+	}
+	invalidateAround(r.cache, existing.StartTime.Time())
+}
+
+// AdhocBookingRepository invalidates the cached schedule whenever an adhoc
+// booking is written, the same way BookingRepository does.
+type AdhocBookingRepository struct {
+	ports.AdhocBookingRepository
+	cache ports.ScheduleCache
+}
+
+func NewAdhocBookingRepository(repo ports.AdhocBookingRepository, cache ports.ScheduleCache) ports.AdhocBookingRepository {
+	return &AdhocBookingRepository{AdhocBookingRepository: repo, cache: cache}
+}
+
+func (r *AdhocBookingRepository) Create(b *booking.AdhocBooking) error {
+	if err := r.AdhocBookingRepository.Create(b); err != nil {
+		return err
+	}
+	invalidateAround(r.cache, b.StartTime.Time())
+	return nil
+}
+
+func (r *AdhocBookingRepository) UpdateState(adhocBookingID domain.AdhocBookingID, state booking.BookingState, updatedAt time.Time) error {
+	defer r.invalidateAdhocBooking(adhocBookingID)
+	return r.AdhocBookingRepository.UpdateState(adhocBookingID, state, updatedAt)
+}
+
+func (r *AdhocBookingRepository) UpdateStateTx(sqlExec ports.SQLExec, adhocBookingID domain.AdhocBookingID, state booking.BookingState, updatedAt time.Time) error {
+	defer r.cache.InvalidateAll()
+	return r.AdhocBookingRepository.UpdateStateTx(sqlExec, adhocBookingID, state, updatedAt)
+}
+
+func (r *AdhocBookingRepository) BulkCancel(tx ports.SQLTx, adhocBookingIDs []domain.AdhocBookingID, updatedAt time.Time) error {
+	defer r.cache.InvalidateAll()
+	return r.AdhocBookingRepository.BulkCancel(tx, adhocBookingIDs, updatedAt)
+}
+
+// invalidateAdhocBooking drops the days around the adhoc booking, or the
+// whole cache when it can't be looked up.
+func (r *AdhocBookingRepository) invalidateAdhocBooking(id domain.AdhocBookingID) {
+	existing, err := r.AdhocBookingRepository.GetByID(id)
+	if err != nil || existing == nil {
+		r.cache.InvalidateAll()
+		return
+	}
+	invalidateAround(r.cache, existing.StartTime.Time())
+}
+
+// TimeSlotRepository invalidates the cached schedule whenever a timeslot is
+// written. Timeslots repeat weekly, so every write drops the whole cache.
+type TimeSlotRepository struct {
+	ports.TimeSlotRepository
+	cache ports.ScheduleCache
+}
+
+func NewTimeSlotRepository(repo ports.TimeSlotRepository, cache ports.ScheduleCache) ports.TimeSlotRepository {
+	return &TimeSlotRepository{TimeSlotRepository: repo, cache: cache}
+}
+
+func (r *TimeSlotRepository) Create(slot *timeslot.TimeSlot) error {
+	defer r.cache.InvalidateAll()
+	return r.TimeSlotRepository.Create(slot)
+}
+
+func (r *TimeSlotRepository) CreateTx(sqlExec ports.SQLExec, slot *timeslot.TimeSlot) error {
+	defer r.cache.InvalidateAll()
+	return r.TimeSlotRepository.CreateTx(sqlExec, slot)
+}
+
+func (r *TimeSlotRepository) Update(slot *timeslot.TimeSlot) error {
+	defer r.cache.InvalidateAll()
+	return r.TimeSlotRepository.Update(slot)
+}
+
+func (r *TimeSlotRepository) Delete(id domain.TimeSlotID) error {
+	defer r.cache.InvalidateAll()
+	return r.TimeSlotRepository.Delete(id)
+}
+
+func (r *TimeSlotRepository) BulkDeleteTx(sqlExec ports.SQLExec, ids []domain.TimeSlotID) error {
+	defer r.cache.InvalidateAll()
+	return r.TimeSlotRepository.BulkDeleteTx(sqlExec, ids)
+}
+
+func (r *TimeSlotRepository) BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) (int, error) {
+	defer r.cache.InvalidateAll()
+	return r.TimeSlotRepository.BulkToggleByTherapistID(therapistID, isActive)
+}
+
+func (r *TimeSlotRepository) SetActive(id domain.TimeSlotID, isActive bool) error {
+	defer r.cache.InvalidateAll()
+	return r.TimeSlotRepository.SetActive(id, isActive)
+}
+
+// RecurringBlockRepository invalidates the cached schedule whenever a
+// recurring block is written. Like timeslots, blocks repeat weekly.
+type RecurringBlockRepository struct {
+	ports.RecurringBlockRepository
+	cache ports.ScheduleCache
+}
+
+func NewRecurringBlockRepository(repo ports.RecurringBlockRepository, cache ports.ScheduleCache) ports.RecurringBlockRepository {
+	return &RecurringBlockRepository{RecurringBlockRepository: repo, cache: cache}
+}
+
+func (r *RecurringBlockRepository) Create(block *timeslot.RecurringBlock) error {
+	defer r.cache.InvalidateAll()
+	return r.RecurringBlockRepository.Create(block)
+}
+
+func (r *RecurringBlockRepository) Update(block *timeslot.RecurringBlock) error {
+	defer r.cache.InvalidateAll()
+	return r.RecurringBlockRepository.Update(block)
+}
+
+func (r *RecurringBlockRepository) Delete(id domain.RecurringBlockID) error {
+	defer r.cache.InvalidateAll()
+	return r.RecurringBlockRepository.Delete(id)
+}
+
+// TherapistRepository invalidates the cached schedule whenever a therapist is
+// written. Schedules embed therapist info and are keyed by specialization, so
+// every write drops the whole cache.
+type TherapistRepository struct {
+	ports.TherapistRepository
+	cache ports.ScheduleCache
+}
+
+func NewTherapistRepository(repo ports.TherapistRepository, cache ports.ScheduleCache) ports.TherapistRepository {
+	return &TherapistRepository{TherapistRepository: repo, cache: cache}
+}
+
+func (r *TherapistRepository) Create(t *therapist.Therapist) error {
+	defer r.cache.InvalidateAll()
+	return r.TherapistRepository.Create(t)
+}
+
+func (r *TherapistRepository) Update(t *therapist.Therapist) error {
+	defer r.cache.InvalidateAll()
+	return r.TherapistRepository.Update(t)
+}
+
+func (r *TherapistRepository) UpdateSpecializations(therapistID domain.TherapistID, specializationIDs []domain.SpecializationID) error {
+	defer r.cache.InvalidateAll()
+	return r.TherapistRepository.UpdateSpecializations(therapistID, specializationIDs)
+}
+
+func (r *TherapistRepository) UpdateLanguages(therapistID domain.TherapistID, languages []domain.LanguageCode) error {
+	defer r.cache.InvalidateAll()
+	return r.TherapistRepository.UpdateLanguages(therapistID, languages)
+}
+
+func (r *TherapistRepository) UpdateMaxDailySessions(therapistID domain.TherapistID, maxDailySessions int) error {
+	defer r.cache.InvalidateAll()
+	return r.TherapistRepository.UpdateMaxDailySessions(therapistID, maxDailySessions)
+}
+
+func (r *TherapistRepository) Delete(id domain.TherapistID) error {
+	defer r.cache.InvalidateAll()
+	return r.TherapistRepository.Delete(id)
+}
+
+// SpecializationRepository invalidates the cached schedule whenever
+// therapists move between specializations. Schedules are keyed by
+// specialization, so every such write drops the whole cache.
+type SpecializationRepository struct {
+	ports.SpecializationRepository
+	cache ports.ScheduleCache
+}
+
+func NewSpecializationRepository(repo ports.SpecializationRepository, cache ports.ScheduleCache) ports.SpecializationRepository {
+	return &SpecializationRepository{SpecializationRepository: repo, cache: cache}
+}
+
+func (r *SpecializationRepository) ReassignTherapistsTx(sqlExec ports.SQLExec, from, to domain.SpecializationID) (int, error) {
+	defer r.cache.InvalidateAll()
+	return r.SpecializationRepository.ReassignTherapistsTx(sqlExec, from, to)
+}
+
+func (r *SpecializationRepository) DeleteTx(sqlExec ports.SQLExec, id domain.SpecializationID) error {
+	defer r.cache.InvalidateAll()
+	return r.SpecializationRepository.DeleteTx(sqlExec, id)
+}
+
+// invalidateAround drops the booking's day and its neighbours, since a slot
+// with a timezone can render a booking onto the adjacent UTC day.
+func invalidateAround(cache ports.ScheduleCache, startTime time.Time) {
+	for offset := -1; offset <= 1; offset++ {
+		cache.InvalidateDate(startTime.AddDate(0, 0, offset))
+	}
+}
@@ -0,0 +1,76 @@
+package schedule_cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain/schedule"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type entry struct {
+	ranges    []schedule.AvailableTimeRange
+	expiresAt time.Time
+}
+
+// ScheduleCache is an in-memory ports.ScheduleCache whose entries expire
+// after a fixed TTL.
+type ScheduleCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[ports.ScheduleCacheKey]entry
+	now     func() time.Time
+}
+
+func NewScheduleCache(ttl time.Duration) *ScheduleCache {
+	return &ScheduleCache{
+		ttl:     ttl,
+		entries: make(map[ports.ScheduleCacheKey]entry),
+		now:     time.Now,
+	}
+}
+
+func (c *ScheduleCache) Get(key ports.ScheduleCacheKey) ([]schedule.AvailableTimeRange, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(cached.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return cached.ranges, true
+}
+
+func (c *ScheduleCache) Set(key ports.ScheduleCacheKey, ranges []schedule.AvailableTimeRange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry{
+		ranges:    ranges,
+		expiresAt: c.now().Add(c.ttl),
+	}
+}
+
+// InvalidateDate drops the entries of every specialization on the given day
+func (c *ScheduleCache) InvalidateDate(date time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	day := date.UTC().Format(time.DateOnly)
+	for key := range c.entries {
+		if key.Date == day {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *ScheduleCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[ports.ScheduleCacheKey]entry)
+}
@@ -0,0 +1,374 @@
+package schedule_cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+// -----------------------------
+// Spies
+// -----------------------------
+
+type spyTherapistRepo struct {
+	ports.TherapistRepository
+	therapists []*therapist.Therapist
+}
+
+func (r *spyTherapistRepo) FindBySpecializationAndLanguage(clinicID domain.ClinicID, tag string, languageCode domain.LanguageCode) ([]*therapist.Therapist, error) {
+	return r.therapists, nil
+}
+
+type spyTimeSlotRepo struct {
+	ports.TimeSlotRepository
+	slots   []*timeslot.TimeSlot
+	queries int
+}
+
+func (r *spyTimeSlotRepo) BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.TimeSlot, error) {
+	r.queries++
+	out := make(map[domain.TherapistID][]*timeslot.TimeSlot)
+	for _, s := range r.slots {
+		out[s.TherapistID] = append(out[s.TherapistID], s)
+	}
+	return out, nil
+}
+
+func (r *spyTimeSlotRepo) BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) (int, error) {
+	return 0, nil
+}
+
+type spyBookingRepo struct {
+	ports.BookingRepository
+	bookings []*booking.Booking
+}
+
+func (r *spyBookingRepo) Create(b *booking.Booking) error {
+	r.bookings = append(r.bookings, b)
+	return nil
+}
+
+func (r *spyBookingRepo) BulkListByTherapistForDateRange(
+	therapistIDs []domain.TherapistID,
+	states []booking.BookingState,
+	startDate, endDate time.Time,
+) (map[domain.TherapistID][]*booking.Booking, error) {
+	// Same window as the SQL query: bookings starting or ending in the range
+	out := make(map[domain.TherapistID][]*booking.Booking)
+	for _, b := range r.bookings {
+		start := b.StartTime.Time()
+		end := start.Add(time.Duration(b.Duration) * time.Minute)
+		startsInRange := !start.Before(startDate) && !start.After(endDate)
+		endsInRange := end.After(startDate) && !end.After(endDate)
+		if !startsInRange && !endsInRange {
+			continue
+		}
+		out[b.TherapistID] = append(out[b.TherapistID], b)
+	}
+	return out, nil
+}
+
+type spyTherapistWriteRepo struct {
+	ports.TherapistRepository
+}
+
+func (r *spyTherapistWriteRepo) Update(t *therapist.Therapist) error {
+	return nil
+}
+
+func (r *spyTherapistWriteRepo) UpdateSpecializations(therapistID domain.TherapistID, specializationIDs []domain.SpecializationID) error {
+	return nil
+}
+
+func (r *spyTherapistWriteRepo) UpdateLanguages(therapistID domain.TherapistID, languages []domain.LanguageCode) error {
+	return nil
+}
+
+func (r *spyTherapistWriteRepo) UpdateMaxDailySessions(therapistID domain.TherapistID, maxDailySessions int) error {
+	return nil
+}
+
+type spySpecializationWriteRepo struct {
+	ports.SpecializationRepository
+}
+
+func (r *spySpecializationWriteRepo) ReassignTherapistsTx(sqlExec ports.SQLExec, from, to domain.SpecializationID) (int, error) {
+	return 0, nil
+}
+
+func (r *spySpecializationWriteRepo) DeleteTx(sqlExec ports.SQLExec, id domain.SpecializationID) error {
+	return nil
+}
+
+type spyAdhocBookingWriteRepo struct {
+	ports.AdhocBookingRepository
+}
+
+func (r *spyAdhocBookingWriteRepo) Create(b *booking.AdhocBooking) error {
+	return nil
+}
+
+// -----------------------------
+// Tests
+// -----------------------------
+
+func TestScheduleCache(t *testing.T) {
+	anxiety := specialization.Specialization{ID: "specialization_1", Name: "anxiety"}
+	therapistEntry := &therapist.Therapist{
+		ID:              "therapist_1",
+		SpeaksEnglish:   true,
+		Specializations: []specialization.Specialization{anxiety},
+	}
+
+	// Far enough ahead that advance notice never excludes the slot
+	day := time.Now().UTC().AddDate(0, 0, 3).Truncate(24 * time.Hour)
+	slot := &timeslot.TimeSlot{
+		ID:                    "slot_1",
+		TherapistID:           therapistEntry.ID,
+		IsActive:              true,
+		DayOfWeek:             timeslot.MapToDayOfWeek(day.Weekday()),
+		Start:                 "09:00",
+		Duration:              180,
+		AfterSessionBreakTime: 15,
+	}
+
+	cache := NewScheduleCache(time.Minute)
+	timeSlotRepo := &spyTimeSlotRepo{slots: []*timeslot.TimeSlot{slot}}
+	bookingRepo := NewBookingRepository(&spyBookingRepo{}, cache)
+	usecase := get_schedule.NewUsecase(
+		&spyTherapistRepo{therapists: []*therapist.Therapist{therapistEntry}},
+		timeSlotRepo,
+		bookingRepo,
+		nil,
+		nil,
+		15,
+		cache,
+		0,
+	)
+
+	input := get_schedule.Input{
+		SpecializationTags: []string{"anxiety"},
+		MustSpeakEnglish:   true,
+		StartDate:          day,
+		EndDate:            day,
+	}
+
+	first, err := usecase.Execute(input)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if len(first) != 1 {
+		t.Fatalf("expected 1 range, got %d", len(first))
+	}
+
+	t.Run("identical request is served from the cache", func(t *testing.T) {
+		second, err := usecase.Execute(input)
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if timeSlotRepo.queries != 1 {
+			t.Errorf("expected 1 timeslot query, got %d", timeSlotRepo.queries)
+		}
+		if len(second) != len(first) || !second[0].From.Equal(first[0].From) {
+			t.Errorf("expected cached ranges %+v, got %+v", first, second)
+		}
+	})
+
+	t.Run("new booking invalidates the day", func(t *testing.T) {
+		now := domain.NewUTCTimestamp()
+		err := bookingRepo.Create(&booking.Booking{
+			ID:          "booking_1",
+			TimeSlotID:  slot.ID,
+			TherapistID: therapistEntry.ID,
+			State:       booking.BookingStateConfirmed,
+			StartTime:   domain.UTCTimestamp(day.Add(9 * time.Hour)),
+			Duration:    60,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			t.Fatalf("failed to create booking: %v", err)
+		}
+
+		afterBooking, err := usecase.Execute(input)
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if timeSlotRepo.queries != 2 {
+			t.Errorf("expected the schedule to be recomputed, got %d timeslot queries", timeSlotRepo.queries)
+		}
+		if len(afterBooking) != 1 || !afterBooking[0].From.After(first[0].From) {
+			t.Errorf("expected availability to start after the booking, got %+v", afterBooking)
+		}
+	})
+
+	t.Run("entries expire after the TTL", func(t *testing.T) {
+		cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+		defer func() { cache.now = time.Now }()
+
+		queriesBefore := timeSlotRepo.queries
+		if _, err := usecase.Execute(input); err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if timeSlotRepo.queries != queriesBefore+1 {
+			t.Errorf("expected expired entry to be recomputed, got %d queries", timeSlotRepo.queries-queriesBefore)
+		}
+	})
+
+	t.Run("timeslot writes drop every entry", func(t *testing.T) {
+		cache.Set(ports.ScheduleCacheKey{SpecializationTag: "anxiety", Date: "2030-01-01"}, nil)
+		repo := NewTimeSlotRepository(timeSlotRepo, cache)
+		if _, err := repo.BulkToggleByTherapistID(therapistEntry.ID, true); err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+
+		if _, ok := cache.Get(ports.ScheduleCacheKey{SpecializationTag: "anxiety", Date: "2030-01-01"}); ok {
+			t.Error("expected entry to be invalidated")
+		}
+	})
+}
+
+func TestScheduleCacheExcludesExistingBookings(t *testing.T) {
+	therapistEntry := &therapist.Therapist{ID: "therapist_1", SpeaksEnglish: true}
+
+	// Far enough ahead that advance notice never excludes the slot
+	day := time.Now().UTC().AddDate(0, 0, 3).Truncate(24 * time.Hour)
+	slot := &timeslot.TimeSlot{
+		ID:                    "slot_1",
+		TherapistID:           therapistEntry.ID,
+		IsActive:              true,
+		DayOfWeek:             timeslot.MapToDayOfWeek(day.Weekday()),
+		Start:                 "09:00",
+		Duration:              180,
+		AfterSessionBreakTime: 15,
+	}
+
+	// The booking exists before the day is first computed and cached
+	now := domain.NewUTCTimestamp()
+	bookingStart := domain.UTCTimestamp(day.Add(9 * time.Hour))
+	bookingRepo := &spyBookingRepo{bookings: []*booking.Booking{{
+		ID:          "booking_1",
+		TimeSlotID:  slot.ID,
+		TherapistID: therapistEntry.ID,
+		State:       booking.BookingStateConfirmed,
+		StartTime:   bookingStart,
+		Duration:    60,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}}}
+
+	cache := NewScheduleCache(time.Minute)
+	usecase := get_schedule.NewUsecase(
+		&spyTherapistRepo{therapists: []*therapist.Therapist{therapistEntry}},
+		&spyTimeSlotRepo{slots: []*timeslot.TimeSlot{slot}},
+		bookingRepo,
+		nil,
+		nil,
+		15,
+		cache,
+		0,
+	)
+
+	input := get_schedule.Input{
+		SpecializationTags: []string{"anxiety"},
+		StartDate:          day,
+		EndDate:            day,
+	}
+
+	for _, label := range []string{"computed", "cached"} {
+		ranges, err := usecase.Execute(input)
+		if err != nil {
+			t.Fatalf("%s: expected success, got %v", label, err)
+		}
+		for _, r := range ranges {
+			if r.From.Before(bookingStart.Add(60 * time.Minute)) {
+				t.Errorf("%s: expected availability to start after the booking, got %+v", label, r)
+			}
+		}
+		if len(ranges) == 0 {
+			t.Errorf("%s: expected the rest of the slot to stay available", label)
+		}
+	}
+}
+
+func TestTherapistWritesInvalidateCache(t *testing.T) {
+	key := ports.ScheduleCacheKey{SpecializationTag: "anxiety", Date: "2030-01-01"}
+	cache := NewScheduleCache(time.Minute)
+	repo := NewTherapistRepository(&spyTherapistWriteRepo{}, cache)
+
+	cache.Set(key, nil)
+	if err := repo.Update(&therapist.Therapist{ID: "therapist_1"}); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected therapist update to invalidate the cache")
+	}
+
+	cache.Set(key, nil)
+	if err := repo.UpdateSpecializations("therapist_1", nil); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected specialization update to invalidate the cache")
+	}
+
+	cache.Set(key, nil)
+	if err := repo.UpdateLanguages("therapist_1", nil); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected language update to invalidate the cache")
+	}
+
+	cache.Set(key, nil)
+	if err := repo.UpdateMaxDailySessions("therapist_1", 2); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected daily session cap update to invalidate the cache")
+	}
+}
+
+func TestAdhocBookingWritesInvalidateCache(t *testing.T) {
+	startTime := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	key := ports.ScheduleCacheKey{SpecializationTag: "anxiety", Date: "2030-01-01"}
+	cache := NewScheduleCache(time.Minute)
+	repo := NewAdhocBookingRepository(&spyAdhocBookingWriteRepo{}, cache)
+
+	cache.Set(key, nil)
+	if err := repo.Create(&booking.AdhocBooking{ID: "adhoc_1", StartTime: domain.UTCTimestamp(startTime)}); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected adhoc booking creation to invalidate its day")
+	}
+}
+
+func TestSpecializationWritesInvalidateCache(t *testing.T) {
+	key := ports.ScheduleCacheKey{SpecializationTag: "anxiety", Date: "2030-01-01"}
+	cache := NewScheduleCache(time.Minute)
+	repo := NewSpecializationRepository(&spySpecializationWriteRepo{}, cache)
+
+	cache.Set(key, nil)
+	if _, err := repo.ReassignTherapistsTx(nil, "specialization_1", "specialization_2"); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected therapist reassignment to invalidate the cache")
+	}
+
+	cache.Set(key, nil)
+	if err := repo.DeleteTx(nil, "specialization_1"); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected specialization deletion to invalidate the cache")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mishkahtherapy/brain/adapters/db"
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/booking"
 	"github.com/mishkahtherapy/brain/core/ports"
@@ -51,6 +52,37 @@ func (r *BookingRepository) GetByID(id domain.BookingID) (*booking.Booking, erro
 }
 
 func (r *BookingRepository) Create(booking *booking.Booking) error {
+	if err := validateBooking(booking); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		slog.Error("error starting booking creation transaction", "error", err)
+		return ports.ErrFailedToCreateBooking
+	}
+
+	if err := insertBooking(tx, booking); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.Error("error committing booking creation", "error", err)
+		return ports.ErrFailedToCreateBooking
+	}
+	return nil
+}
+
+// CreateTx creates the booking within the caller's transaction
+func (r *BookingRepository) CreateTx(sqlExec ports.SQLExec, booking *booking.Booking) error {
+	if err := validateBooking(booking); err != nil {
+		return err
+	}
+	return insertBooking(sqlExec, booking)
+}
+
+func validateBooking(booking *booking.Booking) error {
 	if booking.ID == "" {
 		return ports.ErrBookingIDIsRequired
 	}
@@ -86,13 +118,16 @@ func (r *BookingRepository) Create(booking *booking.Booking) error {
 	if booking.Duration == 0 {
 		return ports.ErrBookingDurationIsRequired
 	}
+	return nil
+}
 
+func insertBooking(sqlExec ports.SQLExec, booking *booking.Booking) error {
 	query := `
 		INSERT INTO bookings (
 			id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := r.db.Exec(
+	_, err := sqlExec.Exec(
 		query,
 		booking.ID,
 		booking.TimeSlotID,
@@ -109,6 +144,12 @@ func (r *BookingRepository) Create(booking *booking.Booking) error {
 		slog.Error("error creating booking", "error", err)
 		return ports.ErrFailedToCreateBooking
 	}
+
+	// The initial state opens the booking's audit trail
+	err = insertStateChange(sqlExec, booking.ID, "", booking.State, booking.CreatedAt.Time(), "")
+	if err != nil {
+		return ports.ErrFailedToCreateBooking
+	}
 	return nil
 }
 
@@ -117,6 +158,7 @@ func (r *BookingRepository) UpdateStateTx(
 	bookingID domain.BookingID,
 	state booking.BookingState,
 	updatedAt time.Time,
+	actor string,
 ) error {
 	if bookingID == "" {
 		return ports.ErrBookingIDIsRequired
@@ -126,6 +168,16 @@ func (r *BookingRepository) UpdateStateTx(
 		return ports.ErrBookingStateIsRequired
 	}
 
+	var fromState booking.BookingState
+	err := sqlExec.QueryRow(`SELECT state FROM bookings WHERE id = ?`, bookingID).Scan(&fromState)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ports.ErrBookingNotFound
+		}
+		slog.Error("error reading booking state", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
 	query := `
 		UPDATE bookings 
 			SET state = ?, updated_at = ?
@@ -153,15 +205,86 @@ func (r *BookingRepository) UpdateStateTx(
 		return ports.ErrBookingNotFound
 	}
 
-	return nil
+	return insertStateChange(sqlExec, bookingID, fromState, state, updatedAt, actor)
 }
 
+// UpdateState runs UpdateStateTx in its own transaction so the state change
+// and its audit row are written together.
 func (r *BookingRepository) UpdateState(
 	bookingID domain.BookingID,
 	state booking.BookingState,
 	updatedAt time.Time,
+	actor string,
 ) error {
-	return r.UpdateStateTx(r.db, bookingID, state, updatedAt)
+	tx, err := r.db.Begin()
+	if err != nil {
+		slog.Error("error starting booking update transaction", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
+	if err := r.UpdateStateTx(tx, bookingID, state, updatedAt, actor); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.Error("error committing booking update", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+	return nil
+}
+
+func (r *BookingRepository) ListStateChanges(bookingID domain.BookingID) ([]*booking.StateChange, error) {
+	query := `
+		SELECT booking_id, from_state, to_state, changed_at, actor
+		FROM booking_audit
+		WHERE booking_id = ?
+		ORDER BY changed_at ASC, id ASC
+	`
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		slog.Error("error listing booking state changes", "error", err)
+		return nil, ports.ErrFailedToGetBookings
+	}
+	defer rows.Close()
+
+	changes := make([]*booking.StateChange, 0)
+	for rows.Next() {
+		change := &booking.StateChange{}
+		err := rows.Scan(
+			&change.BookingID,
+			&change.FromState,
+			&change.ToState,
+			&change.ChangedAt,
+			&change.Actor,
+		)
+		if err != nil {
+			slog.Error("error scanning booking state change", "error", err)
+			return nil, ports.ErrFailedToGetBookings
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func insertStateChange(
+	sqlExec ports.SQLExec,
+	bookingID domain.BookingID,
+	fromState booking.BookingState,
+	toState booking.BookingState,
+	changedAt time.Time,
+	actor string,
+) error {
+	query := `
+		INSERT INTO booking_audit (booking_id, from_state, to_state, changed_at, actor)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	_, err := sqlExec.Exec(query, bookingID, fromState, toState, changedAt, actor)
+	if err != nil {
+		slog.Error("error recording booking state change", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+	return nil
 }
 
 func (r *BookingRepository) Delete(id domain.BookingID) error {
@@ -217,7 +340,7 @@ func (r *BookingRepository) List(filters ports.BookingFilters) ([]*booking.Booki
 
 	query += ` ORDER BY start_time ASC`
 
-	rows, err := r.db.Query(query, params...)
+	rows, err := r.db.Reader().Query(query, params...)
 	if err != nil {
 		slog.Error("error listing bookings", "error", err)
 		return nil, ports.ErrFailedToGetBookings
@@ -276,6 +399,9 @@ func (r *BookingRepository) BulkListByTherapistForDateRange(
 		return nil, ports.ErrBookingTherapistIDIsRequired
 	}
 
+	// This stays on the primary: booking creation and cancellation use it to
+	// find conflicts, and a lagging replica would let a double booking through.
+
 	// Calculate endTimeForBookingStartedBeforeRange (startDate - 1 hour)
 	// FIXME: I don't capture bookings that start before the range but extend into it
 	// Example: a booking at 11.30PM that ends at 12.30AM next day is not captured.
@@ -367,13 +493,14 @@ func (r *BookingRepository) BulkListByTherapistForDateRange(
 // Search returns all bookings whose start_time is within the inclusive range
 // [startDate, endDate]. When state is provided (non-nil), the results are
 // further filtered by the given booking state.
-func (r *BookingRepository) Search(startDate, endDate time.Time, states []booking.BookingState) ([]*booking.Booking, error) {
+func (r *BookingRepository) Search(clinicID domain.ClinicID, startDate, endDate time.Time, states []booking.BookingState, page ports.Page) ([]*booking.Booking, error) {
 	query := `
 		SELECT id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at
 		FROM bookings
 		WHERE 1=1
 	`
-	params := []interface{}{}
+	clinicFilter, params := db.ClinicFilter(clinicID)
+	query += clinicFilter
 
 	// Add start date filter if provided (not zero time)
 	if !startDate.IsZero() {
@@ -400,11 +527,52 @@ func (r *BookingRepository) Search(startDate, endDate time.Time, states []bookin
 		params = append(params, endDate)
 	}
 
+	keysetFilter, keysetParams := db.KeysetFilter(page.After)
+	query += keysetFilter
+	params = append(params, keysetParams...)
+
+	order, orderParams := db.KeysetOrder(page.Limit)
+	query += order
+	params = append(params, orderParams...)
+
+	rows, err := r.db.Reader().Query(query, params...)
+	if err != nil {
+		slog.Error("error searching bookings", "error", err)
+		return nil, ports.ErrFailedToGetBookings
+	}
+	defer rows.Close()
+
+	return r.scanBookings(rows)
+}
+
+// ListByTimeSlot returns the bookings made against a timeslot ordered by
+// start time. When states is empty bookings in every state are returned.
+func (r *BookingRepository) ListByTimeSlot(timeSlotID domain.TimeSlotID, states []booking.BookingState) ([]*booking.Booking, error) {
+	if timeSlotID == "" {
+		return nil, ports.ErrBookingTimeSlotIDIsRequired
+	}
+
+	query := `
+		SELECT id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at
+		FROM bookings
+		WHERE timeslot_id = ?
+	`
+	params := []interface{}{timeSlotID}
+
+	if len(states) > 0 {
+		placeholders := make([]string, len(states))
+		for i, state := range states {
+			placeholders[i] = "?"
+			params = append(params, state)
+		}
+		query += fmt.Sprintf(" AND state IN (%s)", strings.Join(placeholders, ","))
+	}
+
 	query += " ORDER BY start_time ASC"
 
 	rows, err := r.db.Query(query, params...)
 	if err != nil {
-		slog.Error("error searching bookings", "error", err)
+		slog.Error("error listing bookings by timeslot", "error", err, "timeSlotID", timeSlotID)
 		return nil, ports.ErrFailedToGetBookings
 	}
 	defer rows.Close()
@@ -412,27 +580,283 @@ func (r *BookingRepository) Search(startDate, endDate time.Time, states []bookin
 	return r.scanBookings(rows)
 }
 
-func (r *BookingRepository) BulkCancel(tx ports.SQLTx, bookingIDs []domain.BookingID) error {
+func (r *BookingRepository) CountActiveByTimeSlot(timeSlotID domain.TimeSlotID) (int, error) {
+	if timeSlotID == "" {
+		return 0, ports.ErrBookingTimeSlotIDIsRequired
+	}
+
 	query := `
-		UPDATE bookings
-		SET state = ?
-		WHERE id IN (%s)
+		SELECT COUNT(*)
+		FROM bookings
+		WHERE timeslot_id = ? AND state IN (?, ?, ?) AND start_time > ?
 	`
-	values := make([]any, 0)
-	values = append(values, booking.BookingStateCancelled)
+	var count int
+	err := r.db.Reader().QueryRow(
+		query,
+		timeSlotID,
+		booking.BookingStatePending,
+		booking.BookingStateHeld,
+		booking.BookingStateConfirmed,
+		time.Now().UTC(),
+	).Scan(&count)
+	if err != nil {
+		slog.Error("error counting active bookings by timeslot", "error", err, "timeSlotID", timeSlotID)
+		return 0, ports.ErrFailedToGetBookings
+	}
+	return count, nil
+}
+
+// BulkCancel cancels the bookings and records an audit row for each booking
+// whose state actually changed.
+func (r *BookingRepository) BulkCancel(tx ports.SQLTx, bookingIDs []domain.BookingID, updatedAt time.Time, actor string) error {
+	if len(bookingIDs) == 0 {
+		return nil
+	}
 
 	placeholders := make([]string, len(bookingIDs))
+	ids := make([]any, len(bookingIDs))
 	for i := range bookingIDs {
 		placeholders[i] = "?"
-		values = append(values, bookingIDs[i])
+		ids[i] = bookingIDs[i]
 	}
 	placeholdersStr := strings.Join(placeholders, ",")
-	query = fmt.Sprintf(query, placeholdersStr)
 
-	_, err := tx.Exec(query, values...)
-	if err != nil {
+	// Audit first, the current state is the audit row's from_state
+	auditQuery := fmt.Sprintf(`
+		INSERT INTO booking_audit (booking_id, from_state, to_state, changed_at, actor)
+		SELECT id, state, ?, ?, ?
+		FROM bookings
+		WHERE id IN (%s) AND state != ?
+	`, placeholdersStr)
+	auditValues := append([]any{booking.BookingStateCancelled, updatedAt, actor}, ids...)
+	auditValues = append(auditValues, booking.BookingStateCancelled)
+	if _, err := tx.Exec(auditQuery, auditValues...); err != nil {
+		slog.Error("error recording bulk booking cancellation", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
+	updateQuery := fmt.Sprintf(`
+		UPDATE bookings
+		SET state = ?, updated_at = ?
+		WHERE id IN (%s) AND state != ?
+	`, placeholdersStr)
+	updateValues := append([]any{booking.BookingStateCancelled, updatedAt}, ids...)
+	updateValues = append(updateValues, booking.BookingStateCancelled)
+	if _, err := tx.Exec(updateQuery, updateValues...); err != nil {
 		slog.Error("error bulk cancelling bookings", "error", err)
 		return ports.ErrFailedToUpdateBooking
 	}
 	return nil
 }
+
+// ReassignTx moves a booking to another therapist and timeslot. A session
+// created for the booking follows it to the new therapist.
+func (r *BookingRepository) ReassignTx(
+	sqlExec ports.SQLExec,
+	bookingID domain.BookingID,
+	therapistID domain.TherapistID,
+	timeSlotID domain.TimeSlotID,
+	updatedAt time.Time,
+) error {
+	if bookingID == "" {
+		return ports.ErrBookingIDIsRequired
+	}
+	if therapistID == "" {
+		return ports.ErrBookingTherapistIDIsRequired
+	}
+	if timeSlotID == "" {
+		return ports.ErrBookingTimeSlotIDIsRequired
+	}
+
+	query := `
+		UPDATE bookings
+			SET therapist_id = ?, timeslot_id = ?, updated_at = ?
+		WHERE id = ?
+	`
+	result, err := sqlExec.Exec(query, therapistID, timeSlotID, updatedAt, bookingID)
+	if err != nil {
+		slog.Error("error reassigning booking", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.Error("error getting rows affected after reassign", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
+	if rowsAffected == 0 {
+		return ports.ErrBookingNotFound
+	}
+
+	query = `
+		UPDATE sessions
+			SET therapist_id = ?, updated_at = ?
+		WHERE regular_booking_id = ?
+	`
+	_, err = sqlExec.Exec(query, therapistID, updatedAt, bookingID)
+	if err != nil {
+		slog.Error("error reassigning session of booking", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
+	return nil
+}
+
+func (r *BookingRepository) UpdateDurationTx(
+	sqlExec ports.SQLExec,
+	bookingID domain.BookingID,
+	duration domain.DurationMinutes,
+	updatedAt time.Time,
+) error {
+	if bookingID == "" {
+		return ports.ErrBookingIDIsRequired
+	}
+	if duration <= 0 {
+		return ports.ErrBookingDurationIsRequired
+	}
+
+	query := `
+		UPDATE bookings
+			SET duration_minutes = ?, updated_at = ?
+		WHERE id = ?
+	`
+	result, err := sqlExec.Exec(query, duration, updatedAt, bookingID)
+	if err != nil {
+		slog.Error("error updating booking duration", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.Error("error getting rows affected after duration update", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
+	if rowsAffected == 0 {
+		return ports.ErrBookingNotFound
+	}
+
+	query = `
+		UPDATE sessions
+			SET duration_minutes = ?, updated_at = ?
+		WHERE regular_booking_id = ?
+	`
+	_, err = sqlExec.Exec(query, duration, updatedAt, bookingID)
+	if err != nil {
+		slog.Error("error updating session duration of booking", "error", err)
+		return ports.ErrFailedToUpdateBooking
+	}
+
+	return nil
+}
+
+func (r *BookingRepository) CountByState(clinicID domain.ClinicID, startDate, endDate time.Time) (map[booking.BookingState]int, error) {
+	query := `
+		SELECT state, COUNT(*)
+		FROM bookings
+		WHERE 1=1
+	`
+	clinicFilter, params := db.ClinicFilter(clinicID)
+	filter, rangeParams := startTimeRangeFilter(startDate, endDate)
+	query += clinicFilter + filter + " GROUP BY state"
+	params = append(params, rangeParams...)
+
+	rows, err := r.db.Reader().Query(query, params...)
+	if err != nil {
+		slog.Error("error counting bookings by state", "error", err)
+		return nil, ports.ErrFailedToGetBookings
+	}
+	defer rows.Close()
+
+	counts := make(map[booking.BookingState]int)
+	for rows.Next() {
+		var state booking.BookingState
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			slog.Error("error scanning booking state count", "error", err)
+			return nil, ports.ErrFailedToGetBookings
+		}
+		counts[state] = count
+	}
+	return counts, nil
+}
+
+func (r *BookingRepository) CountByTherapist(clinicID domain.ClinicID, startDate, endDate time.Time) (map[domain.TherapistID]int, error) {
+	query := `
+		SELECT therapist_id, COUNT(*)
+		FROM bookings
+		WHERE 1=1
+	`
+	clinicFilter, params := db.ClinicFilter(clinicID)
+	filter, rangeParams := startTimeRangeFilter(startDate, endDate)
+	query += clinicFilter + filter + " GROUP BY therapist_id"
+	params = append(params, rangeParams...)
+
+	rows, err := r.db.Reader().Query(query, params...)
+	if err != nil {
+		slog.Error("error counting bookings by therapist", "error", err)
+		return nil, ports.ErrFailedToGetBookings
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.TherapistID]int)
+	for rows.Next() {
+		var therapistID domain.TherapistID
+		var count int
+		if err := rows.Scan(&therapistID, &count); err != nil {
+			slog.Error("error scanning booking therapist count", "error", err)
+			return nil, ports.ErrFailedToGetBookings
+		}
+		counts[therapistID] = count
+	}
+	return counts, nil
+}
+
+func (r *BookingRepository) LeadTimeStats(therapistID domain.TherapistID, startDate, endDate time.Time) (*ports.LeadTimeStats, error) {
+	filter, params := startTimeRangeFilter(startDate, endDate)
+	// The median averages the middle one or two lead times
+	query := `
+		WITH lead_times AS (
+			SELECT (julianday(start_time) - julianday(created_at)) * 24 * 60 AS minutes
+			FROM bookings
+			WHERE therapist_id = ? AND state = ?` + filter + `
+		)
+		SELECT
+			COUNT(*),
+			COALESCE(AVG(minutes), 0),
+			COALESCE((
+				SELECT AVG(minutes) FROM (
+					SELECT minutes FROM lead_times
+					ORDER BY minutes
+					LIMIT 2 - (SELECT COUNT(*) FROM lead_times) % 2
+					OFFSET ((SELECT COUNT(*) FROM lead_times) - 1) / 2
+				)
+			), 0)
+		FROM lead_times
+	`
+	params = append([]interface{}{therapistID, booking.BookingStateConfirmed}, params...)
+
+	stats := &ports.LeadTimeStats{}
+	err := r.db.Reader().QueryRow(query, params...).Scan(&stats.Count, &stats.AverageMinutes, &stats.MedianMinutes)
+	if err != nil {
+		slog.Error("error aggregating booking lead times", "error", err)
+		return nil, ports.ErrFailedToGetBookings
+	}
+	return stats, nil
+}
+
+// startTimeRangeFilter builds an optional start_time filter. Zero times are ignored.
+func startTimeRangeFilter(startDate, endDate time.Time) (string, []interface{}) {
+	filter := ""
+	params := []interface{}{}
+	if !startDate.IsZero() {
+		filter += " AND start_time >= ?"
+		params = append(params, startDate)
+	}
+	if !endDate.IsZero() {
+		filter += " AND start_time <= ?"
+		params = append(params, endDate)
+	}
+	return filter, params
+}
@@ -0,0 +1,610 @@
+package booking_db
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+func insertTimeSlot(t *testing.T, database ports.SQLDatabase, therapistID domain.TherapistID) domain.TimeSlotID {
+	now := time.Now().UTC()
+	timeSlotID := domain.NewTimeSlotID()
+	_, err := database.Exec(`
+		INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, timeSlotID, therapistID, "Monday", "10:00", 60, 0, 0, true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert test time slot: %v", err)
+	}
+	return timeSlotID
+}
+
+func TestBookingRepositoryCounts(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingRepository(database)
+
+	therapistA := dbtest.InsertTherapist(t, database, "a@example.com")
+	therapistB := dbtest.InsertTherapist(t, database, "b@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	timeSlotA := insertTimeSlot(t, database, therapistA)
+	timeSlotB := insertTimeSlot(t, database, therapistB)
+
+	june := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	july := time.Date(2025, 7, 7, 10, 0, 0, 0, time.UTC)
+
+	seed := []struct {
+		therapistID domain.TherapistID
+		timeSlotID  domain.TimeSlotID
+		state       booking.BookingState
+		startTime   time.Time
+	}{
+		{therapistA, timeSlotA, booking.BookingStatePending, june},
+		{therapistA, timeSlotA, booking.BookingStateConfirmed, june.AddDate(0, 0, 7)},
+		{therapistA, timeSlotA, booking.BookingStateConfirmed, june.AddDate(0, 0, 14)},
+		{therapistB, timeSlotB, booking.BookingStateCancelled, june},
+		{therapistB, timeSlotB, booking.BookingStateConfirmed, july},
+	}
+
+	now := domain.NewUTCTimestamp()
+	for _, s := range seed {
+		err := repo.Create(&booking.Booking{
+			ID:          domain.NewBookingID(),
+			TimeSlotID:  s.timeSlotID,
+			TherapistID: s.therapistID,
+			ClientID:    clientID,
+			State:       s.state,
+			StartTime:   domain.UTCTimestamp(s.startTime),
+			Duration:    60,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+	}
+
+	t.Run("CountByState without range counts all bookings", func(t *testing.T) {
+		counts, err := repo.CountByState("", time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatalf("CountByState failed: %v", err)
+		}
+
+		expected := map[booking.BookingState]int{
+			booking.BookingStatePending:   1,
+			booking.BookingStateConfirmed: 3,
+			booking.BookingStateCancelled: 1,
+		}
+		for state, count := range expected {
+			if counts[state] != count {
+				t.Errorf("Expected %d %s bookings, got %d", count, state, counts[state])
+			}
+		}
+	})
+
+	t.Run("CountByState respects date range", func(t *testing.T) {
+		from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC)
+
+		counts, err := repo.CountByState("", from, to)
+		if err != nil {
+			t.Fatalf("CountByState failed: %v", err)
+		}
+
+		if counts[booking.BookingStateConfirmed] != 2 {
+			t.Errorf("Expected 2 confirmed bookings in June, got %d", counts[booking.BookingStateConfirmed])
+		}
+		if counts[booking.BookingStatePending] != 1 {
+			t.Errorf("Expected 1 pending booking in June, got %d", counts[booking.BookingStatePending])
+		}
+		if counts[booking.BookingStateCancelled] != 1 {
+			t.Errorf("Expected 1 cancelled booking in June, got %d", counts[booking.BookingStateCancelled])
+		}
+	})
+
+	t.Run("CountByTherapist groups per therapist", func(t *testing.T) {
+		counts, err := repo.CountByTherapist("", time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatalf("CountByTherapist failed: %v", err)
+		}
+
+		if counts[therapistA] != 3 {
+			t.Errorf("Expected 3 bookings for therapist A, got %d", counts[therapistA])
+		}
+		if counts[therapistB] != 2 {
+			t.Errorf("Expected 2 bookings for therapist B, got %d", counts[therapistB])
+		}
+	})
+
+	t.Run("Counts are scoped to the therapists' clinic", func(t *testing.T) {
+		if _, err := database.Exec(`UPDATE therapists SET clinic_id = 'clinic_b' WHERE id = ?`, therapistB); err != nil {
+			t.Fatalf("Failed to move therapist B: %v", err)
+		}
+
+		byState, err := repo.CountByState("clinic_b", time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatalf("CountByState failed: %v", err)
+		}
+		if byState[booking.BookingStateCancelled] != 1 || byState[booking.BookingStateConfirmed] != 1 || byState[booking.BookingStatePending] != 0 {
+			t.Errorf("Expected only therapist B's bookings, got %v", byState)
+		}
+
+		byTherapist, err := repo.CountByTherapist(domain.DefaultClinicID, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatalf("CountByTherapist failed: %v", err)
+		}
+		if len(byTherapist) != 1 || byTherapist[therapistA] != 3 {
+			t.Errorf("Expected only therapist A's bookings, got %v", byTherapist)
+		}
+	})
+}
+
+func TestBookingRepositoryStateHistory(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingRepository(database)
+
+	therapistID := dbtest.InsertTherapist(t, database, "history@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	timeSlotID := insertTimeSlot(t, database, therapistID)
+
+	createdAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	bookingID := domain.NewBookingID()
+	err := repo.Create(&booking.Booking{
+		ID:          bookingID,
+		TimeSlotID:  timeSlotID,
+		TherapistID: therapistID,
+		ClientID:    clientID,
+		State:       booking.BookingStatePending,
+		StartTime:   domain.UTCTimestamp(createdAt.AddDate(0, 0, 7)),
+		Duration:    60,
+		CreatedAt:   domain.UTCTimestamp(createdAt),
+		UpdatedAt:   domain.UTCTimestamp(createdAt),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create booking: %v", err)
+	}
+
+	err = repo.UpdateState(bookingID, booking.BookingStateConfirmed, createdAt.Add(time.Hour), "admin@example.com")
+	if err != nil {
+		t.Fatalf("Failed to confirm booking: %v", err)
+	}
+	err = repo.UpdateState(bookingID, booking.BookingStateCancelled, createdAt.Add(2*time.Hour), "support@example.com")
+	if err != nil {
+		t.Fatalf("Failed to cancel booking: %v", err)
+	}
+
+	changes, err := repo.ListStateChanges(bookingID)
+	if err != nil {
+		t.Fatalf("ListStateChanges failed: %v", err)
+	}
+
+	expected := []booking.StateChange{
+		{FromState: "", ToState: booking.BookingStatePending, Actor: ""},
+		{FromState: booking.BookingStatePending, ToState: booking.BookingStateConfirmed, Actor: "admin@example.com"},
+		{FromState: booking.BookingStateConfirmed, ToState: booking.BookingStateCancelled, Actor: "support@example.com"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d audit rows, got %d", len(expected), len(changes))
+	}
+	for i, want := range expected {
+		got := changes[i]
+		if got.FromState != want.FromState || got.ToState != want.ToState || got.Actor != want.Actor {
+			t.Errorf("Audit row %d: expected %s -> %s by %q, got %s -> %s by %q",
+				i, want.FromState, want.ToState, want.Actor, got.FromState, got.ToState, got.Actor)
+		}
+	}
+}
+
+func TestBookingRepositoryDeleteKeepsStateHistory(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingRepository(database)
+
+	therapistID := dbtest.InsertTherapist(t, database, "deleted@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	timeSlotID := insertTimeSlot(t, database, therapistID)
+
+	now := domain.NewUTCTimestamp()
+	bookingID := domain.NewBookingID()
+	err := repo.Create(&booking.Booking{
+		ID:          bookingID,
+		TimeSlotID:  timeSlotID,
+		TherapistID: therapistID,
+		ClientID:    clientID,
+		State:       booking.BookingStatePending,
+		StartTime:   now.Add(24 * time.Hour),
+		Duration:    60,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create booking: %v", err)
+	}
+
+	if err := repo.Delete(bookingID); err != nil {
+		t.Fatalf("Failed to delete booking: %v", err)
+	}
+
+	changes, err := repo.ListStateChanges(bookingID)
+	if err != nil {
+		t.Fatalf("ListStateChanges failed: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Errorf("Expected the audit row to outlive the booking, got %d rows", len(changes))
+	}
+}
+
+func TestBookingRepositoryReassignTx(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingRepository(database)
+
+	fromTherapist := dbtest.InsertTherapist(t, database, "from@example.com")
+	toTherapist := dbtest.InsertTherapist(t, database, "to@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	fromTimeSlot := insertTimeSlot(t, database, fromTherapist)
+	toTimeSlot := insertTimeSlot(t, database, toTherapist)
+
+	now := domain.NewUTCTimestamp()
+	bookingID := domain.NewBookingID()
+	err := repo.Create(&booking.Booking{
+		ID:          bookingID,
+		TimeSlotID:  fromTimeSlot,
+		TherapistID: fromTherapist,
+		ClientID:    clientID,
+		State:       booking.BookingStateConfirmed,
+		StartTime:   now.Add(24 * time.Hour),
+		Duration:    60,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create booking: %v", err)
+	}
+
+	sessionID := domain.NewSessionID()
+	_, err = database.Exec(`
+		INSERT INTO sessions (id, regular_booking_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, paid_amount, language, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, sessionID, bookingID, fromTherapist, clientID, now.Add(24*time.Hour).Time(), 60, 0, 100, "english", "planned", now.Time(), now.Time())
+	if err != nil {
+		t.Fatalf("Failed to insert session: %v", err)
+	}
+
+	t.Run("moves the booking and its session", func(t *testing.T) {
+		err := repo.ReassignTx(database, bookingID, toTherapist, toTimeSlot, time.Now().UTC())
+		if err != nil {
+			t.Fatalf("ReassignTx failed: %v", err)
+		}
+
+		stored, err := repo.GetByID(bookingID)
+		if err != nil {
+			t.Fatalf("Failed to get booking: %v", err)
+		}
+		if stored.TherapistID != toTherapist || stored.TimeSlotID != toTimeSlot {
+			t.Errorf("Expected booking on %s/%s, got %s/%s", toTherapist, toTimeSlot, stored.TherapistID, stored.TimeSlotID)
+		}
+
+		var sessionTherapist domain.TherapistID
+		err = database.QueryRow(`SELECT therapist_id FROM sessions WHERE id = ?`, sessionID).Scan(&sessionTherapist)
+		if err != nil {
+			t.Fatalf("Failed to get session: %v", err)
+		}
+		if sessionTherapist != toTherapist {
+			t.Errorf("Expected session to follow the booking to %s, got %s", toTherapist, sessionTherapist)
+		}
+	})
+
+	t.Run("unknown booking", func(t *testing.T) {
+		err := repo.ReassignTx(database, domain.NewBookingID(), toTherapist, toTimeSlot, time.Now().UTC())
+		if err != ports.ErrBookingNotFound {
+			t.Errorf("Expected ErrBookingNotFound, got %v", err)
+		}
+	})
+}
+
+func TestBookingRepositoryListByTimeSlot(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingRepository(database)
+
+	therapistID := dbtest.InsertTherapist(t, database, "slot@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	timeSlotID := insertTimeSlot(t, database, therapistID)
+	otherTimeSlotID := insertTimeSlot(t, database, therapistID)
+
+	monday := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	now := domain.NewUTCTimestamp()
+	newBooking := func(timeSlotID domain.TimeSlotID, state booking.BookingState, startTime time.Time) domain.BookingID {
+		id := domain.NewBookingID()
+		err := repo.Create(&booking.Booking{
+			ID:          id,
+			TimeSlotID:  timeSlotID,
+			TherapistID: therapistID,
+			ClientID:    clientID,
+			State:       state,
+			StartTime:   domain.UTCTimestamp(startTime),
+			Duration:    60,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+		return id
+	}
+
+	// Created out of order to check the result is sorted by start time
+	laterConfirmed := newBooking(timeSlotID, booking.BookingStateConfirmed, monday.AddDate(0, 0, 7))
+	earlierPending := newBooking(timeSlotID, booking.BookingStatePending, monday)
+	newBooking(otherTimeSlotID, booking.BookingStateConfirmed, monday)
+
+	t.Run("returns every booking on the slot ordered by start time", func(t *testing.T) {
+		bookings, err := repo.ListByTimeSlot(timeSlotID, nil)
+		if err != nil {
+			t.Fatalf("ListByTimeSlot failed: %v", err)
+		}
+		if len(bookings) != 2 {
+			t.Fatalf("Expected 2 bookings, got %d", len(bookings))
+		}
+		if bookings[0].ID != earlierPending || bookings[1].ID != laterConfirmed {
+			t.Errorf("Expected [%s %s], got [%s %s]", earlierPending, laterConfirmed, bookings[0].ID, bookings[1].ID)
+		}
+	})
+
+	t.Run("filters by state", func(t *testing.T) {
+		bookings, err := repo.ListByTimeSlot(timeSlotID, []booking.BookingState{booking.BookingStateConfirmed})
+		if err != nil {
+			t.Fatalf("ListByTimeSlot failed: %v", err)
+		}
+		if len(bookings) != 1 || bookings[0].ID != laterConfirmed {
+			t.Errorf("Expected only confirmed booking %s, got %v", laterConfirmed, bookings)
+		}
+	})
+}
+
+func TestBookingRepositoryCountActiveByTimeSlot(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingRepository(database)
+
+	therapistID := dbtest.InsertTherapist(t, database, "active@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	timeSlotID := insertTimeSlot(t, database, therapistID)
+	otherTimeSlotID := insertTimeSlot(t, database, therapistID)
+
+	nextWeek := time.Now().UTC().AddDate(0, 0, 7).Truncate(time.Hour)
+	lastWeek := time.Now().UTC().AddDate(0, 0, -7).Truncate(time.Hour)
+	now := domain.NewUTCTimestamp()
+	seed := []struct {
+		timeSlotID domain.TimeSlotID
+		state      booking.BookingState
+		startTime  time.Time
+	}{
+		{timeSlotID, booking.BookingStatePending, nextWeek},
+		{timeSlotID, booking.BookingStateConfirmed, nextWeek.AddDate(0, 0, 7)},
+		{timeSlotID, booking.BookingStateCancelled, nextWeek.AddDate(0, 0, 14)},
+		{timeSlotID, booking.BookingStateConfirmed, lastWeek},
+		{otherTimeSlotID, booking.BookingStateConfirmed, nextWeek},
+	}
+	for _, s := range seed {
+		err := repo.Create(&booking.Booking{
+			ID:          domain.NewBookingID(),
+			TimeSlotID:  s.timeSlotID,
+			TherapistID: therapistID,
+			ClientID:    clientID,
+			State:       s.state,
+			StartTime:   domain.UTCTimestamp(s.startTime),
+			Duration:    60,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+	}
+
+	count, err := repo.CountActiveByTimeSlot(timeSlotID)
+	if err != nil {
+		t.Fatalf("CountActiveByTimeSlot failed: %v", err)
+	}
+	// Cancelled, past and other-slot bookings are not counted
+	if count != 2 {
+		t.Errorf("Expected 2 active bookings, got %d", count)
+	}
+
+	count, err = repo.CountActiveByTimeSlot(insertTimeSlot(t, database, therapistID))
+	if err != nil {
+		t.Fatalf("CountActiveByTimeSlot failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 active bookings on an empty slot, got %d", count)
+	}
+}
+
+func TestBookingRepositoryLeadTimeStats(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingRepository(database)
+
+	therapistA := dbtest.InsertTherapist(t, database, "a@example.com")
+	therapistB := dbtest.InsertTherapist(t, database, "b@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	timeSlotA := insertTimeSlot(t, database, therapistA)
+	timeSlotB := insertTimeSlot(t, database, therapistB)
+
+	june := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	seed := []struct {
+		therapistID domain.TherapistID
+		timeSlotID  domain.TimeSlotID
+		state       booking.BookingState
+		startTime   time.Time
+		leadTime    time.Duration
+	}{
+		{therapistA, timeSlotA, booking.BookingStateConfirmed, june, 1 * day},
+		{therapistA, timeSlotA, booking.BookingStateConfirmed, june.AddDate(0, 0, 7), 2 * day},
+		{therapistA, timeSlotA, booking.BookingStateConfirmed, june.AddDate(0, 0, 14), 6 * day},
+		// Not confirmed, or another therapist's, so excluded
+		{therapistA, timeSlotA, booking.BookingStatePending, june.AddDate(0, 0, 21), 30 * day},
+		{therapistB, timeSlotB, booking.BookingStateConfirmed, june, 10 * day},
+	}
+
+	for _, s := range seed {
+		createdAt := domain.UTCTimestamp(s.startTime.Add(-s.leadTime))
+		err := repo.Create(&booking.Booking{
+			ID:          domain.NewBookingID(),
+			TimeSlotID:  s.timeSlotID,
+			TherapistID: s.therapistID,
+			ClientID:    clientID,
+			State:       s.state,
+			StartTime:   domain.UTCTimestamp(s.startTime),
+			Duration:    60,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+	}
+
+	t.Run("aggregates confirmed bookings of the therapist", func(t *testing.T) {
+		stats, err := repo.LeadTimeStats(therapistA, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatalf("LeadTimeStats failed: %v", err)
+		}
+
+		if stats.Count != 3 {
+			t.Errorf("Expected 3 bookings, got %d", stats.Count)
+		}
+		// (1 + 2 + 6) / 3 days
+		if math.Abs(stats.AverageMinutes-3*24*60) > 1 {
+			t.Errorf("Expected an average of %d minutes, got %f", 3*24*60, stats.AverageMinutes)
+		}
+		if math.Abs(stats.MedianMinutes-2*24*60) > 1 {
+			t.Errorf("Expected a median of %d minutes, got %f", 2*24*60, stats.MedianMinutes)
+		}
+	})
+
+	t.Run("respects date range", func(t *testing.T) {
+		stats, err := repo.LeadTimeStats(therapistA, june, june.AddDate(0, 0, 7))
+		if err != nil {
+			t.Fatalf("LeadTimeStats failed: %v", err)
+		}
+
+		if stats.Count != 2 {
+			t.Errorf("Expected 2 bookings, got %d", stats.Count)
+		}
+		// The median of two lead times is their average
+		if math.Abs(stats.MedianMinutes-1.5*24*60) > 1 {
+			t.Errorf("Expected a median of %f minutes, got %f", 1.5*24*60, stats.MedianMinutes)
+		}
+	})
+
+	t.Run("no bookings", func(t *testing.T) {
+		stats, err := repo.LeadTimeStats(therapistA, june.AddDate(1, 0, 0), time.Time{})
+		if err != nil {
+			t.Fatalf("LeadTimeStats failed: %v", err)
+		}
+		if stats.Count != 0 || stats.AverageMinutes != 0 || stats.MedianMinutes != 0 {
+			t.Errorf("Expected empty stats, got %+v", stats)
+		}
+	})
+}
+
+func TestBookingRepositorySearchPages(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingRepository(database)
+
+	clientID := dbtest.InsertClient(t, database)
+
+	// Several bookings share a start time so pages must break ties by ID.
+	// A therapist can't have two bookings at once, so each gets its own.
+	june := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	startTimes := []time.Time{june, june, june, june.AddDate(0, 0, 7), june.AddDate(0, 0, 7), june.AddDate(0, 0, 14), june.AddDate(0, 0, 21)}
+	now := domain.NewUTCTimestamp()
+	for i, startTime := range startTimes {
+		therapistID := dbtest.InsertTherapist(t, database, fmt.Sprintf("therapist%d@example.com", i))
+		timeSlotID := insertTimeSlot(t, database, therapistID)
+		err := repo.Create(&booking.Booking{
+			ID:          domain.NewBookingID(),
+			TimeSlotID:  timeSlotID,
+			TherapistID: therapistID,
+			ClientID:    clientID,
+			State:       booking.BookingStateConfirmed,
+			StartTime:   domain.UTCTimestamp(startTime),
+			Duration:    60,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create booking: %v", err)
+		}
+	}
+
+	all, err := repo.Search("", time.Time{}, time.Time{}, nil, ports.Page{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(all) != len(startTimes) {
+		t.Fatalf("Expected %d bookings, got %d", len(startTimes), len(all))
+	}
+
+	// Walk the pages and check they add up to the unpaged results in order
+	paged := []*booking.Booking{}
+	page := ports.Page{Limit: 2}
+	for range len(startTimes) {
+		results, err := repo.Search("", time.Time{}, time.Time{}, nil, page)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) > page.Limit {
+			t.Fatalf("Expected at most %d bookings per page, got %d", page.Limit, len(results))
+		}
+		if len(results) == 0 {
+			break
+		}
+		paged = append(paged, results...)
+
+		last := results[len(results)-1]
+		page.After = &ports.PageCursor{StartTime: last.StartTime.Time(), ID: string(last.ID)}
+	}
+
+	if len(paged) != len(all) {
+		t.Fatalf("Expected %d bookings across pages, got %d", len(all), len(paged))
+	}
+	for i := range all {
+		if paged[i].ID != all[i].ID {
+			t.Errorf("Expected booking %s at position %d, got %s", all[i].ID, i, paged[i].ID)
+		}
+	}
+
+	// Moving a therapist to another clinic takes their booking along
+	if _, err := database.Exec(`UPDATE therapists SET clinic_id = 'clinic_b' WHERE id = ?`, all[0].TherapistID); err != nil {
+		t.Fatalf("Failed to move therapist: %v", err)
+	}
+	scoped, err := repo.Search("clinic_b", time.Time{}, time.Time{}, nil, ports.Page{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(scoped) != 1 || scoped[0].ID != all[0].ID {
+		t.Errorf("Expected only booking %s for clinic_b, got %d bookings", all[0].ID, len(scoped))
+	}
+}
@@ -0,0 +1,119 @@
+package booking_hold_db
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type BookingHoldRepository struct {
+	db ports.SQLDatabase
+}
+
+var ErrBookingHoldTokenIsRequired = errors.New("booking hold token is required")
+var ErrBookingHoldBookingIDIsRequired = errors.New("booking hold booking id is required")
+var ErrBookingHoldExpiresAtIsRequired = errors.New("booking hold expiry is required")
+var ErrFailedToGetBookingHolds = errors.New("failed to get booking holds")
+var ErrFailedToCreateBookingHold = errors.New("failed to create booking hold")
+var ErrFailedToDeleteBookingHold = errors.New("failed to delete booking hold")
+
+func NewBookingHoldRepository(db ports.SQLDatabase) ports.BookingHoldRepository {
+	return &BookingHoldRepository{db: db}
+}
+
+func (r *BookingHoldRepository) CreateTx(sqlExec ports.SQLExec, hold *booking.Hold) error {
+	if hold.Token == "" {
+		return ErrBookingHoldTokenIsRequired
+	}
+	if hold.BookingID == "" {
+		return ErrBookingHoldBookingIDIsRequired
+	}
+	if hold.ExpiresAt == (domain.UTCTimestamp{}) {
+		return ErrBookingHoldExpiresAtIsRequired
+	}
+
+	query := `
+		INSERT INTO booking_holds (token, booking_id, expires_at, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	_, err := sqlExec.Exec(query, hold.Token, hold.BookingID, hold.ExpiresAt, hold.CreatedAt)
+	if err != nil {
+		slog.Error("error creating booking hold", "error", err)
+		return ErrFailedToCreateBookingHold
+	}
+	return nil
+}
+
+// GetByToken returns nil when the hold does not exist
+func (r *BookingHoldRepository) GetByToken(token domain.HoldToken) (*booking.Hold, error) {
+	query := `
+		SELECT token, booking_id, expires_at, created_at
+		FROM booking_holds
+		WHERE token = ?
+	`
+	hold := &booking.Hold{}
+	err := r.db.QueryRow(query, token).Scan(
+		&hold.Token,
+		&hold.BookingID,
+		&hold.ExpiresAt,
+		&hold.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("error getting booking hold by token", "error", err)
+		return nil, ErrFailedToGetBookingHolds
+	}
+	return hold, nil
+}
+
+func (r *BookingHoldRepository) DeleteTx(sqlExec ports.SQLExec, token domain.HoldToken) error {
+	if token == "" {
+		return ErrBookingHoldTokenIsRequired
+	}
+
+	_, err := sqlExec.Exec(`DELETE FROM booking_holds WHERE token = ?`, token)
+	if err != nil {
+		slog.Error("error deleting booking hold", "error", err)
+		return ErrFailedToDeleteBookingHold
+	}
+	return nil
+}
+
+func (r *BookingHoldRepository) ListExpired(now time.Time) ([]*booking.Hold, error) {
+	query := `
+		SELECT token, booking_id, expires_at, created_at
+		FROM booking_holds
+		WHERE expires_at <= ?
+		ORDER BY expires_at ASC
+	`
+	rows, err := r.db.Reader().Query(query, domain.UTCTimestamp(now))
+	if err != nil {
+		slog.Error("error listing expired booking holds", "error", err)
+		return nil, ErrFailedToGetBookingHolds
+	}
+	defer rows.Close()
+
+	holds := []*booking.Hold{}
+	for rows.Next() {
+		hold := &booking.Hold{}
+		err := rows.Scan(
+			&hold.Token,
+			&hold.BookingID,
+			&hold.ExpiresAt,
+			&hold.CreatedAt,
+		)
+		if err != nil {
+			slog.Error("error scanning booking hold", "error", err)
+			return nil, ErrFailedToGetBookingHolds
+		}
+		holds = append(holds, hold)
+	}
+	return holds, nil
+}
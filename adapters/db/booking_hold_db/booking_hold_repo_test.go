@@ -0,0 +1,105 @@
+package booking_hold_db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+)
+
+func TestBookingHoldRepository(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewBookingHoldRepository(database)
+	bookingRepo := booking_db.NewBookingRepository(database)
+
+	therapistID := dbtest.InsertTherapist(t, database, "hold@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	now := time.Now().UTC()
+	timeSlotID := domain.NewTimeSlotID()
+	_, err := database.Exec(`
+		INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, timeSlotID, therapistID, "Monday", "10:00", 180, 0, 0, true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert test time slot: %v", err)
+	}
+
+	// Holds expiring in 5 and 15 minutes
+	createdAt := domain.NewUTCTimestamp()
+	holds := []*booking.Hold{}
+	for i, expiresIn := range []time.Duration{5 * time.Minute, 15 * time.Minute} {
+		heldBooking := &booking.Booking{
+			ID:          domain.NewBookingID(),
+			TimeSlotID:  timeSlotID,
+			TherapistID: therapistID,
+			ClientID:    clientID,
+			State:       booking.BookingStateHeld,
+			StartTime:   domain.UTCTimestamp(time.Date(2030, 6, 3, 10+i, 0, 0, 0, time.UTC)),
+			Duration:    60,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		}
+		if err := bookingRepo.Create(heldBooking); err != nil {
+			t.Fatalf("Failed to create held booking: %v", err)
+		}
+
+		hold := &booking.Hold{
+			Token:     domain.NewHoldToken(),
+			BookingID: heldBooking.ID,
+			ExpiresAt: createdAt.Add(expiresIn),
+			CreatedAt: createdAt,
+		}
+		if err := repo.CreateTx(database, hold); err != nil {
+			t.Fatalf("Failed to create hold: %v", err)
+		}
+		holds = append(holds, hold)
+	}
+
+	t.Run("GetByToken returns the stored hold", func(t *testing.T) {
+		stored, err := repo.GetByToken(holds[0].Token)
+		if err != nil {
+			t.Fatalf("GetByToken failed: %v", err)
+		}
+		if stored == nil || stored.BookingID != holds[0].BookingID || !stored.ExpiresAt.Equal(holds[0].ExpiresAt) {
+			t.Errorf("Expected hold %+v, got %+v", holds[0], stored)
+		}
+	})
+
+	t.Run("GetByToken returns nil for an unknown token", func(t *testing.T) {
+		stored, err := repo.GetByToken(domain.NewHoldToken())
+		if err != nil {
+			t.Fatalf("GetByToken failed: %v", err)
+		}
+		if stored != nil {
+			t.Errorf("Expected no hold, got %+v", stored)
+		}
+	})
+
+	t.Run("ListExpired only returns holds past their expiry", func(t *testing.T) {
+		expired, err := repo.ListExpired(createdAt.Add(10 * time.Minute).Time())
+		if err != nil {
+			t.Fatalf("ListExpired failed: %v", err)
+		}
+		if len(expired) != 1 || expired[0].Token != holds[0].Token {
+			t.Errorf("Expected only hold %s to be expired, got %+v", holds[0].Token, expired)
+		}
+	})
+
+	t.Run("DeleteTx removes the hold", func(t *testing.T) {
+		if err := repo.DeleteTx(database, holds[0].Token); err != nil {
+			t.Fatalf("DeleteTx failed: %v", err)
+		}
+		stored, err := repo.GetByToken(holds[0].Token)
+		if err != nil {
+			t.Fatalf("GetByToken failed: %v", err)
+		}
+		if stored != nil {
+			t.Errorf("Expected the hold to be deleted, got %+v", stored)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/booking"
@@ -20,6 +21,7 @@ type ClientRepository struct {
 var (
 	ErrReadingClientBookings = errors.New("error reading client bookings")
 	ErrReadingClient         = errors.New("error reading client")
+	ErrReadingClientSummary  = errors.New("error reading client summary")
 )
 
 func NewClientRepository(database ports.SQLDatabase) ports.ClientRepository {
@@ -30,15 +32,17 @@ func NewClientRepository(database ports.SQLDatabase) ports.ClientRepository {
 
 func (r *ClientRepository) Create(client *client.Client) error {
 	query := `
-		INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO clients (id, name, whatsapp_number, email, timezone_offset, clinic_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err := r.db.Exec(
 		query,
 		client.ID,
 		client.Name,
-		client.WhatsAppNumber,
+		nullIfEmpty(string(client.WhatsAppNumber)),
+		nullIfEmpty(string(client.Email.Normalize())),
 		client.TimezoneOffset,
+		client.ClinicID.OrDefault(),
 		client.CreatedAt,
 		client.UpdatedAt,
 	)
@@ -59,9 +63,9 @@ func (r *ClientRepository) FindByIDs(ids []domain.ClientID) ([]*client.Client, e
 	placeholdersStr := strings.Join(placeholders, ",")
 
 	query := `
-		SELECT id, name, whatsapp_number, timezone_offset, created_at, updated_at
+		SELECT id, name, COALESCE(whatsapp_number, ''), COALESCE(email, ''), timezone_offset, clinic_id, created_at, updated_at
 		FROM clients
-		WHERE id IN (%s)
+		WHERE id IN (%s) AND deleted_at IS NULL
 	`
 	query = fmt.Sprintf(query, placeholdersStr)
 
@@ -79,7 +83,9 @@ func (r *ClientRepository) FindByIDs(ids []domain.ClientID) ([]*client.Client, e
 			&client.ID,
 			&client.Name,
 			&client.WhatsAppNumber,
+			&client.Email,
 			&client.TimezoneOffset,
+			&client.ClinicID,
 			&client.CreatedAt,
 			&client.UpdatedAt,
 		)
@@ -93,20 +99,42 @@ func (r *ClientRepository) FindByIDs(ids []domain.ClientID) ([]*client.Client, e
 	return clients, nil
 }
 
+// GetByWhatsAppNumber matches the number with or without its leading "+".
+// Numbers are stored as "+<digits>", but older rows may lack the "+".
 func (r *ClientRepository) GetByWhatsAppNumber(whatsAppNumber domain.WhatsAppNumber) (*client.Client, error) {
 	query := `
-		SELECT id, name, whatsapp_number, timezone_offset, created_at, updated_at
+		SELECT id, name, COALESCE(whatsapp_number, ''), COALESCE(email, ''), timezone_offset, clinic_id, created_at, updated_at
 		FROM clients
-		WHERE whatsapp_number = ?
+		WHERE whatsapp_number IN (?, ?) AND deleted_at IS NULL
+		LIMIT 1
 	`
-	row := r.db.QueryRow(query, whatsAppNumber)
+	row := r.db.QueryRow(query, whatsAppNumber.Normalize(), whatsAppNumber.Digits())
+	return r.scanClientRow(row)
+}
+
+// GetByEmail matches the email case-insensitively, returning nil when no
+// client has it.
+func (r *ClientRepository) GetByEmail(email domain.Email) (*client.Client, error) {
+	query := `
+		SELECT id, name, COALESCE(whatsapp_number, ''), COALESCE(email, ''), timezone_offset, clinic_id, created_at, updated_at
+		FROM clients
+		WHERE email = ? AND deleted_at IS NULL
+	`
+	row := r.db.QueryRow(query, email.Normalize())
+	return r.scanClientRow(row)
+}
 
+// scanClientRow scans a single client along with its bookings, returning nil
+// when the row does not exist.
+func (r *ClientRepository) scanClientRow(row *sql.Row) (*client.Client, error) {
 	var client client.Client
 	err := row.Scan(
 		&client.ID,
 		&client.Name,
 		&client.WhatsAppNumber,
+		&client.Email,
 		&client.TimezoneOffset,
+		&client.ClinicID,
 		&client.CreatedAt,
 		&client.UpdatedAt,
 	)
@@ -130,13 +158,15 @@ func (r *ClientRepository) GetByWhatsAppNumber(whatsAppNumber domain.WhatsAppNum
 	return &client, nil
 }
 
-func (r *ClientRepository) List() ([]*client.Client, error) {
+// List returns the clinic's clients. An empty clinic lists every clinic.
+func (r *ClientRepository) List(clinicID domain.ClinicID) ([]*client.Client, error) {
 	query := `
-		SELECT id, name, whatsapp_number, timezone_offset, created_at, updated_at
+		SELECT id, name, COALESCE(whatsapp_number, ''), COALESCE(email, ''), timezone_offset, clinic_id, created_at, updated_at
 		FROM clients
+		WHERE (? = '' OR clinic_id = ?) AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Reader().Query(query, clinicID, clinicID)
 	if err != nil {
 		return nil, err
 	}
@@ -149,7 +179,9 @@ func (r *ClientRepository) List() ([]*client.Client, error) {
 			&client.ID,
 			&client.Name,
 			&client.WhatsAppNumber,
+			&client.Email,
 			&client.TimezoneOffset,
+			&client.ClinicID,
 			&client.CreatedAt,
 			&client.UpdatedAt,
 		)
@@ -177,13 +209,14 @@ func (r *ClientRepository) List() ([]*client.Client, error) {
 func (r *ClientRepository) Update(client *client.Client) error {
 	query := `
 		UPDATE clients
-		SET name = ?, whatsapp_number = ?, timezone_offset = ?, updated_at = ?
+		SET name = ?, whatsapp_number = ?, email = ?, timezone_offset = ?, updated_at = ?
 		WHERE id = ?
 	`
 	_, err := r.db.Exec(
 		query,
 		client.Name,
-		client.WhatsAppNumber,
+		nullIfEmpty(string(client.WhatsAppNumber)),
+		nullIfEmpty(string(client.Email.Normalize())),
 		client.TimezoneOffset,
 		client.UpdatedAt,
 		client.ID,
@@ -203,6 +236,125 @@ func (r *ClientRepository) UpdateTimezoneOffset(id domain.ClientID, offsetMinute
 	return err
 }
 
+func (r *ClientRepository) GetSummary(id domain.ClientID, now time.Time) (*client.Summary, error) {
+	summary := &client.Summary{
+		ClientID:  id,
+		TotalPaid: make(map[domain.Currency]int),
+	}
+
+	upcomingQuery := `
+		SELECT COUNT(*) FROM (
+			SELECT id FROM bookings
+			WHERE client_id = ? AND state IN (?, ?) AND start_time > ?
+			UNION ALL
+			SELECT id FROM adhoc_bookings
+			WHERE client_id = ? AND state IN (?, ?) AND start_time > ?
+		)
+	`
+	upcomingParams := []interface{}{}
+	for range 2 {
+		upcomingParams = append(upcomingParams, id, booking.BookingStatePending, booking.BookingStateConfirmed, now)
+	}
+	err := r.db.Reader().QueryRow(upcomingQuery, upcomingParams...).Scan(&summary.UpcomingBookings)
+	if err != nil {
+		slog.Error("error counting upcoming client bookings", "error", err)
+		return nil, ErrReadingClientSummary
+	}
+
+	sessionsQuery := `
+		SELECT state, currency, COUNT(*), COALESCE(SUM(paid_amount), 0)
+		FROM sessions
+		WHERE client_id = ?
+		GROUP BY state, currency
+	`
+	rows, err := r.db.Reader().Query(sessionsQuery, id)
+	if err != nil {
+		slog.Error("error totalling client sessions", "error", err)
+		return nil, ErrReadingClientSummary
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var state domain.SessionState
+		var currency domain.Currency
+		var count, paid int
+		if err := rows.Scan(&state, &currency, &count, &paid); err != nil {
+			slog.Error("error scanning client session totals", "error", err)
+			return nil, ErrReadingClientSummary
+		}
+
+		switch state {
+		case domain.SessionStateDone:
+			summary.CompletedSessions += count
+		case domain.SessionStateCancelled:
+			summary.CancelledSessions += count
+		}
+		if state != domain.SessionStateRefunded {
+			summary.TotalPaid[currency] += paid
+		}
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("error reading client session totals", "error", err)
+		return nil, ErrReadingClientSummary
+	}
+
+	return summary, nil
+}
+
+func (r *ClientRepository) MoveBookingsTx(sqlExec ports.SQLExec, from, to domain.ClientID) (int, error) {
+	now := domain.NewUTCTimestamp()
+	moved := 0
+	for _, table := range []string{"bookings", "adhoc_bookings"} {
+		query := fmt.Sprintf(`UPDATE %s SET client_id = ?, updated_at = ? WHERE client_id = ?`, table)
+		result, err := sqlExec.Exec(query, to, now, from)
+		if err != nil {
+			// Only one confirmed booking per therapist, time and client
+			if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+				return 0, client.ErrClientBookingsOverlap
+			}
+			slog.Error("error moving client bookings", "error", err, "table", table, "from", from, "to", to)
+			return 0, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		moved += int(affected)
+	}
+	return moved, nil
+}
+
+func (r *ClientRepository) MoveSessionsTx(sqlExec ports.SQLExec, from, to domain.ClientID) (int, error) {
+	query := `UPDATE sessions SET client_id = ?, updated_at = ? WHERE client_id = ?`
+	result, err := sqlExec.Exec(query, to, domain.NewUTCTimestamp(), from)
+	if err != nil {
+		slog.Error("error moving client sessions", "error", err, "from", from, "to", to)
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+func (r *ClientRepository) SoftDeleteTx(sqlExec ports.SQLExec, id domain.ClientID, deletedAt time.Time) error {
+	// Clear the contact details so the UNIQUE constraints on them don't keep
+	// blocking a new client with the same email or number
+	query := `UPDATE clients SET deleted_at = ?, email = NULL, whatsapp_number = NULL, updated_at = ? WHERE id = ?`
+	_, err := sqlExec.Exec(query, deletedAt, deletedAt, id)
+	return err
+}
+
+// nullIfEmpty stores missing contact details as NULL, so the UNIQUE
+// constraints on them only apply to clients that have them.
+func nullIfEmpty(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
 func (r *ClientRepository) BulkGetClientBookings(
 	clientIDs []domain.ClientID,
 ) (map[domain.ClientID][]booking.Booking, error) {
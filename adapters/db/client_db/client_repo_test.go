@@ -0,0 +1,292 @@
+package client_db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+)
+
+func TestClientRepositoryFindByIDs(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewClientRepository(database)
+
+	now := domain.NewUTCTimestamp()
+	first := &client.Client{
+		ID:             domain.NewClientID(),
+		Name:           "First Client",
+		WhatsAppNumber: "+1555000001",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	second := &client.Client{
+		ID:             domain.NewClientID(),
+		Name:           "Second Client",
+		WhatsAppNumber: "+1555000002",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	for _, c := range []*client.Client{first, second} {
+		if err := repo.Create(c); err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+	}
+
+	clients, err := repo.FindByIDs([]domain.ClientID{first.ID, second.ID, domain.NewClientID()})
+	if err != nil {
+		t.Fatalf("FindByIDs failed: %v", err)
+	}
+
+	if len(clients) != 2 {
+		t.Fatalf("Expected 2 clients, got %d", len(clients))
+	}
+
+	found := make(map[domain.ClientID]bool)
+	for _, c := range clients {
+		found[c.ID] = true
+	}
+	if !found[first.ID] || !found[second.ID] {
+		t.Errorf("Expected clients %s and %s, got %v", first.ID, second.ID, found)
+	}
+}
+
+func TestClientRepositoryGetByWhatsAppNumber(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewClientRepository(database)
+
+	// Rows written before numbers were normalized may lack the "+"
+	now := domain.NewUTCTimestamp()
+	legacy := &client.Client{
+		ID:             domain.NewClientID(),
+		Name:           "Legacy Client",
+		WhatsAppNumber: "201001234567",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	normalized := &client.Client{
+		ID:             domain.NewClientID(),
+		Name:           "Normalized Client",
+		WhatsAppNumber: "+201007654321",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	for _, c := range []*client.Client{legacy, normalized} {
+		if err := repo.Create(c); err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		number   domain.WhatsAppNumber
+		expected domain.ClientID
+	}{
+		{"stored without plus, looked up with it", "+201001234567", legacy.ID},
+		{"stored without plus, looked up without it", "201001234567", legacy.ID},
+		{"stored with plus, looked up without it", "201007654321", normalized.ID},
+		{"stored with plus, looked up with it", "+201007654321", normalized.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.GetByWhatsAppNumber(tt.number)
+			if err != nil {
+				t.Fatalf("GetByWhatsAppNumber failed: %v", err)
+			}
+			if found == nil || found.ID != tt.expected {
+				t.Errorf("Expected client %s, got %+v", tt.expected, found)
+			}
+		})
+	}
+
+	t.Run("unknown number", func(t *testing.T) {
+		found, err := repo.GetByWhatsAppNumber("+19999999999")
+		if err != nil {
+			t.Fatalf("GetByWhatsAppNumber failed: %v", err)
+		}
+		if found != nil {
+			t.Errorf("Expected no client, got %+v", found)
+		}
+	})
+}
+
+func TestClientRepositoryGetByEmail(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewClientRepository(database)
+
+	now := domain.NewUTCTimestamp()
+	emailOnly := &client.Client{
+		ID:        domain.NewClientID(),
+		Name:      "Email Client",
+		Email:     "Client@Example.com",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	// Clients without an email must not collide on the UNIQUE column
+	whatsAppOnly := []*client.Client{
+		{ID: domain.NewClientID(), WhatsAppNumber: "+1555000001", CreatedAt: now, UpdatedAt: now},
+		{ID: domain.NewClientID(), WhatsAppNumber: "+1555000002", CreatedAt: now, UpdatedAt: now},
+	}
+	for _, c := range append([]*client.Client{emailOnly}, whatsAppOnly...) {
+		if err := repo.Create(c); err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+	}
+
+	t.Run("matches regardless of case", func(t *testing.T) {
+		found, err := repo.GetByEmail("CLIENT@example.com")
+		if err != nil {
+			t.Fatalf("GetByEmail failed: %v", err)
+		}
+		if found == nil || found.ID != emailOnly.ID {
+			t.Fatalf("Expected client %s, got %+v", emailOnly.ID, found)
+		}
+		if found.Email != "client@example.com" {
+			t.Errorf("Expected stored email to be lowercased, got %q", found.Email)
+		}
+		if found.WhatsAppNumber != "" {
+			t.Errorf("Expected no WhatsApp number, got %q", found.WhatsAppNumber)
+		}
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		found, err := repo.GetByEmail("nobody@example.com")
+		if err != nil {
+			t.Fatalf("GetByEmail failed: %v", err)
+		}
+		if found != nil {
+			t.Errorf("Expected no client, got %+v", found)
+		}
+	})
+
+	t.Run("duplicate email is rejected", func(t *testing.T) {
+		err := repo.Create(&client.Client{
+			ID:        domain.NewClientID(),
+			Email:     "client@example.com",
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err == nil {
+			t.Error("Expected a UNIQUE constraint error for a duplicate email")
+		}
+	})
+}
+
+func TestClientRepositoryGetSummary(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewClientRepository(database)
+
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+	clientID := dbtest.InsertClient(t, database)
+	now := time.Now().UTC()
+
+	timeSlotID := domain.NewTimeSlotID()
+	_, err := database.Exec(`
+		INSERT INTO time_slots (id, therapist_id, day_of_week, start_time, duration_minutes, advance_notice, after_session_break_time, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, timeSlotID, therapistID, "Monday", "10:00", 60, 0, 0, true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert time slot: %v", err)
+	}
+
+	bookings := []struct {
+		table     string
+		state     booking.BookingState
+		startTime time.Time
+	}{
+		{"bookings", booking.BookingStatePending, now.AddDate(0, 0, 1)},
+		{"bookings", booking.BookingStateConfirmed, now.AddDate(0, 0, 2)},
+		{"bookings", booking.BookingStateCancelled, now.AddDate(0, 0, 3)},  // cancelled, not upcoming
+		{"bookings", booking.BookingStateConfirmed, now.AddDate(0, 0, -2)}, // already started
+		{"adhoc_bookings", booking.BookingStateConfirmed, now.AddDate(0, 0, 4)},
+	}
+	for _, b := range bookings {
+		if b.table == "bookings" {
+			_, err = database.Exec(`
+				INSERT INTO bookings (id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, domain.NewBookingID(), timeSlotID, therapistID, clientID, b.startTime, 60, 0, b.state, now, now)
+		} else {
+			_, err = database.Exec(`
+				INSERT INTO adhoc_bookings (id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, domain.NewAdhocBookingID(), therapistID, clientID, b.startTime, 60, 0, b.state, now, now)
+		}
+		if err != nil {
+			t.Fatalf("Failed to insert %s row: %v", b.table, err)
+		}
+	}
+
+	sessions := []struct {
+		state      domain.SessionState
+		paidAmount int
+		currency   domain.Currency
+	}{
+		{domain.SessionStateDone, 5000, "USD"},
+		{domain.SessionStateDone, 3000, "USD"},
+		{domain.SessionStateDone, 40000, "EGP"},
+		{domain.SessionStateCancelled, 5000, "USD"},
+		{domain.SessionStateRefunded, 5000, "USD"},
+		{domain.SessionStatePlanned, 2000, "USD"},
+	}
+	for _, s := range sessions {
+		_, err := database.Exec(`
+			INSERT INTO sessions (id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, paid_amount, currency, language, state, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, domain.NewSessionID(), therapistID, clientID, now, 60, 0, s.paidAmount, s.currency, "english", s.state, now, now)
+		if err != nil {
+			t.Fatalf("Failed to insert session: %v", err)
+		}
+	}
+
+	// Another client's rows must not be counted
+	otherClientID := domain.NewClientID()
+	_, err = database.Exec(`
+		INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, otherClientID, "Other Client", "+1234567899", 0, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert other client: %v", err)
+	}
+	_, err = database.Exec(`
+		INSERT INTO sessions (id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, paid_amount, currency, language, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, domain.NewSessionID(), therapistID, otherClientID, now, 60, 0, 9999, "USD", "english", domain.SessionStateDone, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert other client's session: %v", err)
+	}
+
+	summary, err := repo.GetSummary(clientID, now)
+	if err != nil {
+		t.Fatalf("GetSummary failed: %v", err)
+	}
+
+	if summary.UpcomingBookings != 3 {
+		t.Errorf("Expected 3 upcoming bookings, got %d", summary.UpcomingBookings)
+	}
+	if summary.CompletedSessions != 3 {
+		t.Errorf("Expected 3 completed sessions, got %d", summary.CompletedSessions)
+	}
+	if summary.CancelledSessions != 1 {
+		t.Errorf("Expected 1 cancelled session, got %d", summary.CancelledSessions)
+	}
+	expectedPaid := map[domain.Currency]int{"USD": 15000, "EGP": 40000}
+	if len(summary.TotalPaid) != len(expectedPaid) {
+		t.Errorf("Expected paid totals %v, got %v", expectedPaid, summary.TotalPaid)
+	}
+	for currency, amount := range expectedPaid {
+		if summary.TotalPaid[currency] != amount {
+			t.Errorf("Expected %d %s paid, got %d", amount, currency, summary.TotalPaid[currency])
+		}
+	}
+}
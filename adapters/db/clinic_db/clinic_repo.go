@@ -0,0 +1,88 @@
+package clinic_db
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type ClinicRepository struct {
+	db ports.SQLDatabase
+}
+
+var ErrFailedToGetClinic = errors.New("failed to get clinic")
+
+func NewClinicRepository(db ports.SQLDatabase) ports.ClinicRepository {
+	return &ClinicRepository{db: db}
+}
+
+func (r *ClinicRepository) GetTherapistClinic(id domain.TherapistID) (domain.ClinicID, error) {
+	return r.getClinic(`SELECT clinic_id FROM therapists WHERE id = ?`, id)
+}
+
+func (r *ClinicRepository) GetClientClinic(id domain.ClientID) (domain.ClinicID, error) {
+	return r.getClinic(`SELECT clinic_id FROM clients WHERE id = ?`, id)
+}
+
+func (r *ClinicRepository) GetSpecializationClinic(id domain.SpecializationID) (domain.ClinicID, error) {
+	return r.getClinic(`SELECT clinic_id FROM specializations WHERE id = ?`, id)
+}
+
+func (r *ClinicRepository) GetBookingClinic(id domain.BookingID) (domain.ClinicID, error) {
+	query := `
+		SELECT t.clinic_id
+		FROM bookings b
+		JOIN therapists t ON t.id = b.therapist_id
+		WHERE b.id = ?
+	`
+	return r.getClinic(query, id)
+}
+
+func (r *ClinicRepository) GetAdhocBookingClinic(id domain.AdhocBookingID) (domain.ClinicID, error) {
+	query := `
+		SELECT t.clinic_id
+		FROM adhoc_bookings b
+		JOIN therapists t ON t.id = b.therapist_id
+		WHERE b.id = ?
+	`
+	return r.getClinic(query, id)
+}
+
+func (r *ClinicRepository) GetHoldClinic(token domain.HoldToken) (domain.ClinicID, error) {
+	query := `
+		SELECT t.clinic_id
+		FROM booking_holds h
+		JOIN bookings b ON b.id = h.booking_id
+		JOIN therapists t ON t.id = b.therapist_id
+		WHERE h.token = ?
+	`
+	return r.getClinic(query, token)
+}
+
+func (r *ClinicRepository) GetSessionClinic(id domain.SessionID) (domain.ClinicID, error) {
+	query := `
+		SELECT t.clinic_id
+		FROM sessions s
+		JOIN therapists t ON t.id = s.therapist_id
+		WHERE s.id = ?
+	`
+	return r.getClinic(query, id)
+}
+
+// getClinic runs a query selecting one clinic_id, returning an empty clinic
+// when no row matches
+func (r *ClinicRepository) getClinic(query string, args ...any) (domain.ClinicID, error) {
+	var clinicID domain.ClinicID
+	err := r.db.QueryRow(query, args...).Scan(&clinicID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		slog.Error("error getting clinic", "error", err)
+		return "", ErrFailedToGetClinic
+	}
+	return clinicID, nil
+}
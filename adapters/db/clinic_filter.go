@@ -0,0 +1,11 @@
+package db
+
+import "github.com/mishkahtherapy/brain/core/domain"
+
+// ClinicFilter returns the condition selecting rows whose therapist_id
+// belongs to the clinic. Append it to a query's WHERE clause; it matches
+// every clinic when clinicID is empty.
+func ClinicFilter(clinicID domain.ClinicID) (string, []interface{}) {
+	return " AND (? = '' OR therapist_id IN (SELECT id FROM therapists WHERE clinic_id = ?))",
+		[]interface{}{clinicID, clinicID}
+}
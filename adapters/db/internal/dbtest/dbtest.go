@@ -0,0 +1,74 @@
+package dbtest
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+// SetupTestDB creates a database in a temporary file with the schema loaded
+// and returns a cleanup function. It expects to run from a package directory
+// under adapters/db.
+func SetupTestDB(t *testing.T) (ports.SQLDatabase, func()) {
+	tmpfile, err := os.CreateTemp("", "test_*.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpfile.Close()
+	dbFilename := tmpfile.Name()
+
+	database := db.NewDatabase(db.DatabaseConfig{
+		DBFilename: dbFilename,
+		SchemaFile: "../../../schema.sql",
+	})
+
+	cleanup := func() {
+		database.Close()
+		os.Remove(dbFilename)
+		os.Remove(dbFilename + "-wal")
+		os.Remove(dbFilename + "-shm")
+	}
+
+	return database, cleanup
+}
+
+// InsertTherapist inserts a therapist row directly. Emails are unique, so
+// tests inserting several therapists pass different ones.
+func InsertTherapist(t *testing.T, database ports.SQLDatabase, email string) domain.TherapistID {
+	now := time.Now().UTC()
+	therapistID := domain.NewTherapistID()
+	_, err := database.Exec(`
+		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, therapistID, "Dr. Test Therapist", email, "+1234567890", "+1234567890", true, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert test therapist: %v", err)
+	}
+	_, err = database.Exec(`
+		INSERT INTO therapist_languages (therapist_id, language_code) VALUES (?, ?)
+	`, therapistID, domain.LanguageCodeEnglish)
+	if err != nil {
+		t.Fatalf("Failed to insert test therapist language: %v", err)
+	}
+	return therapistID
+}
+
+// InsertClient inserts a client row directly
+func InsertClient(t *testing.T, database ports.SQLDatabase) domain.ClientID {
+	now := time.Now().UTC()
+	clientID := domain.NewClientID()
+	_, err := database.Exec(`
+		INSERT INTO clients (id, name, whatsapp_number, timezone_offset, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, clientID, "Test Client", "+1234567891", 0, now, now)
+	if err != nil {
+		t.Fatalf("Failed to insert test client: %v", err)
+	}
+	return clientID
+}
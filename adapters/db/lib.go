@@ -2,8 +2,13 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/mishkahtherapy/brain/core/ports"
 )
@@ -19,8 +24,29 @@ type DatabaseConfig struct {
 	// Password string
 	DBFilename string
 	SchemaFile string
+
+	// JournalMode and BusyTimeout are applied to every pooled connection.
+	// WAL lets readers proceed while a write is in progress and the busy
+	// timeout makes concurrent writers wait instead of failing with
+	// "database is locked". Zero values fall back to the defaults below.
+	JournalMode string
+	BusyTimeout time.Duration
+
+	// MaxOpenConns and MaxIdleConns size the connection pool. Zero keeps
+	// database/sql's defaults.
+	MaxOpenConns int
+	MaxIdleConns int
+
+	// ReadDSN optionally points at a read replica. When set, listing and
+	// report queries go to it while writes stay on the primary.
+	ReadDSN string
 }
 
+const (
+	DefaultJournalMode = "WAL"
+	DefaultBusyTimeout = 5 * time.Second
+)
+
 func NewDatabase(config DatabaseConfig) ports.SQLDatabase {
 	if config.SchemaFile == "" {
 		panic("schema file is required")
@@ -34,7 +60,16 @@ func NewDatabase(config DatabaseConfig) ports.SQLDatabase {
 		slog.Error("Failed to connect to database", "error", err)
 		panic(err)
 	}
-	return &Database{db: db}
+	if config.ReadDSN == "" {
+		return &Database{db: db}
+	}
+
+	readDB, err := connectReadDB(config)
+	if err != nil {
+		slog.Error("Failed to connect to read database", "error", err)
+		panic(err)
+	}
+	return NewReadRoutedDatabase(&Database{db: db}, readDB)
 }
 
 func (d *Database) Query(query string, args ...any) (*sql.Rows, error) {
@@ -57,14 +92,47 @@ func (d *Database) Close() error {
 	return d.db.Close()
 }
 
+func (d *Database) Reader() ports.SQLExec {
+	return d
+}
+
+// ReadRoutedDatabase sends the queries repositories run through Reader to a
+// separate read connection. Everything else goes to the primary.
+type ReadRoutedDatabase struct {
+	ports.SQLDatabase
+	reader ports.SQLExec
+}
+
+func NewReadRoutedDatabase(primary ports.SQLDatabase, reader ports.SQLExec) ports.SQLDatabase {
+	return &ReadRoutedDatabase{SQLDatabase: primary, reader: reader}
+}
+
+func (d *ReadRoutedDatabase) Reader() ports.SQLExec {
+	return d.reader
+}
+
+func (d *ReadRoutedDatabase) Close() error {
+	err := d.SQLDatabase.Close()
+	if closer, ok := d.reader.(io.Closer); ok {
+		if readErr := closer.Close(); err == nil {
+			err = readErr
+		}
+	}
+	return err
+}
+
 func connectDB(config DatabaseConfig) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", config.DBFilename)
+	db, err := sql.Open("sqlite", dataSourceName(config))
 	if err != nil {
 		return nil, err
 	}
 
-	// Initialize the database
-	db.Exec(`PRAGMA foreign_keys = ON`)
+	if config.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(config.MaxOpenConns)
+	}
+	if config.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(config.MaxIdleConns)
+	}
 
 	// Check if the database is has no schema tables
 	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type='table' AND name='specializations'`)
@@ -91,3 +159,65 @@ func connectDB(config DatabaseConfig) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// connectReadDB opens the read replica. Its schema is managed by the primary,
+// so it is never loaded here.
+func connectReadDB(config DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", readDataSourceName(config))
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(config.MaxOpenConns)
+	}
+	if config.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(config.MaxIdleConns)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// readDataSourceName appends the per-connection pragmas to ReadDSN. The
+// journal mode belongs to the primary, and query_only keeps a misrouted write
+// from touching the replica.
+func readDataSourceName(config DatabaseConfig) string {
+	params := url.Values{}
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeout(config).Milliseconds()))
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "query_only(1)")
+
+	separator := "?"
+	if strings.Contains(config.ReadDSN, "?") {
+		separator = "&"
+	}
+	return config.ReadDSN + separator + params.Encode()
+}
+
+func busyTimeout(config DatabaseConfig) time.Duration {
+	if config.BusyTimeout == 0 {
+		return DefaultBusyTimeout
+	}
+	return config.BusyTimeout
+}
+
+// dataSourceName passes the pragmas through the DSN so the driver runs them on
+// each new connection rather than on whichever connection happens to be used.
+func dataSourceName(config DatabaseConfig) string {
+	journalMode := config.JournalMode
+	if journalMode == "" {
+		journalMode = DefaultJournalMode
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeout(config).Milliseconds()))
+	params.Add("_pragma", fmt.Sprintf("journal_mode(%s)", journalMode))
+	// Foreign keys are off by default in SQLite and the setting is per
+	// connection, so it has to be part of the DSN too
+	params.Add("_pragma", "foreign_keys(1)")
+	return config.DBFilename + "?" + params.Encode()
+}
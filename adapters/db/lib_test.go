@@ -0,0 +1,63 @@
+package db
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDataSourceName(t *testing.T) {
+	dsn := dataSourceName(DatabaseConfig{
+		DBFilename:  "brain.db",
+		BusyTimeout: 2 * time.Second,
+	})
+
+	filename, rawQuery, found := strings.Cut(dsn, "?")
+	if !found || filename != "brain.db" {
+		t.Fatalf("expected the pragmas to follow brain.db, got %q", dsn)
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatalf("failed to parse DSN query: %v", err)
+	}
+
+	pragmas := map[string]bool{}
+	for _, pragma := range query["_pragma"] {
+		pragmas[pragma] = true
+	}
+	for _, expected := range []string{"busy_timeout(2000)", "journal_mode(WAL)", "foreign_keys(1)"} {
+		if !pragmas[expected] {
+			t.Errorf("expected pragma %s in %q", expected, dsn)
+		}
+	}
+}
+
+func TestReadDataSourceName(t *testing.T) {
+	dsn := readDataSourceName(DatabaseConfig{ReadDSN: "file:replica.db?mode=ro"})
+
+	filename, rawQuery, found := strings.Cut(dsn, "?")
+	if !found || filename != "file:replica.db" {
+		t.Fatalf("expected the pragmas to follow file:replica.db, got %q", dsn)
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatalf("failed to parse DSN query: %v", err)
+	}
+	if query.Get("mode") != "ro" {
+		t.Errorf("expected the existing mode=ro parameter to be kept in %q", dsn)
+	}
+
+	pragmas := map[string]bool{}
+	for _, pragma := range query["_pragma"] {
+		pragmas[pragma] = true
+	}
+	for _, expected := range []string{"busy_timeout(5000)", "foreign_keys(1)", "query_only(1)"} {
+		if !pragmas[expected] {
+			t.Errorf("expected pragma %s in %q", expected, dsn)
+		}
+	}
+	if pragmas["journal_mode(WAL)"] {
+		t.Errorf("expected the replica DSN to leave the journal mode alone, got %q", dsn)
+	}
+}
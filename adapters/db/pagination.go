@@ -0,0 +1,23 @@
+package db
+
+import "github.com/mishkahtherapy/brain/core/ports"
+
+// KeysetFilter returns the condition selecting rows after the cursor in
+// start_time, id order. Append it to a query's WHERE clause; it is empty on
+// the first page.
+func KeysetFilter(after *ports.PageCursor) (string, []interface{}) {
+	if after == nil {
+		return "", nil
+	}
+	startTime := after.StartTime.UTC()
+	return " AND (start_time > ? OR (start_time = ? AND id > ?))",
+		[]interface{}{startTime, startTime, after.ID}
+}
+
+// KeysetOrder returns the ORDER BY and LIMIT clauses matching KeysetFilter
+func KeysetOrder(limit int) (string, []interface{}) {
+	if limit <= 0 {
+		return " ORDER BY start_time ASC, id ASC", nil
+	}
+	return " ORDER BY start_time ASC, id ASC LIMIT ?", []interface{}{limit}
+}
@@ -0,0 +1,251 @@
+package recurring_block_db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type RecurringBlockRepository struct {
+	db ports.SQLDatabase
+}
+
+var ErrRecurringBlockNotFound = errors.New("recurring block not found")
+var ErrRecurringBlockIDIsRequired = errors.New("recurring block id is required")
+var ErrRecurringBlockTherapistIDIsRequired = errors.New("recurring block therapist id is required")
+var ErrRecurringBlockDayOfWeekIsRequired = errors.New("recurring block day of week is required")
+var ErrRecurringBlockStartTimeIsRequired = errors.New("recurring block start time is required")
+var ErrRecurringBlockDurationIsRequired = errors.New("recurring block duration is required")
+var ErrRecurringBlockCreatedAtIsRequired = errors.New("recurring block created at is required")
+var ErrRecurringBlockUpdatedAtIsRequired = errors.New("recurring block updated at is required")
+var ErrFailedToGetRecurringBlocks = errors.New("failed to get recurring blocks")
+var ErrFailedToCreateRecurringBlock = errors.New("failed to create recurring block")
+var ErrFailedToUpdateRecurringBlock = errors.New("failed to update recurring block")
+var ErrFailedToDeleteRecurringBlock = errors.New("failed to delete recurring block")
+
+func NewRecurringBlockRepository(db ports.SQLDatabase) ports.RecurringBlockRepository {
+	return &RecurringBlockRepository{db: db}
+}
+
+// GetByID returns nil when the block does not exist
+func (r *RecurringBlockRepository) GetByID(id domain.RecurringBlockID) (*timeslot.RecurringBlock, error) {
+	query := `
+		SELECT id, therapist_id, day_of_week, start_time, duration_minutes, timezone, created_at, updated_at
+		FROM recurring_blocks
+		WHERE id = ?
+	`
+	row := r.db.QueryRow(query, id)
+	block := &timeslot.RecurringBlock{}
+	err := row.Scan(
+		&block.ID,
+		&block.TherapistID,
+		&block.DayOfWeek,
+		&block.Start,
+		&block.Duration,
+		&block.Timezone,
+		&block.CreatedAt,
+		&block.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		slog.Error("error getting recurring block by id", "error", err)
+		return nil, ErrFailedToGetRecurringBlocks
+	}
+	if _, err := block.Location(); err != nil {
+		slog.Error("recurring block has an invalid timezone", "id", id, "timezone", block.Timezone)
+		return nil, err
+	}
+	return block, nil
+}
+
+func (r *RecurringBlockRepository) Create(block *timeslot.RecurringBlock) error {
+	if err := validateBlock(block); err != nil {
+		return err
+	}
+
+	if block.CreatedAt == (domain.UTCTimestamp{}) {
+		return ErrRecurringBlockCreatedAtIsRequired
+	}
+
+	query := `
+		INSERT INTO recurring_blocks (
+			id, therapist_id, day_of_week, start_time, duration_minutes, timezone, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.Exec(
+		query,
+		block.ID,
+		block.TherapistID,
+		block.DayOfWeek,
+		block.Start,
+		block.Duration,
+		block.Timezone,
+		block.CreatedAt,
+		block.UpdatedAt,
+	)
+	if err != nil {
+		slog.Error("error creating recurring block", "error", err)
+		return ErrFailedToCreateRecurringBlock
+	}
+	return nil
+}
+
+func (r *RecurringBlockRepository) Update(block *timeslot.RecurringBlock) error {
+	if err := validateBlock(block); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE recurring_blocks
+		SET day_of_week = ?, start_time = ?, duration_minutes = ?, timezone = ?, updated_at = ?
+		WHERE id = ?
+	`
+	result, err := r.db.Exec(
+		query,
+		block.DayOfWeek,
+		block.Start,
+		block.Duration,
+		block.Timezone,
+		block.UpdatedAt,
+		block.ID,
+	)
+	if err != nil {
+		slog.Error("error updating recurring block", "error", err)
+		return ErrFailedToUpdateRecurringBlock
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.Error("error getting rows affected after update", "error", err)
+		return ErrFailedToUpdateRecurringBlock
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecurringBlockNotFound
+	}
+	return nil
+}
+
+func (r *RecurringBlockRepository) Delete(id domain.RecurringBlockID) error {
+	if id == "" {
+		return ErrRecurringBlockIDIsRequired
+	}
+
+	result, err := r.db.Exec(`DELETE FROM recurring_blocks WHERE id = ?`, id)
+	if err != nil {
+		slog.Error("error deleting recurring block", "error", err)
+		return ErrFailedToDeleteRecurringBlock
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.Error("error getting rows affected after delete", "error", err)
+		return ErrFailedToDeleteRecurringBlock
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecurringBlockNotFound
+	}
+	return nil
+}
+
+func (r *RecurringBlockRepository) ListByTherapist(therapistID domain.TherapistID) ([]*timeslot.RecurringBlock, error) {
+	if therapistID == "" {
+		return nil, ErrRecurringBlockTherapistIDIsRequired
+	}
+
+	result, err := r.BulkListByTherapist([]domain.TherapistID{therapistID})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[therapistID], nil
+}
+
+func (r *RecurringBlockRepository) BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.RecurringBlock, error) {
+	blocks := make(map[domain.TherapistID][]*timeslot.RecurringBlock)
+	if len(therapistIDs) == 0 {
+		return blocks, nil
+	}
+
+	query := `
+		SELECT id, therapist_id, day_of_week, start_time, duration_minutes, timezone, created_at, updated_at
+		FROM recurring_blocks
+		WHERE therapist_id IN (%s)
+		ORDER BY day_of_week, start_time
+	`
+	placeholders := make([]string, len(therapistIDs))
+	values := make([]interface{}, len(therapistIDs))
+	for i, id := range therapistIDs {
+		placeholders[i] = "?"
+		values[i] = id
+		blocks[id] = make([]*timeslot.RecurringBlock, 0)
+	}
+	query = fmt.Sprintf(query, strings.Join(placeholders, ","))
+
+	rows, err := r.db.Query(query, values...)
+	if err != nil {
+		slog.Error("error listing recurring blocks by therapist", "error", err)
+		return nil, ErrFailedToGetRecurringBlocks
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		block := &timeslot.RecurringBlock{}
+		err := rows.Scan(
+			&block.ID,
+			&block.TherapistID,
+			&block.DayOfWeek,
+			&block.Start,
+			&block.Duration,
+			&block.Timezone,
+			&block.CreatedAt,
+			&block.UpdatedAt,
+		)
+		if err != nil {
+			slog.Error("error scanning recurring block", "error", err)
+			return nil, ErrFailedToGetRecurringBlocks
+		}
+		if _, err := block.Location(); err != nil {
+			slog.Error("recurring block has an invalid timezone", "id", block.ID, "timezone", block.Timezone)
+			return nil, err
+		}
+		blocks[block.TherapistID] = append(blocks[block.TherapistID], block)
+	}
+	return blocks, nil
+}
+
+func validateBlock(block *timeslot.RecurringBlock) error {
+	if block.ID == "" {
+		return ErrRecurringBlockIDIsRequired
+	}
+
+	if block.TherapistID == "" {
+		return ErrRecurringBlockTherapistIDIsRequired
+	}
+
+	if block.DayOfWeek == "" {
+		return ErrRecurringBlockDayOfWeekIsRequired
+	}
+
+	if block.Start == "" {
+		return ErrRecurringBlockStartTimeIsRequired
+	}
+
+	if block.Duration <= 0 {
+		return ErrRecurringBlockDurationIsRequired
+	}
+
+	if block.UpdatedAt == (domain.UTCTimestamp{}) {
+		return ErrRecurringBlockUpdatedAtIsRequired
+	}
+	return nil
+}
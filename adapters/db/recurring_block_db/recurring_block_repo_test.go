@@ -0,0 +1,138 @@
+package recurring_block_db
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+)
+
+func newBlock(therapistID domain.TherapistID, day timeslot.DayOfWeek, start domain.Time24h) *timeslot.RecurringBlock {
+	now := domain.NewUTCTimestamp()
+	return &timeslot.RecurringBlock{
+		ID:          domain.NewRecurringBlockID(),
+		TherapistID: therapistID,
+		DayOfWeek:   day,
+		Start:       start,
+		Duration:    60,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func TestRecurringBlockRepository(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewRecurringBlockRepository(database)
+
+	therapistA := dbtest.InsertTherapist(t, database, "a@example.com")
+	therapistB := dbtest.InsertTherapist(t, database, "b@example.com")
+
+	lunch := newBlock(therapistA, timeslot.DayOfWeekMonday, "12:00")
+	lunch.Timezone = "Africa/Cairo"
+	morning := newBlock(therapistA, timeslot.DayOfWeekMonday, "08:00")
+	other := newBlock(therapistB, timeslot.DayOfWeekTuesday, "12:00")
+	for _, block := range []*timeslot.RecurringBlock{lunch, morning, other} {
+		if err := repo.Create(block); err != nil {
+			t.Fatalf("Failed to create recurring block: %v", err)
+		}
+	}
+
+	t.Run("GetByID returns the stored block", func(t *testing.T) {
+		stored, err := repo.GetByID(lunch.ID)
+		if err != nil {
+			t.Fatalf("GetByID failed: %v", err)
+		}
+		if stored == nil {
+			t.Fatalf("Expected block %s", lunch.ID)
+		}
+		if stored.TherapistID != therapistA || stored.DayOfWeek != timeslot.DayOfWeekMonday ||
+			stored.Start != "12:00" || stored.Duration != 60 || stored.Timezone != "Africa/Cairo" {
+			t.Errorf("Unexpected block %+v", stored)
+		}
+	})
+
+	t.Run("GetByID returns nil for an unknown block", func(t *testing.T) {
+		stored, err := repo.GetByID(domain.NewRecurringBlockID())
+		if err != nil {
+			t.Fatalf("GetByID failed: %v", err)
+		}
+		if stored != nil {
+			t.Errorf("Expected no block, got %+v", stored)
+		}
+	})
+
+	t.Run("BulkListByTherapist groups blocks per therapist", func(t *testing.T) {
+		unknown := domain.NewTherapistID()
+		blocks, err := repo.BulkListByTherapist([]domain.TherapistID{therapistA, therapistB, unknown})
+		if err != nil {
+			t.Fatalf("BulkListByTherapist failed: %v", err)
+		}
+		if len(blocks[therapistA]) != 2 {
+			t.Errorf("Expected 2 blocks for therapist A, got %d", len(blocks[therapistA]))
+		}
+		if len(blocks[therapistB]) != 1 || blocks[therapistB][0].ID != other.ID {
+			t.Errorf("Expected block %s for therapist B, got %v", other.ID, blocks[therapistB])
+		}
+		if blocks[unknown] == nil || len(blocks[unknown]) != 0 {
+			t.Errorf("Expected an empty list for an unknown therapist, got %v", blocks[unknown])
+		}
+	})
+
+	t.Run("Update changes the window", func(t *testing.T) {
+		morning.Start = "09:30"
+		morning.Duration = 30
+		morning.UpdatedAt = domain.NewUTCTimestamp()
+		if err := repo.Update(morning); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+
+		stored, err := repo.GetByID(morning.ID)
+		if err != nil {
+			t.Fatalf("GetByID failed: %v", err)
+		}
+		if stored.Start != "09:30" || stored.Duration != 30 {
+			t.Errorf("Expected 09:30 for 30 minutes, got %s for %d", stored.Start, stored.Duration)
+		}
+	})
+
+	t.Run("Update and Delete report unknown blocks", func(t *testing.T) {
+		missing := newBlock(therapistA, timeslot.DayOfWeekFriday, "10:00")
+		if err := repo.Update(missing); err != ErrRecurringBlockNotFound {
+			t.Errorf("Expected ErrRecurringBlockNotFound on update, got %v", err)
+		}
+		if err := repo.Delete(missing.ID); err != ErrRecurringBlockNotFound {
+			t.Errorf("Expected ErrRecurringBlockNotFound on delete, got %v", err)
+		}
+	})
+
+	t.Run("Delete removes the block", func(t *testing.T) {
+		if err := repo.Delete(lunch.ID); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+
+		blocks, err := repo.ListByTherapist(therapistA)
+		if err != nil {
+			t.Fatalf("ListByTherapist failed: %v", err)
+		}
+		if len(blocks) != 1 || blocks[0].ID != morning.ID {
+			t.Errorf("Expected only block %s to remain, got %v", morning.ID, blocks)
+		}
+	})
+
+	t.Run("Reading a block with an invalid timezone fails", func(t *testing.T) {
+		broken := newBlock(therapistB, timeslot.DayOfWeekSunday, "10:00")
+		if err := repo.Create(broken); err != nil {
+			t.Fatalf("Failed to create recurring block: %v", err)
+		}
+		if _, err := database.Exec(`UPDATE recurring_blocks SET timezone = ? WHERE id = ?`, "Mars/Olympus_Mons", broken.ID); err != nil {
+			t.Fatalf("Failed to corrupt timezone: %v", err)
+		}
+
+		if _, err := repo.GetByID(broken.ID); err != timeslot.ErrInvalidTimezoneName {
+			t.Errorf("Expected ErrInvalidTimezoneName, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+// Package seed fills a development database with demo therapists, clients,
+// timeslots and bookings. Everything is written through the real
+// repositories, so seeded rows look exactly like ones created via the API.
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+var demoSpecializations = []string{
+	"Anxiety",
+	"Depression",
+	"Couples Therapy",
+	"Trauma",
+	"Grief",
+	"Addiction",
+	"Child Psychology",
+	"Eating Disorders",
+}
+
+var slotDays = []timeslot.DayOfWeek{
+	timeslot.DayOfWeekMonday,
+	timeslot.DayOfWeekTuesday,
+	timeslot.DayOfWeekWednesday,
+	timeslot.DayOfWeekThursday,
+	timeslot.DayOfWeekFriday,
+	timeslot.DayOfWeekSaturday,
+	timeslot.DayOfWeekSunday,
+}
+
+// Slots are three hours long and start at these UTC times, so a therapist
+// can have up to one slot per day and start time without overlaps
+var slotStarts = []domain.Time24h{"09:00", "13:00", "17:00"}
+
+const (
+	slotDuration    = domain.DurationMinutes(180)
+	bookingDuration = domain.DurationMinutes(60)
+)
+
+// MaxTimeslotsPerTherapist is how many non-overlapping slots a therapist can get
+var MaxTimeslotsPerTherapist = len(slotDays) * len(slotStarts)
+
+var (
+	ErrInvalidOptions = errors.New("seed counts must not be negative")
+	ErrTooManySlots   = fmt.Errorf("at most %d timeslots per therapist can be seeded", MaxTimeslotsPerTherapist)
+)
+
+// Options sets how many rows of each kind are generated
+type Options struct {
+	Specializations       int
+	Therapists            int
+	Clients               int
+	TimeslotsPerTherapist int
+	BookingsPerTimeslot   int // Spread over the slot's upcoming weeks
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Specializations:       5,
+		Therapists:            10,
+		Clients:               20,
+		TimeslotsPerTherapist: 5,
+		BookingsPerTimeslot:   2,
+	}
+}
+
+// Result counts the rows that were written
+type Result struct {
+	Specializations int
+	Therapists      int
+	Clients         int
+	Timeslots       int
+	Bookings        int
+}
+
+// Run generates demo data into the database. Specializations are reused by
+// name, so running it twice only adds new therapists, clients and bookings.
+func Run(database ports.SQLDatabase, options Options) (*Result, error) {
+	if options.Specializations < 0 || options.Therapists < 0 || options.Clients < 0 ||
+		options.TimeslotsPerTherapist < 0 || options.BookingsPerTimeslot < 0 {
+		return nil, ErrInvalidOptions
+	}
+	if options.TimeslotsPerTherapist > MaxTimeslotsPerTherapist {
+		return nil, ErrTooManySlots
+	}
+
+	specializationRepo := specialization_db.NewSpecializationRepository(database)
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+	clientRepo := client_db.NewClientRepository(database)
+	timeSlotRepo := timeslot_db.NewTimeSlotRepository(database)
+	bookingRepo := booking_db.NewBookingRepository(database)
+
+	result := &Result{}
+	now := domain.NewUTCTimestamp()
+
+	specializations := make([]specialization.Specialization, 0, options.Specializations)
+	for i := range options.Specializations {
+		name := demoSpecializations[i%len(demoSpecializations)]
+		if i >= len(demoSpecializations) {
+			name = fmt.Sprintf("%s %d", name, i/len(demoSpecializations)+1)
+		}
+
+		existing, err := specializationRepo.GetByName(domain.DefaultClinicID, name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			specializations = append(specializations, *existing)
+			continue
+		}
+
+		created := specialization.Specialization{
+			ID:        domain.NewSpecializationID(),
+			Name:      name,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := specializationRepo.Create(&created); err != nil {
+			return nil, fmt.Errorf("creating specialization %q: %w", name, err)
+		}
+		specializations = append(specializations, created)
+		result.Specializations++
+	}
+
+	clientIDs := make([]domain.ClientID, 0, options.Clients)
+	for i := range options.Clients {
+		demoClient := &client.Client{
+			ID:             domain.NewClientID(),
+			Name:           fmt.Sprintf("Demo Client %d", i+1),
+			WhatsAppNumber: demoPhoneNumber(),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := clientRepo.Create(demoClient); err != nil {
+			return nil, fmt.Errorf("creating client: %w", err)
+		}
+		clientIDs = append(clientIDs, demoClient.ID)
+		result.Clients++
+	}
+
+	for i := range options.Therapists {
+		therapistID := domain.NewTherapistID()
+		demoTherapist := &therapist.Therapist{
+			ID:             therapistID,
+			Name:           fmt.Sprintf("Dr. Demo %d", i+1),
+			Email:          domain.Email(fmt.Sprintf("%s@demo.example.com", therapistID)),
+			PhoneNumber:    domain.PhoneNumber(demoPhoneNumber()),
+			WhatsAppNumber: demoPhoneNumber(),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		demoTherapist.SetLanguages([]domain.LanguageCode{domain.LanguageCodeArabic, domain.LanguageCodeEnglish})
+		if len(specializations) > 0 {
+			demoTherapist.Specializations = []specialization.Specialization{specializations[i%len(specializations)]}
+		}
+		if err := therapistRepo.Create(demoTherapist); err != nil {
+			return nil, fmt.Errorf("creating therapist: %w", err)
+		}
+		result.Therapists++
+
+		for j := range options.TimeslotsPerTherapist {
+			slot := &timeslot.TimeSlot{
+				ID:          domain.NewTimeSlotID(),
+				TherapistID: therapistID,
+				IsActive:    true,
+				DayOfWeek:   slotDays[j%len(slotDays)],
+				Start:       slotStarts[j/len(slotDays)],
+				Duration:    slotDuration,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			if err := timeSlotRepo.Create(slot); err != nil {
+				return nil, fmt.Errorf("creating timeslot: %w", err)
+			}
+			result.Timeslots++
+
+			if len(clientIDs) == 0 {
+				continue
+			}
+			firstStart := nextOccurrence(slot, now.Time())
+			for week := range options.BookingsPerTimeslot {
+				demoBooking := &booking.Booking{
+					ID:          domain.NewBookingID(),
+					TimeSlotID:  slot.ID,
+					TherapistID: therapistID,
+					ClientID:    clientIDs[result.Bookings%len(clientIDs)],
+					State:       booking.BookingStatePending,
+					StartTime:   firstStart.Add(time.Duration(week) * 7 * 24 * time.Hour),
+					Duration:    bookingDuration,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}
+				if err := bookingRepo.Create(demoBooking); err != nil {
+					return nil, fmt.Errorf("creating booking: %w", err)
+				}
+				result.Bookings++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// nextOccurrence returns the start of the slot's first occurrence after today
+func nextOccurrence(slot *timeslot.TimeSlot, now time.Time) domain.UTCTimestamp {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for range 7 {
+		day = day.AddDate(0, 0, 1)
+		if start, _, ok := slot.OccurrenceOn(day); ok {
+			return start
+		}
+	}
+	panic("timeslot has no weekly occurrence: " + string(slot.ID))
+}
+
+// demoPhoneNumber returns a random Egyptian mobile number. Clients need
+// unique WhatsApp numbers, and random ones keep repeated seeds apart.
+func demoPhoneNumber() domain.WhatsAppNumber {
+	return domain.WhatsAppNumber(fmt.Sprintf("+2010%08d", rand.Intn(100_000_000)))
+}
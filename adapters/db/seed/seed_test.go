@@ -0,0 +1,65 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+)
+
+func TestRun(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	options := Options{
+		Specializations:       2,
+		Therapists:            3,
+		Clients:               4,
+		TimeslotsPerTherapist: 2,
+		BookingsPerTimeslot:   2,
+	}
+	result, err := Run(database, options)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	expected := map[string]int{
+		"specializations":           2,
+		"therapists":                3,
+		"therapist_specializations": 3,
+		"clients":                   4,
+		"time_slots":                6,
+		"bookings":                  12,
+	}
+	for table, count := range expected {
+		var rows int
+		if err := database.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&rows); err != nil {
+			t.Fatalf("Failed to count %s: %v", table, err)
+		}
+		if rows != count {
+			t.Errorf("Expected %d rows in %s, got %d", count, table, rows)
+		}
+	}
+	if result.Bookings != 12 || result.Timeslots != 6 {
+		t.Errorf("Expected 6 timeslots and 12 bookings reported, got %+v", result)
+	}
+
+	t.Run("reuses specializations on a second run", func(t *testing.T) {
+		result, err := Run(database, options)
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if result.Specializations != 0 {
+			t.Errorf("Expected no new specializations, got %d", result.Specializations)
+		}
+		if result.Therapists != 3 {
+			t.Errorf("Expected 3 more therapists, got %d", result.Therapists)
+		}
+	})
+
+	t.Run("rejects more slots than fit in a week", func(t *testing.T) {
+		_, err := Run(database, Options{Therapists: 1, TimeslotsPerTherapist: MaxTimeslotsPerTherapist + 1})
+		if err != ErrTooManySlots {
+			t.Errorf("Expected %v, got %v", ErrTooManySlots, err)
+		}
+	})
+}
@@ -3,9 +3,12 @@ package session_db
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/mishkahtherapy/brain/adapters/db"
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/ports"
 )
@@ -79,15 +82,25 @@ func (r *SessionRepository) CreateSession(tx ports.SQLTx, session *domain.Sessio
 		INSERT INTO sessions (
 			id, regular_booking_id, adhoc_booking_id, therapist_id, client_id,
 			start_time, paid_amount, duration_minutes, language, state, notes, 
-			meeting_url, client_timezone_offset, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			meeting_url, client_timezone_offset, currency, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	currency := session.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	// The booking id a session was not created from is stored as NULL, so the
+	// UNIQUE constraints only ever compare real booking ids
+	regularBookingID := sql.NullString{String: string(session.RegularBookingID), Valid: session.RegularBookingID != ""}
+	adhocBookingID := sql.NullString{String: string(session.AdhocBookingID), Valid: session.AdhocBookingID != ""}
+
 	_, err := tx.Exec(
 		query,
 		session.ID,
-		session.RegularBookingID,
-		session.AdhocBookingID,
+		regularBookingID,
+		adhocBookingID,
 		session.TherapistID,
 		session.ClientID,
 		session.StartTime,
@@ -98,11 +111,15 @@ func (r *SessionRepository) CreateSession(tx ports.SQLTx, session *domain.Sessio
 		session.Notes,
 		session.MeetingURL,
 		session.ClientTimezoneOffset,
+		currency,
 		session.CreatedAt,
 		session.UpdatedAt,
 	)
 
 	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return ErrSessionAlreadyExists
+		}
 		slog.Error("error creating session", "error", err)
 		return ErrFailedToCreateSession
 	}
@@ -117,9 +134,9 @@ func (r *SessionRepository) GetSessionByID(id domain.SessionID) (*domain.Session
 	}
 
 	query := `
-		SELECT id, regular_booking_id, adhoc_booking_id, therapist_id, client_id,
+		SELECT id, COALESCE(regular_booking_id, ''), COALESCE(adhoc_booking_id, ''), therapist_id, client_id,
 		       start_time, paid_amount, duration_minutes, language, state, notes, 
-		       meeting_url, client_timezone_offset, created_at, updated_at
+		       meeting_url, client_timezone_offset, currency, created_at, updated_at
 		FROM sessions
 		WHERE id = ?
 	`
@@ -140,6 +157,7 @@ func (r *SessionRepository) GetSessionByID(id domain.SessionID) (*domain.Session
 		&session.Notes,
 		&session.MeetingURL,
 		&session.ClientTimezoneOffset,
+		&session.Currency,
 		&session.CreatedAt,
 		&session.UpdatedAt,
 	)
@@ -155,6 +173,48 @@ func (r *SessionRepository) GetSessionByID(id domain.SessionID) (*domain.Session
 	return session, nil
 }
 
+// GetSessionByRegularBookingID retrieves the session created from a regular booking
+func (r *SessionRepository) GetSessionByRegularBookingID(bookingID domain.BookingID) (*domain.Session, error) {
+	if bookingID == "" {
+		return nil, ErrSessionBookingIDIsRequired
+	}
+	return r.getSessionByBookingColumn("regular_booking_id", string(bookingID))
+}
+
+// GetSessionByAdhocBookingID retrieves the session created from an adhoc booking
+func (r *SessionRepository) GetSessionByAdhocBookingID(bookingID domain.AdhocBookingID) (*domain.Session, error) {
+	if bookingID == "" {
+		return nil, ErrSessionBookingIDIsRequired
+	}
+	return r.getSessionByBookingColumn("adhoc_booking_id", string(bookingID))
+}
+
+func (r *SessionRepository) getSessionByBookingColumn(column string, bookingID string) (*domain.Session, error) {
+	query := fmt.Sprintf(`
+		SELECT id, COALESCE(regular_booking_id, ''), COALESCE(adhoc_booking_id, ''), therapist_id, client_id,
+		       start_time, paid_amount, duration_minutes, language, state, notes, 
+		       meeting_url, client_timezone_offset, currency, created_at, updated_at
+		FROM sessions
+		WHERE %s = ?
+	`, column)
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		slog.Error("error getting session by booking id", "error", err)
+		return nil, ErrFailedToGetSession
+	}
+	defer rows.Close()
+
+	sessions, err := r.scanSessions(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return sessions[0], nil
+}
+
 // UpdateSessionState updates a session's state
 func (r *SessionRepository) UpdateSessionState(id domain.SessionID, state domain.SessionState) error {
 	if id == "" {
@@ -290,22 +350,34 @@ func (r *SessionRepository) UpdateMeetingURL(id domain.SessionID, meetingURL str
 	return nil
 }
 
-// ListSessionsByTherapist lists all sessions for a therapist
-func (r *SessionRepository) ListSessionsByTherapist(therapistID domain.TherapistID) ([]*domain.Session, error) {
+// ListSessionsByTherapist lists the sessions of a therapist, narrowed down to
+// the given states when any are passed
+func (r *SessionRepository) ListSessionsByTherapist(therapistID domain.TherapistID, states []domain.SessionState) ([]*domain.Session, error) {
 	if therapistID == "" {
 		return nil, ErrSessionTherapistIDIsRequired
 	}
 
-	query := `
-		SELECT id, regular_booking_id, adhoc_booking_id, therapist_id, client_id,
+	conditions := []string{"therapist_id = ?"}
+	args := []interface{}{therapistID}
+	if len(states) > 0 {
+		placeholders := make([]string, 0, len(states))
+		for _, state := range states {
+			placeholders = append(placeholders, "?")
+			args = append(args, state)
+		}
+		conditions = append(conditions, fmt.Sprintf("state IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, COALESCE(regular_booking_id, ''), COALESCE(adhoc_booking_id, ''), therapist_id, client_id,
 		       start_time, paid_amount, duration_minutes, language, state, notes, 
-		       meeting_url, client_timezone_offset, created_at, updated_at
+		       meeting_url, client_timezone_offset, currency, created_at, updated_at
 		FROM sessions
-		WHERE therapist_id = ?
+		WHERE %s
 		ORDER BY start_time ASC
-	`
+	`, strings.Join(conditions, " AND "))
 
-	rows, err := r.db.Query(query, therapistID)
+	rows, err := r.db.Reader().Query(query, args...)
 	if err != nil {
 		slog.Error("error listing sessions by therapist", "error", err)
 		return nil, ErrFailedToGetSession
@@ -322,15 +394,15 @@ func (r *SessionRepository) ListSessionsByClient(clientID domain.ClientID) ([]*d
 	}
 
 	query := `
-		SELECT id, regular_booking_id, adhoc_booking_id, therapist_id, client_id,
+		SELECT id, COALESCE(regular_booking_id, ''), COALESCE(adhoc_booking_id, ''), therapist_id, client_id,
 		       start_time, paid_amount, duration_minutes, language, state, notes, 
-		       meeting_url, client_timezone_offset, created_at, updated_at
+		       meeting_url, client_timezone_offset, currency, created_at, updated_at
 		FROM sessions
 		WHERE client_id = ?
 		ORDER BY start_time ASC
 	`
 
-	rows, err := r.db.Query(query, clientID)
+	rows, err := r.db.Reader().Query(query, clientID)
 	if err != nil {
 		slog.Error("error listing sessions by client", "error", err)
 		return nil, ErrFailedToGetSession
@@ -340,25 +412,71 @@ func (r *SessionRepository) ListSessionsByClient(clientID domain.ClientID) ([]*d
 	return r.scanSessions(rows)
 }
 
-// ListSessionsAdmin lists all sessions within a date range for admin purposes
-func (r *SessionRepository) ListSessionsAdmin(startDate, endDate time.Time) ([]*domain.Session, error) {
-	// Validate date range
+// ListSessionsAdmin lists all sessions within a date range for admin purposes,
+// optionally narrowed down to those updated within [updatedFrom, updatedTo]
+func (r *SessionRepository) ListSessionsAdmin(clinicID domain.ClinicID, startDate, endDate, updatedFrom, updatedTo time.Time) ([]*domain.Session, error) {
+	// Validate date ranges
 	if startDate.After(endDate) {
 		return nil, ErrInvalidDateRange
 	}
+	if !updatedFrom.IsZero() && !updatedTo.IsZero() && updatedFrom.After(updatedTo) {
+		return nil, ErrInvalidDateRange
+	}
+
+	conditions := []string{"start_time >= ?", "start_time <= ?"}
+	args := []interface{}{startDate, endDate}
+	if !updatedFrom.IsZero() {
+		conditions = append(conditions, "updated_at >= ?")
+		args = append(args, updatedFrom.UTC())
+	}
+	if !updatedTo.IsZero() {
+		conditions = append(conditions, "updated_at <= ?")
+		args = append(args, updatedTo.UTC())
+	}
+
+	clinicFilter, clinicArgs := db.ClinicFilter(clinicID)
+	args = append(args, clinicArgs...)
+
+	query := fmt.Sprintf(`
+		SELECT id, COALESCE(regular_booking_id, ''), COALESCE(adhoc_booking_id, ''), therapist_id, client_id,
+		       start_time, paid_amount, duration_minutes, language, state, notes, 
+		       meeting_url, client_timezone_offset, currency, created_at, updated_at
+		FROM sessions
+		WHERE %s%s
+		ORDER BY start_time ASC
+	`, strings.Join(conditions, " AND "), clinicFilter)
+
+	rows, err := r.db.Reader().Query(query, args...)
+	if err != nil {
+		slog.Error("error listing sessions for admin", "error", err)
+		return nil, ErrFailedToGetSession
+	}
+	defer rows.Close()
+
+	return r.scanSessions(rows)
+}
 
+func (r *SessionRepository) ListMissingMeetingURL(clinicID domain.ClinicID, startDate, endDate time.Time) ([]*domain.Session, error) {
+	if startDate.After(endDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	clinicFilter, clinicArgs := db.ClinicFilter(clinicID)
 	query := `
-		SELECT id, regular_booking_id, adhoc_booking_id, therapist_id, client_id,
+		SELECT id, COALESCE(regular_booking_id, ''), COALESCE(adhoc_booking_id, ''), therapist_id, client_id,
 		       start_time, paid_amount, duration_minutes, language, state, notes, 
-		       meeting_url, client_timezone_offset, created_at, updated_at
+		       COALESCE(meeting_url, ''), client_timezone_offset, currency, created_at, updated_at
 		FROM sessions
 		WHERE start_time >= ? AND start_time <= ?
+		  AND state = ?
+		  AND (meeting_url IS NULL OR meeting_url = '')` + clinicFilter + `
 		ORDER BY start_time ASC
 	`
+	args := append([]interface{}{startDate.UTC(), endDate.UTC(), domain.SessionStatePlanned}, clinicArgs...)
 
-	rows, err := r.db.Query(query, startDate, endDate)
+	rows, err := r.db.Reader().Query(query, args...)
 	if err != nil {
-		slog.Error("error listing sessions for admin", "error", err)
+		slog.Error("error listing sessions missing meeting url", "error", err)
 		return nil, ErrFailedToGetSession
 	}
 	defer rows.Close()
@@ -366,6 +484,107 @@ func (r *SessionRepository) ListSessionsAdmin(startDate, endDate time.Time) ([]*
 	return r.scanSessions(rows)
 }
 
+// CancelPlannedByBookingIDs cancels the planned sessions created from the
+// given bookings, so they don't outlive their cancelled booking
+func (r *SessionRepository) CancelPlannedByBookingIDs(
+	tx ports.SQLTx,
+	bookingIDs []domain.BookingID,
+	adhocBookingIDs []domain.AdhocBookingID,
+	updatedAt time.Time,
+) error {
+	if len(bookingIDs) == 0 && len(adhocBookingIDs) == 0 {
+		return nil
+	}
+
+	// An empty IN () list is not valid SQL, match nothing instead
+	regularPlaceholders := []string{"NULL"}
+	adhocPlaceholders := []string{"NULL"}
+	values := []any{domain.SessionStateCancelled, updatedAt, domain.SessionStatePlanned}
+	if len(bookingIDs) > 0 {
+		regularPlaceholders = regularPlaceholders[:0]
+		for _, id := range bookingIDs {
+			regularPlaceholders = append(regularPlaceholders, "?")
+			values = append(values, id)
+		}
+	}
+	if len(adhocBookingIDs) > 0 {
+		adhocPlaceholders = adhocPlaceholders[:0]
+		for _, id := range adhocBookingIDs {
+			adhocPlaceholders = append(adhocPlaceholders, "?")
+			values = append(values, id)
+		}
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE sessions
+		SET state = ?, updated_at = ?
+		WHERE state = ?
+		  AND (regular_booking_id IN (%s) OR adhoc_booking_id IN (%s))
+	`, strings.Join(regularPlaceholders, ","), strings.Join(adhocPlaceholders, ","))
+
+	if _, err := tx.Exec(query, values...); err != nil {
+		slog.Error("error cancelling sessions of cancelled bookings", "error", err)
+		return ErrFailedToUpdateSession
+	}
+	return nil
+}
+
+func (r *SessionRepository) ListPlannedStartedBefore(before time.Time) ([]*domain.Session, error) {
+	query := `
+		SELECT id, COALESCE(regular_booking_id, ''), COALESCE(adhoc_booking_id, ''), therapist_id, client_id,
+		       start_time, paid_amount, duration_minutes, language, state, notes, 
+		       COALESCE(meeting_url, ''), client_timezone_offset, currency, created_at, updated_at
+		FROM sessions
+		WHERE start_time < ?
+		  AND state = ?
+		ORDER BY start_time ASC
+	`
+
+	rows, err := r.db.Reader().Query(query, before.UTC(), domain.SessionStatePlanned)
+	if err != nil {
+		slog.Error("error listing planned sessions", "error", err)
+		return nil, ErrFailedToGetSession
+	}
+	defer rows.Close()
+
+	return r.scanSessions(rows)
+}
+
+// MarkNoShow only touches sessions that are still planned, so one moved to
+// done or cancelled in the meantime keeps its state
+func (r *SessionRepository) MarkNoShow(ids []domain.SessionID, updatedAt time.Time) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	placeholders := make([]string, 0, len(ids))
+	values := []any{domain.SessionStateNoShow, updatedAt, domain.SessionStatePlanned}
+	for _, id := range ids {
+		placeholders = append(placeholders, "?")
+		values = append(values, id)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE sessions
+		SET state = ?, updated_at = ?
+		WHERE state = ?
+		  AND id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	result, err := r.db.Exec(query, values...)
+	if err != nil {
+		slog.Error("error marking sessions as no-show", "error", err)
+		return 0, ErrFailedToUpdateSession
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.Error("error getting rows affected after update", "error", err)
+		return 0, ErrFailedToUpdateSession
+	}
+	return int(rowsAffected), nil
+}
+
 // Helper method to scan multiple session rows
 func (r *SessionRepository) scanSessions(rows *sql.Rows) ([]*domain.Session, error) {
 	sessions := make([]*domain.Session, 0)
@@ -385,6 +604,7 @@ func (r *SessionRepository) scanSessions(rows *sql.Rows) ([]*domain.Session, err
 			&session.Notes,
 			&session.MeetingURL,
 			&session.ClientTimezoneOffset,
+			&session.Currency,
 			&session.CreatedAt,
 			&session.UpdatedAt,
 		)
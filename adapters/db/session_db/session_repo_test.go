@@ -0,0 +1,377 @@
+package session_db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+func TestSessionRepositoryListSessionsAdminUpdatedWindow(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewSessionRepository(database)
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+	clientID := dbtest.InsertClient(t, database)
+
+	// Both sessions were last touched a week ago
+	lastWeek := domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, -7))
+	startTime := domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3))
+	sessionIDs := []domain.SessionID{}
+	for i := 0; i < 2; i++ {
+		session := &domain.Session{
+			ID:          domain.NewSessionID(),
+			TherapistID: therapistID,
+			ClientID:    clientID,
+			StartTime:   startTime.Add(time.Duration(i) * time.Hour),
+			Duration:    60,
+			PaidAmount:  5000,
+			Language:    domain.SessionLanguageEnglish,
+			State:       domain.SessionStatePlanned,
+			CreatedAt:   lastWeek,
+			UpdatedAt:   lastWeek,
+		}
+		// Booking IDs are unique per kind, so use one of each
+		if i == 0 {
+			session.RegularBookingID = domain.NewBookingID()
+		} else {
+			session.AdhocBookingID = domain.NewAdhocBookingID()
+		}
+
+		tx, err := database.Begin()
+		if err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		if err := repo.CreateSession(tx, session); err != nil {
+			tx.Rollback()
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Failed to commit session: %v", err)
+		}
+		sessionIDs = append(sessionIDs, session.ID)
+	}
+
+	before := time.Now().UTC().Add(-time.Minute)
+	if err := repo.UpdateSessionNotes(sessionIDs[1], "Follow-up needed"); err != nil {
+		t.Fatalf("Failed to update session notes: %v", err)
+	}
+	after := time.Now().UTC().Add(time.Minute)
+
+	rangeStart := time.Now().UTC()
+	rangeEnd := rangeStart.AddDate(0, 0, 7)
+
+	sessions, err := repo.ListSessionsAdmin("", rangeStart, rangeEnd, before, after)
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("Expected 1 session updated within the window, got %d", len(sessions))
+	}
+	if sessions[0].ID != sessionIDs[1] {
+		t.Errorf("Expected session %s, got %s", sessionIDs[1], sessions[0].ID)
+	}
+
+	// Without the updated window both sessions are returned
+	sessions, err = repo.ListSessionsAdmin("", rangeStart, rangeEnd, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("Expected 2 sessions, got %d", len(sessions))
+	}
+
+	if _, err := repo.ListSessionsAdmin("", rangeStart, rangeEnd, after, before); err != ErrInvalidDateRange {
+		t.Errorf("Expected %v for an inverted updated window, got %v", ErrInvalidDateRange, err)
+	}
+
+	// Sessions belong to their therapist's clinic
+	sessions, err = repo.ListSessionsAdmin("clinic_b", rangeStart, rangeEnd, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("Expected no sessions for another clinic, got %d", len(sessions))
+	}
+}
+
+func TestSessionRepositoryListMissingMeetingURL(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewSessionRepository(database)
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+	clientID := dbtest.InsertClient(t, database)
+
+	now := domain.NewUTCTimestamp()
+	startTime := domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 1))
+	createSession := func(session *domain.Session) domain.SessionID {
+		session.ID = domain.NewSessionID()
+		session.TherapistID = therapistID
+		session.ClientID = clientID
+		session.Duration = 60
+		session.PaidAmount = 5000
+		session.Language = domain.SessionLanguageEnglish
+		session.State = domain.SessionStatePlanned
+		session.CreatedAt = now
+		session.UpdatedAt = now
+
+		tx, err := database.Begin()
+		if err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		if err := repo.CreateSession(tx, session); err != nil {
+			tx.Rollback()
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Failed to commit session: %v", err)
+		}
+		return session.ID
+	}
+
+	// Booking IDs are unique per kind, so use one of each
+	createSession(&domain.Session{
+		RegularBookingID: domain.NewBookingID(),
+		StartTime:        startTime,
+		MeetingURL:       "https://meet.example.com/abc",
+	})
+	missingID := createSession(&domain.Session{
+		AdhocBookingID: domain.NewAdhocBookingID(),
+		StartTime:      startTime.Add(time.Hour),
+	})
+
+	rangeStart := time.Now().UTC()
+	rangeEnd := rangeStart.AddDate(0, 0, 7)
+
+	sessions, err := repo.ListMissingMeetingURL("", rangeStart, rangeEnd)
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("Expected 1 session without a meeting URL, got %d", len(sessions))
+	}
+	if sessions[0].ID != missingID {
+		t.Errorf("Expected session %s, got %s", missingID, sessions[0].ID)
+	}
+
+	if _, err := repo.ListMissingMeetingURL("", rangeEnd, rangeStart); err != ErrInvalidDateRange {
+		t.Errorf("Expected %v for an inverted range, got %v", ErrInvalidDateRange, err)
+	}
+
+	sessions, err = repo.ListMissingMeetingURL("clinic_b", rangeStart, rangeEnd)
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("Expected no sessions for another clinic, got %d", len(sessions))
+	}
+}
+
+func TestSessionRepositoryUniqueBookingID(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewSessionRepository(database)
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+	clientID := dbtest.InsertClient(t, database)
+
+	create := func(regularBookingID domain.BookingID, adhocBookingID domain.AdhocBookingID) error {
+		now := domain.NewUTCTimestamp()
+		tx, err := database.Begin()
+		if err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		err = repo.CreateSession(tx, &domain.Session{
+			ID:               domain.NewSessionID(),
+			RegularBookingID: regularBookingID,
+			AdhocBookingID:   adhocBookingID,
+			TherapistID:      therapistID,
+			ClientID:         clientID,
+			StartTime:        domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3)),
+			Duration:         60,
+			PaidAmount:       5000,
+			Language:         domain.SessionLanguageEnglish,
+			State:            domain.SessionStatePlanned,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}
+
+	regularBookingID := domain.NewBookingID()
+	if err := create(regularBookingID, ""); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	// Sessions of the other kind don't collide on the missing booking id
+	for i := 0; i < 2; i++ {
+		if err := create("", domain.NewAdhocBookingID()); err != nil {
+			t.Fatalf("Failed to create adhoc session: %v", err)
+		}
+	}
+
+	if err := create(regularBookingID, ""); err != ErrSessionAlreadyExists {
+		t.Errorf("Expected %v for a second session of the same booking, got %v", ErrSessionAlreadyExists, err)
+	}
+
+	session, err := repo.GetSessionByRegularBookingID(regularBookingID)
+	if err != nil {
+		t.Fatalf("Failed to get session by booking id: %v", err)
+	}
+	if session == nil || session.RegularBookingID != regularBookingID || session.AdhocBookingID != "" {
+		t.Errorf("Expected the session of booking %s, got %+v", regularBookingID, session)
+	}
+
+	session, err = repo.GetSessionByRegularBookingID(domain.NewBookingID())
+	if err != nil || session != nil {
+		t.Errorf("Expected no session for an unknown booking, got %+v, %v", session, err)
+	}
+}
+
+func TestSessionRepositoryMarkNoShow(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewSessionRepository(database)
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+	clientID := dbtest.InsertClient(t, database)
+
+	now := domain.NewUTCTimestamp()
+	createSession := func(startTime domain.UTCTimestamp, state domain.SessionState) domain.SessionID {
+		session := &domain.Session{
+			ID:               domain.NewSessionID(),
+			RegularBookingID: domain.NewBookingID(),
+			TherapistID:      therapistID,
+			ClientID:         clientID,
+			StartTime:        startTime,
+			Duration:         60,
+			PaidAmount:       5000,
+			Language:         domain.SessionLanguageEnglish,
+			State:            state,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+		tx, err := database.Begin()
+		if err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		if err := repo.CreateSession(tx, session); err != nil {
+			tx.Rollback()
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Failed to commit session: %v", err)
+		}
+		return session.ID
+	}
+
+	yesterday := domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, -1))
+	tomorrow := domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 1))
+	pastID := createSession(yesterday, domain.SessionStatePlanned)
+	doneID := createSession(yesterday, domain.SessionStateDone)
+	createSession(tomorrow, domain.SessionStatePlanned)
+
+	sessions, err := repo.ListPlannedStartedBefore(time.Now().UTC())
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != pastID {
+		t.Fatalf("Expected only the past planned session, got %+v", sessions)
+	}
+
+	marked, err := repo.MarkNoShow([]domain.SessionID{pastID, doneID}, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("Failed to mark sessions: %v", err)
+	}
+	if marked != 1 {
+		t.Errorf("Expected 1 session marked, got %d", marked)
+	}
+
+	for id, want := range map[domain.SessionID]domain.SessionState{
+		pastID: domain.SessionStateNoShow,
+		doneID: domain.SessionStateDone,
+	} {
+		session, err := repo.GetSessionByID(id)
+		if err != nil {
+			t.Fatalf("Failed to get session: %v", err)
+		}
+		if session.State != want {
+			t.Errorf("Expected session %s to be %s, got %s", id, want, session.State)
+		}
+	}
+}
+
+func TestSessionRepositoryListSessionsByTherapistStates(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewSessionRepository(database)
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+	clientID := dbtest.InsertClient(t, database)
+
+	now := domain.NewUTCTimestamp()
+	createSession := func(startTime domain.UTCTimestamp, state domain.SessionState) domain.SessionID {
+		session := &domain.Session{
+			ID:               domain.NewSessionID(),
+			RegularBookingID: domain.NewBookingID(),
+			TherapistID:      therapistID,
+			ClientID:         clientID,
+			StartTime:        startTime,
+			Duration:         60,
+			PaidAmount:       5000,
+			Language:         domain.SessionLanguageEnglish,
+			State:            state,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+		tx, err := database.Begin()
+		if err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		if err := repo.CreateSession(tx, session); err != nil {
+			tx.Rollback()
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Failed to commit session: %v", err)
+		}
+		return session.ID
+	}
+
+	yesterday := domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, -1))
+	tomorrow := domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 1))
+	doneID := createSession(yesterday, domain.SessionStateDone)
+	plannedID := createSession(tomorrow, domain.SessionStatePlanned)
+
+	sessions, err := repo.ListSessionsByTherapist(therapistID, []domain.SessionState{domain.SessionStateDone})
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != doneID {
+		t.Fatalf("Expected only the done session, got %+v", sessions)
+	}
+
+	sessions, err = repo.ListSessionsByTherapist(therapistID, []domain.SessionState{domain.SessionStateDone, domain.SessionStatePlanned})
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != doneID || sessions[1].ID != plannedID {
+		t.Fatalf("Expected both sessions, got %+v", sessions)
+	}
+
+	// Without states every session is returned
+	sessions, err = repo.ListSessionsByTherapist(therapistID, nil)
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("Expected 2 sessions, got %d", len(sessions))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -23,6 +24,8 @@ var ErrSpecializationCreatedAtIsRequired = errors.New("specialization created at
 var ErrSpecializationUpdatedAtIsRequired = errors.New("specialization updated at is required")
 var ErrSpecializationIDIsRequired = errors.New("specialization id is required")
 var ErrFailedToGetSpecializations = errors.New("failed to get specializations")
+var ErrFailedToReassignSpecialization = errors.New("failed to reassign specialization")
+var ErrFailedToDeleteSpecialization = errors.New("failed to delete specialization")
 
 func NewSpecializationRepository(db ports.SQLDatabase) ports.SpecializationRepository {
 	return &SpecializationRepository{db: db}
@@ -46,13 +49,14 @@ func (r *SpecializationRepository) Create(specialization *specialization.Special
 	}
 
 	query := `
-		INSERT INTO specializations (id, name, created_at, updated_at)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO specializations (id, name, clinic_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
 	`
 	_, err := r.db.Exec(
 		query,
 		specialization.ID,
 		specialization.Name,
+		specialization.ClinicID.OrDefault(),
 		specialization.CreatedAt,
 		specialization.UpdatedAt,
 	)
@@ -69,7 +73,7 @@ func (r *SpecializationRepository) BulkGetByIds(ids []domain.SpecializationID) (
 		return nil, nil
 	}
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, clinic_id, created_at, updated_at
 		FROM specializations
 		WHERE id IN (%s)
 	`
@@ -94,6 +98,7 @@ func (r *SpecializationRepository) BulkGetByIds(ids []domain.SpecializationID) (
 		err := rows.Scan(
 			&specialization.ID,
 			&specialization.Name,
+			&specialization.ClinicID,
 			&specialization.CreatedAt,
 			&specialization.UpdatedAt,
 		)
@@ -108,7 +113,7 @@ func (r *SpecializationRepository) BulkGetByIds(ids []domain.SpecializationID) (
 
 func (r *SpecializationRepository) GetByID(id domain.SpecializationID) (*specialization.Specialization, error) {
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, clinic_id, created_at, updated_at
 		FROM specializations
 		WHERE id = ?
 	`
@@ -117,6 +122,7 @@ func (r *SpecializationRepository) GetByID(id domain.SpecializationID) (*special
 	err := row.Scan(
 		&specialization.ID,
 		&specialization.Name,
+		&specialization.ClinicID,
 		&specialization.CreatedAt,
 		&specialization.UpdatedAt,
 	)
@@ -130,17 +136,19 @@ func (r *SpecializationRepository) GetByID(id domain.SpecializationID) (*special
 	return specialization, nil
 }
 
-func (r *SpecializationRepository) GetByName(name string) (*specialization.Specialization, error) {
+// GetByName looks the name up among the clinic's specializations
+func (r *SpecializationRepository) GetByName(clinicID domain.ClinicID, name string) (*specialization.Specialization, error) {
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, clinic_id, created_at, updated_at
 		FROM specializations
-		WHERE name = ?
+		WHERE clinic_id = ? AND name = ?
 	`
-	row := r.db.QueryRow(query, name)
+	row := r.db.QueryRow(query, clinicID.OrDefault(), name)
 	specialization := &specialization.Specialization{}
 	err := row.Scan(
 		&specialization.ID,
 		&specialization.Name,
+		&specialization.ClinicID,
 		&specialization.CreatedAt,
 		&specialization.UpdatedAt,
 	)
@@ -154,13 +162,16 @@ func (r *SpecializationRepository) GetByName(name string) (*specialization.Speci
 	return specialization, nil
 }
 
-func (r *SpecializationRepository) GetAll() ([]*specialization.Specialization, error) {
+// GetAll returns the clinic's specializations. An empty clinic lists every
+// clinic.
+func (r *SpecializationRepository) GetAll(clinicID domain.ClinicID) ([]*specialization.Specialization, error) {
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, clinic_id, created_at, updated_at
 		FROM specializations
+		WHERE ? = '' OR clinic_id = ?
 		ORDER BY name ASC
 	`
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Reader().Query(query, clinicID, clinicID)
 	if err != nil {
 		slog.Error("error getting all specializations", "error", err)
 		return nil, ErrFailedToGetSpecializations
@@ -173,6 +184,7 @@ func (r *SpecializationRepository) GetAll() ([]*specialization.Specialization, e
 		err := rows.Scan(
 			&specialization.ID,
 			&specialization.Name,
+			&specialization.ClinicID,
 			&specialization.CreatedAt,
 			&specialization.UpdatedAt,
 		)
@@ -184,3 +196,138 @@ func (r *SpecializationRepository) GetAll() ([]*specialization.Specialization, e
 	}
 	return specializations, nil
 }
+
+func (r *SpecializationRepository) GetAllWithTherapistCounts(clinicID domain.ClinicID) ([]*ports.SpecializationTherapistCount, error) {
+	query := `
+		SELECT s.id, s.name, s.clinic_id, s.created_at, s.updated_at, COUNT(ts.therapist_id)
+		FROM specializations s
+		LEFT JOIN therapist_specializations ts ON ts.specialization_id = s.id
+		WHERE ? = '' OR s.clinic_id = ?
+		GROUP BY s.id, s.name, s.clinic_id, s.created_at, s.updated_at
+		ORDER BY s.name ASC
+	`
+	rows, err := r.db.Reader().Query(query, clinicID, clinicID)
+	if err != nil {
+		slog.Error("error getting specialization therapist counts", "error", err)
+		return nil, ErrFailedToGetSpecializations
+	}
+	defer rows.Close()
+
+	counts := make([]*ports.SpecializationTherapistCount, 0)
+	for rows.Next() {
+		count := &ports.SpecializationTherapistCount{}
+		err := rows.Scan(
+			&count.ID,
+			&count.Name,
+			&count.ClinicID,
+			&count.CreatedAt,
+			&count.UpdatedAt,
+			&count.TherapistCount,
+		)
+		if err != nil {
+			slog.Error("error scanning specialization therapist count", "error", err)
+			return nil, ErrFailedToGetSpecializations
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
+func (r *SpecializationRepository) ListTherapists(id domain.SpecializationID) ([]*ports.SpecializationTherapist, error) {
+	query := `
+		SELECT t.id, t.name, t.bio, t.photo_url, t.speaks_english, COALESCE(GROUP_CONCAT(tl.language_code), '')
+		FROM therapists t
+		JOIN therapist_specializations ts ON ts.therapist_id = t.id
+		LEFT JOIN therapist_languages tl ON tl.therapist_id = t.id
+		WHERE ts.specialization_id = ?
+		GROUP BY t.id, t.name, t.bio, t.photo_url, t.speaks_english
+		ORDER BY t.name ASC, t.id ASC
+	`
+	rows, err := r.db.Reader().Query(query, id)
+	if err != nil {
+		slog.Error("error listing specialization therapists", "error", err)
+		return nil, ErrFailedToGetSpecializations
+	}
+	defer rows.Close()
+
+	therapists := make([]*ports.SpecializationTherapist, 0)
+	for rows.Next() {
+		therapist := &ports.SpecializationTherapist{}
+		var languages string
+		err := rows.Scan(
+			&therapist.ID,
+			&therapist.Name,
+			&therapist.Bio,
+			&therapist.PhotoURL,
+			&therapist.SpeaksEnglish,
+			&languages,
+		)
+		if err != nil {
+			slog.Error("error scanning specialization therapist", "error", err)
+			return nil, ErrFailedToGetSpecializations
+		}
+
+		// GROUP_CONCAT does not guarantee an order
+		therapist.Languages = make([]domain.LanguageCode, 0)
+		if languages != "" {
+			for _, code := range strings.Split(languages, ",") {
+				therapist.Languages = append(therapist.Languages, domain.LanguageCode(code))
+			}
+			slices.Sort(therapist.Languages)
+		}
+		therapists = append(therapists, therapist)
+	}
+	return therapists, nil
+}
+
+func (r *SpecializationRepository) ReassignTherapistsTx(sqlExec ports.SQLExec, from, to domain.SpecializationID) (int, error) {
+	// Therapists already offering the target would break the unique link
+	dropped, err := sqlExec.Exec(`
+		DELETE FROM therapist_specializations
+		WHERE specialization_id = ?
+		AND therapist_id IN (
+			SELECT therapist_id FROM therapist_specializations WHERE specialization_id = ?
+		)
+	`, from, to)
+	if err != nil {
+		slog.Error("error dropping duplicate specialization links", "error", err)
+		return 0, ErrFailedToReassignSpecialization
+	}
+
+	moved, err := sqlExec.Exec(`
+		UPDATE therapist_specializations
+		SET specialization_id = ?, updated_at = ?
+		WHERE specialization_id = ?
+	`, to, domain.NewUTCTimestamp(), from)
+	if err != nil {
+		slog.Error("error reassigning specialization links", "error", err)
+		return 0, ErrFailedToReassignSpecialization
+	}
+
+	droppedCount, err := dropped.RowsAffected()
+	if err != nil {
+		return 0, ErrFailedToReassignSpecialization
+	}
+	movedCount, err := moved.RowsAffected()
+	if err != nil {
+		return 0, ErrFailedToReassignSpecialization
+	}
+	return int(droppedCount + movedCount), nil
+}
+
+func (r *SpecializationRepository) DeleteTx(sqlExec ports.SQLExec, id domain.SpecializationID) error {
+	result, err := sqlExec.Exec(`DELETE FROM specializations WHERE id = ?`, id)
+	if err != nil {
+		slog.Error("error deleting specialization", "error", err)
+		return ErrFailedToDeleteSpecialization
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return ErrFailedToDeleteSpecialization
+	}
+	if rowsAffected == 0 {
+		return ErrSpecializationNotFound
+	}
+	return nil
+}
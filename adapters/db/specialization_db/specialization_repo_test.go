@@ -0,0 +1,149 @@
+package specialization_db
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+)
+
+func TestSpecializationRepositoryGetAllWithTherapistCounts(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewSpecializationRepository(database)
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+
+	createSpecialization := func(name string) *specialization.Specialization {
+		now := domain.NewUTCTimestamp()
+		created := &specialization.Specialization{
+			ID:        domain.NewSpecializationID(),
+			Name:      name,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := repo.Create(created); err != nil {
+			t.Fatalf("Failed to create specialization: %v", err)
+		}
+		return created
+	}
+
+	anxiety := createSpecialization("Anxiety")
+	depression := createSpecialization("Depression")
+	grief := createSpecialization("Grief")
+
+	createTherapist := func(name string, email domain.Email, phone domain.PhoneNumber, specializationIDs ...domain.SpecializationID) {
+		now := domain.NewUTCTimestamp()
+		specializations := make([]specialization.Specialization, len(specializationIDs))
+		for i, id := range specializationIDs {
+			specializations[i] = specialization.Specialization{ID: id}
+		}
+		created := &therapist.Therapist{
+			ID:              domain.NewTherapistID(),
+			Name:            name,
+			Email:           email,
+			PhoneNumber:     phone,
+			WhatsAppNumber:  domain.WhatsAppNumber(phone),
+			Specializations: specializations,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := therapistRepo.Create(created); err != nil {
+			t.Fatalf("Failed to create therapist: %v", err)
+		}
+	}
+
+	createTherapist("Dr. First", "first@example.com", "+1555000401", anxiety.ID, depression.ID)
+	createTherapist("Dr. Second", "second@example.com", "+1555000402", anxiety.ID)
+	createTherapist("Dr. Third", "third@example.com", "+1555000403", anxiety.ID)
+
+	counts, err := repo.GetAllWithTherapistCounts("")
+	if err != nil {
+		t.Fatalf("Failed to get specialization counts: %v", err)
+	}
+
+	expected := []struct {
+		id    domain.SpecializationID
+		count int
+	}{
+		{anxiety.ID, 3},
+		{depression.ID, 1},
+		{grief.ID, 0},
+	}
+	if len(counts) != len(expected) {
+		t.Fatalf("Expected %d specializations, got %d", len(expected), len(counts))
+	}
+	for i, want := range expected {
+		if counts[i].ID != want.id {
+			t.Errorf("Expected specialization %s at position %d, got %s", want.id, i, counts[i].ID)
+		}
+		if counts[i].TherapistCount != want.count {
+			t.Errorf("Expected %s to have %d therapists, got %d", counts[i].Name, want.count, counts[i].TherapistCount)
+		}
+	}
+}
+
+func TestSpecializationRepositoryListTherapists(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewSpecializationRepository(database)
+	therapistRepo := therapist_db.NewTherapistRepository(database)
+
+	now := domain.NewUTCTimestamp()
+	anxiety := &specialization.Specialization{ID: domain.NewSpecializationID(), Name: "Anxiety", CreatedAt: now, UpdatedAt: now}
+	grief := &specialization.Specialization{ID: domain.NewSpecializationID(), Name: "Grief", CreatedAt: now, UpdatedAt: now}
+	for _, created := range []*specialization.Specialization{anxiety, grief} {
+		if err := repo.Create(created); err != nil {
+			t.Fatalf("Failed to create specialization: %v", err)
+		}
+	}
+
+	createTherapist := func(name string, email domain.Email, phone domain.PhoneNumber, languages []domain.LanguageCode, specializationID domain.SpecializationID) domain.TherapistID {
+		created := &therapist.Therapist{
+			ID:              domain.NewTherapistID(),
+			Name:            name,
+			Email:           email,
+			PhoneNumber:     phone,
+			WhatsAppNumber:  domain.WhatsAppNumber(phone),
+			Languages:       languages,
+			Bio:             name + " bio",
+			Specializations: []specialization.Specialization{{ID: specializationID}},
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := therapistRepo.Create(created); err != nil {
+			t.Fatalf("Failed to create therapist: %v", err)
+		}
+		return created.ID
+	}
+
+	// Created out of name order to check the ordering
+	zaina := createTherapist("Dr. Zaina", "zaina@example.com", "+1555000501", []domain.LanguageCode{"en", "ar"}, anxiety.ID)
+	adam := createTherapist("Dr. Adam", "adam@example.com", "+1555000502", []domain.LanguageCode{"ar"}, anxiety.ID)
+	createTherapist("Dr. Other", "other@example.com", "+1555000503", []domain.LanguageCode{"ar"}, grief.ID)
+
+	therapists, err := repo.ListTherapists(anxiety.ID)
+	if err != nil {
+		t.Fatalf("Failed to list specialization therapists: %v", err)
+	}
+
+	if len(therapists) != 2 {
+		t.Fatalf("Expected 2 therapists, got %d", len(therapists))
+	}
+	if therapists[0].ID != adam || therapists[1].ID != zaina {
+		t.Errorf("Expected therapists ordered by name [%s %s], got [%s %s]", adam, zaina, therapists[0].ID, therapists[1].ID)
+	}
+	if therapists[0].Bio != "Dr. Adam bio" {
+		t.Errorf("Expected bio %q, got %q", "Dr. Adam bio", therapists[0].Bio)
+	}
+	if got := therapists[1].Languages; len(got) != 2 || got[0] != "ar" || got[1] != "en" {
+		t.Errorf("Expected languages [ar en], got %v", got)
+	}
+	if !therapists[1].SpeaksEnglish || therapists[0].SpeaksEnglish {
+		t.Errorf("Expected only %s to speak English", zaina)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -29,6 +30,7 @@ var ErrFailedToGetTherapists = errors.New("failed to get therapists")
 var ErrFailedToCreateTherapist = errors.New("failed to create therapist")
 var ErrFailedToUpdateTherapist = errors.New("failed to update therapist")
 var ErrFailedToUpdateTherapistSpecializations = errors.New("failed to update therapist specializations")
+var ErrFailedToUpdateTherapistLanguages = errors.New("failed to update therapist languages")
 var ErrDeviceIDIsRequired = errors.New("device id is required")
 
 func NewTherapistRepository(db ports.SQLDatabase) ports.TherapistRepository {
@@ -56,6 +58,8 @@ func (r *TherapistRepository) Create(therapist *therapist.Therapist) error {
 		return ErrTherapistUpdatedAtIsRequired
 	}
 
+	languages := storedLanguages(therapist)
+
 	tx, err := r.db.Begin()
 	if err != nil {
 		slog.Error("error beginning create therapist transaction", "error", err)
@@ -64,8 +68,8 @@ func (r *TherapistRepository) Create(therapist *therapist.Therapist) error {
 
 	// Insert therapist
 	query := `
-		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO therapists (id, name, email, phone_number, whatsapp_number, speaks_english, bio, photo_url, default_language, clinic_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err = tx.Exec(
 		query,
@@ -74,7 +78,11 @@ func (r *TherapistRepository) Create(therapist *therapist.Therapist) error {
 		therapist.Email,
 		therapist.PhoneNumber,
 		therapist.WhatsAppNumber,
-		therapist.SpeaksEnglish,
+		slices.Contains(languages, domain.LanguageCodeEnglish),
+		therapist.Bio,
+		therapist.PhotoURL,
+		therapist.DefaultLanguage,
+		therapist.ClinicID.OrDefault(),
 		therapist.CreatedAt,
 		therapist.UpdatedAt,
 	)
@@ -97,6 +105,14 @@ func (r *TherapistRepository) Create(therapist *therapist.Therapist) error {
 		return ErrFailedToCreateTherapist
 	}
 
+	// Insert languages
+	err = r.insertTherapistLanguages(tx, therapist.ID, languages)
+	if err != nil {
+		tx.Rollback()
+		slog.Error("error inserting therapist languages", "error", err)
+		return ErrFailedToCreateTherapist
+	}
+
 	if err := tx.Commit(); err != nil {
 		slog.Error("error committing create therapist transaction", "error", err)
 		return ErrFailedToCreateTherapist
@@ -105,6 +121,8 @@ func (r *TherapistRepository) Create(therapist *therapist.Therapist) error {
 	return nil
 }
 
+// Update saves the therapist's info. Languages are replaced through
+// UpdateLanguages; here only English follows the SpeaksEnglish flag.
 func (r *TherapistRepository) Update(therapist *therapist.Therapist) error {
 	if therapist.ID == "" {
 		return ErrTherapistIDIsRequired
@@ -122,36 +140,72 @@ func (r *TherapistRepository) Update(therapist *therapist.Therapist) error {
 		return ErrTherapistUpdatedAtIsRequired
 	}
 
+	tx, err := r.db.Begin()
+	if err != nil {
+		slog.Error("error beginning update therapist transaction", "error", err)
+		return ErrFailedToUpdateTherapist
+	}
+
 	query := `
 		UPDATE therapists 
-		SET name = ?, email = ?, phone_number = ?, whatsapp_number = ?, speaks_english = ?, updated_at = ?
+		SET name = ?, email = ?, phone_number = ?, whatsapp_number = ?, speaks_english = ?, bio = ?, photo_url = ?, default_language = ?, updated_at = ?
 		WHERE id = ?
 	`
-	result, err := r.db.Exec(
+	result, err := tx.Exec(
 		query,
 		therapist.Name,
 		therapist.Email,
 		therapist.PhoneNumber,
 		therapist.WhatsAppNumber,
 		therapist.SpeaksEnglish,
+		therapist.Bio,
+		therapist.PhotoURL,
+		therapist.DefaultLanguage,
 		therapist.UpdatedAt,
 		therapist.ID,
 	)
 	if err != nil {
+		tx.Rollback()
 		slog.Error("error updating therapist", "error", err)
 		return ErrFailedToUpdateTherapist
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		slog.Error("error getting rows affected after update", "error", err)
 		return ErrFailedToUpdateTherapist
 	}
 
 	if rowsAffected == 0 {
+		tx.Rollback()
 		return ErrTherapistNotFound
 	}
 
+	if therapist.SpeaksEnglish {
+		_, err = tx.Exec(
+			`INSERT OR IGNORE INTO therapist_languages (therapist_id, language_code) VALUES (?, ?)`,
+			therapist.ID,
+			domain.LanguageCodeEnglish,
+		)
+	} else {
+		_, err = tx.Exec(
+			`DELETE FROM therapist_languages WHERE therapist_id = ? AND language_code = ?`,
+			therapist.ID,
+			domain.LanguageCodeEnglish,
+		)
+	}
+	if err != nil {
+		tx.Rollback()
+		slog.Error("error syncing therapist english language", "error", err)
+		return ErrFailedToUpdateTherapist
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.Error("error committing update therapist transaction", "error", err)
+		return ErrFailedToUpdateTherapist
+	}
+
 	return nil
 }
 
@@ -192,6 +246,53 @@ func (r *TherapistRepository) UpdateSpecializations(therapistID domain.Therapist
 	return nil
 }
 
+// UpdateLanguages replaces the therapist's languages and keeps the
+// speaks_english column in sync with them
+func (r *TherapistRepository) UpdateLanguages(therapistID domain.TherapistID, languages []domain.LanguageCode) error {
+	if therapistID == "" {
+		return ErrTherapistIDIsRequired
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		slog.Error("error beginning update therapist languages transaction", "error", err)
+		return ErrFailedToUpdateTherapistLanguages
+	}
+
+	_, err = tx.Exec(`DELETE FROM therapist_languages WHERE therapist_id = ?`, therapistID)
+	if err != nil {
+		tx.Rollback()
+		slog.Error("error deleting existing therapist languages", "error", err)
+		return ErrFailedToUpdateTherapistLanguages
+	}
+
+	err = r.insertTherapistLanguages(tx, therapistID, languages)
+	if err != nil {
+		tx.Rollback()
+		slog.Error("error inserting new therapist languages", "error", err)
+		return ErrFailedToUpdateTherapistLanguages
+	}
+
+	_, err = tx.Exec(
+		`UPDATE therapists SET speaks_english = ?, updated_at = ? WHERE id = ?`,
+		slices.Contains(languages, domain.LanguageCodeEnglish),
+		domain.NewUTCTimestamp(),
+		therapistID,
+	)
+	if err != nil {
+		tx.Rollback()
+		slog.Error("error updating therapist speaks english", "error", err)
+		return ErrFailedToUpdateTherapistLanguages
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.Error("error committing transaction", "error", err)
+		return ErrFailedToUpdateTherapistLanguages
+	}
+
+	return nil
+}
+
 func (r *TherapistRepository) UpdateDevice(therapistID domain.TherapistID, deviceID domain.DeviceID, deviceIDUpdatedAt domain.UTCTimestamp) error {
 	if therapistID == "" {
 		return ErrTherapistIDIsRequired
@@ -275,9 +376,69 @@ func (r *TherapistRepository) UpdateTimezoneOffset(therapistID domain.TherapistI
 	return nil
 }
 
+func (r *TherapistRepository) GetNotificationPreferences(therapistID domain.TherapistID) (therapist.NotificationPreferences, error) {
+	query := `SELECT notification_preferences FROM therapists WHERE id = ? LIMIT 1`
+	row := r.db.QueryRow(query, therapistID)
+	var preferences therapist.NotificationPreferences
+	err := row.Scan(&preferences)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return therapist.NotificationPreferences{}, ErrTherapistNotFound
+		}
+		slog.Error("error getting therapist notification preferences", "error", err)
+		return therapist.NotificationPreferences{}, ErrFailedToGetTherapists
+	}
+	return preferences, nil
+}
+
+func (r *TherapistRepository) UpdateNotificationPreferences(therapistID domain.TherapistID, preferences therapist.NotificationPreferences) error {
+	if therapistID == "" {
+		return ErrTherapistIDIsRequired
+	}
+
+	query := `UPDATE therapists SET notification_preferences = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, preferences, domain.NewUTCTimestamp(), therapistID)
+	if err != nil {
+		slog.Error("error updating therapist notification preferences", "error", err)
+		return ErrFailedToUpdateTherapist
+	}
+
+	return nil
+}
+
+func (r *TherapistRepository) GetMaxDailySessions(therapistID domain.TherapistID) (int, error) {
+	query := `SELECT max_daily_sessions FROM therapists WHERE id = ? LIMIT 1`
+	row := r.db.QueryRow(query, therapistID)
+	var maxDailySessions int
+	err := row.Scan(&maxDailySessions)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrTherapistNotFound
+		}
+		slog.Error("error getting therapist max daily sessions", "error", err)
+		return 0, ErrFailedToGetTherapists
+	}
+	return maxDailySessions, nil
+}
+
+func (r *TherapistRepository) UpdateMaxDailySessions(therapistID domain.TherapistID, maxDailySessions int) error {
+	if therapistID == "" {
+		return ErrTherapistIDIsRequired
+	}
+
+	query := `UPDATE therapists SET max_daily_sessions = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, maxDailySessions, domain.NewUTCTimestamp(), therapistID)
+	if err != nil {
+		slog.Error("error updating therapist max daily sessions", "error", err)
+		return ErrFailedToUpdateTherapist
+	}
+
+	return nil
+}
+
 func (r *TherapistRepository) GetByID(id domain.TherapistID) (*therapist.Therapist, error) {
 	query := `
-		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, created_at, updated_at
+		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, bio, photo_url, default_language, clinic_id, created_at, updated_at
 		FROM therapists
 		WHERE id = ?
 	`
@@ -293,6 +454,10 @@ func (r *TherapistRepository) GetByID(id domain.TherapistID) (*therapist.Therapi
 		&therapist.SpeaksEnglish,
 		&deviceID,
 		&therapist.TimezoneOffset,
+		&therapist.Bio,
+		&therapist.PhotoURL,
+		&therapist.DefaultLanguage,
+		&therapist.ClinicID,
 		&therapist.CreatedAt,
 		&therapist.UpdatedAt,
 	)
@@ -315,17 +480,22 @@ func (r *TherapistRepository) GetByID(id domain.TherapistID) (*therapist.Therapi
 	}
 
 	therapist.Specializations = specializations[id]
+
+	if err := r.attachLanguages(therapist); err != nil {
+		return nil, err
+	}
 	return therapist, nil
 }
 
 func (r *TherapistRepository) GetByEmail(email domain.Email) (*therapist.Therapist, error) {
 	query := `
-		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, timezone_offset, created_at, updated_at
+		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, bio, photo_url, default_language, clinic_id, created_at, updated_at
 		FROM therapists
 		WHERE email = ?
 	`
 	row := r.db.QueryRow(query, email)
 	therapist := &therapist.Therapist{}
+	var deviceID sql.NullString
 	err := row.Scan(
 		&therapist.ID,
 		&therapist.Name,
@@ -333,8 +503,12 @@ func (r *TherapistRepository) GetByEmail(email domain.Email) (*therapist.Therapi
 		&therapist.PhoneNumber,
 		&therapist.WhatsAppNumber,
 		&therapist.SpeaksEnglish,
-		&therapist.DeviceID,
+		&deviceID,
 		&therapist.TimezoneOffset,
+		&therapist.Bio,
+		&therapist.PhotoURL,
+		&therapist.DefaultLanguage,
+		&therapist.ClinicID,
 		&therapist.CreatedAt,
 		&therapist.UpdatedAt,
 	)
@@ -346,6 +520,10 @@ func (r *TherapistRepository) GetByEmail(email domain.Email) (*therapist.Therapi
 		return nil, ErrFailedToGetTherapists
 	}
 
+	if deviceID.Valid {
+		therapist.DeviceID = domain.DeviceID(deviceID.String)
+	}
+
 	// Load specializations
 	specializations, err := r.bulkGetTherapistSpecializations([]domain.TherapistID{therapist.ID})
 	if err != nil {
@@ -353,17 +531,25 @@ func (r *TherapistRepository) GetByEmail(email domain.Email) (*therapist.Therapi
 	}
 
 	therapist.Specializations = specializations[therapist.ID]
+
+	if err := r.attachLanguages(therapist); err != nil {
+		return nil, err
+	}
 	return therapist, nil
 }
 
+// GetByWhatsAppNumber matches the number with or without its leading "+".
+// Numbers are stored in E.164, but older rows may lack the "+".
 func (r *TherapistRepository) GetByWhatsAppNumber(whatsappNumber domain.WhatsAppNumber) (*therapist.Therapist, error) {
 	query := `
-		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, created_at, updated_at
+		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, bio, photo_url, default_language, clinic_id, created_at, updated_at
 		FROM therapists
-		WHERE whatsapp_number = ?
+		WHERE whatsapp_number IN (?, ?)
+		LIMIT 1
 	`
-	row := r.db.QueryRow(query, whatsappNumber)
+	row := r.db.QueryRow(query, whatsappNumber.Normalize(), whatsappNumber.Digits())
 	therapist := &therapist.Therapist{}
+	var deviceID sql.NullString
 	err := row.Scan(
 		&therapist.ID,
 		&therapist.Name,
@@ -371,8 +557,12 @@ func (r *TherapistRepository) GetByWhatsAppNumber(whatsappNumber domain.WhatsApp
 		&therapist.PhoneNumber,
 		&therapist.WhatsAppNumber,
 		&therapist.SpeaksEnglish,
-		&therapist.DeviceID,
+		&deviceID,
 		&therapist.TimezoneOffset,
+		&therapist.Bio,
+		&therapist.PhotoURL,
+		&therapist.DefaultLanguage,
+		&therapist.ClinicID,
 		&therapist.CreatedAt,
 		&therapist.UpdatedAt,
 	)
@@ -384,6 +574,10 @@ func (r *TherapistRepository) GetByWhatsAppNumber(whatsappNumber domain.WhatsApp
 		return nil, ErrFailedToGetTherapists
 	}
 
+	if deviceID.Valid {
+		therapist.DeviceID = domain.DeviceID(deviceID.String)
+	}
+
 	// Load specializations
 	specializations, err := r.bulkGetTherapistSpecializations([]domain.TherapistID{therapist.ID})
 	if err != nil {
@@ -391,6 +585,10 @@ func (r *TherapistRepository) GetByWhatsAppNumber(whatsappNumber domain.WhatsApp
 	}
 
 	therapist.Specializations = specializations[therapist.ID]
+
+	if err := r.attachLanguages(therapist); err != nil {
+		return nil, err
+	}
 	return therapist, nil
 }
 
@@ -401,13 +599,15 @@ func (r *TherapistRepository) Delete(id domain.TherapistID) error {
 	return err
 }
 
-func (r *TherapistRepository) List() ([]*therapist.Therapist, error) {
+// List returns the clinic's therapists. An empty clinic lists every clinic.
+func (r *TherapistRepository) List(clinicID domain.ClinicID) ([]*therapist.Therapist, error) {
 	query := `
-		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, created_at, updated_at
+		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, bio, photo_url, default_language, clinic_id, created_at, updated_at
 		FROM therapists
+		WHERE ? = '' OR clinic_id = ?
 		ORDER BY name ASC
 	`
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Reader().Query(query, clinicID, clinicID)
 	if err != nil {
 		slog.Error("error getting all therapists", "error", err)
 		return nil, ErrFailedToGetTherapists
@@ -427,6 +627,10 @@ func (r *TherapistRepository) List() ([]*therapist.Therapist, error) {
 			&therapist.SpeaksEnglish,
 			&deviceID,
 			&therapist.TimezoneOffset,
+			&therapist.Bio,
+			&therapist.PhotoURL,
+			&therapist.DefaultLanguage,
+			&therapist.ClinicID,
 			&therapist.CreatedAt,
 			&therapist.UpdatedAt,
 		)
@@ -449,12 +653,80 @@ func (r *TherapistRepository) List() ([]*therapist.Therapist, error) {
 		therapists = append(therapists, therapist)
 	}
 
+	if err := r.attachLanguages(therapists...); err != nil {
+		return nil, err
+	}
+
 	return therapists, nil
 }
 
-func (r *TherapistRepository) FindBySpecializationAndLanguage(specializationName string, mustSpeakEnglish bool) ([]*therapist.Therapist, error) {
+// FindByDeviceID lists the therapists registered with the given device
+func (r *TherapistRepository) FindByDeviceID(clinicID domain.ClinicID, deviceID domain.DeviceID) ([]*therapist.Therapist, error) {
 	query := `
-	       SELECT DISTINCT t.id, t.name, t.email, t.phone_number, t.whatsapp_number, t.speaks_english, t.device_id, t.timezone_offset, t.created_at, t.updated_at
+		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, bio, photo_url, default_language, clinic_id, created_at, updated_at
+		FROM therapists
+		WHERE device_id = ? AND (? = '' OR clinic_id = ?)
+		ORDER BY name ASC
+	`
+	rows, err := r.db.Query(query, deviceID, clinicID, clinicID)
+	if err != nil {
+		slog.Error("error getting therapists by device id", "error", err)
+		return nil, ErrFailedToGetTherapists
+	}
+	defer rows.Close()
+
+	therapists := make([]*therapist.Therapist, 0)
+	therapistIDs := make([]domain.TherapistID, 0)
+	for rows.Next() {
+		therapist := &therapist.Therapist{}
+		var deviceID sql.NullString
+		err := rows.Scan(
+			&therapist.ID,
+			&therapist.Name,
+			&therapist.Email,
+			&therapist.PhoneNumber,
+			&therapist.WhatsAppNumber,
+			&therapist.SpeaksEnglish,
+			&deviceID,
+			&therapist.TimezoneOffset,
+			&therapist.Bio,
+			&therapist.PhotoURL,
+			&therapist.DefaultLanguage,
+			&therapist.ClinicID,
+			&therapist.CreatedAt,
+			&therapist.UpdatedAt,
+		)
+		if err != nil {
+			slog.Error("error scanning therapist", "error", err)
+			return nil, ErrFailedToGetTherapists
+		}
+		therapist.DeviceID = domain.DeviceID(deviceID.String)
+
+		therapists = append(therapists, therapist)
+		therapistIDs = append(therapistIDs, therapist.ID)
+	}
+
+	specializations, err := r.bulkGetTherapistSpecializations(therapistIDs)
+	if err != nil {
+		return nil, ErrFailedToGetTherapists
+	}
+	for _, therapist := range therapists {
+		therapist.Specializations = specializations[therapist.ID]
+	}
+
+	if err := r.attachLanguages(therapists...); err != nil {
+		return nil, err
+	}
+
+	return therapists, nil
+}
+
+// FindBySpecializationAndLanguage lists the clinic's therapists of the
+// specialization. An empty clinic matches every clinic and an empty language
+// code matches therapists of any language.
+func (r *TherapistRepository) FindBySpecializationAndLanguage(clinicID domain.ClinicID, specializationName string, languageCode domain.LanguageCode) ([]*therapist.Therapist, error) {
+	query := `
+	       SELECT DISTINCT t.id, t.name, t.email, t.phone_number, t.whatsapp_number, t.speaks_english, t.device_id, t.timezone_offset, t.bio, t.photo_url, t.default_language, t.clinic_id, t.created_at, t.updated_at
 	       FROM therapists t
 	       JOIN therapist_specializations ts ON t.id = ts.therapist_id
 	       JOIN specializations s ON ts.specialization_id = s.id
@@ -463,13 +735,19 @@ func (r *TherapistRepository) FindBySpecializationAndLanguage(specializationName
 
 	args := []interface{}{specializationName}
 
-	if mustSpeakEnglish {
-		query += " AND t.speaks_english = TRUE"
+	if clinicID != "" {
+		query += " AND t.clinic_id = ?"
+		args = append(args, clinicID)
+	}
+
+	if languageCode != "" {
+		query += " AND EXISTS (SELECT 1 FROM therapist_languages tl WHERE tl.therapist_id = t.id AND tl.language_code = ?)"
+		args = append(args, languageCode)
 	}
 
 	query += " ORDER BY t.name ASC"
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.Reader().Query(query, args...)
 	if err != nil {
 		slog.Error("error finding therapists by specialization and language", "error", err)
 		return nil, ErrFailedToGetTherapists
@@ -491,6 +769,10 @@ func (r *TherapistRepository) FindBySpecializationAndLanguage(specializationName
 			&therapist.SpeaksEnglish,
 			&deviceID,
 			&therapist.TimezoneOffset,
+			&therapist.Bio,
+			&therapist.PhotoURL,
+			&therapist.DefaultLanguage,
+			&therapist.ClinicID,
 			&therapist.CreatedAt,
 			&therapist.UpdatedAt,
 		)
@@ -517,6 +799,10 @@ func (r *TherapistRepository) FindBySpecializationAndLanguage(specializationName
 		therapist.Specializations = specializations[therapist.ID]
 	}
 
+	if err := r.attachLanguages(therapists...); err != nil {
+		return nil, err
+	}
+
 	return therapists, nil
 }
 
@@ -526,7 +812,7 @@ func (r *TherapistRepository) FindByIDs(therapistIDs []domain.TherapistID) ([]*t
 	}
 
 	query := `
-		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, created_at, updated_at
+		SELECT id, name, email, phone_number, whatsapp_number, speaks_english, device_id, timezone_offset, bio, photo_url, default_language, clinic_id, created_at, updated_at
 		FROM therapists
 		WHERE id IN (%s)
 	`
@@ -560,6 +846,10 @@ func (r *TherapistRepository) FindByIDs(therapistIDs []domain.TherapistID) ([]*t
 			&therapist.SpeaksEnglish,
 			&deviceID,
 			&therapist.TimezoneOffset,
+			&therapist.Bio,
+			&therapist.PhotoURL,
+			&therapist.DefaultLanguage,
+			&therapist.ClinicID,
 			&therapist.CreatedAt,
 			&therapist.UpdatedAt,
 		)
@@ -585,6 +875,10 @@ func (r *TherapistRepository) FindByIDs(therapistIDs []domain.TherapistID) ([]*t
 		therapist.Specializations = specializations[therapist.ID]
 	}
 
+	if err := r.attachLanguages(therapists...); err != nil {
+		return nil, err
+	}
+
 	return therapists, nil
 }
 
@@ -662,3 +956,95 @@ func (r *TherapistRepository) bulkGetTherapistSpecializations(therapistIDs []dom
 	}
 	return specializations, nil
 }
+
+// Helper methods for managing therapist languages
+
+// storedLanguages returns the languages to save for a new therapist, adding
+// English when only the SpeaksEnglish flag was set.
+func storedLanguages(t *therapist.Therapist) []domain.LanguageCode {
+	if t.SpeaksEnglish && !t.Speaks(domain.LanguageCodeEnglish) {
+		return append(slices.Clone(t.Languages), domain.LanguageCodeEnglish)
+	}
+	return t.Languages
+}
+
+func (r *TherapistRepository) insertTherapistLanguages(tx ports.SQLTx, therapistID domain.TherapistID, languages []domain.LanguageCode) error {
+	if len(languages) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0)
+	values := make([]interface{}, 0)
+	for _, language := range languages {
+		placeholders = append(placeholders, "(?, ?)")
+		values = append(values, therapistID, language)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT OR IGNORE INTO therapist_languages (therapist_id, language_code)
+		VALUES %s
+	`, strings.Join(placeholders, ", "))
+
+	_, err := tx.Exec(query, values...)
+	return err
+}
+
+func (r *TherapistRepository) bulkGetTherapistLanguages(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]domain.LanguageCode, error) {
+	languages := make(map[domain.TherapistID][]domain.LanguageCode)
+	if len(therapistIDs) == 0 {
+		return languages, nil
+	}
+
+	query := `
+		SELECT therapist_id, language_code
+		FROM therapist_languages
+		WHERE therapist_id IN (%s)
+		ORDER BY language_code ASC
+	`
+
+	placeholders := make([]string, 0)
+	values := make([]interface{}, 0)
+	for _, therapistID := range therapistIDs {
+		placeholders = append(placeholders, "?")
+		values = append(values, therapistID)
+	}
+
+	query = fmt.Sprintf(query, strings.Join(placeholders, ", "))
+	rows, err := r.db.Query(query, values...)
+	if err != nil {
+		slog.Error("error getting therapist languages", "error", err)
+		return nil, ErrFailedToGetTherapists
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var therapistID domain.TherapistID
+		var language domain.LanguageCode
+		if err := rows.Scan(&therapistID, &language); err != nil {
+			slog.Error("error scanning therapist language", "error", err)
+			return nil, ErrFailedToGetTherapists
+		}
+		languages[therapistID] = append(languages[therapistID], language)
+	}
+
+	return languages, nil
+}
+
+// attachLanguages loads the therapists' languages, which SpeaksEnglish is
+// derived from
+func (r *TherapistRepository) attachLanguages(therapists ...*therapist.Therapist) error {
+	therapistIDs := make([]domain.TherapistID, 0, len(therapists))
+	for _, t := range therapists {
+		therapistIDs = append(therapistIDs, t.ID)
+	}
+
+	languages, err := r.bulkGetTherapistLanguages(therapistIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range therapists {
+		t.SetLanguages(append([]domain.LanguageCode{}, languages[t.ID]...))
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package therapist_db
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/db"
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+func TestTherapistRepositoryFindByDeviceID(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTherapistRepository(database)
+
+	createTherapist := func(name string, email domain.Email, phone domain.PhoneNumber) *therapist.Therapist {
+		now := domain.NewUTCTimestamp()
+		created := &therapist.Therapist{
+			ID:             domain.NewTherapistID(),
+			Name:           name,
+			Email:          email,
+			PhoneNumber:    phone,
+			WhatsAppNumber: domain.WhatsAppNumber(phone),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := repo.Create(created); err != nil {
+			t.Fatalf("Failed to create therapist: %v", err)
+		}
+		return created
+	}
+
+	first := createTherapist("Dr. First", "first@example.com", "+1555000301")
+	second := createTherapist("Dr. Second", "second@example.com", "+1555000302")
+	other := createTherapist("Dr. Other", "other@example.com", "+1555000303")
+
+	const sharedDevice = domain.DeviceID("shared_device")
+	for _, id := range []domain.TherapistID{first.ID, second.ID} {
+		if err := repo.UpdateDevice(id, sharedDevice, domain.NewUTCTimestamp()); err != nil {
+			t.Fatalf("Failed to register device: %v", err)
+		}
+	}
+	if err := repo.UpdateDevice(other.ID, "other_device", domain.NewUTCTimestamp()); err != nil {
+		t.Fatalf("Failed to register device: %v", err)
+	}
+
+	therapists, err := repo.FindByDeviceID("", sharedDevice)
+	if err != nil {
+		t.Fatalf("Failed to find therapists by device: %v", err)
+	}
+	if len(therapists) != 2 {
+		t.Fatalf("Expected 2 therapists sharing the device, got %d", len(therapists))
+	}
+	if therapists[0].ID != first.ID || therapists[1].ID != second.ID {
+		t.Errorf("Expected %s and %s, got %s and %s", first.ID, second.ID, therapists[0].ID, therapists[1].ID)
+	}
+	for _, found := range therapists {
+		if found.DeviceID != sharedDevice {
+			t.Errorf("Expected device %s, got %s", sharedDevice, found.DeviceID)
+		}
+	}
+
+	therapists, err = repo.FindByDeviceID("", "unknown_device")
+	if err != nil {
+		t.Fatalf("Failed to find therapists by device: %v", err)
+	}
+	if len(therapists) != 0 {
+		t.Errorf("Expected no therapists for an unknown device, got %d", len(therapists))
+	}
+
+	// Only the clinic's therapists are returned
+	if _, err := database.Exec(`UPDATE therapists SET clinic_id = 'clinic_b' WHERE id = ?`, second.ID); err != nil {
+		t.Fatalf("Failed to move therapist: %v", err)
+	}
+	therapists, err = repo.FindByDeviceID("clinic_b", sharedDevice)
+	if err != nil {
+		t.Fatalf("Failed to find therapists by device: %v", err)
+	}
+	if len(therapists) != 1 || therapists[0].ID != second.ID {
+		t.Errorf("Expected only %s for clinic_b, got %d therapists", second.ID, len(therapists))
+	}
+}
+
+func TestTherapistRepositoryFindBySpecializationAndLanguage(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	repo := NewTherapistRepository(database)
+
+	specializationID := domain.NewSpecializationID()
+	if _, err := database.Exec(`INSERT INTO specializations (id, name) VALUES (?, ?)`, specializationID, "anxiety"); err != nil {
+		t.Fatalf("Failed to insert specialization: %v", err)
+	}
+
+	createTherapist := func(name string, email domain.Email, phone domain.PhoneNumber, languages ...domain.LanguageCode) *therapist.Therapist {
+		now := domain.NewUTCTimestamp()
+		created := &therapist.Therapist{
+			ID:              domain.NewTherapistID(),
+			Name:            name,
+			Email:           email,
+			PhoneNumber:     phone,
+			WhatsAppNumber:  domain.WhatsAppNumber(phone),
+			Specializations: []specialization.Specialization{{ID: specializationID}},
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		created.SetLanguages(languages)
+		if err := repo.Create(created); err != nil {
+			t.Fatalf("Failed to create therapist: %v", err)
+		}
+		return created
+	}
+
+	arabicOnly := createTherapist("Dr. Arabic", "arabic@example.com", "+1555000401", domain.LanguageCodeArabic)
+	bilingual := createTherapist("Dr. Bilingual", "bilingual@example.com", "+1555000402", domain.LanguageCodeEnglish, domain.LanguageCodeArabic)
+	createTherapist("Dr. English", "english@example.com", "+1555000403", domain.LanguageCodeEnglish)
+
+	therapists, err := repo.FindBySpecializationAndLanguage("", "anxiety", domain.LanguageCodeArabic)
+	if err != nil {
+		t.Fatalf("Failed to find therapists: %v", err)
+	}
+	if len(therapists) != 2 {
+		t.Fatalf("Expected 2 Arabic-speaking therapists, got %d", len(therapists))
+	}
+	if therapists[0].ID != arabicOnly.ID || therapists[1].ID != bilingual.ID {
+		t.Errorf("Expected %s and %s, got %s and %s", arabicOnly.ID, bilingual.ID, therapists[0].ID, therapists[1].ID)
+	}
+	for _, found := range therapists {
+		if !found.Speaks(domain.LanguageCodeArabic) {
+			t.Errorf("Expected %s to speak Arabic, got languages %v", found.Name, found.Languages)
+		}
+	}
+	if therapists[0].SpeaksEnglish || !therapists[1].SpeaksEnglish {
+		t.Errorf("Expected speaksEnglish to follow the language list")
+	}
+
+	// Without a language filter every therapist in the specialization matches
+	therapists, err = repo.FindBySpecializationAndLanguage("", "anxiety", "")
+	if err != nil {
+		t.Fatalf("Failed to find therapists: %v", err)
+	}
+	if len(therapists) != 3 {
+		t.Errorf("Expected 3 therapists without a language filter, got %d", len(therapists))
+	}
+}
+
+// spyReader counts the queries reaching the read connection
+type spyReader struct {
+	ports.SQLExec
+	queries int
+}
+
+func (s *spyReader) Query(query string, args ...any) (*sql.Rows, error) {
+	s.queries++
+	return s.SQLExec.Query(query, args...)
+}
+
+func (s *spyReader) QueryRow(query string, args ...any) *sql.Row {
+	s.queries++
+	return s.SQLExec.QueryRow(query, args...)
+}
+
+func TestTherapistRepositoryListUsesReadConnection(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	reader := &spyReader{SQLExec: database}
+	repo := NewTherapistRepository(db.NewReadRoutedDatabase(database, reader))
+
+	now := domain.NewUTCTimestamp()
+	created := &therapist.Therapist{
+		ID:             domain.NewTherapistID(),
+		Name:           "Dr. Replica",
+		Email:          "replica@example.com",
+		PhoneNumber:    "+1555000501",
+		WhatsAppNumber: "+1555000501",
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := repo.Create(created); err != nil {
+		t.Fatalf("Failed to create therapist: %v", err)
+	}
+	if _, err := repo.GetByID(created.ID); err != nil {
+		t.Fatalf("Failed to get therapist: %v", err)
+	}
+	if reader.queries != 0 {
+		t.Fatalf("Expected writes and lookups to use the primary, got %d read queries", reader.queries)
+	}
+
+	therapists, err := repo.List("")
+	if err != nil {
+		t.Fatalf("Failed to list therapists: %v", err)
+	}
+	if len(therapists) != 1 || therapists[0].ID != created.ID {
+		t.Fatalf("Expected the created therapist to be listed, got %+v", therapists)
+	}
+	if reader.queries == 0 {
+		t.Error("Expected List to query the read connection")
+	}
+}
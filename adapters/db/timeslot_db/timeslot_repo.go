@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
 	"github.com/mishkahtherapy/brain/core/domain/timeslot"
 	"github.com/mishkahtherapy/brain/core/ports"
 )
@@ -38,7 +40,7 @@ func NewTimeSlotRepository(db ports.SQLDatabase) ports.TimeSlotRepository {
 func (r *TimeSlotRepository) GetByID(id domain.TimeSlotID) (*timeslot.TimeSlot, error) {
 	query := `
 		SELECT id, therapist_id, is_active, day_of_week, start_time, duration_minutes,
-		       advance_notice, after_session_break_time, created_at, updated_at
+		       advance_notice, after_session_break_time, timezone, capacity, created_at, updated_at
 		FROM time_slots
 		WHERE id = ?
 	`
@@ -53,6 +55,8 @@ func (r *TimeSlotRepository) GetByID(id domain.TimeSlotID) (*timeslot.TimeSlot,
 		&timeslot.Duration,
 		&timeslot.AdvanceNotice,
 		&timeslot.AfterSessionBreakTime,
+		&timeslot.Timezone,
+		&timeslot.Capacity,
 		&timeslot.CreatedAt,
 		&timeslot.UpdatedAt,
 	)
@@ -63,6 +67,10 @@ func (r *TimeSlotRepository) GetByID(id domain.TimeSlotID) (*timeslot.TimeSlot,
 		slog.Error("error getting timeslot by id", "error", err)
 		return nil, ErrFailedToGetTimeSlots
 	}
+	if _, err := timeslot.Location(); err != nil {
+		slog.Error("timeslot has an invalid timezone", "id", id, "timezone", timeslot.Timezone)
+		return nil, err
+	}
 
 	// Get bookings associated with this timeslot
 	bookingQuery := `
@@ -89,6 +97,10 @@ func (r *TimeSlotRepository) GetByID(id domain.TimeSlotID) (*timeslot.TimeSlot,
 }
 
 func (r *TimeSlotRepository) Create(timeslot *timeslot.TimeSlot) error {
+	return r.CreateTx(r.db, timeslot)
+}
+
+func (r *TimeSlotRepository) CreateTx(sqlExec ports.SQLExec, timeslot *timeslot.TimeSlot) error {
 	// Validate required fields
 	if timeslot.ID == "" {
 		return ErrTimeSlotIDIsRequired
@@ -121,10 +133,10 @@ func (r *TimeSlotRepository) Create(timeslot *timeslot.TimeSlot) error {
 	query := `
 		INSERT INTO time_slots (
 			id, therapist_id, is_active, day_of_week, start_time, duration_minutes,
-			advance_notice, after_session_break_time, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			advance_notice, after_session_break_time, timezone, capacity, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := r.db.Exec(
+	_, err := sqlExec.Exec(
 		query,
 		timeslot.ID,
 		timeslot.TherapistID,
@@ -134,6 +146,8 @@ func (r *TimeSlotRepository) Create(timeslot *timeslot.TimeSlot) error {
 		timeslot.Duration,
 		timeslot.AdvanceNotice,
 		timeslot.AfterSessionBreakTime,
+		timeslot.Timezone,
+		timeslot.BookingCapacity(),
 		timeslot.CreatedAt,
 		timeslot.UpdatedAt,
 	)
@@ -173,7 +187,7 @@ func (r *TimeSlotRepository) Update(timeslot *timeslot.TimeSlot) error {
 	query := `
 		UPDATE time_slots
 		SET therapist_id = ?, is_active = ?, day_of_week = ?, start_time = ?, duration_minutes = ?,
-		    advance_notice = ?, after_session_break_time = ?, updated_at = ?
+		    advance_notice = ?, after_session_break_time = ?, timezone = ?, capacity = ?, updated_at = ?
 		WHERE id = ?
 	`
 	result, err := r.db.Exec(
@@ -185,6 +199,8 @@ func (r *TimeSlotRepository) Update(timeslot *timeslot.TimeSlot) error {
 		timeslot.Duration,
 		timeslot.AdvanceNotice,
 		timeslot.AfterSessionBreakTime,
+		timeslot.Timezone,
+		timeslot.BookingCapacity(),
 		timeslot.UpdatedAt,
 		timeslot.ID,
 	)
@@ -231,12 +247,78 @@ func (r *TimeSlotRepository) Delete(id domain.TimeSlotID) error {
 	return nil
 }
 
+// BulkDeleteTx deletes all given timeslots. Either every timeslot is deleted
+// or ErrTimeSlotNotFound is returned and the caller rolls back.
+func (r *TimeSlotRepository) BulkDeleteTx(sqlExec ports.SQLExec, ids []domain.TimeSlotID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		values[i] = id
+	}
+	query := fmt.Sprintf(`DELETE FROM time_slots WHERE id IN (%s)`, strings.Join(placeholders, ","))
+
+	result, err := sqlExec.Exec(query, values...)
+	if err != nil {
+		slog.Error("error bulk deleting timeslots", "error", err)
+		return ErrFailedToDeleteTimeSlot
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.Error("error getting rows affected after bulk delete", "error", err)
+		return ErrFailedToDeleteTimeSlot
+	}
+
+	if rowsAffected != int64(len(ids)) {
+		return ErrTimeSlotNotFound
+	}
+
+	return nil
+}
+
+func (r *TimeSlotRepository) ListActiveBookingIDsTx(sqlExec ports.SQLExec, id domain.TimeSlotID, after time.Time) ([]domain.BookingID, error) {
+	if id == "" {
+		return nil, ErrTimeSlotIDIsRequired
+	}
+
+	query := `
+		SELECT id FROM bookings
+		WHERE timeslot_id = ? AND state IN (?, ?, ?) AND start_time > ?
+		ORDER BY start_time
+	`
+	rows, err := sqlExec.Query(query, id, booking.BookingStatePending, booking.BookingStateHeld, booking.BookingStateConfirmed, after)
+	if err != nil {
+		slog.Error("error listing active bookings of timeslot", "error", err)
+		return nil, ErrFailedToGetTimeSlots
+	}
+	defer rows.Close()
+
+	bookingIDs := make([]domain.BookingID, 0)
+	for rows.Next() {
+		var bookingID domain.BookingID
+		if err := rows.Scan(&bookingID); err != nil {
+			slog.Error("error scanning booking id", "error", err)
+			return nil, ErrFailedToGetTimeSlots
+		}
+		bookingIDs = append(bookingIDs, bookingID)
+	}
+
+	return bookingIDs, nil
+}
+
 func (r *TimeSlotRepository) ListByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error) {
 	if therapistID == "" {
 		return nil, ErrTimeSlotTherapistIDIsRequired
 	}
 
-	result, err := r.BulkListByTherapist([]domain.TherapistID{therapistID})
+	// The create/update/delete usecases check conflicts against this list, so
+	// it must come from the primary rather than a possibly lagging replica.
+	result, err := r.bulkListByTherapist(r.db, []domain.TherapistID{therapistID}, false)
 	if err != nil {
 		return nil, err
 	}
@@ -244,16 +326,36 @@ func (r *TimeSlotRepository) ListByTherapist(therapistID domain.TherapistID) ([]
 	return result[therapistID], nil
 }
 
+// ListActiveByTherapist feeds booking UIs, which tolerate replica lag like
+// the schedule does.
+func (r *TimeSlotRepository) ListActiveByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error) {
+	if therapistID == "" {
+		return nil, ErrTimeSlotTherapistIDIsRequired
+	}
+
+	result, err := r.bulkListByTherapist(r.db.Reader(), []domain.TherapistID{therapistID}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return result[therapistID], nil
+}
+
+// BulkListByTherapist only feeds the schedule, which tolerates replica lag.
 func (r *TimeSlotRepository) BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.TimeSlot, error) {
+	return r.bulkListByTherapist(r.db.Reader(), therapistIDs, false)
+}
+
+func (r *TimeSlotRepository) bulkListByTherapist(sqlExec ports.SQLExec, therapistIDs []domain.TherapistID, activeOnly bool) (map[domain.TherapistID][]*timeslot.TimeSlot, error) {
 	if len(therapistIDs) == 0 {
 		return nil, ErrTimeSlotTherapistIDIsRequired
 	}
 
 	query := `
 		SELECT id, therapist_id, is_active, day_of_week, start_time, duration_minutes,
-		       advance_notice, after_session_break_time, created_at, updated_at
+		       advance_notice, after_session_break_time, timezone, capacity, created_at, updated_at
 		FROM time_slots
-		WHERE therapist_id IN (%s)
+		WHERE therapist_id IN (%s) %s
 		ORDER BY day_of_week, start_time
 	`
 	placeholders := make([]string, len(therapistIDs))
@@ -263,9 +365,13 @@ func (r *TimeSlotRepository) BulkListByTherapist(therapistIDs []domain.Therapist
 		values[i] = id
 	}
 	placeholdersStr := strings.Join(placeholders, ",")
-	query = fmt.Sprintf(query, placeholdersStr)
+	activeFilter := ""
+	if activeOnly {
+		activeFilter = "AND is_active = 1"
+	}
+	query = fmt.Sprintf(query, placeholdersStr, activeFilter)
 
-	rows, err := r.db.Query(query, values...)
+	rows, err := sqlExec.Query(query, values...)
 	if err != nil {
 		slog.Error("error listing timeslots by therapist", "error", err)
 		return nil, ErrFailedToGetTimeSlots
@@ -300,6 +406,8 @@ func (r *TimeSlotRepository) scanTimeslot(rows *sql.Rows) (*timeslot.TimeSlot, e
 		&timeslot.Duration,
 		&timeslot.AdvanceNotice,
 		&timeslot.AfterSessionBreakTime,
+		&timeslot.Timezone,
+		&timeslot.Capacity,
 		&timeslot.CreatedAt,
 		&timeslot.UpdatedAt,
 	)
@@ -307,6 +415,10 @@ func (r *TimeSlotRepository) scanTimeslot(rows *sql.Rows) (*timeslot.TimeSlot, e
 		slog.Error("error scanning timeslot", "error", err)
 		return nil, ErrFailedToGetTimeSlots
 	}
+	if _, err := timeslot.Location(); err != nil {
+		slog.Error("timeslot has an invalid timezone", "id", timeslot.ID, "timezone", timeslot.Timezone)
+		return nil, err
+	}
 
 	// Initialize empty slice for booking IDs
 	timeslot.BookingIDs = make([]domain.BookingID, 0)
@@ -314,17 +426,51 @@ func (r *TimeSlotRepository) scanTimeslot(rows *sql.Rows) (*timeslot.TimeSlot, e
 	return timeslot, nil
 }
 
-func (r *TimeSlotRepository) BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) error {
+func (r *TimeSlotRepository) BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) (int, error) {
 	if therapistID == "" {
-		return ErrTimeSlotTherapistIDIsRequired
+		return 0, ErrTimeSlotTherapistIDIsRequired
 	}
 
-	query := `UPDATE time_slots SET is_active = ? WHERE therapist_id = ?`
-	_, err := r.db.Exec(query, isActive, therapistID)
+	// A single statement, so the count matches exactly the rows it changed.
+	// Slots already in the requested state are skipped so they are not counted
+	// and keep their updated_at.
+	query := `UPDATE time_slots SET is_active = ?, updated_at = ? WHERE therapist_id = ? AND is_active != ?`
+	result, err := r.db.Exec(query, isActive, domain.NewUTCTimestamp(), therapistID, isActive)
 	if err != nil {
 		slog.Error("error bulk toggling timeslots", "error", err, "therapistID", therapistID, "isActive", isActive)
+		return 0, ErrFailedToUpdateTimeSlot
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.Error("error getting rows affected after bulk toggle", "error", err)
+		return 0, ErrFailedToUpdateTimeSlot
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r *TimeSlotRepository) SetActive(id domain.TimeSlotID, isActive bool) error {
+	if id == "" {
+		return ErrTimeSlotIDIsRequired
+	}
+
+	query := `UPDATE time_slots SET is_active = ?, updated_at = ? WHERE id = ?`
+	result, err := r.db.Exec(query, isActive, domain.NewUTCTimestamp(), id)
+	if err != nil {
+		slog.Error("error setting timeslot active state", "error", err, "timeslotID", id, "isActive", isActive)
+		return ErrFailedToUpdateTimeSlot
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		slog.Error("error getting rows affected after set active", "error", err)
 		return ErrFailedToUpdateTimeSlot
 	}
 
+	if rowsAffected == 0 {
+		return ErrTimeSlotNotFound
+	}
+
 	return nil
 }
@@ -0,0 +1,228 @@
+package timeslot_db
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/adapters/db/internal/dbtest"
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+)
+
+func TestTimeSlotRepositoryConcurrentCreate(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+
+	var journalMode string
+	if err := database.QueryRow(`PRAGMA journal_mode`).Scan(&journalMode); err != nil {
+		t.Fatalf("Failed to read journal mode: %v", err)
+	}
+	if journalMode != "wal" {
+		t.Errorf("Expected journal mode wal, got %s", journalMode)
+	}
+
+	repo := NewTimeSlotRepository(database)
+
+	const workers = 8
+	const slotsPerWorker = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*slotsPerWorker)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < slotsPerWorker; i++ {
+				errs <- repo.Create(&timeslot.TimeSlot{
+					ID:          domain.NewTimeSlotID(),
+					TherapistID: therapistID,
+					IsActive:    true,
+					DayOfWeek:   timeslot.DayOfWeekMonday,
+					Start:       "10:00",
+					Duration:    60,
+					CreatedAt:   domain.NewUTCTimestamp(),
+					UpdatedAt:   domain.NewUTCTimestamp(),
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Failed to create timeslot concurrently: %v", err)
+		}
+	}
+
+	var count int
+	if err := database.QueryRow(`SELECT COUNT(*) FROM time_slots WHERE therapist_id = ?`, therapistID).Scan(&count); err != nil {
+		t.Fatalf("Failed to count timeslots: %v", err)
+	}
+	if count != workers*slotsPerWorker {
+		t.Errorf("Expected %d timeslots, got %d", workers*slotsPerWorker, count)
+	}
+}
+
+func TestTimeSlotRepositoryBulkToggleByTherapistID(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+
+	repo := NewTimeSlotRepository(database)
+	for _, day := range []timeslot.DayOfWeek{timeslot.DayOfWeekMonday, timeslot.DayOfWeekTuesday, timeslot.DayOfWeekWednesday} {
+		err := repo.Create(&timeslot.TimeSlot{
+			ID:          domain.NewTimeSlotID(),
+			TherapistID: therapistID,
+			IsActive:    true,
+			DayOfWeek:   day,
+			Start:       "10:00",
+			Duration:    60,
+			CreatedAt:   domain.NewUTCTimestamp(),
+			UpdatedAt:   domain.NewUTCTimestamp(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create timeslot: %v", err)
+		}
+	}
+
+	affected, err := repo.BulkToggleByTherapistID(therapistID, false)
+	if err != nil {
+		t.Fatalf("Failed to bulk toggle timeslots: %v", err)
+	}
+	if affected != 3 {
+		t.Errorf("Expected 3 timeslots affected, got %d", affected)
+	}
+
+	var activeCount int
+	if err := database.QueryRow(`SELECT COUNT(*) FROM time_slots WHERE therapist_id = ? AND is_active = TRUE`, therapistID).Scan(&activeCount); err != nil {
+		t.Fatalf("Failed to count active timeslots: %v", err)
+	}
+	if activeCount != 0 {
+		t.Errorf("Expected no active timeslots, got %d", activeCount)
+	}
+
+	// Toggling to the current state changes nothing
+	affected, err = repo.BulkToggleByTherapistID(therapistID, false)
+	if err != nil {
+		t.Fatalf("Failed to bulk toggle timeslots: %v", err)
+	}
+	if affected != 0 {
+		t.Errorf("Expected 0 timeslots affected when already inactive, got %d", affected)
+	}
+
+	// Other therapists' slots are left alone
+	affected, err = repo.BulkToggleByTherapistID(domain.NewTherapistID(), true)
+	if err != nil {
+		t.Fatalf("Failed to bulk toggle timeslots: %v", err)
+	}
+	if affected != 0 {
+		t.Errorf("Expected 0 timeslots affected for an unknown therapist, got %d", affected)
+	}
+}
+
+func TestTimeSlotRepositoryListActiveByTherapist(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+
+	repo := NewTimeSlotRepository(database)
+	activeIDs := make([]domain.TimeSlotID, 0)
+	for i, day := range []timeslot.DayOfWeek{timeslot.DayOfWeekMonday, timeslot.DayOfWeekTuesday, timeslot.DayOfWeekWednesday, timeslot.DayOfWeekThursday} {
+		slot := &timeslot.TimeSlot{
+			ID:          domain.NewTimeSlotID(),
+			TherapistID: therapistID,
+			IsActive:    i%2 == 0,
+			DayOfWeek:   day,
+			Start:       "10:00",
+			Duration:    60,
+			CreatedAt:   domain.NewUTCTimestamp(),
+			UpdatedAt:   domain.NewUTCTimestamp(),
+		}
+		if err := repo.Create(slot); err != nil {
+			t.Fatalf("Failed to create timeslot: %v", err)
+		}
+		if slot.IsActive {
+			activeIDs = append(activeIDs, slot.ID)
+		}
+	}
+
+	active, err := repo.ListActiveByTherapist(therapistID)
+	if err != nil {
+		t.Fatalf("Failed to list active timeslots: %v", err)
+	}
+	if len(active) != len(activeIDs) {
+		t.Fatalf("Expected %d active timeslots, got %d", len(activeIDs), len(active))
+	}
+	for i, slot := range active {
+		if !slot.IsActive {
+			t.Errorf("Expected only active timeslots, got inactive %s", slot.ID)
+		}
+		if slot.ID != activeIDs[i] {
+			t.Errorf("Expected timeslot %s at position %d, got %s", activeIDs[i], i, slot.ID)
+		}
+	}
+
+	all, err := repo.ListByTherapist(therapistID)
+	if err != nil {
+		t.Fatalf("Failed to list timeslots: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("Expected the unfiltered list to keep all 4 timeslots, got %d", len(all))
+	}
+}
+
+func TestTimeSlotRepositoryCapacity(t *testing.T) {
+	database, cleanup := dbtest.SetupTestDB(t)
+	defer cleanup()
+
+	therapistID := dbtest.InsertTherapist(t, database, "therapist@example.com")
+	repo := NewTimeSlotRepository(database)
+
+	groupSlot := &timeslot.TimeSlot{
+		ID:          domain.NewTimeSlotID(),
+		TherapistID: therapistID,
+		IsActive:    true,
+		DayOfWeek:   timeslot.DayOfWeekMonday,
+		Start:       "10:00",
+		Duration:    60,
+		Capacity:    4,
+		CreatedAt:   domain.NewUTCTimestamp(),
+		UpdatedAt:   domain.NewUTCTimestamp(),
+	}
+	regularSlot := &timeslot.TimeSlot{
+		ID:          domain.NewTimeSlotID(),
+		TherapistID: therapistID,
+		IsActive:    true,
+		DayOfWeek:   timeslot.DayOfWeekTuesday,
+		Start:       "10:00",
+		Duration:    60,
+		CreatedAt:   domain.NewUTCTimestamp(),
+		UpdatedAt:   domain.NewUTCTimestamp(),
+	}
+	for _, slot := range []*timeslot.TimeSlot{groupSlot, regularSlot} {
+		if err := repo.Create(slot); err != nil {
+			t.Fatalf("Failed to create timeslot: %v", err)
+		}
+	}
+
+	got, err := repo.GetByID(groupSlot.ID)
+	if err != nil {
+		t.Fatalf("Failed to get timeslot: %v", err)
+	}
+	if got.Capacity != 4 {
+		t.Errorf("Expected capacity 4, got %d", got.Capacity)
+	}
+
+	got, err = repo.GetByID(regularSlot.ID)
+	if err != nil {
+		t.Fatalf("Failed to get timeslot: %v", err)
+	}
+	if got.Capacity != 1 {
+		t.Errorf("Expected an unset capacity to be stored as 1, got %d", got.Capacity)
+	}
+}
@@ -35,3 +35,36 @@ func (r *SQLTransactionRepo) Rollback(tx ports.SQLTx) error {
 	}
 	return nil
 }
+
+type SQLUnitOfWork struct {
+	db ports.SQLDatabase
+}
+
+func NewSQLUnitOfWork(db ports.SQLDatabase) ports.UnitOfWork {
+	return &SQLUnitOfWork{db: db}
+}
+
+func (u *SQLUnitOfWork) RunInTx(fn func(tx ports.SQLTx) error) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	// Don't leave the transaction open when fn panics
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ErrFailedToUpdate
+	}
+	return nil
+}
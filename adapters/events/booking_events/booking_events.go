@@ -0,0 +1,77 @@
+package booking_events
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+)
+
+// subscriberBufferSize is how many events a slow subscriber may fall behind
+// before further events are dropped for it
+const subscriberBufferSize = 16
+
+type subscriber struct {
+	events chan booking.Event
+}
+
+// Broker is an in-memory ports.BookingEventPublisher and
+// ports.BookingEventSubscriber. Events only reach subscribers connected to the
+// same instance.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[domain.TherapistID]map[*subscriber]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[domain.TherapistID]map[*subscriber]struct{}),
+	}
+}
+
+func (b *Broker) Subscribe(therapistID domain.TherapistID) (<-chan booking.Event, func()) {
+	sub := &subscriber{events: make(chan booking.Event, subscriberBufferSize)}
+
+	b.mu.Lock()
+	if b.subscribers[therapistID] == nil {
+		b.subscribers[therapistID] = make(map[*subscriber]struct{})
+	}
+	b.subscribers[therapistID][sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscribers[therapistID], sub)
+			if len(b.subscribers[therapistID]) == 0 {
+				delete(b.subscribers, therapistID)
+			}
+			// Publish holds the lock while sending, so nothing sends after this
+			close(sub.events)
+		})
+	}
+
+	return sub.events, unsubscribe
+}
+
+// Publish never blocks: a subscriber whose buffer is full misses the event.
+func (b *Broker) Publish(event booking.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers[event.TherapistID] {
+		select {
+		case sub.events <- event:
+		default:
+			slog.Warn("dropping booking event for a slow subscriber",
+				"type", event.Type,
+				"bookingID", event.BookingID,
+				"therapistID", event.TherapistID,
+			)
+		}
+	}
+}
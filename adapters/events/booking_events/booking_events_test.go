@@ -0,0 +1,52 @@
+package booking_events
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+)
+
+func TestBrokerDeliversToTherapistSubscribersOnly(t *testing.T) {
+	broker := NewBroker()
+
+	events, unsubscribe := broker.Subscribe("therapist_1")
+	otherEvents, unsubscribeOther := broker.Subscribe("therapist_2")
+	defer unsubscribeOther()
+
+	broker.Publish(booking.Event{Type: booking.EventTypeCreated, BookingID: "booking_1", TherapistID: "therapist_1"})
+
+	select {
+	case event := <-events:
+		if event.BookingID != "booking_1" {
+			t.Errorf("Expected booking_1, got %s", event.BookingID)
+		}
+	default:
+		t.Fatal("Expected the subscriber to receive the event")
+	}
+	select {
+	case event := <-otherEvents:
+		t.Errorf("Expected no event for another therapist, got %+v", event)
+	default:
+	}
+
+	// Unsubscribing closes the channel and is safe to repeat
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Error("Expected the channel to be closed after unsubscribing")
+	}
+	broker.Publish(booking.Event{Type: booking.EventTypeCancelled, BookingID: "booking_1", TherapistID: "therapist_1"})
+}
+
+func TestBrokerPublishDoesNotBlockOnSlowSubscribers(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe("therapist_1")
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBufferSize+5; i++ {
+		broker.Publish(booking.Event{Type: booking.EventTypeCreated, TherapistID: "therapist_1"})
+	}
+	if len(events) != subscriberBufferSize {
+		t.Errorf("Expected %d buffered events, got %d", subscriberBufferSize, len(events))
+	}
+}
@@ -0,0 +1,16 @@
+package booking_events
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+// Fanout is a ports.BookingEventPublisher that publishes every event to each
+// of its publishers, e.g. the Broker and an outbound webhook.
+type Fanout []ports.BookingEventPublisher
+
+func (f Fanout) Publish(event booking.Event) {
+	for _, publisher := range f {
+		publisher.Publish(event)
+	}
+}
@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"log/slog"
+	"time"
+)
+
+type deliverFunc func(payload []byte) error
+
+// withRetry decorates deliver to try up to attempts times, waiting delay
+// after the first failure and doubling the wait after each further one.
+func withRetry(deliver deliverFunc, attempts int, delay time.Duration) deliverFunc {
+	return func(payload []byte) error {
+		var err error
+		for attempt := 1; attempt <= attempts; attempt++ {
+			if err = deliver(payload); err == nil {
+				return nil
+			}
+			if attempt < attempts {
+				slog.Warn("webhook delivery failed, retrying", "error", err, "attempt", attempt, "delay", delay)
+				time.Sleep(delay)
+				delay *= 2
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+)
+
+const (
+	// SignatureHeader carries the hex HMAC-SHA256 of the request body
+	SignatureHeader = "X-Signature"
+
+	// queueSize is how many events may wait for delivery before further
+	// events are dropped
+	queueSize = 64
+
+	defaultAttempts   = 5
+	defaultRetryDelay = time.Second
+	requestTimeout    = 10 * time.Second
+)
+
+// Publisher is a ports.BookingEventPublisher posting each event as JSON to an
+// integrator's URL. Events are delivered one at a time, in order, by a
+// background worker so publishing never blocks the usecase.
+type Publisher struct {
+	url     string
+	secret  string
+	client  *http.Client
+	deliver deliverFunc
+	queue   chan booking.Event
+}
+
+func NewPublisher(url, secret string) *Publisher {
+	return newPublisher(url, secret, defaultAttempts, defaultRetryDelay)
+}
+
+func newPublisher(url, secret string, attempts int, retryDelay time.Duration) *Publisher {
+	p := &Publisher{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: requestTimeout},
+		queue:  make(chan booking.Event, queueSize),
+	}
+	p.deliver = withRetry(p.post, attempts, retryDelay)
+
+	go p.run()
+	return p
+}
+
+// Publish never blocks: when the queue is full the event is dropped.
+func (p *Publisher) Publish(event booking.Event) {
+	select {
+	case p.queue <- event:
+	default:
+		slog.Warn("dropping webhook event, delivery queue is full",
+			"type", event.Type,
+			"bookingID", event.BookingID,
+		)
+	}
+}
+
+// Close stops the worker once the queued events are delivered. Publish must
+// not be called afterwards.
+func (p *Publisher) Close() {
+	close(p.queue)
+}
+
+func (p *Publisher) run() {
+	for event := range p.queue {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			slog.Error("error encoding webhook event", "error", err, "bookingID", event.BookingID)
+			continue
+		}
+		if err := p.deliver(payload); err != nil {
+			slog.Error("giving up on webhook event",
+				"error", err,
+				"type", event.Type,
+				"bookingID", event.BookingID,
+			)
+		}
+	}
+}
+
+func (p *Publisher) post(payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(p.secret, payload))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of payload keyed by secret, the value
+// receivers recompute to check the X-Signature header.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
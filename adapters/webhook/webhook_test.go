@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+)
+
+func TestPublisherDeliversSignedEvents(t *testing.T) {
+	const secret = "integrator-secret"
+
+	received := make(chan booking.Event, 1)
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt to exercise the retry
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read body: %v", err)
+			return
+		}
+		if got, want := r.Header.Get(SignatureHeader), Sign(secret, body); got != want {
+			t.Errorf("Expected signature %s, got %s", want, got)
+		}
+
+		var event booking.Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("Failed to parse event: %v", err)
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	publisher := newPublisher(server.URL, secret, 3, time.Millisecond)
+	defer publisher.Close()
+
+	publisher.Publish(booking.Event{
+		Type:        booking.EventTypeConfirmed,
+		BookingID:   "booking_1",
+		TherapistID: "therapist_1",
+		State:       booking.BookingStateConfirmed,
+	})
+
+	select {
+	case event := <-received:
+		if event.Type != booking.EventTypeConfirmed || event.BookingID != "booking_1" {
+			t.Errorf("Expected booking_1 confirmed, got %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the webhook")
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestSignMatchesKnownHMAC(t *testing.T) {
+	// echo -n '{}' | openssl dgst -sha256 -hmac secret
+	want := "77325902caca812dc259733aacd046b73817372c777b8d95b402647474516e13"
+	if got := Sign("secret", []byte("{}")); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
@@ -1,13 +1,76 @@
 package config
 
-import "github.com/mishkahtherapy/brain/core/domain"
+import (
+	"strings"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
 
 const minimumBookingTime = domain.DurationMinutes(15)
 
-type BookingConfig struct{}
+// {clientName} and {startTime} are replaced when the link is generated.
+const defaultWhatsAppMessageTemplate = "Hello {clientName}, this is Mishkah about your session on {startTime}."
+
+const defaultAllowedCurrencies = "USD,EGP"
+
+// A cancelled booking can be brought back this long after it was cancelled.
+const defaultReactivationGraceMinutes = 30
+
+// A held booking blocks its time this long while the client checks out.
+const defaultHoldMinutes = 10
+
+const defaultHoldSweepSeconds = 60
+
+type BookingConfig struct {
+	// WhatsAppMessageTemplate is the prefilled message of the WhatsApp
+	// deep-links included in booking responses.
+	WhatsAppMessageTemplate string
+	// AllowedCurrencies lists the ISO 4217 codes a booking may be paid in.
+	AllowedCurrencies []domain.Currency
+	// ReactivationGracePeriod is how long after cancelling a booking it can
+	// still be reactivated.
+	ReactivationGracePeriod time.Duration
+	// MinPaidAmount and MaxPaidAmount bound the amount recorded when a
+	// booking is confirmed, in the smallest unit of its currency. The same
+	// bounds apply to every currency. Zero disables a bound.
+	MinPaidAmount int
+	MaxPaidAmount int
+	// CancellationCutoff is how close to its start a booking can no longer
+	// be cancelled without an admin override. Zero disables the policy.
+	CancellationCutoff time.Duration
+	// HoldDuration is how long a booking hold reserves its time before it
+	// expires and is released.
+	HoldDuration time.Duration
+	// HoldSweepInterval is how often expired holds are released.
+	HoldSweepInterval time.Duration
+	// SessionPrice is the price shown when previewing a confirmation, in the
+	// smallest unit of SessionPriceCurrency. Zero leaves the price out.
+	SessionPrice         int
+	SessionPriceCurrency domain.Currency
+}
 
 func GetBookingConfig() BookingConfig {
-	return BookingConfig{}
+	currencies := make([]domain.Currency, 0)
+	for _, code := range strings.Split(GetEnvOrDefault("BRAIN_ALLOWED_CURRENCIES", defaultAllowedCurrencies), ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code != "" {
+			currencies = append(currencies, domain.Currency(code))
+		}
+	}
+
+	return BookingConfig{
+		WhatsAppMessageTemplate: GetEnvOrDefault("BRAIN_WHATSAPP_MESSAGE_TEMPLATE", defaultWhatsAppMessageTemplate),
+		AllowedCurrencies:       currencies,
+		ReactivationGracePeriod: time.Duration(GetIntEnvOrDefault("BRAIN_BOOKING_REACTIVATION_GRACE_MINUTES", defaultReactivationGraceMinutes)) * time.Minute,
+		MinPaidAmount:           GetIntEnvOrDefault("BRAIN_MIN_PAID_AMOUNT", 0),
+		MaxPaidAmount:           GetIntEnvOrDefault("BRAIN_MAX_PAID_AMOUNT", 0),
+		CancellationCutoff:      time.Duration(GetIntEnvOrDefault("BRAIN_CANCELLATION_CUTOFF_HOURS", 0)) * time.Hour,
+		HoldDuration:            time.Duration(GetIntEnvOrDefault("BRAIN_BOOKING_HOLD_MINUTES", defaultHoldMinutes)) * time.Minute,
+		HoldSweepInterval:       time.Duration(GetIntEnvOrDefault("BRAIN_BOOKING_HOLD_SWEEP_SECONDS", defaultHoldSweepSeconds)) * time.Second,
+		SessionPrice:            GetIntEnvOrDefault("BRAIN_SESSION_PRICE", 0),
+		SessionPriceCurrency:    domain.Currency(strings.ToUpper(strings.TrimSpace(GetEnvOrDefault("BRAIN_SESSION_PRICE_CURRENCY", string(domain.DefaultCurrency))))),
+	}
 }
 
 func (c *BookingConfig) MinimumBookingTime() domain.DurationMinutes {
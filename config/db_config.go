@@ -2,6 +2,7 @@ package config
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/mishkahtherapy/brain/adapters/db"
 )
@@ -12,7 +13,13 @@ func GetDBConfig() db.DatabaseConfig {
 	dbPath := filepath.Join(dbRootPath, "brain.db")
 	schemaPath := filepath.Join(dbRootPath, "schema.sql")
 	return db.DatabaseConfig{
-		DBFilename: dbPath,
-		SchemaFile: schemaPath,
+		DBFilename:   dbPath,
+		SchemaFile:   schemaPath,
+		JournalMode:  GetEnvOrDefault("BRAIN_DB_JOURNAL_MODE", db.DefaultJournalMode),
+		BusyTimeout:  time.Duration(GetIntEnvOrDefault("BRAIN_DB_BUSY_TIMEOUT_MS", int(db.DefaultBusyTimeout.Milliseconds()))) * time.Millisecond,
+		MaxOpenConns: GetIntEnvOrDefault("BRAIN_DB_MAX_OPEN_CONNS", 0),
+		MaxIdleConns: GetIntEnvOrDefault("BRAIN_DB_MAX_IDLE_CONNS", 0),
+		// Empty keeps every query on the primary connection
+		ReadDSN: GetEnvOrDefault("BRAIN_DB_READ_DSN", ""),
 	}
 }
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+const defaultScheduleCacheTTLSeconds = 60
+const defaultScheduleMergeGapMinutes = 30
+const defaultScheduleMaxLookaheadDays = 30
+
+type ScheduleConfig struct {
+	// CacheTTL is how long computed daily availability is reused.
+	// Zero disables the cache.
+	CacheTTL time.Duration
+	// MergeGap is the gap below which ranges with the same therapists are
+	// joined when a schedule asks for mergeAdjacent.
+	MergeGap domain.DurationMinutes
+	// MaxLookaheadDays caps how many days ahead the upcoming schedule of a
+	// therapist reaches.
+	MaxLookaheadDays int
+}
+
+func GetScheduleConfig() ScheduleConfig {
+	return ScheduleConfig{
+		CacheTTL:         time.Duration(GetIntEnvOrDefault("BRAIN_SCHEDULE_CACHE_TTL_SECONDS", defaultScheduleCacheTTLSeconds)) * time.Second,
+		MergeGap:         domain.DurationMinutes(GetIntEnvOrDefault("BRAIN_SCHEDULE_MERGE_GAP_MINUTES", defaultScheduleMergeGapMinutes)),
+		MaxLookaheadDays: GetIntEnvOrDefault("BRAIN_SCHEDULE_MAX_LOOKAHEAD_DAYS", defaultScheduleMaxLookaheadDays),
+	}
+}
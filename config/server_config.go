@@ -1,5 +1,26 @@
 package config
 
+import "time"
+
+const defaultRequestTimeoutSeconds = 30
+const defaultMaxRequestBodyBytes = 1 << 20 // 1MB
+
+type ServerConfig struct {
+	// RequestTimeout bounds how long a single request may take to be answered.
+	// Zero disables the timeout.
+	RequestTimeout time.Duration
+	// MaxRequestBodyBytes rejects larger request bodies with a 413.
+	// Zero disables the limit.
+	MaxRequestBodyBytes int64
+}
+
+func GetServerConfig() ServerConfig {
+	return ServerConfig{
+		RequestTimeout:      time.Duration(GetIntEnvOrDefault("BRAIN_REQUEST_TIMEOUT_SECONDS", defaultRequestTimeoutSeconds)) * time.Second,
+		MaxRequestBodyBytes: int64(GetIntEnvOrDefault("BRAIN_MAX_REQUEST_BODY_BYTES", defaultMaxRequestBodyBytes)),
+	}
+}
+
 func IsDevelopment() bool {
 	return GetEnvOrDefault("BRAIN_ENV", "production") == "development"
 }
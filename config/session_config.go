@@ -0,0 +1,53 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+const defaultMeetingURLAllowedHosts = "zoom.us,meet.google.com"
+
+const defaultMaxSessionNotesBytes = 64 * 1024
+
+const defaultNoShowSweepSeconds = 300
+
+type SessionConfig struct {
+	// MeetingURLAllowedHosts lists the hosts meeting links may point to.
+	// Subdomains of a listed host are accepted too, e.g. us02web.zoom.us.
+	MeetingURLAllowedHosts []string
+	// MaxNotesBytes caps the size of a session's notes. Zero disables the cap.
+	MaxNotesBytes int
+	// NotesOverflowPolicy is either "reject" or "truncate", which drops the
+	// oldest notes to make room for new ones.
+	NotesOverflowPolicy domain.NotesOverflowPolicy
+	// NoShowGracePeriod is how long after its scheduled end a still planned
+	// session is marked as no-show. Zero disables marking sessions as no-show.
+	NoShowGracePeriod time.Duration
+	// NoShowSweepInterval is how often planned sessions are checked.
+	NoShowSweepInterval time.Duration
+}
+
+func GetSessionConfig() SessionConfig {
+	hosts := make([]string, 0)
+	for _, host := range strings.Split(GetEnvOrDefault("BRAIN_MEETING_URL_ALLOWED_HOSTS", defaultMeetingURLAllowedHosts), ",") {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	policy := domain.NotesOverflowPolicy(strings.ToLower(GetEnvOrDefault("BRAIN_SESSION_NOTES_OVERFLOW_POLICY", string(domain.NotesOverflowReject))))
+	if policy != domain.NotesOverflowReject && policy != domain.NotesOverflowTruncateOldest {
+		panic("environment variable BRAIN_SESSION_NOTES_OVERFLOW_POLICY must be reject or truncate")
+	}
+
+	return SessionConfig{
+		MeetingURLAllowedHosts: hosts,
+		MaxNotesBytes:          GetIntEnvOrDefault("BRAIN_MAX_SESSION_NOTES_BYTES", defaultMaxSessionNotesBytes),
+		NotesOverflowPolicy:    policy,
+		NoShowGracePeriod:      time.Duration(GetIntEnvOrDefault("BRAIN_SESSION_NO_SHOW_GRACE_MINUTES", 0)) * time.Minute,
+		NoShowSweepInterval:    time.Duration(GetIntEnvOrDefault("BRAIN_SESSION_NO_SHOW_SWEEP_SECONDS", defaultNoShowSweepSeconds)) * time.Second,
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+)
+
+// Slots longer than this are still created but come back with a warning.
+// The hard cap of 24 hours is enforced by the timeslot usecases.
+const defaultMaxSlotDurationWarningMinutes = 12 * 60
+
+type TimeSlotConfig struct {
+	// MaxSlotDurationWarning is the duration above which creating a slot
+	// returns a warning, usually an accidental all-day slot.
+	MaxSlotDurationWarning domain.DurationMinutes
+	// ClinicHours restricts slots to the clinic's opening hours in local
+	// time. Disabled unless both BRAIN_CLINIC_OPEN and BRAIN_CLINIC_CLOSE
+	// are set; a close before the open time runs past midnight.
+	ClinicHours timeslot.ClinicHours
+}
+
+func GetTimeSlotConfig() TimeSlotConfig {
+	clinicHours := timeslot.ClinicHours{
+		Open:  getTime24hEnv("BRAIN_CLINIC_OPEN"),
+		Close: getTime24hEnv("BRAIN_CLINIC_CLOSE"),
+	}
+	if (clinicHours.Open == "") != (clinicHours.Close == "") {
+		panic("environment variables BRAIN_CLINIC_OPEN and BRAIN_CLINIC_CLOSE must be set together")
+	}
+	if clinicHours.Enabled() && clinicHours.Open == clinicHours.Close {
+		panic("environment variables BRAIN_CLINIC_OPEN and BRAIN_CLINIC_CLOSE must differ")
+	}
+
+	return TimeSlotConfig{
+		MaxSlotDurationWarning: domain.DurationMinutes(GetIntEnvOrDefault("BRAIN_TIMESLOT_MAX_DURATION_WARNING_MINUTES", defaultMaxSlotDurationWarningMinutes)),
+		ClinicHours:            clinicHours,
+	}
+}
+
+func getTime24hEnv(key string) domain.Time24h {
+	value := GetEnvOrDefault(key, "")
+	if value == "" {
+		return ""
+	}
+	if err := domain.ValidateTime24h(value); err != nil || len(value) != 5 {
+		panic(fmt.Sprintf("environment variable %s must be a time in HH:MM format", key))
+	}
+	return domain.Time24h(value)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -22,6 +23,18 @@ func GetEnvOrDefault(key, defaultValue string) string {
 	return value
 }
 
+func GetIntEnvOrDefault(key string, defaultValue int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("environment variable %s must be an integer", key))
+	}
+	return parsed
+}
+
 func LoadEnvFileIfExists(path string) error {
 	_, err := os.Stat(path)
 	if os.IsNotExist(err) {
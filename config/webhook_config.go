@@ -0,0 +1,15 @@
+package config
+
+type WebhookConfig struct {
+	// URL receives a POST for every booking event. Empty disables webhooks.
+	URL string
+	// Secret signs each payload with HMAC-SHA256 in the X-Signature header.
+	Secret string
+}
+
+func GetWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		URL:    GetEnvOrDefault("BRAIN_WEBHOOK_URL", ""),
+		Secret: GetEnvOrDefault("BRAIN_WEBHOOK_SECRET", ""),
+	}
+}
@@ -13,6 +13,10 @@ const (
 	BookingStatePending   BookingState = "pending"
 	BookingStateConfirmed BookingState = "confirmed"
 	BookingStateCancelled BookingState = "cancelled"
+	// BookingStateHeld reserves the booking's time from checkout until the
+	// booking is confirmed or cancelled. It is released early only when its
+	// hold expires before being confirmed.
+	BookingStateHeld BookingState = "held"
 )
 
 type BookingType int
@@ -59,3 +63,13 @@ type AdhocBooking struct {
 	CreatedAt            domain.UTCTimestamp    `json:"createdAt"`
 	UpdatedAt            domain.UTCTimestamp    `json:"updatedAt"`
 }
+
+// StateChange is one entry of a booking's audit trail. FromState is empty
+// for the entry recorded when the booking was created.
+type StateChange struct {
+	BookingID domain.BookingID    `json:"bookingId"`
+	FromState BookingState        `json:"fromState"`
+	ToState   BookingState        `json:"toState"`
+	ChangedAt domain.UTCTimestamp `json:"changedAt"`
+	Actor     string              `json:"actor"`
+}
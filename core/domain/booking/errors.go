@@ -3,6 +3,23 @@ package booking
 import "errors"
 
 var (
-	ErrBookingAlreadyConfirmed = errors.New("booking is already confirmed")
-	ErrFailedToCreateSession   = errors.New("failed to create session for confirmed booking")
+	ErrBookingAlreadyConfirmed  = errors.New("booking is already confirmed")
+	ErrFailedToCreateSession    = errors.New("failed to create session for confirmed booking")
+	ErrSpecializationMismatch   = errors.New("new therapist does not share a specialization with the current therapist")
+	ErrFailedToReassign         = errors.New("failed to reassign booking")
+	ErrOutsideTimeSlot          = errors.New("booking does not fit in its timeslot")
+	ErrTimeSlotNotOwned         = errors.New("timeslot does not belong to the booking's therapist")
+	ErrDailySessionLimitReached = errors.New("therapist has reached their maximum sessions for the day")
+	ErrFailedToUpdateDuration   = errors.New("failed to update booking duration")
+
+	ErrReactivationWindowExpired = errors.New("booking was cancelled too long ago to be reactivated")
+	ErrFailedToReactivate        = errors.New("failed to reactivate booking")
+
+	ErrWithinCancellationCutoff = errors.New("booking starts too soon to be cancelled under the cancellation policy")
+
+	ErrTherapistHasNoDevice = errors.New("therapist has no device to notify")
+
+	ErrHoldNotFound = errors.New("booking hold not found")
+	ErrHoldExpired  = errors.New("booking hold has expired")
+	ErrFailedToHold = errors.New("failed to hold booking")
 )
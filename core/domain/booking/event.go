@@ -0,0 +1,38 @@
+package booking
+
+import "github.com/mishkahtherapy/brain/core/domain"
+
+type EventType string
+
+const (
+	EventTypeCreated   EventType = "booking.created"
+	EventTypeConfirmed EventType = "booking.confirmed"
+	EventTypeCancelled EventType = "booking.cancelled"
+)
+
+// Event is published whenever a regular booking changes state, e.g. to push
+// live updates to the therapist's dashboard.
+type Event struct {
+	Type        EventType              `json:"type"`
+	BookingID   domain.BookingID       `json:"bookingId"`
+	TherapistID domain.TherapistID     `json:"therapistId"`
+	ClientID    domain.ClientID        `json:"clientId"`
+	State       BookingState           `json:"state"`
+	StartTime   domain.UTCTimestamp    `json:"startTime"`
+	Duration    domain.DurationMinutes `json:"duration"`
+	OccurredAt  domain.UTCTimestamp    `json:"occurredAt"`
+}
+
+// NewEvent describes the booking after it moved to state
+func NewEvent(eventType EventType, b *Booking, state BookingState) Event {
+	return Event{
+		Type:        eventType,
+		BookingID:   b.ID,
+		TherapistID: b.TherapistID,
+		ClientID:    b.ClientID,
+		State:       state,
+		StartTime:   b.StartTime,
+		Duration:    b.Duration,
+		OccurredAt:  domain.NewUTCTimestamp(),
+	}
+}
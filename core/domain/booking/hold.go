@@ -0,0 +1,20 @@
+package booking
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+// Hold reserves a held booking's time until ExpiresAt. The client that
+// placed it confirms the booking with its token.
+type Hold struct {
+	Token     domain.HoldToken    `json:"token"`
+	BookingID domain.BookingID    `json:"bookingId"`
+	ExpiresAt domain.UTCTimestamp `json:"expiresAt"`
+	CreatedAt domain.UTCTimestamp `json:"createdAt"`
+}
+
+func (h *Hold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt.Time())
+}
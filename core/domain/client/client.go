@@ -9,8 +9,10 @@ type Client struct {
 	ID             domain.ClientID       `json:"id"`
 	Name           string                `json:"name"`
 	WhatsAppNumber domain.WhatsAppNumber `json:"whatsAppNumber"`
+	Email          domain.Email          `json:"email,omitempty"`
 	TimezoneOffset domain.TimezoneOffset `json:"timezoneOffset"` // Frontend hint for timezone adjustments
 	Bookings       []booking.Booking     `json:"bookings"`
+	ClinicID       domain.ClinicID       `json:"clinicId"`
 	CreatedAt      domain.UTCTimestamp   `json:"createdAt"`
 	UpdatedAt      domain.UTCTimestamp   `json:"updatedAt"`
 
@@ -3,9 +3,13 @@ package client
 import "errors"
 
 var ErrClientNotFound = errors.New("client not found")
-var ErrClientAlreadyExists = errors.New("client already exists")
+var ErrClientAlreadyExists = errors.New("client with this whatsapp number already exists")
+var ErrClientEmailAlreadyExists = errors.New("client with this email already exists")
+var ErrClientBookingsOverlap = errors.New("both clients have a confirmed booking with the same therapist at the same time")
 var ErrClientNameIsRequired = errors.New("client name is required")
-var ErrClientWhatsAppNumberIsRequired = errors.New("client whatsapp number is required")
+var ErrClientContactIsRequired = errors.New("client whatsapp number or email is required")
+var ErrClientInvalidWhatsAppNumber = errors.New("invalid whatsapp number format")
+var ErrClientInvalidEmail = errors.New("invalid email format")
 var ErrClientCreatedAtIsRequired = errors.New("client created at is required")
 var ErrClientUpdatedAtIsRequired = errors.New("client updated at is required")
 var ErrClientIDIsRequired = errors.New("client id is required")
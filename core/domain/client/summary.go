@@ -0,0 +1,14 @@
+package client
+
+import "github.com/mishkahtherapy/brain/core/domain"
+
+// Summary aggregates a client's bookings and sessions for profile pages
+type Summary struct {
+	ClientID          domain.ClientID `json:"clientId"`
+	UpcomingBookings  int             `json:"upcomingBookings"` // Pending or confirmed, regular and adhoc
+	CompletedSessions int             `json:"completedSessions"`
+	CancelledSessions int             `json:"cancelledSessions"`
+	// TotalPaid sums the paid amount of sessions that were not refunded, per
+	// currency, in the currency's smallest unit
+	TotalPaid map[domain.Currency]int `json:"totalPaid"`
+}
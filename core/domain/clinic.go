@@ -0,0 +1,17 @@
+package domain
+
+// ClinicID identifies the clinic owning therapists, clients and
+// specializations when several clinics share one deployment
+type ClinicID string
+
+// DefaultClinicID owns everything in single-clinic deployments and rows
+// created before clinics existed
+const DefaultClinicID ClinicID = "default"
+
+// OrDefault returns the clinic, or DefaultClinicID when it is empty
+func (c ClinicID) OrDefault() ClinicID {
+	if c == "" {
+		return DefaultClinicID
+	}
+	return c
+}
@@ -0,0 +1,7 @@
+package domain
+
+// Currency is an ISO 4217 currency code, e.g. "USD"
+type Currency string
+
+// DefaultCurrency is assumed when no currency is given
+const DefaultCurrency Currency = "USD"
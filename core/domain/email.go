@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"net/mail"
+	"strings"
+)
+
 type Email string
 
 func NewEmail(email string) Email {
@@ -9,3 +14,21 @@ func NewEmail(email string) Email {
 func (e Email) String() string {
 	return string(e)
 }
+
+// Normalize trims surrounding whitespace and lowercases the address so the
+// same mailbox always compares equal.
+func (e Email) Normalize() Email {
+	return Email(strings.ToLower(strings.TrimSpace(string(e))))
+}
+
+// IsValid reports whether the email is a bare address such as
+// "name@example.com", without a display name or angle brackets, whose domain
+// has at least one dot.
+func (e Email) IsValid() bool {
+	address, err := mail.ParseAddress(string(e))
+	if err != nil || address.Name != "" || address.Address != string(e) {
+		return false
+	}
+	domain := address.Address[strings.LastIndex(address.Address, "@")+1:]
+	return strings.Contains(domain, ".")
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestEmail_IsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		email Email
+		want  bool
+	}{
+		{"Plain address", "client@example.com", true},
+		{"Subdomain and plus tag", "first.last+tag@mail.example.co", true},
+		{"Missing @", "client.example.com", false},
+		{"Domain without a dot", "client@localhost", false},
+		{"Display name", "Client <client@example.com>", false},
+		{"Surrounding whitespace", " client@example.com", false},
+		{"Empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.email.IsValid(); got != tt.want {
+				t.Errorf("Email(%q).IsValid() = %v, want %v", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmail_Normalize(t *testing.T) {
+	if got := Email("  Client@Example.COM ").Normalize(); got != "client@example.com" {
+		t.Errorf("Normalize() = %q, want %q", got, "client@example.com")
+	}
+}
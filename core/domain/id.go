@@ -15,6 +15,8 @@ type BookingID string
 type SessionID string
 type SpecializationID string
 type AdhocBookingID string
+type RecurringBlockID string
+type HoldToken string
 
 func NewClientID() ClientID {
 	return ClientID(generatePrefixedUUID("client"))
@@ -36,6 +38,14 @@ func NewAdhocBookingID() AdhocBookingID {
 	return AdhocBookingID(generatePrefixedUUID("adhoc_booking"))
 }
 
+func NewRecurringBlockID() RecurringBlockID {
+	return RecurringBlockID(generatePrefixedUUID("recurring_block"))
+}
+
+func NewHoldToken() HoldToken {
+	return HoldToken(generatePrefixedUUID("hold"))
+}
+
 func NewSessionID() SessionID {
 	return SessionID(generatePrefixedUUID("session"))
 }
@@ -0,0 +1,22 @@
+package domain
+
+// LanguageCode is a lowercase ISO 639-1 language code, e.g. "en"
+type LanguageCode string
+
+const (
+	LanguageCodeEnglish LanguageCode = "en"
+	LanguageCodeArabic  LanguageCode = "ar"
+)
+
+// IsValid reports whether the code is two lowercase letters
+func (c LanguageCode) IsValid() bool {
+	if len(c) != 2 {
+		return false
+	}
+	for _, r := range c {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
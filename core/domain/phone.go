@@ -1,13 +1,87 @@
 package domain
 
-import "regexp"
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
 
 type PhoneNumber string
 type WhatsAppNumber string
 
+var ErrInvalidPhoneNumber = errors.New("invalid phone number")
+
 var whatsAppRegex = regexp.MustCompile(`^(\+[1-9]\d{1,14}|\d{1,15})$`)
+var e164Regex = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
 
 func (w WhatsAppNumber) IsValid() bool {
 	// WhatsApp number must be 10 digits
 	return whatsAppRegex.MatchString(string(w))
 }
+
+// Digits returns the number with everything but digits stripped, the form
+// expected by wa.me links.
+func (w WhatsAppNumber) Digits() string {
+	digits := make([]rune, 0, len(w))
+	for _, r := range string(w) {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	return string(digits)
+}
+
+// Normalize drops formatting such as spaces, dashes and parentheses and
+// returns the number in the "+<digits>" form clients are stored with.
+func (w WhatsAppNumber) Normalize() WhatsAppNumber {
+	digits := w.Digits()
+	if digits == "" {
+		return ""
+	}
+	return WhatsAppNumber("+" + digits)
+}
+
+// ToE164 canonicalizes the number to E.164, see toE164.
+func (w WhatsAppNumber) ToE164() (WhatsAppNumber, error) {
+	number, err := toE164(string(w))
+	return WhatsAppNumber(number), err
+}
+
+// ToE164 canonicalizes the number to E.164, see toE164.
+func (p PhoneNumber) ToE164() (PhoneNumber, error) {
+	number, err := toE164(string(p))
+	return PhoneNumber(number), err
+}
+
+// toE164 drops spaces, dashes, dots and parentheses and returns the number as
+// "+<country code><number>". A leading "00" international prefix is read as
+// "+", and numbers without either are taken to start with their country code.
+// Anything else, such as letters, fails with ErrInvalidPhoneNumber.
+func toE164(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	hasPlus := strings.HasPrefix(trimmed, "+")
+	if hasPlus {
+		trimmed = trimmed[1:]
+	}
+
+	var digits strings.Builder
+	for _, r := range trimmed {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	number := digits.String()
+	if !hasPlus {
+		number = strings.TrimPrefix(number, "00")
+	}
+	number = "+" + number
+	if !e164Regex.MatchString(number) {
+		return "", ErrInvalidPhoneNumber
+	}
+	return number, nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "testing"
+
+func TestPhoneNumber_ToE164(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  PhoneNumber
+		want    PhoneNumber
+		wantErr bool
+	}{
+		{"Already E.164", "+12345678901", "+12345678901", false},
+		{"Spaces after the country code", "+1 234 567 8901", "+12345678901", false},
+		{"Dashes, dots and parentheses", "+1 (234) 567-89.01", "+12345678901", false},
+		{"Surrounding whitespace", "  +201001234567 ", "+201001234567", false},
+		{"International 00 prefix", "00201001234567", "+201001234567", false},
+		{"No prefix keeps the country code", "201001234567", "+201001234567", false},
+		{"Letters are rejected", "+1 234 CALL NOW", "", true},
+		{"A plus inside the number is rejected", "+1+234567", "", true},
+		{"Leading zero country code is rejected", "+0123456789", "", true},
+		{"More than 15 digits is rejected", "+1234567890123456", "", true},
+		{"Empty is rejected", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.number.ToE164()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PhoneNumber.ToE164() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PhoneNumber.ToE164() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhatsAppNumber_ToE164(t *testing.T) {
+	// "+1 234" and "+1234" must collide once stored
+	first, err := WhatsAppNumber("+1 234").ToE164()
+	if err != nil {
+		t.Fatalf("WhatsAppNumber.ToE164() error = %v", err)
+	}
+	second, err := WhatsAppNumber("+1234").ToE164()
+	if err != nil {
+		t.Fatalf("WhatsAppNumber.ToE164() error = %v", err)
+	}
+	if first != second {
+		t.Errorf("expected %q and %q to normalize to the same number", first, second)
+	}
+}
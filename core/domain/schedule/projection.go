@@ -0,0 +1,38 @@
+package schedule
+
+import "github.com/mishkahtherapy/brain/core/domain"
+
+// TherapistInfoWithSpecializationIDs serializes like TherapistInfo but lists
+// only the IDs of the therapist's specializations to keep payloads small.
+type TherapistInfoWithSpecializationIDs struct {
+	TherapistInfo
+	Specializations []domain.SpecializationID `json:"specializations"`
+}
+
+type AvailableTimeRangeWithSpecializationIDs struct {
+	AvailableTimeRange
+	Therapists []TherapistInfoWithSpecializationIDs `json:"therapists"`
+}
+
+// WithSpecializationIDs projects the schedule to its specialization-IDs-only shape.
+func WithSpecializationIDs(ranges []AvailableTimeRange) []AvailableTimeRangeWithSpecializationIDs {
+	projected := make([]AvailableTimeRangeWithSpecializationIDs, 0, len(ranges))
+	for _, r := range ranges {
+		therapists := make([]TherapistInfoWithSpecializationIDs, 0, len(r.Therapists))
+		for _, info := range r.Therapists {
+			ids := make([]domain.SpecializationID, 0, len(info.Specializations))
+			for _, s := range info.Specializations {
+				ids = append(ids, s.ID)
+			}
+			therapists = append(therapists, TherapistInfoWithSpecializationIDs{
+				TherapistInfo:   info,
+				Specializations: ids,
+			})
+		}
+		projected = append(projected, AvailableTimeRangeWithSpecializationIDs{
+			AvailableTimeRange: r,
+			Therapists:         therapists,
+		})
+	}
+	return projected
+}
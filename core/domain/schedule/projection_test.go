@@ -0,0 +1,64 @@
+package schedule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+)
+
+func TestWithSpecializationIDs(t *testing.T) {
+	from := domain.UTCTimestamp(time.Date(2025, 7, 7, 9, 0, 0, 0, time.UTC))
+	ranges := []AvailableTimeRange{
+		{
+			From:     from,
+			To:       from.Add(time.Hour),
+			Duration: 60,
+			Therapists: []TherapistInfo{
+				{
+					TherapistID: "therapist_1",
+					Name:        "Dr. Ahmed",
+					Specializations: []specialization.Specialization{
+						{ID: "specialization_1", Name: "anxiety"},
+						{ID: "specialization_2", Name: "depression"},
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(WithSpecializationIDs(ranges))
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var decoded []struct {
+		From       string `json:"from"`
+		Therapists []struct {
+			TherapistID     string          `json:"therapistId"`
+			Name            string          `json:"name"`
+			Specializations json.RawMessage `json:"specializations"`
+		} `json:"therapists"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if len(decoded) != 1 || len(decoded[0].Therapists) != 1 {
+		t.Fatalf("expected one range with one therapist, got %s", body)
+	}
+	if decoded[0].From == "" {
+		t.Errorf("expected range fields to be kept, got %s", body)
+	}
+
+	info := decoded[0].Therapists[0]
+	if info.TherapistID != "therapist_1" || info.Name != "Dr. Ahmed" {
+		t.Errorf("expected therapist fields to be kept, got %s", body)
+	}
+	expected := `["specialization_1","specialization_2"]`
+	if string(info.Specializations) != expected {
+		t.Errorf("expected specializations %s, got %s", expected, info.Specializations)
+	}
+}
@@ -13,10 +13,14 @@ type TimeRange struct {
 type TherapistInfo struct {
 	TherapistID       domain.TherapistID              `json:"therapistId"`
 	Name              string                          `json:"name"`
+	Bio               string                          `json:"bio,omitempty"`
+	PhotoURL          string                          `json:"photoUrl,omitempty"`
 	Specializations   []specialization.Specialization `json:"specializations"`
 	SpeaksEnglish     bool                            `json:"speaksEnglish"`
 	TimeSlotID        domain.TimeSlotID               `json:"timeSlotId"`
 	AvailabilityRange TimeRange                       `json:"availabilityRange"`
+	// CandidateStarts is only filled when a fixed slot length is requested.
+	CandidateStarts []domain.UTCTimestamp `json:"candidateStarts,omitempty"`
 }
 
 // I'm returning available "Time Ranges" not a ready made schedule to cater for timezone conversions on the frotnend.
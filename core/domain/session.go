@@ -1,14 +1,38 @@
 package domain
 
+import (
+	"errors"
+	"regexp"
+)
+
 type SessionState string
 type SessionLanguage string
 
+// NotesOverflowPolicy decides what happens when appending a note would take
+// the notes over their size limit.
+type NotesOverflowPolicy string
+
+const (
+	NotesOverflowReject         NotesOverflowPolicy = "reject"
+	NotesOverflowTruncateOldest NotesOverflowPolicy = "truncate"
+)
+
+var ErrNotesTooLong = errors.New("session notes exceed the maximum length")
+
+const noteSeparator = "\n\n"
+
+// noteBoundary matches the start of every note after the first. A note can
+// itself contain blank lines, so only a separator followed by the
+// "<timestamp>: " prefix AppendNoteWithLimit writes marks a new note.
+var noteBoundary = regexp.MustCompile(noteSeparator + `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z: `)
+
 const (
 	SessionStatePlanned     SessionState = "planned"
 	SessionStateDone        SessionState = "done"
 	SessionStateRescheduled SessionState = "rescheduled"
 	SessionStateCancelled   SessionState = "cancelled"
 	SessionStateRefunded    SessionState = "refunded"
+	SessionStateNoShow      SessionState = "no_show"
 )
 
 const (
@@ -16,13 +40,33 @@ const (
 	SessionLanguageEnglish SessionLanguage = "english"
 )
 
+// IsValid reports whether the language is one sessions can be held in
+func (l SessionLanguage) IsValid() bool {
+	return l == SessionLanguageArabic || l == SessionLanguageEnglish
+}
+
+// IsValid reports whether the state is one of the known session states
+func (s SessionState) IsValid() bool {
+	switch s {
+	case SessionStatePlanned,
+		SessionStateDone,
+		SessionStateRescheduled,
+		SessionStateCancelled,
+		SessionStateRefunded,
+		SessionStateNoShow:
+		return true
+	}
+	return false
+}
+
 // IsFinalState returns true if the session state is a final state
-// (done, rescheduled, cancelled, refunded)
+// (done, rescheduled, cancelled, refunded, no_show)
 func (s SessionState) IsFinalState() bool {
 	return s == SessionStateDone ||
 		s == SessionStateRescheduled ||
 		s == SessionStateCancelled ||
-		s == SessionStateRefunded
+		s == SessionStateRefunded ||
+		s == SessionStateNoShow
 }
 
 // Session represents a confirmed therapy session derived from a booking
@@ -35,7 +79,8 @@ type Session struct {
 	StartTime            UTCTimestamp    `json:"startTime"`
 	Duration             DurationMinutes `json:"duration"`
 	ClientTimezoneOffset TimezoneOffset  `json:"clientTimezoneOffset"`
-	PaidAmount           int             `json:"paidAmount"` // USD cents
+	PaidAmount           int             `json:"paidAmount"` // Smallest unit of Currency, e.g. cents
+	Currency             Currency        `json:"currency"`
 	Language             SessionLanguage `json:"language"`
 	State                SessionState    `json:"state"`
 	Notes                string          `json:"notes"` // delays, special notes, ...etc.
@@ -73,10 +118,45 @@ func (s *Session) CanUpdateField(field string) bool {
 
 // AppendNote adds a note with timestamp, preserving previous notes
 func (s *Session) AppendNote(note string) {
+	// Without a limit appending never fails
+	_ = s.AppendNoteWithLimit(note, 0, NotesOverflowReject)
+}
+
+// AppendNoteWithLimit adds a note with timestamp while keeping the notes within
+// maxBytes. When they would not fit, the policy either rejects the note with
+// ErrNotesTooLong or drops the oldest notes to make room. A maxBytes of zero
+// disables the limit.
+func (s *Session) AppendNoteWithLimit(note string, maxBytes int, policy NotesOverflowPolicy) error {
 	timestamp := NewUTCTimestamp()
-	if s.Notes != "" {
-		s.Notes += "\n\n"
+	entry := timestamp.String() + ": " + note
+
+	notes := s.Notes
+	if maxBytes > 0 {
+		if len(entry) > maxBytes {
+			return ErrNotesTooLong
+		}
+		for notes != "" && len(notes)+len(noteSeparator)+len(entry) > maxBytes {
+			if policy != NotesOverflowTruncateOldest {
+				return ErrNotesTooLong
+			}
+			notes = dropOldestNote(notes)
+		}
+	}
+
+	if notes != "" {
+		notes += noteSeparator
 	}
-	s.Notes += timestamp.String() + ": " + note
+	s.Notes = notes + entry
 	s.UpdatedAt = timestamp
+	return nil
+}
+
+// dropOldestNote removes everything up to the start of the second note, or
+// all of the notes when there is only one
+func dropOldestNote(notes string) string {
+	boundary := noteBoundary.FindStringIndex(notes)
+	if boundary == nil {
+		return ""
+	}
+	return notes[boundary[0]+len(noteSeparator):]
 }
@@ -17,6 +17,7 @@ func TestSessionState_IsFinalState(t *testing.T) {
 		{"Rescheduled state is final", SessionStateRescheduled, true},
 		{"Cancelled state is final", SessionStateCancelled, true},
 		{"Refunded state is final", SessionStateRefunded, true},
+		{"No-show state is final", SessionStateNoShow, true},
 		{"Empty state is not final", SessionState(""), false},
 		{"Unknown state is not final", SessionState("unknown"), false},
 	}
@@ -248,6 +249,105 @@ func TestSession_AppendNote(t *testing.T) {
 	})
 }
 
+func TestSession_AppendNoteWithLimit(t *testing.T) {
+	// Every entry is prefixed with "<timestamp>: "
+	entryLen := func(note string) int {
+		return len(NewUTCTimestamp().String()) + len(": ") + len(note)
+	}
+
+	t.Run("Accepts a note that exactly fills the limit", func(t *testing.T) {
+		session := &Session{Notes: "first"}
+		limit := len("first") + len("\n\n") + entryLen("second")
+
+		if err := session.AppendNoteWithLimit("second", limit, NotesOverflowReject); err != nil {
+			t.Fatalf("expected note to fit, got %v", err)
+		}
+		if len(session.Notes) != limit {
+			t.Errorf("expected notes length %d, got %d", limit, len(session.Notes))
+		}
+	})
+
+	t.Run("Rejects a note one byte over the limit", func(t *testing.T) {
+		session := &Session{Notes: "first"}
+		limit := len("first") + len("\n\n") + entryLen("second") - 1
+
+		err := session.AppendNoteWithLimit("second", limit, NotesOverflowReject)
+		if err != ErrNotesTooLong {
+			t.Fatalf("expected %v, got %v", ErrNotesTooLong, err)
+		}
+		if session.Notes != "first" {
+			t.Errorf("expected notes to be unchanged, got %q", session.Notes)
+		}
+	})
+
+	// Stored notes as AppendNoteWithLimit writes them
+	entry := func(note string) string {
+		return "2025-01-02T10:00:00Z: " + note
+	}
+
+	t.Run("Truncate policy drops the oldest notes", func(t *testing.T) {
+		session := &Session{Notes: entry("oldest") + "\n\n" + entry("middle")}
+		limit := len(entry("middle")) + len("\n\n") + entryLen("newest")
+
+		if err := session.AppendNoteWithLimit("newest", limit, NotesOverflowTruncateOldest); err != nil {
+			t.Fatalf("expected note to be appended, got %v", err)
+		}
+		if strings.Contains(session.Notes, "oldest") {
+			t.Errorf("expected oldest note to be dropped, got %q", session.Notes)
+		}
+		if !strings.HasPrefix(session.Notes, entry("middle")+"\n\n") || !strings.HasSuffix(session.Notes, ": newest") {
+			t.Errorf("expected middle and newest notes to remain, got %q", session.Notes)
+		}
+	})
+
+	t.Run("Truncate policy drops a multi-paragraph note whole", func(t *testing.T) {
+		session := &Session{Notes: entry("first paragraph\n\nsecond paragraph") + "\n\n" + entry("middle")}
+		limit := len(entry("middle")) + len("\n\n") + entryLen("newest")
+
+		if err := session.AppendNoteWithLimit("newest", limit, NotesOverflowTruncateOldest); err != nil {
+			t.Fatalf("expected note to be appended, got %v", err)
+		}
+		if strings.Contains(session.Notes, "paragraph") {
+			t.Errorf("expected the whole oldest note to be dropped, got %q", session.Notes)
+		}
+		if !strings.HasPrefix(session.Notes, entry("middle")+"\n\n") {
+			t.Errorf("expected middle note to remain, got %q", session.Notes)
+		}
+	})
+
+	t.Run("Truncate policy can drop every previous note", func(t *testing.T) {
+		session := &Session{Notes: entry("oldest") + "\n\n" + entry("middle")}
+		limit := entryLen("newest")
+
+		if err := session.AppendNoteWithLimit("newest", limit, NotesOverflowTruncateOldest); err != nil {
+			t.Fatalf("expected note to be appended, got %v", err)
+		}
+		if len(session.Notes) != limit || !strings.HasSuffix(session.Notes, ": newest") {
+			t.Errorf("expected only the newest note, got %q", session.Notes)
+		}
+	})
+
+	t.Run("Rejects a single note larger than the limit under either policy", func(t *testing.T) {
+		for _, policy := range []NotesOverflowPolicy{NotesOverflowReject, NotesOverflowTruncateOldest} {
+			session := &Session{Notes: "first"}
+			err := session.AppendNoteWithLimit("second", entryLen("second")-1, policy)
+			if err != ErrNotesTooLong {
+				t.Errorf("policy %s: expected %v, got %v", policy, ErrNotesTooLong, err)
+			}
+			if session.Notes != "first" {
+				t.Errorf("policy %s: expected notes to be unchanged, got %q", policy, session.Notes)
+			}
+		}
+	})
+
+	t.Run("Zero limit leaves notes unbounded", func(t *testing.T) {
+		session := &Session{Notes: strings.Repeat("x", 1024)}
+		if err := session.AppendNoteWithLimit("more", 0, NotesOverflowReject); err != nil {
+			t.Fatalf("expected no limit, got %v", err)
+		}
+	})
+}
+
 func TestSessionLanguage(t *testing.T) {
 	t.Run("Supported languages are defined", func(t *testing.T) {
 		if SessionLanguageArabic != "arabic" {
@@ -5,6 +5,7 @@ import "github.com/mishkahtherapy/brain/core/domain"
 type Specialization struct {
 	ID        domain.SpecializationID `json:"id"`
 	Name      string                  `json:"name"`
+	ClinicID  domain.ClinicID         `json:"-"`
 	CreatedAt domain.UTCTimestamp     `json:"-"`
 	UpdatedAt domain.UTCTimestamp     `json:"-"`
 }
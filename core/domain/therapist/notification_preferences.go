@@ -0,0 +1,51 @@
+package therapist
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// NotificationPreferences controls which push notifications a therapist
+// receives. Preferences missing from storage default to enabled.
+type NotificationPreferences struct {
+	Confirmations bool `json:"confirmations"` // A booking was confirmed into a session
+	Reminders     bool `json:"reminders"`     // Upcoming session reminders
+}
+
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		Confirmations: true,
+		Reminders:     true,
+	}
+}
+
+func (p NotificationPreferences) Value() (driver.Value, error) {
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return driver.Value(string(encoded)), nil
+}
+
+func (p *NotificationPreferences) Scan(value interface{}) error {
+	*p = DefaultNotificationPreferences()
+	if value == nil {
+		return nil
+	}
+
+	var raw []byte
+	switch v := value.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("unsupported type for notification preferences: %T", value)
+	}
+
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, p)
+}
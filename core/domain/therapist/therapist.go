@@ -11,11 +11,48 @@ type Therapist struct {
 	Email           domain.Email                    `json:"email"`
 	PhoneNumber     domain.PhoneNumber              `json:"phoneNumber"`
 	WhatsAppNumber  domain.WhatsAppNumber           `json:"whatsAppNumber"`
-	SpeaksEnglish   bool                            `json:"speaksEnglish"`
+	SpeaksEnglish   bool                            `json:"speaksEnglish"` // Derived from Languages
+	Languages       []domain.LanguageCode           `json:"languages"`
 	DeviceID        domain.DeviceID                 `json:"-"` // Not exposed to client
 	Specializations []specialization.Specialization `json:"specializations"`
 	TimezoneOffset  domain.TimezoneOffset           `json:"timezoneOffset"`
+	Bio             string                          `json:"bio"`
+	PhotoURL        string                          `json:"photoUrl"`
+	DefaultLanguage domain.SessionLanguage          `json:"defaultLanguage"` // Used when a confirmation omits the session language
+	ClinicID        domain.ClinicID                 `json:"clinicId"`
 
 	CreatedAt domain.UTCTimestamp `json:"createdAt"`
 	UpdatedAt domain.UTCTimestamp `json:"updatedAt"`
 }
+
+// Speaks reports whether the language is in the therapist's list
+func (t *Therapist) Speaks(code domain.LanguageCode) bool {
+	for _, language := range t.Languages {
+		if language == code {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLanguages replaces the therapist's languages and keeps SpeaksEnglish in
+// sync with them.
+func (t *Therapist) SetLanguages(languages []domain.LanguageCode) {
+	t.Languages = languages
+	t.SpeaksEnglish = t.Speaks(domain.LanguageCodeEnglish)
+}
+
+// SetSpeaksEnglish adds English to or removes it from the therapist's
+// languages, for callers still using the boolean.
+func (t *Therapist) SetSpeaksEnglish(speaksEnglish bool) {
+	languages := make([]domain.LanguageCode, 0, len(t.Languages)+1)
+	for _, language := range t.Languages {
+		if language != domain.LanguageCodeEnglish {
+			languages = append(languages, language)
+		}
+	}
+	if speaksEnglish {
+		languages = append(languages, domain.LanguageCodeEnglish)
+	}
+	t.SetLanguages(languages)
+}
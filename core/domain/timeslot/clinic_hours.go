@@ -0,0 +1,60 @@
+package timeslot
+
+import "github.com/mishkahtherapy/brain/core/domain"
+
+const minutesPerDay = 24 * 60
+
+// ClinicHours is the clinic-wide window slots must fall within, in the slot's
+// local time. Close before Open means the clinic stays open past midnight.
+// The zero value allows any slot.
+type ClinicHours struct {
+	Open  domain.Time24h
+	Close domain.Time24h
+}
+
+func (h ClinicHours) Enabled() bool {
+	return h.Open != "" && h.Close != ""
+}
+
+// Contains reports whether a slot starting at start and lasting duration lies
+// entirely within the clinic's hours. Slots running past midnight only fit
+// when the clinic is open past midnight too.
+func (h ClinicHours) Contains(start domain.Time24h, duration domain.DurationMinutes) bool {
+	if !h.Enabled() {
+		return true
+	}
+
+	opensAt, err := minutesSinceMidnight(h.Open)
+	if err != nil {
+		return false
+	}
+	closesAt, err := minutesSinceMidnight(h.Close)
+	if err != nil {
+		return false
+	}
+	slotStart, err := minutesSinceMidnight(start)
+	if err != nil {
+		return false
+	}
+
+	if closesAt <= opensAt {
+		closesAt += minutesPerDay
+	}
+	slotEnd := slotStart + int(duration)
+
+	// A slot early in the morning may belong to the previous day's opening
+	for _, shift := range []int{0, -minutesPerDay} {
+		if slotStart >= opensAt+shift && slotEnd <= closesAt+shift {
+			return true
+		}
+	}
+	return false
+}
+
+func minutesSinceMidnight(t domain.Time24h) (int, error) {
+	parsed, err := t.ParseTime()
+	if err != nil {
+		return 0, err
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
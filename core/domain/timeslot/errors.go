@@ -23,13 +23,26 @@ var (
 	ErrInvalidDuration               = errors.New("duration must be between 1 and 1440 minutes")
 	ErrPreSessionBufferNegative      = errors.New("pre-session buffer cannot be negative")
 	ErrPostSessionBufferTooLow       = errors.New("post-session buffer must be at least 30 minutes")
+	ErrSessionDoesNotFitSlot         = errors.New("duration must fit a minimum-length session plus the post-session buffer")
 	ErrOverlappingTimeslot           = errors.New("timeslot overlaps with existing timeslot for this therapist")
 	ErrOverlappingBooking            = errors.New("booking overlaps with existing booking for this therapist")
 	ErrBookingShouldBeMadeInTimeslot = errors.New("booking should be made in timeslot as it overlapps with an existing timeslot for this therapist")
 	ErrInsufficientGapBetweenSlots   = errors.New("timeslots must be at least 30 minutes apart")
+	ErrOutsideClinicHours            = errors.New("timeslot must fall within clinic hours")
+	ErrInvalidCapacity               = errors.New("capacity must be at least 1 when set")
 
 	// Timezone errors
 	ErrInvalidTimezoneOffset = errors.New("timezone offset must be between -720 and 840 minutes")
+	ErrInvalidTimezoneName   = errors.New("timezone must be a valid IANA name, e.g. America/New_York")
+
+	// Recurring block errors
+	ErrRecurringBlockIDIsRequired = errors.New("recurring block id is required")
+	ErrRecurringBlockNotFound     = errors.New("recurring block not found")
+	ErrRecurringBlockNotOwned     = errors.New("recurring block does not belong to this therapist")
+
+	// Template errors
+	ErrTemplateHasMixedTimezones = errors.New("timeslots use more than one timezone and cannot be exported as one template")
+	ErrTemplateIsEmpty           = errors.New("template has no timeslots")
 
 	// Deletion constraints
 	ErrTimeslotHasActiveBookings = errors.New("cannot delete timeslot with active bookings")
@@ -0,0 +1,38 @@
+package timeslot
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+// RecurringBlock is a weekly window a therapist is unavailable in, e.g. a
+// lunch break. It is subtracted from every slot it overlaps on its day.
+type RecurringBlock struct {
+	ID          domain.RecurringBlockID `json:"id"`
+	TherapistID domain.TherapistID      `json:"therapistId"`
+	DayOfWeek   DayOfWeek               `json:"dayOfWeek"`          // UTC day, or local to Timezone when set
+	Start       domain.Time24h          `json:"start"`              // UTC time e.g. "12:00", or local to Timezone when set
+	Duration    domain.DurationMinutes  `json:"duration"`           // Duration in minutes e.g. 60
+	Timezone    string                  `json:"timezone,omitempty"` // Optional IANA name e.g. "Africa/Cairo"
+	CreatedAt   domain.UTCTimestamp     `json:"createdAt"`
+	UpdatedAt   domain.UTCTimestamp     `json:"updatedAt"`
+}
+
+// ApplyToDate returns the start and end times of the block for a given local
+// date. The timezone must be valid, see Location.
+func (b *RecurringBlock) ApplyToDate(date time.Time) (domain.UTCTimestamp, domain.UTCTimestamp) {
+	return applyWindowToDate(b.Start, b.Duration, mustLoadLocation(b.Timezone), date)
+}
+
+// OccurrenceOn returns the window of the block's weekly occurrence that starts
+// on the given UTC day, see TimeSlot.OccurrenceOn.
+func (b *RecurringBlock) OccurrenceOn(utcDay time.Time) (start, end domain.UTCTimestamp, ok bool) {
+	return occurrenceOn(b.DayOfWeek, b.ApplyToDate, utcDay)
+}
+
+// Location returns the block's timezone, or UTC when none is set. It fails
+// with ErrInvalidTimezoneName when the stored name cannot be loaded.
+func (b *RecurringBlock) Location() (*time.Location, error) {
+	return loadLocation(b.Timezone)
+}
@@ -0,0 +1,22 @@
+package timeslot
+
+import "github.com/mishkahtherapy/brain/core/domain"
+
+// Template is a portable copy of a therapist's weekly availability, used to
+// move a schedule between therapists or environments. Times are local to
+// Timezone, shared by every slot.
+type Template struct {
+	Timezone  string         `json:"timezone"` // IANA name, empty for UTC
+	Timeslots []TemplateSlot `json:"timeslots"`
+}
+
+// TemplateSlot is a timeslot without the identifiers and bookings tying it
+// to a therapist.
+type TemplateSlot struct {
+	DayOfWeek             DayOfWeek                           `json:"dayOfWeek"`
+	StartTime             domain.Time24h                      `json:"startTime"`
+	DurationMinutes       domain.DurationMinutes              `json:"durationMinutes"`
+	AdvanceNotice         domain.AdvanceNoticeMinutes         `json:"advanceNotice"`
+	AfterSessionBreakTime domain.AfterSessionBreakTimeMinutes `json:"afterSessionBreakTime"`
+	IsActive              bool                                `json:"isActive"`
+}
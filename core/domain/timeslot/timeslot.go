@@ -1,6 +1,7 @@
 package timeslot
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -41,18 +42,151 @@ type TimeSlot struct {
 	Duration              domain.DurationMinutes              `json:"duration"`              // Duration in minutes e.g. 60
 	AdvanceNotice         domain.AdvanceNoticeMinutes         `json:"advanceNotice"`         // minutes (advance notice), used only when preparing schedule.
 	AfterSessionBreakTime domain.AfterSessionBreakTimeMinutes `json:"afterSessionBreakTime"` // minutes (break after session).
+	Timezone              string                              `json:"timezone,omitempty"`    // Optional IANA name e.g. "America/New_York". When set, day and start are local to it.
+	Capacity              int                                 `json:"capacity"`              // Concurrent confirmed bookings allowed, more than 1 for group sessions.
 	BookingIDs            []domain.BookingID                  `json:"bookingIds"`
 	CreatedAt             domain.UTCTimestamp                 `json:"createdAt"`
 	UpdatedAt             domain.UTCTimestamp                 `json:"updatedAt"`
 }
 
+// BookingCapacity returns how many confirmed bookings may run at once in the
+// slot. An unset capacity means a regular one-to-one slot.
+func (ts *TimeSlot) BookingCapacity() int {
+	if ts.Capacity < 1 {
+		return 1
+	}
+	return ts.Capacity
+}
+
+// EndTime returns the clock time the session ends, in the same frame as Start
+// and wrapping past midnight.
+func (ts *TimeSlot) EndTime() (domain.Time24h, error) {
+	start, err := ts.Start.ParseTime()
+	if err != nil {
+		return "", err
+	}
+	end := start.Add(time.Duration(ts.Duration) * time.Minute)
+	return domain.Time24h(end.Format(domain.Time24hLayout)), nil
+}
+
+// EffectiveWindow returns the full window the slot blocks around the session.
+// It opens AdvanceNotice minutes before the start and closes
+// AfterSessionBreakTime minutes after the end, wrapping past midnight.
+func (ts *TimeSlot) EffectiveWindow() (domain.Time24h, domain.Time24h, error) {
+	start, err := ts.Start.ParseTime()
+	if err != nil {
+		return "", "", err
+	}
+	effectiveStart := start.Add(-time.Duration(ts.AdvanceNotice) * time.Minute)
+	effectiveEnd := start.Add(time.Duration(int(ts.Duration)+int(ts.AfterSessionBreakTime)) * time.Minute)
+	return domain.Time24h(effectiveStart.Format(domain.Time24hLayout)),
+		domain.Time24h(effectiveEnd.Format(domain.Time24hLayout)),
+		nil
+}
+
+// TimeSlotView is the JSON shape of a time slot: the stored fields plus the
+// computed session end and effective window. Types embedding a TimeSlot marshal
+// through it so their own fields stay next to the slot's.
+type TimeSlotView struct {
+	storedTimeSlot
+	EndTime        domain.Time24h `json:"endTime,omitempty"`
+	EffectiveStart domain.Time24h `json:"effectiveStart,omitempty"`
+	EffectiveEnd   domain.Time24h `json:"effectiveEnd,omitempty"`
+}
+
+// storedTimeSlot drops TimeSlot's methods so the view doesn't marshal itself.
+type storedTimeSlot TimeSlot
+
+// View returns the slot with its computed times. A slot without a parsable
+// start is returned without them.
+func (ts TimeSlot) View() TimeSlotView {
+	view := TimeSlotView{storedTimeSlot: storedTimeSlot(ts)}
+	if end, err := ts.EndTime(); err == nil {
+		view.EndTime = end
+	}
+	if start, end, err := ts.EffectiveWindow(); err == nil {
+		view.EffectiveStart = start
+		view.EffectiveEnd = end
+	}
+	return view
+}
+
+func (ts TimeSlot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ts.View())
+}
+
 // ApplyToDate returns the start and end times of the time slot for a given date.
+// The date's year, month and day are read as a local date in the slot's
+// timezone, which resolves the offset for that specific date so the UTC window
+// follows DST transitions. The timezone must be valid, see Location.
 func (ts *TimeSlot) ApplyToDate(date time.Time) (domain.UTCTimestamp, domain.UTCTimestamp) {
-	slotStartTime, err := ts.Start.ParseTime()
+	return applyWindowToDate(ts.Start, ts.Duration, mustLoadLocation(ts.Timezone), date)
+}
+
+// OccurrenceOn returns the window of the slot's weekly occurrence that starts
+// on the given UTC day. The slot's day of week is local to its timezone, so
+// the occurrence may fall on a neighbouring local date. ok is false when no
+// occurrence starts that day.
+func (ts *TimeSlot) OccurrenceOn(utcDay time.Time) (start, end domain.UTCTimestamp, ok bool) {
+	return occurrenceOn(ts.DayOfWeek, ts.ApplyToDate, utcDay)
+}
+
+// Location returns the slot's timezone, or UTC when none is set. It fails with
+// ErrInvalidTimezoneName when the stored name cannot be loaded.
+func (ts *TimeSlot) Location() (*time.Location, error) {
+	return loadLocation(ts.Timezone)
+}
+
+func applyWindowToDate(
+	startTime domain.Time24h,
+	duration domain.DurationMinutes,
+	location *time.Location,
+	date time.Time,
+) (domain.UTCTimestamp, domain.UTCTimestamp) {
+	parsedStart, err := startTime.ParseTime()
 	if err != nil {
 		panic(err)
 	}
-	start := time.Date(date.Year(), date.Month(), date.Day(), slotStartTime.Hour(), slotStartTime.Minute(), 0, 0, time.UTC)
-	end := start.Add(time.Duration(ts.Duration) * time.Minute)
+	start := time.Date(date.Year(), date.Month(), date.Day(), parsedStart.Hour(), parsedStart.Minute(), 0, 0, location).UTC()
+	end := start.Add(time.Duration(duration) * time.Minute)
 	return domain.UTCTimestamp(start), domain.UTCTimestamp(end)
 }
+
+func occurrenceOn(
+	dayOfWeek DayOfWeek,
+	applyToDate func(time.Time) (domain.UTCTimestamp, domain.UTCTimestamp),
+	utcDay time.Time,
+) (domain.UTCTimestamp, domain.UTCTimestamp, bool) {
+	utcDay = utcDay.UTC()
+	for _, localDate := range []time.Time{utcDay.AddDate(0, 0, -1), utcDay, utcDay.AddDate(0, 0, 1)} {
+		if MapToDayOfWeek(localDate.Weekday()) != dayOfWeek {
+			continue
+		}
+		start, end := applyToDate(localDate)
+		if start.Time().Format(time.DateOnly) == utcDay.Format(time.DateOnly) {
+			return start, end, true
+		}
+	}
+	return domain.UTCTimestamp{}, domain.UTCTimestamp{}, false
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezoneName
+	}
+	return location, nil
+}
+
+// mustLoadLocation panics on an invalid timezone. Timezones are validated
+// when written and when read back by the repositories, so this is a bug.
+func mustLoadLocation(timezone string) *time.Location {
+	location, err := loadLocation(timezone)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
@@ -0,0 +1,180 @@
+package timeslot
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+func TestApplyToDateAcrossDSTBoundary(t *testing.T) {
+	slot := &TimeSlot{
+		DayOfWeek: DayOfWeekMonday,
+		Start:     "09:00",
+		Duration:  60,
+		Timezone:  "America/New_York",
+	}
+
+	// DST starts in New York on Sunday 2025-03-09
+	beforeDST := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	afterDST := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	beforeStart, beforeEnd := slot.ApplyToDate(beforeDST)
+	afterStart, afterEnd := slot.ApplyToDate(afterDST)
+
+	expectedBeforeStart := time.Date(2025, 3, 3, 14, 0, 0, 0, time.UTC) // EST, UTC-5
+	expectedAfterStart := time.Date(2025, 3, 10, 13, 0, 0, 0, time.UTC) // EDT, UTC-4
+
+	if !beforeStart.Time().Equal(expectedBeforeStart) {
+		t.Errorf("expected start before DST %v, got %v", expectedBeforeStart, beforeStart.Time())
+	}
+	if !afterStart.Time().Equal(expectedAfterStart) {
+		t.Errorf("expected start after DST %v, got %v", expectedAfterStart, afterStart.Time())
+	}
+
+	// Same local wall-clock time, one hour earlier in UTC
+	beforeClock := beforeStart.Time().Hour()*60 + beforeStart.Time().Minute()
+	afterClock := afterStart.Time().Hour()*60 + afterStart.Time().Minute()
+	if beforeClock-afterClock != 60 {
+		t.Errorf("expected UTC window to shift by 60 minutes, got %d", beforeClock-afterClock)
+	}
+
+	if afterEnd.Time().Sub(afterStart.Time()) != time.Hour || beforeEnd.Time().Sub(beforeStart.Time()) != time.Hour {
+		t.Errorf("expected duration to stay 60 minutes across DST")
+	}
+}
+
+func TestApplyToDateWithoutTimezoneIsUTC(t *testing.T) {
+	slot := &TimeSlot{
+		DayOfWeek: DayOfWeekMonday,
+		Start:     "09:00",
+		Duration:  60,
+	}
+
+	for _, date := range []time.Time{
+		time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+	} {
+		start, _ := slot.ApplyToDate(date)
+		if start.Time().Hour() != 9 || start.Time().Minute() != 0 {
+			t.Errorf("expected 09:00 UTC on %s, got %v", date.Format(time.DateOnly), start.Time())
+		}
+	}
+}
+
+func TestOccurrenceOnAcrossLocalMidnight(t *testing.T) {
+	// Monday 01:00 in Cairo (UTC+2 in January) is Sunday 23:00 UTC
+	slot := &TimeSlot{
+		DayOfWeek: DayOfWeekMonday,
+		Start:     "01:00",
+		Duration:  60,
+		Timezone:  "Africa/Cairo",
+	}
+
+	sunday := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	start, end, ok := slot.OccurrenceOn(sunday)
+	if !ok {
+		t.Fatalf("expected an occurrence starting on UTC Sunday")
+	}
+	expectedStart := time.Date(2025, 1, 5, 23, 0, 0, 0, time.UTC)
+	if !start.Time().Equal(expectedStart) || end.Time().Sub(start.Time()) != time.Hour {
+		t.Errorf("expected %v for an hour, got %v to %v", expectedStart, start.Time(), end.Time())
+	}
+
+	if _, _, ok := slot.OccurrenceOn(sunday.AddDate(0, 0, 1)); ok {
+		t.Errorf("expected no occurrence starting on UTC Monday")
+	}
+}
+
+func TestOccurrenceOnAcrossDSTBoundary(t *testing.T) {
+	// Monday 21:00 in New York lands on Tuesday UTC, an hour earlier once
+	// DST starts on 2025-03-09
+	slot := &TimeSlot{
+		DayOfWeek: DayOfWeekMonday,
+		Start:     "21:00",
+		Duration:  60,
+		Timezone:  "America/New_York",
+	}
+
+	tests := []struct {
+		utcDay        time.Time
+		expectedStart time.Time
+	}{
+		{time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 4, 2, 0, 0, 0, time.UTC)},
+		{time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 11, 1, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		start, _, ok := slot.OccurrenceOn(tt.utcDay)
+		if !ok {
+			t.Errorf("expected an occurrence starting on %s", tt.utcDay.Format(time.DateOnly))
+			continue
+		}
+		if !start.Time().Equal(tt.expectedStart) {
+			t.Errorf("expected start %v, got %v", tt.expectedStart, start.Time())
+		}
+	}
+
+	if _, _, ok := slot.OccurrenceOn(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("expected no occurrence starting on UTC Monday")
+	}
+}
+
+func TestLocationRejectsInvalidTimezone(t *testing.T) {
+	slot := &TimeSlot{Timezone: "Mars/Olympus_Mons"}
+	if _, err := slot.Location(); err != ErrInvalidTimezoneName {
+		t.Errorf("expected ErrInvalidTimezoneName, got %v", err)
+	}
+
+	slot.Timezone = ""
+	location, err := slot.Location()
+	if err != nil || location != time.UTC {
+		t.Errorf("expected UTC without a timezone, got %v, %v", location, err)
+	}
+}
+
+func TestTimeSlotJSONIncludesEffectiveWindow(t *testing.T) {
+	slot := TimeSlot{
+		DayOfWeek:             DayOfWeekMonday,
+		Start:                 "23:00",
+		Duration:              60,
+		AdvanceNotice:         15,
+		AfterSessionBreakTime: 30,
+	}
+
+	data, err := json.Marshal(slot)
+	if err != nil {
+		t.Fatalf("failed to marshal time slot: %v", err)
+	}
+
+	var response struct {
+		Start          domain.Time24h `json:"start"`
+		EndTime        domain.Time24h `json:"endTime"`
+		EffectiveStart domain.Time24h `json:"effectiveStart"`
+		EffectiveEnd   domain.Time24h `json:"effectiveEnd"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatalf("failed to unmarshal time slot: %v", err)
+	}
+
+	if response.Start != "23:00" || response.EndTime != "00:00" {
+		t.Errorf("expected session window 23:00-00:00, got %s-%s", response.Start, response.EndTime)
+	}
+	if response.EffectiveStart != "22:45" || response.EffectiveEnd != "00:30" {
+		t.Errorf("expected effective window 22:45-00:30, got %s-%s", response.EffectiveStart, response.EffectiveEnd)
+	}
+
+	// Both windows wrap past midnight, so measure them as spans from their start
+	span := func(start, end domain.Time24h) time.Duration {
+		d := end.MustParseTime().Sub(start.MustParseTime())
+		if d < 0 {
+			d += 24 * time.Hour
+		}
+		return d
+	}
+	sessionWindow := span(response.Start, response.EndTime)
+	effectiveWindow := span(response.EffectiveStart, response.EffectiveEnd)
+	if effectiveWindow-sessionWindow != 45*time.Minute {
+		t.Errorf("expected effective window 45 minutes larger than %v, got %v", sessionWindow, effectiveWindow)
+	}
+}
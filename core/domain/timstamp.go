@@ -61,7 +61,8 @@ func (i UTCTimestamp) Format(layout string) string {
 	return time.Time(i).UTC().Format(layout)
 }
 
-// MarshalJSON implements json.Marshaler interface
+// MarshalJSON implements json.Marshaler interface.
+// Timestamps are always written as RFC3339 in UTC, e.g. "2025-06-02T10:00:00Z".
 func (i UTCTimestamp) MarshalJSON() ([]byte, error) {
 	t := time.Time(i).UTC()
 	return json.Marshal(t.Format(time.RFC3339))
@@ -71,7 +72,8 @@ func (i UTCTimestamp) String() string {
 	return time.Time(i).UTC().Format(time.RFC3339)
 }
 
-// UnmarshalJSON implements json.Unmarshaler interface
+// UnmarshalJSON implements json.Unmarshaler interface.
+// Inputs carrying a non-UTC offset (e.g. "+03:00") are normalized to UTC.
 func (i *UTCTimestamp) UnmarshalJSON(data []byte) error {
 	var timeStr string
 	if err := json.Unmarshal(data, &timeStr); err != nil {
@@ -111,6 +113,6 @@ func (i *UTCTimestamp) Scan(value interface{}) error {
 		return fmt.Errorf("unsupported type for ExpiryTime: %T", value)
 	}
 
-	*i = UTCTimestamp(result)
+	*i = UTCTimestamp(result.UTC())
 	return nil
 }
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUTCTimestamp_MarshalJSON(t *testing.T) {
+	location := time.FixedZone("UTC+3", 3*60*60)
+	timestamp := UTCTimestamp(time.Date(2025, 6, 2, 13, 0, 0, 0, location))
+
+	data, err := json.Marshal(timestamp)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	got := string(data)
+	if got != `"2025-06-02T10:00:00Z"` {
+		t.Errorf("Marshal() = %s, want %s", got, `"2025-06-02T10:00:00Z"`)
+	}
+	if !strings.HasSuffix(got, `Z"`) {
+		t.Errorf("Marshal() = %s, want a trailing Z", got)
+	}
+}
+
+func TestUTCTimestamp_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"UTC input", `"2025-06-02T10:00:00Z"`, time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), false},
+		{"Offset input is normalized", `"2025-06-02T13:00:00+03:00"`, time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), false},
+		{"Missing offset", `"2025-06-02T10:00:00"`, time.Time{}, true},
+		{"Not a string", `1748858400`, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var timestamp UTCTimestamp
+			err := json.Unmarshal([]byte(tt.input), &timestamp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := timestamp.Time()
+			if !got.Equal(tt.want) {
+				t.Errorf("Unmarshal() = %v, want %v", got, tt.want)
+			}
+			if got.Location() != time.UTC {
+				t.Errorf("Unmarshal() location = %v, want UTC", got.Location())
+			}
+		})
+	}
+}
+
+func TestUTCTimestamp_RoundTrip(t *testing.T) {
+	var timestamp UTCTimestamp
+	if err := json.Unmarshal([]byte(`"2025-06-02T13:00:00+03:00"`), &timestamp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	data, err := json.Marshal(timestamp)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `"2025-06-02T10:00:00Z"` {
+		t.Errorf("round trip = %s, want %s", data, `"2025-06-02T10:00:00Z"`)
+	}
+}
+
+func TestUTCTimestamp_ScanNormalizesToUTC(t *testing.T) {
+	location := time.FixedZone("UTC+3", 3*60*60)
+
+	var timestamp UTCTimestamp
+	if err := timestamp.Scan(time.Date(2025, 6, 2, 13, 0, 0, 0, location)); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if timestamp.Time().Location() != time.UTC || timestamp.Hour() != 10 {
+		t.Errorf("Scan() = %v, want 2025-06-02 10:00 UTC", timestamp.Time())
+	}
+}
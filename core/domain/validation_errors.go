@@ -0,0 +1,41 @@
+package domain
+
+import "strings"
+
+// FieldError is a validation failure of a single input field, named as in the
+// request body.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors collects every invalid field of an input instead of
+// stopping at the first, so forms can highlight them all at once.
+type ValidationErrors struct {
+	Errors []FieldError `json:"errors"`
+}
+
+// Add records err against field. A nil err is ignored, so validators can be
+// passed through directly.
+func (v *ValidationErrors) Add(field string, err error) {
+	if err == nil {
+		return
+	}
+	v.Errors = append(v.Errors, FieldError{Field: field, Message: err.Error()})
+}
+
+// Err returns v when any field failed and nil otherwise.
+func (v *ValidationErrors) Err() error {
+	if len(v.Errors) == 0 {
+		return nil
+	}
+	return v
+}
+
+func (v *ValidationErrors) Error() string {
+	messages := make([]string, len(v.Errors))
+	for i, fieldErr := range v.Errors {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
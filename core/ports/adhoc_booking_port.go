@@ -22,7 +22,10 @@ type AdhocBookingRepository interface {
 		states []booking.BookingState,
 		startDate, endDate time.Time,
 	) (map[domain.TherapistID][]*booking.AdhocBooking, error)
-	BulkCancel(tx SQLTx, adhocBookingIDs []domain.AdhocBookingID) error
-	Search(startDate, endDate time.Time, states []booking.BookingState) ([]*booking.AdhocBooking, error)
+	BulkCancel(tx SQLTx, adhocBookingIDs []domain.AdhocBookingID, updatedAt time.Time) error
+	// Search lists bookings starting within the date range in start time, then
+	// ID order, one page at a time. It matches every clinic when clinicID is
+	// empty.
+	Search(clinicID domain.ClinicID, startDate, endDate time.Time, states []booking.BookingState, page Page) ([]*booking.AdhocBooking, error)
 	List(filters BookingFilters) ([]*booking.AdhocBooking, error)
 }
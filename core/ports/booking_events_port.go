@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+)
+
+// BookingEventPublisher fans booking events out to subscribers. Publish must
+// not block the usecase calling it.
+type BookingEventPublisher interface {
+	Publish(event booking.Event)
+}
+
+// BookingEventSubscriber delivers the events of one therapist's bookings
+// until unsubscribe is called, which also closes the channel.
+type BookingEventSubscriber interface {
+	Subscribe(therapistID domain.TherapistID) (events <-chan booking.Event, unsubscribe func())
+}
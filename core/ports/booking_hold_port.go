@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+)
+
+type BookingHoldRepository interface {
+	CreateTx(sqlExec SQLExec, hold *booking.Hold) error
+	GetByToken(token domain.HoldToken) (*booking.Hold, error)
+	DeleteTx(sqlExec SQLExec, token domain.HoldToken) error
+	// ListExpired returns the holds expiring at or before now
+	ListExpired(now time.Time) ([]*booking.Hold, error)
+}
@@ -45,11 +45,20 @@ func (f *BookingFilters) IsValid() bool {
 	return false
 }
 
+// LeadTimeStats summarizes how long before their start time bookings were made
+type LeadTimeStats struct {
+	Count          int
+	AverageMinutes float64
+	MedianMinutes  float64
+}
+
 type BookingRepository interface {
 	GetByID(id domain.BookingID) (*booking.Booking, error)
 	Create(booking *booking.Booking) error
-	UpdateState(bookingID domain.BookingID, state booking.BookingState, updatedAt time.Time) error
-	UpdateStateTx(sqlExec SQLExec, bookingID domain.BookingID, state booking.BookingState, updatedAt time.Time) error
+	CreateTx(sqlExec SQLExec, booking *booking.Booking) error
+	UpdateState(bookingID domain.BookingID, state booking.BookingState, updatedAt time.Time, actor string) error
+	UpdateStateTx(sqlExec SQLExec, bookingID domain.BookingID, state booking.BookingState, updatedAt time.Time, actor string) error
+	ListStateChanges(bookingID domain.BookingID) ([]*booking.StateChange, error)
 	Delete(id domain.BookingID) error
 	List(filters BookingFilters) ([]*booking.Booking, error)
 	ListByTherapistForDateRange(
@@ -62,8 +71,24 @@ type BookingRepository interface {
 		states []booking.BookingState,
 		startDate, endDate time.Time,
 	) (map[domain.TherapistID][]*booking.Booking, error)
-	BulkCancel(tx SQLTx, bookingIDs []domain.BookingID) error
-	Search(startDate, endDate time.Time, states []booking.BookingState) ([]*booking.Booking, error)
+	// BulkCancel cancels the bookings, recording each state change in the
+	// audit trail like UpdateStateTx does
+	BulkCancel(tx SQLTx, bookingIDs []domain.BookingID, updatedAt time.Time, actor string) error
+	ReassignTx(sqlExec SQLExec, bookingID domain.BookingID, therapistID domain.TherapistID, timeSlotID domain.TimeSlotID, updatedAt time.Time) error
+	UpdateDurationTx(sqlExec SQLExec, bookingID domain.BookingID, duration domain.DurationMinutes, updatedAt time.Time) error
+	// Search lists bookings starting within the date range in start time, then
+	// ID order, one page at a time. It matches every clinic when clinicID is
+	// empty, as do the counts.
+	Search(clinicID domain.ClinicID, startDate, endDate time.Time, states []booking.BookingState, page Page) ([]*booking.Booking, error)
+	ListByTimeSlot(timeSlotID domain.TimeSlotID, states []booking.BookingState) ([]*booking.Booking, error)
+	// CountActiveByTimeSlot counts the slot's pending, held and confirmed
+	// bookings that have not started yet
+	CountActiveByTimeSlot(timeSlotID domain.TimeSlotID) (int, error)
+	CountByState(clinicID domain.ClinicID, startDate, endDate time.Time) (map[booking.BookingState]int, error)
+	CountByTherapist(clinicID domain.ClinicID, startDate, endDate time.Time) (map[domain.TherapistID]int, error)
+	// LeadTimeStats aggregates the lead time (created_at to start_time) of the
+	// therapist's confirmed bookings starting within the date range.
+	LeadTimeStats(therapistID domain.TherapistID, startDate, endDate time.Time) (*LeadTimeStats, error)
 }
 
 type BookingResponse struct {
@@ -75,4 +100,8 @@ type BookingResponse struct {
 	StartTime            domain.UTCTimestamp    `json:"startTime"` // ISO 8601 datetime, e.g. "2024-06-01T09:00:00Z"
 	Duration             domain.DurationMinutes `json:"duration"`
 	ClientTimezoneOffset domain.TimezoneOffset  `json:"clientTimezoneOffset"` // Frontend hint for timezone adjustments. TODO: add an offset for therapist and an offset for patient
+	PaidAmount           int                    `json:"paidAmount,omitempty"` // Only set on confirmation
+	Currency             domain.Currency        `json:"currency,omitempty"`   // Only set on confirmation
+	SessionID            domain.SessionID       `json:"sessionId,omitempty"`  // Only set on confirmation
+	MeetingURL           *string                `json:"meetingUrl,omitempty"` // Only set on confirmation, empty until a link is added
 }
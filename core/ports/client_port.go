@@ -1,6 +1,8 @@
 package ports
 
 import (
+	"time"
+
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/client"
 )
@@ -9,8 +11,23 @@ type ClientRepository interface {
 	Create(client *client.Client) error
 	FindByIDs(ids []domain.ClientID) ([]*client.Client, error)
 	GetByWhatsAppNumber(whatsAppNumber domain.WhatsAppNumber) (*client.Client, error)
-	List() ([]*client.Client, error)
+	GetByEmail(email domain.Email) (*client.Client, error)
+	// List matches every clinic when clinicID is empty
+	List(clinicID domain.ClinicID) ([]*client.Client, error)
 	Update(client *client.Client) error
 	Delete(id domain.ClientID) error
 	UpdateTimezoneOffset(id domain.ClientID, offsetMinutes domain.TimezoneOffset) error
+	// GetSummary counts the client's bookings starting after now and totals
+	// their sessions by state
+	GetSummary(id domain.ClientID, now time.Time) (*client.Summary, error)
+	// MoveBookingsTx moves every regular and adhoc booking of from over to to,
+	// returning how many moved. It fails with client.ErrClientBookingsOverlap
+	// when both clients have a confirmed booking with a therapist at one time.
+	MoveBookingsTx(sqlExec SQLExec, from, to domain.ClientID) (int, error)
+	// MoveSessionsTx moves every session of from over to to, returning how
+	// many moved
+	MoveSessionsTx(sqlExec SQLExec, from, to domain.ClientID) (int, error)
+	// SoftDeleteTx hides the client from every lookup while keeping its row,
+	// freeing its email and WhatsApp number for other clients
+	SoftDeleteTx(sqlExec SQLExec, id domain.ClientID, deletedAt time.Time) error
 }
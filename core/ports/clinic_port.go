@@ -0,0 +1,16 @@
+package ports
+
+import "github.com/mishkahtherapy/brain/core/domain"
+
+// ClinicRepository looks up the clinic owning a record. Bookings, holds and
+// sessions belong to the clinic of their therapist. Records that don't exist
+// have no clinic.
+type ClinicRepository interface {
+	GetTherapistClinic(id domain.TherapistID) (domain.ClinicID, error)
+	GetClientClinic(id domain.ClientID) (domain.ClinicID, error)
+	GetSpecializationClinic(id domain.SpecializationID) (domain.ClinicID, error)
+	GetBookingClinic(id domain.BookingID) (domain.ClinicID, error)
+	GetAdhocBookingClinic(id domain.AdhocBookingID) (domain.ClinicID, error)
+	GetHoldClinic(token domain.HoldToken) (domain.ClinicID, error)
+	GetSessionClinic(id domain.SessionID) (domain.ClinicID, error)
+}
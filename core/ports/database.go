@@ -23,6 +23,10 @@ type SQLDatabase interface {
 	Exec(query string, args ...any) (sql.Result, error)
 	Begin() (SQLTx, error)
 	Close() error
+	// Reader is the connection for read-only queries that tolerate replica
+	// lag, e.g. listings and reports. It is the primary connection unless a
+	// read replica is configured.
+	Reader() SQLExec
 }
 
 // TODO: Apply transactions to repos
@@ -31,3 +35,10 @@ type TransactionPort interface {
 	Commit(tx SQLTx) error
 	Rollback(tx SQLTx) error
 }
+
+// UnitOfWork runs several repository writes atomically. fn passes the shared
+// transaction to the repositories' ...Tx methods; it is committed when fn
+// returns nil and rolled back otherwise.
+type UnitOfWork interface {
+	RunInTx(fn func(tx SQLTx) error) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "time"
+
+// Page selects a slice of rows ordered by start time, then ID. Paging by
+// key instead of offset keeps pages stable while rows are added.
+type Page struct {
+	Limit int         // Zero returns every remaining row
+	After *PageCursor // Nil starts from the first row
+}
+
+// PageCursor is the position of the last row of the previous page
+type PageCursor struct {
+	StartTime time.Time
+	ID        string
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+)
+
+type RecurringBlockRepository interface {
+	GetByID(id domain.RecurringBlockID) (*timeslot.RecurringBlock, error)
+	Create(block *timeslot.RecurringBlock) error
+	Update(block *timeslot.RecurringBlock) error
+	Delete(id domain.RecurringBlockID) error
+	ListByTherapist(therapistID domain.TherapistID) ([]*timeslot.RecurringBlock, error)
+	BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.RecurringBlock, error)
+}
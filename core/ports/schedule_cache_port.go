@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/schedule"
+)
+
+// ScheduleCacheKey identifies the computed availability of one day.
+// Date is formatted as YYYY-MM-DD.
+type ScheduleCacheKey struct {
+	ClinicID          domain.ClinicID
+	SpecializationTag string
+	MustSpeakEnglish  bool
+	Date              string
+}
+
+type ScheduleCache interface {
+	Get(key ScheduleCacheKey) ([]schedule.AvailableTimeRange, bool)
+	Set(key ScheduleCacheKey, ranges []schedule.AvailableTimeRange)
+	InvalidateDate(date time.Time)
+	InvalidateAll()
+}
@@ -9,10 +9,31 @@ import (
 type SessionRepository interface {
 	CreateSession(tx SQLTx, session *domain.Session) error
 	GetSessionByID(id domain.SessionID) (*domain.Session, error)
+	// GetSessionByRegularBookingID and GetSessionByAdhocBookingID return the
+	// session created when the booking was confirmed, or nil if there is none.
+	GetSessionByRegularBookingID(bookingID domain.BookingID) (*domain.Session, error)
+	GetSessionByAdhocBookingID(bookingID domain.AdhocBookingID) (*domain.Session, error)
 	UpdateSessionState(id domain.SessionID, state domain.SessionState) error
 	UpdateSessionNotes(id domain.SessionID, notes string) error
 	UpdateMeetingURL(id domain.SessionID, meetingURL string) error
-	ListSessionsByTherapist(therapistID domain.TherapistID) ([]*domain.Session, error)
+	// ListSessionsByTherapist lists the therapist's sessions in any of the
+	// given states. No states lists sessions in every state.
+	ListSessionsByTherapist(therapistID domain.TherapistID, states []domain.SessionState) ([]*domain.Session, error)
 	ListSessionsByClient(clientID domain.ClientID) ([]*domain.Session, error)
-	ListSessionsAdmin(startDate, endDate time.Time) ([]*domain.Session, error)
+	// ListSessionsAdmin lists sessions starting within the date range. A zero
+	// updatedFrom or updatedTo leaves that side of the updated_at filter open.
+	// It matches every clinic when clinicID is empty, as does
+	// ListMissingMeetingURL.
+	ListSessionsAdmin(clinicID domain.ClinicID, startDate, endDate, updatedFrom, updatedTo time.Time) ([]*domain.Session, error)
+	// ListMissingMeetingURL lists planned sessions starting within the date
+	// range that have no meeting URL yet, ordered by start time.
+	ListMissingMeetingURL(clinicID domain.ClinicID, startDate, endDate time.Time) ([]*domain.Session, error)
+	// CancelPlannedByBookingIDs cancels the planned sessions of the bookings
+	CancelPlannedByBookingIDs(tx SQLTx, bookingIDs []domain.BookingID, adhocBookingIDs []domain.AdhocBookingID, updatedAt time.Time) error
+	// ListPlannedStartedBefore lists planned sessions starting before the
+	// given time, ordered by start time.
+	ListPlannedStartedBefore(before time.Time) ([]*domain.Session, error)
+	// MarkNoShow moves the sessions that are still planned to no_show and
+	// returns how many were moved. Sessions in any other state are skipped.
+	MarkNoShow(ids []domain.SessionID, updatedAt time.Time) (int, error)
 }
@@ -5,10 +5,41 @@ import (
 	"github.com/mishkahtherapy/brain/core/domain/specialization"
 )
 
+// SpecializationTherapistCount is a specialization along with the number of
+// therapists offering it.
+type SpecializationTherapistCount struct {
+	specialization.Specialization
+	TherapistCount int `json:"therapistCount"`
+}
+
+// SpecializationTherapist is the public profile of a therapist offering a
+// specialization, as listed by the booking UI.
+type SpecializationTherapist struct {
+	ID            domain.TherapistID    `json:"id"`
+	Name          string                `json:"name"`
+	Bio           string                `json:"bio"`
+	PhotoURL      string                `json:"photoUrl"`
+	SpeaksEnglish bool                  `json:"speaksEnglish"`
+	Languages     []domain.LanguageCode `json:"languages"`
+}
+
 type SpecializationRepository interface {
 	Create(specialization *specialization.Specialization) error
 	GetByID(id domain.SpecializationID) (*specialization.Specialization, error)
-	GetByName(name string) (*specialization.Specialization, error)
+	GetByName(clinicID domain.ClinicID, name string) (*specialization.Specialization, error)
 	BulkGetByIds(ids []domain.SpecializationID) (map[domain.SpecializationID]*specialization.Specialization, error)
-	GetAll() ([]*specialization.Specialization, error)
+	// GetAll matches every clinic when clinicID is empty
+	GetAll(clinicID domain.ClinicID) ([]*specialization.Specialization, error)
+	// GetAllWithTherapistCounts includes specializations no therapist offers
+	// with a zero count. It matches every clinic when clinicID is empty.
+	GetAllWithTherapistCounts(clinicID domain.ClinicID) ([]*SpecializationTherapistCount, error)
+	// ListTherapists returns the therapists offering the specialization,
+	// ordered by name.
+	ListTherapists(id domain.SpecializationID) ([]*SpecializationTherapist, error)
+	// ReassignTherapistsTx moves every therapist offering from over to to.
+	// Therapists already offering to just lose their from link. Returns how
+	// many therapists were moved.
+	ReassignTherapistsTx(sqlExec SQLExec, from, to domain.SpecializationID) (int, error)
+	// DeleteTx removes a specialization no therapist offers anymore
+	DeleteTx(sqlExec SQLExec, id domain.SpecializationID) error
 }
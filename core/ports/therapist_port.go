@@ -12,10 +12,22 @@ type TherapistRepository interface {
 	Create(therapist *therapist.Therapist) error
 	Update(therapist *therapist.Therapist) error
 	UpdateSpecializations(therapistID domain.TherapistID, specializationIDs []domain.SpecializationID) error
+	UpdateLanguages(therapistID domain.TherapistID, languages []domain.LanguageCode) error
 	UpdateDevice(therapistID domain.TherapistID, deviceID domain.DeviceID, deviceIDUpdatedAt domain.UTCTimestamp) error
 	UpdateTimezoneOffset(therapistID domain.TherapistID, timezoneOffset domain.TimezoneOffset) error
+	GetNotificationPreferences(therapistID domain.TherapistID) (therapist.NotificationPreferences, error)
+	UpdateNotificationPreferences(therapistID domain.TherapistID, preferences therapist.NotificationPreferences) error
+	// GetMaxDailySessions returns how many bookings the therapist takes per
+	// day, zero when there is no cap
+	GetMaxDailySessions(therapistID domain.TherapistID) (int, error)
+	UpdateMaxDailySessions(therapistID domain.TherapistID, maxDailySessions int) error
 	Delete(id domain.TherapistID) error
-	List() ([]*therapist.Therapist, error)
-	FindBySpecializationAndLanguage(specializationName string, mustSpeakEnglish bool) ([]*therapist.Therapist, error)
+	// List matches every clinic when clinicID is empty
+	List(clinicID domain.ClinicID) ([]*therapist.Therapist, error)
+	// FindBySpecializationAndLanguage matches every clinic when clinicID is
+	// empty and any language when languageCode is empty
+	FindBySpecializationAndLanguage(clinicID domain.ClinicID, specializationName string, languageCode domain.LanguageCode) ([]*therapist.Therapist, error)
 	FindByIDs(therapistIDs []domain.TherapistID) ([]*therapist.Therapist, error)
+	// FindByDeviceID matches every clinic when clinicID is empty
+	FindByDeviceID(clinicID domain.ClinicID, deviceID domain.DeviceID) ([]*therapist.Therapist, error)
 }
@@ -1,6 +1,8 @@
 package ports
 
 import (
+	"time"
+
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/timeslot"
 )
@@ -8,9 +10,18 @@ import (
 type TimeSlotRepository interface {
 	GetByID(id domain.TimeSlotID) (*timeslot.TimeSlot, error)
 	Create(timeslot *timeslot.TimeSlot) error
+	CreateTx(sqlExec SQLExec, timeslot *timeslot.TimeSlot) error
 	Update(timeslot *timeslot.TimeSlot) error
 	Delete(id domain.TimeSlotID) error
+	// BulkDeleteTx deletes all given timeslots, failing if any is missing.
+	BulkDeleteTx(sqlExec SQLExec, ids []domain.TimeSlotID) error
+	// ListActiveBookingIDsTx returns the pending, held or confirmed bookings
+	// of the timeslot that start after the given time.
+	ListActiveBookingIDsTx(sqlExec SQLExec, id domain.TimeSlotID, after time.Time) ([]domain.BookingID, error)
 	ListByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error)
+	ListActiveByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error)
 	BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.TimeSlot, error)
-	BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) error
+	// BulkToggleByTherapistID returns the number of timeslots whose state changed.
+	BulkToggleByTherapistID(therapistID domain.TherapistID, isActive bool) (int, error)
+	SetActive(id domain.TimeSlotID, isActive bool) error
 }
@@ -1,6 +1,8 @@
 package cancel_booking
 
 import (
+	"time"
+
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/booking"
 	"github.com/mishkahtherapy/brain/core/ports"
@@ -9,14 +11,27 @@ import (
 
 type Input struct {
 	BookingID domain.BookingID `json:"bookingId"`
+	Actor     string           `json:"-"` // Recorded in the booking's audit trail
+	// Override lets an admin cancel within the cancellation cutoff
+	Override bool `json:"-"`
 }
 
 type Usecase struct {
-	bookingRepo ports.BookingRepository
+	bookingRepo        ports.BookingRepository
+	eventPublisher     ports.BookingEventPublisher
+	cancellationCutoff time.Duration
 }
 
-func NewUsecase(bookingRepo ports.BookingRepository) *Usecase {
-	return &Usecase{bookingRepo: bookingRepo}
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	eventPublisher ports.BookingEventPublisher,
+	cancellationCutoff time.Duration, // zero allows cancelling at any time
+) *Usecase {
+	return &Usecase{
+		bookingRepo:        bookingRepo,
+		eventPublisher:     eventPublisher,
+		cancellationCutoff: cancellationCutoff,
+	}
 }
 
 func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
@@ -36,17 +51,28 @@ func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
 		return nil, common.ErrInvalidStateTransition
 	}
 
+	// Enforce the cancellation policy unless an admin overrides it
+	now := time.Now().UTC()
+	if u.cancellationCutoff > 0 && !input.Override &&
+		existingBooking.StartTime.Time().Sub(now) < u.cancellationCutoff {
+		return nil, booking.ErrWithinCancellationCutoff
+	}
+
 	// Change state to Cancelled
-	updatedAt := domain.NewUTCTimestamp().Time()
 	err = u.bookingRepo.UpdateState(
 		existingBooking.ID,
 		booking.BookingStateCancelled,
-		updatedAt,
+		now,
+		input.Actor,
 	)
 	if err != nil {
 		return nil, common.ErrFailedToCancelBooking
 	}
 
+	if u.eventPublisher != nil {
+		u.eventPublisher.Publish(booking.NewEvent(booking.EventTypeCancelled, existingBooking, booking.BookingStateCancelled))
+	}
+
 	return &ports.BookingResponse{
 		RegularBookingID:     existingBooking.ID,
 		TherapistID:          existingBooking.TherapistID,
@@ -0,0 +1,64 @@
+package cancel_booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestCancelBookingCutoff(t *testing.T) {
+	cutoff := 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		startsIn    time.Duration
+		override    bool
+		expectedErr error
+	}{
+		{
+			name:     "cancels a booking outside the cutoff",
+			startsIn: 48 * time.Hour,
+		},
+		{
+			name:        "blocks a booking within the cutoff",
+			startsIn:    2 * time.Hour,
+			expectedErr: booking.ErrWithinCancellationCutoff,
+		},
+		{
+			name:     "override cancels a booking within the cutoff",
+			startsIn: 2 * time.Hour,
+			override: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := &booking.Booking{
+				ID:        "booking_1",
+				StartTime: domain.UTCTimestamp(time.Now().UTC().Add(test.startsIn)),
+				Duration:  60,
+				State:     booking.BookingStateConfirmed,
+			}
+			bookingRepo := &fakes.BookingRepo{Bookings: []*booking.Booking{existing}}
+
+			_, err := NewUsecase(bookingRepo, nil, cutoff).Execute(Input{
+				BookingID: existing.ID,
+				Override:  test.override,
+			})
+			if err != test.expectedErr {
+				t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+			}
+
+			expectedState := booking.BookingStateCancelled
+			if test.expectedErr != nil {
+				expectedState = booking.BookingStateConfirmed
+			}
+			if existing.State != expectedState {
+				t.Errorf("expected booking to be %s, got %s", expectedState, existing.State)
+			}
+		})
+	}
+}
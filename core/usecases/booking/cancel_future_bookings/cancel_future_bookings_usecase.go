@@ -0,0 +1,206 @@
+package cancel_future_bookings
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/common/whatsapp_link"
+)
+
+// {clientName} and {startTime} are replaced when the link is generated.
+const cancellationMessageTemplate = "Hello {clientName}, we're sorry to let you know that your session on {startTime} has been cancelled."
+
+// Far enough ahead to cover every booking that can still be made
+const futureHorizon = 10 * 365 * 24 * time.Hour
+
+type Input struct {
+	TherapistID domain.TherapistID
+	Reason      string // Optional, included in the message sent to clients
+	Actor       string // Recorded in the bookings' audit trail
+}
+
+// CancelledBooking describes a cancelled booking. Clients have no app to
+// notify, so the WhatsApp link carries the cancellation message to send them.
+type CancelledBooking struct {
+	RegularBookingID domain.BookingID      `json:"regularBookingId,omitempty"`
+	AdhocBookingID   domain.AdhocBookingID `json:"adhocBookingId,omitempty"`
+	ClientID         domain.ClientID       `json:"clientId"`
+	StartTime        domain.UTCTimestamp   `json:"startTime"`
+	WhatsAppLink     string                `json:"whatsAppLink,omitempty"`
+
+	clientTimezoneOffset domain.TimezoneOffset
+}
+
+type Output struct {
+	Count             int                `json:"count"`
+	CancelledBookings []CancelledBooking `json:"cancelledBookings"`
+}
+
+type Usecase struct {
+	bookingRepo      ports.BookingRepository
+	adhocBookingRepo ports.AdhocBookingRepository
+	sessionRepo      ports.SessionRepository
+	therapistRepo    ports.TherapistRepository
+	clientRepo       ports.ClientRepository
+	transactionPort  ports.TransactionPort
+	eventPublisher   ports.BookingEventPublisher
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	adhocBookingRepo ports.AdhocBookingRepository,
+	sessionRepo ports.SessionRepository,
+	therapistRepo ports.TherapistRepository,
+	clientRepo ports.ClientRepository,
+	transactionPort ports.TransactionPort,
+	eventPublisher ports.BookingEventPublisher, // optional, nil publishes no events
+) *Usecase {
+	return &Usecase{
+		bookingRepo:      bookingRepo,
+		adhocBookingRepo: adhocBookingRepo,
+		sessionRepo:      sessionRepo,
+		therapistRepo:    therapistRepo,
+		clientRepo:       clientRepo,
+		transactionPort:  transactionPort,
+		eventPublisher:   eventPublisher,
+	}
+}
+
+// Execute cancels every pending, held or confirmed booking of the therapist that
+// has not started yet, e.g. when the therapist leaves.
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if input.TherapistID == "" {
+		return nil, common.ErrTherapistIDIsRequired
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	now := time.Now().UTC()
+	states := []booking.BookingState{booking.BookingStatePending, booking.BookingStateHeld, booking.BookingStateConfirmed}
+	therapistIDs := []domain.TherapistID{input.TherapistID}
+
+	bookingMap, err := u.bookingRepo.BulkListByTherapistForDateRange(therapistIDs, states, now, now.Add(futureHorizon))
+	if err != nil {
+		return nil, common.ErrFailedToListBookings
+	}
+	adhocBookingMap, err := u.adhocBookingRepo.BulkListByTherapistForDateRange(therapistIDs, states, now, now.Add(futureHorizon))
+	if err != nil {
+		return nil, common.ErrFailedToListBookings
+	}
+
+	// The date range also matches bookings in progress, skip them
+	bookingIDs := make([]domain.BookingID, 0)
+	cancelledBookings := make([]*booking.Booking, 0)
+	adhocBookingIDs := make([]domain.AdhocBookingID, 0)
+	cancelled := make([]CancelledBooking, 0)
+	for _, b := range bookingMap[input.TherapistID] {
+		if !b.StartTime.Time().After(now) {
+			continue
+		}
+		bookingIDs = append(bookingIDs, b.ID)
+		cancelledBookings = append(cancelledBookings, b)
+		cancelled = append(cancelled, CancelledBooking{
+			RegularBookingID:     b.ID,
+			ClientID:             b.ClientID,
+			StartTime:            b.StartTime,
+			clientTimezoneOffset: b.ClientTimezoneOffset,
+		})
+	}
+	for _, b := range adhocBookingMap[input.TherapistID] {
+		if !b.StartTime.Time().After(now) {
+			continue
+		}
+		adhocBookingIDs = append(adhocBookingIDs, b.ID)
+		cancelled = append(cancelled, CancelledBooking{
+			AdhocBookingID:       b.ID,
+			ClientID:             b.ClientID,
+			StartTime:            b.StartTime,
+			clientTimezoneOffset: b.ClientTimezoneOffset,
+		})
+	}
+
+	if len(cancelled) == 0 {
+		return &Output{Count: 0, CancelledBookings: cancelled}, nil
+	}
+
+	// ------------------
+	// Cancel bookings (run in a transaction)
+	// ------------------
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bookingIDs) > 0 {
+		if err := u.bookingRepo.BulkCancel(tx, bookingIDs, now, input.Actor); err != nil {
+			tx.Rollback()
+			return nil, common.ErrFailedToCancelBooking
+		}
+	}
+	if len(adhocBookingIDs) > 0 {
+		if err := u.adhocBookingRepo.BulkCancel(tx, adhocBookingIDs, now); err != nil {
+			tx.Rollback()
+			return nil, common.ErrFailedToCancelBooking
+		}
+	}
+	// Sessions of confirmed bookings would otherwise stay planned
+	if err := u.sessionRepo.CancelPlannedByBookingIDs(tx, bookingIDs, adhocBookingIDs, now); err != nil {
+		tx.Rollback()
+		return nil, common.ErrFailedToCancelBooking
+	}
+
+	if err := u.transactionPort.Commit(tx); err != nil {
+		return nil, err
+	}
+	// ------------------
+
+	if u.eventPublisher != nil {
+		for _, b := range cancelledBookings {
+			u.eventPublisher.Publish(booking.NewEvent(booking.EventTypeCancelled, b, booking.BookingStateCancelled))
+		}
+	}
+
+	u.attachWhatsAppLinks(cancelled, input.Reason)
+
+	return &Output{Count: len(cancelled), CancelledBookings: cancelled}, nil
+}
+
+func (u *Usecase) attachWhatsAppLinks(cancelled []CancelledBooking, reason string) {
+	clientIDs := make([]domain.ClientID, 0, len(cancelled))
+	for _, c := range cancelled {
+		clientIDs = append(clientIDs, c.ClientID)
+	}
+
+	// The bookings are already cancelled, so a missing link is not fatal
+	clients, err := u.clientRepo.FindByIDs(clientIDs)
+	if err != nil {
+		slog.Warn("failed to get clients of cancelled bookings", "error", err)
+		return
+	}
+	clientMap := make(map[domain.ClientID]*client.Client, len(clients))
+	for _, c := range clients {
+		clientMap[c.ID] = c
+	}
+
+	for i := range cancelled {
+		c, ok := clientMap[cancelled[i].ClientID]
+		if !ok {
+			continue
+		}
+
+		// The reason is admin input, append it after the placeholders are
+		// filled so it is sent as written
+		message := whatsapp_link.Message(cancellationMessageTemplate, c.Name, cancelled[i].StartTime, cancelled[i].clientTimezoneOffset)
+		if reason != "" {
+			message += " Reason: " + reason
+		}
+		cancelled[i].WhatsAppLink = whatsapp_link.Link(c.WhatsAppNumber, message)
+	}
+}
@@ -11,47 +11,67 @@ import (
 type PendingBookingConflictResolver struct {
 	bookingRepo      ports.BookingRepository
 	adhocBookingRepo ports.AdhocBookingRepository
+	eventPublisher   ports.BookingEventPublisher
 }
 
 func NewPendingBookingConflictResolver(
 	bookingRepo ports.BookingRepository,
 	adhocBookingRepo ports.AdhocBookingRepository,
+	eventPublisher ports.BookingEventPublisher, // optional, nil publishes no events
 ) *PendingBookingConflictResolver {
 	return &PendingBookingConflictResolver{
 		bookingRepo:      bookingRepo,
 		adhocBookingRepo: adhocBookingRepo,
+		eventPublisher:   eventPublisher,
 	}
 }
 
+// CancelConflicts fails when the slot already holds capacity confirmed
+// bookings at this time, and cancels the other pending bookings once this
+// confirmation takes the last seat. Capacity is 1 outside group sessions.
+// It returns the regular bookings it cancelled, to be handed to
+// PublishCancellations once the transaction commits.
 func (c *PendingBookingConflictResolver) CancelConflicts(tx ports.SQLTx,
 	therapistID domain.TherapistID,
 	bookingStartTime domain.UTCTimestamp,
 	bookingDuration domain.DurationMinutes,
 	adhocBookingID domain.AdhocBookingID,
 	regularBookingID domain.BookingID,
-) error {
+	capacity int,
+) ([]*booking.Booking, error) {
 	// Get other bookings at the same time
 	startTime := time.Time(bookingStartTime)
 	endTime := startTime.Add(time.Duration(bookingDuration) * time.Minute)
 
-	therapistBookings, err := c.cancelRegularBookings(tx, regularBookingID, therapistID, startTime, endTime)
+	therapistBookings, slotFilled, err := c.cancelRegularBookings(tx, regularBookingID, therapistID, startTime, endTime, max(capacity, 1))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	adhocBookings, err := c.cancelAdhocBookings(tx, adhocBookingID, therapistID, startTime, endTime)
+	adhocBookings, err := c.cancelAdhocBookings(tx, adhocBookingID, therapistID, startTime, endTime, slotFilled)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// If there are no adhoc bookings, return nil. Don't need to notify operators in this case.
 	if len(adhocBookings) == 0 && len(therapistBookings) == 0 {
-		return nil
+		return therapistBookings, nil
 	}
 
 	// TODO: notify operators with cancellations.
 
-	return nil
+	return therapistBookings, nil
+}
+
+// PublishCancellations publishes a cancellation event for every regular
+// booking CancelConflicts cancelled
+func (c *PendingBookingConflictResolver) PublishCancellations(cancelled []*booking.Booking) {
+	if c.eventPublisher == nil {
+		return
+	}
+	for _, b := range cancelled {
+		c.eventPublisher.Publish(booking.NewEvent(booking.EventTypeCancelled, b, booking.BookingStateCancelled))
+	}
 }
 
 func (c *PendingBookingConflictResolver) cancelRegularBookings(tx ports.SQLTx,
@@ -59,43 +79,59 @@ func (c *PendingBookingConflictResolver) cancelRegularBookings(tx ports.SQLTx,
 	therapistID domain.TherapistID,
 	startTime time.Time,
 	endTime time.Time,
-) ([]*booking.Booking, error) {
+	capacity int,
+) ([]*booking.Booking, bool, error) {
 
 	therapistBookings, err := c.bookingRepo.ListByTherapistForDateRange(
 		therapistID,
-		[]booking.BookingState{booking.BookingStatePending, booking.BookingStateConfirmed},
+		[]booking.BookingState{booking.BookingStatePending, booking.BookingStateConfirmed, booking.BookingStateHeld},
 		startTime,
 		endTime,
 	)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	// If any booking is already confirmed, return an error
+	// If the slot is already full of confirmed bookings, return an error.
+	// Held bookings keep their seat like confirmed ones.
+	confirmed := 0
 	for _, b := range therapistBookings {
-		if b.State == booking.BookingStateConfirmed {
-			return nil, booking.ErrBookingAlreadyConfirmed
+		if b.ID == toBeConfirmedBookingID {
+			continue
+		}
+		if b.State == booking.BookingStateConfirmed || b.State == booking.BookingStateHeld {
+			confirmed++
 		}
 	}
+	if confirmed >= capacity {
+		return nil, false, booking.ErrBookingAlreadyConfirmed
+	}
+
+	// Seats are still left after this one, keep the other pending bookings
+	if confirmed+1 < capacity {
+		return []*booking.Booking{}, false, nil
+	}
 
 	toBeCancelled := make([]domain.BookingID, 0)
+	cancelled := make([]*booking.Booking, 0)
 	for _, b := range therapistBookings {
-		if b.ID == toBeConfirmedBookingID {
+		if b.ID == toBeConfirmedBookingID || b.State != booking.BookingStatePending {
 			continue
 		}
 		toBeCancelled = append(toBeCancelled, b.ID)
+		cancelled = append(cancelled, b)
 	}
 
 	if len(toBeCancelled) == 0 {
-		return []*booking.Booking{}, nil
+		return []*booking.Booking{}, true, nil
 	}
 
 	// Cancel the bookings
-	err = c.bookingRepo.BulkCancel(tx, toBeCancelled)
+	err = c.bookingRepo.BulkCancel(tx, toBeCancelled, time.Now().UTC(), "")
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return therapistBookings, nil
+	return cancelled, true, nil
 }
 
 func (c *PendingBookingConflictResolver) cancelAdhocBookings(
@@ -104,6 +140,7 @@ func (c *PendingBookingConflictResolver) cancelAdhocBookings(
 	therapistID domain.TherapistID,
 	startTime time.Time,
 	endTime time.Time,
+	slotFilled bool,
 ) ([]*booking.AdhocBooking, error) {
 
 	adhocBookings, err := c.adhocBookingRepo.ListByTherapistForDateRange(
@@ -123,6 +160,11 @@ func (c *PendingBookingConflictResolver) cancelAdhocBookings(
 		}
 	}
 
+	// Pending adhoc bookings can still fit while the group slot has seats
+	if !slotFilled {
+		return []*booking.AdhocBooking{}, nil
+	}
+
 	toBeCancelled := make([]domain.AdhocBookingID, 0)
 	// Cancel all conflicting adhoc bookings
 	for _, b := range adhocBookings {
@@ -137,7 +179,7 @@ func (c *PendingBookingConflictResolver) cancelAdhocBookings(
 	}
 
 	// Cancel the bookings
-	err = c.adhocBookingRepo.BulkCancel(tx, toBeCancelled)
+	err = c.adhocBookingRepo.BulkCancel(tx, toBeCancelled, time.Now().UTC())
 	if err != nil {
 		return nil, err
 	}
@@ -13,9 +13,10 @@ import (
 )
 
 type Input struct {
-	BookingID     domain.AdhocBookingID
-	PaidAmountUSD int // USD cents
-	Language      domain.SessionLanguage
+	BookingID  domain.AdhocBookingID
+	PaidAmount int                    // Smallest unit of Currency, e.g. cents
+	Currency   domain.Currency        // Defaults to USD
+	Language   domain.SessionLanguage // Defaults to the therapist's default language
 }
 
 type Usecase struct {
@@ -25,7 +26,9 @@ type Usecase struct {
 	notificationPort    ports.NotificationPort
 	notificationRepo    ports.NotificationRepository
 	therapistAppBaseURL string
-	transactionPort     ports.TransactionPort
+	unitOfWork          ports.UnitOfWork
+	allowedCurrencies   []domain.Currency
+	paidAmountLimits    confirm_booking.PaidAmountLimits
 
 	cancelPendingBookings *confirm_booking.PendingBookingConflictResolver
 	notifyTherapist       *notify_therapist_new_booking.Usecase
@@ -39,8 +42,11 @@ func NewUsecase(
 	notificationPort ports.NotificationPort,
 	notificationRepo ports.NotificationRepository,
 	therapistAppBaseURL string,
-	transactionPort ports.TransactionPort,
+	unitOfWork ports.UnitOfWork,
 	notifyTherapist *notify_therapist_new_booking.Usecase,
+	allowedCurrencies []domain.Currency,
+	paidAmountLimits confirm_booking.PaidAmountLimits,
+	eventPublisher ports.BookingEventPublisher, // optional, nil publishes no events
 ) *Usecase {
 	return &Usecase{
 		adhocBookingRepo:    adhocBookingRepo,
@@ -49,10 +55,13 @@ func NewUsecase(
 		notificationPort:    notificationPort,
 		notificationRepo:    notificationRepo,
 		therapistAppBaseURL: therapistAppBaseURL,
-		transactionPort:     transactionPort,
+		unitOfWork:          unitOfWork,
+		allowedCurrencies:   allowedCurrencies,
+		paidAmountLimits:    paidAmountLimits,
 		cancelPendingBookings: confirm_booking.NewPendingBookingConflictResolver(
 			bookingRepo,
 			adhocBookingRepo,
+			eventPublisher,
 		),
 		notifyTherapist: notifyTherapist,
 	}
@@ -64,54 +73,86 @@ func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
 		return nil, err
 	}
 
-	// Get pending booking
-	toBeConfirmedBooking, err := u.getAdhocBooking(input.BookingID)
+	currency, err := confirm_booking.ResolveCurrency(input.Currency, u.allowedCurrencies)
 	if err != nil {
 		return nil, err
 	}
 
-	// ------------------
-	// Confirm booking (run in a transaction)
-	// ------------------
-	tx, err := u.transactionPort.Begin()
+	if err := confirm_booking.ValidatePaidAmount(input.PaidAmount, u.paidAmountLimits); err != nil {
+		return nil, err
+	}
+
+	// A retried confirmation gets back the session created the first time
+	existingSession, err := u.sessionRepo.GetSessionByAdhocBookingID(input.BookingID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = u.cancelPendingBookings.CancelConflicts(tx,
-		toBeConfirmedBooking.TherapistID,
-		toBeConfirmedBooking.StartTime,
-		toBeConfirmedBooking.Duration,
-		toBeConfirmedBooking.ID,
-		"", // No regular booking id for adhoc bookings
-	)
+	// Get pending booking
+	toBeConfirmedBooking, err := u.getAdhocBooking(input.BookingID, existingSession != nil)
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
+	if existingSession != nil && toBeConfirmedBooking.State == booking.BookingStateConfirmed {
+		return bookingResponse(toBeConfirmedBooking, existingSession), nil
+	}
 
-	session, err := u.confirmBooking(tx, toBeConfirmedBooking, input.PaidAmountUSD, input.Language)
+	language, err := confirm_booking.ResolveLanguage(input.Language, u.therapistRepo, toBeConfirmedBooking.TherapistID)
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
 
-	err = u.transactionPort.Commit(tx)
+	// ------------------
+	// Confirm booking (run in a transaction)
+	// ------------------
+	var session *domain.Session
+	var cancelled []*booking.Booking
+	err = u.unitOfWork.RunInTx(func(tx ports.SQLTx) error {
+		var err error
+		cancelled, err = u.cancelPendingBookings.CancelConflicts(tx,
+			toBeConfirmedBooking.TherapistID,
+			toBeConfirmedBooking.StartTime,
+			toBeConfirmedBooking.Duration,
+			toBeConfirmedBooking.ID,
+			"", // No regular booking id for adhoc bookings
+			1,  // Adhoc bookings are always one-to-one
+		)
+		if err != nil {
+			return err
+		}
+
+		session, err = u.confirmBooking(tx, toBeConfirmedBooking, existingSession, input.PaidAmount, currency, language)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 	// ------------------
 
-	u.notifyTherapist.Execute(session)
+	// Adhoc bookings publish no events of their own, but the regular
+	// bookings they pushed out do
+	u.cancelPendingBookings.PublishCancellations(cancelled)
+
+	if confirm_booking.ConfirmationNotificationsEnabled(u.therapistRepo, session.TherapistID) {
+		u.notifyTherapist.Execute(session)
+	}
+	return bookingResponse(toBeConfirmedBooking, session), nil
+}
+
+func bookingResponse(confirmedBooking *booking.AdhocBooking, session *domain.Session) *ports.BookingResponse {
 	return &ports.BookingResponse{
-		AdhocBookingID:       toBeConfirmedBooking.ID,
-		TherapistID:          toBeConfirmedBooking.TherapistID,
-		ClientID:             toBeConfirmedBooking.ClientID,
-		State:                toBeConfirmedBooking.State,
-		StartTime:            toBeConfirmedBooking.StartTime,
-		Duration:             toBeConfirmedBooking.Duration,
-		ClientTimezoneOffset: toBeConfirmedBooking.ClientTimezoneOffset,
-	}, nil
+		AdhocBookingID:       confirmedBooking.ID,
+		TherapistID:          confirmedBooking.TherapistID,
+		ClientID:             confirmedBooking.ClientID,
+		State:                confirmedBooking.State,
+		StartTime:            confirmedBooking.StartTime,
+		Duration:             confirmedBooking.Duration,
+		ClientTimezoneOffset: confirmedBooking.ClientTimezoneOffset,
+		PaidAmount:           session.PaidAmount,
+		Currency:             session.Currency,
+		SessionID:            session.ID,
+		MeetingURL:           &session.MeetingURL,
+	}
 }
 
 func (u *Usecase) validateInput(input Input) error {
@@ -119,21 +160,22 @@ func (u *Usecase) validateInput(input Input) error {
 	if input.BookingID == "" {
 		return common.ErrBookingIDIsRequired
 	}
-	if input.PaidAmountUSD <= 0 {
+	if input.PaidAmount <= 0 {
 		return common.ErrPaidAmountIsRequired
 	}
-	if input.Language == "" {
-		return common.ErrLanguageIsRequired
-	}
 
 	return nil
 }
 
-func (u *Usecase) getAdhocBooking(bookingID domain.AdhocBookingID) (*booking.AdhocBooking, error) {
+func (u *Usecase) getAdhocBooking(bookingID domain.AdhocBookingID, hasSession bool) (*booking.AdhocBooking, error) {
 	toBeConfirmedBooking, err := u.adhocBookingRepo.GetByID(bookingID)
 	if err != nil || toBeConfirmedBooking == nil {
 		return nil, common.ErrBookingNotFound
 	}
+	// Already confirmed bookings are only accepted when retrying a confirmation
+	if hasSession && toBeConfirmedBooking.State == booking.BookingStateConfirmed {
+		return toBeConfirmedBooking, nil
+	}
 	// Validate booking is in Pending state
 	if toBeConfirmedBooking.State != booking.BookingStatePending {
 		slog.Error("to be confirmed adhoc booking is not in Pending state",
@@ -152,7 +194,9 @@ func (u *Usecase) getAdhocBooking(bookingID domain.AdhocBookingID) (*booking.Adh
 func (u *Usecase) confirmBooking(
 	tx ports.SQLTx,
 	existingBooking *booking.AdhocBooking,
-	paidAmountUSD int,
+	existingSession *domain.Session,
+	paidAmount int,
+	currency domain.Currency,
 	language domain.SessionLanguage,
 ) (*domain.Session, error) {
 	// Change state to Confirmed
@@ -166,6 +210,11 @@ func (u *Usecase) confirmBooking(
 		return nil, common.ErrFailedToConfirmBooking
 	}
 
+	// The session survived an earlier attempt that failed to confirm the booking
+	if existingSession != nil {
+		return existingSession, nil
+	}
+
 	// Create a new session for the confirmed booking
 	now := domain.NewUTCTimestamp()
 	session := &domain.Session{
@@ -175,7 +224,8 @@ func (u *Usecase) confirmBooking(
 		ClientID:             existingBooking.ClientID,
 		StartTime:            existingBooking.StartTime,
 		Duration:             existingBooking.Duration,
-		PaidAmount:           paidAmountUSD,
+		PaidAmount:           paidAmount,
+		Currency:             currency,
 		Language:             language,
 		State:                domain.SessionStatePlanned,
 		Notes:                "",
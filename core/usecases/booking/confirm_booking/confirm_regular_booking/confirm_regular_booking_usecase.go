@@ -12,9 +12,11 @@ import (
 )
 
 type Input struct {
-	BookingID     domain.BookingID
-	PaidAmountUSD int // USD cents
-	Language      domain.SessionLanguage
+	BookingID  domain.BookingID
+	PaidAmount int                    // Smallest unit of Currency, e.g. cents
+	Currency   domain.Currency        // Defaults to USD
+	Language   domain.SessionLanguage // Defaults to the therapist's default language
+	Actor      string                 // Recorded in the booking's audit trail
 }
 
 type Usecase struct {
@@ -24,10 +26,14 @@ type Usecase struct {
 	notificationPort    ports.NotificationPort
 	notificationRepo    ports.NotificationRepository
 	therapistAppBaseURL string
-	transactionPort     ports.TransactionPort
+	unitOfWork          ports.UnitOfWork
+	allowedCurrencies   []domain.Currency
+	paidAmountLimits    confirm_booking.PaidAmountLimits
 
 	cancelPendingBookings *confirm_booking.PendingBookingConflictResolver
 	notifyTherapist       *notify_therapist_new_booking.Usecase
+	eventPublisher        ports.BookingEventPublisher
+	timeSlotRepo          ports.TimeSlotRepository
 }
 
 func NewUsecase(
@@ -38,8 +44,12 @@ func NewUsecase(
 	notificationPort ports.NotificationPort,
 	notificationRepo ports.NotificationRepository,
 	therapistAppBaseURL string,
-	transactionPort ports.TransactionPort,
+	unitOfWork ports.UnitOfWork,
 	notifyTherapist *notify_therapist_new_booking.Usecase,
+	allowedCurrencies []domain.Currency,
+	paidAmountLimits confirm_booking.PaidAmountLimits,
+	eventPublisher ports.BookingEventPublisher, // optional, nil publishes no events
+	timeSlotRepo ports.TimeSlotRepository, // optional, nil treats every slot as one-to-one
 ) *Usecase {
 	return &Usecase{
 		bookingRepo:         bookingRepo,
@@ -48,12 +58,17 @@ func NewUsecase(
 		notificationPort:    notificationPort,
 		notificationRepo:    notificationRepo,
 		therapistAppBaseURL: therapistAppBaseURL,
-		transactionPort:     transactionPort,
+		unitOfWork:          unitOfWork,
+		allowedCurrencies:   allowedCurrencies,
+		paidAmountLimits:    paidAmountLimits,
 		cancelPendingBookings: confirm_booking.NewPendingBookingConflictResolver(
 			bookingRepo,
 			adhocBookingRepo,
+			eventPublisher,
 		),
 		notifyTherapist: notifyTherapist,
+		eventPublisher:  eventPublisher,
+		timeSlotRepo:    timeSlotRepo,
 	}
 }
 
@@ -63,14 +78,33 @@ func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
 		return nil, err
 	}
 
+	currency, err := confirm_booking.ResolveCurrency(input.Currency, u.allowedCurrencies)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := confirm_booking.ValidatePaidAmount(input.PaidAmount, u.paidAmountLimits); err != nil {
+		return nil, err
+	}
+
 	// Get pending booking
 	toBeConfirmedBooking, err := u.bookingRepo.GetByID(input.BookingID)
 	if err != nil || toBeConfirmedBooking == nil {
 		return nil, common.ErrBookingNotFound
 	}
-	// Validate booking is in Pending state
-	if toBeConfirmedBooking.State != booking.BookingStatePending {
-		slog.Error("to be confirmed regular booking is not in Pending state",
+
+	// A retried confirmation gets back the session created the first time
+	existingSession, err := u.sessionRepo.GetSessionByRegularBookingID(toBeConfirmedBooking.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existingSession != nil && toBeConfirmedBooking.State == booking.BookingStateConfirmed {
+		return bookingResponse(toBeConfirmedBooking, existingSession), nil
+	}
+
+	// Held bookings whose hold was confirmed are confirmed like pending ones
+	if toBeConfirmedBooking.State != booking.BookingStatePending && toBeConfirmedBooking.State != booking.BookingStateHeld {
+		slog.Error("to be confirmed regular booking is not in Pending or Held state",
 			slog.Group(
 				"booking",
 				"id", toBeConfirmedBooking.ID,
@@ -80,48 +114,68 @@ func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
 		return nil, common.ErrInvalidBookingState
 	}
 
-	// ------------------
-	// Confirm booking (run in a transaction)
-	// ------------------
-	tx, err := u.transactionPort.Begin()
+	language, err := confirm_booking.ResolveLanguage(input.Language, u.therapistRepo, toBeConfirmedBooking.TherapistID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = u.cancelPendingBookings.CancelConflicts(tx,
-		toBeConfirmedBooking.TherapistID,
-		toBeConfirmedBooking.StartTime,
-		toBeConfirmedBooking.Duration,
-		"", // No adhoc booking id for regular bookings
-		toBeConfirmedBooking.ID,
-	)
+	capacity, err := u.slotCapacity(toBeConfirmedBooking.TimeSlotID)
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
 
-	session, err := u.confirmBooking(tx, toBeConfirmedBooking, input.PaidAmountUSD, input.Language)
-	if err != nil {
-		tx.Rollback()
-		return nil, err
-	}
+	// ------------------
+	// Confirm booking (run in a transaction)
+	// ------------------
+	var session *domain.Session
+	var cancelled []*booking.Booking
+	err = u.unitOfWork.RunInTx(func(tx ports.SQLTx) error {
+		var err error
+		cancelled, err = u.cancelPendingBookings.CancelConflicts(tx,
+			toBeConfirmedBooking.TherapistID,
+			toBeConfirmedBooking.StartTime,
+			toBeConfirmedBooking.Duration,
+			"", // No adhoc booking id for regular bookings
+			toBeConfirmedBooking.ID,
+			capacity,
+		)
+		if err != nil {
+			return err
+		}
 
-	err = u.transactionPort.Commit(tx)
+		session, err = u.confirmBooking(tx, toBeConfirmedBooking, existingSession, input.PaidAmount, currency, language, input.Actor)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 	// ------------------
 
-	u.notifyTherapist.Execute(session)
+	u.cancelPendingBookings.PublishCancellations(cancelled)
+	if u.eventPublisher != nil {
+		u.eventPublisher.Publish(booking.NewEvent(booking.EventTypeConfirmed, toBeConfirmedBooking, booking.BookingStateConfirmed))
+	}
+
+	if confirm_booking.ConfirmationNotificationsEnabled(u.therapistRepo, session.TherapistID) {
+		u.notifyTherapist.Execute(session)
+	}
+	return bookingResponse(toBeConfirmedBooking, session), nil
+}
+
+func bookingResponse(confirmedBooking *booking.Booking, session *domain.Session) *ports.BookingResponse {
 	return &ports.BookingResponse{
-		RegularBookingID:     toBeConfirmedBooking.ID,
-		TherapistID:          toBeConfirmedBooking.TherapistID,
-		ClientID:             toBeConfirmedBooking.ClientID,
-		State:                toBeConfirmedBooking.State,
-		StartTime:            toBeConfirmedBooking.StartTime,
-		Duration:             toBeConfirmedBooking.Duration,
-		ClientTimezoneOffset: toBeConfirmedBooking.ClientTimezoneOffset,
-	}, nil
+		RegularBookingID:     confirmedBooking.ID,
+		TherapistID:          confirmedBooking.TherapistID,
+		ClientID:             confirmedBooking.ClientID,
+		State:                confirmedBooking.State,
+		StartTime:            confirmedBooking.StartTime,
+		Duration:             confirmedBooking.Duration,
+		ClientTimezoneOffset: confirmedBooking.ClientTimezoneOffset,
+		PaidAmount:           session.PaidAmount,
+		Currency:             session.Currency,
+		SessionID:            session.ID,
+		MeetingURL:           &session.MeetingURL,
+	}
 }
 
 func (u *Usecase) validateInput(input Input) error {
@@ -129,12 +183,9 @@ func (u *Usecase) validateInput(input Input) error {
 	if input.BookingID == "" {
 		return common.ErrBookingIDIsRequired
 	}
-	if input.PaidAmountUSD <= 0 {
+	if input.PaidAmount <= 0 {
 		return common.ErrPaidAmountIsRequired
 	}
-	if input.Language == "" {
-		return common.ErrLanguageIsRequired
-	}
 
 	return nil
 }
@@ -142,8 +193,11 @@ func (u *Usecase) validateInput(input Input) error {
 func (u *Usecase) confirmBooking(
 	tx ports.SQLTx,
 	existingBooking *booking.Booking,
-	paidAmountUSD int,
+	existingSession *domain.Session,
+	paidAmount int,
+	currency domain.Currency,
 	language domain.SessionLanguage,
+	actor string,
 ) (*domain.Session, error) {
 	// Change state to Confirmed
 	err := u.bookingRepo.UpdateStateTx(
@@ -151,11 +205,17 @@ func (u *Usecase) confirmBooking(
 		existingBooking.ID,
 		booking.BookingStateConfirmed,
 		domain.NewUTCTimestamp().Time(),
+		actor,
 	)
 	if err != nil {
 		return nil, common.ErrFailedToConfirmBooking
 	}
 
+	// The session survived an earlier attempt that failed to confirm the booking
+	if existingSession != nil {
+		return existingSession, nil
+	}
+
 	// Create a new session for the confirmed booking
 	now := domain.NewUTCTimestamp()
 	session := &domain.Session{
@@ -165,7 +225,8 @@ func (u *Usecase) confirmBooking(
 		ClientID:             existingBooking.ClientID,
 		StartTime:            existingBooking.StartTime,
 		Duration:             existingBooking.Duration,
-		PaidAmount:           paidAmountUSD,
+		PaidAmount:           paidAmount,
+		Currency:             currency,
 		Language:             language,
 		State:                domain.SessionStatePlanned,
 		Notes:                "",
@@ -183,3 +244,19 @@ func (u *Usecase) confirmBooking(
 
 	return session, nil
 }
+
+// slotCapacity returns how many confirmed bookings the booking's timeslot
+// allows at once. Deleted slots fall back to one-to-one.
+func (u *Usecase) slotCapacity(timeSlotID domain.TimeSlotID) (int, error) {
+	if u.timeSlotRepo == nil {
+		return 1, nil
+	}
+	slot, err := u.timeSlotRepo.GetByID(timeSlotID)
+	if err != nil {
+		return 0, err
+	}
+	if slot == nil {
+		return 1, nil
+	}
+	return slot.BookingCapacity(), nil
+}
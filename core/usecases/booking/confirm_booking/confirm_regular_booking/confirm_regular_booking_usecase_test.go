@@ -0,0 +1,398 @@
+package confirm_regular_booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/notification/notify_therapist_new_booking"
+)
+
+func TestConfirmRegularBookingNotificationPreferences(t *testing.T) {
+	therapistWithDevice := &therapist.Therapist{ID: "therapist_1", DeviceID: "device_1"}
+
+	confirm := func(preferences therapist.NotificationPreferences) *fakes.NotificationPort {
+		pending := &booking.Booking{
+			ID:          "booking_1",
+			TherapistID: therapistWithDevice.ID,
+			ClientID:    "client_1",
+			StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3)),
+			Duration:    60,
+			State:       booking.BookingStatePending,
+		}
+		therapistRepo := &fakes.TherapistRepo{
+			Therapists:  []*therapist.Therapist{therapistWithDevice},
+			Preferences: map[domain.TherapistID]therapist.NotificationPreferences{therapistWithDevice.ID: preferences},
+		}
+		notificationPort := &fakes.NotificationPort{}
+		notificationRepo := &fakes.NotificationRepo{}
+		notifyTherapist := notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, notificationRepo, "https://therapist.example.com")
+
+		usecase := NewUsecase(
+			&fakes.BookingRepo{Bookings: []*booking.Booking{pending}},
+			&fakes.AdhocBookingRepo{},
+			&fakes.SessionRepo{},
+			therapistRepo,
+			notificationPort,
+			notificationRepo,
+			"https://therapist.example.com",
+			&fakes.UnitOfWork{},
+			notifyTherapist,
+			[]domain.Currency{domain.DefaultCurrency},
+			confirm_booking.PaidAmountLimits{},
+			nil,
+			nil,
+		)
+
+		_, err := usecase.Execute(Input{
+			BookingID:  pending.ID,
+			PaidAmount: 5000,
+			Language:   domain.SessionLanguageEnglish,
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if pending.State != booking.BookingStateConfirmed {
+			t.Fatalf("expected booking to be confirmed, got %s", pending.State)
+		}
+		return notificationPort
+	}
+
+	t.Run("notifies when confirmations are enabled", func(t *testing.T) {
+		notificationPort := confirm(therapist.DefaultNotificationPreferences())
+		if len(notificationPort.SentTo) != 1 {
+			t.Errorf("expected 1 notification, got %d", len(notificationPort.SentTo))
+		}
+	})
+
+	t.Run("skips notification when confirmations are disabled", func(t *testing.T) {
+		notificationPort := confirm(therapist.NotificationPreferences{Confirmations: false, Reminders: true})
+		if len(notificationPort.SentTo) != 0 {
+			t.Errorf("expected no notifications, got %d", len(notificationPort.SentTo))
+		}
+	})
+}
+
+func TestConfirmRegularBookingCurrency(t *testing.T) {
+	therapistWithDevice := &therapist.Therapist{ID: "therapist_1", DeviceID: "device_1"}
+
+	newFixture := func() (*Usecase, *fakes.SessionRepo, *booking.Booking) {
+		pending := &booking.Booking{
+			ID:          "booking_1",
+			TherapistID: therapistWithDevice.ID,
+			ClientID:    "client_1",
+			StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3)),
+			Duration:    60,
+			State:       booking.BookingStatePending,
+		}
+		therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistWithDevice}}
+		notificationPort := &fakes.NotificationPort{}
+		notificationRepo := &fakes.NotificationRepo{}
+		sessionRepo := &fakes.SessionRepo{}
+
+		usecase := NewUsecase(
+			&fakes.BookingRepo{Bookings: []*booking.Booking{pending}},
+			&fakes.AdhocBookingRepo{},
+			sessionRepo,
+			therapistRepo,
+			notificationPort,
+			notificationRepo,
+			"https://therapist.example.com",
+			&fakes.UnitOfWork{},
+			notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, notificationRepo, "https://therapist.example.com"),
+			[]domain.Currency{"USD", "EGP"},
+			confirm_booking.PaidAmountLimits{},
+			nil,
+			nil,
+		)
+		return usecase, sessionRepo, pending
+	}
+
+	t.Run("stores and returns the given currency", func(t *testing.T) {
+		usecase, sessionRepo, pending := newFixture()
+
+		output, err := usecase.Execute(Input{
+			BookingID:  pending.ID,
+			PaidAmount: 150000,
+			Currency:   "EGP",
+			Language:   domain.SessionLanguageArabic,
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if output.Currency != "EGP" || output.PaidAmount != 150000 {
+			t.Errorf("expected 150000 EGP in response, got %d %s", output.PaidAmount, output.Currency)
+		}
+		if len(sessionRepo.Sessions) != 1 || sessionRepo.Sessions[0].Currency != "EGP" {
+			t.Fatalf("expected a session stored in EGP, got %+v", sessionRepo.Sessions)
+		}
+	})
+
+	t.Run("defaults to USD", func(t *testing.T) {
+		usecase, sessionRepo, pending := newFixture()
+
+		_, err := usecase.Execute(Input{
+			BookingID:  pending.ID,
+			PaidAmount: 5000,
+			Language:   domain.SessionLanguageEnglish,
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if sessionRepo.Sessions[0].Currency != domain.DefaultCurrency {
+			t.Errorf("expected %s, got %s", domain.DefaultCurrency, sessionRepo.Sessions[0].Currency)
+		}
+	})
+
+	t.Run("rejects a currency outside the allowlist", func(t *testing.T) {
+		usecase, sessionRepo, pending := newFixture()
+
+		_, err := usecase.Execute(Input{
+			BookingID:  pending.ID,
+			PaidAmount: 5000,
+			Currency:   "XYZ",
+			Language:   domain.SessionLanguageEnglish,
+		})
+		if err != common.ErrInvalidCurrency {
+			t.Fatalf("expected %v, got %v", common.ErrInvalidCurrency, err)
+		}
+		if pending.State != booking.BookingStatePending || len(sessionRepo.Sessions) != 0 {
+			t.Errorf("expected booking to stay pending without a session")
+		}
+	})
+}
+
+func TestConfirmRegularBookingIsIdempotent(t *testing.T) {
+	therapistWithDevice := &therapist.Therapist{ID: "therapist_1", DeviceID: "device_1"}
+	pending := &booking.Booking{
+		ID:          "booking_1",
+		TherapistID: therapistWithDevice.ID,
+		ClientID:    "client_1",
+		StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3)),
+		Duration:    60,
+		State:       booking.BookingStatePending,
+	}
+	therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistWithDevice}}
+	notificationPort := &fakes.NotificationPort{}
+	notificationRepo := &fakes.NotificationRepo{}
+	sessionRepo := &fakes.SessionRepo{}
+
+	usecase := NewUsecase(
+		&fakes.BookingRepo{Bookings: []*booking.Booking{pending}},
+		&fakes.AdhocBookingRepo{},
+		sessionRepo,
+		therapistRepo,
+		notificationPort,
+		notificationRepo,
+		"https://therapist.example.com",
+		&fakes.UnitOfWork{},
+		notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, notificationRepo, "https://therapist.example.com"),
+		[]domain.Currency{domain.DefaultCurrency},
+		confirm_booking.PaidAmountLimits{},
+		nil,
+		nil,
+	)
+
+	input := Input{
+		BookingID:  pending.ID,
+		PaidAmount: 5000,
+		Language:   domain.SessionLanguageEnglish,
+	}
+	for attempt := 1; attempt <= 2; attempt++ {
+		output, err := usecase.Execute(input)
+		if err != nil {
+			t.Fatalf("attempt %d: expected success, got %v", attempt, err)
+		}
+		if output.RegularBookingID != pending.ID {
+			t.Errorf("attempt %d: expected booking %s, got %s", attempt, pending.ID, output.RegularBookingID)
+		}
+	}
+
+	if len(sessionRepo.Sessions) != 1 {
+		t.Errorf("expected exactly one session, got %d", len(sessionRepo.Sessions))
+	}
+	if len(notificationPort.SentTo) != 1 {
+		t.Errorf("expected the therapist to be notified once, got %d", len(notificationPort.SentTo))
+	}
+}
+
+func TestConfirmRegularBookingConfirmsHeldBookings(t *testing.T) {
+	held := &booking.Booking{
+		ID:          "booking_1",
+		TherapistID: "therapist_1",
+		ClientID:    "client_1",
+		StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3)),
+		Duration:    60,
+		State:       booking.BookingStateHeld,
+	}
+	therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{{ID: held.TherapistID}}}
+	notificationPort := &fakes.NotificationPort{}
+	notificationRepo := &fakes.NotificationRepo{}
+	bookingRepo := &fakes.BookingRepo{Bookings: []*booking.Booking{held}}
+
+	usecase := NewUsecase(
+		bookingRepo,
+		&fakes.AdhocBookingRepo{},
+		&fakes.SessionRepo{},
+		therapistRepo,
+		notificationPort,
+		notificationRepo,
+		"https://therapist.example.com",
+		&fakes.UnitOfWork{},
+		notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, notificationRepo, "https://therapist.example.com"),
+		[]domain.Currency{domain.DefaultCurrency},
+		confirm_booking.PaidAmountLimits{},
+		nil,
+		nil,
+	)
+
+	_, err := usecase.Execute(Input{BookingID: held.ID, PaidAmount: 5000, Language: domain.SessionLanguageEnglish})
+	if err != nil {
+		t.Fatalf("expected a held booking to be confirmed, got %v", err)
+	}
+	if held.State != booking.BookingStateConfirmed {
+		t.Errorf("expected the booking to be confirmed, got %s", held.State)
+	}
+}
+
+func TestConfirmRegularBookingPaidAmountLimits(t *testing.T) {
+	limits := confirm_booking.PaidAmountLimits{Min: 1000, Max: 50000}
+
+	tests := []struct {
+		name       string
+		paidAmount int
+		wantErr    error
+	}{
+		{name: "at the minimum", paidAmount: 1000, wantErr: nil},
+		{name: "at the maximum", paidAmount: 50000, wantErr: nil},
+		{name: "in range", paidAmount: 15000, wantErr: nil},
+		{name: "below the minimum", paidAmount: 999, wantErr: common.ErrPaidAmountOutOfRange},
+		{name: "above the maximum", paidAmount: 50001, wantErr: common.ErrPaidAmountOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending := &booking.Booking{
+				ID:          "booking_1",
+				TherapistID: "therapist_1",
+				ClientID:    "client_1",
+				StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3)),
+				Duration:    60,
+				State:       booking.BookingStatePending,
+			}
+			therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{{ID: "therapist_1"}}}
+			notificationPort := &fakes.NotificationPort{}
+			notificationRepo := &fakes.NotificationRepo{}
+			sessionRepo := &fakes.SessionRepo{}
+
+			usecase := NewUsecase(
+				&fakes.BookingRepo{Bookings: []*booking.Booking{pending}},
+				&fakes.AdhocBookingRepo{},
+				sessionRepo,
+				therapistRepo,
+				notificationPort,
+				notificationRepo,
+				"https://therapist.example.com",
+				&fakes.UnitOfWork{},
+				notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, notificationRepo, "https://therapist.example.com"),
+				[]domain.Currency{domain.DefaultCurrency},
+				limits,
+				nil,
+				nil,
+			)
+
+			_, err := usecase.Execute(Input{
+				BookingID:  pending.ID,
+				PaidAmount: tt.paidAmount,
+				Language:   domain.SessionLanguageEnglish,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+
+			wantState := booking.BookingStateConfirmed
+			if tt.wantErr != nil {
+				wantState = booking.BookingStatePending
+			}
+			if pending.State != wantState {
+				t.Errorf("expected booking to be %s, got %s", wantState, pending.State)
+			}
+		})
+	}
+}
+
+func TestConfirmRegularBookingDefaultLanguage(t *testing.T) {
+	newFixture := func(defaultLanguage domain.SessionLanguage) (*Usecase, *fakes.SessionRepo, *booking.Booking) {
+		therapistEntry := &therapist.Therapist{ID: "therapist_1", DeviceID: "device_1", DefaultLanguage: defaultLanguage}
+		pending := &booking.Booking{
+			ID:          "booking_1",
+			TherapistID: therapistEntry.ID,
+			ClientID:    "client_1",
+			StartTime:   domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3)),
+			Duration:    60,
+			State:       booking.BookingStatePending,
+		}
+		therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistEntry}}
+		notificationPort := &fakes.NotificationPort{}
+		notificationRepo := &fakes.NotificationRepo{}
+		sessionRepo := &fakes.SessionRepo{}
+
+		usecase := NewUsecase(
+			&fakes.BookingRepo{Bookings: []*booking.Booking{pending}},
+			&fakes.AdhocBookingRepo{},
+			sessionRepo,
+			therapistRepo,
+			notificationPort,
+			notificationRepo,
+			"https://therapist.example.com",
+			&fakes.UnitOfWork{},
+			notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, notificationRepo, "https://therapist.example.com"),
+			[]domain.Currency{domain.DefaultCurrency},
+			confirm_booking.PaidAmountLimits{},
+			nil,
+			nil,
+		)
+		return usecase, sessionRepo, pending
+	}
+
+	t.Run("falls back to the therapist's default", func(t *testing.T) {
+		usecase, sessionRepo, pending := newFixture(domain.SessionLanguageArabic)
+
+		_, err := usecase.Execute(Input{BookingID: pending.ID, PaidAmount: 5000})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if len(sessionRepo.Sessions) != 1 || sessionRepo.Sessions[0].Language != domain.SessionLanguageArabic {
+			t.Fatalf("expected a session held in %s, got %+v", domain.SessionLanguageArabic, sessionRepo.Sessions)
+		}
+	})
+
+	t.Run("prefers the requested language", func(t *testing.T) {
+		usecase, sessionRepo, pending := newFixture(domain.SessionLanguageArabic)
+
+		_, err := usecase.Execute(Input{BookingID: pending.ID, PaidAmount: 5000, Language: domain.SessionLanguageEnglish})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if sessionRepo.Sessions[0].Language != domain.SessionLanguageEnglish {
+			t.Errorf("expected %s, got %s", domain.SessionLanguageEnglish, sessionRepo.Sessions[0].Language)
+		}
+	})
+
+	t.Run("requires a language when the therapist has no default", func(t *testing.T) {
+		usecase, sessionRepo, pending := newFixture("")
+
+		_, err := usecase.Execute(Input{BookingID: pending.ID, PaidAmount: 5000})
+		if err != common.ErrLanguageIsRequired {
+			t.Fatalf("expected %v, got %v", common.ErrLanguageIsRequired, err)
+		}
+		if pending.State != booking.BookingStatePending || len(sessionRepo.Sessions) != 0 {
+			t.Errorf("expected booking to stay pending without a session")
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package confirm_booking
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// ResolveCurrency normalizes the currency a booking was paid in, defaulting to
+// USD when none is given, and checks it against the allowed currencies.
+func ResolveCurrency(currency domain.Currency, allowedCurrencies []domain.Currency) (domain.Currency, error) {
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	currency = domain.Currency(strings.ToUpper(strings.TrimSpace(string(currency))))
+
+	if !slices.Contains(allowedCurrencies, currency) {
+		return "", common.ErrInvalidCurrency
+	}
+	return currency, nil
+}
@@ -0,0 +1,24 @@
+package confirm_booking
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// ResolveLanguage returns the language the session is held in, falling back
+// to the therapist's default when the confirmation omits it.
+func ResolveLanguage(language domain.SessionLanguage, therapistRepo ports.TherapistRepository, therapistID domain.TherapistID) (domain.SessionLanguage, error) {
+	if language != "" {
+		return language, nil
+	}
+
+	therapist, err := therapistRepo.GetByID(therapistID)
+	if err != nil {
+		return "", err
+	}
+	if therapist == nil || therapist.DefaultLanguage == "" {
+		return "", common.ErrLanguageIsRequired
+	}
+	return therapist.DefaultLanguage, nil
+}
@@ -0,0 +1,20 @@
+package confirm_booking
+
+import (
+	"log/slog"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+// ConfirmationNotificationsEnabled reports whether the therapist wants a push
+// notification when one of their bookings is confirmed. Failing to read the
+// preferences falls back to notifying, as before preferences existed.
+func ConfirmationNotificationsEnabled(therapistRepo ports.TherapistRepository, therapistID domain.TherapistID) bool {
+	preferences, err := therapistRepo.GetNotificationPreferences(therapistID)
+	if err != nil {
+		slog.Warn("failed to get therapist notification preferences", "therapist_id", therapistID, "error", err)
+		return true
+	}
+	return preferences.Confirmations
+}
@@ -0,0 +1,21 @@
+package confirm_booking
+
+import "github.com/mishkahtherapy/brain/core/usecases/common"
+
+// PaidAmountLimits bounds the amount a confirmation may record, in the
+// smallest unit of the currency. A zero bound is not enforced.
+type PaidAmountLimits struct {
+	Min int
+	Max int
+}
+
+// ValidatePaidAmount checks the amount against the inclusive limits.
+func ValidatePaidAmount(paidAmount int, limits PaidAmountLimits) error {
+	if limits.Min > 0 && paidAmount < limits.Min {
+		return common.ErrPaidAmountOutOfRange
+	}
+	if limits.Max > 0 && paidAmount > limits.Max {
+		return common.ErrPaidAmountOutOfRange
+	}
+	return nil
+}
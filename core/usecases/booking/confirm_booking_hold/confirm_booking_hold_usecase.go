@@ -0,0 +1,98 @@
+package confirm_booking_hold
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+type Input struct {
+	Token domain.HoldToken
+}
+
+type Usecase struct {
+	bookingRepo     ports.BookingRepository
+	holdRepo        ports.BookingHoldRepository
+	transactionPort ports.TransactionPort
+	eventPublisher  ports.BookingEventPublisher
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	holdRepo ports.BookingHoldRepository,
+	transactionPort ports.TransactionPort,
+	eventPublisher ports.BookingEventPublisher, // optional, nil publishes no events
+) *Usecase {
+	return &Usecase{
+		bookingRepo:     bookingRepo,
+		holdRepo:        holdRepo,
+		transactionPort: transactionPort,
+		eventPublisher:  eventPublisher,
+	}
+}
+
+// Execute keeps a held booking for good, provided its hold has not expired.
+// Pending bookings don't block the schedule, so the booking stays held,
+// blocking its time until it is confirmed or cancelled; only the hold's
+// expiry is dropped.
+func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
+	if input.Token == "" {
+		return nil, booking.ErrHoldNotFound
+	}
+
+	hold, err := u.holdRepo.GetByToken(input.Token)
+	if err != nil {
+		return nil, err
+	}
+	if hold == nil {
+		return nil, booking.ErrHoldNotFound
+	}
+
+	now := time.Now().UTC()
+	if hold.IsExpired(now) {
+		return nil, booking.ErrHoldExpired
+	}
+
+	heldBooking, err := u.bookingRepo.GetByID(hold.BookingID)
+	if err != nil || heldBooking == nil {
+		return nil, common.ErrBookingNotFound
+	}
+	if heldBooking.State != booking.BookingStateHeld {
+		return nil, common.ErrInvalidStateTransition
+	}
+
+	// ------------------
+	// Drop the hold's expiry (run in a transaction)
+	// ------------------
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.holdRepo.DeleteTx(tx, hold.Token); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := u.transactionPort.Commit(tx); err != nil {
+		return nil, err
+	}
+	// ------------------
+
+	if u.eventPublisher != nil {
+		u.eventPublisher.Publish(booking.NewEvent(booking.EventTypeCreated, heldBooking, booking.BookingStateHeld))
+	}
+
+	return &ports.BookingResponse{
+		RegularBookingID:     heldBooking.ID,
+		TherapistID:          heldBooking.TherapistID,
+		ClientID:             heldBooking.ClientID,
+		State:                heldBooking.State,
+		StartTime:            heldBooking.StartTime,
+		Duration:             heldBooking.Duration,
+		ClientTimezoneOffset: heldBooking.ClientTimezoneOffset,
+	}, nil
+}
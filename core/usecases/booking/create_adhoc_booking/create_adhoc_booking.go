@@ -55,9 +55,6 @@ func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
 		return nil, common.ErrClientNotFound
 	}
 
-	// Get booking day of week
-	bookingDayOfWeek := time.Time(input.StartTime).Weekday()
-
 	// Get therapist
 	therapist, err := u.therapistRepo.GetByID(input.TherapistID)
 	if err != nil {
@@ -70,26 +67,23 @@ func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
 		return nil, common.ErrFailedToCreateBooking
 	}
 
-	// Filter timeslots by day of week
-	dayTimeslots := make([]*timeslot.TimeSlot, 0)
+	// Make sure booking doesn't intersect with any of the therapist's timeslots.
+	// A slot's day is local to its timezone, so occurrences starting on the
+	// neighbouring UTC days are checked too.
+	bookingDay := time.Time(input.StartTime).UTC()
 	for _, slot := range timeslots {
 		if !slot.IsActive {
 			continue
 		}
 
-		if slot.DayOfWeek == timeslot.MapToDayOfWeek(bookingDayOfWeek) {
-			dayTimeslots = append(dayTimeslots, slot)
-		}
-	}
-
-	// Make sure booking doesn't intersect with any of the therapist's timeslots.
-	for _, slot := range dayTimeslots {
-		slotStart, _ := slot.ApplyToDate(time.Time(input.StartTime))
-		if hasOverlap(
-			slotStart, slot.Duration,
-			input.StartTime, input.Duration,
-		) {
-			return nil, timeslot.ErrBookingShouldBeMadeInTimeslot
+		for _, day := range []time.Time{bookingDay.AddDate(0, 0, -1), bookingDay, bookingDay.AddDate(0, 0, 1)} {
+			slotStart, _, ok := slot.OccurrenceOn(day)
+			if ok && hasOverlap(
+				slotStart, slot.Duration,
+				input.StartTime, input.Duration,
+			) {
+				return nil, timeslot.ErrBookingShouldBeMadeInTimeslot
+			}
 		}
 	}
 
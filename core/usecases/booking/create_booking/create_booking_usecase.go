@@ -5,11 +5,10 @@ import (
 
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/booking"
-	"github.com/mishkahtherapy/brain/core/domain/schedule"
 	"github.com/mishkahtherapy/brain/core/ports"
 	"github.com/mishkahtherapy/brain/core/usecases/common"
-	"github.com/mishkahtherapy/brain/core/usecases/common/overlap_detector"
-	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+	"github.com/mishkahtherapy/brain/core/usecases/common/daily_limit"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
 )
 
 type Input struct {
@@ -22,11 +21,12 @@ type Input struct {
 }
 
 type Usecase struct {
-	bookingRepo        ports.BookingRepository
-	therapistRepo      ports.TherapistRepository
-	clientRepo         ports.ClientRepository
-	timeSlotRepo       ports.TimeSlotRepository
-	getScheduleUsecase get_schedule.Usecase
+	bookingRepo       ports.BookingRepository
+	therapistRepo     ports.TherapistRepository
+	clientRepo        ports.ClientRepository
+	timeSlotRepo      ports.TimeSlotRepository
+	checkAvailability check_availability.Usecase
+	eventPublisher    ports.BookingEventPublisher
 }
 
 func NewUsecase(
@@ -34,14 +34,16 @@ func NewUsecase(
 	therapistRepo ports.TherapistRepository,
 	clientRepo ports.ClientRepository,
 	timeSlotRepo ports.TimeSlotRepository,
-	getScheduleUsecase get_schedule.Usecase,
+	checkAvailability check_availability.Usecase,
+	eventPublisher ports.BookingEventPublisher, // optional, nil publishes no events
 ) *Usecase {
 	return &Usecase{
-		bookingRepo:        bookingRepo,
-		therapistRepo:      therapistRepo,
-		clientRepo:         clientRepo,
-		timeSlotRepo:       timeSlotRepo,
-		getScheduleUsecase: getScheduleUsecase,
+		bookingRepo:       bookingRepo,
+		therapistRepo:     therapistRepo,
+		clientRepo:        clientRepo,
+		timeSlotRepo:      timeSlotRepo,
+		checkAvailability: checkAvailability,
+		eventPublisher:    eventPublisher,
 	}
 }
 
@@ -57,34 +59,31 @@ func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
 		return nil, common.ErrClientNotFound
 	}
 
-	startTime := time.Time(input.StartTime)
-	endTime := startTime.Add(time.Duration(input.Duration) * time.Minute)
-	availabilities, err := u.getScheduleUsecase.Execute(get_schedule.Input{
-		TherapistIDs: []domain.TherapistID{input.TherapistID},
-		StartDate:    startTime,
-		EndDate:      endTime,
-	})
+	// The timeslot must belong to the therapist being booked
+	slot, err := u.timeSlotRepo.GetByID(input.TimeSlotID)
+	if err != nil || slot == nil {
+		return nil, common.ErrTimeSlotNotFound
+	}
+	if slot.TherapistID != input.TherapistID {
+		return nil, booking.ErrTimeSlotNotOwned
+	}
 
+	// The slot must be free in the schedule and clear of conflicting bookings
+	availability, err := u.checkAvailability.Execute(check_availability.Input{
+		TherapistID: input.TherapistID,
+		TimeSlotID:  input.TimeSlotID,
+		StartTime:   input.StartTime,
+		Duration:    input.Duration,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	if len(availabilities) == 0 {
+	if !availability.Available {
 		return nil, common.ErrTimeSlotAlreadyBooked
 	}
 
-	var matchingAvailability *schedule.AvailableTimeRange
-	for _, availability := range availabilities {
-		matches := checkIfAvailabilityMatches(availability, input)
-
-		if matches {
-			matchingAvailability = &availability
-			break
-		}
-	}
-
-	if matchingAvailability == nil {
-		return nil, common.ErrInvalidBookingTime
+	if err := daily_limit.Check(u.therapistRepo, u.bookingRepo, input.TherapistID, input.StartTime); err != nil {
+		return nil, err
 	}
 
 	// Create booking with Pending state and timezone (no conversion, just store as hint)
@@ -107,6 +106,10 @@ func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
 		return nil, common.ErrFailedToCreateBooking
 	}
 
+	if u.eventPublisher != nil {
+		u.eventPublisher.Publish(booking.NewEvent(booking.EventTypeCreated, createdBooking, createdBooking.State))
+	}
+
 	return &ports.BookingResponse{
 		RegularBookingID:     createdBooking.ID,
 		TherapistID:          createdBooking.TherapistID,
@@ -134,20 +137,3 @@ func validateInput(input Input) error {
 
 	return nil
 }
-
-func checkIfAvailabilityMatches(availability schedule.AvailableTimeRange, input Input) bool {
-	// Make sure the booked timeslot is within the availability
-	availabilityStartTime := time.Time(availability.From)
-	availabilityEndTime := availabilityStartTime.Add(time.Duration(availability.Duration) * time.Minute)
-
-	inputStartTime := time.Time(input.StartTime)
-	inputEndTime := inputStartTime.Add(time.Duration(input.Duration) * time.Minute)
-
-	return overlap_detector.New(
-		availabilityStartTime,
-		availabilityEndTime,
-	).HasOverlap(
-		inputStartTime,
-		inputEndTime,
-	)
-}
@@ -0,0 +1,95 @@
+package get_booking_calendar
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// Input represents the inclusive range of local dates to show. From and To
+// are dates only; TimezoneOffset decides which UTC instants they cover.
+type Input struct {
+	TherapistID    domain.TherapistID
+	From           time.Time
+	To             time.Time
+	TimezoneOffset domain.TimezoneOffset
+}
+
+// CalendarBooking is a booking with its start time rendered in the requested
+// timezone.
+type CalendarBooking struct {
+	*booking.Booking
+	LocalStartTime string `json:"localStartTime"`
+}
+
+// Output maps each local date (YYYY-MM-DD) with bookings to the bookings
+// starting on it, earliest first.
+type Output map[string][]CalendarBooking
+
+var calendarStates = []booking.BookingState{
+	booking.BookingStatePending,
+	booking.BookingStateConfirmed,
+}
+
+type Usecase struct {
+	bookingRepo   ports.BookingRepository
+	therapistRepo ports.TherapistRepository
+}
+
+func NewUsecase(bookingRepo ports.BookingRepository, therapistRepo ports.TherapistRepository) *Usecase {
+	return &Usecase{
+		bookingRepo:   bookingRepo,
+		therapistRepo: therapistRepo,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (Output, error) {
+	if input.TherapistID == "" {
+		return nil, common.ErrTherapistIDIsRequired
+	}
+	if input.From.IsZero() || input.To.IsZero() || input.To.Before(input.From) {
+		return nil, common.ErrInvalidDateRange
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	// Local midnight of From up to the end of To, expressed in UTC
+	zone := time.FixedZone("", int(input.TimezoneOffset)*60)
+	localFrom := time.Date(input.From.Year(), input.From.Month(), input.From.Day(), 0, 0, 0, 0, zone)
+	localTo := time.Date(input.To.Year(), input.To.Month(), input.To.Day(), 0, 0, 0, 0, zone).AddDate(0, 0, 1)
+	startDate := localFrom.UTC()
+	endDate := localTo.Add(-time.Nanosecond).UTC()
+
+	bookings, err := u.bookingRepo.BulkListByTherapistForDateRange(
+		[]domain.TherapistID{input.TherapistID},
+		calendarStates,
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		return nil, common.ErrFailedToListBookings
+	}
+
+	calendar := make(Output)
+	for _, b := range bookings[input.TherapistID] {
+		localStart := b.StartTime.Time().In(zone)
+		// The listing also returns bookings that only end inside the range;
+		// a day view shows bookings on the day they start.
+		if localStart.Before(localFrom) || !localStart.Before(localTo) {
+			continue
+		}
+
+		day := localStart.Format(time.DateOnly)
+		calendar[day] = append(calendar[day], CalendarBooking{
+			Booking:        b,
+			LocalStartTime: localStart.Format(time.RFC3339),
+		})
+	}
+
+	return calendar, nil
+}
@@ -0,0 +1,68 @@
+package get_booking_calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestGetBookingCalendarBucketsByLocalDay(t *testing.T) {
+	therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{{ID: "therapist_1"}}}
+	bookingRepo := &fakes.BookingRepo{Bookings: []*booking.Booking{
+		{
+			ID:          "booking_late",
+			TherapistID: "therapist_1",
+			State:       booking.BookingStateConfirmed,
+			StartTime:   domain.UTCTimestamp(time.Date(2025, 7, 8, 23, 0, 0, 0, time.UTC)),
+			Duration:    60,
+		},
+		{
+			ID:          "booking_cancelled",
+			TherapistID: "therapist_1",
+			State:       booking.BookingStateCancelled,
+			StartTime:   domain.UTCTimestamp(time.Date(2025, 7, 8, 10, 0, 0, 0, time.UTC)),
+			Duration:    60,
+		},
+	}}
+
+	usecase := NewUsecase(bookingRepo, therapistRepo)
+	calendar, err := usecase.Execute(Input{
+		TherapistID:    "therapist_1",
+		From:           time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2025, 7, 9, 0, 0, 0, 0, time.UTC),
+		TimezoneOffset: 120,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	if len(calendar["2025-07-08"]) != 0 {
+		t.Errorf("expected no bookings on 2025-07-08, got %d", len(calendar["2025-07-08"]))
+	}
+	day := calendar["2025-07-09"]
+	if len(day) != 1 || day[0].ID != "booking_late" {
+		t.Fatalf("expected booking_late on 2025-07-09, got %v", day)
+	}
+	if day[0].LocalStartTime != "2025-07-09T01:00:00+02:00" {
+		t.Errorf("expected local start 2025-07-09T01:00:00+02:00, got %s", day[0].LocalStartTime)
+	}
+}
+
+func TestGetBookingCalendarInvalidRange(t *testing.T) {
+	therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{{ID: "therapist_1"}}}
+	usecase := NewUsecase(&fakes.BookingRepo{}, therapistRepo)
+
+	_, err := usecase.Execute(Input{
+		TherapistID: "therapist_1",
+		From:        time.Date(2025, 7, 9, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2025, 7, 8, 0, 0, 0, 0, time.UTC),
+	})
+	if err != common.ErrInvalidDateRange {
+		t.Errorf("expected ErrInvalidDateRange, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package get_booking_history
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+type Usecase struct {
+	bookingRepo ports.BookingRepository
+}
+
+func NewUsecase(bookingRepo ports.BookingRepository) *Usecase {
+	return &Usecase{bookingRepo: bookingRepo}
+}
+
+// Execute returns the booking's state changes, oldest first
+func (u *Usecase) Execute(bookingID domain.BookingID) ([]*booking.StateChange, error) {
+	if bookingID == "" {
+		return nil, common.ErrBookingIDIsRequired
+	}
+
+	existingBooking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil || existingBooking == nil {
+		return nil, common.ErrBookingNotFound
+	}
+
+	return u.bookingRepo.ListStateChanges(bookingID)
+}
@@ -0,0 +1,65 @@
+package get_booking_stats
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// Input represents the optional UTC range the stats are computed over.
+// Zero values leave that side of the range open. Only bookings of the
+// clinic's therapists are counted, the default clinic when empty.
+type Input struct {
+	From     time.Time
+	To       time.Time
+	ClinicID domain.ClinicID
+}
+
+type Output struct {
+	Total       int                          `json:"total"`
+	ByState     map[booking.BookingState]int `json:"byState"`
+	ByTherapist map[domain.TherapistID]int   `json:"byTherapist"`
+}
+
+type Usecase struct {
+	bookingRepo ports.BookingRepository
+}
+
+func NewUsecase(bookingRepo ports.BookingRepository) *Usecase {
+	return &Usecase{bookingRepo: bookingRepo}
+}
+
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if !input.From.IsZero() && !input.To.IsZero() && input.To.Before(input.From) {
+		return nil, common.ErrInvalidDateRange
+	}
+
+	byState, err := u.bookingRepo.CountByState(input.ClinicID.OrDefault(), input.From, input.To)
+	if err != nil {
+		return nil, common.ErrFailedToListBookings
+	}
+
+	byTherapist, err := u.bookingRepo.CountByTherapist(input.ClinicID.OrDefault(), input.From, input.To)
+	if err != nil {
+		return nil, common.ErrFailedToListBookings
+	}
+
+	// Always report every state so dashboards don't have to handle missing keys
+	output := &Output{
+		ByState: map[booking.BookingState]int{
+			booking.BookingStatePending:   0,
+			booking.BookingStateConfirmed: 0,
+			booking.BookingStateCancelled: 0,
+		},
+		ByTherapist: byTherapist,
+	}
+	for state, count := range byState {
+		output.ByState[state] = count
+		output.Total += count
+	}
+
+	return output, nil
+}
@@ -0,0 +1,92 @@
+package get_confirmation_preview
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// Price is the configured session price. A zero Amount means no price is set.
+type Price struct {
+	Amount   int             `json:"amount"` // Smallest unit of Currency, e.g. cents
+	Currency domain.Currency `json:"currency"`
+}
+
+type TherapistSummary struct {
+	ID       domain.TherapistID `json:"id"`
+	Name     string             `json:"name"`
+	PhotoURL string             `json:"photoUrl"`
+}
+
+// Output summarizes what the client is about to pay for
+type Output struct {
+	BookingID domain.BookingID       `json:"bookingId"`
+	Therapist TherapistSummary       `json:"therapist"`
+	StartTime domain.UTCTimestamp    `json:"startTime"`
+	Duration  domain.DurationMinutes `json:"duration"`        // Session length of the booking's timeslot
+	Price     *Price                 `json:"price,omitempty"` // Only set when a session price is configured
+}
+
+type Usecase struct {
+	bookingRepo   ports.BookingRepository
+	therapistRepo ports.TherapistRepository
+	timeSlotRepo  ports.TimeSlotRepository
+	price         Price
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	therapistRepo ports.TherapistRepository,
+	timeSlotRepo ports.TimeSlotRepository,
+	price Price,
+) *Usecase {
+	return &Usecase{
+		bookingRepo:   bookingRepo,
+		therapistRepo: therapistRepo,
+		timeSlotRepo:  timeSlotRepo,
+		price:         price,
+	}
+}
+
+// Execute previews the confirmation of a pending booking without changing it
+func (u *Usecase) Execute(bookingID domain.BookingID) (*Output, error) {
+	if bookingID == "" {
+		return nil, common.ErrBookingIDIsRequired
+	}
+
+	existingBooking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil || existingBooking == nil {
+		return nil, common.ErrBookingNotFound
+	}
+
+	if existingBooking.State != booking.BookingStatePending {
+		return nil, common.ErrInvalidBookingState
+	}
+
+	therapist, err := u.therapistRepo.GetByID(existingBooking.TherapistID)
+	if err != nil || therapist == nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	timeSlot, err := u.timeSlotRepo.GetByID(existingBooking.TimeSlotID)
+	if err != nil || timeSlot == nil {
+		return nil, common.ErrTimeSlotNotFound
+	}
+
+	output := &Output{
+		BookingID: existingBooking.ID,
+		Therapist: TherapistSummary{
+			ID:       therapist.ID,
+			Name:     therapist.Name,
+			PhotoURL: therapist.PhotoURL,
+		},
+		StartTime: existingBooking.StartTime,
+		Duration:  timeSlot.Duration,
+	}
+	if u.price.Amount > 0 {
+		price := u.price
+		output.Price = &price
+	}
+	return output, nil
+}
@@ -0,0 +1,64 @@
+package get_lead_time_stats
+
+import (
+	"math"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// Input represents the therapist and the optional UTC range of booking start
+// times the stats are computed over. Zero values leave that side open.
+type Input struct {
+	TherapistID domain.TherapistID
+	From        time.Time
+	To          time.Time
+}
+
+// Output reports how far in advance the therapist's confirmed bookings were
+// made, in minutes.
+type Output struct {
+	TherapistID            domain.TherapistID `json:"therapistId"`
+	Count                  int                `json:"count"`
+	AverageLeadTimeMinutes int                `json:"averageLeadTimeMinutes"`
+	MedianLeadTimeMinutes  int                `json:"medianLeadTimeMinutes"`
+}
+
+type Usecase struct {
+	bookingRepo   ports.BookingRepository
+	therapistRepo ports.TherapistRepository
+}
+
+func NewUsecase(bookingRepo ports.BookingRepository, therapistRepo ports.TherapistRepository) *Usecase {
+	return &Usecase{
+		bookingRepo:   bookingRepo,
+		therapistRepo: therapistRepo,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if input.TherapistID == "" {
+		return nil, common.ErrTherapistIDIsRequired
+	}
+	if !input.From.IsZero() && !input.To.IsZero() && input.To.Before(input.From) {
+		return nil, common.ErrInvalidDateRange
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	stats, err := u.bookingRepo.LeadTimeStats(input.TherapistID, input.From, input.To)
+	if err != nil {
+		return nil, common.ErrFailedToListBookings
+	}
+
+	return &Output{
+		TherapistID:            input.TherapistID,
+		Count:                  stats.Count,
+		AverageLeadTimeMinutes: int(math.Round(stats.AverageMinutes)),
+		MedianLeadTimeMinutes:  int(math.Round(stats.MedianMinutes)),
+	}, nil
+}
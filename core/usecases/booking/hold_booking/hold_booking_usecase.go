@@ -0,0 +1,155 @@
+package hold_booking
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/common/daily_limit"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+)
+
+type Input struct {
+	TherapistID          domain.TherapistID     `json:"therapistId"`
+	ClientID             domain.ClientID        `json:"clientId"`
+	TimeSlotID           domain.TimeSlotID      `json:"timeSlotId"`
+	StartTime            domain.UTCTimestamp    `json:"startTime"`
+	Duration             domain.DurationMinutes `json:"duration"`
+	ClientTimezoneOffset domain.TimezoneOffset  `json:"clientTimezoneOffset"`
+}
+
+type Output struct {
+	Token     domain.HoldToken       `json:"token"`
+	ExpiresAt domain.UTCTimestamp    `json:"expiresAt"`
+	Booking   *ports.BookingResponse `json:"booking"`
+}
+
+type Usecase struct {
+	bookingRepo       ports.BookingRepository
+	holdRepo          ports.BookingHoldRepository
+	clientRepo        ports.ClientRepository
+	checkAvailability check_availability.Usecase
+	holdDuration      time.Duration
+	unitOfWork        ports.UnitOfWork
+	therapistRepo     ports.TherapistRepository
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	holdRepo ports.BookingHoldRepository,
+	clientRepo ports.ClientRepository,
+	checkAvailability check_availability.Usecase,
+	holdDuration time.Duration,
+	unitOfWork ports.UnitOfWork,
+	therapistRepo ports.TherapistRepository,
+) *Usecase {
+	return &Usecase{
+		bookingRepo:       bookingRepo,
+		holdRepo:          holdRepo,
+		clientRepo:        clientRepo,
+		checkAvailability: checkAvailability,
+		holdDuration:      holdDuration,
+		unitOfWork:        unitOfWork,
+		therapistRepo:     therapistRepo,
+	}
+}
+
+// Execute reserves the booking's time for the hold duration. The booking is
+// created in the held state, which blocks other bookings like a confirmed
+// one, until the hold expires or the booking is confirmed or cancelled.
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if err := validateInput(input); err != nil {
+		return nil, err
+	}
+
+	clients, err := u.clientRepo.FindByIDs([]domain.ClientID{input.ClientID})
+	if err != nil || len(clients) == 0 {
+		return nil, common.ErrClientNotFound
+	}
+
+	// The slot must accept the booking exactly as create_booking would
+	availability, err := u.checkAvailability.Execute(check_availability.Input{
+		TherapistID: input.TherapistID,
+		TimeSlotID:  input.TimeSlotID,
+		StartTime:   input.StartTime,
+		Duration:    input.Duration,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !availability.Available {
+		return nil, common.ErrTimeSlotAlreadyBooked
+	}
+
+	if err := daily_limit.Check(u.therapistRepo, u.bookingRepo, input.TherapistID, input.StartTime); err != nil {
+		return nil, err
+	}
+
+	now := domain.NewUTCTimestamp()
+	heldBooking := &booking.Booking{
+		ID:                   domain.NewBookingID(),
+		TherapistID:          input.TherapistID,
+		ClientID:             input.ClientID,
+		TimeSlotID:           input.TimeSlotID,
+		StartTime:            input.StartTime,
+		Duration:             input.Duration,
+		ClientTimezoneOffset: input.ClientTimezoneOffset,
+		State:                booking.BookingStateHeld,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	hold := &booking.Hold{
+		Token:     domain.NewHoldToken(),
+		BookingID: heldBooking.ID,
+		ExpiresAt: now.Add(u.holdDuration),
+		CreatedAt: now,
+	}
+
+	// ------------------
+	// Hold the booking (run in a transaction)
+	// ------------------
+	// Without its hold nothing would ever release the booking, so both are
+	// written or neither is
+	err = u.unitOfWork.RunInTx(func(tx ports.SQLTx) error {
+		if err := u.bookingRepo.CreateTx(tx, heldBooking); err != nil {
+			return err
+		}
+		return u.holdRepo.CreateTx(tx, hold)
+	})
+	if err != nil {
+		return nil, booking.ErrFailedToHold
+	}
+	// ------------------
+
+	return &Output{
+		Token:     hold.Token,
+		ExpiresAt: hold.ExpiresAt,
+		Booking: &ports.BookingResponse{
+			RegularBookingID:     heldBooking.ID,
+			TherapistID:          heldBooking.TherapistID,
+			ClientID:             heldBooking.ClientID,
+			State:                heldBooking.State,
+			StartTime:            heldBooking.StartTime,
+			Duration:             heldBooking.Duration,
+			ClientTimezoneOffset: heldBooking.ClientTimezoneOffset,
+		},
+	}, nil
+}
+
+func validateInput(input Input) error {
+	if input.TherapistID == "" {
+		return common.ErrTherapistIDIsRequired
+	}
+	if input.ClientID == "" {
+		return common.ErrClientIDIsRequired
+	}
+	if input.TimeSlotID == "" {
+		return common.ErrTimeSlotIDIsRequired
+	}
+	if time.Time(input.StartTime).IsZero() {
+		return common.ErrStartTimeIsRequired
+	}
+	return nil
+}
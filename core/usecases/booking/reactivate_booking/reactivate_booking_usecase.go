@@ -0,0 +1,141 @@
+package reactivate_booking
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/common/daily_limit"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+)
+
+type Input struct {
+	BookingID domain.BookingID `json:"bookingId"`
+	Actor     string           `json:"-"` // Recorded in the booking's audit trail
+}
+
+type Usecase struct {
+	bookingRepo       ports.BookingRepository
+	transactionPort   ports.TransactionPort
+	checkAvailability check_availability.Usecase
+	gracePeriod       time.Duration
+	therapistRepo     ports.TherapistRepository
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	transactionPort ports.TransactionPort,
+	checkAvailability check_availability.Usecase,
+	gracePeriod time.Duration,
+	therapistRepo ports.TherapistRepository,
+) *Usecase {
+	return &Usecase{
+		bookingRepo:       bookingRepo,
+		transactionPort:   transactionPort,
+		checkAvailability: checkAvailability,
+		gracePeriod:       gracePeriod,
+		therapistRepo:     therapistRepo,
+	}
+}
+
+// Execute moves a booking cancelled within the grace period back to pending,
+// provided its time is still free in the slot.
+func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
+	if input.BookingID == "" {
+		return nil, common.ErrBookingIDIsRequired
+	}
+
+	existingBooking, err := u.bookingRepo.GetByID(input.BookingID)
+	if err != nil || existingBooking == nil {
+		return nil, common.ErrBookingNotFound
+	}
+
+	if existingBooking.State != booking.BookingStateCancelled {
+		return nil, common.ErrInvalidStateTransition
+	}
+
+	now := time.Now().UTC()
+	cancelledAt, err := u.cancelledAt(existingBooking)
+	if err != nil {
+		return nil, err
+	}
+	if now.Sub(cancelledAt) > u.gracePeriod {
+		return nil, booking.ErrReactivationWindowExpired
+	}
+
+	if !existingBooking.StartTime.Time().After(now) {
+		return nil, common.ErrInvalidBookingTime
+	}
+
+	// The slot must accept the booking exactly as create_booking would
+	availability, err := u.checkAvailability.Execute(check_availability.Input{
+		TherapistID: existingBooking.TherapistID,
+		TimeSlotID:  existingBooking.TimeSlotID,
+		StartTime:   existingBooking.StartTime,
+		Duration:    existingBooking.Duration,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !availability.Available {
+		return nil, common.ErrTimeSlotAlreadyBooked
+	}
+
+	if err := daily_limit.Check(u.therapistRepo, u.bookingRepo, existingBooking.TherapistID, existingBooking.StartTime); err != nil {
+		return nil, err
+	}
+
+	// ------------------
+	// Reactivate booking (run in a transaction)
+	// ------------------
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.bookingRepo.UpdateStateTx(
+		tx,
+		existingBooking.ID,
+		booking.BookingStatePending,
+		now,
+		input.Actor,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, booking.ErrFailedToReactivate
+	}
+
+	if err := u.transactionPort.Commit(tx); err != nil {
+		return nil, booking.ErrFailedToReactivate
+	}
+	// ------------------
+
+	return &ports.BookingResponse{
+		RegularBookingID:     existingBooking.ID,
+		TherapistID:          existingBooking.TherapistID,
+		ClientID:             existingBooking.ClientID,
+		State:                booking.BookingStatePending,
+		StartTime:            existingBooking.StartTime,
+		Duration:             existingBooking.Duration,
+		ClientTimezoneOffset: existingBooking.ClientTimezoneOffset,
+	}, nil
+}
+
+// cancelledAt returns when the booking was last cancelled according to its
+// audit trail. Bookings cancelled before the trail existed fall back to their
+// last update.
+func (u *Usecase) cancelledAt(b *booking.Booking) (time.Time, error) {
+	changes, err := u.bookingRepo.ListStateChanges(b.ID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for i := len(changes) - 1; i >= 0; i-- {
+		if changes[i].ToState == booking.BookingStateCancelled {
+			return changes[i].ChangedAt.Time(), nil
+		}
+	}
+	return b.UpdatedAt.Time(), nil
+}
@@ -0,0 +1,143 @@
+package reactivate_booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+func TestReactivateBooking(t *testing.T) {
+	slot := &timeslot.TimeSlot{
+		ID:          "slot_1",
+		TherapistID: "therapist_1",
+		IsActive:    true,
+		DayOfWeek:   timeslot.DayOfWeekMonday,
+		Start:       "09:00",
+		Duration:    180,
+	}
+	startTime := domain.UTCTimestamp(fakes.NextMonday().Add(10 * time.Hour))
+
+	cancelledBooking := func(cancelledAgo time.Duration) (*booking.Booking, *booking.StateChange) {
+		b := &booking.Booking{
+			ID:          "booking_1",
+			TherapistID: slot.TherapistID,
+			TimeSlotID:  slot.ID,
+			StartTime:   startTime,
+			Duration:    60,
+			State:       booking.BookingStateCancelled,
+		}
+		change := &booking.StateChange{
+			BookingID: b.ID,
+			FromState: booking.BookingStatePending,
+			ToState:   booking.BookingStateCancelled,
+			ChangedAt: domain.UTCTimestamp(time.Now().UTC().Add(-cancelledAgo)),
+		}
+		return b, change
+	}
+
+	maxDailySessions := map[domain.TherapistID]int{}
+	newUsecase := func(bookingRepo *fakes.BookingRepo) *Usecase {
+		therapistRepo := &fakes.TherapistRepo{
+			Therapists:       []*therapist.Therapist{{ID: slot.TherapistID}},
+			MaxDailySessions: maxDailySessions,
+		}
+		timeSlotRepo := &fakes.TimeSlotRepo{Slots: []*timeslot.TimeSlot{slot}}
+		adhocBookingRepo := &fakes.AdhocBookingRepo{}
+		getSchedule := get_schedule.NewUsecase(therapistRepo, timeSlotRepo, bookingRepo, adhocBookingRepo, nil, 15, nil, 0)
+		checkAvailability := check_availability.NewUsecase(bookingRepo, adhocBookingRepo, timeSlotRepo, *getSchedule)
+		return NewUsecase(bookingRepo, &fakes.TransactionPort{}, *checkAvailability, 30*time.Minute, therapistRepo)
+	}
+
+	t.Run("reactivates a recently cancelled booking", func(t *testing.T) {
+		existing, change := cancelledBooking(5 * time.Minute)
+		bookingRepo := &fakes.BookingRepo{
+			Bookings:     []*booking.Booking{existing},
+			StateChanges: []*booking.StateChange{change},
+		}
+
+		output, err := newUsecase(bookingRepo).Execute(Input{BookingID: existing.ID})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if output.State != booking.BookingStatePending {
+			t.Errorf("expected state %s in the response, got %s", booking.BookingStatePending, output.State)
+		}
+		if existing.State != booking.BookingStatePending {
+			t.Errorf("expected booking to be %s, got %s", booking.BookingStatePending, existing.State)
+		}
+	})
+
+	t.Run("rejects a slot that was booked since", func(t *testing.T) {
+		existing, change := cancelledBooking(5 * time.Minute)
+		blocking := &booking.Booking{
+			ID:          "booking_2",
+			TherapistID: slot.TherapistID,
+			TimeSlotID:  slot.ID,
+			StartTime:   startTime,
+			Duration:    60,
+			State:       booking.BookingStateConfirmed,
+		}
+		bookingRepo := &fakes.BookingRepo{
+			Bookings:     []*booking.Booking{existing, blocking},
+			StateChanges: []*booking.StateChange{change},
+		}
+
+		_, err := newUsecase(bookingRepo).Execute(Input{BookingID: existing.ID})
+		if err != common.ErrTimeSlotAlreadyBooked {
+			t.Fatalf("expected %v, got %v", common.ErrTimeSlotAlreadyBooked, err)
+		}
+		if existing.State != booking.BookingStateCancelled {
+			t.Errorf("expected booking to stay %s, got %s", booking.BookingStateCancelled, existing.State)
+		}
+	})
+
+	t.Run("rejects a therapist at their daily cap", func(t *testing.T) {
+		maxDailySessions[slot.TherapistID] = 1
+		defer delete(maxDailySessions, slot.TherapistID)
+
+		existing, change := cancelledBooking(5 * time.Minute)
+		// A pending booking later that day doesn't block the slot, but
+		// fills the therapist's day
+		pending := &booking.Booking{
+			ID:          "booking_2",
+			TherapistID: slot.TherapistID,
+			TimeSlotID:  slot.ID,
+			StartTime:   startTime.Add(time.Hour),
+			Duration:    60,
+			State:       booking.BookingStatePending,
+		}
+		bookingRepo := &fakes.BookingRepo{
+			Bookings:     []*booking.Booking{existing, pending},
+			StateChanges: []*booking.StateChange{change},
+		}
+
+		_, err := newUsecase(bookingRepo).Execute(Input{BookingID: existing.ID})
+		if err != booking.ErrDailySessionLimitReached {
+			t.Fatalf("expected %v, got %v", booking.ErrDailySessionLimitReached, err)
+		}
+		if existing.State != booking.BookingStateCancelled {
+			t.Errorf("expected booking to stay %s, got %s", booking.BookingStateCancelled, existing.State)
+		}
+	})
+
+	t.Run("rejects a booking cancelled outside the grace period", func(t *testing.T) {
+		existing, change := cancelledBooking(time.Hour)
+		bookingRepo := &fakes.BookingRepo{
+			Bookings:     []*booking.Booking{existing},
+			StateChanges: []*booking.StateChange{change},
+		}
+
+		_, err := newUsecase(bookingRepo).Execute(Input{BookingID: existing.ID})
+		if err != booking.ErrReactivationWindowExpired {
+			t.Fatalf("expected %v, got %v", booking.ErrReactivationWindowExpired, err)
+		}
+	})
+}
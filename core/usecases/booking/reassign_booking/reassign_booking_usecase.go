@@ -0,0 +1,203 @@
+package reassign_booking
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/common/daily_limit"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+)
+
+type Input struct {
+	BookingID      domain.BookingID
+	NewTherapistID domain.TherapistID
+	NewTimeSlotID  domain.TimeSlotID
+}
+
+type Usecase struct {
+	bookingRepo         ports.BookingRepository
+	therapistRepo       ports.TherapistRepository
+	notificationPort    ports.NotificationPort
+	notificationRepo    ports.NotificationRepository
+	transactionPort     ports.TransactionPort
+	checkAvailability   check_availability.Usecase
+	therapistAppBaseURL string
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	therapistRepo ports.TherapistRepository,
+	notificationPort ports.NotificationPort,
+	notificationRepo ports.NotificationRepository,
+	transactionPort ports.TransactionPort,
+	checkAvailability check_availability.Usecase,
+	therapistAppBaseURL string,
+) *Usecase {
+	return &Usecase{
+		bookingRepo:         bookingRepo,
+		therapistRepo:       therapistRepo,
+		notificationPort:    notificationPort,
+		notificationRepo:    notificationRepo,
+		transactionPort:     transactionPort,
+		checkAvailability:   checkAvailability,
+		therapistAppBaseURL: therapistAppBaseURL,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
+	if err := validateInput(input); err != nil {
+		return nil, err
+	}
+
+	existingBooking, err := u.bookingRepo.GetByID(input.BookingID)
+	if err != nil || existingBooking == nil {
+		return nil, common.ErrBookingNotFound
+	}
+	if existingBooking.State == booking.BookingStateCancelled {
+		return nil, common.ErrInvalidStateTransition
+	}
+
+	currentTherapist, err := u.therapistRepo.GetByID(existingBooking.TherapistID)
+	if err != nil || currentTherapist == nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	newTherapist, err := u.therapistRepo.GetByID(input.NewTherapistID)
+	if err != nil || newTherapist == nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	if !sharesSpecialization(currentTherapist, newTherapist) {
+		return nil, booking.ErrSpecializationMismatch
+	}
+
+	// The new slot must accept the booking exactly as create_booking would
+	availability, err := u.checkAvailability.Execute(check_availability.Input{
+		TherapistID: input.NewTherapistID,
+		TimeSlotID:  input.NewTimeSlotID,
+		StartTime:   existingBooking.StartTime,
+		Duration:    existingBooking.Duration,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !availability.Available {
+		return nil, common.ErrTimeSlotAlreadyBooked
+	}
+
+	// Moving between a therapist's own slots keeps the booking on its day,
+	// where it already counts towards their cap
+	if input.NewTherapistID != existingBooking.TherapistID {
+		if err := daily_limit.Check(u.therapistRepo, u.bookingRepo, input.NewTherapistID, existingBooking.StartTime); err != nil {
+			return nil, err
+		}
+	}
+
+	// ------------------
+	// Reassign booking (run in a transaction)
+	// ------------------
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.bookingRepo.ReassignTx(
+		tx,
+		existingBooking.ID,
+		input.NewTherapistID,
+		input.NewTimeSlotID,
+		domain.NewUTCTimestamp().Time(),
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, booking.ErrFailedToReassign
+	}
+
+	err = u.transactionPort.Commit(tx)
+	if err != nil {
+		return nil, booking.ErrFailedToReassign
+	}
+	// ------------------
+
+	u.notifyTherapists(currentTherapist, newTherapist, existingBooking)
+
+	return &ports.BookingResponse{
+		RegularBookingID:     existingBooking.ID,
+		TherapistID:          input.NewTherapistID,
+		ClientID:             existingBooking.ClientID,
+		State:                existingBooking.State,
+		StartTime:            existingBooking.StartTime,
+		Duration:             existingBooking.Duration,
+		ClientTimezoneOffset: existingBooking.ClientTimezoneOffset,
+	}, nil
+}
+
+func validateInput(input Input) error {
+	if input.BookingID == "" {
+		return common.ErrBookingIDIsRequired
+	}
+	if input.NewTherapistID == "" {
+		return common.ErrTherapistIDIsRequired
+	}
+	if input.NewTimeSlotID == "" {
+		return common.ErrTimeSlotIDIsRequired
+	}
+	return nil
+}
+
+func sharesSpecialization(current, candidate *therapist.Therapist) bool {
+	specializationIDs := make(map[domain.SpecializationID]struct{}, len(current.Specializations))
+	for _, s := range current.Specializations {
+		specializationIDs[s.ID] = struct{}{}
+	}
+	for _, s := range candidate.Specializations {
+		if _, ok := specializationIDs[s.ID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyTherapists tells the previous therapist the booking was moved away
+// and the new therapist that it was assigned to them. Failures are logged only.
+func (u *Usecase) notifyTherapists(previous, next *therapist.Therapist, reassigned *booking.Booking) {
+	date := reassigned.StartTime.Format(time.DateOnly)
+
+	u.notify(previous, ports.Notification{
+		Title:    "Booking Reassigned",
+		Body:     fmt.Sprintf("Your booking on %s was reassigned to %s", date, next.Name),
+		ImageURL: "https://therapist.mishkahtherapy.com/mishkah-logo.png",
+		Link:     fmt.Sprintf("%s/sessions", u.therapistAppBaseURL),
+	})
+
+	u.notify(next, ports.Notification{
+		Title:    "New Booking Assigned",
+		Body:     fmt.Sprintf("A booking on %s was assigned to you", date),
+		ImageURL: "https://therapist.mishkahtherapy.com/mishkah-logo.png",
+		Link:     fmt.Sprintf("%s/sessions", u.therapistAppBaseURL),
+	})
+}
+
+func (u *Usecase) notify(t *therapist.Therapist, notification ports.Notification) {
+	if t.DeviceID == "" {
+		slog.Info("therapist has no device id, skipping notification", "therapist_id", t.ID)
+		return
+	}
+
+	firebaseNotificationID, err := u.notificationPort.SendNotification(t.DeviceID, notification)
+	if err != nil {
+		slog.Warn("failed to notify therapist", "therapist_id", t.ID, "notification", notification.Body, "error", err)
+		return
+	}
+
+	err = u.notificationRepo.CreateNotification(t.ID, *firebaseNotificationID, notification)
+	if err != nil {
+		slog.Warn("failed to persist notification", "therapist_id", t.ID, "notification", notification.Body, "error", err)
+	}
+}
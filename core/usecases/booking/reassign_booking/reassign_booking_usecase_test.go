@@ -0,0 +1,171 @@
+package reassign_booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+func TestReassignBooking(t *testing.T) {
+	anxiety := specialization.Specialization{ID: "spec_anxiety", Name: "anxiety"}
+	depression := specialization.Specialization{ID: "spec_depression", Name: "depression"}
+
+	current := &therapist.Therapist{ID: "therapist_1", DeviceID: "device_1", Specializations: []specialization.Specialization{anxiety}}
+	colleague := &therapist.Therapist{ID: "therapist_2", DeviceID: "device_2", Specializations: []specialization.Specialization{anxiety, depression}}
+	unrelated := &therapist.Therapist{ID: "therapist_3", DeviceID: "device_3", Specializations: []specialization.Specialization{depression}}
+
+	slotFor := func(id domain.TimeSlotID, therapistID domain.TherapistID) *timeslot.TimeSlot {
+		return &timeslot.TimeSlot{
+			ID:          id,
+			TherapistID: therapistID,
+			IsActive:    true,
+			DayOfWeek:   timeslot.DayOfWeekMonday,
+			Start:       "09:00",
+			Duration:    180,
+		}
+	}
+	slots := []*timeslot.TimeSlot{
+		slotFor("slot_1", current.ID),
+		slotFor("slot_2", colleague.ID),
+		slotFor("slot_3", unrelated.ID),
+	}
+
+	monday := fakes.NextMonday()
+	newBooking := func() *booking.Booking {
+		return &booking.Booking{
+			ID:          "booking_1",
+			TherapistID: current.ID,
+			TimeSlotID:  "slot_1",
+			StartTime:   domain.UTCTimestamp(monday.Add(10 * time.Hour)),
+			Duration:    60,
+			State:       booking.BookingStateConfirmed,
+		}
+	}
+
+	maxDailySessions := map[domain.TherapistID]int{}
+	newUsecase := func(bookings []*booking.Booking, notificationPort *fakes.NotificationPort) *Usecase {
+		therapistRepo := &fakes.TherapistRepo{
+			Therapists:       []*therapist.Therapist{current, colleague, unrelated},
+			MaxDailySessions: maxDailySessions,
+		}
+		timeSlotRepo := &fakes.TimeSlotRepo{Slots: slots}
+		bookingRepo := &fakes.BookingRepo{Bookings: bookings}
+		adhocBookingRepo := &fakes.AdhocBookingRepo{}
+		getSchedule := get_schedule.NewUsecase(therapistRepo, timeSlotRepo, bookingRepo, adhocBookingRepo, nil, 15, nil, 0)
+		checkAvailability := check_availability.NewUsecase(bookingRepo, adhocBookingRepo, timeSlotRepo, *getSchedule)
+		return NewUsecase(
+			bookingRepo,
+			therapistRepo,
+			notificationPort,
+			&fakes.NotificationRepo{},
+			&fakes.TransactionPort{},
+			*checkAvailability,
+			"https://therapist.example.com",
+		)
+	}
+
+	t.Run("reassigns to a colleague with a shared specialization", func(t *testing.T) {
+		existing := newBooking()
+		notificationPort := &fakes.NotificationPort{}
+
+		output, err := newUsecase([]*booking.Booking{existing}, notificationPort).Execute(Input{
+			BookingID:      existing.ID,
+			NewTherapistID: colleague.ID,
+			NewTimeSlotID:  "slot_2",
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+
+		if output.TherapistID != colleague.ID {
+			t.Errorf("expected therapist %s, got %s", colleague.ID, output.TherapistID)
+		}
+		if existing.TherapistID != colleague.ID || existing.TimeSlotID != "slot_2" {
+			t.Errorf("expected booking to move to %s/slot_2, got %s/%s", colleague.ID, existing.TherapistID, existing.TimeSlotID)
+		}
+		if len(notificationPort.SentTo) != 2 {
+			t.Fatalf("expected 2 notifications, got %d", len(notificationPort.SentTo))
+		}
+		if notificationPort.SentTo[0] != current.DeviceID || notificationPort.SentTo[1] != colleague.DeviceID {
+			t.Errorf("expected notifications to %s and %s, got %v", current.DeviceID, colleague.DeviceID, notificationPort.SentTo)
+		}
+	})
+
+	t.Run("rejects a therapist without a matching specialization", func(t *testing.T) {
+		existing := newBooking()
+		notificationPort := &fakes.NotificationPort{}
+
+		_, err := newUsecase([]*booking.Booking{existing}, notificationPort).Execute(Input{
+			BookingID:      existing.ID,
+			NewTherapistID: unrelated.ID,
+			NewTimeSlotID:  "slot_3",
+		})
+		if err != booking.ErrSpecializationMismatch {
+			t.Fatalf("expected %v, got %v", booking.ErrSpecializationMismatch, err)
+		}
+		if existing.TherapistID != current.ID {
+			t.Errorf("expected booking to stay with %s, got %s", current.ID, existing.TherapistID)
+		}
+		if len(notificationPort.SentTo) != 0 {
+			t.Errorf("expected no notifications, got %d", len(notificationPort.SentTo))
+		}
+	})
+
+	t.Run("rejects a slot that is already booked", func(t *testing.T) {
+		existing := newBooking()
+		blocking := &booking.Booking{
+			ID:          "booking_2",
+			TherapistID: colleague.ID,
+			TimeSlotID:  "slot_2",
+			StartTime:   existing.StartTime,
+			Duration:    60,
+			State:       booking.BookingStateConfirmed,
+		}
+
+		_, err := newUsecase([]*booking.Booking{existing, blocking}, &fakes.NotificationPort{}).Execute(Input{
+			BookingID:      existing.ID,
+			NewTherapistID: colleague.ID,
+			NewTimeSlotID:  "slot_2",
+		})
+		if err != common.ErrTimeSlotAlreadyBooked {
+			t.Fatalf("expected %v, got %v", common.ErrTimeSlotAlreadyBooked, err)
+		}
+	})
+
+	t.Run("rejects a colleague at their daily cap", func(t *testing.T) {
+		maxDailySessions[colleague.ID] = 1
+		defer delete(maxDailySessions, colleague.ID)
+
+		existing := newBooking()
+		// Pending bookings don't block the slot but fill the colleague's day
+		pending := &booking.Booking{
+			ID:          "booking_2",
+			TherapistID: colleague.ID,
+			TimeSlotID:  "slot_2",
+			StartTime:   existing.StartTime,
+			Duration:    60,
+			State:       booking.BookingStatePending,
+		}
+
+		_, err := newUsecase([]*booking.Booking{existing, pending}, &fakes.NotificationPort{}).Execute(Input{
+			BookingID:      existing.ID,
+			NewTherapistID: colleague.ID,
+			NewTimeSlotID:  "slot_2",
+		})
+		if err != booking.ErrDailySessionLimitReached {
+			t.Fatalf("expected %v, got %v", booking.ErrDailySessionLimitReached, err)
+		}
+		if existing.TherapistID != current.ID {
+			t.Errorf("expected booking to stay with %s, got %s", current.ID, existing.TherapistID)
+		}
+	})
+}
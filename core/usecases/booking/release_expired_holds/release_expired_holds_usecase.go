@@ -0,0 +1,95 @@
+package release_expired_holds
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+// ExpiryActor is recorded in the audit trail of bookings whose hold expired
+const ExpiryActor = "system:hold-expiry"
+
+type Input struct {
+	Now time.Time // Defaults to the current time
+}
+
+type Output struct {
+	Released int `json:"released"`
+}
+
+type Usecase struct {
+	bookingRepo     ports.BookingRepository
+	holdRepo        ports.BookingHoldRepository
+	transactionPort ports.TransactionPort
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	holdRepo ports.BookingHoldRepository,
+	transactionPort ports.TransactionPort,
+) *Usecase {
+	return &Usecase{
+		bookingRepo:     bookingRepo,
+		holdRepo:        holdRepo,
+		transactionPort: transactionPort,
+	}
+}
+
+// Execute cancels the bookings of every expired hold, freeing their time,
+// and deletes the holds. A hold failing to release is logged and retried on
+// the next run.
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+
+	holds, err := u.holdRepo.ListExpired(now)
+	if err != nil {
+		return nil, err
+	}
+
+	released := 0
+	for _, hold := range holds {
+		if err := u.release(hold, now); err != nil {
+			slog.Error("error releasing expired booking hold",
+				slog.Group("hold", "token", hold.Token, "bookingId", hold.BookingID),
+				"error", err,
+			)
+			continue
+		}
+		released++
+	}
+
+	return &Output{Released: released}, nil
+}
+
+func (u *Usecase) release(hold *booking.Hold, now time.Time) error {
+	heldBooking, err := u.bookingRepo.GetByID(hold.BookingID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return err
+	}
+
+	// A booking that left the held state no longer needs releasing
+	if heldBooking.State == booking.BookingStateHeld {
+		err = u.bookingRepo.UpdateStateTx(tx, heldBooking.ID, booking.BookingStateCancelled, now, ExpiryActor)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := u.holdRepo.DeleteTx(tx, hold.Token); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return u.transactionPort.Commit(tx)
+}
@@ -0,0 +1,56 @@
+package resend_confirmation_notification
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/notification/notify_therapist_new_booking"
+)
+
+type Usecase struct {
+	bookingRepo     ports.BookingRepository
+	sessionRepo     ports.SessionRepository
+	notifyTherapist *notify_therapist_new_booking.Usecase
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	sessionRepo ports.SessionRepository,
+	notifyTherapist *notify_therapist_new_booking.Usecase,
+) *Usecase {
+	return &Usecase{
+		bookingRepo:     bookingRepo,
+		sessionRepo:     sessionRepo,
+		notifyTherapist: notifyTherapist,
+	}
+}
+
+// Execute sends the therapist the confirmation notification of a confirmed
+// booking again, e.g. after a new device was registered. Unlike the send on
+// confirmation it ignores the therapist's preferences, since it is requested
+// explicitly.
+func (u *Usecase) Execute(bookingID domain.BookingID) error {
+	if bookingID == "" {
+		return common.ErrBookingIDIsRequired
+	}
+
+	existingBooking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil || existingBooking == nil {
+		return common.ErrBookingNotFound
+	}
+
+	if existingBooking.State != booking.BookingStateConfirmed {
+		return common.ErrInvalidBookingState
+	}
+
+	session, err := u.sessionRepo.GetSessionByRegularBookingID(bookingID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return common.ErrSessionNotFound
+	}
+
+	return u.notifyTherapist.Send(session)
+}
@@ -0,0 +1,67 @@
+package resend_confirmation_notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/notification/notify_therapist_new_booking"
+)
+
+func TestResendConfirmationNotification(t *testing.T) {
+	therapistWithDevice := &therapist.Therapist{ID: "therapist_1", DeviceID: "device_1"}
+	therapistWithoutDevice := &therapist.Therapist{ID: "therapist_2"}
+	startTime := domain.UTCTimestamp(time.Now().UTC().AddDate(0, 0, 3))
+
+	bookings := []*booking.Booking{
+		{ID: "booking_confirmed", TherapistID: therapistWithDevice.ID, StartTime: startTime, Duration: 60, State: booking.BookingStateConfirmed},
+		{ID: "booking_pending", TherapistID: therapistWithDevice.ID, StartTime: startTime, Duration: 60, State: booking.BookingStatePending},
+		{ID: "booking_no_session", TherapistID: therapistWithDevice.ID, StartTime: startTime, Duration: 60, State: booking.BookingStateConfirmed},
+		{ID: "booking_no_device", TherapistID: therapistWithoutDevice.ID, StartTime: startTime, Duration: 60, State: booking.BookingStateConfirmed},
+	}
+	sessions := []*domain.Session{
+		{ID: "session_1", RegularBookingID: "booking_confirmed", TherapistID: therapistWithDevice.ID, StartTime: startTime},
+		{ID: "session_2", RegularBookingID: "booking_no_device", TherapistID: therapistWithoutDevice.ID, StartTime: startTime},
+	}
+
+	tests := []struct {
+		name      string
+		bookingID domain.BookingID
+		wantErr   error
+		wantSent  int
+	}{
+		{name: "Confirmed booking", bookingID: "booking_confirmed", wantSent: 1},
+		{name: "Pending booking", bookingID: "booking_pending", wantErr: common.ErrInvalidBookingState},
+		{name: "Unknown booking", bookingID: "booking_missing", wantErr: common.ErrBookingNotFound},
+		{name: "Missing booking ID", bookingID: "", wantErr: common.ErrBookingIDIsRequired},
+		{name: "Confirmed booking without a session", bookingID: "booking_no_session", wantErr: common.ErrSessionNotFound},
+		{name: "Therapist without a device", bookingID: "booking_no_device", wantErr: booking.ErrTherapistHasNoDevice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistWithDevice, therapistWithoutDevice}}
+			notificationPort := &fakes.NotificationPort{}
+			usecase := NewUsecase(
+				&fakes.BookingRepo{Bookings: bookings},
+				&fakes.SessionRepo{Sessions: sessions},
+				notify_therapist_new_booking.NewUsecase(therapistRepo, notificationPort, &fakes.NotificationRepo{}, "https://therapist.example.com"),
+			)
+
+			err := usecase.Execute(tt.bookingID)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(notificationPort.SentTo) != tt.wantSent {
+				t.Fatalf("expected %d notifications, got %d", tt.wantSent, len(notificationPort.SentTo))
+			}
+			if tt.wantSent > 0 && notificationPort.SentTo[0] != therapistWithDevice.DeviceID {
+				t.Errorf("expected the notification to go to %s, got %s", therapistWithDevice.DeviceID, notificationPort.SentTo[0])
+			}
+		})
+	}
+}
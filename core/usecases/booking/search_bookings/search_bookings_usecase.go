@@ -2,6 +2,7 @@ package search_bookings
 
 import (
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -10,18 +11,31 @@ import (
 	"github.com/mishkahtherapy/brain/core/domain/therapist"
 	"github.com/mishkahtherapy/brain/core/ports"
 	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/common/whatsapp_link"
 )
 
 // Input represents the parameters accepted by the Search Bookings use-case.
 // Start and End define the inclusive UTC time range to search within.
 // When State is nil no filtering by booking state is applied.
 // If provided, State must be one of the valid booking.BookingState constants.
+// Page selects which slice of the results is returned.
+// ClinicID limits the search to the clinic's therapists, the default clinic
+// when empty.
 // Validation is performed inside Execute.
 
 type Input struct {
-	Start  time.Time
-	End    time.Time
-	States []booking.BookingState
+	Start    time.Time
+	End      time.Time
+	States   []booking.BookingState
+	Page     ports.Page
+	ClinicID domain.ClinicID
+}
+
+// Result is one page of bookings. Next is where the following page starts,
+// nil on the last page.
+type Result struct {
+	Bookings []*Output
+	Next     *ports.PageCursor
 }
 
 type Output struct {
@@ -36,6 +50,7 @@ type Output struct {
 	Duration                domain.DurationMinutes `json:"duration"`
 	ClientTimezoneOffset    domain.TimezoneOffset  `json:"clientTimezoneOffset"`
 	TherapistTimezoneOffset domain.TimezoneOffset  `json:"therapistTimezoneOffset"`
+	WhatsAppLink            string                 `json:"whatsAppLink,omitempty"`
 }
 
 type Usecase struct {
@@ -43,6 +58,8 @@ type Usecase struct {
 	adhocBookingRepo ports.AdhocBookingRepository
 	therapistRepo    ports.TherapistRepository
 	clientRepo       ports.ClientRepository
+
+	whatsAppMessageTemplate string
 }
 
 func NewUsecase(
@@ -50,28 +67,37 @@ func NewUsecase(
 	adhocBookingRepo ports.AdhocBookingRepository,
 	therapistRepo ports.TherapistRepository,
 	clientRepo ports.ClientRepository,
+	whatsAppMessageTemplate string,
 ) *Usecase {
 	return &Usecase{
 		bookingRepo:      bookingRepo,
 		adhocBookingRepo: adhocBookingRepo,
 		therapistRepo:    therapistRepo,
 		clientRepo:       clientRepo,
+
+		whatsAppMessageTemplate: whatsAppMessageTemplate,
 	}
 }
 
-func (u *Usecase) Execute(input Input) ([]*Output, error) {
+func (u *Usecase) Execute(input Input) (*Result, error) {
 	// Validate date range only if both dates are provided
 	if !input.Start.IsZero() && !input.End.IsZero() && input.End.Before(input.Start) {
 		return nil, common.ErrInvalidDateRange
 	}
 
-	// Delegate to repository
-	bookings, err := u.bookingRepo.Search(input.Start, input.End, input.States)
+	// Regular and adhoc bookings live in separate tables. Read one extra row
+	// from each so we know whether another page follows after merging.
+	repoPage := input.Page
+	if repoPage.Limit > 0 {
+		repoPage.Limit++
+	}
+
+	bookings, err := u.bookingRepo.Search(input.ClinicID.OrDefault(), input.Start, input.End, input.States, repoPage)
 	if err != nil {
 		return nil, common.ErrFailedToListBookings
 	}
 
-	adhocBookings, err := u.adhocBookingRepo.Search(input.Start, input.End, input.States)
+	adhocBookings, err := u.adhocBookingRepo.Search(input.ClinicID.OrDefault(), input.Start, input.End, input.States, repoPage)
 	if err != nil {
 		return nil, common.ErrFailedToListBookings
 	}
@@ -119,6 +145,7 @@ func (u *Usecase) Execute(input Input) ([]*Output, error) {
 			Duration:                booking.Duration,
 			ClientTimezoneOffset:    booking.ClientTimezoneOffset,
 			TherapistTimezoneOffset: therapistMap[booking.TherapistID].TimezoneOffset,
+			WhatsAppLink:            u.whatsAppLink(clientMap[booking.ClientID], booking.StartTime, booking.ClientTimezoneOffset),
 		})
 	}
 
@@ -134,10 +161,38 @@ func (u *Usecase) Execute(input Input) ([]*Output, error) {
 			Duration:                adhocBooking.Duration,
 			ClientTimezoneOffset:    adhocBooking.ClientTimezoneOffset,
 			TherapistTimezoneOffset: therapistMap[adhocBooking.TherapistID].TimezoneOffset,
+			WhatsAppLink:            u.whatsAppLink(clientMap[adhocBooking.ClientID], adhocBooking.StartTime, adhocBooking.ClientTimezoneOffset),
 		})
 	}
 
-	return outputs, nil
+	// Merge both queries in the order the repositories page by
+	sort.Slice(outputs, func(i, j int) bool {
+		if !outputs[i].StartTime.Equal(outputs[j].StartTime) {
+			return outputs[i].StartTime.Before(outputs[j].StartTime)
+		}
+		return outputs[i].id() < outputs[j].id()
+	})
+
+	result := &Result{Bookings: outputs}
+	if input.Page.Limit > 0 && len(outputs) > input.Page.Limit {
+		result.Bookings = outputs[:input.Page.Limit]
+		last := result.Bookings[len(result.Bookings)-1]
+		result.Next = &ports.PageCursor{StartTime: last.StartTime.Time(), ID: last.id()}
+	}
+
+	return result, nil
+}
+
+// id returns the booking's ID, whichever kind of booking it is
+func (o *Output) id() string {
+	if o.RegularBookingID != "" {
+		return string(o.RegularBookingID)
+	}
+	return string(o.AdhocBookingID)
+}
+
+func (u *Usecase) whatsAppLink(client *client.Client, startTime domain.UTCTimestamp, clientTimezoneOffset domain.TimezoneOffset) string {
+	return whatsapp_link.Build(u.whatsAppMessageTemplate, client.Name, client.WhatsAppNumber, startTime, clientTimezoneOffset)
 }
 
 func getTherapistAndClientIds(bookings []*booking.Booking, adhocBookings []*booking.AdhocBooking) ([]domain.TherapistID, []domain.ClientID) {
@@ -0,0 +1,47 @@
+package stream_booking_events
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+type Input struct {
+	TherapistID domain.TherapistID
+}
+
+// Output delivers the therapist's booking events. Callers must call
+// Unsubscribe once they stop reading.
+type Output struct {
+	Events      <-chan booking.Event
+	Unsubscribe func()
+}
+
+type Usecase struct {
+	therapistRepo   ports.TherapistRepository
+	eventSubscriber ports.BookingEventSubscriber
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository, eventSubscriber ports.BookingEventSubscriber) *Usecase {
+	return &Usecase{
+		therapistRepo:   therapistRepo,
+		eventSubscriber: eventSubscriber,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if input.TherapistID == "" {
+		return nil, common.ErrTherapistIDIsRequired
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	events, unsubscribe := u.eventSubscriber.Subscribe(input.TherapistID)
+	return &Output{
+		Events:      events,
+		Unsubscribe: unsubscribe,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package update_booking_duration
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/common/overlap_detector"
+)
+
+type Input struct {
+	BookingID       domain.BookingID
+	DurationMinutes domain.DurationMinutes
+}
+
+type Usecase struct {
+	bookingRepo      ports.BookingRepository
+	adhocBookingRepo ports.AdhocBookingRepository
+	timeSlotRepo     ports.TimeSlotRepository
+	transactionPort  ports.TransactionPort
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	adhocBookingRepo ports.AdhocBookingRepository,
+	timeSlotRepo ports.TimeSlotRepository,
+	transactionPort ports.TransactionPort,
+) *Usecase {
+	return &Usecase{
+		bookingRepo:      bookingRepo,
+		adhocBookingRepo: adhocBookingRepo,
+		timeSlotRepo:     timeSlotRepo,
+		transactionPort:  transactionPort,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*ports.BookingResponse, error) {
+	if input.BookingID == "" {
+		return nil, common.ErrBookingIDIsRequired
+	}
+	if input.DurationMinutes <= 0 {
+		return nil, common.ErrDurationIsRequired
+	}
+
+	existingBooking, err := u.bookingRepo.GetByID(input.BookingID)
+	if err != nil || existingBooking == nil {
+		return nil, common.ErrBookingNotFound
+	}
+	if existingBooking.State == booking.BookingStateCancelled {
+		return nil, common.ErrInvalidStateTransition
+	}
+
+	timeSlot, err := u.timeSlotRepo.GetByID(existingBooking.TimeSlotID)
+	if err != nil || timeSlot == nil {
+		return nil, common.ErrTimeSlotNotFound
+	}
+
+	startTime := existingBooking.StartTime.Time()
+	endTime := startTime.Add(time.Duration(input.DurationMinutes) * time.Minute)
+
+	// The new window must stay inside the slot on the booking's local day
+	location, err := timeSlot.Location()
+	if err != nil {
+		return nil, err
+	}
+	slotStart, slotEnd := timeSlot.ApplyToDate(startTime.In(location))
+	if startTime.Before(slotStart.Time()) || endTime.After(slotEnd.Time()) {
+		return nil, booking.ErrOutsideTimeSlot
+	}
+
+	hasConflict, err := u.hasConflict(existingBooking, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	if hasConflict {
+		return nil, common.ErrTimeSlotAlreadyBooked
+	}
+
+	// ------------------
+	// Update booking and session (run in a transaction)
+	// ------------------
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.bookingRepo.UpdateDurationTx(tx, existingBooking.ID, input.DurationMinutes, domain.NewUTCTimestamp().Time())
+	if err != nil {
+		tx.Rollback()
+		return nil, booking.ErrFailedToUpdateDuration
+	}
+
+	if err := u.transactionPort.Commit(tx); err != nil {
+		return nil, booking.ErrFailedToUpdateDuration
+	}
+	// ------------------
+
+	return &ports.BookingResponse{
+		RegularBookingID:     existingBooking.ID,
+		TherapistID:          existingBooking.TherapistID,
+		ClientID:             existingBooking.ClientID,
+		State:                existingBooking.State,
+		StartTime:            existingBooking.StartTime,
+		Duration:             input.DurationMinutes,
+		ClientTimezoneOffset: existingBooking.ClientTimezoneOffset,
+	}, nil
+}
+
+// hasConflict reports whether another confirmed booking of the therapist
+// overlaps the new window. The booking itself is ignored.
+func (u *Usecase) hasConflict(existingBooking *booking.Booking, startTime, endTime time.Time) (bool, error) {
+	confirmedStates := []booking.BookingState{booking.BookingStateConfirmed}
+	detector := overlap_detector.New(startTime, endTime)
+
+	bookings, err := u.bookingRepo.ListByTherapistForDateRange(existingBooking.TherapistID, confirmedStates, startTime, endTime)
+	if err != nil {
+		return false, err
+	}
+	for _, b := range bookings {
+		if b.ID == existingBooking.ID {
+			continue
+		}
+		bookingStart := b.StartTime.Time()
+		if detector.HasOverlap(bookingStart, bookingStart.Add(time.Duration(b.Duration)*time.Minute)) {
+			return true, nil
+		}
+	}
+
+	adhocBookings, err := u.adhocBookingRepo.ListByTherapistForDateRange(existingBooking.TherapistID, confirmedStates, startTime, endTime)
+	if err != nil {
+		return false, err
+	}
+	for _, b := range adhocBookings {
+		bookingStart := b.StartTime.Time()
+		if detector.HasOverlap(bookingStart, bookingStart.Add(time.Duration(b.Duration)*time.Minute)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
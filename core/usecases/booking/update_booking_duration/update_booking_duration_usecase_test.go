@@ -0,0 +1,104 @@
+package update_booking_duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestUpdateBookingDuration(t *testing.T) {
+	// A Monday 10:00-13:00 slot with two back-to-back hour-long bookings
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	slot := &timeslot.TimeSlot{
+		ID:          "slot_1",
+		TherapistID: "therapist_1",
+		IsActive:    true,
+		DayOfWeek:   timeslot.DayOfWeekMonday,
+		Start:       "10:00",
+		Duration:    180,
+	}
+
+	newFixture := func() (*Usecase, *fakes.BookingRepo) {
+		bookingRepo := &fakes.BookingRepo{Bookings: []*booking.Booking{
+			{
+				ID:          "booking_1",
+				TimeSlotID:  slot.ID,
+				TherapistID: slot.TherapistID,
+				ClientID:    "client_1",
+				State:       booking.BookingStateConfirmed,
+				StartTime:   domain.UTCTimestamp(monday.Add(10 * time.Hour)),
+				Duration:    60,
+			},
+			{
+				ID:          "booking_2",
+				TimeSlotID:  slot.ID,
+				TherapistID: slot.TherapistID,
+				ClientID:    "client_2",
+				State:       booking.BookingStateConfirmed,
+				StartTime:   domain.UTCTimestamp(monday.Add(11 * time.Hour)),
+				Duration:    60,
+			},
+		}}
+		usecase := NewUsecase(
+			bookingRepo,
+			&fakes.AdhocBookingRepo{},
+			&fakes.TimeSlotRepo{Slots: []*timeslot.TimeSlot{slot}},
+			&fakes.TransactionPort{},
+		)
+		return usecase, bookingRepo
+	}
+
+	t.Run("extending into another booking is rejected", func(t *testing.T) {
+		usecase, bookingRepo := newFixture()
+
+		_, err := usecase.Execute(Input{BookingID: "booking_1", DurationMinutes: 90})
+		if err != common.ErrTimeSlotAlreadyBooked {
+			t.Fatalf("Expected %v, got %v", common.ErrTimeSlotAlreadyBooked, err)
+		}
+
+		b, _ := bookingRepo.GetByID("booking_1")
+		if b.Duration != 60 {
+			t.Errorf("Expected duration to stay 60, got %d", b.Duration)
+		}
+	})
+
+	t.Run("shortening is accepted", func(t *testing.T) {
+		usecase, bookingRepo := newFixture()
+
+		output, err := usecase.Execute(Input{BookingID: "booking_1", DurationMinutes: 30})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if output.Duration != 30 {
+			t.Errorf("Expected output duration 30, got %d", output.Duration)
+		}
+
+		b, _ := bookingRepo.GetByID("booking_1")
+		if b.Duration != 30 {
+			t.Errorf("Expected stored duration 30, got %d", b.Duration)
+		}
+	})
+
+	t.Run("extending past the end of the time slot is rejected", func(t *testing.T) {
+		usecase, _ := newFixture()
+
+		_, err := usecase.Execute(Input{BookingID: "booking_2", DurationMinutes: 150})
+		if err != booking.ErrOutsideTimeSlot {
+			t.Fatalf("Expected %v, got %v", booking.ErrOutsideTimeSlot, err)
+		}
+	})
+
+	t.Run("missing duration", func(t *testing.T) {
+		usecase, _ := newFixture()
+
+		_, err := usecase.Execute(Input{BookingID: "booking_1"})
+		if err != common.ErrDurationIsRequired {
+			t.Fatalf("Expected %v, got %v", common.ErrDurationIsRequired, err)
+		}
+	})
+}
@@ -8,19 +8,18 @@ import (
 	"github.com/mishkahtherapy/brain/core/domain/booking"
 	"github.com/mishkahtherapy/brain/core/domain/client"
 	"github.com/mishkahtherapy/brain/core/ports"
+	clientvalidation "github.com/mishkahtherapy/brain/core/usecases/client"
 	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
 )
 
-var (
-	ErrWhatsAppNumberIsRequired = errors.New("whatsapp number is required")
-	ErrInvalidWhatsAppNumber    = errors.New("invalid whatsapp number format")
-	ErrClientAlreadyExists      = errors.New("client with this whatsapp number already exists")
-	ErrInvalidTimezoneOffset    = errors.New("invalid timezoneOffset")
-)
+var ErrInvalidTimezoneOffset = errors.New("invalid timezoneOffset")
 
+// Input needs a WhatsApp number, an email or both.
 type Input struct {
+	ClinicID       domain.ClinicID       `json:"-"` // Taken from the request, not the body
 	Name           string                `json:"name"`
 	WhatsAppNumber domain.WhatsAppNumber `json:"whatsAppNumber"`
+	Email          domain.Email          `json:"email"`
 	TimezoneOffset domain.TimezoneOffset `json:"timezoneOffset"` // Minutes east of UTC, required
 }
 
@@ -35,27 +34,34 @@ func NewUsecase(clientRepo ports.ClientRepository) *Usecase {
 }
 
 func (u *Usecase) Execute(input Input) (*client.Client, error) {
+	// Store numbers in E.164 and emails lowercased so differently formatted
+	// duplicates collide
+	whatsAppNumber, email, err := clientvalidation.NormalizeContact(input.WhatsAppNumber, input.Email)
+	if err != nil {
+		return nil, err
+	}
+	input.WhatsAppNumber = whatsAppNumber
+	input.Email = email
+
 	// Validate input
 	if err := u.validateInput(input); err != nil {
 		return nil, err
 	}
 
-	// Check if client already exists with this WhatsApp number
-	existingClient, err := u.clientRepo.GetByWhatsAppNumber(input.WhatsAppNumber)
-	if err != nil {
+	// Check no client already uses this WhatsApp number or email
+	if err := clientvalidation.ValidateContactUniqueness(u.clientRepo, input.WhatsAppNumber, input.Email, nil); err != nil {
 		return nil, err
 	}
-	if existingClient != nil {
-		return nil, ErrClientAlreadyExists
-	}
 
 	// Create new client
 	client := &client.Client{
 		ID:             domain.NewClientID(),
 		Name:           strings.TrimSpace(input.Name),
 		WhatsAppNumber: input.WhatsAppNumber,
+		Email:          input.Email,
 		TimezoneOffset: input.TimezoneOffset,
 		Bookings:       []booking.Booking{},
+		ClinicID:       input.ClinicID.OrDefault(),
 		CreatedAt:      domain.NewUTCTimestamp(),
 		UpdatedAt:      domain.NewUTCTimestamp(),
 	}
@@ -68,25 +74,45 @@ func (u *Usecase) Execute(input Input) (*client.Client, error) {
 	return client, nil
 }
 
-func (u *Usecase) validateInput(input Input) error {
-	// Validate WhatsApp number
-	if string(input.WhatsAppNumber) == "" {
-		return ErrWhatsAppNumberIsRequired
+// Validate checks the input like Execute does, but reports every invalid
+// field at once as *domain.ValidationErrors instead of stopping at the first.
+// Other errors, such as failing to look up existing clients, are returned as
+// is.
+func (u *Usecase) Validate(input Input) error {
+	errs := &domain.ValidationErrors{}
+
+	whatsAppNumber, whatsAppErr := clientvalidation.NormalizeWhatsAppNumber(input.WhatsAppNumber)
+	errs.Add("whatsAppNumber", whatsAppErr)
+	email, emailErr := clientvalidation.NormalizeEmail(input.Email)
+	errs.Add("email", emailErr)
+
+	if input.WhatsAppNumber == "" && input.Email.Normalize() == "" {
+		errs.Add("whatsAppNumber", client.ErrClientContactIsRequired)
+		errs.Add("email", client.ErrClientContactIsRequired)
 	}
 
-	// Basic WhatsApp number format validation (starts with + and has digits)
-	whatsAppStr := string(input.WhatsAppNumber)
-	if !strings.HasPrefix(whatsAppStr, "+") || len(whatsAppStr) < 8 {
-		return ErrInvalidWhatsAppNumber
-	}
+	errs.Add("timezoneOffset", u.validateInput(input))
 
-	// Check if the rest are digits (after the +)
-	for _, char := range whatsAppStr[1:] {
-		if char < '0' || char > '9' {
-			return ErrInvalidWhatsAppNumber
+	// Only check valid contact details are unused, each against its own field
+	if whatsAppErr == nil && whatsAppNumber != "" {
+		err := clientvalidation.ValidateContactUniqueness(u.clientRepo, whatsAppNumber, "", nil)
+		if err != nil && err != client.ErrClientAlreadyExists {
+			return err
+		}
+		errs.Add("whatsAppNumber", err)
+	}
+	if emailErr == nil && email != "" {
+		err := clientvalidation.ValidateContactUniqueness(u.clientRepo, "", email, nil)
+		if err != nil && err != client.ErrClientEmailAlreadyExists {
+			return err
 		}
+		errs.Add("email", err)
 	}
 
+	return errs.Err()
+}
+
+func (u *Usecase) validateInput(input Input) error {
 	// Validate timezone offset
 	if err := timeslot_usecase.ValidateTimezoneOffset(input.TimezoneOffset); err != nil {
 		return ErrInvalidTimezoneOffset
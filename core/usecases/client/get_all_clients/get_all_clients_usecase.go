@@ -9,6 +9,7 @@ import (
 )
 
 type Input struct {
+	ClinicID domain.ClinicID
 	WhatsApp domain.WhatsAppNumber
 	Ids      []domain.ClientID
 }
@@ -24,7 +25,7 @@ func NewUsecase(clientRepo ports.ClientRepository) *Usecase {
 }
 
 func (u *Usecase) Execute(input Input) ([]*client.Client, error) {
-	clients, err := u.clientRepo.List()
+	clients, err := u.clientRepo.List(input.ClinicID.OrDefault())
 	if err != nil {
 		return nil, err
 	}
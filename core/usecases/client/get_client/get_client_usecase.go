@@ -17,7 +17,9 @@ func NewUsecase(clientRepo ports.ClientRepository) *Usecase {
 	}
 }
 
-func (u *Usecase) Execute(ids []domain.ClientID) ([]*client.Client, error) {
+// Execute returns the clients, refusing the lookup when any of them belongs
+// to another clinic
+func (u *Usecase) Execute(clinicID domain.ClinicID, ids []domain.ClientID) ([]*client.Client, error) {
 	clients, err := u.clientRepo.FindByIDs(ids)
 	if err != nil {
 		return nil, err
@@ -27,5 +29,11 @@ func (u *Usecase) Execute(ids []domain.ClientID) ([]*client.Client, error) {
 		return nil, common.ErrClientNotFound
 	}
 
+	for _, client := range clients {
+		if client.ClinicID != clinicID.OrDefault() {
+			return nil, common.ErrClinicMismatch
+		}
+	}
+
 	return clients, nil
 }
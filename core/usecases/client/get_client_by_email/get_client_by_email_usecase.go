@@ -0,0 +1,45 @@
+package get_client_by_email
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+type Input struct {
+	ClinicID domain.ClinicID
+	Email    domain.Email
+}
+
+type Usecase struct {
+	clientRepo ports.ClientRepository
+}
+
+func NewUsecase(clientRepo ports.ClientRepository) *Usecase {
+	return &Usecase{
+		clientRepo: clientRepo,
+	}
+}
+
+// Execute returns the client, refusing the lookup when they belong to
+// another clinic
+func (u *Usecase) Execute(input Input) (*client.Client, error) {
+	email := input.Email.Normalize()
+	if !email.IsValid() {
+		return nil, client.ErrClientInvalidEmail
+	}
+
+	found, err := u.clientRepo.GetByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, common.ErrClientNotFound
+	}
+	if found.ClinicID != input.ClinicID.OrDefault() {
+		return nil, common.ErrClinicMismatch
+	}
+
+	return found, nil
+}
@@ -0,0 +1,49 @@
+package get_client_by_whatsapp
+
+import (
+	"errors"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+var ErrInvalidWhatsAppNumber = errors.New("invalid whatsapp number format")
+
+type Input struct {
+	ClinicID       domain.ClinicID
+	WhatsAppNumber domain.WhatsAppNumber
+}
+
+type Usecase struct {
+	clientRepo ports.ClientRepository
+}
+
+func NewUsecase(clientRepo ports.ClientRepository) *Usecase {
+	return &Usecase{
+		clientRepo: clientRepo,
+	}
+}
+
+// Execute returns the client, refusing the lookup when they belong to
+// another clinic
+func (u *Usecase) Execute(input Input) (*client.Client, error) {
+	number := input.WhatsAppNumber.Normalize()
+	if number == "" || !number.IsValid() {
+		return nil, ErrInvalidWhatsAppNumber
+	}
+
+	found, err := u.clientRepo.GetByWhatsAppNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, common.ErrClientNotFound
+	}
+	if found.ClinicID != input.ClinicID.OrDefault() {
+		return nil, common.ErrClinicMismatch
+	}
+
+	return found, nil
+}
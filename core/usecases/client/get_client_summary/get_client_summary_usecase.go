@@ -0,0 +1,40 @@
+package get_client_summary
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+type Input struct {
+	ClientID domain.ClientID `json:"clientId"`
+}
+
+type Usecase struct {
+	clientRepo ports.ClientRepository
+}
+
+func NewUsecase(clientRepo ports.ClientRepository) *Usecase {
+	return &Usecase{
+		clientRepo: clientRepo,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*client.Summary, error) {
+	if input.ClientID == "" {
+		return nil, common.ErrClientIDIsRequired
+	}
+
+	clients, err := u.clientRepo.FindByIDs([]domain.ClientID{input.ClientID})
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		return nil, common.ErrClientNotFound
+	}
+
+	return u.clientRepo.GetSummary(input.ClientID, time.Now().UTC())
+}
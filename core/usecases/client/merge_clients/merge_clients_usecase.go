@@ -0,0 +1,81 @@
+package merge_clients
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+var ErrCannotMergeIntoSelf = errors.New("cannot merge a client into itself")
+
+type Input struct {
+	ClinicID domain.ClinicID `json:"-"`
+	SourceID domain.ClientID `json:"sourceId"`
+	TargetID domain.ClientID `json:"targetId"`
+}
+
+type Output struct {
+	SourceID      domain.ClientID `json:"sourceId"`
+	TargetID      domain.ClientID `json:"targetId"`
+	MovedBookings int             `json:"movedBookings"`
+	MovedSessions int             `json:"movedSessions"`
+}
+
+type Usecase struct {
+	clientRepo ports.ClientRepository
+	unitOfWork ports.UnitOfWork
+}
+
+func NewUsecase(clientRepo ports.ClientRepository, unitOfWork ports.UnitOfWork) *Usecase {
+	return &Usecase{
+		clientRepo: clientRepo,
+		unitOfWork: unitOfWork,
+	}
+}
+
+// Execute moves the source client's bookings and sessions over to the target
+// and soft-deletes the source, all in one transaction
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if input.SourceID == "" || input.TargetID == "" {
+		return nil, common.ErrClientIDIsRequired
+	}
+	if input.SourceID == input.TargetID {
+		return nil, ErrCannotMergeIntoSelf
+	}
+
+	clients, err := u.clientRepo.FindByIDs([]domain.ClientID{input.SourceID, input.TargetID})
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) != 2 {
+		return nil, common.ErrClientNotFound
+	}
+	for _, client := range clients {
+		if client.ClinicID != input.ClinicID.OrDefault() {
+			return nil, common.ErrClinicMismatch
+		}
+	}
+
+	output := &Output{SourceID: input.SourceID, TargetID: input.TargetID}
+	err = u.unitOfWork.RunInTx(func(tx ports.SQLTx) error {
+		movedBookings, err := u.clientRepo.MoveBookingsTx(tx, input.SourceID, input.TargetID)
+		if err != nil {
+			return err
+		}
+		movedSessions, err := u.clientRepo.MoveSessionsTx(tx, input.SourceID, input.TargetID)
+		if err != nil {
+			return err
+		}
+		output.MovedBookings = movedBookings
+		output.MovedSessions = movedSessions
+		return u.clientRepo.SoftDeleteTx(tx, input.SourceID, time.Now().UTC())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
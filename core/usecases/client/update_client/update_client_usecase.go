@@ -0,0 +1,64 @@
+package update_client
+
+import (
+	"strings"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/ports"
+	clientvalidation "github.com/mishkahtherapy/brain/core/usecases/client"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// Input replaces the client's name and contact details. Like on create, a
+// WhatsApp number, an email or both are required.
+type Input struct {
+	ClientID       domain.ClientID       `json:"-"`
+	Name           string                `json:"name"`
+	WhatsAppNumber domain.WhatsAppNumber `json:"whatsAppNumber"`
+	Email          domain.Email          `json:"email"`
+}
+
+type Usecase struct {
+	clientRepo ports.ClientRepository
+}
+
+func NewUsecase(clientRepo ports.ClientRepository) *Usecase {
+	return &Usecase{
+		clientRepo: clientRepo,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*client.Client, error) {
+	if input.ClientID == "" {
+		return nil, common.ErrClientIDIsRequired
+	}
+
+	whatsAppNumber, email, err := clientvalidation.NormalizeContact(input.WhatsAppNumber, input.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := u.clientRepo.FindByIDs([]domain.ClientID{input.ClientID})
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		return nil, common.ErrClientNotFound
+	}
+
+	if err := clientvalidation.ValidateContactUniqueness(u.clientRepo, whatsAppNumber, email, &input.ClientID); err != nil {
+		return nil, err
+	}
+
+	updatedClient := clients[0]
+	updatedClient.Name = strings.TrimSpace(input.Name)
+	updatedClient.WhatsAppNumber = whatsAppNumber
+	updatedClient.Email = email
+	updatedClient.UpdatedAt = domain.NewUTCTimestamp()
+
+	if err := u.clientRepo.Update(updatedClient); err != nil {
+		return nil, err
+	}
+	return updatedClient, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
 	"github.com/mishkahtherapy/brain/core/ports"
 	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
 )
@@ -28,21 +29,27 @@ func NewUsecase(clientRepo ports.ClientRepository) *Usecase {
 	}
 }
 
-func (u *Usecase) Execute(input Input) error {
+func (u *Usecase) Execute(input Input) (*client.Client, error) {
 	// Validate offset
 	if err := timeslot_usecase.ValidateTimezoneOffset(input.TimezoneOffset); err != nil {
-		return ErrInvalidTimezoneOffset
+		return nil, ErrInvalidTimezoneOffset
 	}
 
 	// Check if client exists
 	clients, err := u.clientRepo.FindByIDs([]domain.ClientID{input.ClientID})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(clients) == 0 {
-		return ErrClientNotFound
+		return nil, ErrClientNotFound
 	}
 
 	// Update client timezone offset
-	return u.clientRepo.UpdateTimezoneOffset(input.ClientID, input.TimezoneOffset)
+	if err := u.clientRepo.UpdateTimezoneOffset(input.ClientID, input.TimezoneOffset); err != nil {
+		return nil, err
+	}
+
+	updatedClient := clients[0]
+	updatedClient.TimezoneOffset = input.TimezoneOffset
+	return updatedClient, nil
 }
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+// NormalizeContact canonicalizes the client's contact details, the WhatsApp
+// number to E.164 and the email to lowercase, and checks that at least one of
+// them is given.
+func NormalizeContact(whatsAppNumber domain.WhatsAppNumber, email domain.Email) (domain.WhatsAppNumber, domain.Email, error) {
+	whatsAppNumber, err := NormalizeWhatsAppNumber(whatsAppNumber)
+	if err != nil {
+		return "", "", err
+	}
+
+	email, err = NormalizeEmail(email)
+	if err != nil {
+		return "", "", err
+	}
+
+	if whatsAppNumber == "" && email == "" {
+		return "", "", client.ErrClientContactIsRequired
+	}
+	return whatsAppNumber, email, nil
+}
+
+// NormalizeWhatsAppNumber returns an optional WhatsApp number in E.164
+func NormalizeWhatsAppNumber(whatsAppNumber domain.WhatsAppNumber) (domain.WhatsAppNumber, error) {
+	if whatsAppNumber == "" {
+		return "", nil
+	}
+
+	normalized, err := whatsAppNumber.ToE164()
+	if err != nil || len(normalized) < 8 {
+		return "", client.ErrClientInvalidWhatsAppNumber
+	}
+	return normalized, nil
+}
+
+// NormalizeEmail returns an optional email lowercased
+func NormalizeEmail(email domain.Email) (domain.Email, error) {
+	email = email.Normalize()
+	if email != "" && !email.IsValid() {
+		return "", client.ErrClientInvalidEmail
+	}
+	return email, nil
+}
+
+// ValidateContactUniqueness checks that no other client uses the WhatsApp
+// number or email. skipClientID is the client being updated, nil on create.
+func ValidateContactUniqueness(
+	repo ports.ClientRepository,
+	whatsAppNumber domain.WhatsAppNumber,
+	email domain.Email,
+	skipClientID *domain.ClientID,
+) error {
+	isOther := func(existing *client.Client) bool {
+		return existing != nil && (skipClientID == nil || existing.ID != *skipClientID)
+	}
+
+	if whatsAppNumber != "" {
+		existing, err := repo.GetByWhatsAppNumber(whatsAppNumber)
+		if err != nil {
+			return err
+		}
+		if isOther(existing) {
+			return client.ErrClientAlreadyExists
+		}
+	}
+
+	if email != "" {
+		existing, err := repo.GetByEmail(email)
+		if err != nil {
+			return err
+		}
+		if isOther(existing) {
+			return client.ErrClientEmailAlreadyExists
+		}
+	}
+	return nil
+}
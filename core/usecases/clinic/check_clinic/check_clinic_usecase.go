@@ -0,0 +1,81 @@
+package check_clinic
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// Input names the records a request touches. ClinicID is the clinic the
+// request is scoped to, the default clinic when empty.
+type Input struct {
+	ClinicID          domain.ClinicID
+	TherapistIDs      []domain.TherapistID
+	ClientIDs         []domain.ClientID
+	SpecializationIDs []domain.SpecializationID
+	BookingIDs        []domain.BookingID
+	AdhocBookingIDs   []domain.AdhocBookingID
+	HoldTokens        []domain.HoldToken
+	SessionIDs        []domain.SessionID
+}
+
+type Usecase struct {
+	clinicRepo ports.ClinicRepository
+}
+
+func NewUsecase(clinicRepo ports.ClinicRepository) *Usecase {
+	return &Usecase{clinicRepo: clinicRepo}
+}
+
+// Execute returns common.ErrClinicMismatch when any of the records belongs
+// to another clinic. Records that don't exist pass, so the usecase serving
+// the request reports them as not found.
+func (u *Usecase) Execute(input Input) error {
+	clinicID := input.ClinicID.OrDefault()
+	check := func(owner domain.ClinicID, err error) error {
+		if err != nil {
+			return err
+		}
+		if owner != "" && owner != clinicID {
+			return common.ErrClinicMismatch
+		}
+		return nil
+	}
+
+	for _, id := range input.TherapistIDs {
+		if err := check(u.clinicRepo.GetTherapistClinic(id)); err != nil {
+			return err
+		}
+	}
+	for _, id := range input.ClientIDs {
+		if err := check(u.clinicRepo.GetClientClinic(id)); err != nil {
+			return err
+		}
+	}
+	for _, id := range input.SpecializationIDs {
+		if err := check(u.clinicRepo.GetSpecializationClinic(id)); err != nil {
+			return err
+		}
+	}
+	for _, id := range input.BookingIDs {
+		if err := check(u.clinicRepo.GetBookingClinic(id)); err != nil {
+			return err
+		}
+	}
+	for _, id := range input.AdhocBookingIDs {
+		if err := check(u.clinicRepo.GetAdhocBookingClinic(id)); err != nil {
+			return err
+		}
+	}
+	for _, token := range input.HoldTokens {
+		if err := check(u.clinicRepo.GetHoldClinic(token)); err != nil {
+			return err
+		}
+	}
+	for _, id := range input.SessionIDs {
+		if err := check(u.clinicRepo.GetSessionClinic(id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package check_clinic
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestCheckClinic(t *testing.T) {
+	clinicRepo := &fakes.ClinicRepo{Clinics: map[string]domain.ClinicID{
+		"therapist_a":    "clinic_a",
+		"client_a":       "clinic_a",
+		"booking_a":      "clinic_a",
+		"adhoc_a":        "clinic_a",
+		"hold_a":         "clinic_a",
+		"session_a":      "clinic_a",
+		"session_b":      "clinic_b",
+		"therapist_dflt": domain.DefaultClinicID,
+	}}
+
+	tests := []struct {
+		name        string
+		input       Input
+		expectedErr error
+	}{
+		{
+			name: "passes records of the request's clinic",
+			input: Input{
+				ClinicID:        "clinic_a",
+				TherapistIDs:    []domain.TherapistID{"therapist_a"},
+				ClientIDs:       []domain.ClientID{"client_a"},
+				BookingIDs:      []domain.BookingID{"booking_a"},
+				AdhocBookingIDs: []domain.AdhocBookingID{"adhoc_a"},
+				HoldTokens:      []domain.HoldToken{"hold_a"},
+				SessionIDs:      []domain.SessionID{"session_a"},
+			},
+		},
+		{
+			name:  "passes records that don't exist",
+			input: Input{ClinicID: "clinic_b", BookingIDs: []domain.BookingID{"booking_missing"}},
+		},
+		{
+			name:  "an empty clinic is the default clinic",
+			input: Input{TherapistIDs: []domain.TherapistID{"therapist_dflt"}},
+		},
+		{
+			name:        "rejects a booking of another clinic",
+			input:       Input{ClinicID: "clinic_b", BookingIDs: []domain.BookingID{"booking_a"}},
+			expectedErr: common.ErrClinicMismatch,
+		},
+		{
+			name:        "rejects a client of another clinic",
+			input:       Input{ClinicID: "clinic_b", ClientIDs: []domain.ClientID{"client_a"}},
+			expectedErr: common.ErrClinicMismatch,
+		},
+		{
+			name:        "rejects a hold of another clinic",
+			input:       Input{ClinicID: "clinic_b", HoldTokens: []domain.HoldToken{"hold_a"}},
+			expectedErr: common.ErrClinicMismatch,
+		},
+		{
+			name: "rejects when any record is of another clinic",
+			input: Input{
+				ClinicID:   "clinic_b",
+				SessionIDs: []domain.SessionID{"session_b", "session_a"},
+			},
+			expectedErr: common.ErrClinicMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := NewUsecase(clinicRepo).Execute(test.input)
+			if err != test.expectedErr {
+				t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
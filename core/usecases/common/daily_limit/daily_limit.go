@@ -0,0 +1,65 @@
+package daily_limit
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// States are the bookings counting towards a therapist's daily cap. Held
+// bookings reserve their time like confirmed ones, so they count too.
+var States = []booking.BookingState{
+	booking.BookingStatePending,
+	booking.BookingStateConfirmed,
+	booking.BookingStateHeld,
+}
+
+// Check rejects a booking starting at startTime when the therapist already
+// has their maximum number of bookings on its date, local to the therapist.
+// create_booking, hold_booking, reassign_booking and reactivate_booking all
+// accept a booking through it.
+func Check(
+	therapistRepo ports.TherapistRepository,
+	bookingRepo ports.BookingRepository,
+	therapistID domain.TherapistID,
+	startTime domain.UTCTimestamp,
+) error {
+	maxDailySessions, err := therapistRepo.GetMaxDailySessions(therapistID)
+	if err != nil {
+		return common.ErrTherapistNotFound
+	}
+	if maxDailySessions <= 0 {
+		return nil
+	}
+
+	therapist, err := therapistRepo.GetByID(therapistID)
+	if err != nil || therapist == nil {
+		return common.ErrTherapistNotFound
+	}
+
+	zone := time.FixedZone("", int(therapist.TimezoneOffset)*60)
+	localStart := startTime.Time().In(zone)
+	dayStart := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, zone)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	bookings, err := bookingRepo.ListByTherapistForDateRange(therapistID, States, dayStart.UTC(), dayEnd.UTC())
+	if err != nil {
+		return common.ErrFailedToListBookings
+	}
+
+	// The listing also returns bookings that only end inside the day
+	count := 0
+	for _, b := range bookings {
+		start := b.StartTime.Time()
+		if !start.Before(dayStart) && start.Before(dayEnd) {
+			count++
+		}
+	}
+	if count >= maxDailySessions {
+		return booking.ErrDailySessionLimitReached
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package daily_limit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestCheck(t *testing.T) {
+	// The therapist is three hours ahead of UTC, so their day starts at
+	// 21:00 UTC the evening before
+	const therapistID = domain.TherapistID("therapist_1")
+	day := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	startTime := domain.UTCTimestamp(day.Add(10 * time.Hour))
+	at := func(offset time.Duration) domain.UTCTimestamp {
+		return domain.UTCTimestamp(day.Add(offset))
+	}
+
+	tests := []struct {
+		name             string
+		maxDailySessions int
+		bookings         []*booking.Booking
+		expectedErr      error
+	}{
+		{
+			name:             "no cap",
+			maxDailySessions: 0,
+			bookings: []*booking.Booking{
+				{ID: "booking_1", TherapistID: therapistID, StartTime: at(9 * time.Hour), State: booking.BookingStateConfirmed},
+			},
+		},
+		{
+			name:             "under the cap",
+			maxDailySessions: 2,
+			bookings: []*booking.Booking{
+				{ID: "booking_1", TherapistID: therapistID, StartTime: at(9 * time.Hour), State: booking.BookingStatePending},
+			},
+		},
+		{
+			name:             "held bookings count towards the cap",
+			maxDailySessions: 2,
+			bookings: []*booking.Booking{
+				{ID: "booking_1", TherapistID: therapistID, StartTime: at(9 * time.Hour), State: booking.BookingStateConfirmed},
+				{ID: "booking_2", TherapistID: therapistID, StartTime: at(11 * time.Hour), State: booking.BookingStateHeld},
+			},
+			expectedErr: booking.ErrDailySessionLimitReached,
+		},
+		{
+			name:             "cancelled bookings don't count",
+			maxDailySessions: 1,
+			bookings: []*booking.Booking{
+				{ID: "booking_1", TherapistID: therapistID, StartTime: at(9 * time.Hour), State: booking.BookingStateCancelled},
+			},
+		},
+		{
+			name:             "the day is local to the therapist",
+			maxDailySessions: 1,
+			bookings: []*booking.Booking{
+				// 20:30 UTC is still the previous local day
+				{ID: "booking_1", TherapistID: therapistID, StartTime: at(-210 * time.Minute), State: booking.BookingStateConfirmed},
+			},
+		},
+		{
+			name:             "an evening booking before midnight UTC is on the local day",
+			maxDailySessions: 1,
+			bookings: []*booking.Booking{
+				{ID: "booking_1", TherapistID: therapistID, StartTime: at(-150 * time.Minute), State: booking.BookingStateConfirmed},
+			},
+			expectedErr: booking.ErrDailySessionLimitReached,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			therapistRepo := &fakes.TherapistRepo{
+				Therapists:       []*therapist.Therapist{{ID: therapistID, TimezoneOffset: 180}},
+				MaxDailySessions: map[domain.TherapistID]int{therapistID: test.maxDailySessions},
+			}
+			bookingRepo := &fakes.BookingRepo{Bookings: test.bookings}
+
+			err := Check(therapistRepo, bookingRepo, therapistID, startTime)
+			if err != test.expectedErr {
+				t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+			}
+		})
+	}
+
+	t.Run("unknown therapist", func(t *testing.T) {
+		err := Check(&fakes.TherapistRepo{}, &fakes.BookingRepo{}, therapistID, startTime)
+		if err != common.ErrTherapistNotFound {
+			t.Fatalf("expected error %v, got %v", common.ErrTherapistNotFound, err)
+		}
+	})
+}
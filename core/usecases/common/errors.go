@@ -37,10 +37,18 @@ var (
 var (
 	ErrInvalidStateTransition = errors.New("invalid state transition")
 	ErrInvalidBookingState    = errors.New("booking must be in pending state to be confirmed")
+	ErrInvalidSessionState    = errors.New("invalid session state")
 	ErrTimeSlotAlreadyBooked  = errors.New("timeslot is already booked")
 	ErrInvalidBookingTime     = errors.New("booking time is not within the available time slot. Create an Adhoc Booking instead")
 	ErrMeetingURLNotSet       = errors.New("meeting URL is not set for this session")
 	ErrInvalidMeetingURL      = errors.New("invalid meeting URL format")
+	ErrMeetingURLNotHTTPS     = errors.New("meeting URL must use https")
+	ErrMeetingHostNotAllowed  = errors.New("meeting URL host is not allowed")
+)
+
+// Access errors
+var (
+	ErrClinicMismatch = errors.New("resource belongs to another clinic")
 )
 
 // Common validation errors that appear in multiple usecases
@@ -64,7 +72,9 @@ var (
 	ErrDurationIsRequired             = errors.New("duration is required")
 	ErrClientTimezoneOffsetIsRequired = errors.New("client timezone offset is required")
 	ErrPaidAmountIsRequired           = errors.New("paid amount is required")
+	ErrPaidAmountOutOfRange           = errors.New("paid amount is outside the allowed range")
 	ErrLanguageIsRequired             = errors.New("language is required")
+	ErrInvalidCurrency                = errors.New("currency is not supported")
 	ErrStateIsRequired                = errors.New("state is required")
 	ErrNotesIsRequired                = errors.New("notes is required")
 	ErrMeetingURLIsRequired           = errors.New("meeting URL is required")
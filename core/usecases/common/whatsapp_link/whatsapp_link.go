@@ -0,0 +1,63 @@
+package whatsapp_link
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+// Build returns a wa.me link to the client with the message
+// template filled in. The session time is rendered in the client's timezone.
+// An empty string is returned when the client has no usable number.
+func Build(
+	template string,
+	clientName string,
+	number domain.WhatsAppNumber,
+	startTime domain.UTCTimestamp,
+	clientTimezoneOffset domain.TimezoneOffset,
+) string {
+	return Link(number, Message(template, clientName, startTime, clientTimezoneOffset))
+}
+
+// Message fills the {clientName} and {startTime} placeholders of the template,
+// rendering the session time in the client's timezone.
+func Message(
+	template string,
+	clientName string,
+	startTime domain.UTCTimestamp,
+	clientTimezoneOffset domain.TimezoneOffset,
+) string {
+	offsetSeconds := int(clientTimezoneOffset) * 60
+	clientZone := time.FixedZone(zoneLabel(clientTimezoneOffset), offsetSeconds)
+	clientTime := startTime.Time().In(clientZone)
+
+	return strings.NewReplacer(
+		"{clientName}", clientName,
+		"{startTime}", clientTime.Format("Mon 2 Jan 2006 15:04 MST"),
+	).Replace(template)
+}
+
+// zoneLabel renders the offset as UTC+03:00, keeping the minutes of offsets
+// such as India's +05:30.
+func zoneLabel(offset domain.TimezoneOffset) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, offset/60, offset%60)
+}
+
+// Link returns a wa.me link to the number carrying the message as is, or an
+// empty string when the number has no digits.
+func Link(number domain.WhatsAppNumber, message string) string {
+	digits := number.Digits()
+	if digits == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("https://wa.me/%s?text=%s", digits, url.QueryEscape(message))
+}
@@ -0,0 +1,64 @@
+package whatsapp_link
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+)
+
+func TestBuildWhatsAppLink(t *testing.T) {
+	startTime := domain.UTCTimestamp(time.Date(2025, 7, 7, 9, 0, 0, 0, time.UTC))
+
+	t.Run("normalizes number and encodes message", func(t *testing.T) {
+		link := Build(
+			"Hi {clientName}, see you {startTime}?",
+			"Sara & Co",
+			"+20 (100) 123-4567",
+			startTime,
+			180,
+		)
+
+		prefix := "https://wa.me/201001234567?text="
+		if !strings.HasPrefix(link, prefix) {
+			t.Fatalf("expected link to start with %s, got %s", prefix, link)
+		}
+
+		encoded := strings.TrimPrefix(link, prefix)
+		if strings.ContainsAny(encoded, " &?") {
+			t.Errorf("expected message to be URL-encoded, got %s", encoded)
+		}
+
+		message, err := url.QueryUnescape(encoded)
+		if err != nil {
+			t.Fatalf("failed to decode message: %v", err)
+		}
+		expected := "Hi Sara & Co, see you Mon 7 Jul 2025 12:00 UTC+03:00?"
+		if message != expected {
+			t.Errorf("expected message %q, got %q", expected, message)
+		}
+	})
+
+	t.Run("renders fractional and negative offsets", func(t *testing.T) {
+		cases := map[domain.TimezoneOffset]string{
+			330:  "Mon 7 Jul 2025 14:30 UTC+05:30",
+			-210: "Mon 7 Jul 2025 05:30 UTC-03:30",
+			-30:  "Mon 7 Jul 2025 08:30 UTC-00:30",
+			0:    "Mon 7 Jul 2025 09:00 UTC+00:00",
+		}
+		for offset, expected := range cases {
+			if message := Message("{startTime}", "Sara", startTime, offset); message != expected {
+				t.Errorf("offset %d: expected %q, got %q", offset, expected, message)
+			}
+		}
+	})
+
+	t.Run("empty number yields no link", func(t *testing.T) {
+		link := Build("{clientName}", "Sara", "", startTime, 0)
+		if link != "" {
+			t.Errorf("expected empty link, got %s", link)
+		}
+	})
+}
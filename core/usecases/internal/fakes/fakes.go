@@ -0,0 +1,506 @@
+// Package fakes holds the in-memory repositories and ports shared by the
+// usecase tests. Each fake embeds its port interface, so calling a method a
+// test doesn't set up panics instead of silently returning zero values.
+package fakes
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// -----------------------------
+// Therapists
+// -----------------------------
+
+type TherapistRepo struct {
+	ports.TherapistRepository
+	Therapists []*therapist.Therapist
+	// Preferences missing from the map default to enabled
+	Preferences map[domain.TherapistID]therapist.NotificationPreferences
+	// Therapists missing from the map have no daily cap
+	MaxDailySessions map[domain.TherapistID]int
+}
+
+func (r *TherapistRepo) GetByID(id domain.TherapistID) (*therapist.Therapist, error) {
+	for _, t := range r.Therapists {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, common.ErrTherapistNotFound
+}
+
+func (r *TherapistRepo) FindByIDs(ids []domain.TherapistID) ([]*therapist.Therapist, error) {
+	out := make([]*therapist.Therapist, 0)
+	for _, id := range ids {
+		if t, err := r.GetByID(id); err == nil {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (r *TherapistRepo) FindBySpecializationAndLanguage(clinicID domain.ClinicID, tag string, languageCode domain.LanguageCode) ([]*therapist.Therapist, error) {
+	matches := []*therapist.Therapist{}
+	for _, t := range r.Therapists {
+		if clinicID != "" && t.ClinicID.OrDefault() != clinicID {
+			continue
+		}
+		if languageCode != "" && !t.Speaks(languageCode) {
+			continue
+		}
+		for _, s := range t.Specializations {
+			if s.Name == tag {
+				matches = append(matches, t)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+func (r *TherapistRepo) GetNotificationPreferences(id domain.TherapistID) (therapist.NotificationPreferences, error) {
+	if _, err := r.GetByID(id); err != nil {
+		return therapist.NotificationPreferences{}, err
+	}
+	if preferences, ok := r.Preferences[id]; ok {
+		return preferences, nil
+	}
+	return therapist.DefaultNotificationPreferences(), nil
+}
+
+func (r *TherapistRepo) GetMaxDailySessions(id domain.TherapistID) (int, error) {
+	if _, err := r.GetByID(id); err != nil {
+		return 0, err
+	}
+	return r.MaxDailySessions[id], nil
+}
+
+// -----------------------------
+// Timeslots and recurring blocks
+// -----------------------------
+
+type TimeSlotRepo struct {
+	ports.TimeSlotRepository
+	Slots []*timeslot.TimeSlot
+}
+
+func (r *TimeSlotRepo) GetByID(id domain.TimeSlotID) (*timeslot.TimeSlot, error) {
+	for _, s := range r.Slots {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, common.ErrTimeSlotNotFound
+}
+
+func (r *TimeSlotRepo) Create(slot *timeslot.TimeSlot) error {
+	r.Slots = append(r.Slots, slot)
+	return nil
+}
+
+func (r *TimeSlotRepo) CreateTx(sqlExec ports.SQLExec, slot *timeslot.TimeSlot) error {
+	return r.Create(slot)
+}
+
+func (r *TimeSlotRepo) ListByTherapist(therapistID domain.TherapistID) ([]*timeslot.TimeSlot, error) {
+	byTherapist, err := r.BulkListByTherapist([]domain.TherapistID{therapistID})
+	if err != nil {
+		return nil, err
+	}
+	return byTherapist[therapistID], nil
+}
+
+func (r *TimeSlotRepo) BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.TimeSlot, error) {
+	out := make(map[domain.TherapistID][]*timeslot.TimeSlot)
+	for _, s := range r.Slots {
+		out[s.TherapistID] = append(out[s.TherapistID], s)
+	}
+	return out, nil
+}
+
+type RecurringBlockRepo struct {
+	ports.RecurringBlockRepository
+	Blocks []*timeslot.RecurringBlock
+}
+
+func (r *RecurringBlockRepo) BulkListByTherapist(therapistIDs []domain.TherapistID) (map[domain.TherapistID][]*timeslot.RecurringBlock, error) {
+	out := make(map[domain.TherapistID][]*timeslot.RecurringBlock)
+	for _, b := range r.Blocks {
+		out[b.TherapistID] = append(out[b.TherapistID], b)
+	}
+	return out, nil
+}
+
+// -----------------------------
+// Bookings
+// -----------------------------
+
+// BookingRepo filters listings by therapist and state but ignores the date
+// range, so tests only add the bookings they care about.
+type BookingRepo struct {
+	ports.BookingRepository
+	Bookings     []*booking.Booking
+	StateChanges []*booking.StateChange
+}
+
+func (r *BookingRepo) GetByID(id domain.BookingID) (*booking.Booking, error) {
+	for _, b := range r.Bookings {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return nil, ports.ErrBookingNotFound
+}
+
+func (r *BookingRepo) ListByTherapistForDateRange(
+	therapistID domain.TherapistID,
+	states []booking.BookingState,
+	startDate, endDate time.Time,
+) ([]*booking.Booking, error) {
+	byTherapist, err := r.BulkListByTherapistForDateRange([]domain.TherapistID{therapistID}, states, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return byTherapist[therapistID], nil
+}
+
+func (r *BookingRepo) BulkListByTherapistForDateRange(
+	therapistIDs []domain.TherapistID,
+	states []booking.BookingState,
+	startDate, endDate time.Time,
+) (map[domain.TherapistID][]*booking.Booking, error) {
+	out := make(map[domain.TherapistID][]*booking.Booking)
+	for _, b := range r.Bookings {
+		if containsState(states, b.State) {
+			out[b.TherapistID] = append(out[b.TherapistID], b)
+		}
+	}
+	return out, nil
+}
+
+func (r *BookingRepo) UpdateState(
+	id domain.BookingID,
+	state booking.BookingState,
+	updatedAt time.Time,
+	actor string,
+) error {
+	return r.UpdateStateTx(nil, id, state, updatedAt, actor)
+}
+
+func (r *BookingRepo) UpdateStateTx(
+	sqlExec ports.SQLExec,
+	id domain.BookingID,
+	state booking.BookingState,
+	updatedAt time.Time,
+	actor string,
+) error {
+	b, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+	b.State = state
+	return nil
+}
+
+func (r *BookingRepo) ListStateChanges(bookingID domain.BookingID) ([]*booking.StateChange, error) {
+	changes := make([]*booking.StateChange, 0)
+	for _, change := range r.StateChanges {
+		if change.BookingID == bookingID {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (r *BookingRepo) UpdateDurationTx(
+	sqlExec ports.SQLExec,
+	bookingID domain.BookingID,
+	duration domain.DurationMinutes,
+	updatedAt time.Time,
+) error {
+	b, err := r.GetByID(bookingID)
+	if err != nil {
+		return err
+	}
+	b.Duration = duration
+	return nil
+}
+
+func (r *BookingRepo) ReassignTx(
+	sqlExec ports.SQLExec,
+	bookingID domain.BookingID,
+	therapistID domain.TherapistID,
+	timeSlotID domain.TimeSlotID,
+	updatedAt time.Time,
+) error {
+	b, err := r.GetByID(bookingID)
+	if err != nil {
+		return err
+	}
+	b.TherapistID = therapistID
+	b.TimeSlotID = timeSlotID
+	return nil
+}
+
+type AdhocBookingRepo struct {
+	ports.AdhocBookingRepository
+	Bookings []*booking.AdhocBooking
+}
+
+func (r *AdhocBookingRepo) GetByID(id domain.AdhocBookingID) (*booking.AdhocBooking, error) {
+	for _, b := range r.Bookings {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return nil, ports.ErrBookingNotFound
+}
+
+func (r *AdhocBookingRepo) ListByTherapistForDateRange(
+	therapistID domain.TherapistID,
+	states []booking.BookingState,
+	startDate, endDate time.Time,
+) ([]*booking.AdhocBooking, error) {
+	byTherapist, err := r.BulkListByTherapistForDateRange([]domain.TherapistID{therapistID}, states, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return byTherapist[therapistID], nil
+}
+
+func (r *AdhocBookingRepo) BulkListByTherapistForDateRange(
+	therapistIDs []domain.TherapistID,
+	states []booking.BookingState,
+	startDate, endDate time.Time,
+) (map[domain.TherapistID][]*booking.AdhocBooking, error) {
+	out := make(map[domain.TherapistID][]*booking.AdhocBooking)
+	for _, b := range r.Bookings {
+		if containsState(states, b.State) {
+			out[b.TherapistID] = append(out[b.TherapistID], b)
+		}
+	}
+	return out, nil
+}
+
+func containsState(states []booking.BookingState, state booking.BookingState) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------
+// Clients
+// -----------------------------
+
+type ClientRepo struct {
+	ports.ClientRepository
+	Clients []*client.Client
+}
+
+func (r *ClientRepo) FindByIDs(ids []domain.ClientID) ([]*client.Client, error) {
+	out := make([]*client.Client, 0, len(ids))
+	for _, c := range r.Clients {
+		for _, id := range ids {
+			if c.ID == id {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+// -----------------------------
+// Sessions
+// -----------------------------
+
+type SessionRepo struct {
+	ports.SessionRepository
+	Sessions []*domain.Session
+	// NotesUpdates counts UpdateSessionNotes calls
+	NotesUpdates int
+}
+
+// GetSessionByID hands out a copy so unsaved changes don't leak into the store
+func (r *SessionRepo) GetSessionByID(id domain.SessionID) (*domain.Session, error) {
+	session := r.find(id)
+	if session == nil {
+		return nil, common.ErrSessionNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *SessionRepo) GetSessionByRegularBookingID(bookingID domain.BookingID) (*domain.Session, error) {
+	for _, session := range r.Sessions {
+		if session.RegularBookingID == bookingID {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *SessionRepo) CreateSession(tx ports.SQLTx, session *domain.Session) error {
+	r.Sessions = append(r.Sessions, session)
+	return nil
+}
+
+func (r *SessionRepo) UpdateSessionNotes(id domain.SessionID, notes string) error {
+	session := r.find(id)
+	if session == nil {
+		return common.ErrSessionNotFound
+	}
+	session.Notes = notes
+	r.NotesUpdates++
+	return nil
+}
+
+func (r *SessionRepo) UpdateMeetingURL(id domain.SessionID, meetingURL string) error {
+	session := r.find(id)
+	if session == nil {
+		return common.ErrSessionNotFound
+	}
+	session.MeetingURL = meetingURL
+	return nil
+}
+
+// ListSessionsAdmin ignores the filters and lists every session
+func (r *SessionRepo) ListSessionsAdmin(clinicID domain.ClinicID, startDate, endDate, updatedFrom, updatedTo time.Time) ([]*domain.Session, error) {
+	return r.Sessions, nil
+}
+
+// ListPlannedStartedBefore lists planned sessions in the order they were added
+func (r *SessionRepo) ListPlannedStartedBefore(before time.Time) ([]*domain.Session, error) {
+	out := make([]*domain.Session, 0)
+	for _, session := range r.Sessions {
+		if session.State == domain.SessionStatePlanned && session.StartTime.Time().Before(before) {
+			out = append(out, session)
+		}
+	}
+	return out, nil
+}
+
+func (r *SessionRepo) MarkNoShow(ids []domain.SessionID, updatedAt time.Time) (int, error) {
+	marked := 0
+	for _, id := range ids {
+		session := r.find(id)
+		if session != nil && session.State == domain.SessionStatePlanned {
+			session.State = domain.SessionStateNoShow
+			session.UpdatedAt = domain.UTCTimestamp(updatedAt)
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (r *SessionRepo) find(id domain.SessionID) *domain.Session {
+	for _, session := range r.Sessions {
+		if session.ID == id {
+			return session
+		}
+	}
+	return nil
+}
+
+// -----------------------------
+// Clinics
+// -----------------------------
+
+// ClinicRepo maps record ids and hold tokens to the clinic owning them.
+// Records missing from the map don't exist.
+type ClinicRepo struct {
+	ports.ClinicRepository
+	Clinics map[string]domain.ClinicID
+}
+
+func (r *ClinicRepo) GetTherapistClinic(id domain.TherapistID) (domain.ClinicID, error) {
+	return r.Clinics[string(id)], nil
+}
+
+func (r *ClinicRepo) GetClientClinic(id domain.ClientID) (domain.ClinicID, error) {
+	return r.Clinics[string(id)], nil
+}
+
+func (r *ClinicRepo) GetSpecializationClinic(id domain.SpecializationID) (domain.ClinicID, error) {
+	return r.Clinics[string(id)], nil
+}
+
+func (r *ClinicRepo) GetBookingClinic(id domain.BookingID) (domain.ClinicID, error) {
+	return r.Clinics[string(id)], nil
+}
+
+func (r *ClinicRepo) GetAdhocBookingClinic(id domain.AdhocBookingID) (domain.ClinicID, error) {
+	return r.Clinics[string(id)], nil
+}
+
+func (r *ClinicRepo) GetHoldClinic(token domain.HoldToken) (domain.ClinicID, error) {
+	return r.Clinics[string(token)], nil
+}
+
+func (r *ClinicRepo) GetSessionClinic(id domain.SessionID) (domain.ClinicID, error) {
+	return r.Clinics[string(id)], nil
+}
+
+// -----------------------------
+// Transactions and notifications
+// -----------------------------
+
+type Tx struct {
+	ports.SQLTx
+}
+
+func (tx *Tx) Commit() error   { return nil }
+func (tx *Tx) Rollback() error { return nil }
+
+type TransactionPort struct{}
+
+func (p *TransactionPort) Begin() (ports.SQLTx, error)   { return &Tx{}, nil }
+func (p *TransactionPort) Commit(tx ports.SQLTx) error   { return tx.Commit() }
+func (p *TransactionPort) Rollback(tx ports.SQLTx) error { return tx.Rollback() }
+
+// UnitOfWork runs fn against a fake transaction. The fake repositories apply
+// changes right away, so nothing is undone when fn fails.
+type UnitOfWork struct{}
+
+func (u *UnitOfWork) RunInTx(fn func(tx ports.SQLTx) error) error { return fn(&Tx{}) }
+
+// NotificationPort records the devices it was asked to notify
+type NotificationPort struct {
+	SentTo []domain.DeviceID
+}
+
+func (p *NotificationPort) SendNotification(deviceID domain.DeviceID, notification ports.Notification) (*ports.NotificationID, error) {
+	p.SentTo = append(p.SentTo, deviceID)
+	id := ports.NotificationID("notification_1")
+	return &id, nil
+}
+
+type NotificationRepo struct{}
+
+func (r *NotificationRepo) CreateNotification(therapistID domain.TherapistID, firebaseNotificationID ports.NotificationID, notification ports.Notification) error {
+	return nil
+}
+
+// -----------------------------
+// Dates
+// -----------------------------
+
+// NextMonday returns midnight UTC of a Monday at least a week ahead, so slots
+// built on it are never in the past.
+func NextMonday() time.Time {
+	day := time.Now().UTC().AddDate(0, 0, 7)
+	for day.Weekday() != time.Monday {
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -1,12 +1,15 @@
 package notify_therapist_new_booking
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
 	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
 )
 
 type Usecase struct {
@@ -30,16 +33,32 @@ func NewUsecase(
 	}
 }
 
+// Execute notifies the therapist of a confirmed session. Failures are logged
+// and never block the confirmation.
 func (u *Usecase) Execute(session *domain.Session) {
+	err := u.Send(session)
+	switch {
+	case err == nil:
+	case errors.Is(err, booking.ErrTherapistHasNoDevice):
+		slog.Info("therapist has no device id, skipping notification", "therapist_id", session.TherapistID)
+	default:
+		slog.Warn("failed to notify therapist",
+			"therapist_id", session.TherapistID,
+			"sessionID", session.ID,
+			"error", err)
+	}
+}
+
+// Send notifies the therapist of a confirmed session and records the
+// notification, returning any failure to the caller.
+func (u *Usecase) Send(session *domain.Session) error {
 	therapist, err := u.therapistRepo.GetByID(session.TherapistID)
-	if err != nil {
-		slog.Warn("failed to get therapist for notification", "therapist_id", therapist.ID, "error", err)
-		return
+	if err != nil || therapist == nil {
+		return common.ErrTherapistNotFound
 	}
 
 	if therapist.DeviceID == "" {
-		slog.Info("therapist has no device id, skipping notification", "therapist_id", therapist.ID)
-		return
+		return booking.ErrTherapistHasNoDevice
 	}
 	therapistTimezoneOffset := int(therapist.TimezoneOffset / 60)
 	timezoneLabel := fmt.Sprintf("UTC%+d", therapistTimezoneOffset)
@@ -54,8 +73,8 @@ func (u *Usecase) Execute(session *domain.Session) {
 	}
 
 	firebaseNotificationId, err := u.notificationPort.SendNotification(therapist.DeviceID, notification)
-	if err != nil {
-		slog.Warn("failed to notify therapist",
+	if err != nil || firebaseNotificationId == nil {
+		slog.Warn("failed to send notification",
 			slog.Group(
 				"therapist",
 				"id", therapist.ID,
@@ -65,7 +84,7 @@ func (u *Usecase) Execute(session *domain.Session) {
 			),
 			"sessionID", session.ID,
 			"error", err)
-		return
+		return ports.ErrNotificationFailed
 	}
 
 	// Persist the notification
@@ -81,6 +100,7 @@ func (u *Usecase) Execute(session *domain.Session) {
 			),
 			"sessionID", session.ID,
 			"error", err)
-		return
+		return err
 	}
+	return nil
 }
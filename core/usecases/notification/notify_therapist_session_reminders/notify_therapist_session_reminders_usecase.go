@@ -0,0 +1,138 @@
+package notify_therapist_session_reminders
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// defaultWindow is used when no end of the window is given
+const defaultWindow = 24 * time.Hour
+
+// Input defines the window of session start times to send reminders for.
+// The caller is expected to trigger it once per window.
+type Input struct {
+	From     time.Time       `json:"from"`
+	To       time.Time       `json:"to"`
+	ClinicID domain.ClinicID `json:"-"` // Taken from the request
+}
+
+type Output struct {
+	Sent int `json:"sent"`
+}
+
+type Usecase struct {
+	sessionRepo         ports.SessionRepository
+	therapistRepo       ports.TherapistRepository
+	notificationPort    ports.NotificationPort
+	notificationRepo    ports.NotificationRepository
+	therapistAppBaseURL string
+}
+
+func NewUsecase(
+	sessionRepo ports.SessionRepository,
+	therapistRepo ports.TherapistRepository,
+	notificationPort ports.NotificationPort,
+	notificationRepo ports.NotificationRepository,
+	therapistAppBaseURL string,
+) *Usecase {
+	return &Usecase{
+		sessionRepo:         sessionRepo,
+		therapistRepo:       therapistRepo,
+		notificationPort:    notificationPort,
+		notificationRepo:    notificationRepo,
+		therapistAppBaseURL: therapistAppBaseURL,
+	}
+}
+
+// Execute reminds therapists of their planned sessions starting in the window.
+// Therapists who turned reminders off or have no device are skipped, and a
+// failure to notify one therapist doesn't stop the others.
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if input.From.IsZero() {
+		input.From = time.Now().UTC()
+	}
+	if input.To.IsZero() {
+		input.To = input.From.Add(defaultWindow)
+	}
+
+	if input.From.After(input.To) {
+		return nil, common.ErrInvalidDateRange
+	}
+
+	sessions, err := u.sessionRepo.ListSessionsAdmin(input.ClinicID.OrDefault(), input.From, input.To, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, common.ErrFailedToListSessions
+	}
+
+	output := &Output{}
+	remindersEnabled := make(map[domain.TherapistID]bool)
+	for _, session := range sessions {
+		if session.State != domain.SessionStatePlanned {
+			continue
+		}
+
+		enabled, checked := remindersEnabled[session.TherapistID]
+		if !checked {
+			enabled = u.remindersEnabled(session.TherapistID)
+			remindersEnabled[session.TherapistID] = enabled
+		}
+		if !enabled {
+			continue
+		}
+
+		if u.remind(session) {
+			output.Sent++
+		}
+	}
+
+	return output, nil
+}
+
+// remindersEnabled falls back to reminding when the preferences can't be read,
+// as before preferences existed.
+func (u *Usecase) remindersEnabled(therapistID domain.TherapistID) bool {
+	preferences, err := u.therapistRepo.GetNotificationPreferences(therapistID)
+	if err != nil {
+		slog.Warn("failed to get therapist notification preferences", "therapist_id", therapistID, "error", err)
+		return true
+	}
+	return preferences.Reminders
+}
+
+func (u *Usecase) remind(session *domain.Session) bool {
+	therapist, err := u.therapistRepo.GetByID(session.TherapistID)
+	if err != nil {
+		slog.Warn("failed to get therapist for reminder", "therapist_id", session.TherapistID, "error", err)
+		return false
+	}
+
+	if therapist.DeviceID == "" {
+		slog.Info("therapist has no device id, skipping reminder", "therapist_id", therapist.ID)
+		return false
+	}
+
+	therapistTimezone := time.FixedZone("", int(therapist.TimezoneOffset)*60)
+	startTime := time.Time(session.StartTime).In(therapistTimezone)
+	notification := ports.Notification{
+		Title:    "Upcoming Session",
+		Body:     fmt.Sprintf("Reminder: you have a session at %s", startTime.Format("2006-01-02 15:04")),
+		ImageURL: "https://therapist.mishkahtherapy.com/mishkah-logo.png",
+		Link:     fmt.Sprintf("%s/sessions", u.therapistAppBaseURL),
+	}
+
+	notificationID, err := u.notificationPort.SendNotification(therapist.DeviceID, notification)
+	if err != nil {
+		slog.Warn("failed to send session reminder", "therapist_id", therapist.ID, "session_id", session.ID, "error", err)
+		return false
+	}
+
+	if err := u.notificationRepo.CreateNotification(therapist.ID, *notificationID, notification); err != nil {
+		slog.Warn("failed to persist session reminder", "therapist_id", therapist.ID, "session_id", session.ID, "error", err)
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package notify_therapist_session_reminders
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestNotifyTherapistSessionReminders(t *testing.T) {
+	start := domain.UTCTimestamp(time.Now().UTC().Add(2 * time.Hour))
+	therapistRepo := &fakes.TherapistRepo{
+		Therapists: []*therapist.Therapist{
+			{ID: "therapist_on", DeviceID: "device_on"},
+			{ID: "therapist_off", DeviceID: "device_off"},
+		},
+		Preferences: map[domain.TherapistID]therapist.NotificationPreferences{
+			"therapist_off": {Confirmations: true, Reminders: false},
+		},
+	}
+	sessionRepo := &fakes.SessionRepo{Sessions: []*domain.Session{
+		{ID: "session_1", TherapistID: "therapist_on", StartTime: start, State: domain.SessionStatePlanned},
+		{ID: "session_2", TherapistID: "therapist_off", StartTime: start, State: domain.SessionStatePlanned},
+		{ID: "session_3", TherapistID: "therapist_on", StartTime: start, State: domain.SessionStateCancelled},
+	}}
+	notificationPort := &fakes.NotificationPort{}
+
+	usecase := NewUsecase(sessionRepo, therapistRepo, notificationPort, &fakes.NotificationRepo{}, "https://therapist.example.com")
+	output, err := usecase.Execute(Input{})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	if output.Sent != 1 {
+		t.Errorf("expected 1 reminder sent, got %d", output.Sent)
+	}
+	if len(notificationPort.SentTo) != 1 || notificationPort.SentTo[0] != "device_on" {
+		t.Errorf("expected only device_on to be reminded, got %v", notificationPort.SentTo)
+	}
+}
+
+func TestNotifyTherapistSessionRemindersInvalidRange(t *testing.T) {
+	usecase := NewUsecase(&fakes.SessionRepo{}, &fakes.TherapistRepo{}, &fakes.NotificationPort{}, &fakes.NotificationRepo{}, "")
+
+	now := time.Now().UTC()
+	_, err := usecase.Execute(Input{From: now, To: now.Add(-time.Hour)})
+	if err != common.ErrInvalidDateRange {
+		t.Errorf("expected ErrInvalidDateRange, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package check_availability
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/common/overlap_detector"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+// Input describes a proposed booking. Nothing is created; the usecase only
+// reports whether the time can be booked. create_booking, hold_booking,
+// reassign_booking and reactivate_booking all accept a booking through it.
+type Input struct {
+	TherapistID domain.TherapistID
+	TimeSlotID  domain.TimeSlotID
+	StartTime   domain.UTCTimestamp
+	Duration    domain.DurationMinutes
+}
+
+type Output struct {
+	Available bool                     `json:"available"`
+	Conflicts []*ports.BookingResponse `json:"conflicts"`
+}
+
+type Usecase struct {
+	bookingRepo        ports.BookingRepository
+	adhocBookingRepo   ports.AdhocBookingRepository
+	timeSlotRepo       ports.TimeSlotRepository
+	getScheduleUsecase get_schedule.Usecase
+}
+
+func NewUsecase(
+	bookingRepo ports.BookingRepository,
+	adhocBookingRepo ports.AdhocBookingRepository,
+	timeSlotRepo ports.TimeSlotRepository,
+	getScheduleUsecase get_schedule.Usecase,
+) *Usecase {
+	return &Usecase{
+		bookingRepo:        bookingRepo,
+		adhocBookingRepo:   adhocBookingRepo,
+		timeSlotRepo:       timeSlotRepo,
+		getScheduleUsecase: getScheduleUsecase,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if err := validateInput(input); err != nil {
+		return nil, err
+	}
+
+	timeSlot, err := u.timeSlotRepo.GetByID(input.TimeSlotID)
+	if err != nil || timeSlot == nil {
+		return nil, common.ErrTimeSlotNotFound
+	}
+	if timeSlot.TherapistID != input.TherapistID {
+		return nil, common.ErrTimeSlotNotFound
+	}
+
+	startTime := time.Time(input.StartTime)
+	endTime := startTime.Add(time.Duration(input.Duration) * time.Minute)
+
+	conflicts, err := u.findConflicts(timeSlot, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group slots stay in the schedule until every seat is confirmed
+	availabilities, err := u.getScheduleUsecase.Execute(get_schedule.Input{
+		TherapistIDs: []domain.TherapistID{input.TherapistID},
+		StartDate:    startTime,
+		EndDate:      endTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matchesAvailability := false
+	for _, availability := range availabilities {
+		availabilityStartTime := time.Time(availability.From)
+		availabilityEndTime := availabilityStartTime.Add(time.Duration(availability.Duration) * time.Minute)
+
+		if overlap_detector.New(availabilityStartTime, availabilityEndTime).HasOverlap(startTime, endTime) {
+			matchesAvailability = true
+			break
+		}
+	}
+
+	return &Output{
+		Available: matchesAvailability && len(conflicts) == 0,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// findConflicts returns confirmed and held bookings of the therapist that
+// overlap the proposed range. Pending bookings do not block a new booking,
+// and bookings sharing the slot only do once they fill its capacity.
+func (u *Usecase) findConflicts(
+	slot *timeslot.TimeSlot,
+	startTime time.Time,
+	endTime time.Time,
+) ([]*ports.BookingResponse, error) {
+	therapistID := slot.TherapistID
+	confirmedStates := []booking.BookingState{booking.BookingStateConfirmed}
+	blockingStates := []booking.BookingState{booking.BookingStateConfirmed, booking.BookingStateHeld}
+	detector := overlap_detector.New(startTime, endTime)
+
+	bookings, err := u.bookingRepo.ListByTherapistForDateRange(therapistID, blockingStates, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	adhocBookings, err := u.adhocBookingRepo.ListByTherapistForDateRange(therapistID, confirmedStates, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]*ports.BookingResponse, 0)
+	sameSlot := make([]*ports.BookingResponse, 0)
+	for _, b := range bookings {
+		bookingStart := time.Time(b.StartTime)
+		bookingEnd := bookingStart.Add(time.Duration(b.Duration) * time.Minute)
+		if !detector.HasOverlap(bookingStart, bookingEnd) {
+			continue
+		}
+		response := &ports.BookingResponse{
+			RegularBookingID:     b.ID,
+			TherapistID:          b.TherapistID,
+			ClientID:             b.ClientID,
+			State:                b.State,
+			StartTime:            b.StartTime,
+			Duration:             b.Duration,
+			ClientTimezoneOffset: b.ClientTimezoneOffset,
+		}
+		if b.TimeSlotID == slot.ID {
+			sameSlot = append(sameSlot, response)
+			continue
+		}
+		conflicts = append(conflicts, response)
+	}
+	if len(sameSlot) >= slot.BookingCapacity() {
+		conflicts = append(conflicts, sameSlot...)
+	}
+
+	for _, b := range adhocBookings {
+		bookingStart := time.Time(b.StartTime)
+		bookingEnd := bookingStart.Add(time.Duration(b.Duration) * time.Minute)
+		if !detector.HasOverlap(bookingStart, bookingEnd) {
+			continue
+		}
+		conflicts = append(conflicts, &ports.BookingResponse{
+			AdhocBookingID:       b.ID,
+			TherapistID:          b.TherapistID,
+			ClientID:             b.ClientID,
+			State:                b.State,
+			StartTime:            b.StartTime,
+			Duration:             b.Duration,
+			ClientTimezoneOffset: b.ClientTimezoneOffset,
+		})
+	}
+
+	return conflicts, nil
+}
+
+func validateInput(input Input) error {
+	if input.TherapistID == "" {
+		return common.ErrTherapistIDIsRequired
+	}
+	if input.TimeSlotID == "" {
+		return common.ErrTimeSlotIDIsRequired
+	}
+	if time.Time(input.StartTime).IsZero() {
+		return common.ErrStartTimeIsRequired
+	}
+	if input.Duration <= 0 {
+		return common.ErrDurationIsRequired
+	}
+	return nil
+}
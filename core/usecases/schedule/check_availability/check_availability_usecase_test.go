@@ -0,0 +1,99 @@
+package check_availability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+func TestCheckAvailability(t *testing.T) {
+	therapistID := domain.TherapistID("therapist_1")
+	slot := &timeslot.TimeSlot{
+		ID:          "slot_monday",
+		TherapistID: therapistID,
+		IsActive:    true,
+		DayOfWeek:   timeslot.DayOfWeekMonday,
+		Start:       "09:00",
+		Duration:    180,
+	}
+	monday := fakes.NextMonday()
+	at := func(hour, minute int) domain.UTCTimestamp {
+		return domain.UTCTimestamp(monday.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute))
+	}
+
+	existing := &booking.Booking{
+		ID:          "booking_1",
+		TherapistID: therapistID,
+		TimeSlotID:  slot.ID,
+		StartTime:   at(9, 0),
+		Duration:    60,
+		State:       booking.BookingStateConfirmed,
+	}
+
+	newUsecase := func(bookings []*booking.Booking) *Usecase {
+		therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{{ID: therapistID}}}
+		timeSlotRepo := &fakes.TimeSlotRepo{Slots: []*timeslot.TimeSlot{slot}}
+		bookingRepo := &fakes.BookingRepo{Bookings: bookings}
+		adhocBookingRepo := &fakes.AdhocBookingRepo{}
+		getSchedule := get_schedule.NewUsecase(therapistRepo, timeSlotRepo, bookingRepo, adhocBookingRepo, nil, 15, nil, 0)
+		return NewUsecase(bookingRepo, adhocBookingRepo, timeSlotRepo, *getSchedule)
+	}
+
+	t.Run("free slot is available", func(t *testing.T) {
+		output, err := newUsecase(nil).Execute(Input{
+			TherapistID: therapistID,
+			TimeSlotID:  slot.ID,
+			StartTime:   at(10, 0),
+			Duration:    60,
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if !output.Available {
+			t.Errorf("expected slot to be available")
+		}
+		if len(output.Conflicts) != 0 {
+			t.Errorf("expected no conflicts, got %d", len(output.Conflicts))
+		}
+	})
+
+	t.Run("occupied slot returns the conflicting booking", func(t *testing.T) {
+		output, err := newUsecase([]*booking.Booking{existing}).Execute(Input{
+			TherapistID: therapistID,
+			TimeSlotID:  slot.ID,
+			StartTime:   at(9, 30),
+			Duration:    60,
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if output.Available {
+			t.Errorf("expected slot to be unavailable")
+		}
+		if len(output.Conflicts) != 1 {
+			t.Fatalf("expected 1 conflict, got %d", len(output.Conflicts))
+		}
+		if output.Conflicts[0].RegularBookingID != existing.ID {
+			t.Errorf("expected conflict %s, got %s", existing.ID, output.Conflicts[0].RegularBookingID)
+		}
+	})
+
+	t.Run("timeslot of another therapist", func(t *testing.T) {
+		_, err := newUsecase(nil).Execute(Input{
+			TherapistID: "therapist_2",
+			TimeSlotID:  slot.ID,
+			StartTime:   at(10, 0),
+			Duration:    60,
+		})
+		if err != common.ErrTimeSlotNotFound {
+			t.Fatalf("expected %v, got %v", common.ErrTimeSlotNotFound, err)
+		}
+	})
+}
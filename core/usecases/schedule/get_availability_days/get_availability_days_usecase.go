@@ -0,0 +1,81 @@
+package get_availability_days
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+type Input struct {
+	ClinicID          domain.ClinicID
+	SpecializationTag string
+	MustSpeakEnglish  bool
+	From              time.Time
+	To                time.Time
+}
+
+// DayAvailability summarizes the available ranges starting on one UTC day
+type DayAvailability struct {
+	Date            string `json:"date"` // YYYY-MM-DD
+	HasAvailability bool   `json:"hasAvailability"`
+	AvailableRanges int    `json:"availableRanges"`
+}
+
+type Usecase struct {
+	getScheduleUsecase get_schedule.Usecase
+}
+
+func NewUsecase(getScheduleUsecase get_schedule.Usecase) *Usecase {
+	return &Usecase{
+		getScheduleUsecase: getScheduleUsecase,
+	}
+}
+
+// Execute returns one entry per day between From and To, inclusive. Both
+// default to the schedule's own window when zero.
+func (u *Usecase) Execute(input Input) ([]DayAvailability, error) {
+	if input.SpecializationTag == "" {
+		return nil, get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired
+	}
+
+	if !input.From.IsZero() && !input.To.IsZero() && input.To.Before(input.From) {
+		return nil, get_schedule.ErrInvalidDateRange
+	}
+
+	// Mirror get_schedule's defaults so every returned range has a day
+	if input.From.IsZero() {
+		input.From = time.Now().UTC()
+	}
+	if input.To.IsZero() {
+		input.To = input.From.AddDate(0, 0, 14)
+	}
+
+	ranges, err := u.getScheduleUsecase.Execute(get_schedule.Input{
+		ClinicID:           input.ClinicID,
+		SpecializationTags: []string{input.SpecializationTag},
+		MustSpeakEnglish:   input.MustSpeakEnglish,
+		StartDate:          input.From,
+		EndDate:            input.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, availableRange := range ranges {
+		counts[availableRange.From.Time().UTC().Format(time.DateOnly)]++
+	}
+
+	days := []DayAvailability{}
+	for day := input.From; !day.After(input.To); day = day.AddDate(0, 0, 1) {
+		date := day.UTC().Format(time.DateOnly)
+		days = append(days, DayAvailability{
+			Date:            date,
+			HasAvailability: counts[date] > 0,
+			AvailableRanges: counts[date],
+		})
+	}
+
+	return days, nil
+}
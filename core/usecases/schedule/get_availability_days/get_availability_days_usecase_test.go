@@ -0,0 +1,95 @@
+package get_availability_days
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+func TestGetAvailabilityDays(t *testing.T) {
+	anxiety := specialization.Specialization{ID: "specialization_1", Name: "anxiety"}
+	therapistEntry := &therapist.Therapist{
+		ID:              "therapist_1",
+		SpeaksEnglish:   true,
+		Languages:       []domain.LanguageCode{domain.LanguageCodeEnglish},
+		Specializations: []specialization.Specialization{anxiety},
+	}
+
+	// A week starting far enough ahead that advance notice never applies
+	from := time.Now().UTC().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	to := from.AddDate(0, 0, 6)
+
+	openDay := from.AddDate(0, 0, 2)
+	slots := []*timeslot.TimeSlot{
+		{
+			ID:          "slot_morning",
+			TherapistID: therapistEntry.ID,
+			IsActive:    true,
+			DayOfWeek:   timeslot.MapToDayOfWeek(openDay.Weekday()),
+			Start:       "09:00",
+			Duration:    60,
+		},
+		{
+			ID:          "slot_evening",
+			TherapistID: therapistEntry.ID,
+			IsActive:    true,
+			DayOfWeek:   timeslot.MapToDayOfWeek(openDay.Weekday()),
+			Start:       "18:00",
+			Duration:    60,
+		},
+	}
+
+	getSchedule := get_schedule.NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistEntry}},
+		&fakes.TimeSlotRepo{Slots: slots},
+		&fakes.BookingRepo{},
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+	usecase := NewUsecase(*getSchedule)
+
+	t.Run("only days with slots have availability", func(t *testing.T) {
+		days, err := usecase.Execute(Input{SpecializationTag: "anxiety", From: from, To: to})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if len(days) != 7 {
+			t.Fatalf("expected 7 days, got %d", len(days))
+		}
+
+		for _, day := range days {
+			if day.Date == openDay.Format(time.DateOnly) {
+				if !day.HasAvailability || day.AvailableRanges != 2 {
+					t.Errorf("expected %s to have 2 ranges, got %+v", day.Date, day)
+				}
+				continue
+			}
+			if day.HasAvailability || day.AvailableRanges != 0 {
+				t.Errorf("expected %s to have no availability, got %+v", day.Date, day)
+			}
+		}
+	})
+
+	t.Run("inverted range", func(t *testing.T) {
+		_, err := usecase.Execute(Input{SpecializationTag: "anxiety", From: to, To: from})
+		if err != get_schedule.ErrInvalidDateRange {
+			t.Fatalf("expected %v, got %v", get_schedule.ErrInvalidDateRange, err)
+		}
+	})
+
+	t.Run("tag is required", func(t *testing.T) {
+		_, err := usecase.Execute(Input{From: from, To: to})
+		if err != get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired {
+			t.Fatalf("expected %v, got %v", get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired, err)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package get_next_availability
+
+import (
+	"sort"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/schedule"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+type Input struct {
+	ClinicID          domain.ClinicID
+	SpecializationTag string
+	MustSpeakEnglish  bool
+}
+
+type Usecase struct {
+	getScheduleUsecase get_schedule.Usecase
+}
+
+func NewUsecase(getScheduleUsecase get_schedule.Usecase) *Usecase {
+	return &Usecase{
+		getScheduleUsecase: getScheduleUsecase,
+	}
+}
+
+// Execute returns the earliest available range within the default schedule
+// lookahead window, or nil when no therapist is available.
+func (u *Usecase) Execute(input Input) (*schedule.AvailableTimeRange, error) {
+	if input.SpecializationTag == "" {
+		return nil, get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired
+	}
+
+	ranges, err := u.getScheduleUsecase.Execute(get_schedule.Input{
+		ClinicID:           input.ClinicID,
+		SpecializationTags: []string{input.SpecializationTag},
+		MustSpeakEnglish:   input.MustSpeakEnglish,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].From.Time().Before(ranges[j].From.Time())
+	})
+
+	return &ranges[0], nil
+}
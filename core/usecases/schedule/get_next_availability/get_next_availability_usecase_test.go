@@ -0,0 +1,93 @@
+package get_next_availability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+// dayAfterTomorrow returns the weekday two days from now, far enough ahead
+// that the slot's advance notice never excludes it.
+func dayAfterTomorrow() time.Time {
+	return time.Now().UTC().AddDate(0, 0, 2)
+}
+
+func TestGetNextAvailability(t *testing.T) {
+	anxiety := specialization.Specialization{ID: "specialization_1", Name: "anxiety"}
+	first := &therapist.Therapist{
+		ID:              "therapist_1",
+		SpeaksEnglish:   true,
+		Languages:       []domain.LanguageCode{domain.LanguageCodeEnglish},
+		Specializations: []specialization.Specialization{anxiety},
+	}
+	second := &therapist.Therapist{
+		ID:              "therapist_2",
+		SpeaksEnglish:   true,
+		Languages:       []domain.LanguageCode{domain.LanguageCodeEnglish},
+		Specializations: []specialization.Specialization{anxiety},
+	}
+
+	weekday := timeslot.MapToDayOfWeek(dayAfterTomorrow().Weekday())
+	slots := []*timeslot.TimeSlot{
+		{
+			ID:          "slot_late",
+			TherapistID: first.ID,
+			IsActive:    true,
+			DayOfWeek:   weekday,
+			Start:       "15:00",
+			Duration:    120,
+		},
+		{
+			ID:          "slot_early",
+			TherapistID: second.ID,
+			IsActive:    true,
+			DayOfWeek:   weekday,
+			Start:       "09:00",
+			Duration:    60,
+		},
+	}
+
+	therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{first, second}}
+	timeSlotRepo := &fakes.TimeSlotRepo{Slots: slots}
+	getSchedule := get_schedule.NewUsecase(therapistRepo, timeSlotRepo, &fakes.BookingRepo{}, &fakes.AdhocBookingRepo{}, nil, 15, nil, 0)
+	usecase := NewUsecase(*getSchedule)
+
+	t.Run("returns the earliest range", func(t *testing.T) {
+		next, err := usecase.Execute(Input{SpecializationTag: "anxiety", MustSpeakEnglish: true})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if next == nil {
+			t.Fatal("expected an available range, got nil")
+		}
+		if next.From.Time().Hour() != 9 {
+			t.Errorf("expected earliest range to start at 09:00, got %s", next.From.Time().Format(time.RFC3339))
+		}
+		if len(next.Therapists) != 1 || next.Therapists[0].TherapistID != second.ID {
+			t.Errorf("expected range to belong to %s, got %+v", second.ID, next.Therapists)
+		}
+	})
+
+	t.Run("unknown tag yields no range", func(t *testing.T) {
+		next, err := usecase.Execute(Input{SpecializationTag: "nonexistent"})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if next != nil {
+			t.Errorf("expected nil, got %+v", next)
+		}
+	})
+
+	t.Run("tag is required", func(t *testing.T) {
+		_, err := usecase.Execute(Input{})
+		if err != get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired {
+			t.Fatalf("expected %v, got %v", get_schedule.ErrSpecializationTagOrTherapistIDsIsRequired, err)
+		}
+	})
+}
@@ -5,7 +5,12 @@ import (
 	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
 	"github.com/mishkahtherapy/brain/core/domain/schedule"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
 )
 
 func TestSplitTimeSlotWithBookings(t *testing.T) {
@@ -173,7 +178,7 @@ func TestSplitTimeSlotWithBookings(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actual := findInterBookingAvailabilities(test.slot, test.afterSessionBreakTime, test.bookings, test.timeRangeMinimumDurationMinutes)
+			actual := findInterBookingAvailabilities(test.slot, test.afterSessionBreakTime, test.bookings, nil, test.timeRangeMinimumDurationMinutes)
 			if len(actual) != len(test.expected) {
 				t.Errorf("expected %d available ranges, got %d", len(test.expected), len(actual))
 			}
@@ -189,3 +194,723 @@ func TestSplitTimeSlotWithBookings(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitIntoCandidateStarts(t *testing.T) {
+	fromTime, err := time.Parse(time.RFC3339, "2025-01-01T09:00:00Z")
+	if err != nil {
+		t.Fatalf("failed to parse time: %v", err)
+	}
+	from := domain.UTCTimestamp(fromTime)
+	availabilityRange := schedule.TimeRange{
+		From: from,
+		To:   from.Add(2 * time.Hour),
+	}
+
+	starts := splitIntoCandidateStarts(availabilityRange, 50, 10)
+
+	expected := []domain.UTCTimestamp{from, from.Add(60 * time.Minute)}
+	if len(starts) != len(expected) {
+		t.Fatalf("expected %d candidate starts, got %d: %v", len(expected), len(starts), starts)
+	}
+	for i := range expected {
+		if !starts[i].Equal(expected[i]) {
+			t.Errorf("expected candidate %d to be %s, got %s", i, expected[i], starts[i])
+		}
+	}
+}
+
+func TestRecurringBlockSplitsSlot(t *testing.T) {
+	// Far enough ahead that the slot is never in the past
+	day := time.Now().UTC().AddDate(0, 0, 2)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	weekday := timeslot.MapToDayOfWeek(day.Weekday())
+
+	therapistEntry := &therapist.Therapist{ID: "therapist_1", Name: "Dr. Blocked"}
+	slot := &timeslot.TimeSlot{
+		ID:          "slot_1",
+		TherapistID: therapistEntry.ID,
+		IsActive:    true,
+		DayOfWeek:   weekday,
+		Start:       "09:00",
+		Duration:    8 * 60, // 09:00 - 17:00
+	}
+	lunch := &timeslot.RecurringBlock{
+		ID:          "recurring_block_1",
+		TherapistID: therapistEntry.ID,
+		DayOfWeek:   weekday,
+		Start:       "12:00",
+		Duration:    60,
+	}
+
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistEntry}},
+		&fakes.TimeSlotRepo{Slots: []*timeslot.TimeSlot{slot}},
+		&fakes.BookingRepo{},
+		nil,
+		&fakes.RecurringBlockRepo{Blocks: []*timeslot.RecurringBlock{lunch}},
+		15,
+		nil,
+		0,
+	)
+
+	ranges, err := usecase.Execute(Input{
+		TherapistIDs: []domain.TherapistID{therapistEntry.ID},
+		StartDate:    day,
+		EndDate:      day,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	at := func(hour int) domain.UTCTimestamp {
+		return domain.UTCTimestamp(day.Add(time.Duration(hour) * time.Hour))
+	}
+	expected := []schedule.AvailableTimeRange{
+		{From: at(9), To: at(12)},
+		{From: at(13), To: at(17)},
+	}
+	if len(ranges) != len(expected) {
+		t.Fatalf("expected %d ranges, got %d: %+v", len(expected), len(ranges), ranges)
+	}
+	for i, want := range expected {
+		if !ranges[i].From.Equal(want.From) || !ranges[i].To.Equal(want.To) {
+			t.Errorf("expected range %d to be %s - %s, got %s - %s",
+				i, want.From.Time().Format(time.Kitchen), want.To.Time().Format(time.Kitchen),
+				ranges[i].From.Time().Format(time.Kitchen), ranges[i].To.Time().Format(time.Kitchen))
+		}
+	}
+}
+
+func TestGroupSlotStaysAvailableUntilFull(t *testing.T) {
+	day := time.Now().UTC().AddDate(0, 0, 2)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	therapistEntry := &therapist.Therapist{ID: "therapist_1", Name: "Dr. Group"}
+	slot := &timeslot.TimeSlot{
+		ID:          "slot_1",
+		TherapistID: therapistEntry.ID,
+		IsActive:    true,
+		DayOfWeek:   timeslot.MapToDayOfWeek(day.Weekday()),
+		Start:       "09:00",
+		Duration:    60,
+		Capacity:    2,
+	}
+	sessionAt := func(id domain.BookingID) *booking.Booking {
+		return &booking.Booking{
+			ID:          id,
+			TimeSlotID:  slot.ID,
+			TherapistID: therapistEntry.ID,
+			State:       booking.BookingStateConfirmed,
+			StartTime:   domain.UTCTimestamp(day.Add(9 * time.Hour)),
+			Duration:    60,
+		}
+	}
+
+	bookings := &fakes.BookingRepo{Bookings: []*booking.Booking{sessionAt("booking_1")}}
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistEntry}},
+		&fakes.TimeSlotRepo{Slots: []*timeslot.TimeSlot{slot}},
+		bookings,
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+	input := Input{
+		TherapistIDs: []domain.TherapistID{therapistEntry.ID},
+		StartDate:    day,
+		EndDate:      day,
+	}
+
+	ranges, err := usecase.Execute(input)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if len(ranges) != 1 || int(ranges[0].To.Sub(ranges[0].From).Minutes()) != 60 {
+		t.Fatalf("expected the whole slot to stay available with one seat left, got %+v", ranges)
+	}
+
+	bookings.Bookings = append(bookings.Bookings, sessionAt("booking_2"))
+	ranges, err = usecase.Execute(input)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if len(ranges) != 0 {
+		t.Fatalf("expected a full slot to have no availability, got %+v", ranges)
+	}
+}
+
+func TestFindFullyBookedRanges(t *testing.T) {
+	base := domain.UTCTimestamp(time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC))
+	at := func(minutes int) domain.UTCTimestamp {
+		return domain.UTCTimestamp(base.Time().Add(time.Duration(minutes) * time.Minute))
+	}
+	bookings := []timeRange{
+		{start: at(0), end: at(60)},
+		{start: at(30), end: at(90)},
+		{start: at(60), end: at(120)},
+	}
+
+	full := findFullyBookedRanges(bookings, 2)
+	expected := []timeRange{
+		{start: at(30), end: at(90)},
+	}
+	if len(full) != len(expected) {
+		t.Fatalf("expected %d full ranges, got %d: %+v", len(expected), len(full), full)
+	}
+	for i, want := range expected {
+		if !full[i].start.Equal(want.start) || !full[i].end.Equal(want.end) {
+			t.Errorf("expected full range %d to be %v - %v, got %v - %v", i, want.start, want.end, full[i].start, full[i].end)
+		}
+	}
+
+	if got := findFullyBookedRanges(bookings, 3); len(got) != 0 {
+		t.Errorf("expected no full ranges at capacity 3, got %+v", got)
+	}
+}
+
+func TestMultipleSpecializationTagsUnionTherapists(t *testing.T) {
+	// Far enough ahead that the slots are never in the past
+	day := time.Now().UTC().AddDate(0, 0, 2)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	weekday := timeslot.MapToDayOfWeek(day.Weekday())
+
+	anxiety := specialization.Specialization{ID: "specialization_1", Name: "anxiety"}
+	depression := specialization.Specialization{ID: "specialization_2", Name: "depression"}
+	grief := specialization.Specialization{ID: "specialization_3", Name: "grief"}
+	both := &therapist.Therapist{ID: "therapist_both", Name: "Dr. Both", Specializations: []specialization.Specialization{anxiety, depression}}
+	single := &therapist.Therapist{ID: "therapist_single", Name: "Dr. Single", Specializations: []specialization.Specialization{depression}}
+	other := &therapist.Therapist{ID: "therapist_other", Name: "Dr. Other", Specializations: []specialization.Specialization{grief}}
+
+	slots := []*timeslot.TimeSlot{}
+	for _, therapistEntry := range []*therapist.Therapist{both, single, other} {
+		slots = append(slots, &timeslot.TimeSlot{
+			ID:          domain.TimeSlotID("slot_" + string(therapistEntry.ID)),
+			TherapistID: therapistEntry.ID,
+			IsActive:    true,
+			DayOfWeek:   weekday,
+			Start:       "09:00",
+			Duration:    2 * 60,
+		})
+	}
+
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{both, single, other}},
+		&fakes.TimeSlotRepo{Slots: slots},
+		&fakes.BookingRepo{},
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+
+	ranges, err := usecase.Execute(Input{
+		SpecializationTags: []string{"anxiety", "depression"},
+		StartDate:          day,
+		EndDate:            day,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if len(ranges) != 1 {
+		t.Fatalf("expected 1 range, got %d: %+v", len(ranges), ranges)
+	}
+
+	therapists := ranges[0].Therapists
+	if len(therapists) != 2 || therapists[0].TherapistID != both.ID || therapists[1].TherapistID != single.ID {
+		t.Fatalf("expected therapists [%s %s], got %v", both.ID, single.ID, therapists)
+	}
+}
+
+func TestSpecializationLookupIsScopedToClinic(t *testing.T) {
+	// Far enough ahead that the slots are never in the past
+	day := time.Now().UTC().AddDate(0, 0, 2)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	weekday := timeslot.MapToDayOfWeek(day.Weekday())
+
+	anxiety := specialization.Specialization{ID: "specialization_1", Name: "anxiety"}
+	ownClinic := &therapist.Therapist{ID: "therapist_a", Name: "Dr. A", ClinicID: "clinic_a", Specializations: []specialization.Specialization{anxiety}}
+	defaultClinic := &therapist.Therapist{ID: "therapist_default", Name: "Dr. Default", Specializations: []specialization.Specialization{anxiety}}
+
+	slots := []*timeslot.TimeSlot{}
+	for _, therapistEntry := range []*therapist.Therapist{ownClinic, defaultClinic} {
+		slots = append(slots, &timeslot.TimeSlot{
+			ID:          domain.TimeSlotID("slot_" + string(therapistEntry.ID)),
+			TherapistID: therapistEntry.ID,
+			IsActive:    true,
+			DayOfWeek:   weekday,
+			Start:       "09:00",
+			Duration:    2 * 60,
+		})
+	}
+
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{ownClinic, defaultClinic}},
+		&fakes.TimeSlotRepo{Slots: slots},
+		&fakes.BookingRepo{},
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+
+	tests := []struct {
+		clinicID domain.ClinicID
+		expected domain.TherapistID
+	}{
+		{clinicID: "clinic_a", expected: ownClinic.ID},
+		{clinicID: "", expected: defaultClinic.ID},
+	}
+	for _, test := range tests {
+		ranges, err := usecase.Execute(Input{
+			ClinicID:           test.clinicID,
+			SpecializationTags: []string{"anxiety"},
+			StartDate:          day,
+			EndDate:            day,
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if len(ranges) != 1 {
+			t.Fatalf("clinic %q: expected 1 range, got %d: %+v", test.clinicID, len(ranges), ranges)
+		}
+		therapists := ranges[0].Therapists
+		if len(therapists) != 1 || therapists[0].TherapistID != test.expected {
+			t.Errorf("clinic %q: expected only %s, got %v", test.clinicID, test.expected, therapists)
+		}
+	}
+}
+
+func TestLineSweepIsDeterministicForSharedBoundaries(t *testing.T) {
+	fromTime, err := time.Parse(time.RFC3339, "2025-01-01T09:00:00Z")
+	if err != nil {
+		t.Fatalf("failed to parse time: %v", err)
+	}
+	from := domain.UTCTimestamp(fromTime)
+	to := from.Add(2 * time.Hour)
+
+	// Both therapists share a name, so only their IDs can order them
+	first := &therapist.Therapist{ID: "therapist_a", Name: "Dr. Same"}
+	second := &therapist.Therapist{ID: "therapist_b", Name: "Dr. Same"}
+	availabilities := []therapistAvailability{
+		{TherapistID: second.ID, Therapist: second, StartTime: from, EndTime: to, TimeSlotID: "slot_b"},
+		{TherapistID: first.ID, Therapist: first, StartTime: from, EndTime: to, TimeSlotID: "slot_a"},
+	}
+
+	for run := 0; run < 50; run++ {
+		ranges := applyLineSweepAlgorithm(availabilities, 60)
+		if len(ranges) != 1 {
+			t.Fatalf("run %d: expected 1 range, got %d", run, len(ranges))
+		}
+		if !ranges[0].From.Equal(from) || !ranges[0].To.Equal(to) {
+			t.Fatalf("run %d: expected range %s to %s, got %s to %s", run, from, to, ranges[0].From, ranges[0].To)
+		}
+
+		therapists := ranges[0].Therapists
+		if len(therapists) != 2 || therapists[0].TherapistID != first.ID || therapists[1].TherapistID != second.ID {
+			t.Fatalf("run %d: expected therapists [%s %s], got %v", run, first.ID, second.ID, therapists)
+		}
+	}
+}
+
+func TestCrossDaySlotSurfacesOnItsUTCDay(t *testing.T) {
+	// A Monday 01:30 slot at UTC+3 starts on Sunday 22:30 UTC, the same
+	// cross-day case the timeslot e2e test creates. Pick a Sunday far enough
+	// ahead that the slot is never in the past.
+	sunday := time.Now().UTC().AddDate(0, 0, 2)
+	sunday = time.Date(sunday.Year(), sunday.Month(), sunday.Day(), 0, 0, 0, 0, time.UTC)
+	for sunday.Weekday() != time.Sunday {
+		sunday = sunday.AddDate(0, 0, 1)
+	}
+	monday := sunday.AddDate(0, 0, 1)
+
+	therapistEntry := &therapist.Therapist{ID: "therapist_1", Name: "Dr. Riyadh"}
+	slot := &timeslot.TimeSlot{
+		ID:          "slot_1",
+		TherapistID: therapistEntry.ID,
+		IsActive:    true,
+		DayOfWeek:   timeslot.DayOfWeekMonday,
+		Start:       "01:30",
+		Duration:    3 * 60, // Sunday 22:30 - Monday 01:30 UTC
+		Timezone:    "Asia/Riyadh",
+	}
+	// Booked after midnight UTC, so it lands on the day after the slot's
+	bookedSession := &booking.Booking{
+		ID:          "booking_1",
+		TimeSlotID:  slot.ID,
+		TherapistID: therapistEntry.ID,
+		State:       booking.BookingStateConfirmed,
+		StartTime:   domain.UTCTimestamp(monday),
+		Duration:    60,
+	}
+
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistEntry}},
+		&fakes.TimeSlotRepo{Slots: []*timeslot.TimeSlot{slot}},
+		&fakes.BookingRepo{Bookings: []*booking.Booking{bookedSession}},
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+
+	ranges, err := usecase.Execute(Input{
+		TherapistIDs: []domain.TherapistID{therapistEntry.ID},
+		StartDate:    sunday,
+		EndDate:      monday,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	at := func(day time.Time, hour, minute int) domain.UTCTimestamp {
+		return domain.UTCTimestamp(day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute))
+	}
+	expected := []schedule.AvailableTimeRange{
+		{From: at(sunday, 22, 30), To: at(monday, 0, 0)},
+		{From: at(monday, 1, 0), To: at(monday, 1, 30)},
+	}
+	if len(ranges) != len(expected) {
+		t.Fatalf("expected %d ranges, got %d: %+v", len(expected), len(ranges), ranges)
+	}
+	for i, want := range expected {
+		if !ranges[i].From.Equal(want.From) || !ranges[i].To.Equal(want.To) {
+			t.Errorf("expected range %d to be %s - %s, got %s - %s", i, want.From, want.To, ranges[i].From, ranges[i].To)
+		}
+	}
+	if weekday := ranges[0].From.Time().Weekday(); weekday != time.Sunday {
+		t.Errorf("expected the slot to surface on Sunday UTC, got %s", weekday)
+	}
+}
+
+func TestExplainEmptySchedule(t *testing.T) {
+	// Far enough ahead that the slot is never in the past
+	day := time.Now().UTC().AddDate(0, 0, 2)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	anxiety := specialization.Specialization{ID: "specialization_1", Name: "anxiety"}
+	booked := &therapist.Therapist{ID: "therapist_1", Name: "Dr. Booked", Specializations: []specialization.Specialization{anxiety}}
+	slot := &timeslot.TimeSlot{
+		ID:          "slot_1",
+		TherapistID: booked.ID,
+		IsActive:    true,
+		DayOfWeek:   timeslot.MapToDayOfWeek(day.Weekday()),
+		Start:       "09:00",
+		Duration:    60,
+	}
+	fullSession := &booking.Booking{
+		ID:          "booking_1",
+		TimeSlotID:  slot.ID,
+		TherapistID: booked.ID,
+		State:       booking.BookingStateConfirmed,
+		StartTime:   domain.UTCTimestamp(day.Add(9 * time.Hour)),
+		Duration:    60,
+	}
+
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{booked}},
+		&fakes.TimeSlotRepo{Slots: []*timeslot.TimeSlot{slot}},
+		&fakes.BookingRepo{Bookings: []*booking.Booking{fullSession}},
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+
+	tests := []struct {
+		name     string
+		tag      string
+		expected Explanation
+	}{
+		{
+			name:     "no therapist has the tag",
+			tag:      "nonexistent",
+			expected: Explanation{},
+		},
+		{
+			name:     "the only matching therapist is fully booked",
+			tag:      "anxiety",
+			expected: Explanation{MatchedTherapists: 1, TherapistsWithSlots: 1, FullyBookedCount: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, explanation, err := usecase.Explain(Input{
+				SpecializationTags: []string{tt.tag},
+				StartDate:          day,
+				EndDate:            day,
+			})
+			if err != nil {
+				t.Fatalf("expected success, got %v", err)
+			}
+			if len(ranges) != 0 {
+				t.Errorf("expected no availabilities, got %+v", ranges)
+			}
+			if *explanation != tt.expected {
+				t.Errorf("expected explanation %+v, got %+v", tt.expected, *explanation)
+			}
+		})
+	}
+}
+
+func TestMergeAdjacentJoinsSameTherapistRanges(t *testing.T) {
+	// Far enough ahead that the slots are never in the past
+	day := time.Now().UTC().AddDate(0, 0, 2)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	weekday := timeslot.MapToDayOfWeek(day.Weekday())
+
+	// Two back-to-back slots 15 minutes apart, as left by a break between them
+	therapistEntry := &therapist.Therapist{ID: "therapist_1", Name: "Dr. Merge"}
+	slots := []*timeslot.TimeSlot{
+		{ID: "slot_morning", TherapistID: therapistEntry.ID, IsActive: true, DayOfWeek: weekday, Start: "09:00", Duration: 60},
+		{ID: "slot_late_morning", TherapistID: therapistEntry.ID, IsActive: true, DayOfWeek: weekday, Start: "10:15", Duration: 60},
+	}
+
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistEntry}},
+		&fakes.TimeSlotRepo{Slots: slots},
+		&fakes.BookingRepo{},
+		nil,
+		nil,
+		15,
+		nil,
+		30,
+	)
+	input := Input{
+		TherapistIDs: []domain.TherapistID{therapistEntry.ID},
+		StartDate:    day,
+		EndDate:      day,
+	}
+
+	ranges, err := usecase.Execute(input)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if len(ranges) != 2 {
+		t.Fatalf("expected 2 ranges without merging, got %d: %+v", len(ranges), ranges)
+	}
+
+	input.MergeAdjacent = true
+	ranges, err = usecase.Execute(input)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if len(ranges) != 1 {
+		t.Fatalf("expected 1 merged range, got %d: %+v", len(ranges), ranges)
+	}
+
+	from := domain.UTCTimestamp(day.Add(9 * time.Hour))
+	to := domain.UTCTimestamp(day.Add(11*time.Hour + 15*time.Minute))
+	merged := ranges[0]
+	if !merged.From.Equal(from) || !merged.To.Equal(to) || merged.Duration != 135 {
+		t.Errorf("expected merged range %s to %s (135 minutes), got %s to %s (%d minutes)", from, to, merged.From, merged.To, merged.Duration)
+	}
+	if len(merged.Therapists) != 1 || !merged.Therapists[0].AvailabilityRange.To.Equal(to) {
+		t.Errorf("expected the therapist's availability to end at %s, got %+v", to, merged.Therapists)
+	}
+}
+
+func TestExecuteBatchKeepsQueriesApart(t *testing.T) {
+	// Far enough ahead that the slots are never in the past
+	day := time.Now().UTC().AddDate(0, 0, 2)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	weekday := timeslot.MapToDayOfWeek(day.Weekday())
+
+	anxiety := specialization.Specialization{ID: "specialization_1", Name: "anxiety"}
+	grief := specialization.Specialization{ID: "specialization_2", Name: "grief"}
+	morning := &therapist.Therapist{ID: "therapist_morning", Name: "Dr. Morning", Specializations: []specialization.Specialization{anxiety}}
+	afternoon := &therapist.Therapist{ID: "therapist_afternoon", Name: "Dr. Afternoon", Specializations: []specialization.Specialization{grief}}
+
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{morning, afternoon}},
+		&fakes.TimeSlotRepo{Slots: []*timeslot.TimeSlot{
+			{ID: "slot_morning", TherapistID: morning.ID, IsActive: true, DayOfWeek: weekday, Start: "09:00", Duration: 60},
+			{ID: "slot_afternoon", TherapistID: afternoon.ID, IsActive: true, DayOfWeek: weekday, Start: "14:00", Duration: 60},
+		}},
+		&fakes.BookingRepo{},
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+
+	results, err := usecase.ExecuteBatch(map[string]Input{
+		"anxiety": {SpecializationTags: []string{"anxiety"}, StartDate: day, EndDate: day},
+		"grief":   {SpecializationTags: []string{"grief"}, StartDate: day, EndDate: day},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+
+	expected := map[string]struct {
+		therapistID domain.TherapistID
+		from        time.Time
+	}{
+		"anxiety": {morning.ID, day.Add(9 * time.Hour)},
+		"grief":   {afternoon.ID, day.Add(14 * time.Hour)},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d: %+v", len(expected), len(results), results)
+	}
+	for key, want := range expected {
+		ranges := results[key]
+		if len(ranges) != 1 {
+			t.Fatalf("%s: expected 1 range, got %d: %+v", key, len(ranges), ranges)
+		}
+		if !time.Time(ranges[0].From).Equal(want.from) {
+			t.Errorf("%s: expected the range to start at %v, got %v", key, want.from, time.Time(ranges[0].From))
+		}
+		therapists := ranges[0].Therapists
+		if len(therapists) != 1 || therapists[0].TherapistID != want.therapistID {
+			t.Errorf("%s: expected therapist %s, got %v", key, want.therapistID, therapists)
+		}
+	}
+}
+
+// rangeRecordingBookingRepo records the date range of every bulk booking load
+type rangeRecordingBookingRepo struct {
+	fakes.BookingRepo
+	loads [][2]time.Time
+}
+
+func (r *rangeRecordingBookingRepo) BulkListByTherapistForDateRange(
+	therapistIDs []domain.TherapistID,
+	states []booking.BookingState,
+	startDate, endDate time.Time,
+) (map[domain.TherapistID][]*booking.Booking, error) {
+	r.loads = append(r.loads, [2]time.Time{startDate, endDate})
+	return r.BookingRepo.BulkListByTherapistForDateRange(therapistIDs, states, startDate, endDate)
+}
+
+func TestExecuteBatchLoadsOverlappingQueriesTogether(t *testing.T) {
+	// Far enough ahead that the slots are never in the past
+	day := time.Now().UTC().AddDate(0, 0, 2)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	later := day.AddDate(0, 2, 0)
+
+	therapistEntry := &therapist.Therapist{ID: "therapist_1", Name: "Dr. Batch"}
+	slots := []*timeslot.TimeSlot{}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		slots = append(slots, &timeslot.TimeSlot{
+			ID:          domain.TimeSlotID("slot_" + weekday.String()),
+			TherapistID: therapistEntry.ID,
+			IsActive:    true,
+			DayOfWeek:   timeslot.MapToDayOfWeek(weekday),
+			Start:       "09:00",
+			Duration:    60,
+		})
+	}
+	bookingRepo := &rangeRecordingBookingRepo{}
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{therapistEntry}},
+		&fakes.TimeSlotRepo{Slots: slots},
+		bookingRepo,
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+
+	therapistIDs := []domain.TherapistID{therapistEntry.ID}
+	results, err := usecase.ExecuteBatch(map[string]Input{
+		"today":    {TherapistIDs: therapistIDs, StartDate: day, EndDate: day},
+		"tomorrow": {TherapistIDs: therapistIDs, StartDate: day.AddDate(0, 0, 1), EndDate: day.AddDate(0, 0, 1)},
+		"later":    {TherapistIDs: therapistIDs, StartDate: later, EndDate: later},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	for key, ranges := range results {
+		if len(ranges) != 1 {
+			t.Errorf("%s: expected 1 range, got %d: %+v", key, len(ranges), ranges)
+		}
+	}
+
+	// Today and tomorrow share a load, while the later query doesn't pull
+	// in the two months between them
+	if len(bookingRepo.loads) != 2 {
+		t.Fatalf("expected 2 booking loads, got %d: %v", len(bookingRepo.loads), bookingRepo.loads)
+	}
+	expected := [][2]time.Time{
+		{day, day.AddDate(0, 0, 3)},
+		{later, later.AddDate(0, 0, 2)},
+	}
+	for i, load := range bookingRepo.loads {
+		if !load[0].Equal(expected[i][0]) || !load[1].Equal(expected[i][1]) {
+			t.Errorf("load %d: expected %v to %v, got %v to %v", i, expected[i][0], expected[i][1], load[0], load[1])
+		}
+	}
+}
+
+func TestWeekdaysOnlySkipsWeekends(t *testing.T) {
+	// A Friday far enough ahead that the slots are never in the past
+	friday := time.Now().UTC().AddDate(0, 0, 2)
+	friday = time.Date(friday.Year(), friday.Month(), friday.Day(), 0, 0, 0, 0, time.UTC)
+	for friday.Weekday() != time.Friday {
+		friday = friday.AddDate(0, 0, 1)
+	}
+	monday := friday.AddDate(0, 0, 3)
+
+	everyDay := &therapist.Therapist{ID: "therapist_every_day", Name: "Dr. Every Day"}
+	slots := []*timeslot.TimeSlot{}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		slots = append(slots, &timeslot.TimeSlot{
+			ID:          domain.TimeSlotID("slot_" + weekday.String()),
+			TherapistID: everyDay.ID,
+			IsActive:    true,
+			DayOfWeek:   timeslot.MapToDayOfWeek(weekday),
+			Start:       "09:00",
+			Duration:    60,
+		})
+	}
+
+	usecase := NewUsecase(
+		&fakes.TherapistRepo{Therapists: []*therapist.Therapist{everyDay}},
+		&fakes.TimeSlotRepo{Slots: slots},
+		&fakes.BookingRepo{},
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+
+	tests := []struct {
+		name         string
+		weekdaysOnly bool
+		expectedDays []time.Weekday
+	}{
+		{name: "weekends included by default", expectedDays: []time.Weekday{time.Friday, time.Saturday, time.Sunday, time.Monday}},
+		{name: "weekends skipped", weekdaysOnly: true, expectedDays: []time.Weekday{time.Friday, time.Monday}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := usecase.Execute(Input{
+				TherapistIDs: []domain.TherapistID{everyDay.ID},
+				StartDate:    friday,
+				EndDate:      monday,
+				WeekdaysOnly: tt.weekdaysOnly,
+			})
+			if err != nil {
+				t.Fatalf("expected success, got %v", err)
+			}
+			if len(ranges) != len(tt.expectedDays) {
+				t.Fatalf("expected %d ranges, got %d: %+v", len(tt.expectedDays), len(ranges), ranges)
+			}
+			for i, expected := range tt.expectedDays {
+				if got := time.Time(ranges[i].From).Weekday(); got != expected {
+					t.Errorf("expected range %d on %s, got %s", i, expected, got)
+				}
+			}
+		})
+	}
+}
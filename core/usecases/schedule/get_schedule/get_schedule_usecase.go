@@ -2,7 +2,9 @@ package get_schedule
 
 import (
 	"errors"
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -19,11 +21,55 @@ type timeRange struct {
 }
 
 type Input struct {
-	SpecializationTag string
-	MustSpeakEnglish  bool
-	TherapistIDs      []domain.TherapistID
-	StartDate         time.Time
-	EndDate           time.Time
+	// ClinicID scopes the therapists matched by tag, the default clinic when
+	// empty. Therapists asked for by id are checked by the caller.
+	ClinicID domain.ClinicID
+	// SpecializationTags matches therapists having any of the tags
+	SpecializationTags []string
+	MustSpeakEnglish   bool
+	TherapistIDs       []domain.TherapistID
+	StartDate          time.Time
+	EndDate            time.Time
+	// SlotLengthMinutes, when set, splits every range into fixed-length
+	// candidate start times per therapist.
+	SlotLengthMinutes domain.DurationMinutes
+	// MergeAdjacent joins consecutive ranges listing the same therapists when
+	// they are closer than the configured gap, e.g. split only by a break.
+	MergeAdjacent bool
+	// WeekdaysOnly skips Saturdays and Sundays (UTC), for specializations
+	// only offered on business days.
+	WeekdaysOnly bool
+}
+
+// Explanation tells an empty schedule apart: no therapist matched, none had
+// slots in the range, or every matching therapist was fully booked.
+type Explanation struct {
+	MatchedTherapists   int `json:"matchedTherapists"`
+	TherapistsWithSlots int `json:"therapistsWithSlots"` // Had a slot in the range that was not past its advance notice
+	FullyBookedCount    int `json:"fullyBookedCount"`    // Had slots but no availability left in them
+}
+
+// EmptyReason tells why a schedule came back empty
+type EmptyReason string
+
+const (
+	EmptyReasonNoMatchingTherapists     EmptyReason = "no_matching_therapists"
+	EmptyReasonNoConfiguredAvailability EmptyReason = "no_configured_availability"
+	EmptyReasonFullyBooked              EmptyReason = "fully_booked"
+)
+
+// EmptyReason returns why an empty schedule with this explanation is empty:
+// no therapist matched, none had slots on the requested days, or they had
+// slots that were all booked.
+func (e *Explanation) EmptyReason() EmptyReason {
+	switch {
+	case e.MatchedTherapists == 0:
+		return EmptyReasonNoMatchingTherapists
+	case e.TherapistsWithSlots == 0:
+		return EmptyReasonNoConfiguredAvailability
+	default:
+		return EmptyReasonFullyBooked
+	}
 }
 
 type Usecase struct {
@@ -31,41 +77,204 @@ type Usecase struct {
 	timeSlotRepo                    ports.TimeSlotRepository
 	bookingRepo                     ports.BookingRepository
 	adhocBookingRepo                ports.AdhocBookingRepository
+	recurringBlockRepo              ports.RecurringBlockRepository
 	timeRangeMinimumDurationMinutes domain.DurationMinutes
+	scheduleCache                   ports.ScheduleCache
+	mergeGapMinutes                 domain.DurationMinutes
 }
 
 var ErrSpecializationTagOrTherapistIDsIsRequired = errors.New("specialization tag or therapist ids is required")
 var ErrInvalidDateRange = errors.New("invalid date range")
 var ErrSpecializationTagAndTherapistIDsCannotBeUsedTogether = errors.New("specialization tag and therapist ids cannot be used together")
+var ErrInvalidSlotLength = errors.New("slot length must be positive")
+var ErrBatchQueriesRequired = errors.New("at least one query is required")
+var ErrTooManyBatchQueries = errors.New("too many queries")
+
+// MaxBatchQueries caps the queries of a single ExecuteBatch call
+const MaxBatchQueries = 20
 
 func NewUsecase(
 	therapistRepo ports.TherapistRepository,
 	timeSlotRepo ports.TimeSlotRepository,
 	bookingRepo ports.BookingRepository,
 	adhocBookingRepo ports.AdhocBookingRepository,
+	recurringBlockRepo ports.RecurringBlockRepository, // optional, nil ignores recurring blocks
 	timeRangeMinimumDurationMinutes domain.DurationMinutes,
+	scheduleCache ports.ScheduleCache, // optional, nil disables caching
+	mergeGapMinutes domain.DurationMinutes,
 ) *Usecase {
 	return &Usecase{
 		therapistRepo:                   therapistRepo,
 		timeSlotRepo:                    timeSlotRepo,
 		bookingRepo:                     bookingRepo,
 		adhocBookingRepo:                adhocBookingRepo,
+		recurringBlockRepo:              recurringBlockRepo,
 		timeRangeMinimumDurationMinutes: timeRangeMinimumDurationMinutes,
+		scheduleCache:                   scheduleCache,
+		mergeGapMinutes:                 mergeGapMinutes,
 	}
 }
 
 func (u *Usecase) Execute(input Input) ([]schedule.AvailableTimeRange, error) {
-	// Validate input
-	if input.SpecializationTag == "" && len(input.TherapistIDs) == 0 {
-		return nil, ErrSpecializationTagOrTherapistIDsIsRequired
+	input, err := validateInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the public specialization lookup is cached. Lookups by therapist
+	// back booking checks and must always see the latest bookings.
+	var availableRanges []schedule.AvailableTimeRange
+	if u.scheduleCache != nil && len(input.SpecializationTags) > 0 && input.SlotLengthMinutes == 0 {
+		availableRanges, err = u.executeCached(input)
+	} else {
+		availableRanges, err = u.execute(input, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Merged after the cache so cached days stay as the sweep produced them
+	if input.MergeAdjacent {
+		availableRanges = mergeAdjacentRanges(availableRanges, u.mergeGapMinutes)
+	}
+	return availableRanges, nil
+}
+
+// Explain computes the schedule along with an explanation of its result. It
+// always bypasses the cache, since cached days carry no explanation.
+func (u *Usecase) Explain(input Input) ([]schedule.AvailableTimeRange, *Explanation, error) {
+	input, err := validateInput(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	explanation := &Explanation{}
+	availableRanges, err := u.execute(input, explanation)
+	if err != nil {
+		return nil, nil, err
+	}
+	if input.MergeAdjacent {
+		availableRanges = mergeAdjacentRanges(availableRanges, u.mergeGapMinutes)
+	}
+	return availableRanges, explanation, nil
+}
+
+// ExecuteBatch computes the schedule of each keyed input. Therapists are looked
+// up once per distinct set of tags, and their timeslots, bookings and blocks
+// are loaded together for queries with overlapping date ranges, instead of
+// once per query. Like Explain, it bypasses the cache.
+func (u *Usecase) ExecuteBatch(inputs map[string]Input) (map[string][]schedule.AvailableTimeRange, error) {
+	if len(inputs) == 0 {
+		return nil, ErrBatchQueriesRequired
+	}
+	if len(inputs) > MaxBatchQueries {
+		return nil, ErrTooManyBatchQueries
+	}
+
+	validInputs := make(map[string]Input, len(inputs))
+	queryTherapists := make(map[string][]*therapist.Therapist, len(inputs))
+	found := make(map[therapistQuery][]*therapist.Therapist)
+	for key, input := range inputs {
+		input, err := validateInput(input)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", key, err)
+		}
+		validInputs[key] = input
+
+		therapists, err := u.findTherapists(input, found)
+		if err != nil {
+			return nil, err
+		}
+		queryTherapists[key] = therapists
+	}
+
+	results := make(map[string][]schedule.AvailableTimeRange, len(validInputs))
+	for _, window := range groupIntoWindows(validInputs) {
+		seen := make(map[domain.TherapistID]bool)
+		therapistIDs := []domain.TherapistID{}
+		for _, key := range window.keys {
+			for _, therapistID := range therapistIDsOf(queryTherapists[key]) {
+				if !seen[therapistID] {
+					seen[therapistID] = true
+					therapistIDs = append(therapistIDs, therapistID)
+				}
+			}
+		}
+
+		data, err := u.loadScheduleData(therapistIDs, window.start, window.end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range window.keys {
+			input := validInputs[key]
+			availableRanges := u.compute(input, queryTherapists[key], data, nil)
+			if input.MergeAdjacent {
+				availableRanges = mergeAdjacentRanges(availableRanges, u.mergeGapMinutes)
+			}
+			results[key] = availableRanges
+		}
+	}
+	return results, nil
+}
+
+// batchWindow is a date range covering batch queries loaded together
+type batchWindow struct {
+	keys  []string
+	start time.Time
+	end   time.Time
+}
+
+// groupIntoWindows groups the keyed inputs into windows of overlapping date
+// ranges, so queries months apart don't load every booking in between.
+// Queries on consecutive days share a window, as their loaded days overlap.
+func groupIntoWindows(inputs map[string]Input) []*batchWindow {
+	keys := make([]string, 0, len(inputs))
+	for key := range inputs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := inputs[keys[i]].StartDate, inputs[keys[j]].StartDate
+		if a.Equal(b) {
+			return keys[i] < keys[j]
+		}
+		return a.Before(b)
+	})
+
+	windows := []*batchWindow{}
+	var current *batchWindow
+	for _, key := range keys {
+		input := inputs[key]
+		if current == nil || startOfDay(input.StartDate).After(startOfDay(current.end).AddDate(0, 0, 1)) {
+			current = &batchWindow{start: input.StartDate, end: input.EndDate}
+			windows = append(windows, current)
+		}
+		current.keys = append(current.keys, key)
+		if input.EndDate.After(current.end) {
+			current.end = input.EndDate
+		}
 	}
+	return windows
+}
 
-	if input.SpecializationTag != "" && len(input.TherapistIDs) > 0 {
-		return nil, ErrSpecializationTagAndTherapistIDsCannotBeUsedTogether
+// validateInput validates the input and fills in the default date range
+func validateInput(input Input) (Input, error) {
+	input.ClinicID = input.ClinicID.OrDefault()
+	input.SpecializationTags = normalizeTags(input.SpecializationTags)
+	if len(input.SpecializationTags) == 0 && len(input.TherapistIDs) == 0 {
+		return input, ErrSpecializationTagOrTherapistIDsIsRequired
+	}
+
+	if len(input.SpecializationTags) > 0 && len(input.TherapistIDs) > 0 {
+		return input, ErrSpecializationTagAndTherapistIDsCannotBeUsedTogether
 	}
 
 	if input.EndDate.Before(input.StartDate) {
-		return nil, ErrInvalidDateRange
+		return input, ErrInvalidDateRange
+	}
+
+	if input.SlotLengthMinutes < 0 {
+		return input, ErrInvalidSlotLength
 	}
 
 	// Set default date range if not provided
@@ -77,38 +286,142 @@ func (u *Usecase) Execute(input Input) ([]schedule.AvailableTimeRange, error) {
 		input.EndDate = input.StartDate.AddDate(0, 0, 14) // Default to 2 weeks ahead
 	}
 
-	var therapists []*therapist.Therapist
-	var err error
+	return input, nil
+}
 
-	if len(input.TherapistIDs) > 0 {
-		therapists, err = u.therapistRepo.FindByIDs(input.TherapistIDs)
-	} else {
-		therapists, err = u.therapistRepo.FindBySpecializationAndLanguage(input.SpecializationTag, input.MustSpeakEnglish)
+// executeCached computes the schedule one day at a time, reusing the days
+// found in the cache.
+func (u *Usecase) executeCached(input Input) ([]schedule.AvailableTimeRange, error) {
+	availableRanges := []schedule.AvailableTimeRange{}
+	for day := input.StartDate; !day.After(input.EndDate); day = day.AddDate(0, 0, 1) {
+		if input.WeekdaysOnly && isWeekend(day) {
+			continue
+		}
+
+		key := ports.ScheduleCacheKey{
+			ClinicID:          input.ClinicID,
+			SpecializationTag: strings.Join(input.SpecializationTags, ","),
+			MustSpeakEnglish:  input.MustSpeakEnglish,
+			Date:              day.UTC().Format(time.DateOnly),
+		}
+
+		dayRanges, ok := u.scheduleCache.Get(key)
+		if !ok {
+			dayInput := input
+			dayInput.StartDate = day
+			dayInput.EndDate = day
+
+			var err error
+			dayRanges, err = u.execute(dayInput, nil)
+			if err != nil {
+				return nil, err
+			}
+			u.scheduleCache.Set(key, dayRanges)
+		}
+
+		availableRanges = append(availableRanges, dayRanges...)
 	}
 
+	return availableRanges, nil
+}
+
+// execute computes the schedule of a validated input, filling in explanation
+// when it is not nil
+func (u *Usecase) execute(input Input, explanation *Explanation) ([]schedule.AvailableTimeRange, error) {
+	therapists, err := u.findTherapists(input, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	therapistIDs := make([]domain.TherapistID, len(therapists))
-	for i, therapist := range therapists {
-		therapistIDs[i] = therapist.ID
+	data, err := u.loadScheduleData(therapistIDsOf(therapists), input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
 	}
 
-	// For each therapist, calculate their available time ranges
-	therapistTimeSlots := make(map[domain.TherapistID][]*timeslot.TimeSlot)
-	therapistSlots, err := u.timeSlotRepo.BulkListByTherapist(therapistIDs)
+	return u.compute(input, therapists, data, explanation), nil
+}
+
+// therapistQuery identifies a therapist lookup by specialization tags
+type therapistQuery struct {
+	clinicID         domain.ClinicID
+	tags             string
+	mustSpeakEnglish bool
+}
+
+// findTherapists returns the therapists an input asks for. Lookups by tag
+// are memoized in found when it is not nil, so a batch of queries sharing a
+// tag only hits the repository once.
+func (u *Usecase) findTherapists(input Input, found map[therapistQuery][]*therapist.Therapist) ([]*therapist.Therapist, error) {
+	if len(input.TherapistIDs) > 0 {
+		return u.therapistRepo.FindByIDs(input.TherapistIDs)
+	}
+
+	var languageCode domain.LanguageCode
+	if input.MustSpeakEnglish {
+		languageCode = domain.LanguageCodeEnglish
+	}
+	if found == nil {
+		return u.findBySpecializations(input.ClinicID, input.SpecializationTags, languageCode)
+	}
+
+	key := therapistQuery{
+		clinicID:         input.ClinicID,
+		tags:             strings.Join(input.SpecializationTags, ","),
+		mustSpeakEnglish: input.MustSpeakEnglish,
+	}
+	if therapists, ok := found[key]; ok {
+		return therapists, nil
+	}
+	therapists, err := u.findBySpecializations(input.ClinicID, input.SpecializationTags, languageCode)
 	if err != nil {
 		return nil, err
 	}
-	bookings, err := u.bookingRepo.BulkListByTherapistForDateRange(
+	found[key] = therapists
+	return therapists, nil
+}
+
+// scheduleData holds what the schedule of a set of therapists is computed
+// from, keyed by therapist.
+type scheduleData struct {
+	timeSlots map[domain.TherapistID][]*timeslot.TimeSlot
+	bookings  map[domain.TherapistID][]*booking.Booking
+	blocks    map[domain.TherapistID][]*timeslot.RecurringBlock
+}
+
+// loadScheduleData loads the timeslots, bookings and recurring blocks of the
+// therapists for the days from start to end
+func (u *Usecase) loadScheduleData(therapistIDs []domain.TherapistID, start, end time.Time) (scheduleData, error) {
+	data := scheduleData{blocks: make(map[domain.TherapistID][]*timeslot.RecurringBlock)}
+	if len(therapistIDs) == 0 {
+		// No therapist matched, so there is nothing to load
+		return data, nil
+	}
+
+	var err error
+	data.timeSlots, err = u.timeSlotRepo.BulkListByTherapist(therapistIDs)
+	if err != nil {
+		return data, err
+	}
+	// Bookings are matched to slots by day, so load every rendered day in full,
+	// plus the day after for slots running past midnight UTC
+	bookingsFrom := startOfDay(start)
+	bookingsTo := startOfDay(end).AddDate(0, 0, 2)
+	// Held bookings keep their time reserved until the hold is released
+	data.bookings, err = u.bookingRepo.BulkListByTherapistForDateRange(
 		therapistIDs,
-		[]booking.BookingState{booking.BookingStateConfirmed},
-		input.StartDate,
-		input.EndDate,
+		[]booking.BookingState{booking.BookingStateConfirmed, booking.BookingStateHeld},
+		bookingsFrom,
+		bookingsTo,
 	)
 	if err != nil {
-		return nil, err
+		return data, err
+	}
+
+	if u.recurringBlockRepo != nil {
+		data.blocks, err = u.recurringBlockRepo.BulkListByTherapist(therapistIDs)
+		if err != nil {
+			return data, err
+		}
 	}
 
 	// TODO: if a therapist modifies their timeslot ranges, they might have had conflicting
@@ -125,52 +438,183 @@ func (u *Usecase) Execute(input Input) ([]schedule.AvailableTimeRange, error) {
 	// 	return nil, err
 	// }
 
+	return data, nil
+}
+
+// compute computes the schedule of the therapists from their loaded data,
+// filling in explanation when it is not nil
+func (u *Usecase) compute(input Input, therapists []*therapist.Therapist, data scheduleData, explanation *Explanation) []schedule.AvailableTimeRange {
+	if explanation != nil {
+		explanation.MatchedTherapists = len(therapists)
+	}
+
+	// For each therapist, calculate their available time ranges
+	therapistTimeSlots := make(map[domain.TherapistID][]*timeslot.TimeSlot)
 	allTherapistAvailabilities := []therapistAvailability{}
 	nowUTC := domain.NewUTCTimestamp()
 	for _, therapist := range therapists {
 		// Get all time slots for this therapist
-		timeSlots := therapistSlots[therapist.ID]
+		timeSlots := data.timeSlots[therapist.ID]
 		therapistTimeSlots[therapist.ID] = timeSlots
 
 		// Get confirmed bookings for this therapist in the date range
 		// Convert bookings to a map for efficient lookup
-		bookingMap := makeBookingMap(bookings[therapist.ID])
+		bookingMap := makeBookingMap(data.bookings[therapist.ID])
 
+		hasSlots, hasAvailability := false, false
 		// For each day in the date range
 		for renderedSlotDay := input.StartDate; !renderedSlotDay.After(input.EndDate); renderedSlotDay = renderedSlotDay.AddDate(0, 0, 1) {
+			if input.WeekdaysOnly && isWeekend(renderedSlotDay) {
+				continue
+			}
+
 			availableDaySlots := filterAvailableDaySlots(timeSlots, renderedSlotDay, nowUTC)
+			hasSlots = hasSlots || len(availableDaySlots) > 0
 
 			for _, slot := range availableDaySlots {
 				// Get bookings for this slot on this day
-				slotBookings := getBookingsForSlot(bookingMap, slot.ID, renderedSlotDay)
+				slotBookings := getBookingsForSlot(bookingMap, slot, renderedSlotDay)
+				slotBlocks := getBlocksForSlot(data.blocks[therapist.ID], slot, renderedSlotDay)
 
 				therapistAvailabilities := findTherapistAvailabilities(
 					therapist,
 					slot,
 					slotBookings,
+					slotBlocks,
 					renderedSlotDay,
 					u.timeRangeMinimumDurationMinutes,
 				)
 				allTherapistAvailabilities = append(allTherapistAvailabilities, therapistAvailabilities...)
+				hasAvailability = hasAvailability || len(therapistAvailabilities) > 0
+			}
+		}
+
+		if explanation != nil && hasSlots {
+			explanation.TherapistsWithSlots++
+			if !hasAvailability {
+				explanation.FullyBookedCount++
 			}
 		}
 	}
 
 	// Step 2: Apply the line sweep algorithm to merge overlapping ranges
-	return applyLineSweepAlgorithm(allTherapistAvailabilities, u.timeRangeMinimumDurationMinutes), nil
+	availableRanges := applyLineSweepAlgorithm(allTherapistAvailabilities, u.timeRangeMinimumDurationMinutes)
+
+	// Step 3: Optionally split ranges into fixed-length bookable start times
+	if input.SlotLengthMinutes > 0 {
+		addCandidateStarts(availableRanges, therapistTimeSlots, input.SlotLengthMinutes)
+	}
+
+	return availableRanges
+}
+
+func therapistIDsOf(therapists []*therapist.Therapist) []domain.TherapistID {
+	therapistIDs := make([]domain.TherapistID, len(therapists))
+	for i, therapist := range therapists {
+		therapistIDs[i] = therapist.ID
+	}
+	return therapistIDs
+}
+
+// findBySpecializations returns the therapists matching any of the tags, each
+// listed once.
+func (u *Usecase) findBySpecializations(clinicID domain.ClinicID, tags []string, languageCode domain.LanguageCode) ([]*therapist.Therapist, error) {
+	therapists := []*therapist.Therapist{}
+	seen := make(map[domain.TherapistID]bool)
+	for _, tag := range tags {
+		matches, err := u.therapistRepo.FindBySpecializationAndLanguage(clinicID, tag, languageCode)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if seen[match.ID] {
+				continue
+			}
+			seen[match.ID] = true
+			therapists = append(therapists, match)
+		}
+	}
+	return therapists, nil
+}
+
+// normalizeTags drops empty and repeated tags and sorts the rest, so the same
+// set of tags always shares a cache key.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// addCandidateStarts fills each therapist's candidate start times. Candidates
+// are laid out over the therapist's own availability range, so they stay the
+// same no matter how the line sweep cut it, and each range only lists those
+// starting inside it.
+func addCandidateStarts(
+	availableRanges []schedule.AvailableTimeRange,
+	therapistTimeSlots map[domain.TherapistID][]*timeslot.TimeSlot,
+	slotLength domain.DurationMinutes,
+) {
+	breakTimes := make(map[domain.TimeSlotID]domain.AfterSessionBreakTimeMinutes)
+	for _, slots := range therapistTimeSlots {
+		for _, slot := range slots {
+			breakTimes[slot.ID] = slot.AfterSessionBreakTime
+		}
+	}
+
+	for i := range availableRanges {
+		availableRange := &availableRanges[i]
+		for j := range availableRange.Therapists {
+			info := &availableRange.Therapists[j]
+			candidates := []domain.UTCTimestamp{}
+			for _, start := range splitIntoCandidateStarts(info.AvailabilityRange, slotLength, breakTimes[info.TimeSlotID]) {
+				if start.Before(availableRange.From) || !start.Before(availableRange.To) {
+					continue
+				}
+				candidates = append(candidates, start)
+			}
+			info.CandidateStarts = candidates
+		}
+	}
+}
+
+// splitIntoCandidateStarts returns the start times of back-to-back sessions of
+// slotLength that fit in the range, leaving the post-session break between them.
+func splitIntoCandidateStarts(
+	availabilityRange schedule.TimeRange,
+	slotLength domain.DurationMinutes,
+	afterSessionBreakTime domain.AfterSessionBreakTimeMinutes,
+) []domain.UTCTimestamp {
+	sessionLength := time.Duration(slotLength) * time.Minute
+	step := sessionLength + time.Duration(afterSessionBreakTime)*time.Minute
+
+	starts := []domain.UTCTimestamp{}
+	for start := availabilityRange.From; !start.Add(sessionLength).After(availabilityRange.To); start = start.Add(step) {
+		starts = append(starts, start)
+	}
+	return starts
 }
 
 func findTherapistAvailabilities(
 	therapist *therapist.Therapist,
 	slot *timeslot.TimeSlot,
 	slotBookings []*booking.Booking,
+	slotBlocks []timeRange,
 	renderedSlotDay time.Time,
 	timeRangeMinimumDurationMinutes domain.DurationMinutes,
 ) []therapistAvailability {
-	slotStart, slotEnd := slot.ApplyToDate(renderedSlotDay)
+	slotStart, slotEnd, _ := slot.OccurrenceOn(renderedSlotDay)
 
-	// If no bookings, add the entire slot as available
-	if len(slotBookings) == 0 {
+	// If no bookings or blocks, add the entire slot as available
+	if len(slotBookings) == 0 && len(slotBlocks) == 0 {
 		return []therapistAvailability{
 			{
 				TherapistID: therapist.ID,
@@ -196,11 +640,15 @@ func findTherapistAvailabilities(
 		})
 	}
 
+	// Group slots stay open until every seat is taken
+	fullRanges := findFullyBookedRanges(bookingsTimeRanges, slot.BookingCapacity())
+
 	// Calculate available ranges between bookings
 	availableRanges := findInterBookingAvailabilities(
 		slotTimeRange,
 		slot.AfterSessionBreakTime,
-		bookingsTimeRanges,
+		fullRanges,
+		slotBlocks,
 		timeRangeMinimumDurationMinutes,
 	)
 
@@ -227,7 +675,10 @@ func filterAvailableDaySlots(
 	availableDaySlots := []*timeslot.TimeSlot{}
 	// For each time slot on this day
 	for _, slot := range timeSlots {
-		if slot.DayOfWeek != timeslot.MapToDayOfWeek(renderedSlotDay.Weekday()) {
+		// The slot's day is local to its timezone, so match on the UTC day
+		// its occurrence starts on
+		_, slotEnd, ok := slot.OccurrenceOn(renderedSlotDay)
+		if !ok {
 			continue
 		}
 
@@ -237,9 +688,6 @@ func filterAvailableDaySlots(
 			continue
 		}
 
-		// Calculate the specific time slot for this date
-		_, slotEnd := slot.ApplyToDate(renderedSlotDay)
-
 		// If the slot is in the past, skip it
 		if slotEnd.Before(nowUTC) {
 			continue
@@ -259,6 +707,17 @@ type therapistAvailability struct {
 	TimeSlotID  domain.TimeSlotID
 }
 
+// startOfDay truncates the time to midnight UTC
+func isWeekend(day time.Time) bool {
+	weekday := day.UTC().Weekday()
+	return weekday == time.Saturday || weekday == time.Sunday
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
 // Helper functions for calculateAvailableTimeRanges
 func makeBookingMap(bookings []*booking.Booking) map[string]map[domain.TimeSlotID][]*booking.Booking {
 	bookingMap := make(map[string]map[domain.TimeSlotID][]*booking.Booking)
@@ -272,12 +731,48 @@ func makeBookingMap(bookings []*booking.Booking) map[string]map[domain.TimeSlotI
 	return bookingMap
 }
 
-func getBookingsForSlot(bookingMap map[string]map[domain.TimeSlotID][]*booking.Booking, slotID domain.TimeSlotID, date time.Time) []*booking.Booking {
-	dateStr := date.Format(time.DateOnly)
-	if dateMap, ok := bookingMap[dateStr]; ok {
-		return dateMap[slotID]
+// getBookingsForSlot returns the slot's bookings within its occurrence on the
+// given UTC day. An occurrence starting late in the day can run past midnight
+// UTC, so bookings on the following day are checked too.
+func getBookingsForSlot(bookingMap map[string]map[domain.TimeSlotID][]*booking.Booking, slot *timeslot.TimeSlot, date time.Time) []*booking.Booking {
+	slotStart, slotEnd, ok := slot.OccurrenceOn(date)
+	if !ok {
+		return nil
+	}
+
+	slotBookings := []*booking.Booking{}
+	for day := startOfDay(slotStart.Time()); day.Before(slotEnd.Time()); day = day.AddDate(0, 0, 1) {
+		for _, bookingEntry := range bookingMap[day.Format(time.DateOnly)][slot.ID] {
+			if bookingEntry.StartTime.Before(slotEnd) && !bookingEntry.StartTime.Before(slotStart) {
+				slotBookings = append(slotBookings, bookingEntry)
+			}
+		}
 	}
-	return nil
+	return slotBookings
+}
+
+// getBlocksForSlot returns the recurring blocks overlapping the slot on the
+// given date. Neighbouring days are checked too, since a block in another
+// timezone can land on a different UTC day than the slot.
+func getBlocksForSlot(blocks []*timeslot.RecurringBlock, slot *timeslot.TimeSlot, date time.Time) []timeRange {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	slotStart, slotEnd, _ := slot.OccurrenceOn(date)
+	slotBlocks := []timeRange{}
+	for _, block := range blocks {
+		for _, day := range []time.Time{date.AddDate(0, 0, -1), date, date.AddDate(0, 0, 1)} {
+			if block.DayOfWeek != timeslot.MapToDayOfWeek(day.Weekday()) {
+				continue
+			}
+			blockStart, blockEnd := block.ApplyToDate(day)
+			if blockStart.Before(slotEnd) && slotStart.Before(blockEnd) {
+				slotBlocks = append(slotBlocks, timeRange{start: blockStart, end: blockEnd})
+			}
+		}
+	}
+	return slotBlocks
 }
 
 // applyLineSweepAlgorithm implements the line sweep algorithm to find all unique time ranges
@@ -329,11 +824,15 @@ func applyLineSweepAlgorithm(
 
 	// Step 2: Sort time points
 	sort.Slice(timePoints, func(i, j int) bool {
-		if timePoints[i].Time.Equal(timePoints[j].Time) {
-			// If times are equal, prioritize end points before start points
-			return !timePoints[i].IsStart && timePoints[j].IsStart
+		if !timePoints[i].Time.Equal(timePoints[j].Time) {
+			return timePoints[i].Time.Before(timePoints[j].Time)
 		}
-		return timePoints[i].Time.Before(timePoints[j].Time)
+		// If times are equal, prioritize end points before start points
+		if timePoints[i].IsStart != timePoints[j].IsStart {
+			return !timePoints[i].IsStart
+		}
+		// Then by therapist so equal points are always applied in the same order
+		return timePoints[i].TherapistInfo.Therapist.ID < timePoints[j].TherapistInfo.Therapist.ID
 	})
 
 	// Step 3: Sweep through time points
@@ -355,6 +854,8 @@ func applyLineSweepAlgorithm(
 				therapistInfos = append(therapistInfos, schedule.TherapistInfo{
 					TherapistID:     t.Therapist.ID,
 					Name:            t.Therapist.Name,
+					Bio:             t.Therapist.Bio,
+					PhotoURL:        t.Therapist.PhotoURL,
 					Specializations: t.Therapist.Specializations,
 					SpeaksEnglish:   t.Therapist.SpeaksEnglish,
 					TimeSlotID:      t.TimeSlotID,
@@ -369,9 +870,12 @@ func applyLineSweepAlgorithm(
 			duration := int(point.Time.Sub(lastTime).Minutes())
 			if duration >= int(timeRangeMinimumDurationMinutes) {
 
-				// Sort therapists by name
+				// Sort therapists by name, then ID for therapists sharing a name
 				sort.Slice(therapistInfos, func(i, j int) bool {
-					return therapistInfos[i].Name < therapistInfos[j].Name
+					if therapistInfos[i].Name != therapistInfos[j].Name {
+						return therapistInfos[i].Name < therapistInfos[j].Name
+					}
+					return therapistInfos[i].TherapistID < therapistInfos[j].TherapistID
 				})
 
 				result = append(result, schedule.AvailableTimeRange{
@@ -396,13 +900,69 @@ func applyLineSweepAlgorithm(
 	return result
 }
 
+// mergeAdjacentRanges is a post-pass over the line sweep result joining each
+// range into the previous one when both list the same therapists and the gap
+// between them is shorter than maxGap. The merged range spans the gap, and each
+// therapist's availability and candidate starts are extended to cover both.
+func mergeAdjacentRanges(ranges []schedule.AvailableTimeRange, maxGap domain.DurationMinutes) []schedule.AvailableTimeRange {
+	if len(ranges) < 2 {
+		return ranges
+	}
+
+	merged := []schedule.AvailableTimeRange{ranges[0]}
+	for _, next := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		gap := next.From.Sub(last.To)
+		if gap < 0 || gap >= time.Duration(maxGap)*time.Minute || !sameTherapists(last.Therapists, next.Therapists) {
+			merged = append(merged, next)
+			continue
+		}
+
+		// Copy the therapists so the input ranges are left untouched
+		therapists := make([]schedule.TherapistInfo, len(last.Therapists))
+		for i, info := range last.Therapists {
+			nextInfo := next.Therapists[i]
+			if nextInfo.AvailabilityRange.To.After(info.AvailabilityRange.To) {
+				info.AvailabilityRange.To = nextInfo.AvailabilityRange.To
+			}
+			if len(nextInfo.CandidateStarts) > 0 {
+				info.CandidateStarts = append(append([]domain.UTCTimestamp{}, info.CandidateStarts...), nextInfo.CandidateStarts...)
+			}
+			therapists[i] = info
+		}
+
+		last.To = next.To
+		last.Duration = domain.DurationMinutes(last.To.Sub(last.From).Minutes())
+		last.Therapists = therapists
+	}
+	return merged
+}
+
+// sameTherapists reports whether both ranges list the same therapists. The
+// sweep sorts every range's therapists the same way, so order is comparable.
+func sameTherapists(a, b []schedule.TherapistInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].TherapistID != b[i].TherapistID {
+			return false
+		}
+	}
+	return true
+}
+
+// findInterBookingAvailabilities returns the parts of the slot left free by the
+// bookings and blocks. Bookings are padded with the after-session break,
+// blocks are taken as they are.
 func findInterBookingAvailabilities(
 	slot timeRange,
 	afterSessionBreakTime domain.AfterSessionBreakTimeMinutes,
 	bookings []timeRange,
+	blocks []timeRange,
 	timeRangeMinimumDurationMinutes domain.DurationMinutes,
 ) []schedule.AvailableTimeRange {
-	if len(bookings) == 0 {
+	if len(bookings) == 0 && len(blocks) == 0 {
 		return []schedule.AvailableTimeRange{
 			{
 				From: slot.start,
@@ -419,23 +979,24 @@ func findInterBookingAvailabilities(
 		})
 	}
 
-	sortedBufferedBookings := sortTimeRangesByStartTime(bufferedBookings)
+	busyRanges := sortTimeRangesByStartTime(append(bufferedBookings, blocks...))
 	lastEndTime := slot.start
 	availableRanges := []schedule.AvailableTimeRange{}
 
-	for _, booking := range sortedBufferedBookings {
-		if lastEndTime.Before(booking.start) {
-			duration := int(booking.start.Sub(lastEndTime).Minutes())
-			if duration < int(timeRangeMinimumDurationMinutes) {
-				continue
+	for _, busy := range busyRanges {
+		if lastEndTime.Before(busy.start) {
+			duration := int(busy.start.Sub(lastEndTime).Minutes())
+			if duration >= int(timeRangeMinimumDurationMinutes) {
+				availableRanges = append(availableRanges, schedule.AvailableTimeRange{
+					From: lastEndTime,
+					To:   busy.start,
+				})
 			}
-
-			availableRanges = append(availableRanges, schedule.AvailableTimeRange{
-				From: lastEndTime,
-				To:   booking.start,
-			})
 		}
-		lastEndTime = booking.end
+		// Busy ranges can overlap, never move back
+		if busy.end.After(lastEndTime) {
+			lastEndTime = busy.end
+		}
 	}
 
 	// If there is a remaining time after the last booking, add it as an available range
@@ -454,6 +1015,51 @@ func findInterBookingAvailabilities(
 	return availableRanges
 }
 
+// findFullyBookedRanges returns the periods where the number of concurrent
+// bookings reaches the slot's capacity. With a capacity of one every booking
+// fills the slot on its own.
+func findFullyBookedRanges(bookings []timeRange, capacity int) []timeRange {
+	if capacity <= 1 {
+		return bookings
+	}
+
+	type edge struct {
+		time  domain.UTCTimestamp
+		delta int
+	}
+	edges := make([]edge, 0, len(bookings)*2)
+	for _, booking := range bookings {
+		edges = append(edges, edge{time: booking.start, delta: 1}, edge{time: booking.end, delta: -1})
+	}
+	// Ends come before starts at the same instant, back to back bookings never overlap
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].time.Equal(edges[j].time) {
+			return edges[i].delta < edges[j].delta
+		}
+		return edges[i].time.Before(edges[j].time)
+	})
+
+	fullRanges := []timeRange{}
+	active := 0
+	var fullSince domain.UTCTimestamp
+	for _, e := range edges {
+		wasFull := active >= capacity
+		active += e.delta
+		isFull := active >= capacity
+		if !wasFull && isFull {
+			fullSince = e.time
+		} else if wasFull && !isFull {
+			// A seat freed and retaken at the same instant extends the last range
+			if n := len(fullRanges); n > 0 && fullRanges[n-1].end.Equal(fullSince) {
+				fullRanges[n-1].end = e.time
+				continue
+			}
+			fullRanges = append(fullRanges, timeRange{start: fullSince, end: e.time})
+		}
+	}
+	return fullRanges
+}
+
 func sortTimeRangesByStartTime(bookings []timeRange) []timeRange {
 	sort.Slice(bookings, func(i, j int) bool {
 		return bookings[i].start.Before(bookings[j].start)
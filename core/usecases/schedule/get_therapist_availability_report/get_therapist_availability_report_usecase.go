@@ -0,0 +1,90 @@
+package get_therapist_availability_report
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+var ErrSpecializationTagIsRequired = errors.New("specialization tag is required")
+var ErrInvalidLanguage = errors.New("language must be a two letter ISO 639-1 code")
+
+type Input struct {
+	ClinicID          domain.ClinicID
+	SpecializationTag string
+	Language          domain.LanguageCode // Optional, empty matches any language
+	From              time.Time
+	To                time.Time
+}
+
+// TherapistAvailability is a matching therapist and the minutes they have
+// free in the window
+type TherapistAvailability struct {
+	Therapist        *therapist.Therapist `json:"therapist"`
+	AvailableMinutes int                  `json:"availableMinutes"`
+}
+
+type Usecase struct {
+	therapistRepo      ports.TherapistRepository
+	getScheduleUsecase get_schedule.Usecase
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository, getScheduleUsecase get_schedule.Usecase) *Usecase {
+	return &Usecase{
+		therapistRepo:      therapistRepo,
+		getScheduleUsecase: getScheduleUsecase,
+	}
+}
+
+// Execute lists the therapists with the specialization who speak the
+// language, most available first. Each therapist's minutes come from their
+// own schedule over the window, which defaults to the schedule's window.
+func (u *Usecase) Execute(input Input) ([]TherapistAvailability, error) {
+	tag := strings.TrimSpace(input.SpecializationTag)
+	if tag == "" {
+		return nil, ErrSpecializationTagIsRequired
+	}
+	if input.Language != "" && !input.Language.IsValid() {
+		return nil, ErrInvalidLanguage
+	}
+	if !input.From.IsZero() && !input.To.IsZero() && input.To.Before(input.From) {
+		return nil, get_schedule.ErrInvalidDateRange
+	}
+
+	therapists, err := u.therapistRepo.FindBySpecializationAndLanguage(input.ClinicID.OrDefault(), tag, input.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]TherapistAvailability, 0, len(therapists))
+	for _, match := range therapists {
+		ranges, err := u.getScheduleUsecase.Execute(get_schedule.Input{
+			TherapistIDs: []domain.TherapistID{match.ID},
+			StartDate:    input.From,
+			EndDate:      input.To,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		minutes := 0
+		for _, availableRange := range ranges {
+			minutes += int(availableRange.To.Sub(availableRange.From).Minutes())
+		}
+		report = append(report, TherapistAvailability{
+			Therapist:        match,
+			AvailableMinutes: minutes,
+		})
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		return report[i].AvailableMinutes > report[j].AvailableMinutes
+	})
+	return report, nil
+}
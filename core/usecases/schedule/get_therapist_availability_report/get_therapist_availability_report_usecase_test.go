@@ -0,0 +1,111 @@
+package get_therapist_availability_report
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+func TestGetTherapistAvailabilityReport(t *testing.T) {
+	anxiety := specialization.Specialization{ID: "specialization_1", Name: "anxiety"}
+	newTherapist := func(id domain.TherapistID, languages ...domain.LanguageCode) *therapist.Therapist {
+		return &therapist.Therapist{
+			ID:              id,
+			Languages:       languages,
+			Specializations: []specialization.Specialization{anxiety},
+		}
+	}
+	busy := newTherapist("therapist_busy", domain.LanguageCodeArabic)
+	free := newTherapist("therapist_free", domain.LanguageCodeArabic)
+	englishOnly := newTherapist("therapist_english", domain.LanguageCodeEnglish)
+
+	// A day far enough ahead that advance notice never applies
+	day := time.Now().UTC().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+	slots := []*timeslot.TimeSlot{}
+	for _, entry := range []*therapist.Therapist{busy, free, englishOnly} {
+		slots = append(slots, &timeslot.TimeSlot{
+			ID:          domain.TimeSlotID("slot_" + entry.ID),
+			TherapistID: entry.ID,
+			IsActive:    true,
+			DayOfWeek:   timeslot.MapToDayOfWeek(day.Weekday()),
+			Start:       "09:00",
+			Duration:    4 * 60, // 09:00 - 13:00
+		})
+	}
+
+	// The busy therapist is booked from 09:00 to 12:00
+	bookings := []*booking.Booking{}
+	for hour := 9; hour < 12; hour++ {
+		bookings = append(bookings, &booking.Booking{
+			ID:          domain.BookingID(fmt.Sprintf("booking_%d", hour)),
+			TimeSlotID:  domain.TimeSlotID("slot_" + busy.ID),
+			TherapistID: busy.ID,
+			State:       booking.BookingStateConfirmed,
+			StartTime:   domain.UTCTimestamp(day.Add(time.Duration(hour) * time.Hour)),
+			Duration:    60,
+		})
+	}
+
+	therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{busy, free, englishOnly}}
+	getSchedule := get_schedule.NewUsecase(
+		therapistRepo,
+		&fakes.TimeSlotRepo{Slots: slots},
+		&fakes.BookingRepo{Bookings: bookings},
+		nil,
+		nil,
+		15,
+		nil,
+		0,
+	)
+	usecase := NewUsecase(therapistRepo, *getSchedule)
+
+	t.Run("reports the free minutes of each matching therapist", func(t *testing.T) {
+		report, err := usecase.Execute(Input{
+			SpecializationTag: "anxiety",
+			Language:          domain.LanguageCodeArabic,
+			From:              day,
+			To:                day,
+		})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if len(report) != 2 {
+			t.Fatalf("expected 2 therapists speaking arabic, got %d", len(report))
+		}
+
+		expected := []struct {
+			id      domain.TherapistID
+			minutes int
+		}{
+			{free.ID, 240},
+			{busy.ID, 60},
+		}
+		for i, want := range expected {
+			if report[i].Therapist.ID != want.id || report[i].AvailableMinutes != want.minutes {
+				t.Errorf("expected %s with %d minutes at %d, got %s with %d",
+					want.id, want.minutes, i, report[i].Therapist.ID, report[i].AvailableMinutes)
+			}
+		}
+	})
+
+	t.Run("requires a specialization tag", func(t *testing.T) {
+		if _, err := usecase.Execute(Input{From: day, To: day}); err != ErrSpecializationTagIsRequired {
+			t.Errorf("expected %v, got %v", ErrSpecializationTagIsRequired, err)
+		}
+	})
+
+	t.Run("rejects an invalid language", func(t *testing.T) {
+		_, err := usecase.Execute(Input{SpecializationTag: "anxiety", Language: "arabic", From: day, To: day})
+		if err != ErrInvalidLanguage {
+			t.Errorf("expected %v, got %v", ErrInvalidLanguage, err)
+		}
+	})
+}
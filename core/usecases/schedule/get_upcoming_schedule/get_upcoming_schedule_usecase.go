@@ -0,0 +1,112 @@
+package get_upcoming_schedule
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/schedule"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+// DefaultDays is the window used when no number of days is requested
+const DefaultDays = 7
+
+var ErrInvalidDays = errors.New("days must be a positive number")
+
+type Input struct {
+	TherapistID    domain.TherapistID
+	Days           int // Zero uses DefaultDays, more than the maximum is capped
+	TimezoneOffset domain.TimezoneOffset
+}
+
+// UpcomingRange is an available range with its bounds rendered in the
+// requested timezone
+type UpcomingRange struct {
+	schedule.AvailableTimeRange
+	LocalFrom string `json:"localFrom"`
+	LocalTo   string `json:"localTo"`
+}
+
+type Usecase struct {
+	therapistRepo      ports.TherapistRepository
+	getScheduleUsecase get_schedule.Usecase
+	maxDays            int
+	now                func() time.Time
+}
+
+func NewUsecase(
+	therapistRepo ports.TherapistRepository,
+	getScheduleUsecase get_schedule.Usecase,
+	maxDays int,
+) *Usecase {
+	return &Usecase{
+		therapistRepo:      therapistRepo,
+		getScheduleUsecase: getScheduleUsecase,
+		maxDays:            maxDays,
+		now:                time.Now,
+	}
+}
+
+// Execute returns the therapist's availability from now through now+days.
+// Ranges are cut to the window, since the schedule renders whole days.
+func (u *Usecase) Execute(input Input) ([]UpcomingRange, error) {
+	if input.TherapistID == "" {
+		return nil, common.ErrTherapistIDIsRequired
+	}
+	if input.Days < 0 {
+		return nil, ErrInvalidDays
+	}
+
+	days := input.Days
+	if days == 0 {
+		days = DefaultDays
+	}
+	if u.maxDays > 0 && days > u.maxDays {
+		days = u.maxDays
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	windowStart := u.now().UTC()
+	windowEnd := windowStart.AddDate(0, 0, days)
+	ranges, err := u.getScheduleUsecase.Execute(get_schedule.Input{
+		TherapistIDs: []domain.TherapistID{input.TherapistID},
+		StartDate:    windowStart,
+		EndDate:      windowEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zone := time.FixedZone("", int(input.TimezoneOffset)*60)
+	upcoming := []UpcomingRange{}
+	for _, availableRange := range ranges {
+		from := availableRange.From.Time()
+		to := availableRange.To.Time()
+		if !to.After(windowStart) || !from.Before(windowEnd) {
+			continue
+		}
+		if from.Before(windowStart) {
+			from = windowStart
+		}
+		if to.After(windowEnd) {
+			to = windowEnd
+		}
+
+		availableRange.From = domain.UTCTimestamp(from)
+		availableRange.To = domain.UTCTimestamp(to)
+		availableRange.Duration = domain.DurationMinutes(to.Sub(from).Minutes())
+		upcoming = append(upcoming, UpcomingRange{
+			AvailableTimeRange: availableRange,
+			LocalFrom:          from.In(zone).Format(time.RFC3339),
+			LocalTo:            to.In(zone).Format(time.RFC3339),
+		})
+	}
+
+	return upcoming, nil
+}
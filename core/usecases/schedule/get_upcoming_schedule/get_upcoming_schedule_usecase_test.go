@@ -0,0 +1,97 @@
+package get_upcoming_schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+)
+
+func TestGetUpcomingSchedule(t *testing.T) {
+	available := &therapist.Therapist{ID: "therapist_1"}
+
+	// A two hour slot every day, so each day of the window has a range
+	slots := []*timeslot.TimeSlot{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		slots = append(slots, &timeslot.TimeSlot{
+			ID:          domain.TimeSlotID("slot_" + day.String()),
+			TherapistID: available.ID,
+			IsActive:    true,
+			DayOfWeek:   timeslot.MapToDayOfWeek(day),
+			Start:       "09:00",
+			Duration:    120,
+		})
+	}
+
+	therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{available}}
+	getSchedule := get_schedule.NewUsecase(therapistRepo, &fakes.TimeSlotRepo{Slots: slots}, &fakes.BookingRepo{}, &fakes.AdhocBookingRepo{}, nil, 15, nil, 0)
+	usecase := NewUsecase(therapistRepo, *getSchedule, 5)
+
+	assertWithin := func(t *testing.T, ranges []UpcomingRange, days int) {
+		t.Helper()
+		if len(ranges) == 0 {
+			t.Fatal("expected available ranges, got none")
+		}
+		windowStart := time.Now().UTC().Add(-time.Minute)
+		windowEnd := time.Now().UTC().AddDate(0, 0, days)
+		for _, r := range ranges {
+			if r.From.Time().Before(windowStart) || r.To.Time().After(windowEnd) {
+				t.Errorf("expected range %s - %s within %d days", r.From.Time().Format(time.RFC3339), r.To.Time().Format(time.RFC3339), days)
+			}
+		}
+	}
+
+	t.Run("returns ranges within the window", func(t *testing.T) {
+		ranges, err := usecase.Execute(Input{TherapistID: available.ID, Days: 2})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		assertWithin(t, ranges, 2)
+		if len(ranges) > 3 {
+			t.Errorf("expected at most 3 daily ranges in 2 days, got %d", len(ranges))
+		}
+	})
+
+	t.Run("caps days at the maximum", func(t *testing.T) {
+		ranges, err := usecase.Execute(Input{TherapistID: available.ID, Days: 30})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		assertWithin(t, ranges, 5)
+
+		last := ranges[len(ranges)-1].From.Time()
+		if last.Before(time.Now().UTC().AddDate(0, 0, 3)) {
+			t.Errorf("expected ranges up to the 5 day cap, last one starts %s", last.Format(time.RFC3339))
+		}
+	})
+
+	t.Run("renders local bounds", func(t *testing.T) {
+		ranges, err := usecase.Execute(Input{TherapistID: available.ID, Days: 2, TimezoneOffset: 180})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		for _, r := range ranges {
+			want := r.From.Time().In(time.FixedZone("", 180*60)).Format(time.RFC3339)
+			if r.LocalFrom != want {
+				t.Errorf("expected local start %s, got %s", want, r.LocalFrom)
+			}
+		}
+	})
+
+	t.Run("rejects negative days", func(t *testing.T) {
+		if _, err := usecase.Execute(Input{TherapistID: available.ID, Days: -1}); err != ErrInvalidDays {
+			t.Errorf("expected %v, got %v", ErrInvalidDays, err)
+		}
+	})
+
+	t.Run("unknown therapist", func(t *testing.T) {
+		if _, err := usecase.Execute(Input{TherapistID: "therapist_missing"}); err != common.ErrTherapistNotFound {
+			t.Errorf("expected %v, got %v", common.ErrTherapistNotFound, err)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package get_session_context
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/session/get_session"
+)
+
+// Output is a session together with the booking it was confirmed from and
+// the client attending it
+type Output struct {
+	Session *domain.Session        `json:"session"`
+	Booking *ports.BookingResponse `json:"booking"`
+	Client  *client.Client         `json:"client"`
+}
+
+// Usecase struct with required dependencies
+type Usecase struct {
+	getSessionUsecase get_session.Usecase
+	bookingRepo       ports.BookingRepository
+	adhocBookingRepo  ports.AdhocBookingRepository
+	clientRepo        ports.ClientRepository
+}
+
+// NewUsecase creates a new instance of the get session context usecase
+func NewUsecase(
+	getSessionUsecase get_session.Usecase,
+	bookingRepo ports.BookingRepository,
+	adhocBookingRepo ports.AdhocBookingRepository,
+	clientRepo ports.ClientRepository,
+) *Usecase {
+	return &Usecase{
+		getSessionUsecase: getSessionUsecase,
+		bookingRepo:       bookingRepo,
+		adhocBookingRepo:  adhocBookingRepo,
+		clientRepo:        clientRepo,
+	}
+}
+
+// Execute retrieves the session with the given ID, its booking and its client
+func (u *Usecase) Execute(id domain.SessionID) (*Output, error) {
+	session, err := u.getSessionUsecase.Execute(id)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := u.getBooking(session)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := u.clientRepo.FindByIDs([]domain.ClientID{session.ClientID})
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		return nil, common.ErrClientNotFound
+	}
+
+	return &Output{
+		Session: session,
+		Booking: booking,
+		Client:  clients[0],
+	}, nil
+}
+
+// getBooking returns the regular or adhoc booking the session was created from
+func (u *Usecase) getBooking(session *domain.Session) (*ports.BookingResponse, error) {
+	if session.RegularBookingID != "" {
+		regularBooking, err := u.bookingRepo.GetByID(session.RegularBookingID)
+		if err != nil || regularBooking == nil {
+			return nil, common.ErrBookingNotFound
+		}
+		return &ports.BookingResponse{
+			RegularBookingID:     regularBooking.ID,
+			TherapistID:          regularBooking.TherapistID,
+			ClientID:             regularBooking.ClientID,
+			State:                regularBooking.State,
+			StartTime:            regularBooking.StartTime,
+			Duration:             regularBooking.Duration,
+			ClientTimezoneOffset: regularBooking.ClientTimezoneOffset,
+		}, nil
+	}
+
+	adhocBooking, err := u.adhocBookingRepo.GetByID(session.AdhocBookingID)
+	if err != nil || adhocBooking == nil {
+		return nil, common.ErrBookingNotFound
+	}
+	return &ports.BookingResponse{
+		AdhocBookingID:       adhocBooking.ID,
+		TherapistID:          adhocBooking.TherapistID,
+		ClientID:             adhocBooking.ClientID,
+		State:                adhocBooking.State,
+		StartTime:            adhocBooking.StartTime,
+		Duration:             adhocBooking.Duration,
+		ClientTimezoneOffset: adhocBooking.ClientTimezoneOffset,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package get_session_context
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/client"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/session/get_session"
+)
+
+func TestGetSessionContext(t *testing.T) {
+	sessionRepo := &fakes.SessionRepo{Sessions: []*domain.Session{
+		{ID: "session_1", RegularBookingID: "booking_1", TherapistID: "therapist_1", ClientID: "client_1"},
+		{ID: "session_2", AdhocBookingID: "adhoc_1", TherapistID: "therapist_1", ClientID: "client_2"},
+	}}
+	bookingRepo := &fakes.BookingRepo{Bookings: []*booking.Booking{
+		{ID: "booking_1", TherapistID: "therapist_1", ClientID: "client_1", State: booking.BookingStateConfirmed},
+	}}
+	adhocBookingRepo := &fakes.AdhocBookingRepo{Bookings: []*booking.AdhocBooking{
+		{ID: "adhoc_1", TherapistID: "therapist_1", ClientID: "client_2", State: booking.BookingStateConfirmed},
+	}}
+	clientRepo := &fakes.ClientRepo{Clients: []*client.Client{
+		{ID: "client_1", Name: "Regular Client"},
+		{ID: "client_2", Name: "Adhoc Client"},
+	}}
+	usecase := NewUsecase(*get_session.NewUsecase(sessionRepo), bookingRepo, adhocBookingRepo, clientRepo)
+
+	t.Run("returns the session with its regular booking and client", func(t *testing.T) {
+		output, err := usecase.Execute("session_1")
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if output.Session == nil || output.Booking == nil || output.Client == nil {
+			t.Fatalf("expected session, booking and client, got %+v", output)
+		}
+		if output.Booking.RegularBookingID != output.Session.RegularBookingID {
+			t.Errorf("expected booking %s, got %s", output.Session.RegularBookingID, output.Booking.RegularBookingID)
+		}
+		if output.Client.ID != output.Session.ClientID || output.Booking.ClientID != output.Session.ClientID {
+			t.Errorf("expected client %s, got client %s and booking client %s", output.Session.ClientID, output.Client.ID, output.Booking.ClientID)
+		}
+	})
+
+	t.Run("returns the session with its adhoc booking and client", func(t *testing.T) {
+		output, err := usecase.Execute("session_2")
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if output.Booking.AdhocBookingID != output.Session.AdhocBookingID {
+			t.Errorf("expected adhoc booking %s, got %s", output.Session.AdhocBookingID, output.Booking.AdhocBookingID)
+		}
+		if output.Client.ID != "client_2" {
+			t.Errorf("expected client_2, got %s", output.Client.ID)
+		}
+	})
+
+	t.Run("missing session", func(t *testing.T) {
+		_, err := usecase.Execute("session_missing")
+		if err != common.ErrSessionNotFound {
+			t.Fatalf("expected %v, got %v", common.ErrSessionNotFound, err)
+		}
+	})
+}
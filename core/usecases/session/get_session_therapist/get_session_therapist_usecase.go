@@ -0,0 +1,55 @@
+package get_session_therapist
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/specialization"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/session/get_session"
+)
+
+// TherapistSummary is the client-facing view of the therapist running a session
+type TherapistSummary struct {
+	ID              domain.TherapistID              `json:"id"`
+	Name            string                          `json:"name"`
+	Bio             string                          `json:"bio"`
+	PhotoURL        string                          `json:"photoUrl"`
+	SpeaksEnglish   bool                            `json:"speaksEnglish"`
+	Specializations []specialization.Specialization `json:"specializations"`
+}
+
+// Usecase struct with required dependencies
+type Usecase struct {
+	getSessionUsecase get_session.Usecase
+	therapistRepo     ports.TherapistRepository
+}
+
+// NewUsecase creates a new instance of the get session therapist usecase
+func NewUsecase(getSessionUsecase get_session.Usecase, therapistRepo ports.TherapistRepository) *Usecase {
+	return &Usecase{
+		getSessionUsecase: getSessionUsecase,
+		therapistRepo:     therapistRepo,
+	}
+}
+
+// Execute retrieves the therapist of the session with the given ID
+func (u *Usecase) Execute(id domain.SessionID) (*TherapistSummary, error) {
+	session, err := u.getSessionUsecase.Execute(id)
+	if err != nil {
+		return nil, err
+	}
+
+	therapist, err := u.therapistRepo.GetByID(session.TherapistID)
+	if err != nil || therapist == nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	return &TherapistSummary{
+		ID:              therapist.ID,
+		Name:            therapist.Name,
+		Bio:             therapist.Bio,
+		PhotoURL:        therapist.PhotoURL,
+		SpeaksEnglish:   therapist.SpeaksEnglish,
+		Specializations: therapist.Specializations,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package get_session_therapist
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+	"github.com/mishkahtherapy/brain/core/usecases/session/get_session"
+)
+
+func TestGetSessionTherapist(t *testing.T) {
+	sessionRepo := &fakes.SessionRepo{Sessions: []*domain.Session{
+		{ID: "session_1", TherapistID: "therapist_2"},
+	}}
+	therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{
+		{ID: "therapist_1", Name: "Dr. Other"},
+		{ID: "therapist_2", Name: "Dr. Session", Email: "session@example.com"},
+	}}
+	usecase := NewUsecase(*get_session.NewUsecase(sessionRepo), therapistRepo)
+
+	t.Run("returns the session's therapist", func(t *testing.T) {
+		summary, err := usecase.Execute("session_1")
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if summary.ID != "therapist_2" || summary.Name != "Dr. Session" {
+			t.Errorf("expected therapist_2 (Dr. Session), got %s (%s)", summary.ID, summary.Name)
+		}
+	})
+
+	t.Run("missing session", func(t *testing.T) {
+		_, err := usecase.Execute("session_missing")
+		if err != common.ErrSessionNotFound {
+			t.Fatalf("expected %v, got %v", common.ErrSessionNotFound, err)
+		}
+	})
+}
@@ -12,6 +12,12 @@ import (
 type Input struct {
 	StartDate time.Time `json:"startDate"`
 	EndDate   time.Time `json:"endDate"`
+
+	// Optional window on the sessions' last modification
+	UpdatedFrom time.Time `json:"updatedFrom"`
+	UpdatedTo   time.Time `json:"updatedTo"`
+	// Clinic the sessions' therapists belong to, the default clinic when empty
+	ClinicID domain.ClinicID `json:"-"`
 }
 
 // Usecase struct with required dependencies
@@ -30,6 +36,9 @@ func (u *Usecase) Execute(input Input) ([]*domain.Session, error) {
 	if input.StartDate.After(input.EndDate) {
 		return nil, common.ErrInvalidDateRange
 	}
+	if !input.UpdatedFrom.IsZero() && !input.UpdatedTo.IsZero() && input.UpdatedFrom.After(input.UpdatedTo) {
+		return nil, common.ErrInvalidDateRange
+	}
 
 	// Set default time range if not provided
 	// If zero time, use a large range (past 1 year to future 1 year)
@@ -41,7 +50,7 @@ func (u *Usecase) Execute(input Input) ([]*domain.Session, error) {
 	}
 
 	// Retrieve sessions from repository
-	sessions, err := u.sessionRepo.ListSessionsAdmin(input.StartDate, input.EndDate)
+	sessions, err := u.sessionRepo.ListSessionsAdmin(input.ClinicID.OrDefault(), input.StartDate, input.EndDate, input.UpdatedFrom, input.UpdatedTo)
 	if err != nil {
 		return nil, common.ErrFailedToListSessions
 	}
@@ -8,7 +8,8 @@ import (
 
 // Input struct defines parameters for listing sessions by therapist
 type Input struct {
-	TherapistID domain.TherapistID `json:"therapistId"`
+	TherapistID domain.TherapistID    `json:"therapistId"`
+	States      []domain.SessionState `json:"states"` // Optional, empty lists every state
 }
 
 // Usecase struct with required dependencies
@@ -21,15 +22,21 @@ func NewUsecase(sessionRepo ports.SessionRepository) *Usecase {
 	return &Usecase{sessionRepo: sessionRepo}
 }
 
-// Execute retrieves all sessions for a specific therapist
+// Execute retrieves the sessions of a specific therapist, optionally only
+// those in the given states
 func (u *Usecase) Execute(input Input) ([]*domain.Session, error) {
 	// Validate input
 	if input.TherapistID == "" {
 		return nil, common.ErrTherapistIDIsRequired
 	}
+	for _, state := range input.States {
+		if !state.IsValid() {
+			return nil, common.ErrInvalidSessionState
+		}
+	}
 
 	// Retrieve sessions from repository
-	sessions, err := u.sessionRepo.ListSessionsByTherapist(input.TherapistID)
+	sessions, err := u.sessionRepo.ListSessionsByTherapist(input.TherapistID, input.States)
 	if err != nil {
 		return nil, common.ErrFailedToListSessions
 	}
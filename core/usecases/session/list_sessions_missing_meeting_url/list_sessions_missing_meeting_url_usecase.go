@@ -0,0 +1,51 @@
+package list_sessions_missing_meeting_url
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// defaultWindow is used when no end of the window is given
+const defaultWindow = 7 * 24 * time.Hour
+
+// Input struct defines the window of session start times to check
+type Input struct {
+	From     time.Time       `json:"from"`
+	To       time.Time       `json:"to"`
+	ClinicID domain.ClinicID `json:"-"` // Taken from the request
+}
+
+// Usecase struct with required dependencies
+type Usecase struct {
+	sessionRepo ports.SessionRepository
+}
+
+// NewUsecase creates a new instance of the list sessions missing meeting URL usecase
+func NewUsecase(sessionRepo ports.SessionRepository) *Usecase {
+	return &Usecase{sessionRepo: sessionRepo}
+}
+
+// Execute retrieves planned sessions in the window that don't have a meeting URL yet
+func (u *Usecase) Execute(input Input) ([]*domain.Session, error) {
+	// Default to the upcoming week
+	if input.From.IsZero() {
+		input.From = time.Now().UTC()
+	}
+	if input.To.IsZero() {
+		input.To = input.From.Add(defaultWindow)
+	}
+
+	if input.From.After(input.To) {
+		return nil, common.ErrInvalidDateRange
+	}
+
+	sessions, err := u.sessionRepo.ListMissingMeetingURL(input.ClinicID.OrDefault(), input.From, input.To)
+	if err != nil {
+		return nil, common.ErrFailedToListSessions
+	}
+
+	return sessions, nil
+}
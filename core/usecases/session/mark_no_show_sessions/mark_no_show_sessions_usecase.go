@@ -0,0 +1,59 @@
+package mark_no_show_sessions
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type Input struct {
+	Now time.Time // Defaults to the current time
+}
+
+type Output struct {
+	Marked int `json:"marked"`
+}
+
+type Usecase struct {
+	sessionRepo ports.SessionRepository
+	gracePeriod time.Duration
+}
+
+func NewUsecase(sessionRepo ports.SessionRepository, gracePeriod time.Duration) *Usecase {
+	return &Usecase{
+		sessionRepo: sessionRepo,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// Execute moves planned sessions to no_show once the grace period after
+// their scheduled end has passed. Sessions already moved to another state
+// are left alone.
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	cutoff := now.Add(-u.gracePeriod)
+
+	// A session that started after the cutoff cannot have ended before it
+	sessions, err := u.sessionRepo.ListPlannedStartedBefore(cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	overdue := make([]domain.SessionID, 0, len(sessions))
+	for _, session := range sessions {
+		end := session.StartTime.Time().Add(time.Duration(session.Duration) * time.Minute)
+		if !end.After(cutoff) {
+			overdue = append(overdue, session.ID)
+		}
+	}
+
+	marked, err := u.sessionRepo.MarkNoShow(overdue, now)
+	if err != nil {
+		return nil, err
+	}
+	return &Output{Marked: marked}, nil
+}
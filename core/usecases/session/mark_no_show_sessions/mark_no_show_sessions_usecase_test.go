@@ -0,0 +1,46 @@
+package mark_no_show_sessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestMarkNoShowSessions(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	startedAt := func(ago time.Duration) domain.UTCTimestamp {
+		return domain.UTCTimestamp(now.Add(-ago))
+	}
+	repo := &fakes.SessionRepo{Sessions: []*domain.Session{
+		// Ended two hours ago, well past the grace period
+		{ID: "session_past", StartTime: startedAt(3 * time.Hour), Duration: 60, State: domain.SessionStatePlanned},
+		// Ended ten minutes ago, still within the grace period
+		{ID: "session_recent", StartTime: startedAt(70 * time.Minute), Duration: 60, State: domain.SessionStatePlanned},
+		// Already wrapped up before the worker ran
+		{ID: "session_done", StartTime: startedAt(3 * time.Hour), Duration: 60, State: domain.SessionStateDone},
+		{ID: "session_cancelled", StartTime: startedAt(3 * time.Hour), Duration: 60, State: domain.SessionStateCancelled},
+	}}
+	usecase := NewUsecase(repo, 30*time.Minute)
+
+	output, err := usecase.Execute(Input{Now: now})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if output.Marked != 1 {
+		t.Errorf("expected 1 session marked, got %d", output.Marked)
+	}
+
+	expected := map[domain.SessionID]domain.SessionState{
+		"session_past":      domain.SessionStateNoShow,
+		"session_recent":    domain.SessionStatePlanned,
+		"session_done":      domain.SessionStateDone,
+		"session_cancelled": domain.SessionStateCancelled,
+	}
+	for _, session := range repo.Sessions {
+		if session.State != expected[session.ID] {
+			t.Errorf("expected %s to be %s, got %s", session.ID, expected[session.ID], session.State)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package update_meeting_url
 
 import (
 	"net/url"
+	"strings"
 
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/ports"
@@ -16,12 +17,14 @@ type Input struct {
 
 // Usecase struct with required dependencies
 type Usecase struct {
-	sessionRepo ports.SessionRepository
+	sessionRepo  ports.SessionRepository
+	allowedHosts []string
 }
 
-// NewUsecase creates a new instance of the update meeting URL usecase
-func NewUsecase(sessionRepo ports.SessionRepository) *Usecase {
-	return &Usecase{sessionRepo: sessionRepo}
+// NewUsecase creates a new instance of the update meeting URL usecase.
+// Meeting URLs must point to one of allowedHosts or a subdomain of one.
+func NewUsecase(sessionRepo ports.SessionRepository, allowedHosts []string) *Usecase {
+	return &Usecase{sessionRepo: sessionRepo, allowedHosts: allowedHosts}
 }
 
 // Execute updates a session's meeting URL
@@ -35,8 +38,8 @@ func (u *Usecase) Execute(input Input) (*domain.Session, error) {
 	}
 
 	// Validate meeting URL format
-	if _, err := url.ParseRequestURI(input.MeetingURL); err != nil {
-		return nil, common.ErrInvalidMeetingURL
+	if err := u.validateMeetingURL(input.MeetingURL); err != nil {
+		return nil, err
 	}
 
 	// Get the current session
@@ -57,3 +60,26 @@ func (u *Usecase) Execute(input Input) (*domain.Session, error) {
 
 	return session, nil
 }
+
+// validateMeetingURL rejects anything but https links to an allowed host so
+// that phishing links can't be stored against a session
+func (u *Usecase) validateMeetingURL(meetingURL string) error {
+	parsed, err := url.ParseRequestURI(meetingURL)
+	if err != nil {
+		return common.ErrInvalidMeetingURL
+	}
+	if parsed.Scheme != "https" {
+		return common.ErrMeetingURLNotHTTPS
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host == "" {
+		return common.ErrInvalidMeetingURL
+	}
+	for _, allowed := range u.allowedHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return nil
+		}
+	}
+	return common.ErrMeetingHostNotAllowed
+}
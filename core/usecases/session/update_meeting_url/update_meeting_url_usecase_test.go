@@ -0,0 +1,58 @@
+package update_meeting_url
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestUpdateMeetingURL(t *testing.T) {
+	sessionID := domain.SessionID("session_1")
+	newUsecase := func() (*Usecase, *fakes.SessionRepo) {
+		repo := &fakes.SessionRepo{Sessions: []*domain.Session{
+			{ID: sessionID},
+		}}
+		return NewUsecase(repo, []string{"zoom.us", "meet.google.com"}), repo
+	}
+
+	t.Run("accepts an https zoom URL", func(t *testing.T) {
+		usecase, repo := newUsecase()
+		meetingURL := "https://us02web.zoom.us/j/123456789"
+
+		_, err := usecase.Execute(Input{SessionID: sessionID, MeetingURL: meetingURL})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if repo.Sessions[0].MeetingURL != meetingURL {
+			t.Errorf("expected meeting URL %s, got %s", meetingURL, repo.Sessions[0].MeetingURL)
+		}
+	})
+
+	t.Run("rejects an http URL", func(t *testing.T) {
+		usecase, repo := newUsecase()
+
+		_, err := usecase.Execute(Input{SessionID: sessionID, MeetingURL: "http://zoom.us/j/123456789"})
+		if err != common.ErrMeetingURLNotHTTPS {
+			t.Fatalf("expected %v, got %v", common.ErrMeetingURLNotHTTPS, err)
+		}
+		if repo.Sessions[0].MeetingURL != "" {
+			t.Errorf("expected meeting URL to stay empty, got %s", repo.Sessions[0].MeetingURL)
+		}
+	})
+
+	t.Run("rejects a host outside the allowlist", func(t *testing.T) {
+		usecase, _ := newUsecase()
+
+		for _, meetingURL := range []string{
+			"https://zoom.us.evil.example/j/123456789",
+			"https://notzoom.us/j/123456789",
+		} {
+			_, err := usecase.Execute(Input{SessionID: sessionID, MeetingURL: meetingURL})
+			if err != common.ErrMeetingHostNotAllowed {
+				t.Errorf("%s: expected %v, got %v", meetingURL, common.ErrMeetingHostNotAllowed, err)
+			}
+		}
+	})
+}
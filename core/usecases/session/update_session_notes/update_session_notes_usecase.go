@@ -14,12 +14,20 @@ type Input struct {
 
 // Usecase struct with required dependencies
 type Usecase struct {
-	sessionRepo ports.SessionRepository
+	sessionRepo    ports.SessionRepository
+	maxNotesBytes  int
+	overflowPolicy domain.NotesOverflowPolicy
 }
 
-// NewUsecase creates a new instance of the update session notes usecase
-func NewUsecase(sessionRepo ports.SessionRepository) *Usecase {
-	return &Usecase{sessionRepo: sessionRepo}
+// NewUsecase creates a new instance of the update session notes usecase.
+// Notes are kept within maxNotesBytes according to overflowPolicy, a zero
+// maxNotesBytes leaves them unbounded.
+func NewUsecase(sessionRepo ports.SessionRepository, maxNotesBytes int, overflowPolicy domain.NotesOverflowPolicy) *Usecase {
+	return &Usecase{
+		sessionRepo:    sessionRepo,
+		maxNotesBytes:  maxNotesBytes,
+		overflowPolicy: overflowPolicy,
+	}
 }
 
 // Execute updates a session's notes by appending the new note with a timestamp
@@ -39,7 +47,9 @@ func (u *Usecase) Execute(input Input) (*domain.Session, error) {
 	}
 
 	// Append the new note with timestamp
-	session.AppendNote(input.Notes)
+	if err := session.AppendNoteWithLimit(input.Notes, u.maxNotesBytes, u.overflowPolicy); err != nil {
+		return nil, err
+	}
 
 	// Persist the change
 	err = u.sessionRepo.UpdateSessionNotes(input.SessionID, session.Notes)
@@ -0,0 +1,66 @@
+package update_session_notes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestUpdateSessionNotes(t *testing.T) {
+	sessionID := domain.SessionID("session_1")
+	existingNotes := strings.Repeat("x", 40)
+	newUsecase := func(maxNotesBytes int, policy domain.NotesOverflowPolicy) (*Usecase, *fakes.SessionRepo) {
+		repo := &fakes.SessionRepo{Sessions: []*domain.Session{
+			{ID: sessionID, Notes: existingNotes},
+		}}
+		return NewUsecase(repo, maxNotesBytes, policy), repo
+	}
+
+	t.Run("rejects a note that would exceed the limit", func(t *testing.T) {
+		usecase, repo := newUsecase(64, domain.NotesOverflowReject)
+
+		_, err := usecase.Execute(Input{SessionID: sessionID, Notes: "a note that does not fit"})
+		if err != domain.ErrNotesTooLong {
+			t.Fatalf("expected %v, got %v", domain.ErrNotesTooLong, err)
+		}
+		if repo.NotesUpdates != 0 {
+			t.Errorf("expected notes not to be persisted, got %d updates", repo.NotesUpdates)
+		}
+		if repo.Sessions[0].Notes != existingNotes {
+			t.Errorf("expected stored notes to be unchanged, got %q", repo.Sessions[0].Notes)
+		}
+	})
+
+	t.Run("truncates the oldest notes when configured to", func(t *testing.T) {
+		usecase, repo := newUsecase(64, domain.NotesOverflowTruncateOldest)
+
+		session, err := usecase.Execute(Input{SessionID: sessionID, Notes: "a note that does not fit"})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		stored := repo.Sessions[0].Notes
+		if stored != session.Notes {
+			t.Errorf("expected returned notes to match stored notes")
+		}
+		if strings.Contains(stored, existingNotes) || !strings.HasSuffix(stored, ": a note that does not fit") {
+			t.Errorf("expected only the new note to remain, got %q", stored)
+		}
+		if len(stored) > 64 {
+			t.Errorf("expected notes within 64 bytes, got %d", len(stored))
+		}
+	})
+
+	t.Run("appends when within the limit", func(t *testing.T) {
+		usecase, repo := newUsecase(1024, domain.NotesOverflowReject)
+
+		_, err := usecase.Execute(Input{SessionID: sessionID, Notes: "short"})
+		if err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if !strings.HasPrefix(repo.Sessions[0].Notes, existingNotes+"\n\n") {
+			t.Errorf("expected previous notes to be kept, got %q", repo.Sessions[0].Notes)
+		}
+	})
+}
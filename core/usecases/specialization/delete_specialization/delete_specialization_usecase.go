@@ -0,0 +1,93 @@
+package delete_specialization
+
+import (
+	"errors"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// Domain-specific errors that are not common across usecases
+var (
+	ErrSpecializationInUse    = errors.New("specialization is offered by therapists, pass reassignTo to move them")
+	ErrReassignTargetNotFound = errors.New("reassign target specialization not found")
+	ErrCannotReassignToSelf   = errors.New("cannot reassign a specialization to itself")
+)
+
+type Input struct {
+	ClinicID domain.ClinicID         `json:"-"`
+	ID       domain.SpecializationID `json:"id"`
+	// ReassignTo optionally moves linked therapists to this specialization
+	// before deleting. Without it, a specialization in use is not deleted.
+	ReassignTo domain.SpecializationID `json:"reassignTo,omitempty"`
+}
+
+type Output struct {
+	DeletedID       domain.SpecializationID `json:"deletedId"`
+	MovedTherapists int                     `json:"movedTherapists"`
+}
+
+type Usecase struct {
+	specializationRepo ports.SpecializationRepository
+	unitOfWork         ports.UnitOfWork
+}
+
+func NewUsecase(specializationRepo ports.SpecializationRepository, unitOfWork ports.UnitOfWork) *Usecase {
+	return &Usecase{
+		specializationRepo: specializationRepo,
+		unitOfWork:         unitOfWork,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	specialization, err := u.specializationRepo.GetByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if specialization == nil {
+		return nil, common.ErrSpecializationNotFound
+	}
+	if specialization.ClinicID != input.ClinicID.OrDefault() {
+		return nil, common.ErrClinicMismatch
+	}
+
+	if input.ReassignTo == "" {
+		therapists, err := u.specializationRepo.ListTherapists(input.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(therapists) > 0 {
+			return nil, ErrSpecializationInUse
+		}
+	} else {
+		if input.ReassignTo == input.ID {
+			return nil, ErrCannotReassignToSelf
+		}
+		target, err := u.specializationRepo.GetByID(input.ReassignTo)
+		if err != nil {
+			return nil, err
+		}
+		// Therapists cannot be moved into another clinic's specialization
+		if target == nil || target.ClinicID != specialization.ClinicID {
+			return nil, ErrReassignTargetNotFound
+		}
+	}
+
+	output := &Output{DeletedID: input.ID}
+	err = u.unitOfWork.RunInTx(func(tx ports.SQLTx) error {
+		if input.ReassignTo != "" {
+			moved, err := u.specializationRepo.ReassignTherapistsTx(tx, input.ID, input.ReassignTo)
+			if err != nil {
+				return err
+			}
+			output.MovedTherapists = moved
+		}
+		return u.specializationRepo.DeleteTx(tx, input.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -1,6 +1,7 @@
 package get_all_specializations
 
 import (
+	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/specialization"
 	"github.com/mishkahtherapy/brain/core/ports"
 )
@@ -13,6 +14,6 @@ func NewUsecase(specializationRepo ports.SpecializationRepository) *Usecase {
 	return &Usecase{specializationRepo: specializationRepo}
 }
 
-func (u *Usecase) Execute() ([]*specialization.Specialization, error) {
-	return u.specializationRepo.GetAll()
+func (u *Usecase) Execute(clinicID domain.ClinicID) ([]*specialization.Specialization, error) {
+	return u.specializationRepo.GetAll(clinicID.OrDefault())
 }
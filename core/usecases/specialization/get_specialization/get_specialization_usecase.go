@@ -15,10 +15,15 @@ func NewUsecase(specializationRepo ports.SpecializationRepository) *Usecase {
 	return &Usecase{specializationRepo: specializationRepo}
 }
 
-func (u *Usecase) Execute(id domain.SpecializationID) (*specialization.Specialization, error) {
+// Execute returns the specialization, or nil when it does not exist. It
+// refuses specializations of other clinics.
+func (u *Usecase) Execute(clinicID domain.ClinicID, id domain.SpecializationID) (*specialization.Specialization, error) {
 	specialization, err := u.specializationRepo.GetByID(id)
 	if err != nil {
 		return nil, common.ErrSpecializationNotFound
 	}
+	if specialization != nil && specialization.ClinicID != clinicID.OrDefault() {
+		return nil, common.ErrClinicMismatch
+	}
 	return specialization, nil
 }
@@ -0,0 +1,20 @@
+package get_specialization_counts
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type Usecase struct {
+	specializationRepo ports.SpecializationRepository
+}
+
+func NewUsecase(specializationRepo ports.SpecializationRepository) *Usecase {
+	return &Usecase{specializationRepo: specializationRepo}
+}
+
+// Execute lists every specialization of the clinic with the number of
+// therapists offering it
+func (u *Usecase) Execute(clinicID domain.ClinicID) ([]*ports.SpecializationTherapistCount, error) {
+	return u.specializationRepo.GetAllWithTherapistCounts(clinicID.OrDefault())
+}
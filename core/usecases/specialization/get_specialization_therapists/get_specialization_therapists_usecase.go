@@ -0,0 +1,28 @@
+package get_specialization_therapists
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+type Usecase struct {
+	specializationRepo ports.SpecializationRepository
+}
+
+func NewUsecase(specializationRepo ports.SpecializationRepository) *Usecase {
+	return &Usecase{specializationRepo: specializationRepo}
+}
+
+// Execute lists the therapists offering the specialization. It is cheaper than
+// the schedule since availability is not computed.
+func (u *Usecase) Execute(id domain.SpecializationID) ([]*ports.SpecializationTherapist, error) {
+	specialization, err := u.specializationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if specialization == nil {
+		return nil, common.ErrSpecializationNotFound
+	}
+	return u.specializationRepo.ListTherapists(id)
+}
@@ -14,7 +14,8 @@ import (
 var ErrSpecializationAlreadyExists = errors.New("specialization already exists")
 
 type Input struct {
-	Name string `json:"name"`
+	ClinicID domain.ClinicID `json:"-"` // Taken from the request, not the body
+	Name     string          `json:"name"`
 }
 
 type Usecase struct {
@@ -30,7 +31,7 @@ func (u *Usecase) Execute(input Input) (*specialization.Specialization, error) {
 		return nil, common.ErrNameIsRequired
 	}
 
-	existingSpecialization, err := u.specializationRepo.GetByName(input.Name)
+	existingSpecialization, err := u.specializationRepo.GetByName(input.ClinicID.OrDefault(), input.Name)
 	if err != nil {
 		return nil, common.ErrFailedToGetSpecializations
 	}
@@ -42,6 +43,7 @@ func (u *Usecase) Execute(input Input) (*specialization.Specialization, error) {
 	specialization := &specialization.Specialization{
 		ID:        domain.NewSpecializationID(),
 		Name:      cleanUpName(input.Name),
+		ClinicID:  input.ClinicID.OrDefault(),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -1,10 +1,19 @@
 package get_all_therapists
 
 import (
+	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/therapist"
 	"github.com/mishkahtherapy/brain/core/ports"
 )
 
+// Input holds the optional list filters. An empty Specialization lists
+// therapists of every specialization.
+type Input struct {
+	ClinicID         domain.ClinicID
+	Specialization   string
+	MustSpeakEnglish bool
+}
+
 type Usecase struct {
 	therapistRepo ports.TherapistRepository
 }
@@ -13,6 +22,28 @@ func NewUsecase(therapistRepo ports.TherapistRepository) *Usecase {
 	return &Usecase{therapistRepo: therapistRepo}
 }
 
-func (u *Usecase) Execute() ([]*therapist.Therapist, error) {
-	return u.therapistRepo.List()
+func (u *Usecase) Execute(input Input) ([]*therapist.Therapist, error) {
+	if input.Specialization != "" {
+		var languageCode domain.LanguageCode
+		if input.MustSpeakEnglish {
+			languageCode = domain.LanguageCodeEnglish
+		}
+		return u.therapistRepo.FindBySpecializationAndLanguage(input.ClinicID.OrDefault(), input.Specialization, languageCode)
+	}
+
+	therapists, err := u.therapistRepo.List(input.ClinicID.OrDefault())
+	if err != nil {
+		return nil, err
+	}
+	if !input.MustSpeakEnglish {
+		return therapists, nil
+	}
+
+	englishSpeaking := make([]*therapist.Therapist, 0, len(therapists))
+	for _, t := range therapists {
+		if t.SpeaksEnglish {
+			englishSpeaking = append(englishSpeaking, t)
+		}
+	}
+	return englishSpeaking, nil
 }
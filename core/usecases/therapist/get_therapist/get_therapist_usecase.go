@@ -15,10 +15,14 @@ func NewUsecase(therapistRepo ports.TherapistRepository) *Usecase {
 	return &Usecase{therapistRepo: therapistRepo}
 }
 
-func (u *Usecase) Execute(id domain.TherapistID) (*therapist.Therapist, error) {
+// Execute returns the therapist, refusing therapists of other clinics
+func (u *Usecase) Execute(clinicID domain.ClinicID, id domain.TherapistID) (*therapist.Therapist, error) {
 	therapist, err := u.therapistRepo.GetByID(id)
 	if err != nil {
 		return nil, common.ErrTherapistNotFound
 	}
+	if therapist.ClinicID != clinicID.OrDefault() {
+		return nil, common.ErrClinicMismatch
+	}
 	return therapist, nil
 }
@@ -0,0 +1,30 @@
+package list_therapists_by_device
+
+import (
+	"errors"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+var ErrDeviceIDIsRequired = errors.New("device id is required")
+
+// Usecase lists the therapists registered with a device, so ops can spot
+// devices shared between therapists when debugging push notifications.
+type Usecase struct {
+	therapistRepo ports.TherapistRepository
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository) *Usecase {
+	return &Usecase{therapistRepo: therapistRepo}
+}
+
+// Execute lists the clinic's therapists registered with the device, those of
+// the default clinic when clinicID is empty
+func (u *Usecase) Execute(clinicID domain.ClinicID, deviceID domain.DeviceID) ([]*therapist.Therapist, error) {
+	if deviceID == "" {
+		return nil, ErrDeviceIDIsRequired
+	}
+	return u.therapistRepo.FindByDeviceID(clinicID.OrDefault(), deviceID)
+}
@@ -16,12 +16,17 @@ var ErrSpecializationNotFound = errors.New("specialization not found")
 var ErrFailedToGetSpecializations = errors.New("failed to get specializations")
 
 type Input struct {
+	ClinicID          domain.ClinicID           `json:"-"` // Taken from the request, not the body
 	Name              string                    `json:"name"`
 	Email             domain.Email              `json:"email"`
 	PhoneNumber       domain.PhoneNumber        `json:"phoneNumber"`
 	WhatsAppNumber    domain.WhatsAppNumber     `json:"whatsAppNumber"`
 	SpeaksEnglish     bool                      `json:"speaksEnglish"`
+	Languages         []domain.LanguageCode     `json:"languages"` // Optional; English is added when SpeaksEnglish is set
 	SpecializationIDs []domain.SpecializationID `json:"specializationIds"`
+	Bio               string                    `json:"bio"`
+	PhotoURL          string                    `json:"photoUrl"`
+	DefaultLanguage   domain.SessionLanguage    `json:"defaultLanguage"` // Optional
 }
 
 type Usecase struct {
@@ -39,13 +44,32 @@ func (u *Usecase) Execute(input Input) (*therapist.Therapist, error) {
 		return nil, err
 	}
 
-	// Validate phone number formats
-	if err := therapistvalidation.ValidatePhoneNumbers(input.PhoneNumber, input.WhatsAppNumber); err != nil {
+	// Validate phone numbers and store them in E.164
+	phoneNumber, whatsAppNumber, err := therapistvalidation.NormalizePhoneNumbers(input.PhoneNumber, input.WhatsAppNumber)
+	if err != nil {
+		return nil, err
+	}
+	input.PhoneNumber = phoneNumber
+	input.WhatsAppNumber = whatsAppNumber
+
+	// Validate photo URL
+	if err := therapistvalidation.ValidatePhotoURL(input.PhotoURL); err != nil {
+		return nil, err
+	}
+
+	// Validate default session language
+	if err := therapistvalidation.ValidateDefaultLanguage(input.DefaultLanguage); err != nil {
+		return nil, err
+	}
+
+	// Validate languages
+	languages, err := therapistvalidation.NormalizeLanguages(input.Languages)
+	if err != nil {
 		return nil, err
 	}
 
 	// Validate specializations exist
-	if err := validateSpecializations(u.specializationRepo, input.SpecializationIDs); err != nil {
+	if err := validateSpecializations(u.specializationRepo, input.ClinicID, input.SpecializationIDs); err != nil {
 		return nil, err
 	}
 
@@ -56,12 +80,21 @@ func (u *Usecase) Execute(input Input) (*therapist.Therapist, error) {
 
 	// Create therapist entity
 	newTherapist := &therapist.Therapist{
-		ID:             domain.NewTherapistID(),
-		Name:           input.Name,
-		Email:          input.Email,
-		PhoneNumber:    input.PhoneNumber,
-		WhatsAppNumber: input.WhatsAppNumber,
-		SpeaksEnglish:  input.SpeaksEnglish,
+		ID:              domain.NewTherapistID(),
+		Name:            input.Name,
+		Email:           input.Email,
+		PhoneNumber:     input.PhoneNumber,
+		WhatsAppNumber:  input.WhatsAppNumber,
+		Bio:             input.Bio,
+		PhotoURL:        input.PhotoURL,
+		DefaultLanguage: input.DefaultLanguage,
+		ClinicID:        input.ClinicID.OrDefault(),
+	}
+
+	// Add languages
+	newTherapist.SetLanguages(languages)
+	if input.SpeaksEnglish {
+		newTherapist.SetSpeaksEnglish(true)
 	}
 
 	// Add specializations
@@ -84,13 +117,68 @@ func (u *Usecase) Execute(input Input) (*therapist.Therapist, error) {
 	return newTherapist, nil
 }
 
-func validateSpecializations(specializationRepo ports.SpecializationRepository, specializationIDs []domain.SpecializationID) error {
+// Validate checks the input like Execute does, but reports every invalid
+// field at once as *domain.ValidationErrors instead of stopping at the first.
+// Other errors, such as failing to load specializations, are returned as is.
+func (u *Usecase) Validate(input Input) error {
+	errs := &domain.ValidationErrors{}
+
+	if input.Name == "" {
+		errs.Add("name", therapist.ErrTherapistNameRequired)
+	}
+
+	if input.Email == "" {
+		errs.Add("email", therapist.ErrTherapistEmailRequired)
+	} else {
+		err := therapistvalidation.ValidateEmailUniqueness(u.therapistRepo, input.Email, nil)
+		if err != nil && err != therapist.ErrTherapistEmailExists {
+			return err
+		}
+		errs.Add("email", err)
+	}
+
+	if input.PhoneNumber == "" {
+		errs.Add("phoneNumber", therapist.ErrTherapistPhoneRequired)
+	} else if _, err := input.PhoneNumber.ToE164(); err != nil {
+		errs.Add("phoneNumber", therapist.ErrTherapistInvalidPhone)
+	}
+
+	if input.WhatsAppNumber == "" {
+		errs.Add("whatsAppNumber", therapist.ErrTherapistWhatsAppRequired)
+	} else if whatsAppNumber, err := input.WhatsAppNumber.ToE164(); err != nil {
+		errs.Add("whatsAppNumber", therapist.ErrTherapistInvalidWhatsApp)
+	} else {
+		err := therapistvalidation.ValidateWhatsAppUniqueness(u.therapistRepo, whatsAppNumber, nil)
+		if err != nil && err != therapist.ErrTherapistWhatsAppExists {
+			return err
+		}
+		errs.Add("whatsAppNumber", err)
+	}
+
+	errs.Add("photoUrl", therapistvalidation.ValidatePhotoURL(input.PhotoURL))
+	errs.Add("defaultLanguage", therapistvalidation.ValidateDefaultLanguage(input.DefaultLanguage))
+
+	if _, err := therapistvalidation.NormalizeLanguages(input.Languages); err != nil {
+		errs.Add("languages", err)
+	}
+
+	if err := validateSpecializations(u.specializationRepo, input.ClinicID, input.SpecializationIDs); err == ErrSpecializationNotFound {
+		errs.Add("specializationIds", err)
+	} else if err != nil {
+		return err
+	}
+
+	return errs.Err()
+}
+
+// validateSpecializations treats specializations of other clinics as missing
+func validateSpecializations(specializationRepo ports.SpecializationRepository, clinicID domain.ClinicID, specializationIDs []domain.SpecializationID) error {
 	dbSpecializations, err := specializationRepo.BulkGetByIds(specializationIDs)
 	if err != nil {
 		return ErrFailedToGetSpecializations
 	}
 	for _, specializationID := range specializationIDs {
-		if _, ok := dbSpecializations[specializationID]; !ok {
+		if found, ok := dbSpecializations[specializationID]; !ok || found.ClinicID != clinicID.OrDefault() {
 			return ErrSpecializationNotFound
 		}
 	}
@@ -0,0 +1,55 @@
+package update_max_daily_sessions
+
+import (
+	"errors"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+var ErrInvalidMaxDailySessions = errors.New("max daily sessions must not be negative")
+
+type Input struct {
+	TherapistID      domain.TherapistID `json:"therapistId"`
+	MaxDailySessions int                `json:"maxDailySessions"` // Zero removes the cap
+}
+
+type Output struct {
+	TherapistID      domain.TherapistID `json:"therapistId"`
+	MaxDailySessions int                `json:"maxDailySessions"`
+}
+
+type Usecase struct {
+	therapistRepo ports.TherapistRepository
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository) *Usecase {
+	return &Usecase{
+		therapistRepo: therapistRepo,
+	}
+}
+
+// Execute sets how many pending and confirmed bookings the therapist takes
+// per day. Bookings made before a lower cap are kept.
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if input.TherapistID == "" {
+		return nil, common.ErrTherapistIDIsRequired
+	}
+	if input.MaxDailySessions < 0 {
+		return nil, ErrInvalidMaxDailySessions
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	if err := u.therapistRepo.UpdateMaxDailySessions(input.TherapistID, input.MaxDailySessions); err != nil {
+		return nil, common.ErrFailedToUpdateTherapist
+	}
+
+	return &Output{
+		TherapistID:      input.TherapistID,
+		MaxDailySessions: input.MaxDailySessions,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package update_notification_preferences
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/ports"
+	"github.com/mishkahtherapy/brain/core/usecases/common"
+)
+
+// Input carries only the preferences being changed. Nil fields keep their
+// stored value.
+type Input struct {
+	TherapistID   domain.TherapistID `json:"therapistId"`
+	Confirmations *bool              `json:"confirmations"`
+	Reminders     *bool              `json:"reminders"`
+}
+
+type Usecase struct {
+	therapistRepo ports.TherapistRepository
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository) *Usecase {
+	return &Usecase{
+		therapistRepo: therapistRepo,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*therapist.NotificationPreferences, error) {
+	if input.TherapistID == "" {
+		return nil, common.ErrTherapistIDIsRequired
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, common.ErrTherapistNotFound
+	}
+
+	preferences, err := u.therapistRepo.GetNotificationPreferences(input.TherapistID)
+	if err != nil {
+		return nil, common.ErrFailedToUpdateTherapist
+	}
+	if input.Confirmations != nil {
+		preferences.Confirmations = *input.Confirmations
+	}
+	if input.Reminders != nil {
+		preferences.Reminders = *input.Reminders
+	}
+
+	if err := u.therapistRepo.UpdateNotificationPreferences(input.TherapistID, preferences); err != nil {
+		return nil, common.ErrFailedToUpdateTherapist
+	}
+
+	return &preferences, nil
+}
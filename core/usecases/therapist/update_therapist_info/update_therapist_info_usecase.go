@@ -10,12 +10,29 @@ import (
 )
 
 type Input struct {
-	TherapistID    domain.TherapistID    `json:"therapistId"`
-	Name           string                `json:"name"`
-	Email          domain.Email          `json:"email"`
-	PhoneNumber    domain.PhoneNumber    `json:"phoneNumber"`
-	WhatsAppNumber domain.WhatsAppNumber `json:"whatsAppNumber"`
-	SpeaksEnglish  bool                  `json:"speaksEnglish"`
+	TherapistID     domain.TherapistID     `json:"therapistId"`
+	Name            string                 `json:"name"`
+	Email           domain.Email           `json:"email"`
+	PhoneNumber     domain.PhoneNumber     `json:"phoneNumber"`
+	WhatsAppNumber  domain.WhatsAppNumber  `json:"whatsAppNumber"`
+	SpeaksEnglish   bool                   `json:"speaksEnglish"`
+	Bio             string                 `json:"bio"`
+	PhotoURL        string                 `json:"photoUrl"`
+	DefaultLanguage domain.SessionLanguage `json:"defaultLanguage"`
+}
+
+// PatchInput holds a partial update of the therapist info. Nil fields keep
+// their current value.
+type PatchInput struct {
+	TherapistID     domain.TherapistID
+	Name            *string
+	Email           *domain.Email
+	PhoneNumber     *domain.PhoneNumber
+	WhatsAppNumber  *domain.WhatsAppNumber
+	SpeaksEnglish   *bool
+	Bio             *string
+	PhotoURL        *string
+	DefaultLanguage *domain.SessionLanguage
 }
 
 type Usecase struct {
@@ -39,8 +56,21 @@ func (u *Usecase) Execute(input Input) (*therapist.Therapist, error) {
 		return nil, err
 	}
 
-	// Validate phone number formats
-	if err := therapistvalidation.ValidatePhoneNumbers(input.PhoneNumber, input.WhatsAppNumber); err != nil {
+	// Validate phone numbers and store them in E.164
+	phoneNumber, whatsAppNumber, err := therapistvalidation.NormalizePhoneNumbers(input.PhoneNumber, input.WhatsAppNumber)
+	if err != nil {
+		return nil, err
+	}
+	input.PhoneNumber = phoneNumber
+	input.WhatsAppNumber = whatsAppNumber
+
+	// Validate photo URL
+	if err := therapistvalidation.ValidatePhotoURL(input.PhotoURL); err != nil {
+		return nil, err
+	}
+
+	// Validate default session language
+	if err := therapistvalidation.ValidateDefaultLanguage(input.DefaultLanguage); err != nil {
 		return nil, err
 	}
 
@@ -63,6 +93,9 @@ func (u *Usecase) Execute(input Input) (*therapist.Therapist, error) {
 		PhoneNumber:     input.PhoneNumber,
 		WhatsAppNumber:  input.WhatsAppNumber,
 		SpeaksEnglish:   input.SpeaksEnglish,
+		Bio:             input.Bio,
+		PhotoURL:        input.PhotoURL,
+		DefaultLanguage: input.DefaultLanguage,
 		Specializations: existingTherapist.Specializations, // Keep existing specializations
 		CreatedAt:       existingTherapist.CreatedAt,       // Keep original creation time
 		UpdatedAt:       domain.UTCTimestamp(time.Now().UTC()),
@@ -76,3 +109,84 @@ func (u *Usecase) Execute(input Input) (*therapist.Therapist, error) {
 	// Return updated therapist (fetch fresh from DB to ensure consistency)
 	return u.therapistRepo.GetByID(input.TherapistID)
 }
+
+// Patch merges the provided fields into the existing therapist. Email and
+// WhatsApp uniqueness is only checked for the fields that change.
+func (u *Usecase) Patch(input PatchInput) (*therapist.Therapist, error) {
+	// Validate therapist ID
+	if input.TherapistID == "" {
+		return nil, therapist.ErrTherapistIDRequired
+	}
+
+	// Get existing therapist
+	existingTherapist, err := u.therapistRepo.GetByID(input.TherapistID)
+	if err != nil {
+		return nil, therapist.ErrTherapistNotFound
+	}
+
+	// Merge the provided fields
+	patchedTherapist := *existingTherapist
+	if input.Name != nil {
+		patchedTherapist.Name = *input.Name
+	}
+	if input.Email != nil {
+		patchedTherapist.Email = *input.Email
+	}
+	if input.PhoneNumber != nil {
+		patchedTherapist.PhoneNumber = *input.PhoneNumber
+	}
+	if input.WhatsAppNumber != nil {
+		patchedTherapist.WhatsAppNumber = *input.WhatsAppNumber
+	}
+	if input.SpeaksEnglish != nil {
+		patchedTherapist.SpeaksEnglish = *input.SpeaksEnglish
+	}
+	if input.Bio != nil {
+		patchedTherapist.Bio = *input.Bio
+	}
+	if input.PhotoURL != nil {
+		patchedTherapist.PhotoURL = *input.PhotoURL
+	}
+	if input.DefaultLanguage != nil {
+		patchedTherapist.DefaultLanguage = *input.DefaultLanguage
+	}
+
+	// Validate the merged therapist
+	if err := therapistvalidation.ValidateRequiredFields(patchedTherapist.Name, patchedTherapist.Email, patchedTherapist.PhoneNumber, patchedTherapist.WhatsAppNumber); err != nil {
+		return nil, err
+	}
+	phoneNumber, whatsAppNumber, err := therapistvalidation.NormalizePhoneNumbers(patchedTherapist.PhoneNumber, patchedTherapist.WhatsAppNumber)
+	if err != nil {
+		return nil, err
+	}
+	patchedTherapist.PhoneNumber = phoneNumber
+	patchedTherapist.WhatsAppNumber = whatsAppNumber
+	if err := therapistvalidation.ValidatePhotoURL(patchedTherapist.PhotoURL); err != nil {
+		return nil, err
+	}
+	if err := therapistvalidation.ValidateDefaultLanguage(patchedTherapist.DefaultLanguage); err != nil {
+		return nil, err
+	}
+
+	// Only check uniqueness of the contact details that change
+	if patchedTherapist.Email != existingTherapist.Email {
+		if err := therapistvalidation.ValidateEmailUniqueness(u.therapistRepo, patchedTherapist.Email, &input.TherapistID); err != nil {
+			return nil, err
+		}
+	}
+	if patchedTherapist.WhatsAppNumber != existingTherapist.WhatsAppNumber {
+		if err := therapistvalidation.ValidateWhatsAppUniqueness(u.therapistRepo, patchedTherapist.WhatsAppNumber, &input.TherapistID); err != nil {
+			return nil, err
+		}
+	}
+
+	patchedTherapist.UpdatedAt = domain.UTCTimestamp(time.Now().UTC())
+
+	// Save patched therapist
+	if err := u.therapistRepo.Update(&patchedTherapist); err != nil {
+		return nil, err
+	}
+
+	// Return patched therapist (fetch fresh from DB to ensure consistency)
+	return u.therapistRepo.GetByID(input.TherapistID)
+}
@@ -0,0 +1,51 @@
+package update_therapist_languages
+
+import (
+	"errors"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/ports"
+	therapistvalidation "github.com/mishkahtherapy/brain/core/usecases/therapist"
+)
+
+var ErrTherapistNotFound = errors.New("therapist not found")
+var ErrFailedToUpdateTherapist = errors.New("failed to update therapist")
+
+type Input struct {
+	TherapistID domain.TherapistID    `json:"therapistId"`
+	Languages   []domain.LanguageCode `json:"languages"`
+}
+
+type Usecase struct {
+	therapistRepo ports.TherapistRepository
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository) *Usecase {
+	return &Usecase{therapistRepo: therapistRepo}
+}
+
+// Execute replaces the therapist's languages. SpeaksEnglish follows whether
+// English is in the new list.
+func (u *Usecase) Execute(input Input) (*therapist.Therapist, error) {
+	languages, err := therapistvalidation.NormalizeLanguages(input.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the existing therapist
+	existingTherapist, err := u.therapistRepo.GetByID(input.TherapistID)
+	if err != nil || existingTherapist == nil {
+		return nil, ErrTherapistNotFound
+	}
+
+	// Save the updated languages
+	if err := u.therapistRepo.UpdateLanguages(input.TherapistID, languages); err != nil {
+		return nil, ErrFailedToUpdateTherapist
+	}
+
+	existingTherapist.SetLanguages(languages)
+	existingTherapist.UpdatedAt = domain.NewUTCTimestamp()
+
+	return existingTherapist, nil
+}
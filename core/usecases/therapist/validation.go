@@ -1,7 +1,7 @@
 package therapist
 
 import (
-	"regexp"
+	"net/url"
 
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/therapist"
@@ -29,32 +29,71 @@ func ValidateRequiredFields(name string, email domain.Email, phoneNumber domain.
 	return nil
 }
 
-// ValidatePhoneNumbers validates the format of phone and WhatsApp numbers
-func ValidatePhoneNumbers(phoneNumber domain.PhoneNumber, whatsAppNumber domain.WhatsAppNumber) error {
-	if !IsValidPhoneNumber(string(phoneNumber)) {
-		return therapist.ErrTherapistInvalidPhone
+// NormalizePhoneNumbers validates the phone and WhatsApp numbers and returns
+// them in E.164, the form they are stored and compared for uniqueness in
+func NormalizePhoneNumbers(phoneNumber domain.PhoneNumber, whatsAppNumber domain.WhatsAppNumber) (domain.PhoneNumber, domain.WhatsAppNumber, error) {
+	normalizedPhone, err := phoneNumber.ToE164()
+	if err != nil {
+		return "", "", therapist.ErrTherapistInvalidPhone
 	}
 
-	if !IsValidPhoneNumber(string(whatsAppNumber)) {
-		return therapist.ErrTherapistInvalidWhatsApp
+	normalizedWhatsApp, err := whatsAppNumber.ToE164()
+	if err != nil {
+		return "", "", therapist.ErrTherapistInvalidWhatsApp
+	}
+
+	return normalizedPhone, normalizedWhatsApp, nil
+}
+
+// ValidatePhotoURL validates that an optional photo URL is an absolute https URL
+func ValidatePhotoURL(photoURL string) error {
+	if photoURL == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(photoURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return therapist.ErrTherapistInvalidPhotoURL
 	}
 
 	return nil
 }
 
-// IsValidPhoneNumber validates a phone number format using international format
-func IsValidPhoneNumber(phoneNumber string) bool {
-	re := regexp.MustCompile(`^\+?[1-9]\d{1,14}$`)
-	return re.MatchString(phoneNumber)
+// ValidateDefaultLanguage validates that an optional default session language
+// is one sessions can be held in
+func ValidateDefaultLanguage(language domain.SessionLanguage) error {
+	if language != "" && !language.IsValid() {
+		return therapist.ErrTherapistInvalidDefaultLanguage
+	}
+
+	return nil
+}
+
+// NormalizeLanguages validates language codes and drops duplicates, keeping
+// the first occurrence of each
+func NormalizeLanguages(languages []domain.LanguageCode) ([]domain.LanguageCode, error) {
+	normalized := make([]domain.LanguageCode, 0, len(languages))
+	seen := make(map[domain.LanguageCode]bool, len(languages))
+	for _, language := range languages {
+		if !language.IsValid() {
+			return nil, therapist.ErrTherapistInvalidLanguage
+		}
+		if seen[language] {
+			continue
+		}
+		seen[language] = true
+		normalized = append(normalized, language)
+	}
+	return normalized, nil
 }
 
 // ValidateEmailUniqueness checks if an email is already in use by another therapist
 // skipTherapistID allows skipping a specific therapist (useful for updates)
 func ValidateEmailUniqueness(repo ports.TherapistRepository, email domain.Email, skipTherapistID *domain.TherapistID) error {
+	// GetByEmail reports an unused email as a nil therapist, so any error is real
 	existingTherapist, err := repo.GetByEmail(email)
 	if err != nil {
-		// If error is "not found", email is available
-		return nil
+		return err
 	}
 
 	if existingTherapist != nil {
@@ -73,8 +112,7 @@ func ValidateEmailUniqueness(repo ports.TherapistRepository, email domain.Email,
 func ValidateWhatsAppUniqueness(repo ports.TherapistRepository, whatsAppNumber domain.WhatsAppNumber, skipTherapistID *domain.TherapistID) error {
 	existingTherapist, err := repo.GetByWhatsAppNumber(whatsAppNumber)
 	if err != nil {
-		// If error is "not found", WhatsApp is available
-		return nil
+		return err
 	}
 
 	if existingTherapist != nil {
@@ -5,49 +5,122 @@ import (
 	"github.com/mishkahtherapy/brain/core/domain/timeslot"
 	"github.com/mishkahtherapy/brain/core/ports"
 	"github.com/mishkahtherapy/brain/core/usecases/common"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
 )
 
 type Input struct {
 	TherapistID domain.TherapistID
 	IsActive    bool
+	DryRun      bool // Report what would change without toggling
+}
+
+type Output struct {
+	// Affected is the number of timeslots whose state changed (or would change)
+	Affected            int                 `json:"affected"`
+	AffectedTimeslotIDs []domain.TimeSlotID `json:"affectedTimeslotIds"`
+	// BlockingBookings lists the active bookings on timeslots being
+	// deactivated. They are kept, but no new bookings can be made.
+	BlockingBookings []timeslot_usecase.Conflict `json:"blockingBookings"`
+	DryRun           bool                        `json:"dryRun,omitempty"`
 }
 
 type Usecase interface {
-	Execute(input Input) error
+	Execute(input Input) (*Output, error)
 }
 
 type usecase struct {
-	therapistRepo ports.TherapistRepository
-	timeslotRepo  ports.TimeSlotRepository
+	therapistRepo   ports.TherapistRepository
+	timeslotRepo    ports.TimeSlotRepository
+	transactionPort ports.TransactionPort
 }
 
-func NewUsecase(therapistRepo ports.TherapistRepository, timeslotRepo ports.TimeSlotRepository) Usecase {
+func NewUsecase(
+	therapistRepo ports.TherapistRepository,
+	timeslotRepo ports.TimeSlotRepository,
+	transactionPort ports.TransactionPort,
+) Usecase {
 	return &usecase{
-		therapistRepo: therapistRepo,
-		timeslotRepo:  timeslotRepo,
+		therapistRepo:   therapistRepo,
+		timeslotRepo:    timeslotRepo,
+		transactionPort: transactionPort,
 	}
 }
 
-func (u *usecase) Execute(input Input) error {
+func (u *usecase) Execute(input Input) (*Output, error) {
 	// Validate input
 	if input.TherapistID == "" {
-		return timeslot.ErrTherapistIDRequired
+		return nil, timeslot.ErrTherapistIDRequired
 	}
 
 	// Check if therapist exists
 	_, err := u.therapistRepo.GetByID(input.TherapistID)
 	if err != nil {
 		if err == common.ErrTherapistNotFound {
-			return timeslot.ErrTherapistNotFound
+			return nil, timeslot.ErrTherapistNotFound
 		}
-		return err
+		return nil, err
+	}
+
+	output, err := u.plan(input)
+	if err != nil {
+		return nil, err
+	}
+	if input.DryRun {
+		return output, nil
 	}
 
 	// Bulk toggle all timeslots for the therapist
-	err = u.timeslotRepo.BulkToggleByTherapistID(input.TherapistID, input.IsActive)
+	affected, err := u.timeslotRepo.BulkToggleByTherapistID(input.TherapistID, input.IsActive)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	output.Affected = affected
+
+	return output, nil
+}
+
+// plan lists the timeslots the toggle changes: those not already in the
+// requested state, matching BulkToggleByTherapistID.
+func (u *usecase) plan(input Input) (*Output, error) {
+	slots, err := u.timeslotRepo.ListByTherapist(input.TherapistID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &Output{
+		AffectedTimeslotIDs: make([]domain.TimeSlotID, 0),
+		BlockingBookings:    make([]timeslot_usecase.Conflict, 0),
+		DryRun:              input.DryRun,
+	}
+
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// Read only; nothing to commit
+	defer u.transactionPort.Rollback(tx)
+
+	for _, slot := range slots {
+		if slot.IsActive == input.IsActive {
+			continue
+		}
+		output.AffectedTimeslotIDs = append(output.AffectedTimeslotIDs, slot.ID)
+
+		if input.IsActive {
+			continue
+		}
+		activeBookingIDs, err := timeslot_usecase.ActiveBookingIDs(tx, u.timeslotRepo, slot.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(activeBookingIDs) > 0 {
+			output.BlockingBookings = append(output.BlockingBookings, timeslot_usecase.Conflict{
+				TimeslotID: slot.ID,
+				BookingIDs: activeBookingIDs,
+			})
+		}
 	}
+	output.Affected = len(output.AffectedTimeslotIDs)
 
-	return nil
+	return output, nil
 }
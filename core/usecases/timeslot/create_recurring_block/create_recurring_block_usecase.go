@@ -0,0 +1,66 @@
+package create_recurring_block
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
+)
+
+type Input struct {
+	TherapistID     domain.TherapistID     `json:"therapistId"`
+	DayOfWeek       string                 `json:"dayOfWeek"` // "Monday"
+	StartTime       domain.Time24h         `json:"start"`     // "12:00"
+	DurationMinutes domain.DurationMinutes `json:"duration"`  // Duration in minutes
+	Timezone        string                 `json:"timezone"`  // Optional IANA name, e.g. "Africa/Cairo"
+}
+
+type Usecase struct {
+	therapistRepo      ports.TherapistRepository
+	recurringBlockRepo ports.RecurringBlockRepository
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository, recurringBlockRepo ports.RecurringBlockRepository) *Usecase {
+	return &Usecase{
+		therapistRepo:      therapistRepo,
+		recurringBlockRepo: recurringBlockRepo,
+	}
+}
+
+func (u *Usecase) Execute(input Input) (*timeslot.RecurringBlock, error) {
+	if input.TherapistID == "" {
+		return nil, timeslot.ErrTherapistIDRequired
+	}
+
+	if err := timeslot_usecase.ValidateRecurringBlock(
+		input.DayOfWeek,
+		input.StartTime,
+		input.DurationMinutes,
+		input.Timezone,
+	); err != nil {
+		return nil, err
+	}
+
+	// Verify therapist exists
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, timeslot.ErrTherapistNotFound
+	}
+
+	now := domain.NewUTCTimestamp()
+	block := &timeslot.RecurringBlock{
+		ID:          domain.NewRecurringBlockID(),
+		TherapistID: input.TherapistID,
+		DayOfWeek:   timeslot.DayOfWeek(input.DayOfWeek),
+		Start:       input.StartTime,
+		Duration:    input.DurationMinutes,
+		Timezone:    input.Timezone,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := u.recurringBlockRepo.Create(block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
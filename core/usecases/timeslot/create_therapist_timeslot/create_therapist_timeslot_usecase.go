@@ -1,6 +1,8 @@
 package create_therapist_timeslot
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/mishkahtherapy/brain/core/domain"
@@ -17,21 +19,52 @@ type Input struct {
 	DurationMinutes       domain.DurationMinutes              `json:"durationMinutes"`       // Duration in minutes
 	AfterSessionBreakTime domain.AfterSessionBreakTimeMinutes `json:"afterSessionBreakTime"` // minutes
 	AdvanceNotice         domain.AdvanceNoticeMinutes         `json:"advanceNotice"`         // minutes
+	Timezone              string                              `json:"timezone"`              // Optional IANA name, e.g. "America/New_York"
+	Capacity              int                                 `json:"capacity"`              // Optional, defaults to 1; more for group sessions
+}
+
+// Output is the created timeslot along with any soft validation warnings.
+// Warnings never prevent the slot from being created.
+type Output struct {
+	*timeslot.TimeSlot
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// MarshalJSON keeps the timeslot's fields, including its computed times, flat
+// alongside the output's own.
+func (o Output) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		timeslot.TimeSlotView
+		Warnings []string `json:"warnings,omitempty"`
+	}{o.TimeSlot.View(), o.Warnings})
 }
 
 type Usecase struct {
 	therapistRepo ports.TherapistRepository
 	timeslotRepo  ports.TimeSlotRepository
+
+	maxSlotDurationWarning domain.DurationMinutes
+	minimumSessionDuration domain.DurationMinutes
+	clinicHours            timeslot.ClinicHours
 }
 
-func NewUsecase(therapistRepo ports.TherapistRepository, timeslotRepo ports.TimeSlotRepository) *Usecase {
+func NewUsecase(
+	therapistRepo ports.TherapistRepository,
+	timeslotRepo ports.TimeSlotRepository,
+	maxSlotDurationWarning domain.DurationMinutes,
+	minimumSessionDuration domain.DurationMinutes,
+	clinicHours timeslot.ClinicHours, // zero value disables the check
+) *Usecase {
 	return &Usecase{
-		therapistRepo: therapistRepo,
-		timeslotRepo:  timeslotRepo,
+		therapistRepo:          therapistRepo,
+		timeslotRepo:           timeslotRepo,
+		maxSlotDurationWarning: maxSlotDurationWarning,
+		minimumSessionDuration: minimumSessionDuration,
+		clinicHours:            clinicHours,
 	}
 }
 
-func (u *Usecase) Execute(input Input) (*timeslot.TimeSlot, error) {
+func (u *Usecase) Execute(input Input) (*Output, error) {
 	// Validate input
 	if err := u.validateInput(input); err != nil {
 		return nil, err
@@ -51,6 +84,8 @@ func (u *Usecase) Execute(input Input) (*timeslot.TimeSlot, error) {
 		AdvanceNotice:         input.AdvanceNotice,
 		AfterSessionBreakTime: input.AfterSessionBreakTime,
 		IsActive:              input.IsActive,
+		Timezone:              input.Timezone,
+		Capacity:              max(input.Capacity, 1),
 	}
 
 	// Check for overlapping timeslots
@@ -72,7 +107,23 @@ func (u *Usecase) Execute(input Input) (*timeslot.TimeSlot, error) {
 		return nil, err
 	}
 
-	return newTimeslot, nil
+	return &Output{
+		TimeSlot: newTimeslot,
+		Warnings: u.warningsFor(input),
+	}, nil
+}
+
+// warningsFor returns soft validation warnings for an otherwise valid input.
+func (u *Usecase) warningsFor(input Input) []string {
+	warnings := make([]string, 0)
+	if u.maxSlotDurationWarning > 0 && input.DurationMinutes > u.maxSlotDurationWarning {
+		warnings = append(warnings, fmt.Sprintf(
+			"slot duration of %d minutes exceeds %d minutes, check it was not created by accident",
+			input.DurationMinutes,
+			u.maxSlotDurationWarning,
+		))
+	}
+	return warnings
 }
 
 func (u *Usecase) validateInput(input Input) error {
@@ -117,6 +168,34 @@ func (u *Usecase) validateInput(input Input) error {
 		return err
 	}
 
+	// A slot too short for a session and its buffer never has availability
+	if err := timeslot_usecase.ValidateSessionFitsSlot(
+		input.DurationMinutes,
+		input.AfterSessionBreakTime,
+		u.minimumSessionDuration,
+	); err != nil {
+		return err
+	}
+
+	// Validate optional timezone name
+	if err := timeslot_usecase.ValidateTimezoneName(input.Timezone); err != nil {
+		return err
+	}
+
+	// Validate optional capacity
+	if err := timeslot_usecase.ValidateCapacity(input.Capacity); err != nil {
+		return err
+	}
+
+	// The slot is stored in local time, so compare it to clinic hours as is
+	if err := timeslot_usecase.ValidateWithinClinicHours(
+		input.LocalStartTime,
+		input.DurationMinutes,
+		u.clinicHours,
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
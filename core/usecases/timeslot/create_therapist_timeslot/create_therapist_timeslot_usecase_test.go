@@ -0,0 +1,77 @@
+package create_therapist_timeslot
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/therapist"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/internal/fakes"
+)
+
+func TestCreateTimeslotSessionMustFitSlot(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration domain.DurationMinutes
+		wantErr  error
+	}{
+		// 15 minute minimum session plus the 30 minute buffer
+		{name: "buffer and minimum session exactly fill the slot", duration: 45, wantErr: nil},
+		{name: "one minute short", duration: 44, wantErr: timeslot.ErrSessionDoesNotFitSlot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{{ID: "therapist_1"}}}
+			usecase := NewUsecase(therapistRepo, &fakes.TimeSlotRepo{}, 12*60, 15, timeslot.ClinicHours{})
+
+			_, err := usecase.Execute(Input{
+				TherapistID:           "therapist_1",
+				LocalDayOfWeek:        "Monday",
+				LocalStartTime:        "10:00",
+				DurationMinutes:       tt.duration,
+				AfterSessionBreakTime: 30,
+			})
+			if err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCreateTimeslotClinicHours(t *testing.T) {
+	tests := []struct {
+		name        string
+		clinicHours timeslot.ClinicHours
+		start       domain.Time24h
+		duration    domain.DurationMinutes
+		wantErr     error
+	}{
+		{name: "disabled when unset", clinicHours: timeslot.ClinicHours{}, start: "03:00", duration: 60, wantErr: nil},
+		{name: "in hours", clinicHours: timeslot.ClinicHours{Open: "08:00", Close: "22:00"}, start: "08:00", duration: 14 * 60, wantErr: nil},
+		{name: "starts before opening", clinicHours: timeslot.ClinicHours{Open: "08:00", Close: "22:00"}, start: "07:30", duration: 60, wantErr: timeslot.ErrOutsideClinicHours},
+		{name: "ends after closing", clinicHours: timeslot.ClinicHours{Open: "08:00", Close: "22:00"}, start: "21:30", duration: 60, wantErr: timeslot.ErrOutsideClinicHours},
+		{name: "runs past midnight", clinicHours: timeslot.ClinicHours{Open: "08:00", Close: "22:00"}, start: "23:30", duration: 60, wantErr: timeslot.ErrOutsideClinicHours},
+		{name: "past midnight while the clinic is open", clinicHours: timeslot.ClinicHours{Open: "18:00", Close: "02:00"}, start: "23:30", duration: 120, wantErr: nil},
+		{name: "after midnight while the clinic is open", clinicHours: timeslot.ClinicHours{Open: "18:00", Close: "02:00"}, start: "00:30", duration: 60, wantErr: nil},
+		{name: "after the clinic closes past midnight", clinicHours: timeslot.ClinicHours{Open: "18:00", Close: "02:00"}, start: "01:30", duration: 60, wantErr: timeslot.ErrOutsideClinicHours},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			therapistRepo := &fakes.TherapistRepo{Therapists: []*therapist.Therapist{{ID: "therapist_1"}}}
+			usecase := NewUsecase(therapistRepo, &fakes.TimeSlotRepo{}, 24*60, 15, tt.clinicHours)
+
+			_, err := usecase.Execute(Input{
+				TherapistID:           "therapist_1",
+				LocalDayOfWeek:        "Monday",
+				LocalStartTime:        tt.start,
+				DurationMinutes:       tt.duration,
+				AfterSessionBreakTime: 30,
+			})
+			if err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
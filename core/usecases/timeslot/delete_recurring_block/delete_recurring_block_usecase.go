@@ -0,0 +1,43 @@
+package delete_recurring_block
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type Input struct {
+	TherapistID domain.TherapistID      `json:"therapistId"`
+	BlockID     domain.RecurringBlockID `json:"blockId"`
+}
+
+type Usecase struct {
+	recurringBlockRepo ports.RecurringBlockRepository
+}
+
+func NewUsecase(recurringBlockRepo ports.RecurringBlockRepository) *Usecase {
+	return &Usecase{recurringBlockRepo: recurringBlockRepo}
+}
+
+func (u *Usecase) Execute(input Input) error {
+	if input.TherapistID == "" {
+		return timeslot.ErrTherapistIDRequired
+	}
+
+	if input.BlockID == "" {
+		return timeslot.ErrRecurringBlockIDIsRequired
+	}
+
+	block, err := u.recurringBlockRepo.GetByID(input.BlockID)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		return timeslot.ErrRecurringBlockNotFound
+	}
+	if block.TherapistID != input.TherapistID {
+		return timeslot.ErrRecurringBlockNotOwned
+	}
+
+	return u.recurringBlockRepo.Delete(input.BlockID)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/timeslot"
 	"github.com/mishkahtherapy/brain/core/ports"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
 )
 
 type Input struct {
@@ -12,14 +13,20 @@ type Input struct {
 }
 
 type Usecase struct {
-	therapistRepo ports.TherapistRepository
-	timeslotRepo  ports.TimeSlotRepository
+	therapistRepo   ports.TherapistRepository
+	timeslotRepo    ports.TimeSlotRepository
+	transactionPort ports.TransactionPort
 }
 
-func NewUsecase(therapistRepo ports.TherapistRepository, timeslotRepo ports.TimeSlotRepository) *Usecase {
+func NewUsecase(
+	therapistRepo ports.TherapistRepository,
+	timeslotRepo ports.TimeSlotRepository,
+	transactionPort ports.TransactionPort,
+) *Usecase {
 	return &Usecase{
-		therapistRepo: therapistRepo,
-		timeslotRepo:  timeslotRepo,
+		therapistRepo:   therapistRepo,
+		timeslotRepo:    timeslotRepo,
+		transactionPort: transactionPort,
 	}
 }
 
@@ -49,17 +56,29 @@ func (u *Usecase) Execute(input Input) error {
 		return timeslot.ErrTimeslotNotOwned
 	}
 
-	// Check if timeslot has active bookings
-	if len(timeslotResult.BookingIDs) > 0 {
+	// Check for active bookings and delete in one transaction so a booking
+	// cannot slip in between
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return err
+	}
+
+	activeBookingIDs, err := timeslot_usecase.ActiveBookingIDs(tx, u.timeslotRepo, input.TimeslotID)
+	if err != nil {
+		u.transactionPort.Rollback(tx)
+		return err
+	}
+	if len(activeBookingIDs) > 0 {
+		u.transactionPort.Rollback(tx)
 		return timeslot.ErrTimeslotHasActiveBookings
 	}
 
-	// Delete the timeslot
-	if err := u.timeslotRepo.Delete(input.TimeslotID); err != nil {
+	if err := u.timeslotRepo.BulkDeleteTx(tx, []domain.TimeSlotID{input.TimeslotID}); err != nil {
+		u.transactionPort.Rollback(tx)
 		return err
 	}
 
-	return nil
+	return u.transactionPort.Commit(tx)
 }
 
 func (u *Usecase) validateInput(input Input) error {
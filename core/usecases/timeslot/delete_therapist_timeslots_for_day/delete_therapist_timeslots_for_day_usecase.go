@@ -0,0 +1,125 @@
+package delete_therapist_timeslots_for_day
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
+)
+
+type Input struct {
+	TherapistID domain.TherapistID `json:"therapistId"`
+	DayOfWeek   timeslot.DayOfWeek `json:"dayOfWeek"`
+	DryRun      bool               `json:"dryRun"` // Report what would be deleted without deleting
+}
+
+type Output struct {
+	DeletedTimeslotIDs []domain.TimeSlotID         `json:"deletedTimeslotIds"`
+	Conflicts          []timeslot_usecase.Conflict `json:"conflicts,omitempty"`
+	DryRun             bool                        `json:"dryRun,omitempty"`
+}
+
+type Usecase struct {
+	therapistRepo   ports.TherapistRepository
+	timeslotRepo    ports.TimeSlotRepository
+	transactionPort ports.TransactionPort
+}
+
+func NewUsecase(
+	therapistRepo ports.TherapistRepository,
+	timeslotRepo ports.TimeSlotRepository,
+	transactionPort ports.TransactionPort,
+) *Usecase {
+	return &Usecase{
+		therapistRepo:   therapistRepo,
+		timeslotRepo:    timeslotRepo,
+		transactionPort: transactionPort,
+	}
+}
+
+// Execute deletes every timeslot of the therapist on the given day. If any of
+// them has active bookings nothing is deleted, and the returned output lists
+// the conflicts alongside timeslot.ErrTimeslotHasActiveBookings. A dry run
+// returns the same output without an error and leaves the timeslots in place.
+func (u *Usecase) Execute(input Input) (*Output, error) {
+	if input.TherapistID == "" {
+		return nil, timeslot.ErrTherapistIDRequired
+	}
+
+	if input.DayOfWeek == "" {
+		return nil, timeslot.ErrDayOfWeekIsRequired
+	}
+
+	if !timeslot_usecase.IsValidDayOfWeek(input.DayOfWeek) {
+		return nil, timeslot.ErrInvalidDayOfWeek
+	}
+
+	// Verify therapist exists
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, timeslot.ErrTherapistNotFound
+	}
+
+	slots, err := u.timeslotRepo.ListByTherapist(input.TherapistID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &Output{
+		DeletedTimeslotIDs: make([]domain.TimeSlotID, 0),
+		Conflicts:          make([]timeslot_usecase.Conflict, 0),
+		DryRun:             input.DryRun,
+	}
+
+	// Check for active bookings and delete in one transaction so a booking
+	// cannot slip in between
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slot := range slots {
+		if slot.DayOfWeek != input.DayOfWeek {
+			continue
+		}
+
+		activeBookingIDs, err := timeslot_usecase.ActiveBookingIDs(tx, u.timeslotRepo, slot.ID)
+		if err != nil {
+			u.transactionPort.Rollback(tx)
+			return nil, err
+		}
+
+		if len(activeBookingIDs) > 0 {
+			output.Conflicts = append(output.Conflicts, timeslot_usecase.Conflict{
+				TimeslotID: slot.ID,
+				BookingIDs: activeBookingIDs,
+			})
+			continue
+		}
+		output.DeletedTimeslotIDs = append(output.DeletedTimeslotIDs, slot.ID)
+	}
+
+	if len(output.Conflicts) > 0 {
+		u.transactionPort.Rollback(tx)
+		output.DeletedTimeslotIDs = make([]domain.TimeSlotID, 0)
+		if input.DryRun {
+			return output, nil
+		}
+		return output, timeslot.ErrTimeslotHasActiveBookings
+	}
+
+	if input.DryRun {
+		u.transactionPort.Rollback(tx)
+		return output, nil
+	}
+
+	if err := u.timeslotRepo.BulkDeleteTx(tx, output.DeletedTimeslotIDs); err != nil {
+		u.transactionPort.Rollback(tx)
+		return nil, err
+	}
+
+	if err := u.transactionPort.Commit(tx); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,63 @@
+package export_therapist_timeslots
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type Input struct {
+	TherapistID domain.TherapistID `json:"therapistId"`
+}
+
+type Usecase struct {
+	therapistRepo ports.TherapistRepository
+	timeslotRepo  ports.TimeSlotRepository
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository, timeslotRepo ports.TimeSlotRepository) *Usecase {
+	return &Usecase{
+		therapistRepo: therapistRepo,
+		timeslotRepo:  timeslotRepo,
+	}
+}
+
+// Execute returns all of the therapist's timeslots, active or not, as a
+// template. Slots are stored in local time, so they are exported as is and
+// must all share one timezone.
+func (u *Usecase) Execute(input Input) (*timeslot.Template, error) {
+	if input.TherapistID == "" {
+		return nil, timeslot.ErrTherapistIDRequired
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, timeslot.ErrTherapistNotFound
+	}
+
+	slots, err := u.timeslotRepo.ListByTherapist(input.TherapistID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &timeslot.Template{
+		Timeslots: make([]timeslot.TemplateSlot, 0, len(slots)),
+	}
+	for i, slot := range slots {
+		if i == 0 {
+			template.Timezone = slot.Timezone
+		} else if slot.Timezone != template.Timezone {
+			return nil, timeslot.ErrTemplateHasMixedTimezones
+		}
+
+		template.Timeslots = append(template.Timeslots, timeslot.TemplateSlot{
+			DayOfWeek:             slot.DayOfWeek,
+			StartTime:             slot.Start,
+			DurationMinutes:       slot.Duration,
+			AdvanceNotice:         slot.AdvanceNotice,
+			AfterSessionBreakTime: slot.AfterSessionBreakTime,
+			IsActive:              slot.IsActive,
+		})
+	}
+
+	return template, nil
+}
@@ -1,6 +1,8 @@
 package get_therapist_timeslot
 
 import (
+	"encoding/json"
+
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/therapist"
 	"github.com/mishkahtherapy/brain/core/domain/timeslot"
@@ -12,19 +14,37 @@ type Input struct {
 	TimeslotID  domain.TimeSlotID  `json:"timeslotId"`
 }
 
+// Output is the timeslot along with the number of its upcoming pending and
+// confirmed bookings.
+type Output struct {
+	*timeslot.TimeSlot
+	ActiveBookingCount int `json:"activeBookingCount"`
+}
+
+// MarshalJSON keeps the timeslot's fields, including its computed times, flat
+// alongside the output's own.
+func (o Output) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		timeslot.TimeSlotView
+		ActiveBookingCount int `json:"activeBookingCount"`
+	}{o.TimeSlot.View(), o.ActiveBookingCount})
+}
+
 type Usecase struct {
 	therapistRepo ports.TherapistRepository
 	timeslotRepo  ports.TimeSlotRepository
+	bookingRepo   ports.BookingRepository
 }
 
-func NewUsecase(therapistRepo ports.TherapistRepository, timeslotRepo ports.TimeSlotRepository) *Usecase {
+func NewUsecase(therapistRepo ports.TherapistRepository, timeslotRepo ports.TimeSlotRepository, bookingRepo ports.BookingRepository) *Usecase {
 	return &Usecase{
 		therapistRepo: therapistRepo,
 		timeslotRepo:  timeslotRepo,
+		bookingRepo:   bookingRepo,
 	}
 }
 
-func (u *Usecase) Execute(input Input) (*timeslot.TimeSlot, error) {
+func (u *Usecase) Execute(input Input) (*Output, error) {
 	// Validate input
 	if err := u.validateInput(input); err != nil {
 		return nil, err
@@ -50,7 +70,15 @@ func (u *Usecase) Execute(input Input) (*timeslot.TimeSlot, error) {
 		return nil, timeslot.ErrTimeslotNotOwned
 	}
 
-	return timeslotResult, nil
+	activeBookingCount, err := u.bookingRepo.CountActiveByTimeSlot(timeslotResult.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Output{
+		TimeSlot:           timeslotResult,
+		ActiveBookingCount: activeBookingCount,
+	}, nil
 }
 
 func (u *Usecase) validateInput(input Input) error {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/mishkahtherapy/brain/core/domain"
 	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
 )
 
 const MIN_POST_SESSION_BUFFER_MINUTES = 15
@@ -29,6 +30,20 @@ func IsValidDayOfWeek(day timeslot.DayOfWeek) bool {
 	return false
 }
 
+// Conflict is a timeslot with active bookings that block or are affected by
+// a bulk operation
+type Conflict struct {
+	TimeslotID domain.TimeSlotID  `json:"timeslotId"`
+	BookingIDs []domain.BookingID `json:"bookingIds"`
+}
+
+// ActiveBookingIDs returns the bookings that prevent the slot from being
+// deleted: pending or confirmed ones that have not started yet. Past and
+// cancelled bookings do not block a deletion.
+func ActiveBookingIDs(sqlExec ports.SQLExec, timeslotRepo ports.TimeSlotRepository, id domain.TimeSlotID) ([]domain.BookingID, error) {
+	return timeslotRepo.ListActiveBookingIDsTx(sqlExec, id, time.Now().UTC())
+}
+
 // Helper function to check if two time ranges overlap
 func TimesOverlap(start1, end1, start2, end2 time.Time) bool {
 	return start1.Before(end2) && start2.Before(end1)
@@ -87,6 +102,35 @@ func ValidateBufferTimes(
 	return nil
 }
 
+// ValidateSessionFitsSlot checks that a session of the minimum length plus
+// the post-session buffer fits in the slot. Bookings are padded with the
+// buffer when computing availability, so a slot any shorter never shows a
+// bookable range. Advance notice is counted back from the booking time, not
+// taken out of the slot, so it is not part of the check.
+func ValidateSessionFitsSlot(
+	durationMinutes domain.DurationMinutes,
+	afterSessionBreakTime domain.AfterSessionBreakTimeMinutes,
+	minimumSessionDuration domain.DurationMinutes,
+) error {
+	if int(minimumSessionDuration)+int(afterSessionBreakTime) > int(durationMinutes) {
+		return timeslot.ErrSessionDoesNotFitSlot
+	}
+	return nil
+}
+
+// ValidateWithinClinicHours checks that the slot's local window lies within
+// the clinic's hours. It passes when clinic hours are not configured.
+func ValidateWithinClinicHours(
+	start domain.Time24h,
+	durationMinutes domain.DurationMinutes,
+	clinicHours timeslot.ClinicHours,
+) error {
+	if !clinicHours.Contains(start, durationMinutes) {
+		return timeslot.ErrOutsideClinicHours
+	}
+	return nil
+}
+
 // Get actual time range for a time slot (handles cross-day scenarios)
 func GetActualTimeRange(slot timeslot.TimeSlot) (start, end time.Time) {
 	baseDate := getBaseDateForDay(string(slot.DayOfWeek))
@@ -109,54 +153,75 @@ func HasTimeSlotConflict(slot1, slot2 timeslot.TimeSlot) bool {
 	return start1.Before(end2) && start2.Before(end1)
 }
 
-// Get effective time range for a time slot including buffers (handles cross-day scenarios)
-func ApplyTimesToReferenceDate(slot timeslot.TimeSlot) (start, end time.Time) {
-	baseDate := getBaseDateForDay(string(slot.DayOfWeek))
-	startTime, _ := slot.Start.ParseTime()
-	sessionStart := time.Date(baseDate.Year(), baseDate.Month(), baseDate.Day(),
-		startTime.Hour(), startTime.Minute(), 0, 0, time.UTC)
-	sessionEnd := sessionStart.Add(time.Duration(slot.Duration) * time.Minute)
+// referenceWeeks start on a Sunday in winter and in summer, so slots in
+// timezones observing DST are compared under both of their offsets.
+var referenceWeeks = []time.Time{
+	time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC),
+	time.Date(2000, 7, 2, 0, 0, 0, 0, time.UTC),
+}
+
+// Get the UTC time range of a time slot in the reference week. Slots with a
+// timezone are converted with the offset in effect that week.
+func ApplyTimesToReferenceDate(slot timeslot.TimeSlot, week time.Time) (start, end time.Time) {
+	baseDate := week.AddDate(0, 0, getDayOffset(string(slot.DayOfWeek)))
+	sessionStart, sessionEnd := slot.ApplyToDate(baseDate)
+	return sessionStart.Time(), sessionEnd.Time()
+}
 
-	return sessionStart, sessionEnd
+// compareInReferenceWeeks calls compare with the UTC windows of both slots in
+// each reference week. slot2 is also placed in the neighbouring weeks, since
+// a timezone can move a window across the week boundary. It stops at the
+// first comparison returning true.
+func compareInReferenceWeeks(slot1, slot2 timeslot.TimeSlot, compare func(start1, end1, start2, end2 time.Time) bool) bool {
+	for _, week := range referenceWeeks {
+		start1, end1 := ApplyTimesToReferenceDate(slot1, week)
+		for _, shift := range []int{-7, 0, 7} {
+			start2, end2 := ApplyTimesToReferenceDate(slot2, week.AddDate(0, 0, shift))
+			if compare(start1, end1, start2, end2) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Check if two time slots have sufficient gap between them (at least MIN_POST_SESSION_BUFFER_MINUTES minutes)
 func HasSufficientGapBetweenSlots(slot1, slot2 timeslot.TimeSlot) bool {
-	// Skip validation if slots are on different days
-	if slot1.DayOfWeek != slot2.DayOfWeek {
+	// Skip validation if slots are on different days of the same timezone
+	if slot1.Timezone == slot2.Timezone && slot1.DayOfWeek != slot2.DayOfWeek {
 		return true
 	}
 
-	start1, end1 := ApplyTimesToReferenceDate(slot1)
-	start2, end2 := ApplyTimesToReferenceDate(slot2)
-
 	// Check if there's at least MIN_POST_SESSION_BUFFER_MINUTES minutes between the slots
 	minGap := MIN_POST_SESSION_BUFFER_MINUTES * time.Minute
 
-	// If slot1 ends before slot2 starts, check the gap
-	if end1.Before(start2) || end1.Equal(start2) {
-		return start2.Sub(end1) >= minGap
-	}
+	tooClose := compareInReferenceWeeks(slot1, slot2, func(start1, end1, start2, end2 time.Time) bool {
+		// If slot1 ends before slot2 starts, check the gap
+		if !end1.After(start2) {
+			return start2.Sub(end1) < minGap
+		}
 
-	// If slot2 ends before slot1 starts, check the gap
-	if end2.Before(start1) || end2.Equal(start1) {
-		return start1.Sub(end2) >= minGap
-	}
+		// If slot2 ends before slot1 starts, check the gap
+		if !end2.After(start1) {
+			return start1.Sub(end2) < minGap
+		}
 
-	// If neither condition is met, the slots overlap - no sufficient gap
-	return false
+		// If neither condition is met, the slots overlap - no sufficient gap
+		return true
+	})
+	return !tooClose
 }
 
 // Check if two time slots have conflicting effective time ranges (including buffers)
 func HasEffectiveTimeSlotConflict(slot1, slot2 timeslot.TimeSlot) bool {
-	// Skip validation if slots are on different days
-	if slot1.DayOfWeek != slot2.DayOfWeek {
+	// Skip validation if slots are on different days of the same timezone
+	if slot1.Timezone == slot2.Timezone && slot1.DayOfWeek != slot2.DayOfWeek {
 		return false
 	}
 
-	start1, end1 := ApplyTimesToReferenceDate(slot1)
-	start2, end2 := ApplyTimesToReferenceDate(slot2)
-	return start1.Before(end2) && start2.Before(end1)
+	return compareInReferenceWeeks(slot1, slot2, func(start1, end1, start2, end2 time.Time) bool {
+		return start1.Before(end2) && start2.Before(end1)
+	})
 }
 
 // Validate duration
@@ -167,14 +232,73 @@ func ValidateDuration(durationMinutes domain.DurationMinutes) error {
 	return nil
 }
 
+// Timezone offset bounds in minutes, from UTC-12 to UTC+14
+const (
+	MinTimezoneOffset domain.TimezoneOffset = -720
+	MaxTimezoneOffset domain.TimezoneOffset = 840
+)
+
 // Validate timezone offset (between -12 to +14 hours in minutes)
 func ValidateTimezoneOffset(offsetMinutes domain.TimezoneOffset) error {
-	if offsetMinutes < -720 || offsetMinutes > 840 {
+	if offsetMinutes < MinTimezoneOffset || offsetMinutes > MaxTimezoneOffset {
 		return timeslot.ErrInvalidTimezoneOffset
 	}
 	return nil
 }
 
+// Validate the optional capacity. Zero means the default of a single booking.
+func ValidateCapacity(capacity int) error {
+	if capacity < 0 {
+		return timeslot.ErrInvalidCapacity
+	}
+	return nil
+}
+
+// Validate IANA timezone name. Empty means the slot is stored in UTC.
+func ValidateTimezoneName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return timeslot.ErrInvalidTimezoneName
+	}
+	return nil
+}
+
+// ValidateRecurringBlock validates the window of a recurring block
+func ValidateRecurringBlock(
+	dayOfWeek string,
+	startTime domain.Time24h,
+	durationMinutes domain.DurationMinutes,
+	timezone string,
+) error {
+	if dayOfWeek == "" {
+		return timeslot.ErrDayOfWeekIsRequired
+	}
+
+	if startTime == "" {
+		return timeslot.ErrStartTimeIsRequired
+	}
+
+	if durationMinutes == 0 {
+		return timeslot.ErrDurationIsRequired
+	}
+
+	if !IsValidDayOfWeek(timeslot.DayOfWeek(dayOfWeek)) {
+		return timeslot.ErrInvalidDayOfWeek
+	}
+
+	if _, err := ParseTimeString(startTime); err != nil {
+		return err
+	}
+
+	if err := ValidateDuration(durationMinutes); err != nil {
+		return err
+	}
+
+	return ValidateTimezoneName(timezone)
+}
+
 // Helper function to get base date for a day of week
 func getBaseDateForDay(dayOfWeek string) time.Time {
 	// Use a reference week starting Sunday 2000-01-02
@@ -0,0 +1,62 @@
+package timeslot_usecase
+
+import (
+	"testing"
+
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+)
+
+func TestHasEffectiveTimeSlotConflictAcrossTimezones(t *testing.T) {
+	tests := []struct {
+		name     string
+		slot1    timeslot.TimeSlot
+		slot2    timeslot.TimeSlot
+		conflict bool
+	}{
+		{
+			name: "same UTC window written in different timezones",
+			// Cairo is UTC+2 in January
+			slot1:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekMonday, Start: "10:00", Duration: 60},
+			slot2:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekMonday, Start: "12:00", Duration: 60, Timezone: "Africa/Cairo"},
+			conflict: true,
+		},
+		{
+			name:     "same local time in different timezones",
+			slot1:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekMonday, Start: "10:00", Duration: 60},
+			slot2:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekMonday, Start: "10:00", Duration: 60, Timezone: "Africa/Cairo"},
+			conflict: false,
+		},
+		{
+			name: "local day differs from the UTC day",
+			// Tuesday 01:00 in Cairo is Monday 23:00 UTC
+			slot1:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekMonday, Start: "22:30", Duration: 60},
+			slot2:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekTuesday, Start: "01:00", Duration: 60, Timezone: "Africa/Cairo"},
+			conflict: true,
+		},
+		{
+			name: "window wraps around the week",
+			// Monday 00:30 in Cairo is Sunday 22:30 UTC
+			slot1:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekSunday, Start: "22:00", Duration: 60},
+			slot2:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekMonday, Start: "00:30", Duration: 60, Timezone: "Africa/Cairo"},
+			conflict: true,
+		},
+		{
+			name: "overlap only while DST is in effect",
+			// New York is UTC-5 in winter and UTC-4 in summer
+			slot1:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekMonday, Start: "13:00", Duration: 60},
+			slot2:    timeslot.TimeSlot{DayOfWeek: timeslot.DayOfWeekMonday, Start: "09:30", Duration: 60, Timezone: "America/New_York"},
+			conflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasEffectiveTimeSlotConflict(tt.slot1, tt.slot2); got != tt.conflict {
+				t.Errorf("expected conflict %v, got %v", tt.conflict, got)
+			}
+			if got := HasEffectiveTimeSlotConflict(tt.slot2, tt.slot1); got != tt.conflict {
+				t.Errorf("expected conflict %v with the slots swapped, got %v", tt.conflict, got)
+			}
+		})
+	}
+}
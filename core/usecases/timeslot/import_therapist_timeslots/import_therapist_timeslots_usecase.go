@@ -0,0 +1,191 @@
+package import_therapist_timeslots
+
+import (
+	"time"
+
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
+)
+
+type Input struct {
+	TherapistID domain.TherapistID `json:"therapistId"`
+	Template    timeslot.Template  `json:"template"`
+}
+
+type Usecase struct {
+	therapistRepo   ports.TherapistRepository
+	timeslotRepo    ports.TimeSlotRepository
+	transactionPort ports.TransactionPort
+
+	minimumSessionDuration domain.DurationMinutes
+	clinicHours            timeslot.ClinicHours
+}
+
+func NewUsecase(
+	therapistRepo ports.TherapistRepository,
+	timeslotRepo ports.TimeSlotRepository,
+	transactionPort ports.TransactionPort,
+	minimumSessionDuration domain.DurationMinutes,
+	clinicHours timeslot.ClinicHours, // zero value disables the check
+) *Usecase {
+	return &Usecase{
+		therapistRepo:          therapistRepo,
+		timeslotRepo:           timeslotRepo,
+		transactionPort:        transactionPort,
+		minimumSessionDuration: minimumSessionDuration,
+		clinicHours:            clinicHours,
+	}
+}
+
+// Execute creates a timeslot for every slot in the template. Slots are
+// validated as create_therapist_timeslot would, against the therapist's
+// existing slots and each other, and either all of them are created or none.
+func (u *Usecase) Execute(input Input) ([]*timeslot.TimeSlot, error) {
+	if err := u.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, timeslot.ErrTherapistNotFound
+	}
+
+	now := domain.UTCTimestamp(time.Now().UTC())
+	newSlots := make([]*timeslot.TimeSlot, 0, len(input.Template.Timeslots))
+	for _, slot := range input.Template.Timeslots {
+		newSlots = append(newSlots, &timeslot.TimeSlot{
+			ID:                    domain.NewTimeSlotID(),
+			TherapistID:           input.TherapistID,
+			DayOfWeek:             slot.DayOfWeek,
+			Start:                 slot.StartTime,
+			Duration:              slot.DurationMinutes,
+			AdvanceNotice:         slot.AdvanceNotice,
+			AfterSessionBreakTime: slot.AfterSessionBreakTime,
+			IsActive:              slot.IsActive,
+			Timezone:              input.Template.Timezone,
+			BookingIDs:            make([]domain.BookingID, 0),
+			CreatedAt:             now,
+			UpdatedAt:             now,
+		})
+	}
+
+	if err := u.checkForOverlaps(input.TherapistID, newSlots); err != nil {
+		return nil, err
+	}
+
+	// ------------------
+	// Create timeslots (run in a transaction)
+	// ------------------
+	tx, err := u.transactionPort.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slot := range newSlots {
+		if err := u.timeslotRepo.CreateTx(tx, slot); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := u.transactionPort.Commit(tx); err != nil {
+		return nil, err
+	}
+	// ------------------
+
+	return newSlots, nil
+}
+
+func (u *Usecase) validateInput(input Input) error {
+	if input.TherapistID == "" {
+		return timeslot.ErrTherapistIDRequired
+	}
+
+	if len(input.Template.Timeslots) == 0 {
+		return timeslot.ErrTemplateIsEmpty
+	}
+
+	if err := timeslot_usecase.ValidateTimezoneName(input.Template.Timezone); err != nil {
+		return err
+	}
+
+	for _, slot := range input.Template.Timeslots {
+		if err := u.validateSlot(slot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *Usecase) validateSlot(slot timeslot.TemplateSlot) error {
+	if slot.DayOfWeek == "" {
+		return timeslot.ErrDayOfWeekIsRequired
+	}
+
+	if slot.StartTime == "" {
+		return timeslot.ErrStartTimeIsRequired
+	}
+
+	if slot.DurationMinutes == 0 {
+		return timeslot.ErrDurationIsRequired
+	}
+
+	if !timeslot_usecase.IsValidDayOfWeek(slot.DayOfWeek) {
+		return timeslot.ErrInvalidDayOfWeek
+	}
+
+	if _, err := timeslot_usecase.ParseTimeString(slot.StartTime); err != nil {
+		return err
+	}
+
+	if err := timeslot_usecase.ValidateDuration(slot.DurationMinutes); err != nil {
+		return err
+	}
+
+	if err := timeslot_usecase.ValidateBufferTimes(
+		slot.AdvanceNotice,
+		slot.AfterSessionBreakTime,
+	); err != nil {
+		return err
+	}
+
+	if err := timeslot_usecase.ValidateSessionFitsSlot(
+		slot.DurationMinutes,
+		slot.AfterSessionBreakTime,
+		u.minimumSessionDuration,
+	); err != nil {
+		return err
+	}
+
+	return timeslot_usecase.ValidateWithinClinicHours(
+		slot.StartTime,
+		slot.DurationMinutes,
+		u.clinicHours,
+	)
+}
+
+// checkForOverlaps compares every new slot with the therapist's existing
+// slots and with the new slots before it.
+func (u *Usecase) checkForOverlaps(therapistID domain.TherapistID, newSlots []*timeslot.TimeSlot) error {
+	existingSlots, err := u.timeslotRepo.ListByTherapist(therapistID)
+	if err != nil {
+		return err
+	}
+
+	for i, newSlot := range newSlots {
+		others := append(existingSlots[:len(existingSlots):len(existingSlots)], newSlots[:i]...)
+		for _, other := range others {
+			if timeslot_usecase.HasEffectiveTimeSlotConflict(*newSlot, *other) {
+				return timeslot.ErrOverlappingTimeslot
+			}
+
+			if !timeslot_usecase.HasSufficientGapBetweenSlots(*newSlot, *other) {
+				return timeslot.ErrInsufficientGapBetweenSlots
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package list_raw_therapist_timeslots
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type Input struct {
+	TherapistID domain.TherapistID `json:"therapistId"`
+}
+
+// RawTimeSlot is a timeslot exactly as stored in the time_slots table. Day and
+// start are UTC, or local to Timezone when one is set, and are never converted.
+type RawTimeSlot struct {
+	ID                    domain.TimeSlotID                   `json:"id"`
+	IsActive              bool                                `json:"isActive"`
+	DayOfWeek             timeslot.DayOfWeek                  `json:"dayOfWeek"`
+	Start                 domain.Time24h                      `json:"start"`
+	Duration              domain.DurationMinutes              `json:"duration"`
+	AdvanceNotice         domain.AdvanceNoticeMinutes         `json:"advanceNotice"`
+	AfterSessionBreakTime domain.AfterSessionBreakTimeMinutes `json:"afterSessionBreakTime"`
+	Timezone              string                              `json:"timezone"`
+	Capacity              int                                 `json:"capacity"`
+}
+
+type Usecase struct {
+	therapistRepo ports.TherapistRepository
+	timeslotRepo  ports.TimeSlotRepository
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository, timeslotRepo ports.TimeSlotRepository) *Usecase {
+	return &Usecase{
+		therapistRepo: therapistRepo,
+		timeslotRepo:  timeslotRepo,
+	}
+}
+
+// Execute returns all of the therapist's timeslots, active or not, with the
+// stored values untouched. Meant for debugging timezone issues.
+func (u *Usecase) Execute(input Input) ([]RawTimeSlot, error) {
+	if input.TherapistID == "" {
+		return nil, timeslot.ErrTherapistIDRequired
+	}
+
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, timeslot.ErrTherapistNotFound
+	}
+
+	slots, err := u.timeslotRepo.ListByTherapist(input.TherapistID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]RawTimeSlot, 0, len(slots))
+	for _, slot := range slots {
+		result = append(result, RawTimeSlot{
+			ID:                    slot.ID,
+			IsActive:              slot.IsActive,
+			DayOfWeek:             slot.DayOfWeek,
+			Start:                 slot.Start,
+			Duration:              slot.Duration,
+			AdvanceNotice:         slot.AdvanceNotice,
+			AfterSessionBreakTime: slot.AfterSessionBreakTime,
+			Timezone:              slot.Timezone,
+			Capacity:              slot.Capacity,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package list_recurring_blocks
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type Usecase struct {
+	therapistRepo      ports.TherapistRepository
+	recurringBlockRepo ports.RecurringBlockRepository
+}
+
+func NewUsecase(therapistRepo ports.TherapistRepository, recurringBlockRepo ports.RecurringBlockRepository) *Usecase {
+	return &Usecase{
+		therapistRepo:      therapistRepo,
+		recurringBlockRepo: recurringBlockRepo,
+	}
+}
+
+func (u *Usecase) Execute(therapistID domain.TherapistID) ([]*timeslot.RecurringBlock, error) {
+	if therapistID == "" {
+		return nil, timeslot.ErrTherapistIDRequired
+	}
+
+	// Verify therapist exists
+	if _, err := u.therapistRepo.GetByID(therapistID); err != nil {
+		return nil, timeslot.ErrTherapistNotFound
+	}
+
+	return u.recurringBlockRepo.ListByTherapist(therapistID)
+}
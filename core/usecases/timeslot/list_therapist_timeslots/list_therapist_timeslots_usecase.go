@@ -9,6 +9,7 @@ import (
 
 type Input struct {
 	TherapistID domain.TherapistID `json:"therapistId"`
+	ActiveOnly  bool               `json:"activeOnly"`
 }
 
 type Usecase struct {
@@ -36,7 +37,11 @@ func (u *Usecase) Execute(input Input) ([]timeslot.TimeSlot, error) {
 
 	var timeslots []*timeslot.TimeSlot
 	var err error
-	timeslots, err = u.timeslotRepo.ListByTherapist(input.TherapistID)
+	if input.ActiveOnly {
+		timeslots, err = u.timeslotRepo.ListActiveByTherapist(input.TherapistID)
+	} else {
+		timeslots, err = u.timeslotRepo.ListByTherapist(input.TherapistID)
+	}
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,66 @@
+package list_timeslot_bookings
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/booking"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+)
+
+type Input struct {
+	TherapistID domain.TherapistID     `json:"therapistId"`
+	TimeslotID  domain.TimeSlotID      `json:"timeslotId"`
+	States      []booking.BookingState `json:"states"` // Optional, empty means every state
+}
+
+type Usecase struct {
+	therapistRepo ports.TherapistRepository
+	timeslotRepo  ports.TimeSlotRepository
+	bookingRepo   ports.BookingRepository
+}
+
+func NewUsecase(
+	therapistRepo ports.TherapistRepository,
+	timeslotRepo ports.TimeSlotRepository,
+	bookingRepo ports.BookingRepository,
+) *Usecase {
+	return &Usecase{
+		therapistRepo: therapistRepo,
+		timeslotRepo:  timeslotRepo,
+		bookingRepo:   bookingRepo,
+	}
+}
+
+// Execute returns the bookings made against the therapist's timeslot,
+// ordered by start time
+func (u *Usecase) Execute(input Input) ([]*booking.Booking, error) {
+	// Validate input
+	if input.TherapistID == "" {
+		return nil, timeslot.ErrTherapistIDRequired
+	}
+	if input.TimeslotID == "" {
+		return nil, timeslot.ErrTimeslotIDIsRequired
+	}
+
+	// Verify therapist exists
+	if _, err := u.therapistRepo.GetByID(input.TherapistID); err != nil {
+		return nil, timeslot.ErrTherapistNotFound
+	}
+
+	// Get the timeslot
+	timeslotResult, err := u.timeslotRepo.GetByID(input.TimeslotID)
+	if err != nil {
+		// Check if it's the repository's not found error
+		if err.Error() == "timeslot not found" {
+			return nil, timeslot.ErrTimeslotNotFound
+		}
+		return nil, err
+	}
+
+	// Verify the timeslot belongs to the specified therapist
+	if timeslotResult.TherapistID != input.TherapistID {
+		return nil, timeslot.ErrTimeslotNotOwned
+	}
+
+	return u.bookingRepo.ListByTimeSlot(input.TimeslotID, input.States)
+}
@@ -0,0 +1,67 @@
+package update_recurring_block
+
+import (
+	"github.com/mishkahtherapy/brain/core/domain"
+	"github.com/mishkahtherapy/brain/core/domain/timeslot"
+	"github.com/mishkahtherapy/brain/core/ports"
+	timeslot_usecase "github.com/mishkahtherapy/brain/core/usecases/timeslot"
+)
+
+type Input struct {
+	TherapistID     domain.TherapistID      `json:"therapistId"`
+	BlockID         domain.RecurringBlockID `json:"blockId"`
+	DayOfWeek       string                  `json:"dayOfWeek"`
+	StartTime       domain.Time24h          `json:"start"`
+	DurationMinutes domain.DurationMinutes  `json:"duration"`
+	Timezone        string                  `json:"timezone"`
+}
+
+type Usecase struct {
+	recurringBlockRepo ports.RecurringBlockRepository
+}
+
+func NewUsecase(recurringBlockRepo ports.RecurringBlockRepository) *Usecase {
+	return &Usecase{recurringBlockRepo: recurringBlockRepo}
+}
+
+func (u *Usecase) Execute(input Input) (*timeslot.RecurringBlock, error) {
+	if input.TherapistID == "" {
+		return nil, timeslot.ErrTherapistIDRequired
+	}
+
+	if input.BlockID == "" {
+		return nil, timeslot.ErrRecurringBlockIDIsRequired
+	}
+
+	if err := timeslot_usecase.ValidateRecurringBlock(
+		input.DayOfWeek,
+		input.StartTime,
+		input.DurationMinutes,
+		input.Timezone,
+	); err != nil {
+		return nil, err
+	}
+
+	block, err := u.recurringBlockRepo.GetByID(input.BlockID)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, timeslot.ErrRecurringBlockNotFound
+	}
+	if block.TherapistID != input.TherapistID {
+		return nil, timeslot.ErrRecurringBlockNotOwned
+	}
+
+	block.DayOfWeek = timeslot.DayOfWeek(input.DayOfWeek)
+	block.Start = input.StartTime
+	block.Duration = input.DurationMinutes
+	block.Timezone = input.Timezone
+	block.UpdatedAt = domain.NewUTCTimestamp()
+
+	if err := u.recurringBlockRepo.Update(block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
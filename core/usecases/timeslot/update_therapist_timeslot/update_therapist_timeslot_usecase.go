@@ -19,17 +19,25 @@ type Input struct {
 	AdvanceNotice         domain.AdvanceNoticeMinutes         `json:"advanceNotice"`         // minutes
 	AfterSessionBreakTime domain.AfterSessionBreakTimeMinutes `json:"afterSessionBreakTime"` // minutes
 	IsActive              bool                                `json:"isActive"`
+	Timezone              string                              `json:"timezone"` // Optional IANA name, e.g. "America/New_York"
+	Capacity              int                                 `json:"capacity"` // Optional, the current capacity is kept when omitted
 }
 
 type Usecase struct {
 	therapistRepo ports.TherapistRepository
 	timeslotRepo  ports.TimeSlotRepository
+	clinicHours   timeslot.ClinicHours
 }
 
-func NewUsecase(therapistRepo ports.TherapistRepository, timeslotRepo ports.TimeSlotRepository) *Usecase {
+func NewUsecase(
+	therapistRepo ports.TherapistRepository,
+	timeslotRepo ports.TimeSlotRepository,
+	clinicHours timeslot.ClinicHours, // zero value disables the check
+) *Usecase {
 	return &Usecase{
 		therapistRepo: therapistRepo,
 		timeslotRepo:  timeslotRepo,
+		clinicHours:   clinicHours,
 	}
 }
 
@@ -64,6 +72,12 @@ func (u *Usecase) Execute(input Input) (*timeslot.TimeSlot, error) {
 		return nil, err
 	}
 
+	// Keep the current capacity unless a new one is given
+	capacity := existingTimeslot.BookingCapacity()
+	if input.Capacity > 0 {
+		capacity = input.Capacity
+	}
+
 	// Update the timeslot
 	updatedTimeslot := &timeslot.TimeSlot{
 		ID:                    input.TimeslotID,
@@ -74,6 +88,8 @@ func (u *Usecase) Execute(input Input) (*timeslot.TimeSlot, error) {
 		AdvanceNotice:         input.AdvanceNotice,
 		AfterSessionBreakTime: input.AfterSessionBreakTime,
 		IsActive:              input.IsActive,
+		Timezone:              input.Timezone,
+		Capacity:              capacity,
 		BookingIDs:            existingTimeslot.BookingIDs, // Preserve existing bookings
 		CreatedAt:             existingTimeslot.CreatedAt,  // Preserve creation time
 		UpdatedAt:             domain.UTCTimestamp(time.Now().UTC()),
@@ -132,6 +148,25 @@ func (u *Usecase) validateInput(input Input) error {
 		return err
 	}
 
+	// Validate optional timezone name
+	if err := timeslot_usecase.ValidateTimezoneName(input.Timezone); err != nil {
+		return err
+	}
+
+	// Validate optional capacity
+	if err := timeslot_usecase.ValidateCapacity(input.Capacity); err != nil {
+		return err
+	}
+
+	// The slot is stored in local time, so compare it to clinic hours as is
+	if err := timeslot_usecase.ValidateWithinClinicHours(
+		input.Start,
+		input.Duration,
+		u.clinicHours,
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,2 @@
+ALTER TABLE time_slots
+ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS booking_audit (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id VARCHAR(128) NOT NULL,
+    from_state VARCHAR(20) NOT NULL DEFAULT '',
+    to_state VARCHAR(20) NOT NULL,
+    changed_at DATETIME NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    CONSTRAINT fk_booking_audit_booking FOREIGN KEY (booking_id) REFERENCES bookings (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_booking_audit_booking ON booking_audit (booking_id);
@@ -0,0 +1,2 @@
+ALTER TABLE therapists
+ADD COLUMN notification_preferences TEXT NOT NULL DEFAULT '{"confirmations":true,"reminders":true}';
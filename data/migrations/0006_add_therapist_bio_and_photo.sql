@@ -0,0 +1,5 @@
+ALTER TABLE therapists
+ADD COLUMN bio TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE therapists
+ADD COLUMN photo_url VARCHAR(2048) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE sessions
+ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
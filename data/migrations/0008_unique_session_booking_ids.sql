@@ -0,0 +1,6 @@
+-- Sessions used to store the booking id they were not created from as an
+-- empty string, which collides under the UNIQUE constraints
+-- sessions.regular_booking_id and sessions.adhoc_booking_id already carry
+-- (see schema.sql), and which confirming a booking now relies on.
+UPDATE sessions SET regular_booking_id = NULL WHERE regular_booking_id = '';
+UPDATE sessions SET adhoc_booking_id = NULL WHERE adhoc_booking_id = '';
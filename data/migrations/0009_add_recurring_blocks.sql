@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS recurring_blocks (
+    id VARCHAR(128) PRIMARY KEY,
+    therapist_id VARCHAR(128) NOT NULL,
+    day_of_week VARCHAR(10) NOT NULL CHECK (
+        day_of_week IN (
+            'Monday',
+            'Tuesday',
+            'Wednesday',
+            'Thursday',
+            'Friday',
+            'Saturday',
+            'Sunday'
+        )
+    ), -- UTC day, or local to timezone when set
+    start_time TIME NOT NULL, -- UTC time, or local to timezone when set
+    duration_minutes INTEGER NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT '', -- optional IANA name
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_recurring_blocks_therapist FOREIGN KEY (therapist_id) REFERENCES therapists (id) ON DELETE CASCADE,
+    CONSTRAINT check_recurring_block_duration CHECK (
+        duration_minutes > 0
+        AND duration_minutes <= 1440
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_blocks_therapist ON recurring_blocks (therapist_id);
@@ -0,0 +1,18 @@
+-- booking_audit rows were deleted along with their booking. SQLite can't drop
+-- a constraint, so rebuild the table without the foreign key.
+CREATE TABLE booking_audit_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id VARCHAR(128) NOT NULL,
+    from_state VARCHAR(20) NOT NULL DEFAULT '',
+    to_state VARCHAR(20) NOT NULL,
+    changed_at DATETIME NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+INSERT INTO booking_audit_new (id, booking_id, from_state, to_state, changed_at, actor)
+SELECT id, booking_id, from_state, to_state, changed_at, actor FROM booking_audit;
+
+DROP TABLE booking_audit;
+ALTER TABLE booking_audit_new RENAME TO booking_audit;
+
+CREATE INDEX idx_booking_audit_booking ON booking_audit (booking_id);
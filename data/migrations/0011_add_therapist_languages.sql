@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS therapist_languages (
+    therapist_id VARCHAR(128) NOT NULL,
+    language_code VARCHAR(8) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (therapist_id, language_code),
+    CONSTRAINT fk_therapist_languages_therapist FOREIGN KEY (therapist_id) REFERENCES therapists (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_therapist_languages_code ON therapist_languages (language_code);
+
+-- speaks_english was the only language flag until now
+INSERT INTO therapist_languages (therapist_id, language_code)
+SELECT id, 'en' FROM therapists WHERE speaks_english = 1;
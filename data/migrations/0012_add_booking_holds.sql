@@ -0,0 +1,60 @@
+-- Bookings gain the held state, reserving their time during checkout. SQLite
+-- can't alter a CHECK constraint, so rebuild the table. Foreign keys are
+-- switched off so dropping the old table doesn't touch rows referencing it.
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE bookings_new (
+    id VARCHAR(128) PRIMARY KEY,
+    timeslot_id VARCHAR(128) NOT NULL,
+    therapist_id VARCHAR(128) NOT NULL,
+    client_id VARCHAR(128) NOT NULL,
+    start_time DATETIME NOT NULL, -- Specific start datetime for this booking
+    duration_minutes INTEGER NOT NULL, -- Duration in minutes (e.g., 60, 120, 480)
+    client_timezone_offset INTEGER NOT NULL, -- Frontend hint for timezone adjustments (minutes ahead of UTC)
+    state VARCHAR(20) DEFAULT 'pending' CHECK (
+        state IN (
+            'pending',
+            'confirmed',
+            'cancelled',
+            'held'
+        )
+    ),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_bookings_timeslot FOREIGN KEY (timeslot_id) REFERENCES time_slots (id) ON DELETE NO ACTION,
+    CONSTRAINT fk_bookings_therapist FOREIGN KEY (therapist_id) REFERENCES therapists (id) ON DELETE NO ACTION,
+    CONSTRAINT fk_bookings_client FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE NO ACTION
+);
+
+INSERT INTO bookings_new (id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at)
+SELECT id, timeslot_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, state, created_at, updated_at FROM bookings;
+
+DROP TABLE bookings;
+ALTER TABLE bookings_new RENAME TO bookings;
+
+CREATE INDEX idx_bookings_therapist ON bookings (therapist_id);
+
+CREATE INDEX idx_bookings_client ON bookings (client_id);
+
+CREATE INDEX idx_bookings_start_time ON bookings (start_time);
+
+CREATE INDEX idx_bookings_therapist_start_time ON bookings (therapist_id, start_time);
+
+CREATE INDEX idx_bookings_state ON bookings (state);
+
+CREATE UNIQUE INDEX idx_no_overlapping_bookings ON bookings (therapist_id, start_time)
+WHERE
+    state = 'confirmed';
+
+PRAGMA foreign_keys = ON;
+
+-- Holds of bookings in the held state, released once they expire
+CREATE TABLE IF NOT EXISTS booking_holds (
+    token VARCHAR(128) PRIMARY KEY,
+    booking_id VARCHAR(128) NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_booking_holds_booking FOREIGN KEY (booking_id) REFERENCES bookings (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_booking_holds_expires_at ON booking_holds (expires_at);
@@ -0,0 +1,3 @@
+-- Session language used when a confirmation omits one, empty for none
+ALTER TABLE therapists
+ADD COLUMN default_language VARCHAR(16) NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+-- Optional client email, lowercased and unique; NULL when missing. SQLite
+-- can't add a UNIQUE column, so uniqueness comes from an index instead.
+ALTER TABLE clients
+ADD COLUMN email VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_clients_email ON clients (email);
+
+-- A missing WhatsApp number is now stored as NULL rather than empty, letting
+-- several clients go without one despite the UNIQUE constraint.
+UPDATE clients SET whatsapp_number = NULL WHERE whatsapp_number = '';
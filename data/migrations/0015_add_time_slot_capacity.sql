@@ -0,0 +1,11 @@
+-- Concurrent confirmed bookings a slot allows, > 1 for group sessions
+ALTER TABLE time_slots
+ADD COLUMN capacity INTEGER NOT NULL DEFAULT 1 CHECK (capacity >= 1);
+
+-- Group slots take several clients at once, so a confirmed booking only
+-- excludes the same client; capacity is checked on confirm.
+DROP INDEX idx_no_overlapping_bookings;
+
+CREATE UNIQUE INDEX idx_no_overlapping_bookings ON bookings (therapist_id, start_time, client_id)
+WHERE
+    state = 'confirmed';
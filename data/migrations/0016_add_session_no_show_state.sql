@@ -0,0 +1,57 @@
+-- Sessions gain the no_show state, for clients who never turned up. SQLite
+-- can't alter a CHECK constraint, so rebuild the table. Foreign keys are
+-- switched off so dropping the old table doesn't touch rows referencing it.
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE sessions_new (
+    id VARCHAR(128) PRIMARY KEY,
+    regular_booking_id VARCHAR(128) NULL UNIQUE,
+    adhoc_booking_id VARCHAR(128) NULL UNIQUE,
+    therapist_id VARCHAR(128) NOT NULL,
+    client_id VARCHAR(128) NOT NULL,
+    start_time DATETIME NOT NULL,
+    duration_minutes INTEGER NOT NULL,
+    client_timezone_offset INTEGER NOT NULL,
+    paid_amount INTEGER NOT NULL, -- Smallest unit of currency, e.g. cents
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD', -- ISO 4217 code
+    language VARCHAR(10) NOT NULL CHECK (
+        language IN ('arabic', 'english')
+    ),
+    state VARCHAR(20) NOT NULL DEFAULT 'planned' CHECK (
+        state IN (
+            'planned',
+            'done',
+            'rescheduled',
+            'cancelled',
+            'refunded',
+            'no_show'
+        )
+    ),
+    notes TEXT,
+    meeting_url VARCHAR(512),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_sessions_therapist FOREIGN KEY (therapist_id) REFERENCES therapists (id) ON DELETE NO ACTION,
+    CONSTRAINT fk_sessions_client FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE NO ACTION
+);
+
+INSERT INTO sessions_new (id, regular_booking_id, adhoc_booking_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, paid_amount, currency, language, state, notes, meeting_url, created_at, updated_at)
+SELECT id, regular_booking_id, adhoc_booking_id, therapist_id, client_id, start_time, duration_minutes, client_timezone_offset, paid_amount, currency, language, state, notes, meeting_url, created_at, updated_at FROM sessions;
+
+DROP TABLE sessions;
+ALTER TABLE sessions_new RENAME TO sessions;
+
+CREATE INDEX idx_sessions_regular_booking ON sessions (regular_booking_id);
+
+CREATE INDEX idx_sessions_therapist ON sessions (therapist_id);
+
+CREATE INDEX idx_sessions_client ON sessions (client_id);
+
+CREATE INDEX idx_sessions_state ON sessions (state);
+
+CREATE INDEX idx_sessions_start_time ON sessions (start_time);
+
+CREATE INDEX idx_sessions_therapist_start_time ON sessions (therapist_id, start_time);
+
+
+PRAGMA foreign_keys = ON;
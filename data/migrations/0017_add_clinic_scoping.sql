@@ -0,0 +1,34 @@
+-- Therapists, clients and specializations belong to a clinic, so several
+-- clinics can share one deployment. Existing rows go to the default clinic.
+ALTER TABLE therapists
+ADD COLUMN clinic_id VARCHAR(128) NOT NULL DEFAULT 'default';
+
+CREATE INDEX idx_therapists_clinic ON therapists (clinic_id);
+
+ALTER TABLE clients
+ADD COLUMN clinic_id VARCHAR(128) NOT NULL DEFAULT 'default';
+
+CREATE INDEX idx_clients_clinic ON clients (clinic_id);
+
+-- Specialization names become unique per clinic. SQLite can't alter a UNIQUE
+-- constraint, so rebuild the table. Foreign keys are switched off so dropping
+-- the old table doesn't touch therapist_specializations rows referencing it.
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE specializations_new (
+    id VARCHAR(128) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    clinic_id VARCHAR(128) NOT NULL DEFAULT 'default', -- Owning clinic, see domain.ClinicID
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    -- Each clinic names its own specializations
+    CONSTRAINT unique_clinic_specialization_name UNIQUE (clinic_id, name)
+);
+
+INSERT INTO specializations_new (id, name, created_at, updated_at)
+SELECT id, name, created_at, updated_at FROM specializations;
+
+DROP TABLE specializations;
+ALTER TABLE specializations_new RENAME TO specializations;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,3 @@
+-- Cap on held, pending and confirmed bookings per local day, 0 for no cap
+ALTER TABLE therapists
+ADD COLUMN max_daily_sessions INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Set once the client is merged into another; deleted clients are hidden
+ALTER TABLE clients
+ADD COLUMN deleted_at DATETIME NULL;
@@ -1,65 +1,125 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"time"
+	_ "time/tzdata" // embed IANA zones so timeslot timezones resolve in minimal images
 
 	"github.com/mishkahtherapy/brain/adapters/api"
 	bookingHandler "github.com/mishkahtherapy/brain/adapters/api/booking"
 	clientHandler "github.com/mishkahtherapy/brain/adapters/api/client"
+	recurringBlockHandler "github.com/mishkahtherapy/brain/adapters/api/recurring_block"
 	scheduleHandler "github.com/mishkahtherapy/brain/adapters/api/schedule"
 	specializationHandler "github.com/mishkahtherapy/brain/adapters/api/specialization"
 	"github.com/mishkahtherapy/brain/adapters/api/test"
 	therapistHandler "github.com/mishkahtherapy/brain/adapters/api/therapist"
 	timeslotHandler "github.com/mishkahtherapy/brain/adapters/api/timeslot"
+	"github.com/mishkahtherapy/brain/adapters/cache/schedule_cache"
 	"github.com/mishkahtherapy/brain/adapters/db"
 	"github.com/mishkahtherapy/brain/adapters/db/adhoc_booking_db"
 	"github.com/mishkahtherapy/brain/adapters/db/booking_db"
+	"github.com/mishkahtherapy/brain/adapters/db/booking_hold_db"
 	"github.com/mishkahtherapy/brain/adapters/db/client_db"
+	"github.com/mishkahtherapy/brain/adapters/db/clinic_db"
 	"github.com/mishkahtherapy/brain/adapters/db/notification_db"
+	"github.com/mishkahtherapy/brain/adapters/db/recurring_block_db"
+	"github.com/mishkahtherapy/brain/adapters/db/seed"
 	"github.com/mishkahtherapy/brain/adapters/db/session_db"
 	"github.com/mishkahtherapy/brain/adapters/db/specialization_db"
 	"github.com/mishkahtherapy/brain/adapters/db/therapist_db"
 	"github.com/mishkahtherapy/brain/adapters/db/timeslot_db"
+	"github.com/mishkahtherapy/brain/adapters/events/booking_events"
 	firebase_notifier "github.com/mishkahtherapy/brain/adapters/firebase"
+	"github.com/mishkahtherapy/brain/adapters/webhook"
 	"github.com/mishkahtherapy/brain/config"
+	"github.com/mishkahtherapy/brain/core/ports"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/cancel_future_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_adhoc_booking"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking/confirm_regular_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/confirm_booking_hold"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/create_adhoc_booking"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/create_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_calendar"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_history"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_booking_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_confirmation_preview"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/get_lead_time_stats"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/hold_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reactivate_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/reassign_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/release_expired_holds"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/resend_confirmation_notification"
 	"github.com/mishkahtherapy/brain/core/usecases/booking/search_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/stream_booking_events"
+	"github.com/mishkahtherapy/brain/core/usecases/booking/update_booking_duration"
 	"github.com/mishkahtherapy/brain/core/usecases/client/create_client"
 	"github.com/mishkahtherapy/brain/core/usecases/client/get_all_clients"
 	"github.com/mishkahtherapy/brain/core/usecases/client/get_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_email"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_by_whatsapp"
+	"github.com/mishkahtherapy/brain/core/usecases/client/get_client_summary"
+	"github.com/mishkahtherapy/brain/core/usecases/client/merge_clients"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_client"
+	"github.com/mishkahtherapy/brain/core/usecases/client/update_timezone"
+	"github.com/mishkahtherapy/brain/core/usecases/clinic/check_clinic"
 	"github.com/mishkahtherapy/brain/core/usecases/notification/notify_therapist_new_booking"
+	"github.com/mishkahtherapy/brain/core/usecases/notification/notify_therapist_session_reminders"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/check_availability"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_availability_days"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_next_availability"
 	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_schedule"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_therapist_availability_report"
+	"github.com/mishkahtherapy/brain/core/usecases/schedule/get_upcoming_schedule"
 	"github.com/mishkahtherapy/brain/core/usecases/session/get_meeting_link"
 	"github.com/mishkahtherapy/brain/core/usecases/session/get_session"
+	"github.com/mishkahtherapy/brain/core/usecases/session/get_session_context"
+	"github.com/mishkahtherapy/brain/core/usecases/session/get_session_therapist"
 	"github.com/mishkahtherapy/brain/core/usecases/session/list_sessions_admin"
 	"github.com/mishkahtherapy/brain/core/usecases/session/list_sessions_by_client"
 	"github.com/mishkahtherapy/brain/core/usecases/session/list_sessions_by_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/session/list_sessions_missing_meeting_url"
+	"github.com/mishkahtherapy/brain/core/usecases/session/mark_no_show_sessions"
 	"github.com/mishkahtherapy/brain/core/usecases/session/update_meeting_url"
 	"github.com/mishkahtherapy/brain/core/usecases/session/update_session_notes"
 	"github.com/mishkahtherapy/brain/core/usecases/session/update_session_state"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/delete_specialization"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_all_specializations"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_counts"
+	"github.com/mishkahtherapy/brain/core/usecases/specialization/get_specialization_therapists"
 	"github.com/mishkahtherapy/brain/core/usecases/specialization/new_specialization"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_all_therapists"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/get_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/list_therapists_by_device"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/new_therapist"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_max_daily_sessions"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_notification_preferences"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_device"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_info"
+	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_languages"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_therapist_specializations"
 	"github.com/mishkahtherapy/brain/core/usecases/therapist/update_timezone_offset"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/bulk_toggle_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_recurring_block"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/create_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_recurring_block"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/delete_therapist_timeslots_for_day"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/export_therapist_timeslots"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/get_therapist_timeslot"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/import_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_raw_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_recurring_blocks"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_therapist_timeslots"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/list_timeslot_bookings"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/set_therapist_timeslot_active"
+	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_recurring_block"
 	"github.com/mishkahtherapy/brain/core/usecases/timeslot/update_therapist_timeslot"
 
 	_ "github.com/glebarez/go-sqlite" // SQLite driver
@@ -82,25 +142,63 @@ func main() {
 	bookingConfig := config.GetBookingConfig()
 	database := db.NewDatabase(dbConfig)
 	notificationConfig := config.GetNotificationConfig()
+	sessionConfig := config.GetSessionConfig()
+	timeSlotConfig := config.GetTimeSlotConfig()
+	scheduleConfig := config.GetScheduleConfig()
+	serverConfig := config.GetServerConfig()
+	webhookConfig := config.GetWebhookConfig()
 	defer database.Close()
 
 	slog.Info("Database initialized successfully", slog.Group("db", "name", dbConfig.DBFilename, "schema", dbConfig.SchemaFile))
 
+	// "brain seed" fills a development database with demo data instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		runSeed(database, os.Args[2:])
+		return
+	}
+
 	// Initialize repositories
 	specializationRepo := specialization_db.NewSpecializationRepository(database)
 	therapistRepo := therapist_db.NewTherapistRepository(database)
 	clientRepo := client_db.NewClientRepository(database)
 	bookingRepo := booking_db.NewBookingRepository(database)
 	adhocBookingRepo := adhoc_booking_db.NewAdhocBookingRepository(database)
+	bookingHoldRepo := booking_hold_db.NewBookingHoldRepository(database)
 	sessionRepo := session_db.NewSessionRepository(database)
 	timeSlotRepo := timeslot_db.NewTimeSlotRepository(database)
+	recurringBlockRepo := recurring_block_db.NewRecurringBlockRepository(database)
+	clinicRepo := clinic_db.NewClinicRepository(database)
 	notificationPort := firebase_notifier.NewFirebaseNotifier(notificationConfig.FirebaseServiceAccountPath)
 	notificationRepo := notification_db.NewNotificationRepository(database)
 	transactionRepo := db.NewSQLTransactionRepo(database)
+	unitOfWork := db.NewSQLUnitOfWork(database)
+	bookingEvents := booking_events.NewBroker()
+
+	// Usecases publish booking events to the broker, and to the integrator's webhook when configured
+	var bookingEventPublisher ports.BookingEventPublisher = bookingEvents
+	if webhookConfig.URL != "" {
+		bookingEventPublisher = booking_events.Fanout{bookingEvents, webhook.NewPublisher(webhookConfig.URL, webhookConfig.Secret)}
+	}
+
+	// Cache computed schedules, dropping them whenever therapists, bookings or timeslots change
+	var scheduleCache ports.ScheduleCache
+	if scheduleConfig.CacheTTL > 0 {
+		scheduleCache = schedule_cache.NewScheduleCache(scheduleConfig.CacheTTL)
+		therapistRepo = schedule_cache.NewTherapistRepository(therapistRepo, scheduleCache)
+		bookingRepo = schedule_cache.NewBookingRepository(bookingRepo, scheduleCache)
+		adhocBookingRepo = schedule_cache.NewAdhocBookingRepository(adhocBookingRepo, scheduleCache)
+		timeSlotRepo = schedule_cache.NewTimeSlotRepository(timeSlotRepo, scheduleCache)
+		recurringBlockRepo = schedule_cache.NewRecurringBlockRepository(recurringBlockRepo, scheduleCache)
+		specializationRepo = schedule_cache.NewSpecializationRepository(specializationRepo, scheduleCache)
+	}
+
 	// Initialize specialization usecases
 	newSpecializationUsecase := new_specialization.NewUsecase(specializationRepo)
 	getAllSpecializationsUsecase := get_all_specializations.NewUsecase(specializationRepo)
 	getSpecializationUsecase := get_specialization.NewUsecase(specializationRepo)
+	getSpecializationCountsUsecase := get_specialization_counts.NewUsecase(specializationRepo)
+	getSpecializationTherapistsUsecase := get_specialization_therapists.NewUsecase(specializationRepo)
+	deleteSpecializationUsecase := delete_specialization.NewUsecase(specializationRepo, unitOfWork)
 
 	// Initialize therapist usecases
 	newTherapistUsecase := new_therapist.NewUsecase(therapistRepo, specializationRepo)
@@ -108,21 +206,53 @@ func main() {
 	getTherapistUsecase := get_therapist.NewUsecase(therapistRepo)
 	updateTherapistInfoUsecase := update_therapist_info.NewUsecase(therapistRepo)
 	updateTherapistSpecializationsUsecase := update_therapist_specializations.NewUsecase(therapistRepo, specializationRepo)
+	updateTherapistLanguagesUsecase := update_therapist_languages.NewUsecase(therapistRepo)
+	updateMaxDailySessionsUsecase := update_max_daily_sessions.NewUsecase(therapistRepo)
 	updateTherapistDeviceUsecase := update_therapist_device.NewUsecase(therapistRepo, notificationPort)
 	updateTherapistTimezoneOffsetUsecase := update_timezone_offset.NewUsecase(therapistRepo)
+	updateNotificationPreferencesUsecase := update_notification_preferences.NewUsecase(therapistRepo)
+	listTherapistsByDeviceUsecase := list_therapists_by_device.NewUsecase(therapistRepo)
 
 	// Initialize timeslot usecases
-	createTherapistTimeslotUsecase := create_therapist_timeslot.NewUsecase(therapistRepo, timeSlotRepo)
-	getTherapistTimeslotUsecase := get_therapist_timeslot.NewUsecase(therapistRepo, timeSlotRepo)
-	updateTherapistTimeslotUsecase := update_therapist_timeslot.NewUsecase(therapistRepo, timeSlotRepo)
-	deleteTherapistTimeslotUsecase := delete_therapist_timeslot.NewUsecase(therapistRepo, timeSlotRepo)
+	createTherapistTimeslotUsecase := create_therapist_timeslot.NewUsecase(
+		therapistRepo,
+		timeSlotRepo,
+		timeSlotConfig.MaxSlotDurationWarning,
+		bookingConfig.MinimumBookingTime(),
+		timeSlotConfig.ClinicHours,
+	)
+	getTherapistTimeslotUsecase := get_therapist_timeslot.NewUsecase(therapistRepo, timeSlotRepo, bookingRepo)
+	updateTherapistTimeslotUsecase := update_therapist_timeslot.NewUsecase(therapistRepo, timeSlotRepo, timeSlotConfig.ClinicHours)
+	deleteTherapistTimeslotUsecase := delete_therapist_timeslot.NewUsecase(therapistRepo, timeSlotRepo, transactionRepo)
 	listTherapistTimeslotsUsecase := list_therapist_timeslots.NewUsecase(therapistRepo, timeSlotRepo)
-	bulkToggleTherapistTimeslotsUsecase := bulk_toggle_therapist_timeslots.NewUsecase(therapistRepo, timeSlotRepo)
+	bulkToggleTherapistTimeslotsUsecase := bulk_toggle_therapist_timeslots.NewUsecase(therapistRepo, timeSlotRepo, transactionRepo)
+	setTherapistTimeslotActiveUsecase := set_therapist_timeslot_active.NewUsecase(therapistRepo, timeSlotRepo)
+	listTimeslotBookingsUsecase := list_timeslot_bookings.NewUsecase(therapistRepo, timeSlotRepo, bookingRepo)
+	deleteTherapistTimeslotsForDayUsecase := delete_therapist_timeslots_for_day.NewUsecase(therapistRepo, timeSlotRepo, transactionRepo)
+	exportTherapistTimeslotsUsecase := export_therapist_timeslots.NewUsecase(therapistRepo, timeSlotRepo)
+	importTherapistTimeslotsUsecase := import_therapist_timeslots.NewUsecase(
+		therapistRepo,
+		timeSlotRepo,
+		transactionRepo,
+		bookingConfig.MinimumBookingTime(),
+		timeSlotConfig.ClinicHours,
+	)
+	listRawTherapistTimeslotsUsecase := list_raw_therapist_timeslots.NewUsecase(therapistRepo, timeSlotRepo)
+	createRecurringBlockUsecase := create_recurring_block.NewUsecase(therapistRepo, recurringBlockRepo)
+	listRecurringBlocksUsecase := list_recurring_blocks.NewUsecase(therapistRepo, recurringBlockRepo)
+	updateRecurringBlockUsecase := update_recurring_block.NewUsecase(recurringBlockRepo)
+	deleteRecurringBlockUsecase := delete_recurring_block.NewUsecase(recurringBlockRepo)
 
 	// Initialize client usecases
 	createClientUsecase := create_client.NewUsecase(clientRepo)
 	getAllClientsUsecase := get_all_clients.NewUsecase(clientRepo)
 	getClientUsecase := get_client.NewUsecase(clientRepo)
+	getClientByWhatsAppUsecase := get_client_by_whatsapp.NewUsecase(clientRepo)
+	updateClientTimezoneUsecase := update_timezone.NewUsecase(clientRepo)
+	getClientSummaryUsecase := get_client_summary.NewUsecase(clientRepo)
+	updateClientUsecase := update_client.NewUsecase(clientRepo)
+	getClientByEmailUsecase := get_client_by_email.NewUsecase(clientRepo)
+	mergeClientsUsecase := merge_clients.NewUsecase(clientRepo, unitOfWork)
 
 	// Initialize schedule usecases
 	getScheduleUsecase := get_schedule.NewUsecase(
@@ -130,14 +260,34 @@ func main() {
 		timeSlotRepo,
 		bookingRepo,
 		adhocBookingRepo,
+		recurringBlockRepo,
 		bookingConfig.MinimumBookingTime(),
+		scheduleCache,
+		scheduleConfig.MergeGap,
+	)
+	checkAvailabilityUsecase := check_availability.NewUsecase(
+		bookingRepo,
+		adhocBookingRepo,
+		timeSlotRepo,
+		*getScheduleUsecase,
 	)
+	getNextAvailabilityUsecase := get_next_availability.NewUsecase(*getScheduleUsecase)
+	getAvailabilityDaysUsecase := get_availability_days.NewUsecase(*getScheduleUsecase)
+	getTherapistAvailabilityReportUsecase := get_therapist_availability_report.NewUsecase(therapistRepo, *getScheduleUsecase)
+	getUpcomingScheduleUsecase := get_upcoming_schedule.NewUsecase(therapistRepo, *getScheduleUsecase, scheduleConfig.MaxLookaheadDays)
 	notifyTherapistUsecase := notify_therapist_new_booking.NewUsecase(
 		therapistRepo,
 		notificationPort,
 		notificationRepo,
 		notificationConfig.TherapistAppBaseURL,
 	)
+	sendSessionRemindersUsecase := notify_therapist_session_reminders.NewUsecase(
+		sessionRepo,
+		therapistRepo,
+		notificationPort,
+		notificationRepo,
+		notificationConfig.TherapistAppBaseURL,
+	)
 
 	// Initialize booking usecases
 	createBookingUsecase := create_booking.NewUsecase(
@@ -145,7 +295,8 @@ func main() {
 		therapistRepo,
 		clientRepo,
 		timeSlotRepo,
-		*getScheduleUsecase,
+		*checkAvailabilityUsecase,
+		bookingEventPublisher,
 	)
 	createAdhocBookingUsecase := create_adhoc_booking.NewUsecase(
 		bookingRepo,
@@ -162,8 +313,12 @@ func main() {
 		notificationPort,
 		notificationRepo,
 		notificationConfig.TherapistAppBaseURL,
-		transactionRepo,
+		unitOfWork,
 		notifyTherapistUsecase,
+		bookingConfig.AllowedCurrencies,
+		confirm_booking.PaidAmountLimits{Min: bookingConfig.MinPaidAmount, Max: bookingConfig.MaxPaidAmount},
+		bookingEventPublisher,
+		timeSlotRepo,
 	)
 	confirmAdhocBookingUsecase := confirm_adhoc_booking.NewUsecase(
 		bookingRepo,
@@ -173,27 +328,96 @@ func main() {
 		notificationPort,
 		notificationRepo,
 		notificationConfig.TherapistAppBaseURL,
-		transactionRepo,
+		unitOfWork,
 		notifyTherapistUsecase,
+		bookingConfig.AllowedCurrencies,
+		confirm_booking.PaidAmountLimits{Min: bookingConfig.MinPaidAmount, Max: bookingConfig.MaxPaidAmount},
+		bookingEventPublisher,
+	)
+	cancelBookingUsecase := cancel_booking.NewUsecase(bookingRepo, bookingEventPublisher, bookingConfig.CancellationCutoff)
+	searchBookingsUsecase := search_bookings.NewUsecase(bookingRepo, adhocBookingRepo, therapistRepo, clientRepo, bookingConfig.WhatsAppMessageTemplate)
+	getBookingStatsUsecase := get_booking_stats.NewUsecase(bookingRepo)
+	getLeadTimeStatsUsecase := get_lead_time_stats.NewUsecase(bookingRepo, therapistRepo)
+	getBookingCalendarUsecase := get_booking_calendar.NewUsecase(bookingRepo, therapistRepo)
+	cancelFutureBookingsUsecase := cancel_future_bookings.NewUsecase(
+		bookingRepo,
+		adhocBookingRepo,
+		sessionRepo,
+		therapistRepo,
+		clientRepo,
+		transactionRepo,
+		bookingEventPublisher,
+	)
+	getBookingHistoryUsecase := get_booking_history.NewUsecase(bookingRepo)
+	updateBookingDurationUsecase := update_booking_duration.NewUsecase(
+		bookingRepo,
+		adhocBookingRepo,
+		timeSlotRepo,
+		transactionRepo,
+	)
+	reassignBookingUsecase := reassign_booking.NewUsecase(
+		bookingRepo,
+		therapistRepo,
+		notificationPort,
+		notificationRepo,
+		transactionRepo,
+		*checkAvailabilityUsecase,
+		notificationConfig.TherapistAppBaseURL,
+	)
+	reactivateBookingUsecase := reactivate_booking.NewUsecase(
+		bookingRepo,
+		transactionRepo,
+		*checkAvailabilityUsecase,
+		bookingConfig.ReactivationGracePeriod,
+		therapistRepo,
+	)
+	streamBookingEventsUsecase := stream_booking_events.NewUsecase(therapistRepo, bookingEvents)
+	holdBookingUsecase := hold_booking.NewUsecase(
+		bookingRepo,
+		bookingHoldRepo,
+		clientRepo,
+		*checkAvailabilityUsecase,
+		bookingConfig.HoldDuration,
+		unitOfWork,
+		therapistRepo,
+	)
+	confirmBookingHoldUsecase := confirm_booking_hold.NewUsecase(bookingRepo, bookingHoldRepo, transactionRepo, bookingEventPublisher)
+	confirmPreviewUsecase := get_confirmation_preview.NewUsecase(
+		bookingRepo,
+		therapistRepo,
+		timeSlotRepo,
+		get_confirmation_preview.Price{Amount: bookingConfig.SessionPrice, Currency: bookingConfig.SessionPriceCurrency},
 	)
-	cancelBookingUsecase := cancel_booking.NewUsecase(bookingRepo)
-	searchBookingsUsecase := search_bookings.NewUsecase(bookingRepo, adhocBookingRepo, therapistRepo, clientRepo)
+	resendNotificationUsecase := resend_confirmation_notification.NewUsecase(bookingRepo, sessionRepo, notifyTherapistUsecase)
+	releaseExpiredHoldsUsecase := release_expired_holds.NewUsecase(bookingRepo, bookingHoldRepo, transactionRepo)
 
 	// Initialize session usecases
 	getSessionUsecase := get_session.NewUsecase(sessionRepo)
+	getSessionTherapistUsecase := get_session_therapist.NewUsecase(*getSessionUsecase, therapistRepo)
+	getSessionContextUsecase := get_session_context.NewUsecase(*getSessionUsecase, bookingRepo, adhocBookingRepo, clientRepo)
 	updateSessionStateUsecase := update_session_state.NewUsecase(sessionRepo)
-	updateSessionNotesUsecase := update_session_notes.NewUsecase(sessionRepo)
-	updateMeetingURLUsecase := update_meeting_url.NewUsecase(sessionRepo)
+	updateSessionNotesUsecase := update_session_notes.NewUsecase(sessionRepo, sessionConfig.MaxNotesBytes, sessionConfig.NotesOverflowPolicy)
+	updateMeetingURLUsecase := update_meeting_url.NewUsecase(sessionRepo, sessionConfig.MeetingURLAllowedHosts)
 	listSessionsByTherapistUsecase := list_sessions_by_therapist.NewUsecase(sessionRepo)
 	listSessionsByClientUsecase := list_sessions_by_client.NewUsecase(sessionRepo)
 	listSessionsAdminUsecase := list_sessions_admin.NewUsecase(sessionRepo)
+	listSessionsMissingMeetingURLUsecase := list_sessions_missing_meeting_url.NewUsecase(sessionRepo)
 	getMeetingLinkUsecase := get_meeting_link.NewUsecase(sessionRepo)
+	markNoShowSessionsUsecase := mark_no_show_sessions.NewUsecase(sessionRepo, sessionConfig.NoShowGracePeriod)
+
+	checkClinicUsecase := check_clinic.NewUsecase(clinicRepo)
 
 	// Initialize handlers
+	clinicGuard := api.NewClinicGuard(*checkClinicUsecase)
+
 	specializationHandler := specializationHandler.NewSpecializationHandler(
 		*newSpecializationUsecase,
 		*getAllSpecializationsUsecase,
 		*getSpecializationUsecase,
+		*getSpecializationCountsUsecase,
+		*getSpecializationTherapistsUsecase,
+		*deleteSpecializationUsecase,
+		clinicGuard,
 	)
 
 	therapistHandler := therapistHandler.NewTherapistHandler(
@@ -204,12 +428,24 @@ func main() {
 		*updateTherapistSpecializationsUsecase,
 		*updateTherapistDeviceUsecase,
 		*updateTherapistTimezoneOffsetUsecase,
+		*updateNotificationPreferencesUsecase,
+		*listTherapistsByDeviceUsecase,
+		*updateTherapistLanguagesUsecase,
+		*updateMaxDailySessionsUsecase,
+		clinicGuard,
 	)
 
 	clientHandler := clientHandler.NewClientHandler(
 		*createClientUsecase,
 		*getAllClientsUsecase,
 		*getClientUsecase,
+		*getClientByWhatsAppUsecase,
+		*updateClientTimezoneUsecase,
+		*getClientSummaryUsecase,
+		*updateClientUsecase,
+		*getClientByEmailUsecase,
+		*mergeClientsUsecase,
+		clinicGuard,
 	)
 
 	bookingHandler := bookingHandler.NewBookingHandler(
@@ -219,6 +455,20 @@ func main() {
 		*confirmAdhocBookingUsecase,
 		*cancelBookingUsecase,
 		*searchBookingsUsecase,
+		*getBookingStatsUsecase,
+		*reassignBookingUsecase,
+		*getBookingHistoryUsecase,
+		*updateBookingDurationUsecase,
+		*getLeadTimeStatsUsecase,
+		*cancelFutureBookingsUsecase,
+		*getBookingCalendarUsecase,
+		*reactivateBookingUsecase,
+		*streamBookingEventsUsecase,
+		*holdBookingUsecase,
+		*confirmBookingHoldUsecase,
+		*confirmPreviewUsecase,
+		*resendNotificationUsecase,
+		clinicGuard,
 	)
 
 	sessionHandler := api.NewSessionHandler(
@@ -229,14 +479,27 @@ func main() {
 		*listSessionsByTherapistUsecase,
 		*listSessionsByClientUsecase,
 		*listSessionsAdminUsecase,
+		*listSessionsMissingMeetingURLUsecase,
+		*getSessionTherapistUsecase,
+		*sendSessionRemindersUsecase,
+		*getSessionContextUsecase,
+		clinicGuard,
 	)
 
 	meetingLinkProxyHandler := api.NewMeetingLinkProxyHandler(
 		*getMeetingLinkUsecase,
 	)
 
+	serverTimeHandler := api.NewServerTimeHandler()
+
 	scheduleHandler := scheduleHandler.NewScheduleHandler(
 		*getScheduleUsecase,
+		*checkAvailabilityUsecase,
+		*getNextAvailabilityUsecase,
+		*getAvailabilityDaysUsecase,
+		*getTherapistAvailabilityReportUsecase,
+		*getUpcomingScheduleUsecase,
+		clinicGuard,
 	)
 
 	timeslotHandler := timeslotHandler.NewTimeslotHandler(
@@ -246,43 +509,70 @@ func main() {
 		*updateTherapistTimeslotUsecase,
 		*deleteTherapistTimeslotUsecase,
 		*listTherapistTimeslotsUsecase,
+		*setTherapistTimeslotActiveUsecase,
+		*listTimeslotBookingsUsecase,
+		*deleteTherapistTimeslotsForDayUsecase,
+		*exportTherapistTimeslotsUsecase,
+		*importTherapistTimeslotsUsecase,
+		*listRawTherapistTimeslotsUsecase,
+		clinicGuard,
+	)
+
+	recurringBlockHandler := recurringBlockHandler.NewRecurringBlockHandler(
+		*createRecurringBlockUsecase,
+		*listRecurringBlocksUsecase,
+		*updateRecurringBlockUsecase,
+		*deleteRecurringBlockUsecase,
+		clinicGuard,
 	)
 
 	testHandler := test.NewTestHandler(notificationPort, notificationRepo)
 
 	// Setup HTTP routes
 	mux := http.NewServeMux()
+	routes := api.NewRouteRegistry(mux)
 
 	// Register specialization routes
-	specializationHandler.RegisterRoutes(mux)
+	specializationHandler.RegisterRoutes(routes)
 
 	// Register therapist routes
-	therapistHandler.RegisterRoutes(mux)
+	therapistHandler.RegisterRoutes(routes)
 
 	// Register client routes
-	clientHandler.RegisterRoutes(mux)
+	clientHandler.RegisterRoutes(routes)
 
 	// Register booking routes
-	bookingHandler.RegisterRoutes(mux)
+	bookingHandler.RegisterRoutes(routes)
 
 	// Register session routes
-	sessionHandler.RegisterRoutes(mux)
+	sessionHandler.RegisterRoutes(routes)
 
 	// Register meeting link proxy routes
-	meetingLinkProxyHandler.RegisterRoutes(mux)
+	meetingLinkProxyHandler.RegisterRoutes(routes)
 
 	// Register schedule routes
-	scheduleHandler.RegisterRoutes(mux)
+	scheduleHandler.RegisterRoutes(routes)
 
 	// Register timeslot routes
-	timeslotHandler.RegisterRoutes(mux)
+	timeslotHandler.RegisterRoutes(routes)
+
+	// Register recurring block routes
+	recurringBlockHandler.RegisterRoutes(routes)
+
+	// Register server time routes
+	serverTimeHandler.RegisterRoutes(routes)
 
 	if config.IsDevelopment() {
-		testHandler.RegisterRoutes(mux)
+		testHandler.RegisterRoutes(routes)
+		timeslotHandler.RegisterDebugRoutes(routes)
 	}
 
+	// Register the route manifest
+	routes.RegisterRoutes(routes)
+
 	// Add health check endpoint
-	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+	healthRoute := "GET /health"
+	routes.HandleFunc(healthRoute, func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"healthy","service":"therapist-api"}`))
@@ -295,11 +585,18 @@ func main() {
 		middleWareStack = append(middleWareStack, corsMiddleware)
 	}
 
-	handler = loggingMiddleware(mux)
+	// Health checks and meeting links sent to clients come without a clinic header
+	publicRoutes := append(meetingLinkProxyHandler.PublicRoutes(), healthRoute)
+	handler = loggingMiddleware(api.TimeoutMiddleware(serverConfig.RequestTimeout, bookingHandler.LongLivedRoutes()...)(api.BodyLimitMiddleware(serverConfig.MaxRequestBodyBytes)(api.ActorMiddleware(api.ClinicMiddleware(publicRoutes...)(routes)))))
 	for _, middleware := range middleWareStack {
 		handler = middleware(handler)
 	}
 
+	go sweepExpiredHolds(*releaseExpiredHoldsUsecase, bookingConfig.HoldSweepInterval)
+	if sessionConfig.NoShowGracePeriod > 0 {
+		go sweepNoShowSessions(*markNoShowSessionsUsecase, sessionConfig.NoShowSweepInterval)
+	}
+
 	// Start server
 	port := getEnvOrDefault("PORT", "8090")
 	slog.Info("Starting server", "port", port)
@@ -309,6 +606,50 @@ func main() {
 	}
 }
 
+// sweepExpiredHolds releases expired booking holds every interval, for as
+// long as the server runs.
+func sweepExpiredHolds(releaseExpiredHolds release_expired_holds.Usecase, interval time.Duration) {
+	if interval <= 0 {
+		slog.Warn("Booking hold sweeper disabled, expired holds will not be released")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		output, err := releaseExpiredHolds.Execute(release_expired_holds.Input{})
+		if err != nil {
+			slog.Error("Failed to release expired booking holds", "error", err)
+			continue
+		}
+		if output.Released > 0 {
+			slog.Info("Released expired booking holds", "count", output.Released)
+		}
+	}
+}
+
+// sweepNoShowSessions marks overdue planned sessions as no-show every
+// interval, for as long as the server runs.
+func sweepNoShowSessions(markNoShowSessions mark_no_show_sessions.Usecase, interval time.Duration) {
+	if interval <= 0 {
+		slog.Warn("No-show sweeper disabled, planned sessions will not be marked as no-show")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		output, err := markNoShowSessions.Execute(mark_no_show_sessions.Input{})
+		if err != nil {
+			slog.Error("Failed to mark no-show sessions", "error", err)
+			continue
+		}
+		if output.Marked > 0 {
+			slog.Info("Marked sessions as no-show", "count", output.Marked)
+		}
+	}
+}
+
 // loggingMiddleware logs the HTTP method, path, status code, and response time for each request.
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -344,13 +685,48 @@ func (w *statusCapturingResponseWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// flush event streams.
+func (w *statusCapturingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// runSeed generates demo data, see seed.Options for the flags. It refuses to
+// run outside development so a production database is never touched.
+func runSeed(database ports.SQLDatabase, args []string) {
+	if !config.IsDevelopment() {
+		log.Fatal("Refusing to seed: BRAIN_ENV must be development")
+	}
+
+	options := seed.DefaultOptions()
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	flags.IntVar(&options.Specializations, "specializations", options.Specializations, "number of specializations")
+	flags.IntVar(&options.Therapists, "therapists", options.Therapists, "number of therapists")
+	flags.IntVar(&options.Clients, "clients", options.Clients, "number of clients")
+	flags.IntVar(&options.TimeslotsPerTherapist, "timeslots", options.TimeslotsPerTherapist, "timeslots per therapist")
+	flags.IntVar(&options.BookingsPerTimeslot, "bookings", options.BookingsPerTimeslot, "bookings per timeslot")
+	flags.Parse(args)
+
+	result, err := seed.Run(database, options)
+	if err != nil {
+		log.Fatal("Seeding failed: ", err)
+	}
+	slog.Info("Seeded demo data",
+		"specializations", result.Specializations,
+		"therapists", result.Therapists,
+		"clients", result.Clients,
+		"timeslots", result.Timeslots,
+		"bookings", result.Bookings,
+	)
+}
+
 // corsMiddleware adds CORS headers to allow cross-origin requests
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+api.ClinicHeader)
 
 		// Handle preflight requests
 		if r.Method == "OPTIONS" {
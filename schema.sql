@@ -16,6 +16,12 @@ CREATE TABLE IF NOT EXISTS therapists (
     device_id VARCHAR(255), -- nullable, Firebase ID
     device_id_updated_at DATETIME, -- nullable, Firebase ID update timestamp
     timezone_offset INTEGER NOT NULL DEFAULT 0, -- Frontend hint for timezone adjustments (minutes east of UTC)
+    notification_preferences TEXT NOT NULL DEFAULT '{"confirmations":true,"reminders":true}', -- JSON, see therapist.NotificationPreferences
+    bio TEXT NOT NULL DEFAULT '', -- Client-facing description
+    photo_url VARCHAR(2048) NOT NULL DEFAULT '', -- https URL of the profile photo
+    default_language VARCHAR(16) NOT NULL DEFAULT '', -- Session language used when a confirmation omits one, empty for none
+    clinic_id VARCHAR(128) NOT NULL DEFAULT 'default', -- Owning clinic, see domain.ClinicID
+    max_daily_sessions INTEGER NOT NULL DEFAULT 0, -- Cap on held, pending and confirmed bookings per local day, 0 for no cap
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
@@ -23,9 +29,12 @@ CREATE TABLE IF NOT EXISTS therapists (
 -- Therapist specializations table
 CREATE TABLE IF NOT EXISTS specializations (
     id VARCHAR(128) PRIMARY KEY,
-    name VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    clinic_id VARCHAR(128) NOT NULL DEFAULT 'default', -- Owning clinic, see domain.ClinicID
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    -- Each clinic names its own specializations
+    CONSTRAINT unique_clinic_specialization_name UNIQUE (clinic_id, name)
 );
 
 -- Therapist specializations table
@@ -44,13 +53,24 @@ CREATE TABLE IF NOT EXISTS therapist_specializations (
     )
 );
 
+-- Languages each therapist offers sessions in (ISO 639-1 codes)
+CREATE TABLE IF NOT EXISTS therapist_languages (
+    therapist_id VARCHAR(128) NOT NULL,
+    language_code VARCHAR(8) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (therapist_id, language_code),
+    CONSTRAINT fk_therapist_languages_therapist FOREIGN KEY (therapist_id) REFERENCES therapists (id) ON DELETE CASCADE
+);
+
 -- Clients table
 CREATE TABLE IF NOT EXISTS clients (
     id VARCHAR(128) PRIMARY KEY,
     name VARCHAR(255), -- Optional field
-    -- email VARCHAR(255) UNIQUE NOT NULL,
-    whatsapp_number VARCHAR(20) UNIQUE, -- International format support, unique
+    email VARCHAR(255), -- Optional, lowercased and unique; NULL when missing
+    whatsapp_number VARCHAR(20) UNIQUE, -- International format support, unique; NULL when missing
     timezone_offset INTEGER NOT NULL, -- Frontend hint for timezone adjustments (minutes east of UTC)
+    clinic_id VARCHAR(128) NOT NULL DEFAULT 'default', -- Owning clinic, see domain.ClinicID
+    deleted_at DATETIME NULL, -- Set once the client is merged into another; deleted clients are hidden
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
@@ -76,6 +96,8 @@ CREATE TABLE IF NOT EXISTS time_slots (
     duration_minutes INTEGER NOT NULL, -- Duration in minutes (e.g., 60, 120, 480)
     advance_notice INTEGER NOT NULL DEFAULT 0, -- minutes (advance notice requirement)
     after_session_break_time INTEGER NOT NULL DEFAULT 0, -- minutes (break time after session)
+    timezone VARCHAR(64) NOT NULL DEFAULT '', -- optional IANA name; when set, day_of_week/start_time are local to it
+    capacity INTEGER NOT NULL DEFAULT 1 CHECK (capacity >= 1), -- concurrent confirmed bookings allowed, > 1 for group sessions
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     CONSTRAINT fk_time_slots_therapist FOREIGN KEY (therapist_id) REFERENCES therapists (id) ON DELETE NO ACTION,
@@ -89,6 +111,33 @@ CREATE TABLE IF NOT EXISTS time_slots (
     )
 );
 
+-- Recurring blocks (weekly windows subtracted from a therapist's time slots)
+CREATE TABLE IF NOT EXISTS recurring_blocks (
+    id VARCHAR(128) PRIMARY KEY,
+    therapist_id VARCHAR(128) NOT NULL,
+    day_of_week VARCHAR(10) NOT NULL CHECK (
+        day_of_week IN (
+            'Monday',
+            'Tuesday',
+            'Wednesday',
+            'Thursday',
+            'Friday',
+            'Saturday',
+            'Sunday'
+        )
+    ), -- UTC day, or local to timezone when set
+    start_time TIME NOT NULL, -- UTC time, or local to timezone when set
+    duration_minutes INTEGER NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT '', -- optional IANA name
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_recurring_blocks_therapist FOREIGN KEY (therapist_id) REFERENCES therapists (id) ON DELETE CASCADE,
+    CONSTRAINT check_recurring_block_duration CHECK (
+        duration_minutes > 0
+        AND duration_minutes <= 1440
+    )
+);
+
 -- Bookings table
 CREATE TABLE IF NOT EXISTS bookings (
     id VARCHAR(128) PRIMARY KEY,
@@ -102,7 +151,8 @@ CREATE TABLE IF NOT EXISTS bookings (
         state IN (
             'pending',
             'confirmed',
-            'cancelled'
+            'cancelled',
+            'held'
         )
     ),
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
@@ -112,6 +162,26 @@ CREATE TABLE IF NOT EXISTS bookings (
     CONSTRAINT fk_bookings_client FOREIGN KEY (client_id) REFERENCES clients (id) ON DELETE NO ACTION
 );
 
+-- Booking state change audit trail
+CREATE TABLE IF NOT EXISTS booking_audit (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id VARCHAR(128) NOT NULL,
+    from_state VARCHAR(20) NOT NULL DEFAULT '', -- Empty when the booking was created
+    to_state VARCHAR(20) NOT NULL,
+    changed_at DATETIME NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '' -- Prefixed with "unverified:" when taken from an unauthenticated header
+    -- No foreign key to bookings: the history must outlive deleted bookings
+);
+
+-- Holds of bookings in the held state, released once they expire
+CREATE TABLE IF NOT EXISTS booking_holds (
+    token VARCHAR(128) PRIMARY KEY,
+    booking_id VARCHAR(128) NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_booking_holds_booking FOREIGN KEY (booking_id) REFERENCES bookings (id) ON DELETE CASCADE
+);
+
 -- Adhoc bookings table
 CREATE TABLE IF NOT EXISTS adhoc_bookings (
     id VARCHAR(128) PRIMARY KEY,
@@ -142,7 +212,8 @@ CREATE TABLE IF NOT EXISTS sessions (
     start_time DATETIME NOT NULL,
     duration_minutes INTEGER NOT NULL,
     client_timezone_offset INTEGER NOT NULL,
-    paid_amount INTEGER NOT NULL, -- USD cents
+    paid_amount INTEGER NOT NULL, -- Smallest unit of currency, e.g. cents
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD', -- ISO 4217 code
     language VARCHAR(10) NOT NULL CHECK (
         language IN ('arabic', 'english')
     ),
@@ -152,7 +223,8 @@ CREATE TABLE IF NOT EXISTS sessions (
             'done',
             'rescheduled',
             'cancelled',
-            'refunded'
+            'refunded',
+            'no_show'
         )
     ),
     notes TEXT,
@@ -184,7 +256,14 @@ CREATE TABLE IF NOT EXISTS push_notifications (
 -- Therapist queries
 CREATE INDEX idx_therapists_email ON therapists (email);
 
+CREATE INDEX idx_therapists_clinic ON therapists (clinic_id);
+
+CREATE INDEX idx_therapist_languages_code ON therapist_languages (language_code);
+
 -- Client queries
+CREATE UNIQUE INDEX idx_clients_email ON clients (email);
+
+CREATE INDEX idx_clients_clinic ON clients (clinic_id);
 
 -- Time slot queries (most critical for scheduling)
 CREATE INDEX idx_time_slots_therapist ON time_slots (therapist_id);
@@ -195,6 +274,8 @@ CREATE INDEX idx_time_slots_therapist_day ON time_slots (therapist_id, day_of_we
 
 CREATE INDEX idx_time_slots_time_range ON time_slots (start_time, duration_minutes);
 
+CREATE INDEX idx_recurring_blocks_therapist ON recurring_blocks (therapist_id);
+
 -- Booking queries
 CREATE INDEX idx_bookings_therapist ON bookings (therapist_id);
 
@@ -208,6 +289,10 @@ CREATE INDEX idx_bookings_state ON bookings (state);
 
 -- CREATE INDEX idx_bookings_timezone_offset ON bookings (timezone_offset);
 
+CREATE INDEX idx_booking_audit_booking ON booking_audit (booking_id);
+
+CREATE INDEX idx_booking_holds_expires_at ON booking_holds (expires_at);
+
 -- Session queries
 CREATE INDEX idx_sessions_regular_booking ON sessions (regular_booking_id);
 
@@ -221,8 +306,9 @@ CREATE INDEX idx_sessions_start_time ON sessions (start_time);
 
 CREATE INDEX idx_sessions_therapist_start_time ON sessions (therapist_id, start_time);
 
--- Prevent overlapping 1-hour bookings for the same therapist
-CREATE UNIQUE INDEX idx_no_overlapping_bookings ON bookings (therapist_id, start_time)
+-- Prevent a client from holding two confirmed bookings with the same therapist
+-- at once. Group slots take several clients, their capacity is checked on confirm.
+CREATE UNIQUE INDEX idx_no_overlapping_bookings ON bookings (therapist_id, start_time, client_id)
 WHERE
     state = 'confirmed';
 